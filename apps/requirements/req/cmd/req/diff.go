@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/modeldiff"
+)
+
+// Output formats of the diff and impact subcommands.
+const (
+	ReportFormatMD   = "md"   // Markdown, such as for a pull request
+	ReportFormatJSON = "json" // JSON for tools
+)
+
+// runDiffCommand parses the flags of the diff subcommand, compares two versions of a model
+// and prints the changes to stdout, returning the exit code.
+func runDiffCommand(args []string) int {
+	flags := flag.NewFlagSet("diff", flag.ExitOnError)
+	var basePath, headPath, inputFormat, outputFormat string
+	flags.StringVar(&basePath, "base", "", "the path to the base version of the model")
+	flags.StringVar(&headPath, "head", "", "the path to the head version of the model")
+	flags.StringVar(&inputFormat, "input", InputFormatDataYAML, "input format of both versions: data/yaml or ai/json")
+	flags.StringVar(&outputFormat, "output", ReportFormatMD, "output format: md or json")
+	_ = flags.Parse(args)
+
+	if basePath == "" || headPath == "" {
+		modelFactsError("base and head are required for diff")
+		flags.Usage()
+		return 1
+	}
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != ReportFormatMD && outputFormat != ReportFormatJSON {
+		modelFactsError("invalid diff output format '%s'. Valid options: md, json", outputFormat)
+		return 1
+	}
+
+	if err := runDiff(os.Stdout, basePath, headPath, strings.ToLower(inputFormat), outputFormat); err != nil {
+		modelFactsError("%+v", err)
+		return 1
+	}
+	return 0
+}
+
+// runDiff writes the changes between two versions of a model.
+func runDiff(w io.Writer, basePath, headPath, inputFormat, outputFormat string) error {
+	base, err := readModel(basePath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read base model: %w", err)
+	}
+	head, err := readModel(headPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read head model: %w", err)
+	}
+
+	diff := modeldiff.Compare(base, head)
+	if outputFormat == ReportFormatJSON {
+		data, err := json.MarshalIndent(diff, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	_, err = io.WriteString(w, modeldiff.Markdown(diff))
+	return err
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/impact"
+)
+
+// runImpactCommand parses the flags of the impact subcommand, finds what depends on an
+// element of a model and prints it to stdout, returning the exit code.
+func runImpactCommand(args []string) int {
+	flags := flag.NewFlagSet("impact", flag.ExitOnError)
+	var rootSourcePath, model, inputFormat, outputFormat string
+	flags.StringVar(&rootSourcePath, "rootsource", "", "the path to the source models")
+	flags.StringVar(&model, "model", "", "the model to analyze")
+	flags.StringVar(&inputFormat, "input", InputFormatDataYAML, "input format: data/yaml or ai/json")
+	flags.StringVar(&outputFormat, "output", ReportFormatMD, "output format: md or json")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: req impact -rootsource <path> -model <model> [-input format] [-output md|json] <identity key>")
+		flags.PrintDefaults()
+	}
+	_ = flags.Parse(args)
+
+	if rootSourcePath == "" || model == "" || flags.NArg() != 1 {
+		modelFactsError("rootsource, model and one identity key are required for impact")
+		flags.Usage()
+		return 1
+	}
+	outputFormat = strings.ToLower(outputFormat)
+	if outputFormat != ReportFormatMD && outputFormat != ReportFormatJSON {
+		modelFactsError("invalid impact output format '%s'. Valid options: md, json", outputFormat)
+		return 1
+	}
+
+	if err := runImpact(os.Stdout, filepath.Join(rootSourcePath, model), strings.ToLower(inputFormat), outputFormat, flags.Arg(0)); err != nil {
+		modelFactsError("%+v", err)
+		return 1
+	}
+	return 0
+}
+
+// runImpact writes what depends on the element of a model with a key.
+func runImpact(w io.Writer, modelPath, inputFormat, outputFormat, key string) error {
+	elementKey, err := identity.ParseKey(key)
+	if err != nil {
+		return fmt.Errorf("invalid identity key %q: %w", key, err)
+	}
+	parsed, err := readModel(modelPath, inputFormat)
+	if err != nil {
+		return fmt.Errorf("failed to read model: %w", err)
+	}
+
+	found, err := impact.Analyze(parsed, elementKey)
+	if err != nil {
+		return err
+	}
+	if outputFormat == ReportFormatJSON {
+		data, err := json.MarshalIndent(found, "", "  ")
+		if err != nil {
+			return err
+		}
+		_, err = fmt.Fprintln(w, string(data))
+		return err
+	}
+	_, err = io.WriteString(w, impact.Markdown(found, 1))
+	return err
+}
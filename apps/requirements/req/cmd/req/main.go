@@ -9,16 +9,28 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"time"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/database"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/gosource"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/jsonschema"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/metrics"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/openapi"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/plantuml"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/proto"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/reqif"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/testcases"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/tlaps"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/xmi"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/httpserver"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/modelfacts"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_human"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 )
 
 // Supported input formats.
@@ -29,11 +41,26 @@ const (
 
 // Supported output formats.
 const (
-	OutputFormatDataYAML = "data/yaml" // Parser format (YAML files)
-	OutputFormatMD       = "md"        // Markdown documentation
-	OutputFormatAIJSON   = "ai/json"   // AI format (JSON files)
+	OutputFormatDataYAML   = "data/yaml"  // Parser format (YAML files)
+	OutputFormatMD         = "md"         // Markdown documentation
+	OutputFormatHTML       = "html"       // Static HTML site of the markdown documentation, with search
+	OutputFormatAIJSON     = "ai/json"    // AI format (JSON files)
+	OutputFormatTLAPS      = "tlaps"      // TLAPS proof obligation modules (one .tla file per subdomain)
+	OutputFormatGo         = "go"         // Go source skeletons (one package per subdomain)
+	OutputFormatOpenAPI    = "openapi"    // OpenAPI 3.1 documents (one .openapi.json file per subdomain)
+	OutputFormatJSONSchema = "jsonschema" // JSON Schemas of instance data (one .schema.json file per class)
+	OutputFormatProto      = "proto"      // Protocol Buffers definitions (one .proto file per subdomain)
+	OutputFormatTestCases  = "testcases"  // Transition-coverage test cases (a .feature and .testcases.json file per class)
+	OutputFormatMetrics    = "metrics"    // Model metrics and completeness (metrics.json and metrics.md)
+	OutputFormatPlantUML   = "plantuml"   // PlantUML diagram sources (class, use case, state and sequence .puml files per subdomain)
+	OutputFormatXMI        = "xmi"        // XMI 2.5.1 UML document (one .xmi file for the model)
+	OutputFormatReqIF      = "reqif"      // ReqIF 1.2 requirements interchange document (one .reqif file for the model)
+	OutputFormatLaTeX      = "latex"      // LaTeX document for formal review (model.tex with its diagrams)
 )
 
+// outputFormats lists the supported output formats in the order the usage text shows them.
+var outputFormats = []string{OutputFormatDataYAML, OutputFormatMD, OutputFormatHTML, OutputFormatAIJSON, OutputFormatTLAPS, OutputFormatGo, OutputFormatOpenAPI, OutputFormatJSONSchema, OutputFormatProto, OutputFormatTestCases, OutputFormatMetrics, OutputFormatPlantUML, OutputFormatXMI, OutputFormatReqIF, OutputFormatLaTeX}
+
 func main() {
 	// Example calls:
 	// Default: data/yaml to md
@@ -42,17 +69,94 @@ func main() {
 	// Convert ai/json to md
 	//   $GOBIN/req -input ai/json -output md -rootsource example/ai_models -rootoutput example/output/models -model model_a
 	//
+	// A static HTML site of the same documentation, with search, to open without a server:
+	//   $GOBIN/req -output html -rootsource example/models -rootoutput example/output/html -model model_a
+	//
 	// Convert data/yaml to ai/json
 	//   $GOBIN/req -input data/yaml -output ai/json -rootsource example/models -rootoutput example/ai_models -model model_a
 	//
 	// Convert ai/json to data/yaml
 	//   $GOBIN/req -input ai/json -output data/yaml -rootsource example/ai_models -rootoutput example/models -model model_a
 	//
+	// TLAPS proof obligation skeletons, one .tla module per subdomain:
+	//   $GOBIN/req -output tlaps -rootsource example/models -rootoutput example/output/proofs -model model_a
+	//
+	// Go source skeletons, one package per subdomain:
+	//   $GOBIN/req -output go -rootsource example/models -rootoutput example/output/go -model model_a
+	//
+	// OpenAPI contracts, one document per subdomain:
+	//   $GOBIN/req -output openapi -rootsource example/models -rootoutput example/output/openapi -model model_a
+	//
+	// JSON Schemas of instance data, one schema per class:
+	//   $GOBIN/req -output jsonschema -rootsource example/models -rootoutput example/output/jsonschema -model model_a
+	//
+	// Protocol Buffers definitions, one .proto file per subdomain, with field numbers
+	// kept in example/models/model_a.protolock.yaml:
+	//   $GOBIN/req -output proto -rootsource example/models -rootoutput example/output/proto -model model_a
+	//
+	// Transition-coverage test cases, as Gherkin and JSON, one suite per class with a state machine:
+	//   $GOBIN/req -output testcases -rootsource example/models -rootoutput example/output/testcases -model model_a
+	//
+	// Model metrics and completeness per subdomain, with the trend against the metrics.json
+	// of an earlier run (by default the one already in the output directory):
+	//   $GOBIN/req -output metrics -rootsource example/models -rootoutput example/output/metrics -model model_a
+	//   $GOBIN/req -output metrics -metricsbaseline snapshots/metrics.json -rootsource example/models -rootoutput example/output/metrics -model model_a
+	//
+	// PlantUML sources of the class, use case, state and sequence diagrams of each subdomain:
+	//   $GOBIN/req -output plantuml -rootsource example/models -rootoutput example/output/plantuml -model model_a
+	//
+	// An XMI document of the whole model for UML tools, with model keys as xmi:ids:
+	//   $GOBIN/req -output xmi -rootsource example/models -rootoutput example/output/xmi -model model_a
+	//
+	// A ReqIF document for requirements tools, with model keys as each object's ReqIF.ForeignID:
+	//   $GOBIN/req -output reqif -rootsource example/models -rootoutput example/output/reqif -model model_a
+	//
+	// A LaTeX document for formal review, with TLA+ typeset as math, a glossary and an index,
+	// built to PDF with its Graphviz diagrams by latexmk -pdf -shell-escape model.tex:
+	//   $GOBIN/req -output latex -rootsource example/models -rootoutput example/output/latex -model model_a
+	//
+	// Import a ReqIF document from a requirements tool, adding stub classes and use cases with
+	// unfinished notes to the "imported" domain, and write the model out for analysts to place them:
+	//   $GOBIN/req -reqifimport customer.reqif -output data/yaml -rootsource example/models -rootoutput example/imported -model model_a
+	//
+	// The md output and HTTP server include a metrics page, which shows the trend when given a baseline:
+	//   $GOBIN/req -http -metricsbaseline snapshots/metrics.json -rootsource example/models -model model_a
+	//
 	// HTTP server mode (serves in-memory generated content for a single model):
 	//   $GOBIN/req -http -port 8080 -rootsource example/models -model model_a
 	//
 	// Model facts for human review of one subdomain:
 	//   $GOBIN/req -modelfacts -rootsource example/models -model model_a -subdomain domain/subdomain
+	//
+	// Display logic specifications in infix notation regardless of how they are written:
+	//   $GOBIN/req -notation infix -rootsource example/models -rootoutput example/output/models -model model_a
+	//
+	// Draw class diagrams as Graphviz SVG instead of Mermaid, in md output or HTTP server mode:
+	//   $GOBIN/req -classdiagrams graphviz -rootsource example/models -rootoutput example/output/models -model model_a
+	//   $GOBIN/req -http -classdiagrams graphviz -rootsource example/models -model model_a
+	//
+	// Draw state diagrams as Graphviz SVG, with transitions colored by how often a simulation took them:
+	//   $GOBIN/simulate -rootsource example/models -model model_a -output json -trace > example/output/simulation.json
+	//   $GOBIN/req -statediagrams graphviz -statecoverage example/output/simulation.json -rootsource example/models -rootoutput example/output/models -model model_a
+	//
+	// Render md, html and latex output, or HTTP server pages, with template overrides and extra custom/ pages (see docs/templates.md):
+	//   $GOBIN/req -templates example/templates -rootsource example/models -rootoutput example/output/models -model model_a
+	//
+	// The changes between two versions of a model, grouped by domain and subdomain, as markdown for a pull request or as JSON:
+	//   $GOBIN/req diff -base ../main/example/models/model_a -head example/models/model_a > diff.md
+	//   $GOBIN/req diff -output json -base ../main/example/models/model_a -head example/models/model_a
+	//
+	// What depends on an element of a model, before changing it (also a panel on class pages in HTTP server mode):
+	//   $GOBIN/req impact -rootsource example/models -model model_a domain/domain_a/subdomain/subdomain_a/class/order/attribute/total
+
+	if len(os.Args) > 1 {
+		switch os.Args[1] {
+		case "diff":
+			os.Exit(runDiffCommand(os.Args[2:]))
+		case "impact":
+			os.Exit(runImpactCommand(os.Args[2:]))
+		}
+	}
 
 	var rootSourcePath, rootOutputPath, model string
 	var inputFormat, outputFormat string
@@ -60,17 +164,30 @@ func main() {
 	var httpMode, modelFactsMode bool
 	var subdomainPath string
 	var port string
+	var notation string
+	var metricsBaselinePath string
+	var classDiagrams, stateDiagrams string
+	var stateCoveragePath string
+	var reqifImportPath string
+	var templateDir string
 	flag.StringVar(&rootSourcePath, "rootsource", "", "the path to the source models")
 	flag.StringVar(&rootOutputPath, "rootoutput", "", "the path to output files")
 	flag.StringVar(&model, "model", "", "the model to process")
 	flag.StringVar(&inputFormat, "input", InputFormatDataYAML, "input format: data/yaml or ai/json")
-	flag.StringVar(&outputFormat, "output", OutputFormatMD, "output format: data/yaml, md, or ai/json")
+	flag.StringVar(&outputFormat, "output", OutputFormatMD, "output format: "+strings.Join(outputFormats, ", "))
 	flag.BoolVar(&debug, "debug", false, "enable the debug level of logging")
 	flag.BoolVar(&skipDB, "skipdb", false, "skip database validation step")
 	flag.BoolVar(&httpMode, "http", false, "start HTTP server mode")
 	flag.BoolVar(&modelFactsMode, "modelfacts", false, "print human-readable model facts (associations and indexes) for one subdomain")
 	flag.StringVar(&subdomainPath, "subdomain", "", "domain/subdomain path for -modelfacts (e.g. billing/ledger)")
 	flag.StringVar(&port, "port", "8080", "port for HTTP server (only used with -http)")
+	flag.StringVar(&classDiagrams, "classdiagrams", generate.ClassDiagramsMermaid, "class diagram renderer in md output: mermaid or graphviz")
+	flag.StringVar(&stateDiagrams, "statediagrams", generate.StateDiagramsMermaid, "state diagram renderer in md output: mermaid or graphviz")
+	flag.StringVar(&stateCoveragePath, "statecoverage", "", "simulate -output json -trace output to color graphviz state diagram transitions by")
+	flag.StringVar(&metricsBaselinePath, "metricsbaseline", "", "an earlier metrics.json to show the metrics trend against")
+	flag.StringVar(&reqifImportPath, "reqifimport", "", "a ReqIF document to add stubs to the model for, before conversion")
+	flag.StringVar(&templateDir, "templates", "", "a directory of template overrides and custom/ templates for md, html and latex output (see docs/templates.md)")
+	flag.StringVar(&notation, "notation", "", "display notation for logic specifications in md output: tla_plus or infix (default: as written)")
 	flag.Parse()
 
 	// Validate input format
@@ -82,11 +199,53 @@ func main() {
 
 	// Validate output format
 	outputFormat = strings.ToLower(outputFormat)
-	if outputFormat != OutputFormatDataYAML && outputFormat != OutputFormatMD && outputFormat != OutputFormatAIJSON {
-		log.Printf("Error: invalid output format '%s'. Valid options: data/yaml, md, ai/json", outputFormat)
+	if !slices.Contains(outputFormats, outputFormat) {
+		log.Printf("Error: invalid output format '%s'. Valid options: %s", outputFormat, strings.Join(outputFormats, ", "))
+		os.Exit(1)
+	}
+
+	// Validate display notation
+	if err := generate.SetDisplayNotation(strings.ToLower(notation)); err != nil {
+		log.Printf("Error: %s", err)
+		os.Exit(1)
+	}
+
+	// Validate class diagram renderer
+	if err := generate.SetClassDiagrams(strings.ToLower(classDiagrams)); err != nil {
+		log.Printf("Error: %s", err)
 		os.Exit(1)
 	}
 
+	// Validate state diagram renderer and load any coverage to overlay
+	if err := generate.SetStateDiagrams(strings.ToLower(stateDiagrams)); err != nil {
+		log.Printf("Error: %s", err)
+		os.Exit(1)
+	}
+	if stateCoveragePath != "" {
+		simTrace, err := generate.ReadStateCoverage(stateCoveragePath)
+		if err != nil {
+			log.Printf("Error: %+v", err)
+			os.Exit(1)
+		}
+		generate.SetStateCoverage(simTrace)
+	}
+
+	// Load any template overrides
+	if err := generate.SetTemplateDir(templateDir); err != nil {
+		log.Printf("Error: %+v", err)
+		os.Exit(1)
+	}
+
+	// Load the metrics baseline
+	if metricsBaselinePath != "" {
+		baseline, err := metrics.Read(metricsBaselinePath)
+		if err != nil {
+			log.Printf("Error: %+v", err)
+			os.Exit(1)
+		}
+		generate.SetMetricsBaseline(baseline)
+	}
+
 	// Set the appropriate logging level.
 	_ = slog.SetLogLoggerLevel(slog.LevelInfo)
 	if debug {
@@ -150,7 +309,7 @@ func main() {
 	// Process the conversion
 	err := processConversion(
 		conversionFlags{debug: debug, skipDB: skipDB},
-		conversionPaths{rootSourcePath: rootSourcePath, rootOutputPath: rootOutputPath},
+		conversionPaths{rootSourcePath: rootSourcePath, rootOutputPath: rootOutputPath, reqifImportPath: reqifImportPath},
 		model,
 		conversionFormats{inputFormat: inputFormat, outputFormat: outputFormat},
 	)
@@ -176,8 +335,9 @@ type conversionFlags struct {
 }
 
 type conversionPaths struct {
-	rootSourcePath string
-	rootOutputPath string
+	rootSourcePath  string
+	rootOutputPath  string
+	reqifImportPath string // A ReqIF document to add stubs for, if any.
 }
 
 type conversionFormats struct {
@@ -198,7 +358,7 @@ func processConversion(flags conversionFlags, paths conversionPaths, model strin
 	}
 	if len(failures) > 0 {
 		for _, f := range failures {
-			log.Printf("Parse failure: %s: %s", f.Path, f.Err)
+			log.Printf("Parse failure: %s", f.Message())
 		}
 		return fmt.Errorf("%d class file(s) failed to parse — see the generated error pages", len(failures))
 	}
@@ -245,10 +405,24 @@ func runConversion(flags conversionFlags, paths conversionPaths, model string, f
 		parsedModel = &m
 	}
 
+	// Add stubs for the objects of a ReqIF document that the model does not have yet.
+	if paths.reqifImportPath != "" {
+		log.Printf("Importing ReqIF document %s...", paths.reqifImportPath)
+		document, err := os.ReadFile(paths.reqifImportPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read reqif document: %w", err)
+		}
+		keys, err := reqif.Import(parsedModel, document)
+		if err != nil {
+			return nil, fmt.Errorf("failed to import reqif document: %w", err)
+		}
+		log.Printf("Added %d stub(s) to the %s domain", len(keys), reqif.ImportDomain)
+	}
+
 	// Step 2: Optionally validate through database. Skipped when there are
 	// parse failures — the model is known-partial (placeholder classes), so the
 	// database round-trip would reject it.
-	if !flags.skipDB && formats.outputFormat == OutputFormatMD && len(failures) == 0 {
+	if !flags.skipDB && (formats.outputFormat == OutputFormatMD || formats.outputFormat == OutputFormatHTML) && len(failures) == 0 {
 		db, err := database.NewDb()
 		if err != nil {
 			return nil, fmt.Errorf("failed to create database: %w", err)
@@ -272,11 +446,19 @@ func runConversion(flags conversionFlags, paths conversionPaths, model string, f
 	case OutputFormatMD:
 		log.Println("Generating markdown output...")
 		// Use the already-parsed model to generate markdown
-		err := generate.GenerateMdFromModel(outputPath, *parsedModel, classErrorMap(failures))
+		err := generate.GenerateMdFromModel(outputPath, *parsedModel, classErrorMap(failures), sourceIndex(formats.inputFormat, sourcePath))
 		if err != nil {
 			return nil, fmt.Errorf("failed to generate markdown: %w", err)
 		}
 
+	case OutputFormatHTML:
+		log.Println("Generating static HTML site...")
+		err := generate.GenerateHTMLFromModel(outputPath, *parsedModel, classErrorMap(failures), sourceIndex(formats.inputFormat, sourcePath))
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate html site: %w", err)
+		}
+		log.Printf("HTML site written to: %s (open %s)", outputPath, generate.HTMLIndexFilename)
+
 	case OutputFormatAIJSON:
 		log.Println("Converting to ai/json format...")
 		if err := os.MkdirAll(outputPath, 0755); err != nil {
@@ -293,12 +475,103 @@ func runConversion(flags conversionFlags, paths conversionPaths, model string, f
 			return nil, fmt.Errorf("failed to write data/yaml model: %w", err)
 		}
 		log.Printf("Model written to: %s", outputPath)
+
+	case OutputFormatTLAPS:
+		log.Println("Generating TLAPS proof modules...")
+		if err := tlaps.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate tlaps proof modules: %w", err)
+		}
+		log.Printf("Proof modules written to: %s", outputPath)
+
+	case OutputFormatGo:
+		log.Println("Generating Go source packages...")
+		if err := gosource.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate go source packages: %w", err)
+		}
+		log.Printf("Go packages written to: %s", outputPath)
+
+	case OutputFormatOpenAPI:
+		log.Println("Generating OpenAPI documents...")
+		if err := openapi.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate openapi documents: %w", err)
+		}
+		log.Printf("OpenAPI documents written to: %s", outputPath)
+
+	case OutputFormatJSONSchema:
+		log.Println("Generating JSON Schemas...")
+		if err := jsonschema.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate json schemas: %w", err)
+		}
+		log.Printf("JSON Schemas written to: %s", outputPath)
+
+	case OutputFormatProto:
+		log.Println("Generating Protocol Buffers definitions...")
+		lockPath := filepath.Join(paths.rootSourcePath, model+proto.LockFileExtension)
+		if err := proto.Generate(*parsedModel, outputPath, lockPath); err != nil {
+			return nil, fmt.Errorf("failed to generate proto definitions: %w", err)
+		}
+		log.Printf("Proto definitions written to: %s (field numbers locked in %s)", outputPath, lockPath)
+
+	case OutputFormatTestCases:
+		log.Println("Generating transition-coverage test cases...")
+		if err := testcases.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate test cases: %w", err)
+		}
+		log.Printf("Test cases written to: %s", outputPath)
+
+	case OutputFormatMetrics:
+		log.Println("Generating model metrics...")
+		if err := metrics.Generate(*parsedModel, outputPath, metricsBaseline(outputPath)); err != nil {
+			return nil, fmt.Errorf("failed to generate metrics: %w", err)
+		}
+		log.Printf("Metrics written to: %s", outputPath)
+
+	case OutputFormatPlantUML:
+		log.Println("Generating PlantUML diagrams...")
+		if err := plantuml.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate plantuml diagrams: %w", err)
+		}
+		log.Printf("PlantUML diagrams written to: %s", outputPath)
+
+	case OutputFormatXMI:
+		log.Println("Generating XMI document...")
+		if err := xmi.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate xmi document: %w", err)
+		}
+		log.Printf("XMI document written to: %s", outputPath)
+
+	case OutputFormatReqIF:
+		log.Println("Generating ReqIF document...")
+		if err := reqif.Generate(*parsedModel, outputPath); err != nil {
+			return nil, fmt.Errorf("failed to generate reqif document: %w", err)
+		}
+		log.Printf("ReqIF document written to: %s", outputPath)
+
+	case OutputFormatLaTeX:
+		log.Println("Generating LaTeX document...")
+		if err := generate.GenerateLaTeXFromModel(outputPath, *parsedModel); err != nil {
+			return nil, fmt.Errorf("failed to generate latex document: %w", err)
+		}
+		log.Printf("LaTeX document written to: %s", filepath.Join(outputPath, generate.LaTeXFilename))
 	}
 
 	log.Println("Done!")
 	return failures, nil
 }
 
+// metricsBaseline returns the snapshot to show the metrics trend against: the one given
+// with -metricsbaseline, otherwise the metrics.json of the previous run into outputPath.
+func metricsBaseline(outputPath string) *metrics.Metrics {
+	if baseline := generate.MetricsBaseline(); baseline != nil {
+		return baseline
+	}
+	baseline, err := metrics.Read(filepath.Join(outputPath, metrics.JSONFilename))
+	if err != nil {
+		return nil
+	}
+	return baseline
+}
+
 // classErrorMap converts parser failures into a class-key -> error-message map
 // for the generator. Returns nil when there are no failures.
 func classErrorMap(failures []parser_human.ParseFailure) map[string]string {
@@ -307,11 +580,29 @@ func classErrorMap(failures []parser_human.ParseFailure) map[string]string {
 	}
 	m := make(map[string]string, len(failures))
 	for _, f := range failures {
-		m[f.ClassKey.String()] = f.Err
+		m[f.ClassKey.String()] = f.Message()
 	}
 	return m
 }
 
+// sourceIndex indexes the model's source files so generated errors carry their
+// positions. Returns nil, leaving errors without positions, if that fails.
+func sourceIndex(inputFormat, sourcePath string) *sourcepos.Index {
+	var sources *sourcepos.Index
+	var err error
+	switch inputFormat {
+	case InputFormatDataYAML:
+		sources, err = parser_human.SourceIndex(sourcePath)
+	case InputFormatAIJSON:
+		sources, err = parser_ai.SourceIndex(sourcePath)
+	}
+	if err != nil {
+		log.Printf("Could not index source files: %v", err)
+		return nil
+	}
+	return sources
+}
+
 // modelFactsError writes a message to stderr so failures remain visible even when
 // parse logging is suppressed.
 func modelFactsError(format string, args ...any) {
@@ -325,6 +616,32 @@ func withDiscardedLog(fn func()) {
 	log.SetOutput(os.Stderr)
 }
 
+// readModel quietly reads a whole model in an input format. Class files that fail to
+// parse are an error, since the model would be compared without them.
+func readModel(modelPath, inputFormat string) (model core.Model, err error) {
+	switch inputFormat {
+	case InputFormatDataYAML:
+		var failures []parser_human.ParseFailure
+		withDiscardedLog(func() {
+			model, failures, err = parser_human.Parse(modelPath)
+		})
+		if err != nil {
+			return core.Model{}, err
+		}
+		if len(failures) > 0 {
+			return core.Model{}, fmt.Errorf("%d class file(s) failed to parse", len(failures))
+		}
+		return model, nil
+	case InputFormatAIJSON:
+		withDiscardedLog(func() {
+			model, err = parser_ai.ReadModel(modelPath)
+		})
+		return model, err
+	default:
+		return core.Model{}, fmt.Errorf("invalid input format '%s'. Valid options: data/yaml, ai/json", inputFormat)
+	}
+}
+
 // runModelFacts parses a model and prints model fact strings for one subdomain.
 func runModelFacts(rootSourcePath, model, subdomainPath string) error {
 	sourcePath := filepath.Join(rootSourcePath, model)
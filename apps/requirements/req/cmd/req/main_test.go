@@ -1,11 +1,15 @@
 package main
 
 import (
+	"bytes"
 	"errors"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
 )
 
 func TestWriteErrorMarkdown(t *testing.T) {
@@ -62,3 +66,68 @@ func TestProcessConversionFailureNonMarkdownNoErrorFile(t *testing.T) {
 		t.Error("did not expect a model.md error file for ai/json output")
 	}
 }
+
+// Without -metricsbaseline the metrics trend is against the previous run's metrics.json.
+func TestMetricsBaselineDefaultsToPreviousRun(t *testing.T) {
+	outputPath := t.TempDir()
+	if baseline := metricsBaseline(outputPath); baseline != nil {
+		t.Fatalf("expected no baseline before a first run, got %+v", baseline)
+	}
+
+	if err := os.WriteFile(filepath.Join(outputPath, "metrics.json"), []byte(`{"model": "Sample", "classes": 3}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	baseline := metricsBaseline(outputPath)
+	if baseline == nil || baseline.Model != "Sample" || baseline.Classes != 3 {
+		t.Errorf("expected the previous run's metrics as the baseline, got %+v", baseline)
+	}
+}
+
+// The diff of a model against itself read back from disk has no changes.
+func TestRunDiffUnchanged(t *testing.T) {
+	modelPath := filepath.Join(t.TempDir(), "model_a")
+	if err := parser_ai.WriteModel(test_helper.GetTestModel(), modelPath); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	if err := runDiff(&out, modelPath, modelPath, InputFormatAIJSON, ReportFormatMD); err != nil {
+		t.Fatalf("runDiff failed: %v", err)
+	}
+	if !strings.HasSuffix(out.String(), "\nNo changes.\n") {
+		t.Errorf("expected no changes, got: %s", out.String())
+	}
+
+	out.Reset()
+	if err := runDiff(&out, modelPath, modelPath, InputFormatAIJSON, ReportFormatJSON); err != nil {
+		t.Fatalf("runDiff failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "{\n  \"base\": ") {
+		t.Errorf("expected a JSON diff, got: %s", out.String())
+	}
+
+	if err := runDiff(&out, filepath.Join(t.TempDir(), "missing"), modelPath, InputFormatAIJSON, ReportFormatMD); err == nil {
+		t.Error("expected runDiff to fail for a missing base model")
+	}
+}
+
+// The impact of an element lists what depends on it; an unknown key is an error.
+func TestRunImpact(t *testing.T) {
+	rootSource := t.TempDir()
+	if err := parser_ai.WriteModel(test_helper.GetTestModel(), filepath.Join(rootSource, "model_a")); err != nil {
+		t.Fatal(err)
+	}
+	modelPath := filepath.Join(rootSource, "model_a")
+
+	var out bytes.Buffer
+	if err := runImpact(&out, modelPath, InputFormatAIJSON, ReportFormatMD, "domain/domain_a/subdomain/subdomain_a/class/order/attribute/total"); err != nil {
+		t.Fatalf("runImpact failed: %v", err)
+	}
+	if !strings.HasPrefix(out.String(), "# Impact of attribute **Total** of **Order**\n") || !strings.Contains(out.String(), "\n## Indexes\n") {
+		t.Errorf("expected the impact of Total, got: %s", out.String())
+	}
+
+	if err := runImpact(&out, modelPath, InputFormatAIJSON, ReportFormatMD, "domain/domain_a/subdomain/subdomain_a/class/missing"); err == nil {
+		t.Error("expected runImpact to fail for an element not in the model")
+	}
+}
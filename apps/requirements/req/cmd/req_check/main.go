@@ -13,9 +13,11 @@ import (
 	"strings"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/coreerr"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai"
 	parserErrors "github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai/errors"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai/json_schemas"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/executability"
 )
 
 const helpText = `req_check - validate an AI-generated requirements model
@@ -165,9 +167,43 @@ func validateModel(modelPath string) []error {
 		allErrors = append(allErrors, flattenErrors(err)...)
 	}
 
+	// Report every specification that does not parse or lower, at its place in the files.
+	sources, err := parser_ai.SourceIndex(modelPath)
+	if err != nil {
+		log.Printf("could not index source files: %v", err)
+	}
+	for _, issue := range convert.CollectUnparsedExpressionIssues(&m, sources) {
+		allErrors = append(allErrors, &expressionError{issue: issue})
+	}
+
+	// Report every construct the simulator would fail to evaluate.
+	for _, finding := range executability.Analyze(&m).NonExecutable() {
+		allErrors = append(allErrors, &executabilityError{finding: finding})
+	}
+
 	return allErrors
 }
 
+// expressionError is a specification in the model that does not parse or lower.
+type expressionError struct {
+	issue convert.ExpressionParseIssue
+}
+
+func (e *expressionError) Error() string {
+	return e.issue.Position.Prefix() + e.issue.Location + ": " + e.issue.Message
+}
+
+// executabilityError is a construct in a specification the simulator cannot execute.
+// It is identified by the key of its logic rather than a file position, since lowered
+// specifications hold their normalized text rather than the text of the file.
+type executabilityError struct {
+	finding executability.Finding
+}
+
+func (e *executabilityError) Error() string {
+	return e.finding.String()
+}
+
 // flattenErrors unwraps joined errors into individual errors.
 func flattenErrors(err error) []error {
 	if err == nil {
@@ -199,6 +235,8 @@ func outputJSONTo(w io.Writer, errs []error) {
 		Code    string                          `json:"code"`
 		Message string                          `json:"message"`
 		File    string                          `json:"file,omitempty"`
+		Line    int                             `json:"line,omitempty"`
+		Column  int                             `json:"column,omitempty"`
 		Field   string                          `json:"field,omitempty"`
 		Hint    string                          `json:"hint,omitempty"`
 		Context *parser_ai.CoreValidationDetail `json:"context,omitempty"`
@@ -208,6 +246,8 @@ func outputJSONTo(w io.Writer, errs []error) {
 	for _, err := range errs {
 		var pe *parser_ai.ParseError
 		var ve *coreerr.ValidationError
+		var ee *expressionError
+		var xe *executabilityError
 		switch {
 		case errors.As(err, &pe):
 			code := fmt.Sprintf("E%d", pe.Code)
@@ -217,6 +257,8 @@ func outputJSONTo(w io.Writer, errs []error) {
 				Code:    code,
 				Message: pe.Message,
 				File:    pe.File,
+				Line:    pe.Line,
+				Column:  pe.Column,
 				Field:   pe.Field,
 				Hint:    hint,
 				Context: pe.Context,
@@ -236,6 +278,19 @@ func outputJSONTo(w io.Writer, errs []error) {
 					Want:    ve.Want(),
 				},
 			})
+		case errors.As(err, &ee):
+			items = append(items, jsonError{
+				Type:    "expression",
+				Message: ee.issue.Location + ": " + ee.issue.Message,
+				File:    ee.issue.Position.File,
+				Line:    ee.issue.Position.Line,
+				Column:  ee.issue.Position.Column,
+			})
+		case errors.As(err, &xe):
+			items = append(items, jsonError{
+				Type:    "executability",
+				Message: xe.finding.String(),
+			})
 		default:
 			items = append(items, jsonError{
 				Type:    "error",
@@ -8,7 +8,12 @@ import (
 	"testing"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/coreerr"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/executability"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 	"github.com/stretchr/testify/suite"
 )
 
@@ -89,6 +94,63 @@ func (s *CLISuite) TestOutputJSON_ParseError() {
 	s.Equal("add a name field | run: req_check --explain E1001", item["hint"])
 }
 
+func (s *CLISuite) TestOutputJSON_ParseErrorPosition() {
+	pe := parser_ai.NewParseError(parser_ai.ErrModelNameRequired, "model name is required", "model.json").WithField("name")
+	pe.Line, pe.Column = 3, 11
+
+	var buf bytes.Buffer
+	outputJSONTo(&buf, []error{pe})
+
+	var items []map[string]any
+	s.Require().NoError(json.Unmarshal(buf.Bytes(), &items))
+	s.Require().Len(items, 1)
+	s.InDelta(3, items[0]["line"], 0)
+	s.InDelta(11, items[0]["column"], 0)
+}
+
+func (s *CLISuite) TestOutputJSON_ExpressionError() {
+	ee := &expressionError{issue: convert.ExpressionParseIssue{
+		Location: "class invariant 0",
+		Message:  "TLA+ lowering error in \"self.x > 0\": unresolved identifier: \"x\"",
+		Position: sourcepos.Position{File: "classes/order/class.json", Line: 4, Column: 27},
+	}}
+	s.Equal(`classes/order/class.json:4:27: class invariant 0: TLA+ lowering error in "self.x > 0": unresolved identifier: "x"`, ee.Error())
+
+	var buf bytes.Buffer
+	outputJSONTo(&buf, []error{ee})
+
+	var items []map[string]any
+	s.Require().NoError(json.Unmarshal(buf.Bytes(), &items))
+	s.Require().Len(items, 1)
+
+	item := items[0]
+	s.Equal("expression", item["type"])
+	s.Equal(`class invariant 0: TLA+ lowering error in "self.x > 0": unresolved identifier: "x"`, item["message"])
+	s.Equal("classes/order/class.json", item["file"])
+	s.InDelta(4, item["line"], 0)
+	s.InDelta(27, item["column"], 0)
+}
+
+func (s *CLISuite) TestOutputJSON_ExecutabilityError() {
+	xe := &executabilityError{finding: executability.Finding{
+		Classification: executability.NonExecutable,
+		LogicKey:       helper.Must(identity.ParseKey("invariant/0")),
+		Construct:      "quantifier",
+		Expression:     "∀ n ∈ Nat : n ≥ 0",
+		Reason:         "ranges over Nat, which is infinite",
+	}}
+	s.Equal("invariant/0: quantifier ∀ n ∈ Nat : n ≥ 0 ranges over Nat, which is infinite", xe.Error())
+
+	var buf bytes.Buffer
+	outputJSONTo(&buf, []error{xe})
+
+	var items []map[string]any
+	s.Require().NoError(json.Unmarshal(buf.Bytes(), &items))
+	s.Require().Len(items, 1)
+	s.Equal("executability", items[0]["type"])
+	s.Equal(xe.Error(), items[0]["message"])
+}
+
 func (s *CLISuite) TestOutputJSON_ValidationError() {
 	ctx := coreerr.NewContext("test", "")
 	ve := coreerr.NewWithValues(ctx, "TEST_CODE", "test message", "field1", "bad_value", "good_value")
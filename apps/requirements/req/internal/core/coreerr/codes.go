@@ -35,6 +35,11 @@ const (
 	ModelAgenSubclassCount     Code = "MODEL_AGEN_SUBCLASS_COUNT"     // Actor generalization doesn't have enough subclasses.
 	ModelCassocOrphanParent    Code = "MODEL_CASSOC_ORPHAN_PARENT"    // Model-level class association has a parent key.
 
+	// Global function recursion.
+	ModelGfuncRecursionUndeclared     Code = "MODEL_GFUNC_RECURSION_UNDECLARED"       // Global function calls itself without being declared recursive.
+	ModelGfuncRecursionMutual         Code = "MODEL_GFUNC_RECURSION_MUTUAL"           // Global functions call each other in a cycle.
+	ModelGfuncRecursionNotWellFounded Code = "MODEL_GFUNC_RECURSION_NOT_WELL_FOUNDED" // Recursive global function call does not decrease any argument.
+
 	// ---------------------------------------------------------------
 	// ExpressionSpec errors.
 
@@ -513,10 +518,14 @@ const (
 	ExprArgInvalid            Code = "EXPR_ARG_INVALID"             // Call argument failed validation.
 	ExprFunctionkeyInvalid    Code = "EXPR_FUNCTIONKEY_INVALID"     // GlobalCall FunctionKey failed validation.
 	ExprModuleRequired        Code = "EXPR_MODULE_REQUIRED"         // BuiltinCall Module is empty.
-	ExprFunctionRequired      Code = "EXPR_FUNCTION_REQUIRED"       // BuiltinCall Function is empty.
+	ExprFunctionRequired      Code = "EXPR_FUNCTION_REQUIRED"       // BuiltinCall Function or FunctionDef/FunctionApply Name is empty.
 	ExprSetkeyInvalid         Code = "EXPR_SETKEY_INVALID"          // NamedSetRef SetKey failed validation.
 	ExprClasskeyInvalid       Code = "EXPR_CLASSKEY_INVALID"        // ClassRef ClassKey failed validation.
 	ExprClassNameRequired     Code = "EXPR_CLASS_NAME_REQUIRED"     // ClassRef Name is empty.
+	ExprBuiltinArgType        Code = "EXPR_BUILTIN_ARG_TYPE"        // BuiltinCall arguments do not match the builtin's type rule.
+
+	// Recursion errors.
+	ExprRecursionNotWellFounded Code = "EXPR_RECURSION_NOT_WELL_FOUNDED" // FunctionDef recursive application does not decrease its argument.
 
 	// ---------------------------------------------------------------
 	// DataType errors — data type validation.
@@ -67,6 +67,11 @@ func allCodeConstants() map[string]Code {
 		"ModelAgenSubclassCount":     ModelAgenSubclassCount,
 		"ModelCassocOrphanParent":    ModelCassocOrphanParent,
 
+		// Global function recursion errors.
+		"ModelGfuncRecursionUndeclared":     ModelGfuncRecursionUndeclared,
+		"ModelGfuncRecursionMutual":         ModelGfuncRecursionMutual,
+		"ModelGfuncRecursionNotWellFounded": ModelGfuncRecursionNotWellFounded,
+
 		// ExpressionSpec errors.
 		"ExprspecNotationRequired":  ExprspecNotationRequired,
 		"ExprspecNotationInvalid":   ExprspecNotationInvalid,
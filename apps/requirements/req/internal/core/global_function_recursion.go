@@ -0,0 +1,98 @@
+package core
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/coreerr"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// validateGlobalFunctionRecursion checks that recursion between global functions is well-founded.
+// A function that calls itself must be declared recursive, and every self-call must decrease
+// one of its parameters (see logic_expression.DecreasesOn). Parameters have no domain, so a
+// subtraction only decreases one under a guard that bounds it below. Cycles through several functions
+// are rejected since no argument can be checked to decrease across them.
+// Functions whose specification has not been parsed are skipped.
+func (m *Model) validateGlobalFunctionRecursion(ctx *coreerr.ValidationContext) error {
+	calls := make(map[identity.Key][]*me.GlobalCall, len(m.GlobalFunctions))
+	for gfKey, gf := range m.GlobalFunctions {
+		calls[gfKey] = me.GlobalCalls(gf.Logic.Spec.Expression)
+	}
+
+	gfKeys := make([]identity.Key, 0, len(m.GlobalFunctions))
+	for gfKey := range m.GlobalFunctions {
+		gfKeys = append(gfKeys, gfKey)
+	}
+	slices.SortFunc(gfKeys, func(a, b identity.Key) int { return strings.Compare(a.String(), b.String()) })
+
+	for _, gfKey := range gfKeys {
+		gf := m.GlobalFunctions[gfKey]
+		childCtx := ctx.Child("globalFunction", gfKey.String())
+		for _, call := range calls[gfKey] {
+			if call.FunctionKey != gfKey {
+				continue
+			}
+			if !gf.Recursive {
+				return coreerr.NewWithValues(childCtx, coreerr.ModelGfuncRecursionUndeclared,
+					fmt.Sprintf("global function '%s' calls itself but is not declared recursive", gf.Name),
+					"Recursive", "false", "true")
+			}
+			if !decreasesAnyParameter(gf.Logic.Spec.Expression, call, gf.Parameters) {
+				return coreerr.New(childCtx, coreerr.ModelGfuncRecursionNotWellFounded,
+					fmt.Sprintf("recursive call of '%s' must decrease a parameter (p \\ S, p - k under a guard p > c, or _Seq!Tail(p))", gf.Name),
+					"Logic.Spec")
+			}
+		}
+		if path := callCycle(gfKey, calls); path != nil {
+			names := make([]string, 0, len(path))
+			for _, key := range path {
+				names = append(names, m.GlobalFunctions[key].Name)
+			}
+			return coreerr.New(childCtx, coreerr.ModelGfuncRecursionMutual,
+				fmt.Sprintf("global function '%s' is mutually recursive through %s; only direct recursion is supported", gf.Name, strings.Join(names, " -> ")),
+				"Logic.Spec")
+		}
+	}
+	return nil
+}
+
+// decreasesAnyParameter reports whether some argument of the call, made within body, decreases
+// the matching parameter.
+func decreasesAnyParameter(body me.Expression, call *me.GlobalCall, parameters []string) bool {
+	for i, arg := range call.Args {
+		if i < len(parameters) && me.DecreasesOn(arg, parameters[i], me.BoundedBelow(body, call, parameters[i])) {
+			return true
+		}
+	}
+	return false
+}
+
+// callCycle returns the path of functions through which start calls itself again via at least
+// one other function, or nil if there is no such cycle. Direct self-calls are not cycles here.
+func callCycle(start identity.Key, calls map[identity.Key][]*me.GlobalCall) []identity.Key {
+	visited := map[identity.Key]bool{start: true}
+	var search func(key identity.Key, path []identity.Key) []identity.Key
+	search = func(key identity.Key, path []identity.Key) []identity.Key {
+		for _, call := range calls[key] {
+			next := call.FunctionKey
+			if next == key {
+				continue
+			}
+			if next == start {
+				return append(path, start)
+			}
+			if visited[next] {
+				continue
+			}
+			visited[next] = true
+			if found := search(next, append(slices.Clone(path), next)); found != nil {
+				return found
+			}
+		}
+		return nil
+	}
+	return search(start, []identity.Key{start})
+}
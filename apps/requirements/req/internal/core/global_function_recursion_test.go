@@ -0,0 +1,117 @@
+package core
+
+import (
+	"math/big"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// TestValidateGlobalFunctionRecursion tests the recursion rules between global functions.
+func (suite *ModelSuite) TestValidateGlobalFunctionRecursion() {
+	countKey := helper.Must(identity.NewGlobalFunctionKey("_count"))
+	evenKey := helper.Must(identity.NewGlobalFunctionKey("_even"))
+	oddKey := helper.Must(identity.NewGlobalFunctionKey("_odd"))
+
+	s := &me.LocalVar{Name: "s"}
+	n := &me.LocalVar{Name: "n"}
+	one := &me.IntLiteral{Value: big.NewInt(1)}
+	// IF s = {} THEN 0 ELSE 1 + <call>
+	countBody := func(call me.Expression) me.Expression {
+		return &me.IfThenElse{
+			Condition: &me.Compare{Op: me.CompareEq, Left: s, Right: &me.SetLiteral{}},
+			Then:      &me.IntLiteral{Value: big.NewInt(0)},
+			Else:      &me.BinaryArith{Op: me.ArithAdd, Left: one, Right: call},
+		}
+	}
+	globalFunction := func(key identity.Key, name, parameter string, recursive bool, body me.Expression) model_logic.GlobalFunction {
+		return model_logic.GlobalFunction{
+			Key:        key,
+			Name:       name,
+			Parameters: []string{parameter},
+			Recursive:  recursive,
+			Logic: model_logic.Logic{
+				Key:         key,
+				Type:        model_logic.LogicTypeValue,
+				Description: "Recursive function.",
+				Spec:        logic_spec.ExpressionSpec{Notation: model_logic.NotationTLAPlus, Specification: "recursive", Expression: body},
+			},
+		}
+	}
+	shrinking := &me.GlobalCall{FunctionKey: countKey, Args: []me.Expression{&me.SetOp{Op: me.SetDifference, Left: s, Right: &me.SetLiteral{Elements: []me.Expression{one}}}}}
+	unchanged := &me.GlobalCall{FunctionKey: countKey, Args: []me.Expression{s}}
+	callOdd := &me.GlobalCall{FunctionKey: oddKey, Args: []me.Expression{&me.BinaryArith{Op: me.ArithSub, Left: n, Right: one}}}
+	callEven := &me.GlobalCall{FunctionKey: evenKey, Args: []me.Expression{&me.BinaryArith{Op: me.ArithSub, Left: n, Right: one}}}
+	countDown := &me.GlobalCall{FunctionKey: countKey, Args: []me.Expression{&me.BinaryArith{Op: me.ArithSub, Left: n, Right: one}}}
+	// IF n > 0 THEN <call> ELSE 0
+	guardedBody := func(call me.Expression) me.Expression {
+		return &me.IfThenElse{
+			Condition: &me.Compare{Op: me.CompareGt, Left: n, Right: &me.IntLiteral{Value: big.NewInt(0)}},
+			Then:      call,
+			Else:      &me.IntLiteral{Value: big.NewInt(0)},
+		}
+	}
+
+	tests := []struct {
+		testName  string
+		functions []model_logic.GlobalFunction
+		errstr    string
+	}{
+		{
+			testName:  "valid recursive function",
+			functions: []model_logic.GlobalFunction{globalFunction(countKey, "_Count", "s", true, countBody(shrinking))},
+		},
+		{
+			testName:  "valid recursive declaration without self call",
+			functions: []model_logic.GlobalFunction{globalFunction(countKey, "_Count", "s", true, countBody(one))},
+		},
+		{
+			testName:  "error self call not declared recursive",
+			functions: []model_logic.GlobalFunction{globalFunction(countKey, "_Count", "s", false, countBody(shrinking))},
+			errstr:    "global function '_Count' calls itself but is not declared recursive",
+		},
+		{
+			testName:  "error self call does not decrease",
+			functions: []model_logic.GlobalFunction{globalFunction(countKey, "_Count", "s", true, countBody(unchanged))},
+			errstr:    "recursive call of '_Count' must decrease a parameter",
+		},
+		{
+			testName:  "valid subtraction under a guard",
+			functions: []model_logic.GlobalFunction{globalFunction(countKey, "_Count", "n", true, guardedBody(countDown))},
+		},
+		{
+			testName:  "error subtraction without a guard",
+			functions: []model_logic.GlobalFunction{globalFunction(countKey, "_Count", "n", true, countDown)},
+			errstr:    "recursive call of '_Count' must decrease a parameter",
+		},
+		{
+			testName: "error mutual recursion",
+			functions: []model_logic.GlobalFunction{
+				globalFunction(evenKey, "_Even", "n", true, callOdd),
+				globalFunction(oddKey, "_Odd", "n", true, callEven),
+			},
+			errstr: "global function '_Even' is mutually recursive through _Even -> _Odd -> _Even",
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			model := Model{
+				Key:             "model1",
+				Name:            "Name",
+				GlobalFunctions: map[identity.Key]model_logic.GlobalFunction{},
+			}
+			for _, gf := range tt.functions {
+				model.GlobalFunctions[gf.Key] = gf
+			}
+			err := model.Validate()
+			if tt.errstr == "" {
+				suite.Require().NoError(err)
+			} else {
+				suite.Require().ErrorContains(err, tt.errstr)
+			}
+		})
+	}
+}
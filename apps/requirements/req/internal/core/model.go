@@ -122,7 +122,7 @@ func (m *Model) validateGlobalFunctions(ctx *coreerr.ValidationContext) error {
 				"GlobalFunctions", gfKey.String(), gf.Key.String())
 		}
 	}
-	return nil
+	return m.validateGlobalFunctionRecursion(ctx)
 }
 
 func (m *Model) validateNamedSets(ctx *coreerr.ValidationContext) error {
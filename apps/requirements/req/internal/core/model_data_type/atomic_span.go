@@ -132,3 +132,15 @@ func (a *AtomicSpan) validatePrecision(ctx *coreerr.ValidationContext) error {
 	}
 	return nil
 }
+
+// Fractional reports whether a span admits values that are not whole numbers: a bound is a
+// fraction or the precision is not a whole number. Whole bounds are parsed with a
+// denominator of 1. Nothing is known of the values of a nil span, so it is fractional.
+func (a *AtomicSpan) Fractional() bool {
+	if a == nil {
+		return true
+	}
+	fraction := func(denominator *int) bool { return denominator != nil && *denominator != 1 }
+	return fraction(a.LowerDenominator) || fraction(a.HigherDenominator) ||
+		(a.Precision > 0 && a.Precision != math.Trunc(a.Precision))
+}
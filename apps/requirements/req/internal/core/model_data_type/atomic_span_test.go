@@ -286,3 +286,24 @@ func TestAtomicSpanValidate(t *testing.T) {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestAtomicSpanFractional(t *testing.T) {
+	tests := []struct {
+		name       string
+		atomicSpan *AtomicSpan
+		fractional bool
+	}{
+		{name: "nil span", atomicSpan: nil, fractional: true},
+		{name: "whole bounds and precision", atomicSpan: &AtomicSpan{LowerDenominator: intPtr(1), HigherDenominator: intPtr(1), Precision: 1}, fractional: false},
+		{name: "unbounded whole precision", atomicSpan: &AtomicSpan{Precision: 1}, fractional: false},
+		{name: "fraction lower bound", atomicSpan: &AtomicSpan{LowerDenominator: intPtr(2), Precision: 1}, fractional: true},
+		{name: "fraction higher bound", atomicSpan: &AtomicSpan{HigherDenominator: intPtr(4), Precision: 1}, fractional: true},
+		{name: "decimal precision", atomicSpan: &AtomicSpan{Precision: 0.01}, fractional: true},
+		{name: "precision over one not whole", atomicSpan: &AtomicSpan{Precision: 2.5}, fractional: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.fractional, tt.atomicSpan.Fractional())
+		})
+	}
+}
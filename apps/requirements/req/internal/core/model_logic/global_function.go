@@ -18,19 +18,24 @@ import (
 //
 // All global definitions must have a leading underscore to distinguish them
 // from class-scoped actions.
+//
+// A definition that calls itself must be declared Recursive, the TLA+
+// RECURSIVE declaration, and each self-call must decrease an argument.
 type GlobalFunction struct {
 	Key        identity.Key
 	Name       string   // The definition name (e.g., _Max, _SetOfValues). Must start with underscore.
 	Parameters []string // The parameter names (e.g., ["x", "y"] for _Max(x, y)).
+	Recursive  bool     // Whether the definition may call itself (TLA+ RECURSIVE).
 	Logic      Logic    // The logic specification for this global function.
 }
 
 // NewGlobalFunction creates a new GlobalFunction.
-func NewGlobalFunction(key identity.Key, name string, parameters []string, logic Logic) GlobalFunction {
+func NewGlobalFunction(key identity.Key, name string, parameters []string, recursive bool, logic Logic) GlobalFunction {
 	return GlobalFunction{
 		Key:        key,
 		Name:       name,
 		Parameters: parameters,
+		Recursive:  recursive,
 		Logic:      logic,
 	}
 }
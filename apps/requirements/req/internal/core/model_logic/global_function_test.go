@@ -214,17 +214,18 @@ func (s *GlobalFunctionTestSuite) TestNew() {
 
 	// Test all parameters are mapped correctly.
 
-	gf := NewGlobalFunction(gfKey1, "_Max", []string{"x", "y"}, spec)
+	gf := NewGlobalFunction(gfKey1, "_Max", []string{"x", "y"}, true, spec)
 	s.Equal(GlobalFunction{
 		Key:        gfKey1,
 		Name:       "_Max",
 		Parameters: []string{"x", "y"},
+		Recursive:  true,
 		Logic:      spec,
 	}, gf)
 
 	// Test with nil optional fields (Comment and Parameters are optional).
 
-	gf = NewGlobalFunction(gfKey2, "_Constant", nil,
+	gf = NewGlobalFunction(gfKey2, "_Constant", nil, false,
 		NewLogic(gfKey2, LogicTypeValue, "A constant.", "",
 			validSpecWithBody("42"), nil))
 	s.Equal("_Constant", gf.Name)
@@ -9,8 +9,11 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
 )
 
-// NotationTLAPlus is the only supported notation for logic specifications.
-const NotationTLAPlus = "tla_plus"
+// Notations for logic specifications.
+const (
+	NotationTLAPlus = "tla_plus"
+	NotationInfix   = "infix"
+)
 
 // Logic kinds.
 const (
@@ -0,0 +1,146 @@
+package logic_expression
+
+import "fmt"
+
+// valueKind is the kind of value an expression evaluates to, as far as it can be
+// told from the expression alone. kindUnknown covers references and anything else
+// whose value is only known during evaluation.
+type valueKind string
+
+const (
+	kindUnknown  = valueKind("")
+	kindBoolean  = valueKind("Boolean")
+	kindNumber   = valueKind("Number")
+	kindString   = valueKind("String")
+	kindSet      = valueKind("Set")
+	kindSequence = valueKind("Sequence")
+	kindRecord   = valueKind("Record")
+	kindBag      = valueKind("Bag")
+)
+
+// builtinParam is the type rule for one argument of a builtin.
+type builtinParam struct {
+	kind       valueKind // kindUnknown accepts any value.
+	integer    bool      // A number that must be an integer.
+	setOfBags  bool      // A set whose elements must be bags.
+	isOperator bool      // The operator argument of a higher-order builtin.
+}
+
+// builtinSignature is the type rule for a builtin call: its parameters and result.
+type builtinSignature struct {
+	params []builtinParam
+	result valueKind
+}
+
+var (
+	paramAny      = builtinParam{}
+	paramSeq      = builtinParam{kind: kindSequence}
+	paramInt      = builtinParam{kind: kindNumber, integer: true}
+	paramSet      = builtinParam{kind: kindSet}
+	paramBag      = builtinParam{kind: kindBag}
+	paramBags     = builtinParam{kind: kindSet, setOfBags: true}
+	paramOperator = builtinParam{isOperator: true}
+)
+
+// builtinSignatures holds the type rules of the standard module builtins (_Module!Function).
+// Builtins not listed here, such as the simulator's _GZ module, are not type-checked.
+var builtinSignatures = map[string]builtinSignature{
+	// Sequences.
+	"_Seq!Seq":       {params: []builtinParam{paramSet}, result: kindSet},
+	"_Seq!SeqUnique": {params: []builtinParam{paramSet}, result: kindSet},
+	"_Seq!Head":      {params: []builtinParam{paramSeq}},
+	"_Seq!Tail":      {params: []builtinParam{paramSeq}, result: kindSequence},
+	"_Seq!Append":    {params: []builtinParam{paramSeq, paramAny}, result: kindSequence},
+	"_Seq!Len":       {params: []builtinParam{paramSeq}, result: kindNumber},
+	"_Seq!SubSeq":    {params: []builtinParam{paramSeq, paramInt, paramInt}, result: kindSequence},
+	"_Seq!SelectSeq": {params: []builtinParam{paramSeq, paramOperator}, result: kindSequence},
+
+	// Stack and Queue.
+	"_Stack!Push":    {params: []builtinParam{paramSeq, paramAny}, result: kindSequence},
+	"_Stack!Pop":     {params: []builtinParam{paramSeq}, result: kindSequence},
+	"_Queue!Enqueue": {params: []builtinParam{paramSeq, paramAny}, result: kindSequence},
+	"_Queue!Dequeue": {params: []builtinParam{paramSeq}, result: kindSequence},
+
+	// Bags.
+	"_Bags!SetToBag":       {params: []builtinParam{paramSet}, result: kindBag},
+	"_Bags!BagToSet":       {params: []builtinParam{paramBag}, result: kindSet},
+	"_Bags!CopiesIn":       {params: []builtinParam{paramAny, paramBag}, result: kindNumber},
+	"_Bags!BagIn":          {params: []builtinParam{paramAny, paramBag}, result: kindBoolean},
+	"_Bags!BagCardinality": {params: []builtinParam{paramBag}, result: kindNumber},
+	"_Bags!BagUnion":       {params: []builtinParam{paramBags}, result: kindBag},
+	"_Bags!SubBag":         {params: []builtinParam{paramBag}, result: kindSet},
+	"_Bags!EmptyBag":       {result: kindBag},
+	"_Bags!BagOfAll":       {params: []builtinParam{paramOperator, paramBag}, result: kindBag},
+
+	// FiniteSets.
+	"_FiniteSets!Cardinality": {params: []builtinParam{paramSet}, result: kindNumber},
+}
+
+// kindOf returns the kind of value expr evaluates to, or kindUnknown when it
+// cannot be told without evaluating it.
+func kindOf(expr Expression) valueKind {
+	switch n := expr.(type) {
+	case *BoolLiteral, *BinaryLogic, *Compare, *SetCompare, *BagCompare, *Membership, *Not, *Quantifier:
+		return kindBoolean
+	case *IntLiteral, *RationalLiteral, *BinaryArith, *Negate:
+		return kindNumber
+	case *StringLiteral, *StringConcat:
+		return kindString
+	case *SetLiteral, *SetConstant, *SetOp, *SetFilter, *SetMap, *SetRange, *NamedSetRef, *ClassRef:
+		return kindSet
+	case *TupleLiteral, *TupleConcat:
+		return kindSequence
+	case *RecordLiteral, *RecordUpdate:
+		return kindRecord
+	case *BagOp:
+		return kindBag
+	case *BuiltinCall:
+		return builtinSignatures[n.Module+"!"+n.Function].result
+	}
+	return kindUnknown
+}
+
+// checkBuiltinTypes checks the arguments of a builtin call against its type rule.
+func checkBuiltinTypes(n *BuiltinCall) error {
+	name := n.Module + "!" + n.Function
+	signature, ok := builtinSignatures[name]
+	if !ok {
+		return nil
+	}
+	if len(n.Args) != len(signature.params) {
+		return fmt.Errorf("%s takes %d arguments, got %d", name, len(signature.params), len(n.Args))
+	}
+	for i, param := range signature.params {
+		if err := checkBuiltinArg(param, n.Args[i]); err != nil {
+			return fmt.Errorf("Args[%d]: %s %w", i, name, err)
+		}
+	}
+	return nil
+}
+
+func checkBuiltinArg(param builtinParam, arg Expression) error {
+	if param.isOperator {
+		if _, ok := arg.(*GlobalCall); !ok {
+			return fmt.Errorf("requires a global function, got %s", arg.NodeType())
+		}
+		return nil
+	}
+	kind := kindOf(arg)
+	if param.kind == kindUnknown || kind == kindUnknown {
+		return nil
+	}
+	if kind != param.kind {
+		return fmt.Errorf("requires %s, got %s", param.kind, kind)
+	}
+	if literal, ok := arg.(*RationalLiteral); ok && param.integer && literal.Value != nil && !literal.Value.IsInt() {
+		return fmt.Errorf("requires an integer, got %s", literal.Value.RatString())
+	}
+	if literal, ok := arg.(*SetLiteral); ok && param.setOfBags {
+		for _, element := range literal.Elements {
+			if elementKind := kindOf(element); elementKind != kindUnknown && elementKind != kindBag {
+				return fmt.Errorf("requires a Set of Bags, got an element of %s", elementKind)
+			}
+		}
+	}
+	return nil
+}
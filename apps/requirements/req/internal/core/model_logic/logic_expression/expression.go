@@ -60,6 +60,10 @@ const (
 	NodeLetExpr    = "let_expr"
 	NodeChoose     = "choose"
 
+	// Recursive function definitions.
+	NodeFunctionDef   = "function_def"
+	NodeFunctionApply = "function_apply"
+
 	// Quantifiers.
 	NodeQuantifier = "quantifier"
 	NodeSetFilter  = "set_filter"
@@ -314,7 +314,7 @@ func (s *ExpressionTestSuite) TestValidateCalls() {
 		{testName: "error action call nil arg", expr: &ActionCall{ActionKey: validActionKey(), Args: []Expression{nil}}, errstr: "Args[0]: is required"},
 		{testName: "valid global call", expr: &GlobalCall{FunctionKey: validGlobalFunctionKey(), Args: []Expression{arg}}},
 		{testName: "error global call empty key", expr: &GlobalCall{}, errstr: "FunctionKey"},
-		{testName: "valid builtin call", expr: &BuiltinCall{Module: "_Seq", Function: "Len", Args: []Expression{arg}}},
+		{testName: "valid builtin call", expr: &BuiltinCall{Module: "_Seq", Function: "Len", Args: []Expression{&TupleLiteral{Elements: []Expression{arg}}}}},
 		{testName: "error builtin call no module", expr: &BuiltinCall{Function: "Len"}, errstr: "Module"},
 		{testName: "error builtin call no function", expr: &BuiltinCall{Module: "_Seq"}, errstr: "Function"},
 		{testName: "valid named set ref", expr: &NamedSetRef{SetKey: validNamedSetKey()}},
@@ -337,6 +337,161 @@ func (s *ExpressionTestSuite) TestValidateCalls() {
 	}
 }
 
+func (s *ExpressionTestSuite) TestValidateBuiltinTypes() {
+	one := &IntLiteral{Value: big.NewInt(1)}
+	seq := &TupleLiteral{Elements: []Expression{one}}
+	set := &SetLiteral{Elements: []Expression{one}}
+	bag := &BuiltinCall{Module: "_Bags", Function: "SetToBag", Args: []Expression{set}}
+	local := &LocalVar{Name: "x"}
+	test := &GlobalCall{FunctionKey: validGlobalFunctionKey()}
+
+	tests := []struct {
+		testName string
+		expr     Expression
+		errstr   string
+	}{
+		{testName: "valid sub seq", expr: &BuiltinCall{Module: "_Seq", Function: "SubSeq", Args: []Expression{seq, one, one}}},
+		{testName: "valid sub seq of local", expr: &BuiltinCall{Module: "_Seq", Function: "SubSeq", Args: []Expression{local, local, local}}},
+		{testName: "valid select seq", expr: &BuiltinCall{Module: "_Seq", Function: "SelectSeq", Args: []Expression{seq, test}}},
+		{testName: "valid bag union", expr: &BuiltinCall{Module: "_Bags", Function: "BagUnion", Args: []Expression{&SetLiteral{Elements: []Expression{bag, local}}}}},
+		{testName: "valid sub bag", expr: &BuiltinCall{Module: "_Bags", Function: "SubBag", Args: []Expression{&BagOp{Op: BagSum, Left: bag, Right: bag}}}},
+		{testName: "valid empty bag", expr: &BuiltinCall{Module: "_Bags", Function: "EmptyBag"}},
+		{testName: "valid bag of all", expr: &BuiltinCall{Module: "_Bags", Function: "BagOfAll", Args: []Expression{test, bag}}},
+		{testName: "valid unlisted builtin", expr: &BuiltinCall{Module: "_GZ", Function: "WhenNotNull", Args: []Expression{one}}},
+		{testName: "error sub seq of set", expr: &BuiltinCall{Module: "_Seq", Function: "SubSeq", Args: []Expression{set, one, one}}, errstr: "Args[0]: _Seq!SubSeq requires Sequence, got Set"},
+		{testName: "error sub seq fractional index", expr: &BuiltinCall{Module: "_Seq", Function: "SubSeq", Args: []Expression{seq, &RationalLiteral{Value: big.NewRat(1, 2)}, one}}, errstr: "Args[1]: _Seq!SubSeq requires an integer, got 1/2"},
+		{testName: "error sub seq arity", expr: &BuiltinCall{Module: "_Seq", Function: "SubSeq", Args: []Expression{seq, one}}, errstr: "_Seq!SubSeq takes 3 arguments, got 2"},
+		{testName: "error select seq operator", expr: &BuiltinCall{Module: "_Seq", Function: "SelectSeq", Args: []Expression{seq, local}}, errstr: "Args[1]: _Seq!SelectSeq requires a global function, got local_var"},
+		{testName: "error bag union of set", expr: &BuiltinCall{Module: "_Bags", Function: "BagUnion", Args: []Expression{bag}}, errstr: "_Bags!BagUnion requires Set, got Bag"},
+		{testName: "error bag union of numbers", expr: &BuiltinCall{Module: "_Bags", Function: "BagUnion", Args: []Expression{set}}, errstr: "_Bags!BagUnion requires a Set of Bags, got an element of Number"},
+		{testName: "error sub bag of set", expr: &BuiltinCall{Module: "_Bags", Function: "SubBag", Args: []Expression{set}}, errstr: "_Bags!SubBag requires Bag, got Set"},
+		{testName: "error empty bag with args", expr: &BuiltinCall{Module: "_Bags", Function: "EmptyBag", Args: []Expression{one}}, errstr: "_Bags!EmptyBag takes 0 arguments, got 1"},
+		{testName: "error cardinality of bag", expr: &BuiltinCall{Module: "_FiniteSets", Function: "Cardinality", Args: []Expression{bag}}, errstr: "_FiniteSets!Cardinality requires Set, got Bag"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			ctx := coreerr.NewContext("test", "")
+			err := tt.expr.Validate(ctx)
+			if tt.errstr == "" {
+				s.Require().NoError(err)
+			} else {
+				s.Require().Error(err)
+				s.Contains(err.Error(), tt.errstr)
+			}
+		})
+	}
+}
+
+func (s *ExpressionTestSuite) TestValidateFunctionDefs() {
+	domain := &SetConstant{Kind: SetConstantNat}
+	integers := &SetConstant{Kind: SetConstantInt}
+	n := &LocalVar{Name: "n"}
+	one := &IntLiteral{Value: big.NewInt(1)}
+	decreasing := &FunctionApply{Name: "f", Arg: &BinaryArith{Op: ArithSub, Left: n, Right: one}}
+	increasing := &FunctionApply{Name: "f", Arg: &BinaryArith{Op: ArithAdd, Left: n, Right: one}}
+	body := &FunctionApply{Name: "f", Arg: one}
+
+	tests := []struct {
+		testName string
+		expr     Expression
+		errstr   string
+	}{
+		{testName: "valid function def", expr: &FunctionDef{Name: "f", Variable: "n", Domain: domain, Value: one, Body: body}},
+		{testName: "valid function def decreasing recursion", expr: &FunctionDef{Name: "f", Variable: "n", Domain: domain, Value: decreasing, Body: body}},
+		{testName: "valid function def shadowed recursion", expr: &FunctionDef{Name: "f", Variable: "n", Domain: domain, Value: &FunctionDef{Name: "f", Variable: "m", Domain: domain, Value: one, Body: increasing}, Body: body}},
+		{testName: "error function def no name", expr: &FunctionDef{Variable: "n", Domain: domain, Value: one, Body: body}, errstr: "Name"},
+		{testName: "error function def no variable", expr: &FunctionDef{Name: "f", Domain: domain, Value: one, Body: body}, errstr: "Variable"},
+		{testName: "error function def nil domain", expr: &FunctionDef{Name: "f", Variable: "n", Value: one, Body: body}, errstr: "Domain: is required"},
+		{testName: "error function def nil value", expr: &FunctionDef{Name: "f", Variable: "n", Domain: domain, Body: body}, errstr: "Value: is required"},
+		{testName: "error function def nil body", expr: &FunctionDef{Name: "f", Variable: "n", Domain: domain, Value: one}, errstr: "Body: is required"},
+		{testName: "error function def not well-founded", expr: &FunctionDef{Name: "f", Variable: "n", Domain: domain, Value: increasing, Body: body}, errstr: "recursive application of 'f' does not decrease 'n'"},
+		{testName: "error function def decreasing over Int", expr: &FunctionDef{Name: "f", Variable: "n", Domain: integers, Value: decreasing, Body: body}, errstr: "recursive application of 'f' does not decrease 'n'"},
+		{testName: "valid function def decreasing over Int under a guard", expr: &FunctionDef{Name: "f", Variable: "n", Domain: integers, Value: &IfThenElse{Condition: &Compare{Op: CompareGt, Left: n, Right: one}, Then: decreasing, Else: one}, Body: body}},
+		{testName: "valid function def decreasing over a range", expr: &FunctionDef{Name: "f", Variable: "n", Domain: &SetRange{Start: one, End: one}, Value: decreasing, Body: body}},
+		{testName: "valid function apply", expr: body},
+		{testName: "error function apply no name", expr: &FunctionApply{Arg: one}, errstr: "Name"},
+		{testName: "error function apply nil arg", expr: &FunctionApply{Name: "f"}, errstr: "Arg: is required"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			ctx := coreerr.NewContext("test", "")
+			err := tt.expr.Validate(ctx)
+			if tt.errstr == "" {
+				s.Require().NoError(err)
+			} else {
+				s.Require().Error(err)
+				s.Contains(err.Error(), tt.errstr)
+			}
+		})
+	}
+}
+
+func (s *ExpressionTestSuite) TestDecreasesOn() {
+	v := &LocalVar{Name: "v"}
+	w := &LocalVar{Name: "w"}
+	one := &IntLiteral{Value: big.NewInt(1)}
+	zero := &IntLiteral{Value: big.NewInt(0)}
+
+	tests := []struct {
+		testName string
+		arg      Expression
+		bounded  bool
+		expected bool
+	}{
+		{testName: "set difference", arg: &SetOp{Op: SetDifference, Left: v, Right: &SetLiteral{Elements: []Expression{one}}}, expected: true},
+		{testName: "set union", arg: &SetOp{Op: SetUnion, Left: v, Right: &SetLiteral{}}},
+		{testName: "set difference other variable", arg: &SetOp{Op: SetDifference, Left: w, Right: &SetLiteral{}}},
+		{testName: "subtract positive literal", arg: &BinaryArith{Op: ArithSub, Left: v, Right: one}, bounded: true, expected: true},
+		{testName: "subtract positive literal unbounded", arg: &BinaryArith{Op: ArithSub, Left: v, Right: one}},
+		{testName: "subtract zero", arg: &BinaryArith{Op: ArithSub, Left: v, Right: zero}, bounded: true},
+		{testName: "subtract variable", arg: &BinaryArith{Op: ArithSub, Left: v, Right: w}, bounded: true},
+		{testName: "add literal", arg: &BinaryArith{Op: ArithAdd, Left: v, Right: one}, bounded: true},
+		{testName: "tail", arg: &BuiltinCall{Module: "_Seq", Function: "Tail", Args: []Expression{v}}, expected: true},
+		{testName: "head", arg: &BuiltinCall{Module: "_Seq", Function: "Head", Args: []Expression{v}}},
+		{testName: "variable unchanged", arg: v, bounded: true},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			s.Equal(tt.expected, DecreasesOn(tt.arg, "v", tt.bounded))
+		})
+	}
+}
+
+func (s *ExpressionTestSuite) TestBoundedBelow() {
+	v := &LocalVar{Name: "v"}
+	zero := &IntLiteral{Value: big.NewInt(0)}
+	target := &FunctionApply{Name: "f", Arg: v}
+	compare := func(op CompareOp, left, right Expression) *Compare {
+		return &Compare{Op: op, Left: left, Right: right}
+	}
+
+	tests := []struct {
+		testName string
+		expr     Expression
+		expected bool
+	}{
+		{testName: "then of greater than", expr: &IfThenElse{Condition: compare(CompareGt, v, zero), Then: target, Else: zero}, expected: true},
+		{testName: "then of literal less than", expr: &IfThenElse{Condition: compare(CompareLt, zero, v), Then: target, Else: zero}, expected: true},
+		{testName: "else of less or equal", expr: &IfThenElse{Condition: compare(CompareLte, v, zero), Then: zero, Else: target}, expected: true},
+		{testName: "else of not greater or equal", expr: &IfThenElse{Condition: &Not{Expr: compare(CompareGte, v, zero)}, Then: target, Else: zero}},
+		{testName: "else of equal", expr: &IfThenElse{Condition: compare(CompareEq, v, zero), Then: zero, Else: target}},
+		{testName: "then of less than", expr: &IfThenElse{Condition: compare(CompareLt, v, zero), Then: target, Else: zero}},
+		{testName: "nested in then", expr: &IfThenElse{Condition: compare(CompareGte, v, zero), Then: &Negate{Expr: target}, Else: zero}, expected: true},
+		{testName: "case branch", expr: &Case{Branches: []CaseBranch{{Condition: compare(CompareGt, v, zero), Result: target}}, Otherwise: zero}, expected: true},
+		{testName: "case otherwise", expr: &Case{Branches: []CaseBranch{{Condition: compare(CompareGt, v, zero), Result: zero}}, Otherwise: target}},
+		{testName: "right of conjunction", expr: &BinaryLogic{Op: LogicAnd, Left: &BinaryLogic{Op: LogicAnd, Left: &BoolLiteral{Value: true}, Right: compare(CompareGt, v, zero)}, Right: target}, expected: true},
+		{testName: "left of conjunction", expr: &BinaryLogic{Op: LogicAnd, Left: target, Right: compare(CompareGt, v, zero)}},
+		{testName: "other variable", expr: &IfThenElse{Condition: compare(CompareGt, &LocalVar{Name: "w"}, zero), Then: target, Else: zero}},
+		{testName: "unguarded", expr: target},
+		{testName: "not found", expr: &IfThenElse{Condition: compare(CompareGt, v, zero), Then: zero, Else: zero}},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			s.Equal(tt.expected, BoundedBelow(tt.expr, target, "v"))
+		})
+	}
+}
+
 func (s *ExpressionTestSuite) TestValidateExpression() {
 	ctx := coreerr.NewContext("test", "")
 	// Test the ValidateExpression helper function.
@@ -358,6 +513,8 @@ func (s *ExpressionTestSuite) TestNodeType() {
 	s.Equal(NodeRecordUpdate, (&RecordUpdate{}).NodeType())
 	s.Equal(NodeNamedSetRef, (&NamedSetRef{}).NodeType())
 	s.Equal(NodeClassRef, (&ClassRef{}).NodeType())
+	s.Equal(NodeFunctionDef, (&FunctionDef{}).NodeType())
+	s.Equal(NodeFunctionApply, (&FunctionApply{}).NodeType())
 }
 
 func (s *ExpressionTestSuite) TestRecursiveValidation() {
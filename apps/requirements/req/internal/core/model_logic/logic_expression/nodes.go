@@ -335,6 +335,28 @@ type Choose struct {
 func (n *Choose) expressionNode()  {}
 func (n *Choose) NodeType() string { return NodeChoose }
 
+// FunctionDef is a recursive function definition: LET f[x ∈ S] == value IN body.
+// FunctionApply nodes naming the function, in Value or Body, apply the definition.
+type FunctionDef struct {
+	Name     string
+	Variable string
+	Domain   Expression
+	Value    Expression
+	Body     Expression
+}
+
+func (n *FunctionDef) expressionNode()  {}
+func (n *FunctionDef) NodeType() string { return NodeFunctionDef }
+
+// FunctionApply applies a function defined by an enclosing FunctionDef: f[arg].
+type FunctionApply struct {
+	Name string
+	Arg  Expression
+}
+
+func (n *FunctionApply) expressionNode()  {}
+func (n *FunctionApply) NodeType() string { return NodeFunctionApply }
+
 // --- Quantifiers ---
 
 // Quantifier represents universal (∀) or existential (∃) quantification.
@@ -416,6 +438,20 @@ type BuiltinCall struct {
 func (n *BuiltinCall) expressionNode()  {}
 func (n *BuiltinCall) NodeType() string { return NodeBuiltinCall }
 
+// builtinOperatorArgs maps higher-order builtins (_Module!Function) to the index of
+// their operator argument. TLA+ passes an operator there, such as the Test of
+// SelectSeq; specifications name a global function, carried as a GlobalCall with no args.
+var builtinOperatorArgs = map[string]int{
+	"_Seq!SelectSeq": 1,
+	"_Bags!BagOfAll": 0,
+}
+
+// BuiltinOperatorArg returns the index of the operator argument of a higher-order builtin.
+func BuiltinOperatorArg(module, function string) (int, bool) {
+	index, ok := builtinOperatorArgs[module+"!"+function]
+	return index, ok
+}
+
 // --- Named set references ---
 
 // NamedSetRef references a model-level named set by key.
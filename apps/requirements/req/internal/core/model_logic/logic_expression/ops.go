@@ -4,12 +4,13 @@ package logic_expression
 type ArithOp string
 
 const (
-	ArithAdd = ArithOp("add")
-	ArithSub = ArithOp("sub")
-	ArithMul = ArithOp("mul")
-	ArithDiv = ArithOp("div")
-	ArithMod = ArithOp("mod")
-	ArithPow = ArithOp("pow")
+	ArithAdd    = ArithOp("add")
+	ArithSub    = ArithOp("sub")
+	ArithMul    = ArithOp("mul")
+	ArithDiv    = ArithOp("div")     // Real division (/).
+	ArithIntDiv = ArithOp("int_div") // Integer division (\div), rounds toward negative infinity.
+	ArithMod    = ArithOp("mod")
+	ArithPow    = ArithOp("pow")
 )
 
 // LogicOp represents logical operators.
@@ -0,0 +1,276 @@
+package logic_expression
+
+// Walk calls visit for expr and each of its subexpressions, depth first.
+// When visit returns false the children of that node are skipped.
+func Walk(expr Expression, visit func(Expression) bool) {
+	if expr == nil || !visit(expr) {
+		return
+	}
+	for _, child := range children(expr) {
+		Walk(child, visit)
+	}
+}
+
+// GlobalCalls returns every global function call within expr.
+func GlobalCalls(expr Expression) []*GlobalCall {
+	var calls []*GlobalCall
+	Walk(expr, func(e Expression) bool {
+		if call, ok := e.(*GlobalCall); ok {
+			calls = append(calls, call)
+		}
+		return true
+	})
+	return calls
+}
+
+// FunctionApplications returns every application of the named function within expr.
+// A nested FunctionDef of the same name shadows the outer one, so its value is not searched.
+func FunctionApplications(expr Expression, name string) []*FunctionApply {
+	var applies []*FunctionApply
+	Walk(expr, func(e Expression) bool {
+		switch n := e.(type) {
+		case *FunctionApply:
+			if n.Name == name {
+				applies = append(applies, n)
+			}
+		case *FunctionDef:
+			if n.Name == name {
+				Walk(n.Domain, func(inner Expression) bool {
+					if apply, ok := inner.(*FunctionApply); ok && apply.Name == name {
+						applies = append(applies, apply)
+					}
+					return true
+				})
+				return false
+			}
+		}
+		return true
+	})
+	return applies
+}
+
+// DecreasesOn reports whether arg is a well-founded decrease of the named variable,
+// the shapes of argument a recursive call may pass back to itself:
+//   - v \ S, a smaller finite set.
+//   - v - k for a positive integer literal k, a smaller number, when bounded reports
+//     that v is bounded below where the call is made (see BoundedBelow).
+//   - _Seq!Tail(v), a shorter sequence.
+func DecreasesOn(arg Expression, variable string, bounded bool) bool {
+	switch n := arg.(type) {
+	case *SetOp:
+		return n.Op == SetDifference && isLocalVar(n.Left, variable)
+	case *BinaryArith:
+		literal, ok := n.Right.(*IntLiteral)
+		return bounded && n.Op == ArithSub && isLocalVar(n.Left, variable) && ok && literal.Value != nil && literal.Value.Sign() > 0
+	case *BuiltinCall:
+		return n.Module == "_Seq" && n.Function == "Tail" && len(n.Args) == 1 && isLocalVar(n.Args[0], variable)
+	default:
+		return false
+	}
+}
+
+// BoundedDomain reports whether every number in domain is bounded below: Nat, or a
+// finite set such as a literal set or an integer range.
+func BoundedDomain(domain Expression) bool {
+	switch n := domain.(type) {
+	case *SetConstant:
+		return n.Kind == SetConstantNat
+	case *SetLiteral, *SetRange:
+		return true
+	default:
+		return false
+	}
+}
+
+// BoundedBelow reports whether the named variable is bounded below wherever target is
+// evaluated within expr, because target is only reached when a guard such as v > 0
+// holds: the THEN branch of an IF (or the ELSE branch of its negation), a CASE branch,
+// or the right of a conjunction or implication.
+func BoundedBelow(expr, target Expression, variable string) bool {
+	found, bounded := boundedAt(expr, target, variable, false)
+	return found && bounded
+}
+
+// boundedAt searches expr for target, reporting whether it was found and whether
+// the variable is bounded below there.
+func boundedAt(expr, target Expression, variable string, bounded bool) (bool, bool) {
+	if expr == nil {
+		return false, false
+	}
+	if expr == target {
+		return true, bounded
+	}
+	guarded := func(child Expression, guard bool) (bool, bool) {
+		return boundedAt(child, target, variable, bounded || guard)
+	}
+	switch n := expr.(type) {
+	case *IfThenElse:
+		if found, ok := guarded(n.Condition, false); found {
+			return found, ok
+		}
+		if found, ok := guarded(n.Then, boundsBelow(n.Condition, variable, false)); found {
+			return found, ok
+		}
+		return guarded(n.Else, boundsBelow(n.Condition, variable, true))
+	case *Case:
+		for _, branch := range n.Branches {
+			if found, ok := guarded(branch.Condition, false); found {
+				return found, ok
+			}
+			if found, ok := guarded(branch.Result, boundsBelow(branch.Condition, variable, false)); found {
+				return found, ok
+			}
+		}
+		return guarded(n.Otherwise, false)
+	case *BinaryLogic:
+		if n.Op == LogicAnd || n.Op == LogicImplies {
+			if found, ok := guarded(n.Left, false); found {
+				return found, ok
+			}
+			return guarded(n.Right, boundsBelow(n.Left, variable, false))
+		}
+	}
+	for _, child := range children(expr) {
+		if found, ok := guarded(child, false); found {
+			return found, ok
+		}
+	}
+	return false, false
+}
+
+// boundsBelow reports whether cond, or its negation when negated, bounds the
+// variable below by an integer literal: v > c, v >= c, c < v, or c <= v.
+func boundsBelow(cond Expression, variable string, negated bool) bool {
+	switch n := cond.(type) {
+	case *Not:
+		return boundsBelow(n.Expr, variable, !negated)
+	case *BinaryLogic:
+		// Either side of a conjunction bounds it; the negation of a disjunction is a conjunction.
+		if (n.Op == LogicAnd && !negated) || (n.Op == LogicOr && negated) {
+			return boundsBelow(n.Left, variable, negated) || boundsBelow(n.Right, variable, negated)
+		}
+	case *Compare:
+		op := n.Op
+		if isIntLiteral(n.Left) && isLocalVar(n.Right, variable) {
+			op = flippedCompare[op]
+		} else if !isLocalVar(n.Left, variable) || !isIntLiteral(n.Right) {
+			return false
+		}
+		if negated {
+			op = negatedCompare[op]
+		}
+		return op == CompareGt || op == CompareGte
+	}
+	return false
+}
+
+// flippedCompare swaps the sides of a comparison: c < v is v > c.
+var flippedCompare = map[CompareOp]CompareOp{
+	CompareLt: CompareGt, CompareGt: CompareLt, CompareLte: CompareGte, CompareGte: CompareLte,
+	CompareEq: CompareEq, CompareNeq: CompareNeq,
+}
+
+// negatedCompare is the comparison that holds when another does not: not v <= c is v > c.
+var negatedCompare = map[CompareOp]CompareOp{
+	CompareLt: CompareGte, CompareGt: CompareLte, CompareLte: CompareGt, CompareGte: CompareLt,
+	CompareEq: CompareNeq, CompareNeq: CompareEq,
+}
+
+func isIntLiteral(expr Expression) bool {
+	literal, ok := expr.(*IntLiteral)
+	return ok && literal.Value != nil
+}
+
+func isLocalVar(expr Expression, name string) bool {
+	local, ok := expr.(*LocalVar)
+	return ok && local.Name == name
+}
+
+// children returns the direct subexpressions of expr.
+func children(expr Expression) []Expression {
+	switch n := expr.(type) {
+	case *SetLiteral:
+		return n.Elements
+	case *TupleLiteral:
+		return n.Elements
+	case *RecordLiteral:
+		out := make([]Expression, 0, len(n.Fields))
+		for _, field := range n.Fields {
+			out = append(out, field.Value)
+		}
+		return out
+	case *NextState:
+		return []Expression{n.Expr}
+	case *BinaryArith:
+		return []Expression{n.Left, n.Right}
+	case *BinaryLogic:
+		return []Expression{n.Left, n.Right}
+	case *Compare:
+		return []Expression{n.Left, n.Right}
+	case *SetOp:
+		return []Expression{n.Left, n.Right}
+	case *SetCompare:
+		return []Expression{n.Left, n.Right}
+	case *BagOp:
+		return []Expression{n.Left, n.Right}
+	case *BagCompare:
+		return []Expression{n.Left, n.Right}
+	case *Membership:
+		return []Expression{n.Element, n.Set}
+	case *Negate:
+		return []Expression{n.Expr}
+	case *Not:
+		return []Expression{n.Expr}
+	case *FieldAccess:
+		return []Expression{n.Base}
+	case *TupleIndex:
+		return []Expression{n.Tuple, n.Index}
+	case *RecordUpdate:
+		out := []Expression{n.Base}
+		for _, alt := range n.Alterations {
+			out = append(out, alt.Value)
+		}
+		return out
+	case *StringIndex:
+		return []Expression{n.Str, n.Index}
+	case *StringConcat:
+		return n.Operands
+	case *TupleConcat:
+		return n.Operands
+	case *IfThenElse:
+		return []Expression{n.Condition, n.Then, n.Else}
+	case *Case:
+		out := make([]Expression, 0, 2*len(n.Branches)+1)
+		for _, branch := range n.Branches {
+			out = append(out, branch.Condition, branch.Result)
+		}
+		return append(out, n.Otherwise)
+	case *LetExpr:
+		return []Expression{n.Value, n.Body}
+	case *Choose:
+		return []Expression{n.Set, n.Predicate}
+	case *FunctionDef:
+		return []Expression{n.Domain, n.Value, n.Body}
+	case *FunctionApply:
+		return []Expression{n.Arg}
+	case *Quantifier:
+		return []Expression{n.Domain, n.Predicate}
+	case *SetFilter:
+		return []Expression{n.Set, n.Predicate}
+	case *SetMap:
+		return []Expression{n.Set, n.Transform}
+	case *SetRange:
+		return []Expression{n.Start, n.End}
+	case *ActionCall:
+		return n.Args
+	case *EventCall:
+		return n.Args
+	case *GlobalCall:
+		return n.Args
+	case *BuiltinCall:
+		return n.Args
+	default:
+		// Literals and references have no subexpressions.
+		return nil
+	}
+}
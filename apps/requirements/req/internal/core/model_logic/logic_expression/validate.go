@@ -11,7 +11,7 @@ import (
 
 var validArithOps = map[ArithOp]bool{
 	ArithAdd: true, ArithSub: true, ArithMul: true,
-	ArithDiv: true, ArithMod: true, ArithPow: true,
+	ArithDiv: true, ArithIntDiv: true, ArithMod: true, ArithPow: true,
 }
 
 var validLogicOps = map[LogicOp]bool{
@@ -522,6 +522,54 @@ func (n *LetExpr) Validate(ctx *coreerr.ValidationContext) error {
 	return nil
 }
 
+func (n *FunctionDef) Validate(ctx *coreerr.ValidationContext) error {
+	if n.Name == "" {
+		return coreerr.New(ctx, coreerr.ExprFunctionRequired, "FunctionDef.Name: is required", "Name")
+	}
+	if n.Variable == "" {
+		return coreerr.New(ctx, coreerr.ExprVariableRequired, "FunctionDef.Variable: is required", "Variable")
+	}
+	if n.Domain == nil {
+		return coreerr.New(ctx, coreerr.ExprDomainRequired, "FunctionDef.Domain: is required", "Domain")
+	}
+	if err := n.Domain.Validate(ctx); err != nil {
+		return coreerr.New(ctx, coreerr.ExprDomainInvalid, fmt.Sprintf("FunctionDef.Domain: %s", err.Error()), "Domain")
+	}
+	if n.Value == nil {
+		return coreerr.New(ctx, coreerr.ExprExprRequired, "FunctionDef.Value: is required", "Value")
+	}
+	if err := n.Value.Validate(ctx); err != nil {
+		return coreerr.New(ctx, coreerr.ExprOperandInvalid, fmt.Sprintf("FunctionDef.Value: %s", err.Error()), "Value")
+	}
+	if n.Body == nil {
+		return coreerr.New(ctx, coreerr.ExprExprRequired, "FunctionDef.Body: is required", "Body")
+	}
+	if err := n.Body.Validate(ctx); err != nil {
+		return coreerr.New(ctx, coreerr.ExprOperandInvalid, fmt.Sprintf("FunctionDef.Body: %s", err.Error()), "Body")
+	}
+	// Every recursive application must decrease the argument, or evaluation may never end.
+	boundedDomain := BoundedDomain(n.Domain)
+	for _, apply := range FunctionApplications(n.Value, n.Name) {
+		if !DecreasesOn(apply.Arg, n.Variable, boundedDomain || BoundedBelow(n.Value, apply, n.Variable)) {
+			return coreerr.New(ctx, coreerr.ExprRecursionNotWellFounded, fmt.Sprintf("FunctionDef.Value: recursive application of '%s' does not decrease '%s'", n.Name, n.Variable), "Value")
+		}
+	}
+	return nil
+}
+
+func (n *FunctionApply) Validate(ctx *coreerr.ValidationContext) error {
+	if n.Name == "" {
+		return coreerr.New(ctx, coreerr.ExprFunctionRequired, "FunctionApply.Name: is required", "Name")
+	}
+	if n.Arg == nil {
+		return coreerr.New(ctx, coreerr.ExprArgRequired, "FunctionApply.Arg: is required", "Arg")
+	}
+	if err := n.Arg.Validate(ctx); err != nil {
+		return coreerr.New(ctx, coreerr.ExprArgInvalid, fmt.Sprintf("FunctionApply.Arg: %s", err.Error()), "Arg")
+	}
+	return nil
+}
+
 func (n *Choose) Validate(ctx *coreerr.ValidationContext) error {
 	if n.Set == nil {
 		return coreerr.New(ctx, coreerr.ExprSetRequired, "Choose.Set: is required", "Set")
@@ -684,6 +732,9 @@ func (n *BuiltinCall) Validate(ctx *coreerr.ValidationContext) error {
 			return coreerr.New(ctx, coreerr.ExprArgInvalid, fmt.Sprintf("BuiltinCall.Args[%d]: %s", i, err.Error()), fmt.Sprintf("Args[%d]", i))
 		}
 	}
+	if err := checkBuiltinTypes(n); err != nil {
+		return coreerr.New(ctx, coreerr.ExprBuiltinArgType, fmt.Sprintf("BuiltinCall.%s", err.Error()), "Args")
+	}
 	return nil
 }
 
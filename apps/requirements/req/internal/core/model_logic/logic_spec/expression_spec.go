@@ -18,7 +18,7 @@ type ExpressionParseFunc func(specification string) (logic_expression.Expression
 //  2. Unparsed: Specification is set, Expression is nil (parse not attempted or failed).
 //  3. Fully parsed: Specification is set, Expression is non-nil. ParseOk() returns true.
 type ExpressionSpec struct {
-	Notation      string                      // Notation system (currently only TLA+).
+	Notation      string                      // Notation system (TLA+ or infix).
 	Specification string                      // Optional specification body text.
 	Expression    logic_expression.Expression // Optional parsed expression tree (nil = not yet parsed).
 }
@@ -53,10 +53,10 @@ func (s *ExpressionSpec) ParseOk() bool {
 // Validate validates the ExpressionSpec.
 func (s *ExpressionSpec) Validate(ctx *coreerr.ValidationContext) error {
 	if s.Notation == "" {
-		return coreerr.NewWithValues(ctx, coreerr.ExprspecNotationRequired, "Notation is required", "Notation", "", "one of: tla_plus")
+		return coreerr.NewWithValues(ctx, coreerr.ExprspecNotationRequired, "Notation is required", "Notation", "", "one of: tla_plus, infix")
 	}
-	if s.Notation != NotationTLAPlus {
-		return coreerr.NewWithValues(ctx, coreerr.ExprspecNotationInvalid, fmt.Sprintf("Notation '%s' is not valid", s.Notation), "Notation", s.Notation, "one of: tla_plus")
+	if !IsExpressionNotation(s.Notation) {
+		return coreerr.NewWithValues(ctx, coreerr.ExprspecNotationInvalid, fmt.Sprintf("Notation '%s' is not valid", s.Notation), "Notation", s.Notation, "one of: tla_plus, infix")
 	}
 	if s.Expression != nil {
 		if err := s.Expression.Validate(ctx); err != nil {
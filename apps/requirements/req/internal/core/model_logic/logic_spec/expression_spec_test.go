@@ -51,6 +51,13 @@ func (s *ExpressionSpecTestSuite) TestValidate() {
 				Expression:    &logic_expression.BoolLiteral{Value: true},
 			},
 		},
+		{
+			testName: "valid infix notation",
+			spec: ExpressionSpec{
+				Notation:      "infix",
+				Specification: "x > 0 && y",
+			},
+		},
 		{
 			testName: "error missing notation",
 			spec: ExpressionSpec{
@@ -1,6 +1,14 @@
 package logic_spec
 
 const (
-	// NotationTLAPlus is the only supported notation for logic and type specifications.
+	// NotationTLAPlus is the notation for logic and type specifications.
 	NotationTLAPlus = "tla_plus"
+	// NotationInfix is the programmer-friendly infix notation (&&, ||, all x in S: ...).
+	// It is available for logic specifications only; type specifications are always TLA+.
+	NotationInfix = "infix"
 )
+
+// IsExpressionNotation reports whether notation can be used for a logic specification.
+func IsExpressionNotation(notation string) bool {
+	return notation == NotationTLAPlus || notation == NotationInfix
+}
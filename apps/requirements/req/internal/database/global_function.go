@@ -19,6 +19,7 @@ func scanGlobalFunction(scanner Scanner, gf *model_logic.GlobalFunction) (err er
 		&logicKeyStr,
 		&gf.Name,
 		pq.Array(&gf.Parameters),
+		&gf.Recursive,
 	); err != nil {
 		if err.Error() == _POSTGRES_NOT_FOUND {
 			err = ErrNotFound
@@ -50,7 +51,8 @@ func LoadGlobalFunction(dbOrTx DbOrTx, modelKey string, logicKey identity.Key) (
 		`SELECT
 			logic_key  ,
 			name       ,
-			parameters
+			parameters ,
+			recursive
 		FROM
 			global_function
 		WHERE
@@ -79,7 +81,8 @@ func UpdateGlobalFunction(dbOrTx DbOrTx, modelKey string, gf model_logic.GlobalF
 			global_function
 		SET
 			name       = $3 ,
-			parameters = $4
+			parameters = $4 ,
+			recursive  = $5
 		WHERE
 			model_key = $1
 		AND
@@ -87,7 +90,8 @@ func UpdateGlobalFunction(dbOrTx DbOrTx, modelKey string, gf model_logic.GlobalF
 		modelKey,
 		gf.Key.String(),
 		gf.Name,
-		pq.Array(gf.Parameters))
+		pq.Array(gf.Parameters),
+		gf.Recursive)
 	if err != nil {
 		return errors.WithStack(err)
 	}
@@ -130,7 +134,8 @@ func QueryGlobalFunctions(dbOrTx DbOrTx, modelKey string) (gfs []model_logic.Glo
 		`SELECT
 			logic_key  ,
 			name       ,
-			parameters
+			parameters ,
+			recursive
 		FROM
 			global_function
 		WHERE
@@ -152,19 +157,20 @@ func AddGlobalFunctions(dbOrTx DbOrTx, modelKey string, gfs []model_logic.Global
 	}
 
 	var queryBuilder strings.Builder
-	queryBuilder.WriteString(`INSERT INTO global_function (model_key, logic_key, name, parameters) VALUES `)
-	args := make([]any, 0, len(gfs)*4)
+	queryBuilder.WriteString(`INSERT INTO global_function (model_key, logic_key, name, parameters, recursive) VALUES `)
+	args := make([]any, 0, len(gfs)*5)
 	for i, gf := range gfs {
 		if i > 0 {
 			queryBuilder.WriteString(", ")
 		}
-		base := i * 4
-		fmt.Fprintf(&queryBuilder, "($%d, $%d, $%d, $%d)", base+1, base+2, base+3, base+4)
+		base := i * 5
+		fmt.Fprintf(&queryBuilder, "($%d, $%d, $%d, $%d, $%d)", base+1, base+2, base+3, base+4, base+5)
 		args = append(args,
 			modelKey,
 			gf.Key.String(),
 			gf.Name,
-			pq.Array(gf.Parameters))
+			pq.Array(gf.Parameters),
+			gf.Recursive)
 	}
 
 	err = dbExec(dbOrTx, queryBuilder.String(), args...)
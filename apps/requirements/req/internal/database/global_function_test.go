@@ -71,6 +71,7 @@ func (suite *GlobalFunctionSuite) TestAdd() {
 		Key:        suite.gfKey,
 		Name:       "_Max",
 		Parameters: []string{"x", "y"},
+		Recursive:  true,
 	})
 	suite.Require().NoError(err)
 
@@ -80,6 +81,7 @@ func (suite *GlobalFunctionSuite) TestAdd() {
 		Key:        suite.gfKey,
 		Name:       "_Max",
 		Parameters: []string{"x", "y"},
+		Recursive:  true,
 	}, gf)
 }
 
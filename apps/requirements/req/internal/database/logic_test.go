@@ -342,6 +342,27 @@ func (suite *LogicSuite) TestAddWithTargetTypeSpec() {
 	}, logic)
 }
 
+func (suite *LogicSuite) TestAddInfix() {
+	err := AddLogic(suite.db, suite.model.Key, model_logic.Logic{
+		Key:         suite.logicKey,
+		Type:        model_logic.LogicTypeAssessment,
+		Description: "Description",
+		Target:      "",
+		Spec:        logic_spec.ExpressionSpec{Notation: "infix", Specification: "self.balance >= 0 && self.balance <= 100"},
+	})
+	suite.Require().NoError(err)
+
+	logic, err := LoadLogic(suite.db, suite.model.Key, suite.logicKey)
+	suite.Require().NoError(err)
+	suite.Equal(model_logic.Logic{
+		Key:         suite.logicKey,
+		Type:        model_logic.LogicTypeAssessment,
+		Description: "Description",
+		Target:      "",
+		Spec:        logic_spec.ExpressionSpec{Notation: "infix", Specification: "self.balance >= 0 && self.balance <= 100"},
+	}, logic)
+}
+
 //==================================================
 // Test objects for other tests.
 //==================================================
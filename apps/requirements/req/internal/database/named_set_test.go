@@ -105,6 +105,25 @@ func (suite *NamedSetSuite) TestAddWithTypeSpec() {
 	}, ns)
 }
 
+func (suite *NamedSetSuite) TestAddInfix() {
+	err := AddNamedSet(suite.db, suite.model.Key, model_logic.NamedSet{
+		Key:         suite.nsKey,
+		Name:        "_ValidStatuses",
+		Description: "Valid statuses",
+		Spec:        logic_spec.ExpressionSpec{Notation: "infix", Specification: `{"pending", "active"}`},
+	})
+	suite.Require().NoError(err)
+
+	ns, err := LoadNamedSet(suite.db, suite.model.Key, suite.nsKey)
+	suite.Require().NoError(err)
+	suite.Equal(model_logic.NamedSet{
+		Key:         suite.nsKey,
+		Name:        "_ValidStatuses",
+		Description: "Valid statuses",
+		Spec:        logic_spec.ExpressionSpec{Notation: "infix", Specification: `{"pending", "active"}`},
+	}, ns)
+}
+
 func (suite *NamedSetSuite) TestRemove() {
 	err := AddNamedSet(suite.db, suite.model.Key, model_logic.NamedSet{
 		Key:         suite.nsKey,
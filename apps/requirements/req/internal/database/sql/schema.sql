@@ -17,7 +17,7 @@ COMMENT ON COLUMN model.unfinished_notes IS 'Scratch notes not yet placed in fin
 
 --------------------------------------------------------------
 
-CREATE TYPE notation AS ENUM ('tla_plus');
+CREATE TYPE notation AS ENUM ('tla_plus', 'infix');
 COMMENT ON TYPE notation IS 'The notation used for a logic or type specification.';
 
 CREATE TYPE logic_type AS ENUM ('assessment', 'state_change', 'query', 'safety_rule', 'value', 'let', 'destroy');
@@ -74,6 +74,7 @@ CREATE TABLE global_function (
   logic_key text NOT NULL,
   name text NOT NULL,
   parameters text[],
+  recursive boolean NOT NULL DEFAULT false,
   PRIMARY KEY (model_key, logic_key),
   UNIQUE (model_key, name),
   CONSTRAINT fk_global_logic FOREIGN KEY (model_key, logic_key) REFERENCES logic (model_key, logic_key) ON DELETE CASCADE
@@ -84,6 +85,7 @@ COMMENT ON COLUMN global_function.model_key IS 'The model this function is part
 COMMENT ON COLUMN global_function.logic_key IS 'The logic of the function.';
 COMMENT ON COLUMN global_function.name IS 'The name of the function, fitting for the notation of the logic.';
 COMMENT ON COLUMN global_function.parameters IS 'The parameters of the function, fitting for the notation of the logic.';
+COMMENT ON COLUMN global_function.recursive IS 'Whether the function may call itself, a TLA+ RECURSIVE definition.';
 
 --------------------------------------------------------------
 
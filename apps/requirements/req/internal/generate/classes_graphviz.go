@@ -0,0 +1,255 @@
+package generate
+
+import (
+	"fmt"
+	"html"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// The renderers class diagrams can be drawn with.
+const (
+	ClassDiagramsMermaid  = "mermaid"  // Mermaid markup embedded in the page, rendered in the browser.
+	ClassDiagramsGraphviz = "graphviz" // SVG laid out by Graphviz, linked from the page.
+)
+
+// classDiagrams is the renderer class diagrams are drawn with.
+var classDiagrams = ClassDiagramsMermaid
+
+// SetClassDiagrams chooses the renderer generated markdown draws class diagrams with
+// (mermaid or graphviz). An empty renderer is mermaid.
+func SetClassDiagrams(renderer string) error {
+	switch renderer {
+	case "":
+		classDiagrams = ClassDiagramsMermaid
+	case ClassDiagramsMermaid, ClassDiagramsGraphviz:
+		classDiagrams = renderer
+	default:
+		return errors.Errorf("class diagram renderer '%s' is not valid, want one of: %s, %s", renderer, ClassDiagramsMermaid, ClassDiagramsGraphviz)
+	}
+	return nil
+}
+
+// graphvizClassDiagrams reports whether class diagrams are drawn by Graphviz, in which case
+// a page's classes diagram is the filename of its SVG rather than Mermaid markup.
+func graphvizClassDiagrams() bool {
+	return classDiagrams == ClassDiagramsGraphviz
+}
+
+// Styles of the Graphviz class boxes, matching the Mermaid ones.
+const (
+	_classesGraphvizFill       = "#ECECFF" // Mermaid's default class fill.
+	_classesGraphvizMarkedFill = "#FFEB3B"
+	_classesGraphvizFocalColor = "#9370DB"
+)
+
+// generateClassesDiagram draws a class diagram with the chosen renderer. With Mermaid it
+// returns the markup. With Graphviz it writes the diagram to svgFilename and returns that
+// filename.
+func generateClassesDiagram(
+	reqs *req_flat.Requirements,
+	writer ContentWriter,
+	generalizations []model_class.Generalization,
+	classes []model_class.Class,
+	associations []model_class.Association,
+	viewerSubdomainKey identity.Key,
+	focalClassKey *identity.Key,
+	svgFilename string,
+) (string, error) {
+	if !graphvizClassDiagrams() {
+		return generateClassesMermaidContents(reqs, generalizations, classes, associations, viewerSubdomainKey, focalClassKey)
+	}
+	dot, err := generateClassesGraphvizContents(reqs, generalizations, classes, associations, viewerSubdomainKey, focalClassKey)
+	if err != nil {
+		return "", err
+	}
+	svg, err := renderGraphvizSVG(dot)
+	if err != nil {
+		return "", err
+	}
+	if err := writer.WriteSVG(svgFilename, svg); err != nil {
+		return "", err
+	}
+	return svgFilename, nil
+}
+
+// generateClassesGraphvizContents generates a UML class diagram as DOT. Classes are
+// boxes with an attribute compartment linked to their class pages. Classes of other
+// subdomains are grouped into clusters named like Mermaid namespaces, and each
+// generalization groups its local classes into a borderless cluster so a hierarchy is
+// laid out together. Associations carry multiplicities at their ends; those with an
+// association class or a comment pass through a point that the association class and
+// the comment hang from by dashed lines.
+func generateClassesGraphvizContents(
+	reqs *req_flat.Requirements,
+	generalizations []model_class.Generalization,
+	classes []model_class.Class,
+	associations []model_class.Association,
+	viewerSubdomainKey identity.Key,
+	focalClassKey *identity.Key,
+) (string, error) {
+	layout, err := groupClassesMermaidByNamespace(reqs, viewerSubdomainKey, classes)
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+	drawn := make(map[identity.Key]model_class.Class, len(classes))
+	for _, class := range classes {
+		drawn[class.Key] = class
+	}
+	superclassLookup := reqs.GeneralizationSuperclassLookup()
+	subclassesLookup := reqs.GeneralizationSubclassesLookup()
+
+	generalizations = slices.Clone(generalizations)
+	slices.SortFunc(generalizations, func(a, b model_class.Generalization) int {
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+	associations = slices.Clone(associations)
+	slices.SortFunc(associations, func(a, b model_class.Association) int {
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+
+	var b strings.Builder
+	b.WriteString("digraph Classes {\n")
+	b.WriteString("    graph [nodesep=0.6, ranksep=0.8, fontname=\"Sans-Serif\", fontsize=11];\n")
+	b.WriteString("    node [shape=plain, fontname=\"Sans-Serif\", fontsize=11];\n")
+	b.WriteString("    edge [fontname=\"Sans-Serif\", fontsize=9, arrowhead=vee, labeldistance=1.8];\n")
+
+	// Group the local classes of each generalization, each class in the first it is part of.
+	clustered := map[identity.Key]bool{}
+	for i, generalization := range generalizations {
+		var members []model_class.Class
+		for _, class := range append([]model_class.Class{superclassLookup[generalization.Key.String()]}, subclassesLookup[generalization.Key.String()]...) {
+			if _, ok := drawn[class.Key]; ok && !clustered[class.Key] && slices.ContainsFunc(layout.LocalClasses, func(local model_class.Class) bool { return local.Key == class.Key }) {
+				clustered[class.Key] = true
+				members = append(members, class)
+			}
+		}
+		if len(members) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "    subgraph cluster_generalization_%d {\n", i)
+		b.WriteString("        peripheries=0;\n")
+		for _, class := range members {
+			writeClassGraphvizNode(&b, "        ", class, focalClassKey)
+		}
+		b.WriteString("    }\n")
+	}
+	for _, class := range layout.LocalClasses {
+		if !clustered[class.Key] {
+			writeClassGraphvizNode(&b, "    ", class, focalClassKey)
+		}
+	}
+	for i, namespace := range layout.Namespaces {
+		fmt.Fprintf(&b, "    subgraph cluster_namespace_%d {\n", i)
+		fmt.Fprintf(&b, "        label=%s; style=\"rounded,dashed\"; color=gray50;\n", dotQuote(namespace.Path))
+		for _, class := range namespace.Classes {
+			writeClassGraphvizNode(&b, "        ", class, focalClassKey)
+		}
+		b.WriteString("    }\n")
+	}
+	for _, class := range classes {
+		writeGraphvizNote(&b, mermaidNodeID("note", class.Key), mermaidNodeID("class", class.Key), class.UmlComment)
+	}
+
+	// Superclasses sit above their subclasses with the hollow arrowhead at the superclass.
+	for _, generalization := range generalizations {
+		superclass, ok := drawn[superclassLookup[generalization.Key.String()].Key]
+		if !ok {
+			continue
+		}
+		for _, subclass := range subclassesLookup[generalization.Key.String()] {
+			if _, ok := drawn[subclass.Key]; ok {
+				fmt.Fprintf(&b, "    %s -> %s [dir=back, arrowtail=empty];\n", mermaidNodeID("class", superclass.Key), mermaidNodeID("class", subclass.Key))
+			}
+		}
+	}
+
+	for _, assoc := range associations {
+		fromClass, fromOK := drawn[assoc.FromClassKey]
+		toClass, toOK := drawn[assoc.ToClassKey]
+		if !fromOK || !toOK {
+			continue
+		}
+		fromID := mermaidNodeID("class", assoc.FromClassKey)
+		toID := mermaidNodeID("class", assoc.ToClassKey)
+		label := assoc.Name
+		if tag := associationUniquenessMermaidTag(assoc.Uniqueness, fromClass, toClass); tag != "" {
+			label += "\n" + tag
+		}
+		if !renderAssociationLinkNodeMermaid(assoc) {
+			fmt.Fprintf(&b, "    %s -> %s [label=%s, taillabel=%s, headlabel=%s];\n",
+				fromID, toID, dotQuote(label), dotQuote(assoc.FromMultiplicity.String()), dotQuote(assoc.ToMultiplicity.String()))
+			continue
+		}
+		linkID := mermaidNodeID("assoc", assoc.Key)
+		fmt.Fprintf(&b, "    %s [shape=point, width=0.06, xlabel=%s];\n", linkID, dotQuote(label))
+		fmt.Fprintf(&b, "    %s -> %s [arrowhead=none, taillabel=%s];\n", fromID, linkID, dotQuote(assoc.FromMultiplicity.String()))
+		fmt.Fprintf(&b, "    %s -> %s [headlabel=%s];\n", linkID, toID, dotQuote(assoc.ToMultiplicity.String()))
+		if assoc.AssociationClassKey != nil {
+			if _, ok := drawn[*assoc.AssociationClassKey]; ok {
+				fmt.Fprintf(&b, "    %s -> %s [style=dashed, arrowhead=none, constraint=false];\n", mermaidNodeID("class", *assoc.AssociationClassKey), linkID)
+			}
+		}
+		writeGraphvizNote(&b, mermaidNodeID("note", assoc.Key), linkID, assoc.UmlComment)
+	}
+
+	b.WriteString("}\n")
+	return b.String(), nil
+}
+
+// writeClassGraphvizNode writes a class as an HTML-like table: a title compartment with
+// any «actor» stereotype, then an attribute compartment when it has attributes. Marked
+// classes are filled yellow and the focal class has a heavier purple border.
+func writeClassGraphvizNode(b *strings.Builder, indent string, class model_class.Class, focalClassKey *identity.Key) {
+	fill := _classesGraphvizFill
+	if class.Marked {
+		fill = _classesGraphvizMarkedFill
+	}
+	border := `BORDER="1" COLOR="black"`
+	if focalClassKey != nil && class.Key == *focalClassKey {
+		border = `BORDER="3" COLOR="` + _classesGraphvizFocalColor + `"`
+	}
+
+	var label strings.Builder
+	fmt.Fprintf(&label, `<TABLE %s CELLBORDER="0" CELLSPACING="0" CELLPADDING="4" BGCOLOR="%s">`, border, fill)
+	label.WriteString(`<TR><TD>`)
+	if class.ActorKey != nil {
+		label.WriteString(`&laquo;actor&raquo;<BR/>`)
+	}
+	label.WriteString(`<B>` + html.EscapeString(class.Name) + `</B></TD></TR>`)
+	if len(class.Attributes) > 0 {
+		label.WriteString(`<TR><TD BORDER="1" SIDES="T" ALIGN="LEFT" BALIGN="LEFT">`)
+		for i, attr := range class.Attributes {
+			if i > 0 {
+				label.WriteString(`<BR/>`)
+			}
+			label.WriteString(html.EscapeString(classesMermaidAttributeMember(attr)))
+		}
+		label.WriteString(`</TD></TR>`)
+	}
+	label.WriteString(`</TABLE>`)
+
+	classURL := convertKeyToFilename("class", class.Key.String(), "", ".md")
+	fmt.Fprintf(b, "%s%s [URL=%s, target=\"_top\", tooltip=%s, label=<%s>];\n",
+		indent, mermaidNodeID("class", class.Key), dotQuote(classURL), dotQuote(class.Name), label.String())
+}
+
+// writeGraphvizNote writes a uml_comment as a note hanging from a node by a dashed line.
+func writeGraphvizNote(b *strings.Builder, noteID, targetID, comment string) {
+	text := strings.TrimSpace(comment)
+	if text == "" {
+		return
+	}
+	lines := strings.Split(text, "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimRight(line, " \t")
+	}
+	fmt.Fprintf(b, "    %s [shape=note, fontsize=9, label=%s];\n", noteID, dotQuote(strings.Join(lines, "\n")))
+	fmt.Fprintf(b, "    %s -> %s [style=dashed, arrowhead=none];\n", noteID, targetID)
+}
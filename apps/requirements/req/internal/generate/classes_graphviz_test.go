@@ -0,0 +1,132 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// shapesGraphvizModel is one subdomain where a marked Shape generalizes Circle, and a
+// Canvas holds many shapes through a Placement association class with a comment.
+func shapesGraphvizModel() (core.Model, identity.Key) {
+	domainKey := helper.Must(identity.NewDomainKey("dg"))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "sg"))
+
+	genKey := helper.Must(identity.NewGeneralizationKey(subdomainKey, "shape_types"))
+	shapeKey := helper.Must(identity.NewClassKey(subdomainKey, "shape"))
+	circleKey := helper.Must(identity.NewClassKey(subdomainKey, "circle"))
+	canvasKey := helper.Must(identity.NewClassKey(subdomainKey, "canvas"))
+	placementKey := helper.Must(identity.NewClassKey(subdomainKey, "placement"))
+
+	shape := model_class.NewClass(shapeKey, model_class.ClassLinks{SuperclassOfKey: &genKey}, model_class.ClassDetails{Name: "Shape"})
+	shape.SetMarked(true)
+	circle := model_class.NewClass(circleKey, model_class.ClassLinks{SubclassOfKey: &genKey}, model_class.ClassDetails{Name: "Circle"})
+	circle.Attributes = []model_class.Attribute{
+		helper.Must(model_class.NewAttribute(helper.Must(identity.NewAttributeKey(circleKey, "radius")), model_class.AttributeDetails{Name: "radius"}, "", nil, false, model_class.AttributeAnnotations{})),
+	}
+	canvas := model_class.NewClass(canvasKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Canvas & Co", UmlComment: "The drawing surface."})
+	placement := model_class.NewClass(placementKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Placement"})
+	gen := model_class.NewGeneralization(genKey, model_class.GeneralizationDetails{Name: "Shape Types"}, "", model_class.GeneralizationTraits{IsComplete: true, IsStatic: true}, "")
+
+	assocKey := helper.Must(identity.NewClassAssociationKey(subdomainKey, canvasKey, shapeKey, "holds"))
+	assoc := model_class.NewAssociation(assocKey, model_class.AssociationDetails{Name: "holds"},
+		model_class.AssociationEnd{ClassKey: canvasKey, Multiplicity: helper.Must(model_class.NewMultiplicity("1"))},
+		model_class.AssociationEnd{ClassKey: shapeKey, Multiplicity: helper.Must(model_class.NewMultiplicity("any"))},
+		model_class.AssociationOptions{AssociationClassKey: &placementKey, UmlComment: "Shapes may overlap."})
+
+	subdomain := model_domain.Subdomain{
+		Key:  subdomainKey,
+		Name: "S G",
+		Classes: map[identity.Key]model_class.Class{
+			shapeKey: shape, circleKey: circle, canvasKey: canvas, placementKey: placement,
+		},
+		Generalizations:   map[identity.Key]model_class.Generalization{genKey: gen},
+		ClassAssociations: map[identity.Key]model_class.Association{assocKey: assoc},
+	}
+	domain := model_domain.Domain{Key: domainKey, Name: "D G", Subdomains: map[identity.Key]model_domain.Subdomain{subdomainKey: subdomain}}
+	model := core.Model{Key: "test_graphviz_classes", Name: "Test", Domains: map[identity.Key]model_domain.Domain{domainKey: domain}}
+	return model, subdomainKey
+}
+
+func TestSetClassDiagrams(t *testing.T) {
+	defer func() { require.NoError(t, SetClassDiagrams("")) }()
+
+	require.NoError(t, SetClassDiagrams(ClassDiagramsGraphviz))
+	assert.True(t, graphvizClassDiagrams())
+	require.NoError(t, SetClassDiagrams(""))
+	assert.False(t, graphvizClassDiagrams())
+	assert.Error(t, SetClassDiagrams("plantuml"))
+}
+
+func TestGenerateClassesGraphvizContents(t *testing.T) {
+	model, subdomainKey := shapesGraphvizModel()
+	reqs := req_flat.NewRequirements(model)
+	reqs.PrepLookups()
+	classLookup, _ := reqs.ClassLookup()
+	var classes []model_class.Class
+	for _, class := range classLookup {
+		classes = append(classes, class)
+	}
+	generalizations, classes, associations := reqs.RegardingClasses(classes)
+
+	shapeKey := helper.Must(identity.NewClassKey(subdomainKey, "shape"))
+	dot, err := generateClassesGraphvizContents(reqs, generalizations, classes, associations, subdomainKey, &shapeKey)
+	require.NoError(t, err)
+
+	shapeID := mermaidNodeID("class", shapeKey)
+	circleID := mermaidNodeID("class", helper.Must(identity.NewClassKey(subdomainKey, "circle")))
+	canvasID := mermaidNodeID("class", helper.Must(identity.NewClassKey(subdomainKey, "canvas")))
+	placementID := mermaidNodeID("class", helper.Must(identity.NewClassKey(subdomainKey, "placement")))
+	linkID := mermaidNodeID("assoc", associations[0].Key)
+
+	// The generalization is grouped and drawn with the hollow arrowhead at the superclass.
+	assert.Contains(t, dot, "subgraph cluster_generalization_0 {\n        peripheries=0;\n        "+shapeID+" ")
+	assert.Contains(t, dot, "    "+shapeID+" -> "+circleID+" [dir=back, arrowtail=empty];\n")
+
+	// The focal, marked superclass; an attribute compartment; an escaped, linked name.
+	assert.Contains(t, dot, `<TABLE BORDER="3" COLOR="#9370DB" CELLBORDER="0" CELLSPACING="0" CELLPADDING="4" BGCOLOR="#FFEB3B">`)
+	assert.Contains(t, dot, `<TD BORDER="1" SIDES="T" ALIGN="LEFT" BALIGN="LEFT">radius</TD>`)
+	assert.Contains(t, dot, `<B>Canvas &amp; Co</B>`)
+	assert.Contains(t, dot, `URL="class-domain.dg.subdomain.sg.class.canvas.md"`)
+
+	// The association passes through a point the association class and comment hang from.
+	assert.Contains(t, dot, "    "+linkID+" [shape=point, width=0.06, xlabel=\"holds\"];\n")
+	assert.Contains(t, dot, "    "+canvasID+" -> "+linkID+" [arrowhead=none, taillabel=\"1\"];\n")
+	assert.Contains(t, dot, "    "+linkID+" -> "+shapeID+" [headlabel=\"*\"];\n")
+	assert.Contains(t, dot, "    "+placementID+" -> "+linkID+" [style=dashed, arrowhead=none, constraint=false];\n")
+	assert.Contains(t, dot, "[shape=note, fontsize=9, label=\"Shapes may overlap.\"];\n")
+	assert.Contains(t, dot, "[shape=note, fontsize=9, label=\"The drawing surface.\"];\n")
+
+	svg, err := renderGraphvizSVG(dot)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<svg")
+}
+
+func TestGenerateMdWithGraphvizClassDiagrams(t *testing.T) {
+	require.NoError(t, SetClassDiagrams(ClassDiagramsGraphviz))
+	defer func() { require.NoError(t, SetClassDiagrams("")) }()
+
+	model, subdomainKey := shapesGraphvizModel()
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	shapeKey := helper.Must(identity.NewClassKey(subdomainKey, "shape"))
+	classSVG := convertKeyToFilename("class", shapeKey.String(), "classes", ".svg")
+	domainSVG := convertKeyToFilename("domain", subdomainKey.ParentKey, "classes", ".svg")
+	for page, svg := range map[string]string{
+		convertKeyToFilename("class", shapeKey.String(), "", ".md"):       classSVG,
+		convertKeyToFilename("domain", subdomainKey.ParentKey, "", ".md"): domainSVG,
+	} {
+		body := string(writer.md[page])
+		assert.Contains(t, body, "[![Class diagram]("+svg+")]("+svg+")", page)
+		assert.NotContains(t, body, "classDiagram", page)
+		assert.Contains(t, string(writer.svg[svg]), "<svg", svg)
+	}
+}
@@ -2,6 +2,8 @@ package generate
 
 import (
 	"io"
+	"maps"
+	"slices"
 	"sort"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
@@ -9,6 +11,7 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/metrics"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
 
@@ -19,6 +22,7 @@ import (
 type ContentWriter interface {
 	WriteMarkdown(filename string, content []byte) error
 	WriteSVG(filename string, content []byte) error
+	WriteCSV(filename string, content []byte) error
 	WriteCSS(content []byte) error
 }
 
@@ -28,7 +32,14 @@ type ContentWriter interface {
 // there has its own page replaced with a red-bold error block; every other page
 // renders normally. Pass nil when there are no parse failures.
 func GenerateMdToWriter(parsedModel core.Model, writer ContentWriter, classErrors map[string]string) error { //nolint:revive // public API name
-	activeParseIssues = BuildParseIssueIndex(&parsedModel, classErrors)
+	return GenerateMdWithIssuesToWriter(parsedModel, writer, BuildParseIssueIndex(&parsedModel, classErrors, nil))
+}
+
+// GenerateMdWithIssuesToWriter is GenerateMdToWriter with the model's parse issues
+// already gathered, so callers that know the model's source files can have every
+// error shown with its position (see BuildParseIssueIndex).
+func GenerateMdWithIssuesToWriter(parsedModel core.Model, writer ContentWriter, issues *ParseIssueIndex) error { //nolint:revive // public API name
+	activeParseIssues = issues
 	defer func() { activeParseIssues = nil }()
 
 	// Create the flattened requirements from the model.
@@ -37,8 +48,12 @@ func GenerateMdToWriter(parsedModel core.Model, writer ContentWriter, classError
 	// Prepare the convenience structures inside.
 	reqs.PrepLookups()
 
+	// Link the terms of the glossary where details mention them.
+	activeGlossary = newGlossaryLinker(modelGlossaryEntries(reqs))
+	defer func() { activeGlossary = nil }()
+
 	// Generate files to writer.
-	return generateFilesToWriter(reqs, writer, classErrors)
+	return generateFilesToWriter(reqs, writer, issues.FileErrors)
 }
 
 // generateFilesToWriter generates all files and writes them to the ContentWriter.
@@ -83,6 +98,11 @@ func generateFilesToWriter(reqs *req_flat.Requirements, writer ContentWriter, cl
 		return err
 	}
 
+	// Generate the pages of custom templates
+	if err := generateCustomFilesToWriter(reqs, writer); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -154,6 +174,48 @@ func generateModelFilesToWriter(reqs *req_flat.Requirements, writer ContentWrite
 		return err
 	}
 
+	// Generate the data dictionary of the whole model.
+	entries := modelDictionaryEntries(model)
+	dictionaryMd, err := generateDictionaryMdContents(reqs, model, nil, nil, entries, "dictionary.csv")
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteMarkdown("dictionary.md", []byte(dictionaryMd)); err != nil {
+		return err
+	}
+	dictionaryCSV, err := generateDictionaryCSVContents(entries)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteCSV("dictionary.csv", dictionaryCSV); err != nil {
+		return err
+	}
+
+	// Generate the glossary of the whole model.
+	glossaryMd, err := generateGlossaryMdContents(reqs, modelGlossaryEntries(reqs))
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteMarkdown(_glossaryFilename, []byte(glossaryMd)); err != nil {
+		return err
+	}
+
+	// Generate the traceability matrices of the whole model.
+	traceabilityPages, err := generateTraceabilityMdContents(reqs)
+	if err != nil {
+		return err
+	}
+	for _, filename := range slices.Sorted(maps.Keys(traceabilityPages)) {
+		if err := writer.WriteMarkdown(filename, []byte(traceabilityPages[filename])); err != nil {
+			return err
+		}
+	}
+
+	// Generate the metrics dashboard of the whole model.
+	if err := writer.WriteMarkdown(metrics.MarkdownFilename, []byte(generateMetricsMdContents(model))); err != nil {
+		return err
+	}
+
 	return nil
 }
 
@@ -180,7 +242,7 @@ func generateDomainFilesToWriter(reqs *req_flat.Requirements, writer ContentWrit
 	domainLookup, _ := reqs.DomainLookup()
 
 	for _, domain := range domainLookup {
-		diagrams, err := buildDomainDiagrams(reqs, domain)
+		diagrams, err := buildDomainDiagrams(reqs, writer, domain)
 		if err != nil {
 			return err
 		}
@@ -200,6 +262,12 @@ func generateDomainFilesToWriter(reqs *req_flat.Requirements, writer ContentWrit
 				if err := writeSubdomainFactsToWriter(reqs, writer, domain, subdomain); err != nil {
 					return err
 				}
+				if err := writeSubdomainDictionaryToWriter(reqs, writer, domain, subdomain); err != nil {
+					return err
+				}
+				if err := writeSubdomainDataFlowToWriter(reqs, writer, subdomain); err != nil {
+					return err
+				}
 			}
 		}
 	}
@@ -207,8 +275,8 @@ func generateDomainFilesToWriter(reqs *req_flat.Requirements, writer ContentWrit
 	return nil
 }
 
-// buildDomainDiagrams generates the Mermaid diagrams needed for a domain page.
-func buildDomainDiagrams(reqs *req_flat.Requirements, domain model_domain.Domain) (domainDiagrams, error) {
+// buildDomainDiagrams generates the diagrams needed for a domain page.
+func buildDomainDiagrams(reqs *req_flat.Requirements, writer ContentWriter, domain model_domain.Domain) (domainDiagrams, error) {
 	hasMultipleSubdomains := len(domain.Subdomains) > 1
 
 	if hasMultipleSubdomains {
@@ -225,7 +293,7 @@ func buildDomainDiagrams(reqs *req_flat.Requirements, domain model_domain.Domain
 	if !ok && len(localClasses) > 0 {
 		return domainDiagrams{}, errors.Errorf("single-subdomain domain %q has classes but no subdomain", domain.Key.String())
 	}
-	classesDiagram, err := buildClassesDiagram(reqs, localClasses, viewerSubdomainKey)
+	classesDiagram, err := buildClassesDiagram(reqs, writer, localClasses, viewerSubdomainKey, convertKeyToFilename("domain", domain.Key.String(), "classes", ".svg"))
 	if err != nil {
 		return domainDiagrams{}, err
 	}
@@ -249,16 +317,16 @@ func gatherDomainClasses(domain model_domain.Domain) []model_class.Class {
 	return classes
 }
 
-// buildClassesDiagram generates a Mermaid class diagram for a set of classes.
-// Returns an empty string when there is nothing to render so the template can
-// omit the mermaid fence; an empty `classDiagram` block is a syntax error in
-// Mermaid 11+.
-func buildClassesDiagram(reqs *req_flat.Requirements, classes []model_class.Class, viewerSubdomainKey identity.Key) (string, error) {
+// buildClassesDiagram generates a class diagram for a set of classes, written to
+// svgFilename when drawn by Graphviz. Returns an empty string when there is nothing
+// to render so the template can omit the diagram; an empty `classDiagram` block is
+// a syntax error in Mermaid 11+.
+func buildClassesDiagram(reqs *req_flat.Requirements, writer ContentWriter, classes []model_class.Class, viewerSubdomainKey identity.Key, svgFilename string) (string, error) {
 	generalizations, allClasses, associations := reqs.RegardingClasses(classes)
 	if len(generalizations) == 0 && len(allClasses) == 0 && len(associations) == 0 {
 		return "", nil
 	}
-	return generateClassesMermaidContents(reqs, generalizations, allClasses, associations, viewerSubdomainKey, nil)
+	return generateClassesDiagram(reqs, writer, generalizations, allClasses, associations, viewerSubdomainKey, nil, svgFilename)
 }
 
 func soleSubdomainKey(domain model_domain.Domain) (identity.Key, bool) {
@@ -315,7 +383,7 @@ func generateSingleSubdomainFiles(reqs *req_flat.Requirements, writer ContentWri
 	for _, class := range subdomain.Classes {
 		subdomainClasses = append(subdomainClasses, class)
 	}
-	classesDiagram, err := buildClassesDiagram(reqs, subdomainClasses, subdomain.Key)
+	classesDiagram, err := buildClassesDiagram(reqs, writer, subdomainClasses, subdomain.Key, convertKeyToFilename("subdomain", subdomain.Key.String(), "classes", ".svg"))
 	if err != nil {
 		return err
 	}
@@ -339,6 +407,12 @@ func generateSingleSubdomainFiles(reqs *req_flat.Requirements, writer ContentWri
 	if err := writeSubdomainFactsToWriter(reqs, writer, domain, subdomain); err != nil {
 		return err
 	}
+	if err := writeSubdomainDictionaryToWriter(reqs, writer, domain, subdomain); err != nil {
+		return err
+	}
+	if err := writeSubdomainDataFlowToWriter(reqs, writer, subdomain); err != nil {
+		return err
+	}
 
 	return nil
 }
@@ -352,6 +426,24 @@ func writeSubdomainFactsToWriter(reqs *req_flat.Requirements, writer ContentWrit
 	return writer.WriteMarkdown(factsFilename, []byte(mdContents))
 }
 
+// writeSubdomainDictionaryToWriter writes the data dictionary page of a subdomain and its CSV export.
+func writeSubdomainDictionaryToWriter(reqs *req_flat.Requirements, writer ContentWriter, domain model_domain.Domain, subdomain model_domain.Subdomain) error {
+	entries := subdomainDictionaryEntries(domain, subdomain)
+	csvFilename := convertKeyToFilename("subdomain", subdomain.Key.String(), "dictionary", ".csv")
+	mdContents, err := generateDictionaryMdContents(reqs, reqs.Model, &domain, &subdomain, entries, csvFilename)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteMarkdown(convertKeyToFilename("subdomain", subdomain.Key.String(), "dictionary", ".md"), []byte(mdContents)); err != nil {
+		return err
+	}
+	csvContents, err := generateDictionaryCSVContents(entries)
+	if err != nil {
+		return err
+	}
+	return writer.WriteCSV(csvFilename, csvContents)
+}
+
 // buildSubdomainUseCasesDiagram generates a Mermaid use case diagram for a subdomain.
 // Returns an empty string when no use cases exist; rendering an empty subgraph
 // produces a Mermaid syntax error.
@@ -400,14 +492,14 @@ func writeClassMarkdownPage(
 	if err != nil {
 		return err
 	}
-	classesDiagram, err := generateClassesMermaidContents(reqs, generalizations, classes, associations, viewerSubdomainKey, &class.Key)
+	classesDiagram, err := generateClassesDiagram(reqs, writer, generalizations, classes, associations, viewerSubdomainKey, &class.Key, convertKeyToFilename("class", class.Key.String(), "classes", ".svg"))
 	if err != nil {
 		return err
 	}
 
 	stateDiagram := ""
 	if len(class.States) > 0 {
-		stateDiagram, err = generateClassStateMermaidContents(reqs, class)
+		stateDiagram, err = generateClassStateDiagram(reqs, writer, class)
 		if err != nil {
 			return err
 		}
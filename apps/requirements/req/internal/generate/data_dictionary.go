@@ -0,0 +1,307 @@
+package generate
+
+import (
+	"bytes"
+	"encoding/csv"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+
+	"github.com/pkg/errors"
+)
+
+// The kinds of data dictionary entries, in the order entries of the same term sort.
+const (
+	_dictionaryKindClass     = "class"
+	_dictionaryKindAttribute = "attribute"
+	_dictionaryKindEvent     = "event"
+	_dictionaryKindState     = "state"
+)
+
+var _dictionaryKindOrder = []string{_dictionaryKindClass, _dictionaryKindAttribute, _dictionaryKindEvent, _dictionaryKindState}
+
+// _dictionaryCSVHeader is the header row of a data dictionary export.
+var _dictionaryCSVHeader = []string{
+	"Term", "Kind", "Class", "Domain", "Subdomain", "Definition", "Data Type", "Span", "Unit",
+	"Values", "Nullable", "Indexes", "Derivation", "Invariants", "Parameters", "Key",
+}
+
+// DictionaryEntry is one term of a data dictionary: a class, or an attribute, event or
+// state of a class. Fields that do not apply to the kind of entry are empty.
+type DictionaryEntry struct {
+	Term       string
+	Kind       string
+	Key        string
+	Class      model_class.Class // The class, or the class the term belongs to.
+	Domain     model_domain.Domain
+	Subdomain  model_domain.Subdomain
+	Definition string
+	DataType   string   // The data type rules as written.
+	Span       string   // The bounds and precision of a span.
+	Unit       string   // The unit of a span.
+	Values     []string // The values of an enumeration.
+	Nullable   string
+	Indexes    []string
+	Derivation string
+	Invariants []string
+	Parameters []string // Event parameters with their data types.
+}
+
+// DictionaryLetter groups the entries whose terms start with one letter.
+type DictionaryLetter struct {
+	Letter  string
+	Entries []DictionaryEntry
+}
+
+// subdomainDictionaryEntries returns the alphabetized data dictionary of one subdomain.
+func subdomainDictionaryEntries(domain model_domain.Domain, subdomain model_domain.Subdomain) []DictionaryEntry {
+	var entries []DictionaryEntry
+	for _, class := range subdomain.Classes {
+		entries = append(entries, classDictionaryEntries(domain, subdomain, class)...)
+	}
+	sortDictionaryEntries(entries)
+	return entries
+}
+
+// modelDictionaryEntries returns the alphabetized data dictionary of the whole model.
+func modelDictionaryEntries(model core.Model) []DictionaryEntry {
+	var entries []DictionaryEntry
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for _, class := range subdomain.Classes {
+				entries = append(entries, classDictionaryEntries(domain, subdomain, class)...)
+			}
+		}
+	}
+	sortDictionaryEntries(entries)
+	return entries
+}
+
+func classDictionaryEntries(domain model_domain.Domain, subdomain model_domain.Subdomain, class model_class.Class) []DictionaryEntry {
+	newEntry := func(term, kind, key, definition string) DictionaryEntry {
+		return DictionaryEntry{
+			Term:       term,
+			Kind:       kind,
+			Key:        key,
+			Class:      class,
+			Domain:     domain,
+			Subdomain:  subdomain,
+			Definition: strings.TrimSpace(definition),
+		}
+	}
+
+	classEntry := newEntry(class.Name, _dictionaryKindClass, class.Key.String(), class.Details)
+	classEntry.Invariants = logicDescriptions(class.Invariants)
+	entries := []DictionaryEntry{classEntry}
+
+	for _, attr := range class.Attributes {
+		entry := newEntry(attr.Name, _dictionaryKindAttribute, attr.Key.String(), attr.Details)
+		entry.DataType = attr.DataTypeRules
+		entry.Span, entry.Unit, entry.Values = dictionaryConstraint(attr.DataType)
+		entry.Nullable = "no"
+		if attr.Nullable {
+			entry.Nullable = "yes"
+		}
+		for _, indexNum := range slices.Sorted(slices.Values(attr.IndexNums)) {
+			entry.Indexes = append(entry.Indexes, classIndexListingHeading(indexNum))
+		}
+		if attr.DerivationPolicy != nil {
+			entry.Derivation = logicDescription(*attr.DerivationPolicy)
+		}
+		entry.Invariants = logicDescriptions(attr.Invariants)
+		entries = append(entries, entry)
+	}
+
+	for _, event := range class.Events {
+		entry := newEntry(model_state.SystemEventDisplayName(event.Name), _dictionaryKindEvent, event.Key.String(), event.Details)
+		for _, name := range event.ParameterNames {
+			entry.Parameters = append(entry.Parameters, eventParameterDisplay(class, event, name))
+		}
+		entries = append(entries, entry)
+	}
+
+	for _, state := range class.States {
+		entries = append(entries, newEntry(state.Name, _dictionaryKindState, state.Key.String(), state.Details))
+	}
+
+	return entries
+}
+
+// dictionaryConstraint describes the span or enumeration a data type constrains its
+// values to, if any.
+func dictionaryConstraint(dataType *model_data_type.DataType) (span, unit string, values []string) {
+	if dataType == nil || dataType.Atomic == nil {
+		return "", "", nil
+	}
+	atomic := dataType.Atomic
+	switch atomic.ConstraintType {
+	case model_data_type.CONSTRAINT_TYPE_SPAN:
+		if atomic.Span == nil {
+			return "", "", nil
+		}
+		// The atomic form is "[lower .. higher] at precision units"; the units get their own column.
+		span = strings.TrimSpace(strings.TrimSuffix(atomic.String(), atomic.Span.Units))
+		return span, atomic.Span.Units, nil
+	case model_data_type.CONSTRAINT_TYPE_ENUMERATION:
+		for _, enum := range atomic.Enums {
+			values = append(values, enum.Value)
+		}
+		return "", "", values
+	}
+	return "", "", nil
+}
+
+// eventParameterDisplay is a parameter name with the data type of the action
+// parameter of the same name, such as "amount: [1 .. 500] at 1 unit".
+func eventParameterDisplay(class model_class.Class, event model_state.Event, name string) string {
+	transitionKeys := make([]string, 0, len(class.Transitions))
+	transitions := make(map[string]model_state.Transition, len(class.Transitions))
+	for key, transition := range class.Transitions {
+		transitionKeys = append(transitionKeys, key.String())
+		transitions[key.String()] = transition
+	}
+	slices.Sort(transitionKeys)
+	for _, key := range transitionKeys {
+		transition := transitions[key]
+		if transition.EventKey != event.Key || transition.ActionKey == nil {
+			continue
+		}
+		for _, param := range class.Actions[*transition.ActionKey].Parameters {
+			if param.Name == name && param.DataTypeRules != "" {
+				return name + ": " + param.DataTypeRules
+			}
+		}
+	}
+	return name
+}
+
+// logicDescription is the description of a logic with its specification, if any.
+func logicDescription(logic model_logic.Logic) string {
+	description := strings.TrimSpace(logic.Description)
+	spec := strings.TrimSpace(logic.Spec.Specification)
+	switch {
+	case spec == "":
+		return description
+	case description == "":
+		return spec
+	}
+	return description + " (" + spec + ")"
+}
+
+func logicDescriptions(logics []model_logic.Logic) []string {
+	var descriptions []string
+	for _, logic := range logics {
+		descriptions = append(descriptions, logicDescription(logic))
+	}
+	return descriptions
+}
+
+// dictionarySortTerm is the form of a term entries are alphabetized by, ignoring case
+// and surrounding marks such as the guillemets of «new».
+func dictionarySortTerm(term string) string {
+	return strings.ToLower(strings.TrimFunc(term, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}))
+}
+
+func sortDictionaryEntries(entries []DictionaryEntry) {
+	slices.SortFunc(entries, func(a, b DictionaryEntry) int {
+		if c := strings.Compare(dictionarySortTerm(a.Term), dictionarySortTerm(b.Term)); c != 0 {
+			return c
+		}
+		if c := slices.Index(_dictionaryKindOrder, a.Kind) - slices.Index(_dictionaryKindOrder, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+// dictionaryLetters groups sorted entries by the first letter of their terms. Terms
+// that do not start with a letter are grouped under "#".
+func dictionaryLetters(entries []DictionaryEntry) []DictionaryLetter {
+	var letters []DictionaryLetter
+	for _, entry := range entries {
+		letter := "#"
+		if runes := []rune(dictionarySortTerm(entry.Term)); len(runes) > 0 && unicode.IsLetter(runes[0]) {
+			letter = string(unicode.ToUpper(runes[0]))
+		}
+		if len(letters) == 0 || letters[len(letters)-1].Letter != letter {
+			letters = append(letters, DictionaryLetter{Letter: letter})
+		}
+		letters[len(letters)-1].Entries = append(letters[len(letters)-1].Entries, entry)
+	}
+	return letters
+}
+
+// generateDictionaryMdContents renders a data dictionary page. Pass a nil domain and
+// subdomain for the dictionary of the whole model, which names the subdomain of each entry.
+func generateDictionaryMdContents(reqs *req_flat.Requirements, model core.Model, domain *model_domain.Domain, subdomain *model_domain.Subdomain, entries []DictionaryEntry, csvFilename string) (contents string, err error) {
+	contents, err = generateFromTemplate(_dataDictionaryMdTemplate, struct {
+		Reqs        *req_flat.Requirements
+		Model       core.Model
+		ModelWide   bool
+		Domain      *model_domain.Domain
+		Subdomain   *model_domain.Subdomain
+		Letters     []DictionaryLetter
+		CSVFilename string
+	}{
+		Reqs:        reqs,
+		Model:       model,
+		ModelWide:   subdomain == nil,
+		Domain:      domain,
+		Subdomain:   subdomain,
+		Letters:     dictionaryLetters(entries),
+		CSVFilename: csvFilename,
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return contents, nil
+}
+
+// generateDictionaryCSVContents renders a data dictionary as CSV, one row per entry.
+// Lists within a cell are separated by semicolons.
+func generateDictionaryCSVContents(entries []DictionaryEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+	if err := w.Write(_dictionaryCSVHeader); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	for _, entry := range entries {
+		row := []string{
+			entry.Term,
+			entry.Kind,
+			entry.Class.Name,
+			entry.Domain.Name,
+			entry.Subdomain.Name,
+			entry.Definition,
+			entry.DataType,
+			entry.Span,
+			entry.Unit,
+			strings.Join(entry.Values, "; "),
+			entry.Nullable,
+			strings.Join(entry.Indexes, "; "),
+			entry.Derivation,
+			strings.Join(entry.Invariants, "; "),
+			strings.Join(entry.Parameters, "; "),
+			entry.Key,
+		}
+		if err := w.Write(row); err != nil {
+			return nil, errors.WithStack(err)
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,130 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/modelfacts"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dictionaryShop is a subdomain with an order class that has one of each kind of entry.
+func dictionaryShop(t *testing.T) (model_domain.Domain, model_domain.Subdomain) {
+	t.Helper()
+	domainKey := helper.Must(identity.NewDomainKey("shop"))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "orders"))
+	orderKey := helper.Must(identity.NewClassKey(subdomainKey, "order"))
+	payEventKey := helper.Must(identity.NewEventKey(orderKey, "pay"))
+	newEventKey := helper.Must(identity.NewEventKey(orderKey, "_new"))
+	payKey := helper.Must(identity.NewActionKey(orderKey, "pay"))
+	openKey := helper.Must(identity.NewStateKey(orderKey, "open"))
+	totalKey := helper.Must(identity.NewAttributeKey(orderKey, "total"))
+	statusKey := helper.Must(identity.NewAttributeKey(orderKey, "status"))
+	spec := helper.Must(logic_spec.NewExpressionSpec("tla_plus", "self.total >= 0", nil))
+
+	order := model_class.NewClass(orderKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Order", Details: "A basket being bought.\n\nMore detail."})
+	order.Attributes = []model_class.Attribute{
+		helper.Must(model_class.NewAttribute(totalKey, model_class.AttributeDetails{Name: "Total", Details: "What is owed."}, "[0 .. 1000) at 0.01 dollar",
+			&model_logic.Logic{Description: "Sum of the lines"}, false, model_class.AttributeAnnotations{IndexNums: []uint{2, 0}})),
+		helper.Must(model_class.NewAttribute(statusKey, model_class.AttributeDetails{Name: "status"}, "enum of open, paid", nil, true, model_class.AttributeAnnotations{})),
+	}
+	order.Attributes[0].Invariants = []model_logic.Logic{{Description: "Never negative", Spec: spec}}
+	order.Events = map[identity.Key]model_state.Event{
+		payEventKey: model_state.NewEvent(payEventKey, "pay", "Money arrives.", []string{"amount"}),
+		newEventKey: model_state.NewEvent(newEventKey, "_new", "", nil),
+	}
+	amount := helper.Must(model_state.NewParameter(payKey, "amount", "[1 .. 500] at 1 unit", false))
+	order.Actions = map[identity.Key]model_state.Action{
+		payKey: model_state.NewAction(payKey, model_state.ActionDetails{Name: "Pay"}, nil, nil, nil, []model_state.Parameter{amount}),
+	}
+	order.States = map[identity.Key]model_state.State{openKey: model_state.NewState(openKey, "Open", "Waiting for payment.", "")}
+	payTransitionKey := helper.Must(identity.NewTransitionKey(orderKey, "open", "pay", "", "pay", "open"))
+	order.Transitions = map[identity.Key]model_state.Transition{
+		payTransitionKey: model_state.NewTransition(payTransitionKey, payEventKey,
+			model_state.TransitionStateKeys{FromStateKey: &openKey, ToStateKey: &openKey},
+			model_state.TransitionLogicKeys{ActionKey: &payKey}, ""),
+	}
+
+	subdomain := model_domain.Subdomain{Key: subdomainKey, Name: "Orders", Classes: map[identity.Key]model_class.Class{orderKey: order}}
+	domain := model_domain.Domain{Key: domainKey, Name: "Shop", Subdomains: map[identity.Key]model_domain.Subdomain{subdomainKey: subdomain}}
+	return domain, subdomain
+}
+
+func TestSubdomainDictionaryEntries(t *testing.T) {
+	domain, subdomain := dictionaryShop(t)
+	entries := subdomainDictionaryEntries(domain, subdomain)
+
+	var terms []string
+	for _, entry := range entries {
+		terms = append(terms, entry.Term+" "+entry.Kind)
+	}
+	assert.Equal(t, []string{"«new» event", "Open state", "Order class", "pay event", "status attribute", "Total attribute"}, terms)
+
+	assert.Equal(t, []DictionaryLetter{
+		{Letter: "N", Entries: entries[0:1]},
+		{Letter: "O", Entries: entries[1:3]},
+		{Letter: "P", Entries: entries[3:4]},
+		{Letter: "S", Entries: entries[4:5]},
+		{Letter: "T", Entries: entries[5:6]},
+	}, dictionaryLetters(entries))
+
+	csvContents, err := generateDictionaryCSVContents(entries)
+	require.NoError(t, err)
+	assert.Equal(t, `Term,Kind,Class,Domain,Subdomain,Definition,Data Type,Span,Unit,Values,Nullable,Indexes,Derivation,Invariants,Parameters,Key
+«new»,event,Order,Shop,Orders,,,,,,,,,,,domain/shop/subdomain/orders/class/order/event/_new
+Open,state,Order,Shop,Orders,Waiting for payment.,,,,,,,,,,domain/shop/subdomain/orders/class/order/state/open
+Order,class,Order,Shop,Orders,"A basket being bought.
+
+More detail.",,,,,,,,,,domain/shop/subdomain/orders/class/order
+pay,event,Order,Shop,Orders,Money arrives.,,,,,,,,,amount: [1 .. 500] at 1 unit,domain/shop/subdomain/orders/class/order/event/pay
+status,attribute,Order,Shop,Orders,,"enum of open, paid",,,open; paid,yes,,,,,domain/shop/subdomain/orders/class/order/attribute/status
+Total,attribute,Order,Shop,Orders,What is owed.,[0 .. 1000) at 0.01 dollar,[0 .. 1000) at 0.01,dollar,,no,key; index 2,Sum of the lines,Never negative (self.total >= 0),,domain/shop/subdomain/orders/class/order/attribute/total
+`, string(csvContents))
+}
+
+func TestGenerateDataDictionaryPages(t *testing.T) {
+	model := test_helper.GetTestModel()
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	modelText := string(writer.md["model.md"])
+	assert.Contains(t, modelText, "[Data dictionary](dictionary.md)")
+	dictionaryText := string(writer.md["dictionary.md"])
+	assert.Contains(t, dictionaryText, "# Data Dictionary — "+model.Name)
+	assert.Contains(t, dictionaryText, "[CSV](dictionary.csv)")
+	assert.Contains(t, writer.csv, "dictionary.csv")
+
+	subdomain, err := modelfacts.FindSubdomain(model, modelfacts.SubdomainPath{
+		DomainSubKey:    "domain_a",
+		SubdomainSubKey: "subdomain_a",
+	})
+	require.NoError(t, err)
+	dictionaryFile := convertKeyToFilename("subdomain", subdomain.Key.String(), "dictionary", ".md")
+	csvFile := convertKeyToFilename("subdomain", subdomain.Key.String(), "dictionary", ".csv")
+	subdomainText := string(writer.md[convertKeyToFilename("subdomain", subdomain.Key.String(), "", ".md")])
+	assert.Contains(t, subdomainText, "[Data dictionary]("+dictionaryFile+")")
+	assert.Contains(t, writer.csv, csvFile)
+
+	// Every class of the subdomain is listed with a link to its page.
+	subdomainDictionary := string(writer.md[dictionaryFile])
+	assert.Contains(t, subdomainDictionary, "# Data Dictionary — "+subdomain.Name)
+	for _, class := range subdomain.Classes {
+		assert.Contains(t, subdomainDictionary, "**["+class.Name+"]("+convertKeyToFilename("class", class.Key.String(), "", ".md")+")** *(class)*")
+	}
+	assert.Less(t, strings.Index(subdomainDictionary, "## C"), strings.Index(subdomainDictionary, "## O"))
+
+	domain, single, ok := findSingleSubdomainDomain(model)
+	require.True(t, ok, "test model should include a single-subdomain domain")
+	singleDictionaryFile := convertKeyToFilename("subdomain", single.Key.String(), "dictionary", ".md")
+	assert.Contains(t, string(writer.md[convertKeyToFilename("domain", domain.Key.String(), "", ".md")]), "[Data dictionary]("+singleDictionaryFile+")")
+	assert.Contains(t, writer.md, singleDictionaryFile)
+}
@@ -0,0 +1,226 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/model_bridge"
+)
+
+// The kinds of data flow edges, which are also their labels.
+const (
+	_dataFlowTriggers = "triggers" // An event triggers an action.
+	_dataFlowReads    = "reads"    // An action reads an attribute.
+	_dataFlowWrites   = "writes"   // An action writes an attribute.
+	_dataFlowDerives  = "derives"  // A derived attribute is computed from an attribute.
+	_dataFlowNotifies = "notifies" // An action sends an event to, or calls an action of, a peer class.
+)
+
+// dataFlowEdge is a directed edge between two node ids of a data flow diagram.
+type dataFlowEdge struct {
+	From string
+	To   string
+	Kind string
+}
+
+// dataFlowPeer is a class outside the subdomain that an action of the subdomain notifies.
+type dataFlowPeer struct {
+	Key  identity.Key
+	Name string
+}
+
+// dataFlowGraph is the attribute-level data flow of the classes of one subdomain.
+type dataFlowGraph struct {
+	Classes []model_class.Class // Sorted by name.
+	Peers   []dataFlowPeer      // Sorted by key.
+	Edges   []dataFlowEdge      // Sorted and without duplicates.
+}
+
+// buildSubdomainDataFlow connects the events and actions of each class in a subdomain to
+// the attributes they read and write, derived attributes to their sources, and actions to
+// the peer classes they notify. It relies on the lowered expressions of the model's logic;
+// logic that was not lowered contributes no edges.
+func buildSubdomainDataFlow(reqs *req_flat.Requirements, subdomain model_domain.Subdomain) dataFlowGraph {
+	var graph dataFlowGraph
+	for _, class := range subdomain.Classes {
+		graph.Classes = append(graph.Classes, class)
+	}
+	graph.Classes = classesSortedByName(graph.Classes)
+
+	// Every node in the subdomain, so edges are only drawn to nodes that are drawn.
+	nodes := map[string]bool{}
+	for _, class := range graph.Classes {
+		for _, attr := range class.Attributes {
+			nodes[attr.Key.String()] = true
+		}
+		for key := range class.Events {
+			nodes[key.String()] = true
+		}
+		for key := range class.Actions {
+			nodes[key.String()] = true
+		}
+	}
+
+	classLookup, _ := reqs.ClassLookup()
+	peers := map[string]dataFlowPeer{}
+	edges := map[dataFlowEdge]bool{}
+	addEdge := func(from, to, kind string) {
+		if nodes[from] && (nodes[to] || peers[to].Name != "") {
+			edges[dataFlowEdge{From: from, To: to, Kind: kind}] = true
+		}
+	}
+
+	for _, class := range graph.Classes {
+		attributesByField := make(map[string]identity.Key, len(class.Attributes))
+		for _, attr := range class.Attributes {
+			attributesByField[model_class.AttributeTLAFieldName(attr.Name)] = attr.Key
+			if attr.DerivationPolicy != nil {
+				for source := range model_bridge.CollectAttributeRefs(attr.DerivationPolicy.Spec.Expression) {
+					addEdge(source.String(), attr.Key.String(), _dataFlowDerives)
+				}
+			}
+		}
+
+		for _, transition := range class.Transitions {
+			if transition.ActionKey != nil {
+				addEdge(transition.EventKey.String(), transition.ActionKey.String(), _dataFlowTriggers)
+			}
+		}
+
+		for actionKey, action := range class.Actions {
+			actionID := actionKey.String()
+			for _, logic := range slices.Concat(action.Requires, action.Guarantees, action.SafetyRules) {
+				for attrKey := range model_bridge.CollectAttributeRefs(logic.Spec.Expression) {
+					addEdge(attrKey.String(), actionID, _dataFlowReads)
+				}
+			}
+			for _, guarantee := range action.Guarantees {
+				if attrKey, ok := attributesByField[guarantee.Target]; ok && guarantee.Type == model_logic.LogicTypeStateChange {
+					addEdge(actionID, attrKey.String(), _dataFlowWrites)
+				}
+				for _, target := range dataFlowNotifiedKeys(class.Key, guarantee) {
+					if nodes[target.String()] {
+						addEdge(actionID, target.String(), _dataFlowNotifies)
+						continue
+					}
+					// The peer is outside the subdomain; point at its class instead.
+					peerKey := target.GetParentKey()
+					peerClass, found := classLookup[peerKey]
+					if !found {
+						continue
+					}
+					peers[peerKey] = dataFlowPeer{Key: peerClass.Key, Name: peerClass.Name}
+					addEdge(actionID, peerKey, _dataFlowNotifies)
+				}
+			}
+		}
+	}
+
+	for _, peer := range peers {
+		graph.Peers = append(graph.Peers, peer)
+	}
+	slices.SortFunc(graph.Peers, func(a, b dataFlowPeer) int {
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+	for edge := range edges {
+		graph.Edges = append(graph.Edges, edge)
+	}
+	slices.SortFunc(graph.Edges, func(a, b dataFlowEdge) int {
+		return strings.Compare(a.From+"\x00"+a.To+"\x00"+a.Kind, b.From+"\x00"+b.To+"\x00"+b.Kind)
+	})
+	return graph
+}
+
+// dataFlowNotifiedKeys returns the events and actions of other classes that a guarantee
+// sends or calls, including the destroy event of a destroy guarantee.
+func dataFlowNotifiedKeys(classKey identity.Key, guarantee model_logic.Logic) []identity.Key {
+	var keys []identity.Key
+	visit := func(expr me.Expression) bool {
+		var key identity.Key
+		switch call := expr.(type) {
+		case *me.EventCall:
+			key = call.EventKey
+		case *me.ActionCall:
+			key = call.ActionKey
+		default:
+			return true
+		}
+		if key.GetParentKey() != classKey.String() {
+			keys = append(keys, key)
+		}
+		return true
+	}
+	me.Walk(guarantee.Spec.Expression, visit)
+	me.Walk(guarantee.DestroyEventSpec.Expression, visit)
+	return keys
+}
+
+// generateDataFlowDot renders a data flow graph as DOT. Each class is a cluster linked to
+// its class page holding its attributes, events and actions; derived attributes are
+// dashed and prefixed with "/" as in UML.
+func generateDataFlowDot(graph dataFlowGraph) string {
+	var b strings.Builder
+	b.WriteString("digraph DataFlow {\n")
+	b.WriteString("    graph [rankdir=LR, fontname=\"Sans-Serif\", fontsize=11];\n")
+	b.WriteString("    node [fontname=\"Sans-Serif\", fontsize=10];\n")
+	b.WriteString("    edge [fontname=\"Sans-Serif\", fontsize=9];\n")
+
+	for i, class := range graph.Classes {
+		classURL := convertKeyToFilename("class", class.Key.String(), "", ".md")
+		fmt.Fprintf(&b, "    subgraph cluster_%d {\n", i)
+		fmt.Fprintf(&b, "        label=%s; URL=%s; target=\"_top\"; style=rounded;\n", dotQuote(class.Name), dotQuote(classURL))
+		for _, attr := range class.Attributes {
+			if attr.DerivationPolicy != nil {
+				fmt.Fprintf(&b, "        %s [label=%s, shape=ellipse, style=dashed];\n", dotQuote(attr.Key.String()), dotQuote("/"+attr.Name))
+				continue
+			}
+			fmt.Fprintf(&b, "        %s [label=%s, shape=ellipse];\n", dotQuote(attr.Key.String()), dotQuote(attr.Name))
+		}
+		for _, key := range identity.SortedKeys(class.Events) {
+			name := model_state.SystemEventDisplayName(class.Events[key].Name)
+			fmt.Fprintf(&b, "        %s [label=%s, shape=cds];\n", dotQuote(key.String()), dotQuote(name))
+		}
+		for _, key := range identity.SortedKeys(class.Actions) {
+			fmt.Fprintf(&b, "        %s [label=%s, shape=box, style=rounded];\n", dotQuote(key.String()), dotQuote(class.Actions[key].Name))
+		}
+		b.WriteString("    }\n")
+	}
+
+	for _, peer := range graph.Peers {
+		peerURL := convertKeyToFilename("class", peer.Key.String(), "", ".md")
+		fmt.Fprintf(&b, "    %s [label=%s, shape=box, style=dashed, URL=%s, target=\"_top\"];\n", dotQuote(peer.Key.String()), dotQuote(peer.Name), dotQuote(peerURL))
+	}
+
+	for _, edge := range graph.Edges {
+		style := ""
+		switch edge.Kind {
+		case _dataFlowReads:
+			style = ", style=dashed"
+		case _dataFlowDerives:
+			style = ", style=dotted"
+		case _dataFlowNotifies:
+			style = ", style=bold"
+		}
+		fmt.Fprintf(&b, "    %s -> %s [label=%s%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Kind), style)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeSubdomainDataFlowToWriter writes the data flow diagram of a subdomain as SVG.
+func writeSubdomainDataFlowToWriter(reqs *req_flat.Requirements, writer ContentWriter, subdomain model_domain.Subdomain) error {
+	svg, err := renderGraphvizSVG(generateDataFlowDot(buildSubdomainDataFlow(reqs, subdomain)))
+	if err != nil {
+		return err
+	}
+	return writer.WriteSVG(convertKeyToFilename("subdomain", subdomain.Key.String(), "dataflow", ".svg"), svg)
+}
@@ -0,0 +1,129 @@
+package generate
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// dataFlowShop is a model where paying an order reads and writes its attributes, a derived
+// attribute averages them, and the payment is recorded in a ledger of another subdomain.
+func dataFlowShop(t *testing.T) (*req_flat.Requirements, model_domain.Subdomain) {
+	t.Helper()
+	domainKey := helper.Must(identity.NewDomainKey("shop"))
+	ordersKey := helper.Must(identity.NewSubdomainKey(domainKey, "orders"))
+	accountsKey := helper.Must(identity.NewSubdomainKey(domainKey, "accounts"))
+	orderKey := helper.Must(identity.NewClassKey(ordersKey, "order"))
+	ledgerKey := helper.Must(identity.NewClassKey(accountsKey, "ledger"))
+	totalKey := helper.Must(identity.NewAttributeKey(orderKey, "total"))
+	countKey := helper.Must(identity.NewAttributeKey(orderKey, "count"))
+	averageKey := helper.Must(identity.NewAttributeKey(orderKey, "average"))
+	payEventKey := helper.Must(identity.NewEventKey(orderKey, "pay"))
+	payKey := helper.Must(identity.NewActionKey(orderKey, "pay"))
+	openKey := helper.Must(identity.NewStateKey(orderKey, "open"))
+	recordKey := helper.Must(identity.NewEventKey(ledgerKey, "record"))
+
+	lowered := func(logicType, target string, expr me.Expression) model_logic.Logic {
+		return model_logic.Logic{Type: logicType, Target: target, Spec: logic_spec.ExpressionSpec{Expression: expr}}
+	}
+	average := lowered(model_logic.LogicTypeValue, "", &me.BinaryArith{Op: me.ArithDiv, Left: &me.AttributeRef{AttributeKey: totalKey}, Right: &me.AttributeRef{AttributeKey: countKey}})
+
+	order := model_class.NewClass(orderKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Order"})
+	order.Attributes = []model_class.Attribute{
+		helper.Must(model_class.NewAttribute(totalKey, model_class.AttributeDetails{Name: "total"}, "", nil, false, model_class.AttributeAnnotations{})),
+		helper.Must(model_class.NewAttribute(countKey, model_class.AttributeDetails{Name: "count"}, "", nil, false, model_class.AttributeAnnotations{})),
+		helper.Must(model_class.NewAttribute(averageKey, model_class.AttributeDetails{Name: "average"}, "", &average, false, model_class.AttributeAnnotations{})),
+	}
+	order.Events = map[identity.Key]model_state.Event{payEventKey: model_state.NewEvent(payEventKey, "pay", "", []string{"amount"})}
+	pay := model_state.NewAction(payKey, model_state.ActionDetails{Name: "Pay"}, nil, nil, nil, nil)
+	pay.Requires = []model_logic.Logic{
+		lowered(model_logic.LogicTypeAssessment, "", &me.Compare{Op: me.CompareGt, Left: &me.AttributeRef{AttributeKey: countKey}, Right: &me.IntLiteral{}}),
+	}
+	pay.Guarantees = []model_logic.Logic{
+		lowered(model_logic.LogicTypeStateChange, "total", &me.BinaryArith{Op: me.ArithAdd, Left: &me.AttributeRef{AttributeKey: totalKey}, Right: &me.LocalVar{Name: "amount"}}),
+		lowered(model_logic.LogicTypeStateChange, "ledger", &me.EventCall{EventKey: recordKey}),
+	}
+	order.Actions = map[identity.Key]model_state.Action{payKey: pay}
+	payTransitionKey := helper.Must(identity.NewTransitionKey(orderKey, "open", "pay", "", "pay", "open"))
+	order.Transitions = map[identity.Key]model_state.Transition{
+		payTransitionKey: model_state.NewTransition(payTransitionKey, payEventKey,
+			model_state.TransitionStateKeys{FromStateKey: &openKey, ToStateKey: &openKey},
+			model_state.TransitionLogicKeys{ActionKey: &payKey}, ""),
+	}
+
+	ledger := model_class.NewClass(ledgerKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Ledger"})
+	ledger.Events = map[identity.Key]model_state.Event{recordKey: model_state.NewEvent(recordKey, "record", "", nil)}
+
+	orders := model_domain.NewSubdomain(ordersKey, "Orders", "", "", "")
+	orders.Classes = map[identity.Key]model_class.Class{orderKey: order}
+	accounts := model_domain.NewSubdomain(accountsKey, "Accounts", "", "", "")
+	accounts.Classes = map[identity.Key]model_class.Class{ledgerKey: ledger}
+	domain := model_domain.NewDomain(domainKey, "Shop", "", "", false, "")
+	domain.Subdomains = map[identity.Key]model_domain.Subdomain{ordersKey: orders, accountsKey: accounts}
+	model := core.NewModel("test", core.ModelDetails{Name: "Test"}, "", nil, nil, nil)
+	model.Domains = map[identity.Key]model_domain.Domain{domainKey: domain}
+
+	reqs := req_flat.NewRequirements(model)
+	reqs.PrepLookups()
+	return reqs, orders
+}
+
+func TestBuildSubdomainDataFlow(t *testing.T) {
+	reqs, orders := dataFlowShop(t)
+	graph := buildSubdomainDataFlow(reqs, orders)
+
+	const order = "domain/shop/subdomain/orders/class/order"
+	assert.Equal(t, []dataFlowEdge{
+		{From: order + "/action/pay", To: "domain/shop/subdomain/accounts/class/ledger", Kind: _dataFlowNotifies},
+		{From: order + "/action/pay", To: order + "/attribute/total", Kind: _dataFlowWrites},
+		{From: order + "/attribute/count", To: order + "/action/pay", Kind: _dataFlowReads},
+		{From: order + "/attribute/count", To: order + "/attribute/average", Kind: _dataFlowDerives},
+		{From: order + "/attribute/total", To: order + "/action/pay", Kind: _dataFlowReads},
+		{From: order + "/attribute/total", To: order + "/attribute/average", Kind: _dataFlowDerives},
+		{From: order + "/event/pay", To: order + "/action/pay", Kind: _dataFlowTriggers},
+	}, graph.Edges)
+	require.Len(t, graph.Peers, 1)
+	assert.Equal(t, "Ledger", graph.Peers[0].Name)
+
+	dot := generateDataFlowDot(graph)
+	assert.Contains(t, dot, `label="Order"; URL="class-domain.shop.subdomain.orders.class.order.md"`)
+	assert.Contains(t, dot, `"`+order+`/attribute/average" [label="/average", shape=ellipse, style=dashed];`)
+	assert.Contains(t, dot, `"`+order+`/attribute/total" -> "`+order+`/action/pay" [label="reads", style=dashed];`)
+	assert.Contains(t, dot, `"domain/shop/subdomain/accounts/class/ledger" [label="Ledger", shape=box, style=dashed, URL="class-domain.shop.subdomain.accounts.class.ledger.md", target="_top"];`)
+
+	svg, err := renderGraphvizSVG(dot)
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(strings.TrimSpace(string(svg)), "<?xml"))
+	assert.Contains(t, string(svg), "class-domain.shop.subdomain.orders.class.order.md")
+}
+
+func TestGenerateDataFlowDiagrams(t *testing.T) {
+	model := test_helper.GetTestModel()
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			svgFile := convertKeyToFilename("subdomain", subdomain.Key.String(), "dataflow", ".svg")
+			assert.Contains(t, writer.svg, svgFile)
+			pageFile := convertKeyToFilename("subdomain", subdomain.Key.String(), "", ".md")
+			if len(domain.Subdomains) == 1 {
+				pageFile = convertKeyToFilename("domain", domain.Key.String(), "", ".md")
+			}
+			assert.Contains(t, string(writer.md[pageFile]), "[Data flow]("+svgFile+")")
+		}
+	}
+}
@@ -94,7 +94,7 @@ func diagramClassesOutsideDomain(reqs *req_flat.Requirements, domain model_domai
 // domainDiagrams holds the Mermaid diagram strings for a domain page.
 type domainDiagrams struct {
 	SubdomainsDiagram string
-	ClassesDiagram    string
+	ClassesDiagram    string // The SVG filename when class diagrams are drawn by Graphviz.
 	UseCasesDiagram   string
 }
 
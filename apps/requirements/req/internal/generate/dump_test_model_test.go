@@ -13,6 +13,7 @@ type discardWriter struct{}
 
 func (d discardWriter) WriteMarkdown(_ string, _ []byte) error { return nil }
 func (d discardWriter) WriteSVG(_ string, _ []byte) error      { return nil }
+func (d discardWriter) WriteCSV(_ string, _ []byte) error      { return nil }
 func (d discardWriter) WriteCSS(_ []byte) error                { return nil }
 
 // TestGenerateTemplates exercises all templates with the test model to catch
@@ -30,7 +31,7 @@ func TestDumpTestModel(t *testing.T) {
 
 	// Write to the dump folder within this package for manual inspection.
 	outputDir := "/workspaces/glemzurg/test_model_dump"
-	err := GenerateMdFromModel(outputDir, model, nil)
+	err := GenerateMdFromModel(outputDir, model, nil, nil)
 	require.NoError(t, err, "GenerateMdFromModel should succeed")
 
 	fmt.Printf("Model written to: %s\n", outputDir)
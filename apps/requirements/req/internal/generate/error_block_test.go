@@ -10,16 +10,27 @@ import (
 
 // collectWriter is a ContentWriter that keeps generated files in memory.
 type collectWriter struct {
-	md map[string][]byte
+	md  map[string][]byte
+	svg map[string][]byte
+	csv map[string][]byte
 }
 
-func newCollectWriter() *collectWriter { return &collectWriter{md: map[string][]byte{}} }
+func newCollectWriter() *collectWriter {
+	return &collectWriter{md: map[string][]byte{}, svg: map[string][]byte{}, csv: map[string][]byte{}}
+}
 func (c *collectWriter) WriteMarkdown(f string, b []byte) error {
 	c.md[f] = b
 	return nil
 }
-func (c *collectWriter) WriteSVG(string, []byte) error { return nil }
-func (c *collectWriter) WriteCSS([]byte) error         { return nil }
+func (c *collectWriter) WriteCSV(f string, b []byte) error {
+	c.csv[f] = b
+	return nil
+}
+func (c *collectWriter) WriteSVG(f string, b []byte) error {
+	c.svg[f] = b
+	return nil
+}
+func (c *collectWriter) WriteCSS([]byte) error { return nil }
 
 func TestGenerateClassErrorBlock(t *testing.T) {
 	model := test_helper.GetTestModel()
@@ -28,6 +28,11 @@ func (fw *FileWriter) WriteSVG(filename string, content []byte) error {
 	return fw.writeFile(filename, content)
 }
 
+// WriteCSV writes CSV content to a file.
+func (fw *FileWriter) WriteCSV(filename string, content []byte) error {
+	return fw.writeFile(filename, content)
+}
+
 // WriteCSS writes CSS content to style.css.
 func (fw *FileWriter) WriteCSS(content []byte) error {
 	return fw.writeFile("style.css", content)
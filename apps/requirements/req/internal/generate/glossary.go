@@ -0,0 +1,465 @@
+package generate
+
+import (
+	"maps"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// _glossaryFilename is the page of the glossary, which details link their terms to.
+const _glossaryFilename = "glossary.md"
+
+// The kinds of glossary entries, in the order entries of the same term sort.
+var _glossaryKindOrder = []string{
+	"domain", "subdomain", "actor", "class", "attribute", "state", "event", "action", "query",
+	"use case", "global function", "named set",
+}
+
+// _glossaryFoldedKinds are the kinds whose terms are linked in details whatever their case,
+// since prose names actors and classes in lower case. Other terms are linked only as written,
+// so a state named New doesn't link every "new".
+var _glossaryFoldedKinds = []string{"actor", "class"}
+
+// _glossaryProtected matches the parts of a markdown line whose text is never linked: code
+// spans, links and images, HTML tags and bare URLs.
+var _glossaryProtected = regexp.MustCompile("`+[^`]*`+|!?\\[[^\\]]*\\]\\([^)]*\\)|<[^>]*>|https?://\\S+")
+
+// activeGlossary is set for the duration of GenerateMdWithIssuesToWriter so the
+// glossary_links template function can link terms in details.
+var activeGlossary *glossaryLinker
+
+// GlossaryEntry is one named element of a model in the glossary.
+type GlossaryEntry struct {
+	Term         string
+	Kind         string
+	Key          string
+	Anchor       string // The anchor of the term, on the first of the entries of the same term.
+	Page         string // The page the element is described on.
+	Parent       string // The name of the element it is part of, if any.
+	ParentPage   string
+	Place        string // The domain and subdomain it is in, if any.
+	Summary      string // The first sentence of its details.
+	ReferencedBy []GlossaryReference
+}
+
+// GlossaryReference is an element whose logic or scenario steps refer to a glossary entry.
+type GlossaryReference struct {
+	Name   string
+	Kind   string
+	Parent string // The name of the element it is part of, if any.
+	Page   string
+}
+
+// GlossaryLetter groups the entries whose terms start with one letter.
+type GlossaryLetter struct {
+	Letter  string
+	Anchor  string
+	Entries []GlossaryEntry
+}
+
+// modelGlossaryEntries returns the alphabetized glossary of a model, each entry with what
+// refers to it.
+func modelGlossaryEntries(reqs *req_flat.Requirements) []GlossaryEntry {
+	model := reqs.Model
+	var entries []GlossaryEntry
+	add := func(entry GlossaryEntry, details string) {
+		entry.Summary = strings.Join(strings.Fields(firstSentence(firstMdParagraph(details))), " ")
+		entries = append(entries, entry)
+	}
+
+	for _, actor := range model.Actors {
+		add(GlossaryEntry{Term: actor.Name, Kind: "actor", Key: actor.Key.String(), Page: convertKeyToFilename("actor", actor.Key.String(), "", ".md")}, actor.Details)
+	}
+	for _, function := range model.GlobalFunctions {
+		add(GlossaryEntry{Term: function.Name, Kind: "global function", Key: function.Key.String(), Page: "model.md"}, function.Logic.Description)
+	}
+	for _, set := range model.NamedSets {
+		add(GlossaryEntry{Term: set.Name, Kind: "named set", Key: set.Key.String(), Page: "model.md"}, set.Description)
+	}
+	for _, domain := range model.Domains {
+		domainPage := convertKeyToFilename("domain", domain.Key.String(), "", ".md")
+		add(GlossaryEntry{Term: domain.Name, Kind: "domain", Key: domain.Key.String(), Page: domainPage}, domain.Details)
+		for _, subdomain := range domain.Subdomains {
+			entry := GlossaryEntry{Term: subdomain.Name, Kind: "subdomain", Key: subdomain.Key.String(), Page: domainPage, Parent: domain.Name, ParentPage: domainPage}
+			if len(domain.Subdomains) > 1 {
+				entry.Page = convertKeyToFilename("subdomain", subdomain.Key.String(), "", ".md")
+			}
+			add(entry, subdomain.Details)
+
+			place := domain.Name + " / " + subdomain.Name
+			for _, class := range subdomain.Classes {
+				classPage := convertKeyToFilename("class", class.Key.String(), "", ".md")
+				add(GlossaryEntry{Term: class.Name, Kind: "class", Key: class.Key.String(), Page: classPage, Place: place}, class.Details)
+				member := func(term, kind string, key identity.Key, details string) {
+					add(GlossaryEntry{Term: term, Kind: kind, Key: key.String(), Page: classPage, Parent: class.Name, ParentPage: classPage, Place: place}, details)
+				}
+				for _, attr := range class.Attributes {
+					member(attr.Name, "attribute", attr.Key, attr.Details)
+				}
+				for _, state := range class.States {
+					member(state.Name, "state", state.Key, state.Details)
+				}
+				for _, event := range class.Events {
+					member(model_state.SystemEventDisplayName(event.Name), "event", event.Key, event.Details)
+				}
+				for _, action := range class.Actions {
+					member(action.Name, "action", action.Key, action.Details)
+				}
+				for _, query := range class.Queries {
+					member(query.Name, "query", query.Key, query.Details)
+				}
+			}
+			for _, useCase := range subdomain.UseCases {
+				add(GlossaryEntry{Term: useCase.Name, Kind: "use case", Key: useCase.Key.String(), Page: convertKeyToFilename("use_case", useCase.Key.String(), "", ".md"), Place: place}, useCase.Details)
+			}
+		}
+	}
+
+	sortGlossaryEntries(entries)
+
+	references := glossaryReferences(model)
+	anchored := map[string]bool{}
+	for i := range entries {
+		entries[i].ReferencedBy = references[entries[i].Key]
+		if slug := glossarySlug(entries[i].Term); slug != "" && !anchored[slug] {
+			anchored[slug] = true
+			entries[i].Anchor = "term-" + slug
+		}
+	}
+	return entries
+}
+
+func sortGlossaryEntries(entries []GlossaryEntry) {
+	slices.SortFunc(entries, func(a, b GlossaryEntry) int {
+		if c := strings.Compare(dictionarySortTerm(a.Term), dictionarySortTerm(b.Term)); c != 0 {
+			return c
+		}
+		if c := slices.Index(_glossaryKindOrder, a.Kind) - slices.Index(_glossaryKindOrder, b.Kind); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Key, b.Key)
+	})
+}
+
+// glossarySlug is the form of a term its anchor is made from, such as order-date for
+// Order Date. Terms that differ only in case or punctuation share a slug.
+func glossarySlug(term string) string {
+	return strings.Join(strings.FieldsFunc(strings.ToLower(term), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	}), "-")
+}
+
+// glossaryLetters groups sorted entries by the first letter of their terms. Terms that do
+// not start with a letter are grouped under "#".
+func glossaryLetters(entries []GlossaryEntry) []GlossaryLetter {
+	var letters []GlossaryLetter
+	for _, entry := range entries {
+		letter, anchor := "#", "letter-other"
+		if runes := []rune(dictionarySortTerm(entry.Term)); len(runes) > 0 && unicode.IsLetter(runes[0]) {
+			letter = string(unicode.ToUpper(runes[0]))
+			anchor = "letter-" + strings.ToLower(letter)
+		}
+		if len(letters) == 0 || letters[len(letters)-1].Letter != letter {
+			letters = append(letters, GlossaryLetter{Letter: letter, Anchor: anchor})
+		}
+		letters[len(letters)-1].Entries = append(letters[len(letters)-1].Entries, entry)
+	}
+	return letters
+}
+
+// glossaryReferrers gathers, for each element of a model, the elements whose lowered logic
+// expressions or scenario steps refer to it.
+type glossaryReferrers struct {
+	references map[string][]GlossaryReference
+	seen       map[string]bool // A target key and referrer key, to list each referrer once.
+}
+
+// glossaryReferences returns the references to the elements of a model by element key.
+// Within a class, self.field is the class's attribute or outgoing association of that name;
+// other field accesses can't be resolved without types and aren't followed.
+func glossaryReferences(model core.Model) map[string][]GlossaryReference {
+	g := &glossaryReferrers{
+		references: map[string][]GlossaryReference{},
+		seen:       map[string]bool{},
+	}
+
+	for _, invariant := range model.Invariants {
+		g.logics(nil, invariant.Key.String(), GlossaryReference{Name: logicDescription(invariant), Kind: "invariant", Page: "model.md"}, invariant)
+	}
+	for _, function := range model.GlobalFunctions {
+		g.logics(nil, function.Key.String(), GlossaryReference{Name: function.Name, Kind: "global function", Page: "model.md"}, function.Logic)
+	}
+	for _, set := range model.NamedSets {
+		g.expression(set.Spec.Expression, nil, set.Key.String(), GlossaryReference{Name: set.Name, Kind: "named set", Page: "model.md"})
+	}
+	for _, association := range model.GetClassAssociations() {
+		page := convertKeyToFilename("class", association.FromClassKey.String(), "", ".md")
+		g.logics(nil, association.Key.String(), GlossaryReference{Name: association.Name, Kind: "association", Page: page}, association.Invariants...)
+	}
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for _, class := range subdomain.Classes {
+				g.class(class)
+			}
+			for _, useCase := range subdomain.UseCases {
+				page := convertKeyToFilename("use_case", useCase.Key.String(), "", ".md")
+				for _, scenario := range useCase.Scenarios {
+					if scenario.Steps != nil {
+						g.steps(scenario, scenario.Steps.Statements, GlossaryReference{Name: scenario.Name, Kind: "scenario", Parent: useCase.Name, Page: page})
+					}
+				}
+			}
+		}
+	}
+
+	for target, references := range g.references {
+		slices.SortFunc(references, func(a, b GlossaryReference) int {
+			if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Kind+" "+a.Parent+" "+a.Page, b.Kind+" "+b.Parent+" "+b.Page)
+		})
+		g.references[target] = references
+	}
+	return g.references
+}
+
+func (g *glossaryReferrers) class(class model_class.Class) {
+	page := convertKeyToFilename("class", class.Key.String(), "", ".md")
+	member := func(name, kind string) GlossaryReference {
+		return GlossaryReference{Name: name, Kind: kind, Parent: class.Name, Page: page}
+	}
+
+	g.logics(&class, class.Key.String(), GlossaryReference{Name: class.Name, Kind: "class", Page: page}, class.Invariants...)
+	for _, attr := range class.Attributes {
+		referrer := member(attr.Name, "attribute")
+		if attr.DerivationPolicy != nil {
+			g.logics(&class, attr.Key.String(), referrer, *attr.DerivationPolicy)
+		}
+		g.logics(&class, attr.Key.String(), referrer, attr.Invariants...)
+	}
+	for _, key := range identity.SortedKeys(class.Guards) {
+		guard := class.Guards[key]
+		g.logics(&class, key.String(), member(guard.Name, "guard"), guard.Logic)
+	}
+	for _, key := range identity.SortedKeys(class.Actions) {
+		action := class.Actions[key]
+		referrer := member(action.Name, "action")
+		g.logics(&class, key.String(), referrer, slices.Concat(action.Requires, action.Guarantees, action.SafetyRules)...)
+		g.parameters(&class, key.String(), referrer, action.Parameters)
+	}
+	for _, key := range identity.SortedKeys(class.Queries) {
+		query := class.Queries[key]
+		referrer := member(query.Name, "query")
+		g.logics(&class, key.String(), referrer, slices.Concat(query.Requires, query.Guarantees)...)
+		g.parameters(&class, key.String(), referrer, query.Parameters)
+	}
+}
+
+func (g *glossaryReferrers) parameters(class *model_class.Class, referrerKey string, referrer GlossaryReference, parameters []model_state.Parameter) {
+	for _, parameter := range parameters {
+		g.logics(class, referrerKey, referrer, parameter.Invariants...)
+	}
+}
+
+func (g *glossaryReferrers) logics(class *model_class.Class, referrerKey string, referrer GlossaryReference, logics ...model_logic.Logic) {
+	for _, logic := range logics {
+		for _, spec := range []me.Expression{logic.Spec.Expression, logic.DestroyEventSpec.Expression, logic.EndpointSelectorSpec.Expression} {
+			g.expression(spec, class, referrerKey, referrer)
+		}
+	}
+}
+
+func (g *glossaryReferrers) expression(expr me.Expression, class *model_class.Class, referrerKey string, referrer GlossaryReference) {
+	if expr == nil {
+		return
+	}
+	me.Walk(expr, func(e me.Expression) bool {
+		switch n := e.(type) {
+		case *me.AttributeRef:
+			g.add(n.AttributeKey, referrerKey, referrer)
+		case *me.ActionCall:
+			g.add(n.ActionKey, referrerKey, referrer)
+		case *me.EventCall:
+			g.add(n.EventKey, referrerKey, referrer)
+		case *me.GlobalCall:
+			g.add(n.FunctionKey, referrerKey, referrer)
+		case *me.NamedSetRef:
+			g.add(n.SetKey, referrerKey, referrer)
+		case *me.ClassRef:
+			g.add(n.ClassKey, referrerKey, referrer)
+		case *me.FieldAccess:
+			if _, self := n.Base.(*me.SelfRef); self && class != nil {
+				if key, found := g.selfField(*class, n.Field); found {
+					g.add(key, referrerKey, referrer)
+				}
+			}
+		}
+		return true
+	})
+}
+
+// selfField is the attribute of a class a self.field access names. Outgoing associations
+// are fields too, but have no glossary entry.
+func (g *glossaryReferrers) selfField(class model_class.Class, field string) (identity.Key, bool) {
+	for _, attr := range class.Attributes {
+		if attr.Key.SubKey == field || attr.Name == field {
+			return attr.Key, true
+		}
+	}
+	return identity.Key{}, false
+}
+
+func (g *glossaryReferrers) steps(scenario model_scenario.Scenario, statements []model_scenario.Step, referrer GlossaryReference) {
+	referrerKey := scenario.Key.String()
+	for _, step := range statements {
+		for _, key := range []*identity.Key{step.EventKey, step.QueryKey} {
+			if key != nil {
+				g.add(*key, referrerKey, referrer)
+			}
+		}
+		for _, objectKey := range []*identity.Key{step.FromObjectKey, step.ToObjectKey} {
+			if objectKey == nil {
+				continue
+			}
+			if object, found := scenario.Objects[*objectKey]; found {
+				g.add(object.ClassKey, referrerKey, referrer)
+			}
+		}
+		g.steps(scenario, step.Statements, referrer)
+	}
+}
+
+// add records that a referrer refers to a target, unless it is the target itself.
+func (g *glossaryReferrers) add(target identity.Key, referrerKey string, referrer GlossaryReference) {
+	targetKey := target.String()
+	if targetKey == referrerKey || g.seen[targetKey+" "+referrerKey] {
+		return
+	}
+	g.seen[targetKey+" "+referrerKey] = true
+	g.references[targetKey] = append(g.references[targetKey], referrer)
+}
+
+// glossaryLinker links the terms of a glossary where they appear in markdown.
+type glossaryLinker struct {
+	pattern *regexp.Regexp // Matches any term, longest terms first.
+}
+
+// newGlossaryLinker makes a linker for the terms of glossary entries. Terms that don't
+// start and end with a letter or digit, such as «new» or _Max, aren't linked.
+func newGlossaryLinker(entries []GlossaryEntry) *glossaryLinker {
+	folded := map[string]bool{}
+	terms := map[string]bool{}
+	for _, entry := range entries {
+		runes := []rune(entry.Term)
+		if len(runes) == 0 || !isGlossaryWordRune(runes[0]) || !isGlossaryWordRune(runes[len(runes)-1]) {
+			continue
+		}
+		terms[entry.Term] = true
+		if slices.Contains(_glossaryFoldedKinds, entry.Kind) {
+			folded[strings.ToLower(entry.Term)] = true
+		}
+	}
+	if len(terms) == 0 {
+		return &glossaryLinker{}
+	}
+
+	// Go's alternation prefers the leftmost alternative, so Order Date is tried before Order.
+	sorted := slices.SortedFunc(maps.Keys(terms), func(a, b string) int {
+		if c := len(b) - len(a); c != 0 {
+			return c
+		}
+		return strings.Compare(a, b)
+	})
+	var alternatives []string
+	for _, term := range sorted {
+		if folded[strings.ToLower(term)] {
+			alternatives = append(alternatives, "(?i:"+regexp.QuoteMeta(term)+")")
+		} else {
+			alternatives = append(alternatives, regexp.QuoteMeta(term))
+		}
+	}
+	return &glossaryLinker{pattern: regexp.MustCompile(`\b(?:` + strings.Join(alternatives, "|") + `)\b`)}
+}
+
+func isGlossaryWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+// links links the first mention of each term in markdown to its glossary entry. Headings,
+// code blocks, code spans, links, HTML tags and URLs are left as they are.
+func (l *glossaryLinker) links(md string) string {
+	if l == nil || l.pattern == nil || md == "" {
+		return md
+	}
+	linked := map[string]bool{}
+	fenced := false
+	var b strings.Builder
+	for _, line := range strings.SplitAfter(md, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if strings.HasPrefix(trimmed, "```") || strings.HasPrefix(trimmed, "~~~") {
+			fenced = !fenced
+			b.WriteString(line)
+			continue
+		}
+		if fenced || strings.HasPrefix(trimmed, "#") {
+			b.WriteString(line)
+			continue
+		}
+		last := 0
+		for _, span := range _glossaryProtected.FindAllStringIndex(line, -1) {
+			b.WriteString(l.linkText(line[last:span[0]], linked))
+			b.WriteString(line[span[0]:span[1]])
+			last = span[1]
+		}
+		b.WriteString(l.linkText(line[last:], linked))
+	}
+	return b.String()
+}
+
+func (l *glossaryLinker) linkText(text string, linked map[string]bool) string {
+	return l.pattern.ReplaceAllStringFunc(text, func(term string) string {
+		slug := glossarySlug(term)
+		if linked[slug] {
+			return term
+		}
+		linked[slug] = true
+		return "[" + term + "](" + _glossaryFilename + "#term-" + slug + ")"
+	})
+}
+
+// glossaryLinks links the terms of the glossary of the model being generated in markdown.
+func glossaryLinks(md string) string {
+	return activeGlossary.links(md)
+}
+
+// generateGlossaryMdContents renders the glossary page of a model.
+func generateGlossaryMdContents(reqs *req_flat.Requirements, entries []GlossaryEntry) (contents string, err error) {
+	contents, err = generateFromTemplate(_glossaryMdTemplate, struct {
+		Reqs    *req_flat.Requirements
+		Model   core.Model
+		Letters []GlossaryLetter
+	}{
+		Reqs:    reqs,
+		Model:   reqs.Model,
+		Letters: glossaryLetters(entries),
+	})
+	if err != nil {
+		return "", errors.WithStack(err)
+	}
+
+	return contents, nil
+}
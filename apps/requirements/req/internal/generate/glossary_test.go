@@ -0,0 +1,112 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGlossaryLinks(t *testing.T) {
+	linker := newGlossaryLinker([]GlossaryEntry{
+		{Term: "Order", Kind: "class"},
+		{Term: "Order Date", Kind: "attribute"},
+		{Term: "New", Kind: "state"},
+		{Term: "«new»", Kind: "event"},
+		{Term: "_Max", Kind: "global function"},
+	})
+
+	md := "# Order\n\nAn order has an Order Date, and another order a new Order Date.\n" +
+		"See `Order` and [Order](x.md) or <b title=\"Order\">New</b>.\n\n" +
+		"```\norder\n```\n"
+	assert.Equal(t, "# Order\n\nAn [order](glossary.md#term-order) has an [Order Date](glossary.md#term-order-date), and another order a new Order Date.\n"+
+		"See `Order` and [Order](x.md) or <b title=\"Order\">[New](glossary.md#term-new)</b>.\n\n"+
+		"```\norder\n```\n", linker.links(md))
+
+	// Without a glossary, markdown is left as it is.
+	assert.Equal(t, "An order.", (*glossaryLinker)(nil).links("An order."))
+}
+
+func TestModelGlossaryEntries(t *testing.T) {
+	model := test_helper.GetTestModel()
+	var orderKey, totalKey identity.Key
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for classKey, class := range subdomain.Classes {
+				if class.Name != "Order" {
+					continue
+				}
+				orderKey = classKey
+				for _, attr := range class.Attributes {
+					if attr.Name == "Total" {
+						totalKey = attr.Key
+					}
+				}
+				// Shipping an order guarantees its total, read through self.
+				for actionKey, action := range class.Actions {
+					if action.Name == "Ship Order" {
+						spec := logic_spec.ExpressionSpec{Notation: "tla_plus", Specification: "self.total' = self.total", Expression: &me.FieldAccess{Base: &me.SelfRef{}, Field: "total"}}
+						action.Guarantees = append(action.Guarantees, model_logic.Logic{Key: helper.Must(identity.NewActionGuaranteeKey(actionKey, "9")), Type: model_logic.LogicTypeStateChange, Description: "Total is kept", Spec: spec})
+						class.Actions[actionKey] = action
+					}
+				}
+			}
+		}
+	}
+
+	entries := modelGlossaryEntries(req_flat.NewRequirements(model))
+	byKey := map[string]GlossaryEntry{}
+	for _, entry := range entries {
+		byKey[entry.Key] = entry
+	}
+
+	total := byKey[totalKey.String()]
+	orderPage := convertKeyToFilename("class", orderKey.String(), "", ".md")
+	assert.Equal(t, "attribute", total.Kind)
+	assert.Equal(t, "term-total", total.Anchor)
+	assert.Equal(t, orderPage, total.Page)
+	assert.Equal(t, "Order", total.Parent)
+	assert.Equal(t, "Commerce / Order Management", total.Place)
+	assert.Equal(t, "Total amount for the order.", total.Summary)
+	assert.Equal(t, []GlossaryReference{{Name: "Ship Order", Kind: "action", Parent: "Order", Page: orderPage}}, total.ReferencedBy)
+
+	order := byKey[orderKey.String()]
+	require.NotEmpty(t, order.ReferencedBy)
+	assert.Equal(t, "Happy Path", order.ReferencedBy[0].Name)
+	assert.Equal(t, "scenario", order.ReferencedBy[0].Kind)
+
+	// Only the first entry of a term has its anchor.
+	var customers []string
+	for _, entry := range entries {
+		if entry.Term == "Customer" {
+			customers = append(customers, entry.Kind+" "+entry.Anchor)
+		}
+	}
+	assert.Equal(t, []string{"actor term-customer", "class "}, customers)
+}
+
+func TestGenerateGlossaryPage(t *testing.T) {
+	model := test_helper.GetTestModel()
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	assert.Contains(t, string(writer.md["model.md"]), "[Glossary](glossary.md)")
+	glossaryText := string(writer.md["glossary.md"])
+	assert.Contains(t, glossaryText, "# Glossary — "+model.Name)
+	assert.Contains(t, glossaryText, "[A](#letter-a) · [C](#letter-c)")
+	assert.Contains(t, glossaryText, "## <a id=\"letter-o\"></a>O\n")
+	assert.Contains(t, glossaryText, "- <a id=\"term-commerce\"></a>**[Commerce](domain-domain.domain_a.md)** *(domain)* Core commerce domain.\n")
+	assert.Contains(t, glossaryText, "*(scenario of Place Order)*")
+
+	// Details link their terms to the glossary.
+	assert.Contains(t, string(writer.md["use_case-domain.domain_a.subdomain.subdomain_a.usecase.place_order.md"]),
+		"[Customer](glossary.md#term-customer) places an [order](glossary.md#term-order).")
+	assert.Nil(t, activeGlossary)
+}
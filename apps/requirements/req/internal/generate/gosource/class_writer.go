@@ -0,0 +1,466 @@
+package gosource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// selfName is the parameter that action and query methods receive the object on,
+// named as in the specifications.
+const selfName = "self"
+
+// classWriter writes the source file of one class.
+type classWriter struct {
+	sourceWriter
+	types    typeMapper
+	class    model_class.Class
+	names    classNames
+	receiver string
+	members  *namer // Fields and methods of the class struct.
+
+	states map[identity.Key]string // State constant of each state.
+	events map[identity.Key]string // Event constant of each event.
+}
+
+func classFile(scope *packageScope, domain model_domain.Domain, subdomain model_domain.Subdomain, class model_class.Class, packageName string) (File, error) {
+	names := scope.classes[class.Key]
+	receiver := strings.ToLower(names.Type[:1])
+	w := &classWriter{
+		class:    class,
+		names:    names,
+		receiver: receiver,
+		members:  newNamer("fire"),
+		states:   make(map[identity.Key]string),
+		events:   make(map[identity.Key]string),
+	}
+	w.types = typeMapper{scope: scope, file: &w.sourceWriter}
+
+	w.comment("Generated by req from class %s of %s / %s.", class.Key.String(), domain.Name, subdomain.Name)
+	w.line("")
+	w.line("package %s", packageName)
+
+	hasStateMachine := len(class.States) > 0 || len(class.Transitions) > 0
+	if hasStateMachine {
+		w.members.name("State")
+	}
+	w.classStruct(hasStateMachine)
+	if hasStateMachine {
+		w.stateMachine()
+	}
+	w.actions()
+	w.queries()
+
+	w.WriteString(w.types.decls.String())
+	source, err := w.format()
+	if err != nil {
+		return File{}, err
+	}
+	return File{Name: class.Key.SubKey + FileExtension, Source: source}, nil
+}
+
+// classStruct writes the struct of the class, with a field per stored attribute
+// and a method per derived attribute.
+func (w *classWriter) classStruct(hasStateMachine bool) {
+	var derived []model_class.Attribute
+	var fields []string
+	for _, attr := range w.class.Attributes {
+		if attr.DerivationPolicy != nil {
+			derived = append(derived, attr)
+			continue
+		}
+		name := w.members.name(exportedName(attr.Name))
+		fields = append(fields, fmt.Sprintf("%s %s", name, w.types.fieldType(w.names.Type+name, attr.DataType, attr.Nullable)))
+		if rules := strings.TrimSpace(attr.DataTypeRules); rules != "" {
+			fields[len(fields)-1] += " // " + strings.Join(strings.Fields(rules), " ")
+		}
+	}
+
+	w.line("")
+	w.docComment(w.names.Type, "is the "+w.class.Name+" class.", w.class.Details)
+	w.line("type %s struct {", w.names.Type)
+	for _, field := range fields {
+		w.line("%s", field)
+	}
+	if hasStateMachine {
+		if len(fields) > 0 {
+			w.line("")
+		}
+		w.line("State %s // The state the object is in.", w.names.State)
+	}
+	w.line("}")
+
+	for _, attr := range derived {
+		name := w.members.name(exportedName(attr.Name))
+		goType := w.types.fieldType(w.names.Type+name, attr.DataType, attr.Nullable)
+		w.line("")
+		w.docComment(name, "derives the "+attr.Name+" attribute.", attr.Details)
+		w.line("func (%s *%s) %s() %s {", w.receiver, w.names.Type, name, goType)
+		w.logicComments("", []model_logic.Logic{*attr.DerivationPolicy})
+		w.line("var value %s", goType)
+		w.line("return value")
+		w.line("}")
+	}
+}
+
+// stateMachine writes the state and event enums, the transition table, and a method
+// per event that fires the transitions on it.
+func (w *classWriter) stateMachine() {
+	states := identity.SortedValues(w.class.States)
+	events := identity.SortedValues(w.class.Events)
+	transitions := identity.SortedValues(w.class.Transitions)
+
+	w.line("")
+	w.comment("%s is the state of %s.", w.names.State, article(w.class.Name))
+	w.line("type %s int", w.names.State)
+	w.line("")
+	w.line("const (")
+	none := w.types.scope.names.name(w.names.State + "None")
+	w.line("%s %s = iota // Not yet created, or destroyed.", none, w.names.State)
+	for _, state := range states {
+		w.states[state.Key] = w.types.scope.names.name(w.names.State + exportedName(state.Name))
+		w.line("%s", w.states[state.Key])
+	}
+	w.line(")")
+
+	w.line("")
+	w.comment("String returns the name of the state.")
+	w.line("func (s %s) String() string {", w.names.State)
+	w.line("switch s {")
+	for _, state := range states {
+		w.line("case %s:", w.states[state.Key])
+		w.line("return %q", state.Name)
+	}
+	w.line("}")
+	w.line("return %q", "none")
+	w.line("}")
+
+	w.line("")
+	w.comment("%s is an event %s handles.", w.names.Event, article(w.class.Name))
+	w.line("type %s string", w.names.Event)
+	if len(events) > 0 {
+		w.line("")
+		w.line("const (")
+		for _, event := range events {
+			w.events[event.Key] = w.types.scope.names.name(w.names.Event + exportedName(event.Name))
+			w.line("%s %s = %q", w.events[event.Key], w.names.Event, event.Name)
+		}
+		w.line(")")
+	}
+
+	w.line("")
+	w.comment("%s is a row of %s.", w.names.Transition, w.names.Transitions)
+	w.line("type %s struct {", w.names.Transition)
+	w.line("From %s", w.names.State)
+	w.line("Event %s", w.names.Event)
+	w.line("Guard string // The guard that must hold to take the transition, if any.")
+	w.line("Action string // The action the transition runs, if any.")
+	w.line("To %s", w.names.State)
+	w.line("}")
+
+	w.line("")
+	w.comment("%s lists every transition of %s.", w.names.Transitions, article(w.class.Name))
+	w.line("var %s = []%s{", w.names.Transitions, w.names.Transition)
+	for _, transition := range transitions {
+		row := []string{
+			"From: " + w.state(transition.FromStateKey, none),
+			"Event: " + w.events[transition.EventKey],
+		}
+		if guard := w.guard(transition); guard != nil {
+			row = append(row, fmt.Sprintf("Guard: %q", guard.Name))
+		}
+		if action := w.action(transition); action != nil {
+			row = append(row, fmt.Sprintf("Action: %q", action.Name))
+		}
+		row = append(row, "To: "+w.state(transition.ToStateKey, none))
+		w.line("{%s},", strings.Join(row, ", "))
+	}
+	w.line("}")
+
+	w.line("")
+	w.comment("fire moves %s to the target of the first transition on event from its state whose guard holds.", w.receiver)
+	w.line("func (%s *%s) fire(event %s, guard func(name string) bool) error {", w.receiver, w.names.Type, w.names.Event)
+	w.line("for _, transition := range %s {", w.names.Transitions)
+	w.line("if transition.From == %s.State && transition.Event == event && (transition.Guard == \"\" || guard(transition.Guard)) {", w.receiver)
+	w.line("%s.State = transition.To", w.receiver)
+	w.line("return nil")
+	w.line("}")
+	w.line("}")
+	w.line("return fmt.Errorf(%q, %s.State, event)", strings.ToLower(w.names.Type)+": no transition from %s on %s", w.receiver)
+	w.line("}")
+	w.use("fmt")
+
+	for _, event := range events {
+		w.eventMethod(event, transitions)
+	}
+}
+
+// eventMethod writes the method that handles an event. Its body lists the
+// transitions on the event with the requires and guarantees of their actions.
+func (w *classWriter) eventMethod(event model_state.Event, transitions []model_state.Transition) {
+	var on []model_state.Transition
+	for _, transition := range transitions {
+		if transition.EventKey == event.Key {
+			on = append(on, transition)
+		}
+	}
+
+	params := newNamer(w.receiver)
+	signature := make([]string, len(event.ParameterNames))
+	for i, paramName := range event.ParameterNames {
+		signature[i] = params.name(unexportedName(paramName)) + " " + w.eventParamType(on, paramName)
+	}
+
+	name := w.members.name(exportedName(event.Name))
+	w.line("")
+	w.docComment(name, "handles the "+event.Name+" event.", event.Details)
+	w.line("func (%s *%s) %s(%s) error {", w.receiver, w.names.Type, name, strings.Join(signature, ", "))
+
+	var guards []model_state.Guard
+	for _, transition := range on {
+		description := fmt.Sprintf("From %s to %s", w.stateName(transition.FromStateKey), w.stateName(transition.ToStateKey))
+		guard := w.guard(transition)
+		if guard != nil {
+			guards = append(guards, *guard)
+			description += ", when " + guard.Name
+		}
+		action := w.action(transition)
+		if action == nil {
+			w.comment("%s.", description)
+			continue
+		}
+		w.comment("%s, running %s.", description, action.Name)
+		w.logicComments("Requires: ", action.Requires)
+		w.logicComments("Guarantees: ", action.Guarantees)
+		w.logicComments("Safety rule: ", action.SafetyRules)
+	}
+
+	if len(guards) == 0 {
+		w.line("return %s.fire(%s, nil)", w.receiver, w.events[event.Key])
+		w.line("}")
+		return
+	}
+	w.line("return %s.fire(%s, func(guard string) bool {", w.receiver, w.events[event.Key])
+	w.line("switch guard {")
+	seen := make(map[string]bool)
+	for _, guard := range guards {
+		if seen[guard.Name] {
+			continue
+		}
+		seen[guard.Name] = true
+		w.line("case %q:", guard.Name)
+		w.logicComments("", []model_logic.Logic{guard.Logic})
+		w.line("return true")
+	}
+	w.line("}")
+	w.line("return false")
+	w.line("})")
+	w.line("}")
+}
+
+// eventParamType types an event parameter from the parameter of the same name of an
+// action the event runs, or any if none has one.
+func (w *classWriter) eventParamType(transitions []model_state.Transition, paramName string) string {
+	for _, transition := range transitions {
+		action := w.action(transition)
+		if action == nil {
+			continue
+		}
+		for _, param := range action.Parameters {
+			if param.Name == paramName {
+				return w.types.fieldType(w.names.Type+exportedName(action.Name)+exportedName(param.Name), param.DataType, param.Nullable)
+			}
+		}
+	}
+	return "any"
+}
+
+// actions writes the interface of the actions of the class.
+func (w *classWriter) actions() {
+	if len(w.class.Actions) == 0 {
+		return
+	}
+	w.line("")
+	w.comment("%s are the actions of %s, run by its transitions and states.", w.names.Actions, article(w.class.Name))
+	w.line("type %s interface {", w.names.Actions)
+	methods := newNamer()
+	for i, action := range identity.SortedValues(w.class.Actions) {
+		name := methods.name(exportedName(action.Name))
+		if i > 0 {
+			w.line("")
+		}
+		w.docComment(name, "runs the "+action.Name+" action.", action.Details)
+		w.logicComments("Requires: ", action.Requires)
+		w.logicComments("Guarantees: ", action.Guarantees)
+		w.logicComments("Safety rule: ", action.SafetyRules)
+		w.line("%s(%s) error", name, w.parameters(name, action.Parameters))
+	}
+	w.line("}")
+}
+
+// queries writes the interface of the queries of the class, with a result struct
+// for each query that names what it returns.
+func (w *classWriter) queries() {
+	if len(w.class.Queries) == 0 {
+		return
+	}
+	type result struct {
+		name   string
+		fields []string
+	}
+	var results []result
+
+	w.line("")
+	w.comment("%s are the queries of %s.", w.names.Queries, article(w.class.Name))
+	w.line("type %s interface {", w.names.Queries)
+	methods := newNamer()
+	for i, query := range identity.SortedValues(w.class.Queries) {
+		name := methods.name(exportedName(query.Name))
+		if i > 0 {
+			w.line("")
+		}
+		w.docComment(name, "answers the "+query.Name+" query.", query.Details)
+		w.logicComments("Requires: ", query.Requires)
+		w.logicComments("Guarantees: ", query.Guarantees)
+
+		var fields []string
+		fieldNames := newNamer()
+		for _, guarantee := range query.Guarantees {
+			if guarantee.Type != model_logic.LogicTypeQuery {
+				continue
+			}
+			fieldName := fieldNames.name(exportedName(guarantee.Target))
+			fields = append(fields, fieldName+" "+w.types.typeSpecType(w.names.Type+name+fieldName, guarantee.TargetTypeSpec))
+		}
+		params := w.parameters(name, query.Parameters)
+		if len(fields) == 0 {
+			w.line("%s(%s) error", name, params)
+			continue
+		}
+		resultName := w.types.scope.names.name(w.names.Type + name + "Result")
+		results = append(results, result{name: resultName, fields: fields})
+		w.line("%s(%s) (%s, error)", name, params, resultName)
+	}
+	w.line("}")
+
+	for _, r := range results {
+		w.line("")
+		w.comment("%s is what the %s query returns.", r.name, strings.TrimSuffix(r.name, "Result"))
+		w.line("type %s struct {", r.name)
+		for _, field := range r.fields {
+			w.line("%s", field)
+		}
+		w.line("}")
+	}
+}
+
+// parameters returns the parameter list of an action or query method: the object
+// it runs on, then its parameters in order.
+func (w *classWriter) parameters(method string, parameters []model_state.Parameter) string {
+	names := newNamer(selfName)
+	list := []string{selfName + " *" + w.names.Type}
+	for _, param := range parameters {
+		goType := w.types.fieldType(w.names.Type+method+exportedName(param.Name), param.DataType, param.Nullable)
+		list = append(list, names.name(unexportedName(param.Name))+" "+goType)
+	}
+	return strings.Join(list, ", ")
+}
+
+// docComment writes the doc comment of a declaration: its name and summary, then any
+// details as a paragraph of their own.
+func (w *classWriter) docComment(name, summary, details string) {
+	w.comment("%s %s", name, summary)
+	if details = strings.TrimSpace(details); details != "" {
+		w.line("//")
+		w.comment("%s", details)
+	}
+}
+
+// logicComments writes each logic as a comment led by label, followed by its
+// description if it has one.
+func (w *classWriter) logicComments(label string, logics []model_logic.Logic) {
+	for _, logic := range logics {
+		text := logicText(logic)
+		if text == "" {
+			continue
+		}
+		if description := strings.TrimSpace(logic.Description); description != "" {
+			text += " (" + strings.TrimSuffix(description, ".") + ")"
+		}
+		w.comment("%s%s", label, text)
+	}
+}
+
+// logicText renders a logic as its specification on one line, with its target
+// where it has one.
+func logicText(logic model_logic.Logic) string {
+	spec := strings.Join(strings.Fields(logic.Spec.Specification), " ")
+	if spec == "" {
+		return ""
+	}
+	switch logic.Type {
+	case model_logic.LogicTypeStateChange:
+		return logic.Target + "' = " + spec
+	case model_logic.LogicTypeQuery:
+		return logic.Target + " = " + spec
+	case model_logic.LogicTypeLet:
+		return "LET " + logic.Target + " == " + spec
+	}
+	return spec
+}
+
+func (w *classWriter) guard(transition model_state.Transition) *model_state.Guard {
+	if transition.GuardKey == nil {
+		return nil
+	}
+	guard, ok := w.class.Guards[*transition.GuardKey]
+	if !ok {
+		return nil
+	}
+	return &guard
+}
+
+func (w *classWriter) action(transition model_state.Transition) *model_state.Action {
+	if transition.ActionKey == nil {
+		return nil
+	}
+	action, ok := w.class.Actions[*transition.ActionKey]
+	if !ok {
+		return nil
+	}
+	return &action
+}
+
+// state returns the constant of a state, or none for the absence of one.
+func (w *classWriter) state(stateKey *identity.Key, none string) string {
+	if stateKey == nil {
+		return none
+	}
+	if constant, ok := w.states[*stateKey]; ok {
+		return constant
+	}
+	return none
+}
+
+func (w *classWriter) stateName(stateKey *identity.Key) string {
+	if stateKey == nil {
+		return "none"
+	}
+	if state, ok := w.class.States[*stateKey]; ok {
+		return state.Name
+	}
+	return "none"
+}
+
+// article prefixes a class name with "a" or "an".
+func article(name string) string {
+	if name != "" && strings.ContainsRune("AEIOUaeiou", rune(name[0])) {
+		return "an " + name
+	}
+	return "a " + name
+}
@@ -0,0 +1,123 @@
+// Package gosource generates Go source skeletons from a model.
+//
+// Each subdomain becomes one Go package. Every class gets a struct with a typed field per
+// attribute, and a class with a state machine also gets state and event enums, an explicit
+// transition table, and one method per event whose body lists the requires and guarantees
+// of the actions it runs. The actions and queries of a class become interfaces. The
+// generated code uses only the standard library, so it compiles in any module.
+package gosource
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// FileExtension is the extension of generated source files.
+const FileExtension = ".go"
+
+// Package is a generated Go package.
+type Package struct {
+	Dir   string // The package directory, relative to the output path.
+	Name  string // The package name.
+	Files []File
+}
+
+// File is one generated source file of a package.
+type File struct {
+	Name   string // The file name, with its extension.
+	Source string // The complete gofmt-formatted file text.
+}
+
+// Generate writes one package directory per subdomain into outputPath.
+func Generate(model core.Model, outputPath string) error {
+	packages, err := Packages(model)
+	if err != nil {
+		return err
+	}
+	for _, pkg := range packages {
+		dir := filepath.Join(outputPath, pkg.Dir)
+		if err := os.MkdirAll(dir, 0755); err != nil {
+			return errors.WithStack(err)
+		}
+		for _, file := range pkg.Files {
+			if err := os.WriteFile(filepath.Join(dir, file.Name), []byte(file.Source), 0o644); err != nil { //nolint:gosec // generated source is intentionally world-readable
+				return errors.WithStack(err)
+			}
+		}
+	}
+	return nil
+}
+
+// Packages returns a package for each subdomain with classes. Domains and their
+// subdomains come in key order, so regenerating a model gives the same packages.
+func Packages(model core.Model) ([]Package, error) {
+	var packages []Package
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			if len(subdomain.Classes) == 0 {
+				continue
+			}
+			pkg, err := SubdomainPackage(model, domain, subdomain)
+			if err != nil {
+				return nil, err
+			}
+			packages = append(packages, pkg)
+		}
+	}
+	return packages, nil
+}
+
+// SubdomainPackage returns the package for the classes of one subdomain: a doc.go
+// file describing the package and one file per class.
+func SubdomainPackage(model core.Model, domain model_domain.Domain, subdomain model_domain.Subdomain) (Package, error) {
+	pkg := Package{
+		Dir:  filepath.Join(domain.Key.SubKey, subdomain.Key.SubKey),
+		Name: packageName(domain, subdomain),
+	}
+
+	doc, err := docFile(model, domain, subdomain, pkg.Name)
+	if err != nil {
+		return Package{}, err
+	}
+	pkg.Files = append(pkg.Files, doc)
+
+	scope := newPackageScope(subdomain)
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		file, err := classFile(scope, domain, subdomain, class, pkg.Name)
+		if err != nil {
+			return Package{}, err
+		}
+		pkg.Files = append(pkg.Files, file)
+	}
+	return pkg, nil
+}
+
+// packageName joins the domain and subdomain keys into a package name. The subdomain
+// key alone is often a keyword, such as default.
+func packageName(domain model_domain.Domain, subdomain model_domain.Subdomain) string {
+	return strings.ReplaceAll(domain.Key.SubKey+subdomain.Key.SubKey, "_", "")
+}
+
+func docFile(model core.Model, domain model_domain.Domain, subdomain model_domain.Subdomain, name string) (File, error) {
+	w := &sourceWriter{}
+	w.comment("Package %s implements the %s / %s subdomain of the %s model.", name, domain.Name, subdomain.Name, model.Name)
+	if details := strings.TrimSpace(subdomain.Details); details != "" {
+		w.line("//")
+		w.comment("%s", details)
+	}
+	w.line("//")
+	w.comment("Generated by req as a skeleton to complete by hand.")
+	w.line("package %s", name)
+	source, err := w.format()
+	if err != nil {
+		return File{}, err
+	}
+	return File{Name: "doc" + FileExtension, Source: source}, nil
+}
@@ -0,0 +1,260 @@
+package gosource
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type GoSourceSuite struct {
+	suite.Suite
+}
+
+func TestGoSourceSuite(t *testing.T) {
+	suite.Run(t, new(GoSourceSuite))
+}
+
+func (suite *GoSourceSuite) TestClassFile() {
+	packages, err := Packages(test_helper.GetBankModel())
+	suite.Require().NoError(err)
+	suite.Require().Len(packages, 2)
+	pkg := packages[0]
+	suite.Equal(filepath.Join("bank", "accounts"), pkg.Dir)
+	suite.Equal("bankaccounts", pkg.Name)
+	suite.Require().Len(pkg.Files, 6)
+	suite.Equal("doc.go", pkg.Files[0].Name)
+	suite.Equal("account.go", pkg.Files[1].Name)
+	suite.Equal(`// Generated by req from class domain/bank/subdomain/accounts/class/account of Bank / Accounts.
+
+package bankaccounts
+
+import (
+	"fmt"
+)
+
+// Account is the Account class.
+//
+// Money held for a customer.
+type Account struct {
+	Balance  int            // [0 .. 1000] at 1 dollar
+	Rate     *float64       // [0 .. 1) at 0.01 percent
+	Status   *AccountStatus // enum of open, frozen
+	Owners   []*Customer    // unique 1-3 unordered of obj of customer
+	Nickname any
+
+	State AccountState // The state the object is in.
+}
+
+// AccountState is the state of an Account.
+type AccountState int
+
+const (
+	AccountStateNone AccountState = iota // Not yet created, or destroyed.
+	AccountStateClosed
+	AccountStateFrozen
+	AccountStateOpen
+)
+
+// String returns the name of the state.
+func (s AccountState) String() string {
+	switch s {
+	case AccountStateClosed:
+		return "Closed"
+	case AccountStateFrozen:
+		return "Frozen"
+	case AccountStateOpen:
+		return "Open"
+	}
+	return "none"
+}
+
+// AccountEvent is an event an Account handles.
+type AccountEvent string
+
+const (
+	AccountEventDestroy AccountEvent = "_destroy"
+	AccountEventNew     AccountEvent = "_new"
+	AccountEventAudit   AccountEvent = "audit"
+	AccountEventClose   AccountEvent = "close"
+	AccountEventDeposit AccountEvent = "deposit"
+	AccountEventThaw    AccountEvent = "thaw"
+)
+
+// AccountTransition is a row of AccountTransitions.
+type AccountTransition struct {
+	From   AccountState
+	Event  AccountEvent
+	Guard  string // The guard that must hold to take the transition, if any.
+	Action string // The action the transition runs, if any.
+	To     AccountState
+}
+
+// AccountTransitions lists every transition of an Account.
+var AccountTransitions = []AccountTransition{
+	{From: AccountStateClosed, Event: AccountEventDestroy, To: AccountStateNone},
+	{From: AccountStateFrozen, Event: AccountEventThaw, To: AccountStateOpen},
+	{From: AccountStateNone, Event: AccountEventNew, Action: "Open", To: AccountStateOpen},
+	{From: AccountStateOpen, Event: AccountEventClose, Guard: "rich", Action: "Close", To: AccountStateClosed},
+	{From: AccountStateOpen, Event: AccountEventDeposit, Action: "Deposit", To: AccountStateOpen},
+}
+
+// fire moves a to the target of the first transition on event from its state whose guard holds.
+func (a *Account) fire(event AccountEvent, guard func(name string) bool) error {
+	for _, transition := range AccountTransitions {
+		if transition.From == a.State && transition.Event == event && (transition.Guard == "" || guard(transition.Guard)) {
+			a.State = transition.To
+			return nil
+		}
+	}
+	return fmt.Errorf("account: no transition from %s on %s", a.State, event)
+}
+
+// Destroy handles the _destroy event.
+func (a *Account) Destroy() error {
+	// From Closed to none.
+	return a.fire(AccountEventDestroy, nil)
+}
+
+// New handles the _new event.
+func (a *Account) New(opening int) error {
+	// From none to Open, running Open.
+	// Guarantees: balance' = opening
+	return a.fire(AccountEventNew, nil)
+}
+
+// Audit handles the audit event.
+func (a *Account) Audit() error {
+	return a.fire(AccountEventAudit, nil)
+}
+
+// Close handles the close event.
+func (a *Account) Close() error {
+	// From Open to Closed, when rich, running Close.
+	// Requires: balance >= 0 (Nothing is owed)
+	return a.fire(AccountEventClose, func(guard string) bool {
+		switch guard {
+		case "rich":
+			// balance > 50 (balance > 50)
+			return true
+		}
+		return false
+	})
+}
+
+// Deposit handles the deposit event.
+//
+// Money arrives.
+func (a *Account) Deposit(amount int, type_ any) error {
+	// From Open to Open, running Deposit.
+	// Guarantees: balance' = balance + amount
+	return a.fire(AccountEventDeposit, nil)
+}
+
+// Thaw handles the thaw event.
+func (a *Account) Thaw() error {
+	// From Frozen to Open.
+	return a.fire(AccountEventThaw, nil)
+}
+
+// AccountActions are the actions of an Account, run by its transitions and states.
+type AccountActions interface {
+	// Close runs the Close action.
+	//
+	// Closes the account.
+	// Requires: balance >= 0 (Nothing is owed)
+	Close(self *Account) error
+
+	// Deposit runs the Deposit action.
+	// Guarantees: balance' = balance + amount
+	Deposit(self *Account, amount int) error
+
+	// Log runs the Log action.
+	Log(self *Account) error
+
+	// Open runs the Open action.
+	// Guarantees: balance' = opening
+	Open(self *Account, opening int) error
+}
+
+// AccountQueries are the queries of an Account.
+type AccountQueries interface {
+	// Balance answers the Balance query.
+	// Guarantees: balance = self.balance
+	Balance(self *Account) (AccountBalanceResult, error)
+}
+
+// AccountBalanceResult is what the AccountBalance query returns.
+type AccountBalanceResult struct {
+	Balance int
+}
+
+// AccountStatus is one of "open", "frozen".
+type AccountStatus string
+
+const (
+	AccountStatusOpen   AccountStatus = "open"
+	AccountStatusFrozen AccountStatus = "frozen"
+)
+`, pkg.Files[1].Source)
+}
+
+func (suite *GoSourceSuite) TestSpanTypes() {
+	attributeKey := helper.Must(identity.ParseKey("domain/d/subdomain/s/class/c/attribute/a"))
+	dataTypeKey := helper.Must(identity.NewDataTypeKey(attributeKey, ""))
+	tests := []struct {
+		rules  string
+		goType string
+	}{
+		{rules: "[1 .. 500] at 1 unit", goType: "int"},
+		{rules: "[0 .. 1000) at 0.01 dollar", goType: "float64"},
+		{rules: "[1/3 .. 2] at 1 unit", goType: "float64"},
+	}
+	for _, tt := range tests {
+		dataType := helper.Must(model_data_type.New(dataTypeKey, tt.rules, nil))
+		mapper := typeMapper{scope: &packageScope{names: newNamer()}, file: &sourceWriter{}}
+		suite.Equal(tt.goType, mapper.dataType("Span", dataType), tt.rules)
+	}
+}
+
+func (suite *GoSourceSuite) TestTestModelCompiles() {
+	packages, err := Packages(test_helper.GetTestModel())
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(packages)
+	for _, pkg := range packages {
+		suite.typeCheck(pkg)
+	}
+}
+
+func (suite *GoSourceSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath))
+	source, err := os.ReadFile(filepath.Join(outputPath, "bank", "accounts", "account.go"))
+	suite.Require().NoError(err)
+	suite.Contains(string(source), "type Account struct {")
+	suite.FileExists(filepath.Join(outputPath, "bank", "ledger", "doc.go"))
+}
+
+// typeCheck compiles the package against the standard library alone.
+func (suite *GoSourceSuite) typeCheck(pkg Package) {
+	fset := token.NewFileSet()
+	var files []*ast.File
+	for _, file := range pkg.Files {
+		parsed, err := parser.ParseFile(fset, file.Name, file.Source, parser.ParseComments)
+		suite.Require().NoError(err, "%s/%s", pkg.Dir, file.Name)
+		files = append(files, parsed)
+	}
+	config := types.Config{Importer: importer.ForCompiler(fset, "source", nil)}
+	_, err := config.Check(pkg.Name, fset, files, nil)
+	suite.Require().NoError(err, pkg.Dir)
+}
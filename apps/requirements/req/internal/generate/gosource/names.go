@@ -0,0 +1,77 @@
+package gosource
+
+import (
+	"go/token"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// _predeclared are the identifiers a generated parameter must not shadow, since the
+// signatures and bodies around it refer to them.
+var _predeclared = map[string]bool{
+	"any": true, "bool": true, "error": true, "false": true, "float64": true, "fmt": true,
+	"int": true, "len": true, "nil": true, "string": true, "time": true, "true": true,
+}
+
+// words splits a model name into its alphanumeric runs, such as "line_item" into
+// "line" and "item".
+func words(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// exportedName turns a model name into an exported Go identifier, such as
+// "Line Item" into "LineItem" and "_new" into "New".
+func exportedName(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	identifier := b.String()
+	if identifier == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		return "X" + identifier
+	}
+	return identifier
+}
+
+// unexportedName turns a model name into an unexported Go identifier, such as
+// "product_id" into "productId". Keywords and predeclared identifiers get a trailing
+// underscore.
+func unexportedName(name string) string {
+	exported := []rune(exportedName(name))
+	identifier := string(unicode.ToLower(exported[0])) + string(exported[1:])
+	if token.IsKeyword(identifier) || _predeclared[identifier] {
+		return identifier + "_"
+	}
+	return identifier
+}
+
+// namer hands out identifiers unique within one scope, numbering repeats.
+type namer struct {
+	used map[string]bool
+}
+
+func newNamer(reserved ...string) *namer {
+	n := &namer{used: make(map[string]bool)}
+	for _, name := range reserved {
+		n.used[name] = true
+	}
+	return n
+}
+
+// name returns base, or base followed by the lowest number that makes it unique.
+func (n *namer) name(base string) string {
+	name := base
+	for i := 2; n.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	n.used[name] = true
+	return name
+}
@@ -0,0 +1,64 @@
+package gosource
+
+import (
+	"fmt"
+	"go/format"
+	"slices"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// sourceWriter accumulates the text of one source file, and the standard library
+// packages it imports.
+type sourceWriter struct {
+	strings.Builder
+	imports []string
+}
+
+func (w *sourceWriter) line(format string, args ...any) {
+	fmt.Fprintf(w, format, args...)
+	w.WriteString("\n")
+}
+
+// comment writes text as line comments, one per line of text.
+func (w *sourceWriter) comment(format string, args ...any) {
+	for _, text := range strings.Split(fmt.Sprintf(format, args...), "\n") {
+		text = strings.TrimRight(text, " \t\r")
+		if text == "" {
+			w.line("//")
+			continue
+		}
+		w.line("// %s", text)
+	}
+}
+
+// use records that the file imports the package at path.
+func (w *sourceWriter) use(path string) {
+	if !slices.Contains(w.imports, path) {
+		w.imports = append(w.imports, path)
+	}
+}
+
+// format returns the file with its imports inserted after the package clause,
+// formatted as gofmt would.
+func (w *sourceWriter) format() (string, error) {
+	text := w.String()
+	if len(w.imports) > 0 {
+		slices.Sort(w.imports)
+		var block strings.Builder
+		block.WriteString("\nimport (\n")
+		for _, path := range w.imports {
+			fmt.Fprintf(&block, "\t%q\n", path)
+		}
+		block.WriteString(")\n")
+		start := strings.Index(text, "\npackage ") + 1
+		end := start + strings.Index(text[start:], "\n") + 1
+		text = text[:end] + block.String() + text[end:]
+	}
+	source, err := format.Source([]byte(text))
+	if err != nil {
+		return "", errors.Wrapf(err, "formatting generated source:\n%s", text)
+	}
+	return string(source), nil
+}
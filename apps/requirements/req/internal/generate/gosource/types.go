@@ -0,0 +1,270 @@
+package gosource
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// packageScope holds the package-level identifiers of a subdomain package.
+type packageScope struct {
+	names   *namer
+	classes map[identity.Key]classNames
+	// bySubKey finds the classes of an object data type, which names its class by subkey.
+	bySubKey map[string]identity.Key
+}
+
+// classNames are the package-level identifiers generated for one class.
+type classNames struct {
+	Type        string // The class struct.
+	State       string // The state enum.
+	Event       string // The event enum.
+	Transition  string // The transition table row.
+	Transitions string // The transition table.
+	Actions     string // The actions interface.
+	Queries     string // The queries interface.
+}
+
+// newPackageScope names every class of the subdomain up front, so that classes
+// can refer to each other whichever is generated first.
+func newPackageScope(subdomain model_domain.Subdomain) *packageScope {
+	scope := &packageScope{
+		names:    newNamer(),
+		classes:  make(map[identity.Key]classNames),
+		bySubKey: make(map[string]identity.Key),
+	}
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		scope.bySubKey[class.Key.SubKey] = class.Key
+		typeName := scope.names.name(exportedName(class.Name))
+		scope.classes[class.Key] = classNames{Type: typeName}
+	}
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		names := scope.classes[class.Key]
+		names.State = scope.names.name(names.Type + "State")
+		names.Event = scope.names.name(names.Type + "Event")
+		names.Transition = scope.names.name(names.Type + "Transition")
+		names.Transitions = scope.names.name(names.Type + "Transitions")
+		names.Actions = scope.names.name(names.Type + "Actions")
+		names.Queries = scope.names.name(names.Type + "Queries")
+		scope.classes[class.Key] = names
+	}
+	return scope
+}
+
+// typeMapper maps model data types to Go types for one class file, declaring the
+// enum and record types it needs along the way.
+type typeMapper struct {
+	scope *packageScope
+	file  *sourceWriter
+	decls sourceWriter // Type declarations, written after the rest of the file.
+}
+
+// fieldType returns the Go type of an attribute or parameter, a pointer when it is
+// nullable and its type has no nil value of its own. The hint names any type declared
+// for it.
+func (m *typeMapper) fieldType(hint string, dataType *model_data_type.DataType, nullable bool) string {
+	goType := m.dataType(hint, dataType)
+	if nullable && !nilable(goType) {
+		return "*" + goType
+	}
+	return goType
+}
+
+// dataType returns the Go type of a data type. A parsed type spec is the more
+// precise description, except of datetimes and enumerations, whose type spec is
+// only their representation.
+func (m *typeMapper) dataType(hint string, dataType *model_data_type.DataType) string {
+	if dataType == nil {
+		return "any"
+	}
+	if typeSpec := dataType.TypeSpec; typeSpec != nil && typeSpec.ExpressionType != nil && !representedAtomic(dataType) {
+		return m.expressionType(hint, typeSpec.ExpressionType)
+	}
+	switch dataType.CollectionType {
+	case model_data_type.COLLECTION_TYPE_ATOMIC:
+		return m.atomic(hint, dataType.Atomic)
+	case model_data_type.COLLECTION_TYPE_RECORD:
+		return m.record(hint, dataType.RecordFields)
+	default:
+		// Ordered, unordered, queue and stack collections are all slices.
+		if dataType.ElementDataType != nil {
+			return "[]" + m.dataType(hint+"Element", dataType.ElementDataType)
+		}
+		if len(dataType.RecordFields) > 0 {
+			return "[]" + m.record(hint+"Element", dataType.RecordFields)
+		}
+		return "[]" + m.atomic(hint+"Element", dataType.Atomic)
+	}
+}
+
+// typeSpecType returns the Go type of a type spec, or any if it did not parse.
+func (m *typeMapper) typeSpecType(hint string, typeSpec *logic_spec.TypeSpec) string {
+	if typeSpec == nil || typeSpec.ExpressionType == nil {
+		return "any"
+	}
+	return m.expressionType(hint, typeSpec.ExpressionType)
+}
+
+func (m *typeMapper) expressionType(hint string, expressionType logic_expression_type.ExpressionType) string {
+	switch t := expressionType.(type) {
+	case *logic_expression_type.BooleanType:
+		return "bool"
+	case *logic_expression_type.IntegerType:
+		return "int"
+	case *logic_expression_type.RationalType:
+		return "float64"
+	case *logic_expression_type.StringType:
+		return "string"
+	case *logic_expression_type.EnumType:
+		return m.enum(hint, t.Values)
+	case *logic_expression_type.SequenceType:
+		return "[]" + m.expressionType(hint+"Element", t.ElementType)
+	case *logic_expression_type.TupleType:
+		fields := make([]string, len(t.ElementTypes))
+		for i, elementType := range t.ElementTypes {
+			fields[i] = fmt.Sprintf("V%d %s", i+1, m.expressionType(fmt.Sprintf("%sV%d", hint, i+1), elementType))
+		}
+		return "struct{ " + strings.Join(fields, "; ") + " }"
+	case *logic_expression_type.RecordType:
+		name := m.scope.names.name(hint)
+		fields := newNamer()
+		lines := make([]string, len(t.Fields))
+		for i, field := range t.Fields {
+			fieldName := fields.name(exportedName(field.Name))
+			lines[i] = fieldName + " " + m.expressionType(hint+fieldName, field.Type)
+		}
+		m.structDecl(name, lines)
+		return name
+	case *logic_expression_type.FunctionType:
+		params := make([]string, len(t.Params))
+		for i, param := range t.Params {
+			params[i] = m.expressionType(fmt.Sprintf("%sParam%d", hint, i+1), param)
+		}
+		return "func(" + strings.Join(params, ", ") + ") " + m.expressionType(hint+"Result", t.Return)
+	case *logic_expression_type.ObjectType:
+		return m.object(t.ClassKey)
+	}
+	return "any"
+}
+
+func (m *typeMapper) atomic(hint string, atomic *model_data_type.Atomic) string {
+	if atomic == nil {
+		return "any"
+	}
+	switch atomic.ConstraintType {
+	case model_data_type.CONSTRAINT_TYPE_DATETIME:
+		m.file.use("time")
+		return "time.Time"
+	case model_data_type.CONSTRAINT_TYPE_SPAN:
+		if atomic.Span.Fractional() {
+			return "float64"
+		}
+		return "int"
+	case model_data_type.CONSTRAINT_TYPE_ENUMERATION:
+		values := make([]string, len(atomic.Enums))
+		for i, enum := range atomic.Enums {
+			values[i] = enum.Value
+		}
+		return m.enum(hint, values)
+	case model_data_type.CONSTRAINT_TYPE_REFERENCE:
+		return "string"
+	case model_data_type.CONSTRAINT_TYPE_OBJECT:
+		if atomic.ObjectClassKey != nil {
+			if classKey, ok := m.scope.bySubKey[*atomic.ObjectClassKey]; ok {
+				return m.object(classKey)
+			}
+		}
+	}
+	return "any"
+}
+
+// object returns a pointer to the class struct, or any for a class of another package.
+func (m *typeMapper) object(classKey identity.Key) string {
+	if names, ok := m.scope.classes[classKey]; ok {
+		return "*" + names.Type
+	}
+	return "any"
+}
+
+// enum declares a string type with a constant per value.
+func (m *typeMapper) enum(hint string, values []string) string {
+	name := m.scope.names.name(hint)
+	m.decls.line("")
+	m.decls.line("// %s is one of %s.", name, quotedList(values))
+	m.decls.line("type %s string", name)
+	if len(values) > 0 {
+		m.decls.line("")
+		m.decls.line("const (")
+		for _, value := range values {
+			m.decls.line("%s %s = %q", m.scope.names.name(name+exportedName(value)), name, value)
+		}
+		m.decls.line(")")
+	}
+	return name
+}
+
+// record declares a struct with a field per record field.
+func (m *typeMapper) record(hint string, recordFields []model_data_type.Field) string {
+	name := m.scope.names.name(hint)
+	fields := newNamer()
+	lines := make([]string, len(recordFields))
+	for i, field := range recordFields {
+		fieldName := fields.name(exportedName(field.Name))
+		lines[i] = fieldName + " " + m.dataType(hint+fieldName, field.FieldDataType)
+	}
+	m.structDecl(name, lines)
+	return name
+}
+
+// structDecl declares a record struct from its field lines. The field types are
+// mapped before the struct is written, so any type they declare comes first.
+func (m *typeMapper) structDecl(name string, lines []string) {
+	m.decls.line("")
+	m.decls.line("// %s is a record of %s.", name, fieldList(len(lines)))
+	m.decls.line("type %s struct {", name)
+	for _, line := range lines {
+		m.decls.line("%s", line)
+	}
+	m.decls.line("}")
+}
+
+// representedAtomic reports whether the data type is a datetime or enumeration, whose
+// type spec gives only how the value is represented.
+func representedAtomic(dataType *model_data_type.DataType) bool {
+	if dataType.CollectionType != model_data_type.COLLECTION_TYPE_ATOMIC || dataType.Atomic == nil {
+		return false
+	}
+	switch dataType.Atomic.ConstraintType {
+	case model_data_type.CONSTRAINT_TYPE_DATETIME, model_data_type.CONSTRAINT_TYPE_ENUMERATION:
+		return true
+	}
+	return false
+}
+
+// nilable reports whether a Go type already has nil as a value.
+func nilable(goType string) bool {
+	return goType == "any" || strings.HasPrefix(goType, "*") || strings.HasPrefix(goType, "[]") || strings.HasPrefix(goType, "func(")
+}
+
+func quotedList(values []string) string {
+	quoted := make([]string, len(values))
+	for i, value := range values {
+		quoted[i] = fmt.Sprintf("%q", value)
+	}
+	if len(quoted) == 0 {
+		return "no values"
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func fieldList(count int) string {
+	if count == 1 {
+		return "1 field"
+	}
+	return fmt.Sprintf("%d fields", count)
+}
@@ -0,0 +1,41 @@
+package generate
+
+import (
+	"bytes"
+	"context"
+	"strings"
+
+	"github.com/goccy/go-graphviz"
+	"github.com/pkg/errors"
+)
+
+// renderGraphvizSVG lays out a DOT graph and renders it as SVG.
+func renderGraphvizSVG(dot string) ([]byte, error) {
+	ctx := context.Background()
+
+	gv, err := graphviz.New(ctx)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	defer func() { _ = gv.Close() }()
+
+	graph, err := graphviz.ParseBytes([]byte(dot))
+	if err != nil {
+		return nil, errors.Wrap(err, "parse dot")
+	}
+	defer func() { _ = graph.Close() }()
+
+	var buf bytes.Buffer
+	if err := gv.Render(ctx, graph, graphviz.SVG, &buf); err != nil {
+		return nil, errors.Wrap(err, "render dot")
+	}
+	return buf.Bytes(), nil
+}
+
+// dotQuote quotes text as a DOT identifier or attribute value.
+func dotQuote(text string) string {
+	text = strings.ReplaceAll(text, `\`, `\\`)
+	text = strings.ReplaceAll(text, `"`, `\"`)
+	text = strings.ReplaceAll(text, "\n", `\n`)
+	return `"` + text + `"`
+}
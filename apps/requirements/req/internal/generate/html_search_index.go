@@ -0,0 +1,146 @@
+package generate
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// searchEntry is one thing the search page of the static HTML site can find: a named
+// part of the model, the page it is on, and the text of its details and specifications.
+type searchEntry struct {
+	Name string `json:"name"`
+	Kind string `json:"kind"`
+	Page string `json:"page"`
+	Text string `json:"text"`
+}
+
+// searchIndex gathers the entries of the search index in the order of the model.
+type searchIndex struct {
+	entries []searchEntry
+}
+
+// add adds an entry, its text the given parts with their whitespace collapsed.
+func (index *searchIndex) add(name, kind, page string, texts ...string) {
+	index.entries = append(index.entries, searchEntry{
+		Name: name,
+		Kind: kind,
+		Page: page,
+		Text: strings.Join(strings.Fields(strings.Join(texts, " ")), " "),
+	})
+}
+
+// buildSearchIndex indexes the names, details and specifications of a model by the pages
+// of the static HTML site that show them.
+func buildSearchIndex(model core.Model) []searchEntry {
+	const modelPage = "model.html"
+
+	index := &searchIndex{}
+	index.add(model.Name, "model", modelPage, model.Details)
+	for _, invariant := range logicDescriptions(model.Invariants) {
+		index.add(model.Name, "invariant", modelPage, invariant)
+	}
+	for _, key := range sortedSearchKeys(model.GlobalFunctions) {
+		function := model.GlobalFunctions[key]
+		index.add(function.Name, "global function", modelPage, logicDescription(function.Logic))
+	}
+	for _, key := range sortedSearchKeys(model.NamedSets) {
+		set := model.NamedSets[key]
+		index.add(set.Name, "named set", modelPage, set.Description, set.Spec.Specification)
+	}
+
+	for _, key := range sortedSearchKeys(model.Actors) {
+		actor := model.Actors[key]
+		index.add(actor.Name, "actor", searchPage("actor", key), actor.Details)
+	}
+
+	for _, key := range sortedSearchKeys(model.Domains) {
+		domain := model.Domains[key]
+		domainPage := searchPage("domain", key)
+		index.add(domain.Name, "domain", domainPage, domain.Details)
+		for _, subdomainKey := range sortedSearchKeys(domain.Subdomains) {
+			subdomain := domain.Subdomains[subdomainKey]
+			// A domain with one subdomain shows it on the domain page.
+			subdomainPage := domainPage
+			if len(domain.Subdomains) > 1 {
+				subdomainPage = searchPage("subdomain", subdomainKey)
+				index.add(subdomain.Name, "subdomain", subdomainPage, subdomain.Details)
+			}
+			for _, classKey := range sortedSearchKeys(subdomain.Classes) {
+				index.addClass(subdomain.Classes[classKey])
+			}
+			for _, useCaseKey := range sortedSearchKeys(subdomain.UseCases) {
+				useCase := subdomain.UseCases[useCaseKey]
+				useCasePage := searchPage("use_case", useCaseKey)
+				index.add(useCase.Name, "use case", useCasePage, useCase.Details)
+				for _, scenarioKey := range sortedSearchKeys(useCase.Scenarios) {
+					scenario := useCase.Scenarios[scenarioKey]
+					index.add(useCase.Name+" · "+scenario.Name, "scenario", useCasePage, scenario.Details)
+				}
+			}
+		}
+	}
+
+	return index.entries
+}
+
+// addClass indexes a class and the parts of it shown on its page.
+func (index *searchIndex) addClass(class model_class.Class) {
+	page := searchPage("class", class.Key)
+	partName := func(name string) string { return class.Name + " · " + name }
+
+	index.add(class.Name, "class", page, append([]string{class.Details}, logicDescriptions(class.Invariants)...)...)
+	for _, attribute := range class.Attributes {
+		texts := []string{attribute.Details, attribute.DataTypeRules}
+		if attribute.DerivationPolicy != nil {
+			texts = append(texts, logicDescription(*attribute.DerivationPolicy))
+		}
+		index.add(partName(attribute.Name), "attribute", page, append(texts, logicDescriptions(attribute.Invariants)...)...)
+	}
+	for _, key := range sortedSearchKeys(class.States) {
+		state := class.States[key]
+		index.add(partName(state.Name), "state", page, state.Details)
+	}
+	for _, key := range sortedSearchKeys(class.Events) {
+		event := class.Events[key]
+		index.add(partName(model_state.SystemEventDisplayName(event.Name)), "event", page, event.Details)
+	}
+	for _, key := range sortedSearchKeys(class.Guards) {
+		guard := class.Guards[key]
+		index.add(partName(guard.Name), "guard", page, logicDescription(guard.Logic))
+	}
+	for _, key := range sortedSearchKeys(class.Actions) {
+		action := class.Actions[key]
+		index.add(partName(action.Name), "action", page, searchLogicTexts(action.Details, action.Requires, action.Guarantees, action.SafetyRules)...)
+	}
+	for _, key := range sortedSearchKeys(class.Queries) {
+		query := class.Queries[key]
+		index.add(partName(query.Name), "query", page, searchLogicTexts(query.Details, query.Requires, query.Guarantees)...)
+	}
+}
+
+// searchLogicTexts is the details of an action or query followed by its specifications.
+func searchLogicTexts(details string, logics ...[]model_logic.Logic) []string {
+	texts := []string{details}
+	for _, logic := range logics {
+		texts = append(texts, logicDescriptions(logic)...)
+	}
+	return texts
+}
+
+// searchPage is the page of the static HTML site for a part of the model.
+func searchPage(objType string, key identity.Key) string {
+	return convertKeyToFilename(objType, key.String(), "", ".html")
+}
+
+func sortedSearchKeys[V any](m map[identity.Key]V) []identity.Key {
+	return slices.SortedFunc(maps.Keys(m), func(a, b identity.Key) int {
+		return strings.Compare(a.String(), b.String())
+	})
+}
@@ -0,0 +1,239 @@
+package generate
+
+import (
+	"encoding/json"
+	"html"
+	"log"
+	"regexp"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
+	"github.com/gomarkdown/markdown"
+
+	"github.com/pkg/errors"
+)
+
+// The files of the static HTML site besides its pages and diagrams.
+const (
+	HTMLIndexFilename       = "index.html"        // Opens the model page.
+	HTMLSearchFilename      = "search.html"       // The search page.
+	HTMLSearchIndexFilename = "search-index.json" // The search index, for the search page and other tools.
+	htmlSearchIndexJS       = "search-index.js"   // The search index as a script, since pages opened from files cannot fetch JSON.
+)
+
+// _markdownPageLink matches a link to another generated markdown page, with any fragment,
+// in an href of a rendered page or a Graphviz SVG.
+var _markdownPageLink = regexp.MustCompile(`(href=")([^"/:#]+)\.md([#"])`)
+
+// _pageHeading matches the first heading of a rendered page, which is its title.
+var _pageHeading = regexp.MustCompile(`(?s)<h1[^>]*>(.*?)</h1>`)
+
+// _htmlTag matches an HTML tag, to take the text of a heading.
+var _htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// GenerateHTMLFromModel generates a static HTML site from an already-parsed model: the
+// pages of the markdown documentation rendered to HTML, their diagrams and stylesheet, a
+// search index over the names, details and specifications of the model, and a search page.
+// The site needs no server, so it can be opened from a file share.
+//
+// classErrors and sources are as for GenerateMdFromModel.
+func GenerateHTMLFromModel(outputPath string, parsedModel core.Model, classErrors map[string]string, sources *sourcepos.Index) (err error) { //nolint:revive // public API name
+	// Create necessary output paths if we don't have them.
+	if err = createMissingPaths([]string{outputPath}); err != nil {
+		return err
+	}
+
+	log.Println()
+
+	writer := newHTMLSiteWriter(NewFileWriter(outputPath), parsedModel.Name)
+	if err = GenerateMdWithIssuesToWriter(parsedModel, writer, BuildParseIssueIndex(&parsedModel, classErrors, sources)); err != nil {
+		return err
+	}
+	if err = writer.writeSiteFiles(buildSearchIndex(parsedModel)); err != nil {
+		return err
+	}
+
+	log.Println()
+
+	return nil
+}
+
+// htmlSiteWriter is a ContentWriter that renders markdown pages to the HTML pages of a
+// static site, with links between pages pointing at the HTML pages.
+type htmlSiteWriter struct {
+	files      *FileWriter
+	modelName  string
+	hasMermaid bool // Whether any page has a Mermaid diagram, so the site needs the bundle.
+}
+
+func newHTMLSiteWriter(files *FileWriter, modelName string) *htmlSiteWriter {
+	return &htmlSiteWriter{files: files, modelName: modelName}
+}
+
+// WriteMarkdown writes a markdown page as an HTML page.
+func (w *htmlSiteWriter) WriteMarkdown(filename string, content []byte) error {
+	hasMermaid := MarkdownHasMermaid(content)
+	w.hasMermaid = w.hasMermaid || hasMermaid
+	return w.files.writeFile(htmlPageFilename(filename), buildHTMLSitePage(w.modelName, markdown.ToHTML(content, nil, nil), hasMermaid))
+}
+
+// WriteSVG writes a diagram, with its links to pages pointing at the HTML pages.
+func (w *htmlSiteWriter) WriteSVG(filename string, content []byte) error {
+	return w.files.WriteSVG(filename, htmlPageLinks(content))
+}
+
+// WriteCSV writes a CSV export.
+func (w *htmlSiteWriter) WriteCSV(filename string, content []byte) error {
+	return w.files.WriteCSV(filename, content)
+}
+
+// WriteCSS writes the stylesheet, with the styles of the site's search.
+func (w *htmlSiteWriter) WriteCSS(content []byte) error {
+	return w.files.WriteCSS(append(append([]byte{}, content...), _HTML_SITE_CSS...))
+}
+
+// writeSiteFiles writes the files the site has besides its pages: the index page, the
+// search index and page, and the Mermaid bundle when a page needs it.
+func (w *htmlSiteWriter) writeSiteFiles(entries []searchEntry) error {
+	index, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	files := []struct {
+		filename string
+		content  []byte
+	}{
+		{HTMLIndexFilename, []byte(`<!DOCTYPE html><html><head><meta charset="utf-8"><meta http-equiv="refresh" content="0; url=model.html"></head><body><a href="model.html">` + html.EscapeString(w.modelName) + `</a></body></html>`)},
+		{HTMLSearchIndexFilename, index},
+		{htmlSearchIndexJS, append(append([]byte("const searchIndex = "), index...), ";\n"...)},
+		{HTMLSearchFilename, buildHTMLSitePage(w.modelName, []byte(_HTML_SEARCH_BODY), false)},
+	}
+	if w.hasMermaid {
+		files = append(files, struct {
+			filename string
+			content  []byte
+		}{MermaidJSFilename, MermaidJS})
+	}
+	for _, file := range files {
+		if err := w.files.writeFile(file.filename, file.content); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// htmlPageFilename is the name of the HTML page of a markdown page.
+func htmlPageFilename(filename string) string {
+	return strings.TrimSuffix(filename, ".md") + ".html"
+}
+
+// htmlPageLinks points the links to markdown pages in rendered content at their HTML pages.
+func htmlPageLinks(content []byte) []byte {
+	return _markdownPageLink.ReplaceAll(content, []byte("${1}${2}.html${3}"))
+}
+
+// buildHTMLSitePage builds a page of the static site around rendered markdown, titled by
+// its first heading, with the search box above it.
+func buildHTMLSitePage(modelName string, mdHTML []byte, hasMermaid bool) []byte {
+	title := html.EscapeString(modelName)
+	if match := _pageHeading.FindSubmatch(mdHTML); match != nil {
+		if heading := strings.TrimSpace(_htmlTag.ReplaceAllString(string(match[1]), "")); heading != "" && heading != title {
+			title = heading + " — " + title
+		}
+	}
+
+	var buf strings.Builder
+	buf.WriteString(`<!DOCTYPE html><html><head><meta charset="utf-8"><title>`)
+	buf.WriteString(title)
+	buf.WriteString(`</title><link rel="stylesheet" href="style.css">`)
+	if hasMermaid {
+		buf.WriteString(`<script src="` + MermaidJSFilename + `"></script>`)
+	}
+	buf.WriteString(`</head><body>`)
+	buf.WriteString(`<form class="site-search" action="` + HTMLSearchFilename + `"><input type="search" name="q" placeholder="Search the model"></form>`)
+	buf.Write(htmlPageLinks(mdHTML))
+	if hasMermaid {
+		buf.WriteString(MermaidRenderScript)
+	}
+	buf.WriteString(`</body></html>`)
+	return []byte(buf.String())
+}
+
+const _HTML_SITE_CSS = `
+.site-search {
+  float: right;
+}
+
+.site-search input {
+  padding: 4px 8px;
+  width: 16em;
+}
+
+.search-result {
+  margin-bottom: 1em;
+}
+
+.search-kind {
+  color: #666;
+  font-size: smaller;
+}
+
+.search-text {
+  color: #333;
+  margin-top: 2px;
+}
+`
+
+// _HTML_SEARCH_BODY is the body of the search page. It reads the query from the page's
+// URL and matches every word of it against the search index, names before other text.
+const _HTML_SEARCH_BODY = `<p><a href="model.html">⇦ Model</a></p>
+<h1>Search</h1>
+<p id="search-summary"></p>
+<div id="search-results"></div>
+<script src="` + htmlSearchIndexJS + `"></script>
+<script>
+(function () {
+  var query = new URLSearchParams(location.search).get('q') || '';
+  document.querySelector('.site-search input').value = query;
+  var words = query.toLowerCase().split(/\s+/).filter(function (word) { return word !== ''; });
+  var summary = document.getElementById('search-summary');
+  var results = document.getElementById('search-results');
+  if (words.length === 0) {
+    summary.textContent = 'Search the names, details and specifications of the model.';
+    return;
+  }
+  var matches = [];
+  searchIndex.forEach(function (entry) {
+    var name = entry.name.toLowerCase();
+    var all = name + ' ' + entry.text.toLowerCase();
+    if (!words.every(function (word) { return all.indexOf(word) >= 0; })) {
+      return;
+    }
+    var rank = words.filter(function (word) { return name.indexOf(word) >= 0; }).length;
+    matches.push({ entry: entry, rank: rank });
+  });
+  matches.sort(function (a, b) { return b.rank - a.rank; });
+  summary.textContent = matches.length + (matches.length === 1 ? ' match' : ' matches') + ' for "' + query + '".';
+  matches.forEach(function (match) {
+    var entry = match.entry;
+    var result = document.createElement('div');
+    result.className = 'search-result';
+    var link = document.createElement('a');
+    link.href = entry.page;
+    link.textContent = entry.name;
+    var kind = document.createElement('span');
+    kind.className = 'search-kind';
+    kind.textContent = ' ' + entry.kind;
+    result.appendChild(link);
+    result.appendChild(kind);
+    if (entry.text !== '') {
+      var text = document.createElement('div');
+      text.className = 'search-text';
+      text.textContent = entry.text.length > 200 ? entry.text.substring(0, 200) + '…' : entry.text;
+      result.appendChild(text);
+    }
+    results.appendChild(result);
+  });
+})();
+</script>`
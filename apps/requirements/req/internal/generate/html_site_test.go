@@ -0,0 +1,108 @@
+package generate
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHTMLPageLinks(t *testing.T) {
+	assert.Equal(t,
+		`<a href="class-domain.a.class.b.html">B</a> <a href="use_case-x.html#step_1">1</a> <a href="https://example.com/read.md">out</a> <a href="dictionary.csv">csv</a>`,
+		string(htmlPageLinks([]byte(`<a href="class-domain.a.class.b.md">B</a> <a href="use_case-x.md#step_1">1</a> <a href="https://example.com/read.md">out</a> <a href="dictionary.csv">csv</a>`))))
+	assert.Equal(t, "model.html", htmlPageFilename("model.md"))
+}
+
+func TestBuildHTMLSitePage(t *testing.T) {
+	page := string(buildHTMLSitePage("Bank", []byte(`<p><a href="model.md">⇦ Bank</a></p><h1 id="x">Account <em>old</em></h1>`), false))
+	assert.Equal(t, `<!DOCTYPE html><html><head><meta charset="utf-8"><title>Account old — Bank</title><link rel="stylesheet" href="style.css"></head><body>`+
+		`<form class="site-search" action="search.html"><input type="search" name="q" placeholder="Search the model"></form>`+
+		`<p><a href="model.html">⇦ Bank</a></p><h1 id="x">Account <em>old</em></h1></body></html>`, page)
+
+	page = string(buildHTMLSitePage("Bank", []byte(`<h1>Bank</h1>`), true))
+	assert.Contains(t, page, `<title>Bank</title>`)
+	assert.Contains(t, page, `<script src="mermaid.min.js"></script></head>`)
+	assert.True(t, strings.HasSuffix(page, MermaidRenderScript+`</body></html>`))
+}
+
+func TestGenerateHTMLFromModel(t *testing.T) {
+	model := test_helper.GetTestModel()
+	outputPath := filepath.Join(t.TempDir(), "site")
+	require.NoError(t, GenerateHTMLFromModel(outputPath, model, nil, nil))
+
+	read := func(filename string) string {
+		contents, err := os.ReadFile(filepath.Join(outputPath, filename))
+		require.NoError(t, err, filename)
+		return string(contents)
+	}
+
+	// Every markdown page is an HTML page, linking to the others' HTML pages.
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+	for filename := range writer.md {
+		page := read(htmlPageFilename(filename))
+		assert.NotRegexp(t, `href="[^"/:]+\.md[#"]`, page, filename)
+		assert.NoFileExists(t, filepath.Join(outputPath, filename))
+	}
+	for filename := range writer.svg {
+		assert.FileExists(t, filepath.Join(outputPath, filename))
+	}
+
+	const orderPage = "class-domain.domain_a.subdomain.subdomain_a.class.order.html"
+	modelPage := read("model.html")
+	assert.Contains(t, modelPage, `<link rel="stylesheet" href="style.css">`)
+	assert.Contains(t, modelPage, `<script src="mermaid.min.js"></script>`)
+	assert.Contains(t, modelPage, `<a href="traceability-classes.html">Traceability</a>`)
+	assert.NotContains(t, modelPage, "EventSource")
+	assert.Contains(t, read("traceability-events.html"), `<a href="`+orderPage+`">`)
+
+	assert.Contains(t, read("style.css"), ".site-search {")
+	assert.Equal(t, string(MermaidJS), read(MermaidJSFilename))
+	assert.Contains(t, read(HTMLIndexFilename), `url=model.html`)
+
+	// The search page finds entries of the index, which the page loads as a script.
+	search := read(HTMLSearchFilename)
+	assert.Contains(t, search, `<script src="search-index.js"></script>`)
+	assert.Contains(t, search, `<form class="site-search" action="search.html">`)
+
+	var entries []searchEntry
+	require.NoError(t, json.Unmarshal([]byte(read(HTMLSearchIndexFilename)), &entries))
+	assert.Equal(t, "const searchIndex = "+read(HTMLSearchIndexFilename)+";\n", read("search-index.js"))
+	assert.Equal(t, buildSearchIndex(model), entries)
+}
+
+func TestBuildSearchIndex(t *testing.T) {
+	model := test_helper.GetTestModel()
+	entries := buildSearchIndex(model)
+
+	find := func(name, kind string) searchEntry {
+		for _, entry := range entries {
+			if entry.Name == name && entry.Kind == kind {
+				return entry
+			}
+		}
+		t.Fatalf("no %s entry %q", kind, name)
+		return searchEntry{}
+	}
+
+	assert.Equal(t, searchEntry{Name: model.Name, Kind: "model", Page: "model.html", Text: strings.Join(strings.Fields(model.Details), " ")}, entries[0])
+
+	const orderPage = "class-domain.domain_a.subdomain.subdomain_a.class.order.html"
+	assert.Equal(t, orderPage, find("Order", "class").Page)
+	assert.Equal(t, orderPage, find("Order · Submit", "event").Page)
+	assert.Equal(t, "use_case-domain.domain_a.subdomain.subdomain_a.usecase.place_order.html", find("Place Order · Happy Path", "scenario").Page)
+
+	// Every page an entry points at is one the site has.
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+	for _, entry := range entries {
+		assert.Contains(t, writer.md, strings.TrimSuffix(entry.Page, ".html")+".md", entry.Name)
+		assert.NotContains(t, entry.Text, "\n", entry.Name)
+	}
+}
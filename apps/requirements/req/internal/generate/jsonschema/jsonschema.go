@@ -0,0 +1,79 @@
+// Package jsonschema generates JSON Schemas for the data of class instances.
+//
+// Each class becomes one schema, an object with a property per attribute. Spans become
+// number bounds, enumerations become enums, collections become arrays and records
+// become nested objects. An attribute holding objects of another class refers to that
+// class's schema file, so the schemas of a model validate together.
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// Dialect is the JSON Schema version of generated schemas.
+const Dialect = "https://json-schema.org/draft/2020-12/schema"
+
+// FileExtension is the extension of generated schema files.
+const FileExtension = ".schema.json"
+
+// File is a generated schema, named for its class.
+type File struct {
+	Name   string // The file name without its extension.
+	Schema *Schema
+}
+
+// Generate writes one schema per class into outputPath.
+func Generate(model core.Model, outputPath string) error {
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, file := range Files(model) {
+		content, err := json.MarshalIndent(file.Schema, "", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		path := filepath.Join(outputPath, file.Name+FileExtension)
+		if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil { //nolint:gosec // generated schemas are intentionally world-readable
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Files returns the schema of every class, ordered by domain, subdomain and class key.
+// Files are named "<domain>.<subdomain>.<class>" from the subkeys.
+func Files(model core.Model) []File {
+	names := make(map[identity.Key]string)
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for classKey := range subdomain.Classes {
+				names[classKey] = domain.Key.SubKey + "." + subdomain.Key.SubKey + "." + classKey.SubKey
+			}
+		}
+	}
+	classRef := func(classKey identity.Key) (string, bool) {
+		name, ok := names[classKey]
+		return name + FileExtension, ok
+	}
+
+	var files []File
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			builder := NewBuilder(model, subdomain, classRef)
+			for _, class := range identity.SortedValues(subdomain.Classes) {
+				schema := builder.Class(class)
+				schema.Dialect = Dialect
+				schema.Title = class.Name
+				files = append(files, File{Name: names[class.Key], Schema: schema})
+			}
+		}
+	}
+	return files
+}
@@ -0,0 +1,114 @@
+package jsonschema
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	validator "github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONSchemaSuite struct {
+	suite.Suite
+}
+
+func TestJSONSchemaSuite(t *testing.T) {
+	suite.Run(t, new(JSONSchemaSuite))
+}
+
+func (suite *JSONSchemaSuite) marshal(value any) string {
+	content, err := json.Marshal(value)
+	suite.Require().NoError(err)
+	return string(content)
+}
+
+func (suite *JSONSchemaSuite) TestFiles() {
+	files := Files(test_helper.GetShopModel())
+	suite.Require().Len(files, 2)
+	suite.Equal("shop.orders.customer", files[0].Name)
+	suite.Equal("shop.orders.order", files[1].Name)
+	suite.JSONEq(`{
+		"$schema": "https://json-schema.org/draft/2020-12/schema",
+		"title": "Order",
+		"type": "object",
+		"description": "A basket being bought.",
+		"properties": {
+			"total": {"type": "number", "minimum": 0, "exclusiveMaximum": 1000, "multipleOf": 0.01, "description": "In dollar."},
+			"status": {"type": ["string", "null"], "enum": ["open", "paid", null]},
+			"buyers": {
+				"type": "array",
+				"items": {"$ref": "shop.orders.customer.schema.json"},
+				"minItems": 1,
+				"maxItems": 3,
+				"uniqueItems": true,
+				"description": "In no particular order."
+			},
+			"address": {
+				"type": "object",
+				"properties": {
+					"street": {},
+					"floor": {"type": "integer", "minimum": 0, "maximum": 100, "description": "In floor."}
+				},
+				"required": ["street", "floor"]
+			}
+		},
+		"required": ["total", "buyers", "address"],
+		"x-req-key": "domain/shop/subdomain/orders/class/order"
+	}`, suite.marshal(files[1].Schema))
+}
+
+// TestValidate checks the schemas compile together and accept and reject instances.
+func (suite *JSONSchemaSuite) TestValidate() {
+	compiler := validator.NewCompiler()
+	for _, file := range Files(test_helper.GetShopModel()) {
+		suite.Require().NoError(compiler.AddResource(file.Name+FileExtension, strings.NewReader(suite.marshal(file.Schema))))
+	}
+	schema, err := compiler.Compile("shop.orders.order" + FileExtension)
+	suite.Require().NoError(err)
+
+	tests := []struct {
+		instance string
+		valid    bool
+	}{
+		{`{"total": 12.5, "buyers": [{"name": "Ann"}], "address": {"street": "Main", "floor": 2}}`, true},
+		{`{"total": 12.5, "status": null, "buyers": [{"name": "Ann"}], "address": {"street": "Main", "floor": 2}}`, true},
+		{`{"total": 1000, "buyers": [{"name": "Ann"}], "address": {"street": "Main", "floor": 2}}`, false},
+		{`{"total": 1, "status": "lost", "buyers": [{"name": "Ann"}], "address": {"street": "Main", "floor": 2}}`, false},
+		{`{"total": 1, "buyers": [], "address": {"street": "Main", "floor": 2}}`, false},
+		{`{"total": 1, "buyers": [{}], "address": {"street": "Main", "floor": 2}}`, false},
+		{`{"total": 1, "buyers": [{"name": "Ann"}], "address": {"street": "Main", "floor": 2.5}}`, false},
+	}
+	for _, tt := range tests {
+		var instance any
+		suite.Require().NoError(json.Unmarshal([]byte(tt.instance), &instance))
+		suite.Equal(tt.valid, schema.Validate(instance) == nil, tt.instance)
+	}
+}
+
+func (suite *JSONSchemaSuite) TestTestModel() {
+	files := Files(test_helper.GetTestModel())
+	suite.Require().NotEmpty(files)
+	compiler := validator.NewCompiler()
+	for _, file := range files {
+		suite.Require().NoError(compiler.AddResource(file.Name+FileExtension, strings.NewReader(suite.marshal(file.Schema))))
+	}
+	for _, file := range files {
+		_, err := compiler.Compile(file.Name + FileExtension)
+		suite.NoError(err, file.Name)
+	}
+}
+
+func (suite *JSONSchemaSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetShopModel(), outputPath))
+	content, err := os.ReadFile(filepath.Join(outputPath, "shop.orders.order"+FileExtension))
+	suite.Require().NoError(err)
+	var schema Schema
+	suite.Require().NoError(json.Unmarshal(content, &schema))
+	suite.Equal(Dialect, schema.Dialect)
+	suite.Equal("Order", schema.Title)
+}
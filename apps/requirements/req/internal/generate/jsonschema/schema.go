@@ -0,0 +1,312 @@
+package jsonschema
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// Span bound types, as the data type parser writes them.
+const (
+	boundClosed = "closed"
+	boundOpen   = "open"
+)
+
+// Schema is a JSON Schema (draft 2020-12), also the dialect of OpenAPI 3.1.
+type Schema struct {
+	Dialect          string             `json:"$schema,omitempty"` // Only set on a root schema.
+	Ref              string             `json:"$ref,omitempty"`
+	Title            string             `json:"title,omitempty"`
+	Type             any                `json:"type,omitempty"` // A type name, or a list of them when nullable.
+	Format           string             `json:"format,omitempty"`
+	Description      string             `json:"description,omitempty"`
+	Enum             []any              `json:"enum,omitempty"`
+	Minimum          *float64           `json:"minimum,omitempty"`
+	ExclusiveMinimum *float64           `json:"exclusiveMinimum,omitempty"`
+	Maximum          *float64           `json:"maximum,omitempty"`
+	ExclusiveMaximum *float64           `json:"exclusiveMaximum,omitempty"`
+	MultipleOf       *float64           `json:"multipleOf,omitempty"`
+	Items            *Schema            `json:"items,omitempty"`
+	PrefixItems      []*Schema          `json:"prefixItems,omitempty"`
+	MinItems         *int               `json:"minItems,omitempty"`
+	MaxItems         *int               `json:"maxItems,omitempty"`
+	UniqueItems      bool               `json:"uniqueItems,omitempty"`
+	Properties       map[string]*Schema `json:"properties,omitempty"`
+	Required         []string           `json:"required,omitempty"`
+	AnyOf            []*Schema          `json:"anyOf,omitempty"`
+	ReadOnly         bool               `json:"readOnly,omitempty"`
+	ReqKey           string             `json:"x-req-key,omitempty"` // The key of the model element the schema describes.
+}
+
+// ClassRefFunc returns the reference to the schema of a class, or false if the class
+// has no schema to refer to.
+type ClassRefFunc func(classKey identity.Key) (ref string, ok bool)
+
+// Builder maps model data types to schemas.
+type Builder struct {
+	classRef   ClassRefFunc
+	bySubKey   map[string]identity.Key // Classes of the subdomain by subkey, as object data types name them.
+	classNames map[identity.Key]string // Names of every class in the model, for descriptions.
+}
+
+// NewBuilder returns a builder for the data types of a subdomain. Objects become
+// references where classRef has one for their class, and are described otherwise.
+func NewBuilder(model core.Model, subdomain model_domain.Subdomain, classRef ClassRefFunc) *Builder {
+	b := &Builder{
+		classRef:   classRef,
+		bySubKey:   make(map[string]identity.Key),
+		classNames: make(map[identity.Key]string),
+	}
+	for _, domain := range model.Domains {
+		for _, modelSubdomain := range domain.Subdomains {
+			for classKey, class := range modelSubdomain.Classes {
+				b.classNames[classKey] = class.Name
+			}
+		}
+	}
+	for classKey := range subdomain.Classes {
+		b.bySubKey[classKey.SubKey] = classKey
+	}
+	return b
+}
+
+// Field returns the schema of an attribute or parameter, admitting null when it is nullable.
+func (b *Builder) Field(dataType *model_data_type.DataType, nullable bool) *Schema {
+	schema := b.DataType(dataType)
+	if nullable {
+		return Nullable(schema)
+	}
+	return schema
+}
+
+// DataType returns the schema of a data type. The data type carries the constraints,
+// such as span bounds, so its type spec is only used when the data type is unconstrained.
+func (b *Builder) DataType(dataType *model_data_type.DataType) *Schema {
+	if dataType == nil {
+		return &Schema{}
+	}
+	switch dataType.CollectionType {
+	case model_data_type.COLLECTION_TYPE_ATOMIC:
+		if unconstrained(dataType.Atomic) {
+			return b.TypeSpec(dataType.TypeSpec)
+		}
+		return b.atomic(dataType.Atomic)
+	case model_data_type.COLLECTION_TYPE_RECORD:
+		return b.record(dataType.RecordFields)
+	}
+
+	// Ordered, unordered, queue and stack collections are all arrays.
+	schema := &Schema{Type: "array", MinItems: dataType.CollectionMin, MaxItems: dataType.CollectionMax}
+	switch {
+	case dataType.ElementDataType != nil:
+		schema.Items = b.DataType(dataType.ElementDataType)
+	case len(dataType.RecordFields) > 0:
+		schema.Items = b.record(dataType.RecordFields)
+	default:
+		schema.Items = b.atomic(dataType.Atomic)
+	}
+	if dataType.CollectionUnique != nil && *dataType.CollectionUnique {
+		schema.UniqueItems = true
+	}
+	switch dataType.CollectionType {
+	case model_data_type.COLLECTION_TYPE_QUEUE:
+		schema.Description = "A first in, first out queue."
+	case model_data_type.COLLECTION_TYPE_STACK:
+		schema.Description = "A first in, last out stack."
+	case model_data_type.COLLECTION_TYPE_UNORDERED:
+		schema.Description = "In no particular order."
+	}
+	return schema
+}
+
+// TypeSpec returns the schema of a type spec, or an unconstrained schema if it did not parse.
+func (b *Builder) TypeSpec(typeSpec *logic_spec.TypeSpec) *Schema {
+	if typeSpec == nil || typeSpec.ExpressionType == nil {
+		return &Schema{}
+	}
+	return b.expressionType(typeSpec.ExpressionType)
+}
+
+// Class returns the schema of the data of a class: an object with a property per
+// attribute. Derived attributes are read only, and nullable ones are not required.
+func (b *Builder) Class(class model_class.Class) *Schema {
+	schema := &Schema{Type: "object", Description: class.Details, Properties: make(map[string]*Schema), ReqKey: class.Key.String()}
+	for _, attr := range class.Attributes {
+		property := b.Field(attr.DataType, attr.Nullable)
+		if property.Description == "" {
+			property.Description = attr.Details
+		}
+		property.ReadOnly = attr.DerivationPolicy != nil
+		schema.Properties[attr.Key.SubKey] = property
+		if !attr.Nullable {
+			schema.Required = append(schema.Required, attr.Key.SubKey)
+		}
+	}
+	return schema
+}
+
+func (b *Builder) atomic(atomic *model_data_type.Atomic) *Schema {
+	if atomic == nil {
+		return &Schema{}
+	}
+	switch atomic.ConstraintType {
+	case model_data_type.CONSTRAINT_TYPE_DATETIME:
+		return &Schema{Type: "string", Format: "date-time"}
+	case model_data_type.CONSTRAINT_TYPE_SPAN:
+		return spanSchema(atomic.Span)
+	case model_data_type.CONSTRAINT_TYPE_ENUMERATION:
+		schema := &Schema{Type: "string"}
+		for _, enum := range atomic.Enums {
+			schema.Enum = append(schema.Enum, enum.Value)
+		}
+		return schema
+	case model_data_type.CONSTRAINT_TYPE_REFERENCE:
+		schema := &Schema{Type: "string"}
+		if atomic.Reference != nil {
+			schema.Description = "A reference to " + *atomic.Reference + "."
+		}
+		return schema
+	case model_data_type.CONSTRAINT_TYPE_OBJECT:
+		if atomic.ObjectClassKey != nil {
+			if classKey, ok := b.bySubKey[*atomic.ObjectClassKey]; ok {
+				return b.object(classKey)
+			}
+			return &Schema{Description: "An object of class " + *atomic.ObjectClassKey + "."}
+		}
+	}
+	return &Schema{}
+}
+
+// record returns an object schema with a required property per field.
+func (b *Builder) record(fields []model_data_type.Field) *Schema {
+	schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+	for _, field := range fields {
+		schema.Properties[field.Name] = b.DataType(field.FieldDataType)
+		schema.Required = append(schema.Required, field.Name)
+	}
+	return schema
+}
+
+// object returns a reference to the schema of a class, or a description of a class
+// without one.
+func (b *Builder) object(classKey identity.Key) *Schema {
+	if ref, ok := b.classRef(classKey); ok {
+		return &Schema{Ref: ref}
+	}
+	if name, ok := b.classNames[classKey]; ok {
+		return &Schema{Description: "An object of class " + name + "."}
+	}
+	return &Schema{Description: "An object of class " + classKey.SubKey + "."}
+}
+
+func (b *Builder) expressionType(expressionType logic_expression_type.ExpressionType) *Schema {
+	switch t := expressionType.(type) {
+	case *logic_expression_type.BooleanType:
+		return &Schema{Type: "boolean"}
+	case *logic_expression_type.IntegerType:
+		return &Schema{Type: "integer"}
+	case *logic_expression_type.RationalType:
+		return &Schema{Type: "number"}
+	case *logic_expression_type.StringType:
+		return &Schema{Type: "string"}
+	case *logic_expression_type.EnumType:
+		schema := &Schema{Type: "string"}
+		for _, value := range t.Values {
+			schema.Enum = append(schema.Enum, value)
+		}
+		return schema
+	case *logic_expression_type.SequenceType:
+		return &Schema{Type: "array", Items: b.expressionType(t.ElementType), UniqueItems: t.Unique}
+	case *logic_expression_type.TupleType:
+		count := len(t.ElementTypes)
+		schema := &Schema{Type: "array", MinItems: &count, MaxItems: &count}
+		for _, elementType := range t.ElementTypes {
+			schema.PrefixItems = append(schema.PrefixItems, b.expressionType(elementType))
+		}
+		return schema
+	case *logic_expression_type.RecordType:
+		schema := &Schema{Type: "object", Properties: make(map[string]*Schema)}
+		for _, field := range t.Fields {
+			schema.Properties[field.Name] = b.expressionType(field.Type)
+			schema.Required = append(schema.Required, field.Name)
+		}
+		return schema
+	case *logic_expression_type.ObjectType:
+		return b.object(t.ClassKey)
+	}
+	// Functions have no JSON representation.
+	return &Schema{}
+}
+
+// spanSchema translates a span into a number schema with its bounds. A span whose
+// precision is a whole number and whose bounds are not fractions is an integer.
+func spanSchema(span *model_data_type.AtomicSpan) *Schema {
+	if span == nil {
+		return &Schema{Type: "number"}
+	}
+	schema := &Schema{Type: "integer"}
+	if span.Fractional() {
+		schema.Type = "number"
+	}
+	if span.Precision > 0 && span.Precision != 1 {
+		precision := span.Precision
+		schema.MultipleOf = &precision
+	}
+	if lower := boundValue(span.LowerValue, span.LowerDenominator); lower != nil {
+		switch span.LowerType {
+		case boundClosed:
+			schema.Minimum = lower
+		case boundOpen:
+			schema.ExclusiveMinimum = lower
+		}
+	}
+	if higher := boundValue(span.HigherValue, span.HigherDenominator); higher != nil {
+		switch span.HigherType {
+		case boundClosed:
+			schema.Maximum = higher
+		case boundOpen:
+			schema.ExclusiveMaximum = higher
+		}
+	}
+	if span.Units != "" {
+		schema.Description = "In " + span.Units + "."
+	}
+	return schema
+}
+
+func boundValue(value, denominator *int) *float64 {
+	if value == nil {
+		return nil
+	}
+	bound := float64(*value)
+	if denominator != nil && *denominator != 0 {
+		bound /= float64(*denominator)
+	}
+	return &bound
+}
+
+// Nullable admits null alongside the values of schema.
+func Nullable(schema *Schema) *Schema {
+	switch t := schema.Type.(type) {
+	case string:
+		schema.Type = []string{t, "null"}
+		if schema.Enum != nil {
+			schema.Enum = append(schema.Enum, nil)
+		}
+		return schema
+	case nil:
+		if schema.Ref == "" && len(schema.AnyOf) == 0 {
+			// An unconstrained schema already admits null.
+			return schema
+		}
+	}
+	return &Schema{AnyOf: []*Schema{schema, {Type: "null"}}}
+}
+
+func unconstrained(atomic *model_data_type.Atomic) bool {
+	return atomic == nil || atomic.ConstraintType == model_data_type.CONSTRAINT_TYPE_UNCONSTRAINED
+}
@@ -0,0 +1,202 @@
+package generate
+
+import (
+	"log"
+	"sort"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_actor"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+)
+
+// LaTeXFilename is the document the latex output writes beside its diagrams.
+const LaTeXFilename = "model.tex"
+
+// GenerateLaTeXFromModel generates a LaTeX document of an already-parsed model for formal
+// review: a section per domain, subsection per subdomain and subsubsection per class and
+// use case, with TLA+ specifications typeset as math, a glossary of the model's terms and
+// an index. Class and state diagrams are rendered by Graphviz to SVG files beside the
+// document, which includes them with the svg package, so the document is built with
+// shell escape and Inkscape on the path (latexmk -pdf -shell-escape model.tex).
+func GenerateLaTeXFromModel(outputPath string, parsedModel core.Model) (err error) { //nolint:revive // public API name
+	// Create necessary output paths if we don't have them.
+	if err = createMissingPaths([]string{outputPath}); err != nil {
+		return err
+	}
+
+	log.Println()
+
+	reqs := req_flat.NewRequirements(parsedModel)
+	reqs.PrepLookups()
+
+	writer := NewFileWriter(outputPath)
+	contents, err := generateLaTeXContents(reqs, writer)
+	if err != nil {
+		return err
+	}
+	if err = writer.writeFile(LaTeXFilename, []byte(contents)); err != nil {
+		return err
+	}
+
+	log.Println()
+
+	return nil
+}
+
+// latexDocument is the data of the LaTeX document template.
+type latexDocument struct {
+	Reqs     *req_flat.Requirements
+	Model    core.Model
+	Actors   []model_actor.Actor
+	Domains  []model_domain.Domain
+	Glossary []DictionaryEntry
+
+	classesDiagrams map[identity.Key]string // Subdomain key to its class diagram.
+	stateDiagrams   map[identity.Key]string // Class key to its state diagram.
+}
+
+// ClassesDiagram is the diagram file of a subdomain's classes, empty when it has none.
+func (d latexDocument) ClassesDiagram(subdomainKey identity.Key) string {
+	return d.classesDiagrams[subdomainKey]
+}
+
+// StateDiagram is the diagram file of a class's state machine, empty when it has none.
+func (d latexDocument) StateDiagram(classKey identity.Key) string {
+	return d.stateDiagrams[classKey]
+}
+
+// generateLaTeXContents renders the LaTeX document of a model, writing its diagrams to
+// the writer.
+func generateLaTeXContents(reqs *req_flat.Requirements, writer ContentWriter) (string, error) {
+	doc := latexDocument{
+		Reqs:            reqs,
+		Model:           reqs.Model,
+		Glossary:        modelDictionaryEntries(reqs.Model),
+		classesDiagrams: map[identity.Key]string{},
+		stateDiagrams:   map[identity.Key]string{},
+	}
+	for _, actor := range reqs.Actors {
+		doc.Actors = append(doc.Actors, actor)
+	}
+	sort.Slice(doc.Actors, func(i, j int) bool {
+		return doc.Actors[i].Key.String() < doc.Actors[j].Key.String()
+	})
+	for _, domain := range reqs.Domains {
+		doc.Domains = append(doc.Domains, domain)
+	}
+	sort.Slice(doc.Domains, func(i, j int) bool {
+		return doc.Domains[i].Key.String() < doc.Domains[j].Key.String()
+	})
+
+	for _, domain := range doc.Domains {
+		for _, subdomain := range domain.Subdomains {
+			if err := writeLaTeXSubdomainDiagrams(reqs, writer, &doc, subdomain); err != nil {
+				return "", err
+			}
+		}
+	}
+
+	return generateFromTemplate(_modelTexTemplate, doc)
+}
+
+// writeLaTeXSubdomainDiagrams draws the class diagram of a subdomain and the state
+// machines of its classes.
+func writeLaTeXSubdomainDiagrams(reqs *req_flat.Requirements, writer ContentWriter, doc *latexDocument, subdomain model_domain.Subdomain) error {
+	var classes []model_class.Class
+	for _, class := range subdomain.Classes {
+		classes = append(classes, class)
+	}
+	generalizations, allClasses, associations := reqs.RegardingClasses(classes)
+	if len(allClasses) > 0 {
+		dot, err := generateClassesGraphvizContents(reqs, generalizations, allClasses, associations, subdomain.Key, nil)
+		if err != nil {
+			return err
+		}
+		filename, err := writeLaTeXDiagram(writer, dot, "subdomain", subdomain.Key, "classes")
+		if err != nil {
+			return err
+		}
+		doc.classesDiagrams[subdomain.Key] = filename
+	}
+
+	for _, class := range classes {
+		if len(class.States) == 0 {
+			continue
+		}
+		var counts map[identity.Key]int
+		if stateCoverage != nil {
+			counts = transitionCoverageCounts(class, stateCoverage.Steps)
+		}
+		filename, err := writeLaTeXDiagram(writer, generateClassStateGraphvizContents(class, counts), "class", class.Key, "states")
+		if err != nil {
+			return err
+		}
+		doc.stateDiagrams[class.Key] = filename
+	}
+
+	return nil
+}
+
+// writeLaTeXDiagram renders a DOT graph to an SVG file and returns the name the document
+// includes it by: the filename without its extension, and with the dots of the key
+// spelled as underscores since \includesvg reads a dot as the start of the extension.
+func writeLaTeXDiagram(writer ContentWriter, dot, objType string, key identity.Key, suffix string) (string, error) {
+	svg, err := renderGraphvizSVG(dot)
+	if err != nil {
+		return "", err
+	}
+	name := strings.ReplaceAll(convertKeyToFilename(objType, key.String(), suffix, ""), ".", "_")
+	if err := writer.WriteSVG(name+".svg", svg); err != nil {
+		return "", err
+	}
+	return name, nil
+}
+
+// latexSpec typesets a logic specification as TLA+ math, displayed on its own line. A
+// specification that does not parse is shown as written in a typewriter font.
+func latexSpec(spec logic_spec.ExpressionSpec) string {
+	text := strings.TrimSpace(spec.Specification)
+	if text == "" {
+		return ""
+	}
+	expr, err := convert.ParseNotation(spec.Notation, text)
+	if err != nil {
+		return "\\begin{quote}\n\\texttt{" + latexText(strings.Join(strings.Fields(text), " ")) + "}\n\\end{quote}"
+	}
+	return "\\begin{quote}\n$" + ast.PrintLaTeX(expr) + "$\n\\end{quote}"
+}
+
+// _latexIndexReplacer quotes the characters makeindex reads as part of an entry's syntax.
+var _latexIndexReplacer = strings.NewReplacer(`"`, `""`, `!`, `"!`, `@`, `"@`, `|`, `"|`)
+
+// latexIndex is an index entry for a name, with each further name a subentry of the one
+// before. Entries sort by their names without case or surrounding marks, so «new» sorts
+// under n.
+func latexIndex(names ...string) string {
+	var levels []string
+	for _, name := range names {
+		sortTerm := dictionarySortTerm(name)
+		if sortTerm == "" {
+			sortTerm = name
+		}
+		levels = append(levels, _latexIndexReplacer.Replace(sortTerm)+"@"+_latexIndexReplacer.Replace(latexText(name)))
+	}
+	return `\index{` + strings.Join(levels, "!") + `}`
+}
+
+// latexLabel is the label of the section of a part of the model, to refer to it by.
+func latexLabel(key identity.Key) string {
+	return `\label{` + key.String() + `}`
+}
+
+// latexRef refers to the section of a part of the model by its number.
+func latexRef(key identity.Key) string {
+	return `\ref{` + key.String() + `}`
+}
@@ -0,0 +1,176 @@
+package generate
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gomarkdown/markdown"
+	"github.com/gomarkdown/markdown/ast"
+	"github.com/gomarkdown/markdown/parser"
+)
+
+// _latexTextReplacer escapes the characters LaTeX treats specially in text, and spells
+// the non-ASCII marks models use (guillemets, arrows, logic symbols) as LaTeX commands
+// so the document needs no Unicode font.
+var _latexTextReplacer = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`$`, `\$`,
+	`&`, `\&`,
+	`#`, `\#`,
+	`%`, `\%`,
+	`_`, `\_`,
+	`^`, `\textasciicircum{}`,
+	`~`, `\textasciitilde{}`,
+	`<`, `\textless{}`,
+	`>`, `\textgreater{}`,
+	`|`, `\textbar{}`,
+	"«", `\guillemotleft{}`,
+	"»", `\guillemotright{}`,
+	"“", "``",
+	"”", "''",
+	"‘", "`",
+	"’", "'",
+	"—", "---",
+	"–", "--",
+	"…", `\ldots{}`,
+	"·", `$\cdot$`,
+	"→", `$\rightarrow$`,
+	"←", `$\leftarrow$`,
+	"⇒", `$\Rightarrow$`,
+	"⇐", `$\Leftarrow$`,
+	"⇦", `$\Leftarrow$`,
+	"≜", `$\triangleq$`,
+	"∀", `$\forall$`,
+	"∃", `$\exists$`,
+	"∈", `$\in$`,
+	"∉", `$\notin$`,
+	"∧", `$\land$`,
+	"∨", `$\lor$`,
+	"¬", `$\lnot$`,
+	"≠", `$\neq$`,
+	"≤", `$\leq$`,
+	"≥", `$\geq$`,
+	"⊆", `$\subseteq$`,
+	"∪", `$\cup$`,
+	"∩", `$\cap$`,
+	"×", `$\times$`,
+	"′", `$'$`,
+)
+
+// latexText escapes plain text for a LaTeX document.
+func latexText(text string) string {
+	return _latexTextReplacer.Replace(text)
+}
+
+// markdownToLaTeX renders the markdown of model details as LaTeX. Raw HTML is dropped,
+// and links to other pages of the markdown documentation keep only their text.
+func markdownToLaTeX(md string) string {
+	doc := markdown.Parse([]byte(md), parser.NewWithExtensions(parser.CommonExtensions))
+	var b strings.Builder
+	ast.WalkFunc(doc, func(node ast.Node, entering bool) ast.WalkStatus {
+		return writeMarkdownLaTeXNode(&b, node, entering)
+	})
+	return strings.TrimSpace(b.String())
+}
+
+func writeMarkdownLaTeXNode(b *strings.Builder, node ast.Node, entering bool) ast.WalkStatus {
+	// enclose writes the start of a node on entering it and the end on leaving it.
+	enclose := func(start, end string) {
+		if entering {
+			b.WriteString(start)
+		} else {
+			b.WriteString(end)
+		}
+	}
+
+	switch n := node.(type) {
+	case *ast.Text:
+		b.WriteString(latexText(string(n.Literal)))
+	case *ast.Paragraph:
+		if !entering {
+			if list, ok := n.Parent.GetParent().(*ast.List); ok && list.Tight {
+				b.WriteString("\n")
+			} else {
+				b.WriteString("\n\n")
+			}
+		}
+	case *ast.Emph:
+		enclose(`\emph{`, `}`)
+	case *ast.Strong:
+		enclose(`\textbf{`, `}`)
+	case *ast.Del:
+		enclose(`\sout{`, `}`)
+	case *ast.Code:
+		b.WriteString(`\texttt{` + latexText(string(n.Literal)) + `}`)
+	case *ast.CodeBlock:
+		b.WriteString("\\begin{verbatim}\n" + strings.TrimRight(string(n.Literal), "\n") + "\n\\end{verbatim}\n\n")
+	case *ast.Link:
+		if strings.Contains(string(n.Destination), "://") {
+			enclose(`\href{`+latexURL(string(n.Destination))+`}{`, `}`)
+		}
+	case *ast.Image:
+		// Pictures of the markdown pages, such as actor icons, have no place in the document.
+		return ast.SkipChildren
+	case *ast.Heading:
+		enclose(`\paragraph*{`, "}\n")
+	case *ast.List:
+		environment := "itemize"
+		if n.ListFlags&ast.ListTypeOrdered != 0 {
+			environment = "enumerate"
+		}
+		enclose(`\begin{`+environment+"}\n", `\end{`+environment+"}\n\n")
+	case *ast.ListItem:
+		if entering {
+			b.WriteString(`\item `)
+		}
+	case *ast.BlockQuote:
+		enclose("\\begin{quote}\n", "\\end{quote}\n\n")
+	case *ast.HorizontalRule:
+		b.WriteString("\\noindent\\rule{\\linewidth}{0.4pt}\n\n")
+	case *ast.Softbreak:
+		b.WriteString("\n")
+	case *ast.Hardbreak:
+		b.WriteString("\\\\\n")
+	case *ast.Table:
+		if entering {
+			columns := markdownTableColumns(n)
+			fmt.Fprintf(b, "\\begin{tabular}{*{%d}{p{\\dimexpr\\linewidth/%d-2\\tabcolsep\\relax}}}\n\\hline\n", columns, columns)
+		} else {
+			b.WriteString("\\end{tabular}\n\n")
+		}
+	case *ast.TableRow:
+		if !entering {
+			b.WriteString(" \\\\\n\\hline\n")
+		}
+	case *ast.TableCell:
+		if entering && n.Parent.GetChildren()[0] != node {
+			b.WriteString(" & ")
+		}
+		if n.IsHeader {
+			enclose(`\textbf{`, `}`)
+		}
+	case *ast.HTMLSpan, *ast.HTMLBlock:
+		// Raw HTML has no LaTeX rendering.
+	}
+	return ast.GoToNext
+}
+
+// markdownTableColumns counts the columns of a table by the cells of its first row.
+func markdownTableColumns(table *ast.Table) int {
+	columns := 1
+	ast.WalkFunc(table, func(node ast.Node, entering bool) ast.WalkStatus {
+		if row, ok := node.(*ast.TableRow); ok && entering {
+			columns = max(len(row.Children), 1)
+			return ast.Terminate
+		}
+		return ast.GoToNext
+	})
+	return columns
+}
+
+// latexURL escapes the characters of a URL that \href cannot take as written.
+func latexURL(url string) string {
+	return strings.NewReplacer(`\`, `\\`, `#`, `\#`, `%`, `\%`, `{`, `\{`, `}`, `\}`).Replace(url)
+}
@@ -0,0 +1,92 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLatexText(t *testing.T) {
+	assert.Equal(t, `50\% of \$x\_y \& \{z\}`, latexText(`50% of $x_y & {z}`))
+	assert.Equal(t, `\guillemotleft{}new\guillemotright{} $\rightarrow$ Order --- Submit`, latexText("«new» → Order — Submit"))
+}
+
+func TestMarkdownToLaTeX(t *testing.T) {
+	assert.Equal(t, `An \emph{open} order, see \textbf{Order} and \texttt{is\_open}.`, markdownToLaTeX("An *open* order, see **Order** and `is_open`."))
+	assert.Equal(t, "Steps:\n\n\\begin{enumerate}\n\\item First\n\\item Second\n\\end{enumerate}", markdownToLaTeX("Steps:\n\n1. First\n2. Second\n"))
+	assert.Equal(t, `See Order and \href{https://example.com/a\#b}{the spec}.`, markdownToLaTeX("See [Order](class-order.md) and [the spec](https://example.com/a#b)."))
+	assert.Equal(t, "Before.", markdownToLaTeX("Before.<br>![icon](person.svg)"))
+	assert.Equal(t, "", markdownToLaTeX(""))
+}
+
+func TestLatexSpec(t *testing.T) {
+	assert.Equal(t, "\\begin{quote}\n$\\forall x \\in S : x \\geq 0$\n\\end{quote}",
+		latexSpec(logic_spec.ExpressionSpec{Notation: logic_spec.NotationTLAPlus, Specification: `\A x \in S : x >= 0`}))
+	assert.Equal(t, "\\begin{quote}\n$\\forall x \\in S : x \\geq 0$\n\\end{quote}",
+		latexSpec(logic_spec.ExpressionSpec{Notation: logic_spec.NotationInfix, Specification: `all x in S: x >= 0`}))
+
+	// Specifications that do not parse are shown as written.
+	assert.Equal(t, "\\begin{quote}\n\\texttt{x.toJSON() \\& y}\n\\end{quote}",
+		latexSpec(logic_spec.ExpressionSpec{Notation: logic_spec.NotationTLAPlus, Specification: "x.toJSON()\n  & y"}))
+	assert.Equal(t, "", latexSpec(logic_spec.ExpressionSpec{Notation: logic_spec.NotationTLAPlus}))
+}
+
+func TestLatexIndex(t *testing.T) {
+	assert.Equal(t, `\index{order@Order}`, latexIndex("Order"))
+	assert.Equal(t, `\index{order@Order!new@\guillemotleft{}new\guillemotright{}}`, latexIndex("Order", "«new»"))
+	assert.Equal(t, `\index{a"!b@a"!b}`, latexIndex("a!b"))
+}
+
+func TestGenerateLaTeXContents(t *testing.T) {
+	reqs := req_flat.NewRequirements(test_helper.GetTestModel())
+	reqs.PrepLookups()
+	writer := newCollectWriter()
+	contents, err := generateLaTeXContents(reqs, writer)
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(contents, "% Test Model\n"))
+	assert.True(t, strings.HasSuffix(contents, "\\printindex\n\n\\end{document}\n"))
+	assert.Contains(t, contents, "\\tableofcontents\n")
+
+	// Domains, subdomains and classes are numbered sections in that order, labeled by key.
+	domain := strings.Index(contents, `\section{Commerce}\label{domain/domain_a}`)
+	subdomain := strings.Index(contents, `\subsection{Order Management}\label{domain/domain_a/subdomain/subdomain_a}`)
+	class := strings.Index(contents, `\subsubsection{Order}\label{domain/domain_a/subdomain/subdomain_a/class/order}\index{order@Order}`)
+	assert.Less(t, -1, domain)
+	assert.Less(t, domain, subdomain)
+	assert.Less(t, subdomain, class)
+
+	// Specifications are typeset as math.
+	assert.Contains(t, contents, "\\item Inventory cannot go negative\n\\begin{quote}\n$\\mathit{inventory}' \\geq 0$\n\\end{quote}")
+
+	// Scenario steps are numbered.
+	assert.Contains(t, contents, "\\begin{enumerate}\n\\item Alice:Customer $\\rightarrow$ Order 42: Customer submits order --- Submit(")
+
+	// The glossary refers to the sections of its terms.
+	assert.Contains(t, contents, `\item[{Submit}] \emph{event of Order, \S\ref{domain/domain_a/subdomain/subdomain_a/class/order}.} Customer submits the order.`)
+
+	// Every diagram the document includes is written beside it.
+	assert.Contains(t, contents, `{class-domain_domain_a_subdomain_subdomain_a_class_order-states}`)
+	for filename := range writer.svg {
+		assert.Contains(t, contents, "{"+strings.TrimSuffix(filename, ".svg")+"}\n\\caption", filename)
+		assert.NotContains(t, strings.TrimSuffix(filename, ".svg"), ".", filename)
+	}
+	assert.Len(t, writer.svg, strings.Count(contents, `\includesvg`))
+}
+
+func TestGenerateLaTeXFromModel(t *testing.T) {
+	outputPath := filepath.Join(t.TempDir(), "latex")
+	require.NoError(t, GenerateLaTeXFromModel(outputPath, test_helper.GetTestModel()))
+
+	contents, err := os.ReadFile(filepath.Join(outputPath, LaTeXFilename))
+	require.NoError(t, err)
+	assert.Contains(t, string(contents), `\documentclass`)
+	assert.FileExists(t, filepath.Join(outputPath, "class-domain_domain_a_subdomain_subdomain_a_class_order-states.svg"))
+}
@@ -185,7 +185,11 @@ func logicMarkdownSpecLines(logic model_logic.Logic) string {
 }
 
 // destroyEventSpecBoldDisplay renders destroy_event using canonical TLA+ system event spellings.
+// Infix keeps the _destroy spelling, which is how that notation writes system events.
 func destroyEventSpecBoldDisplay(spec logic_spec.ExpressionSpec) string {
+	if displayedNotation(spec) == logic_spec.NotationInfix {
+		return expressionSpecBoldDisplay(spec)
+	}
 	display := systemEventCallSpecDisplay(spec.Specification)
 	if display == "" {
 		return ""
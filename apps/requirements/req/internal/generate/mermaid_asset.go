@@ -0,0 +1,32 @@
+package generate
+
+import (
+	"bytes"
+	_ "embed"
+)
+
+// MermaidJS is the Mermaid 11.x bundle that renders the diagrams of the generated
+// pages, served by the HTTP server and copied into the static HTML site so diagram
+// pages work offline.
+//
+//go:embed assets/mermaid.min.js
+var MermaidJS []byte
+
+// MermaidJSFilename is the name the Mermaid bundle is served or written under.
+const MermaidJSFilename = "mermaid.min.js"
+
+// MermaidRenderScript replaces the code blocks that gomarkdown renders Mermaid
+// diagrams as with the diagrams themselves. It goes at the end of the page body,
+// after the Mermaid bundle has been loaded.
+const MermaidRenderScript = `<script>` +
+	`document.querySelectorAll('pre code.language-mermaid').forEach(function(el){` +
+	`var d=document.createElement('div');d.className='mermaid';` +
+	`d.textContent=el.textContent;el.parentElement.replaceWith(d);});` +
+	`mermaid.initialize({startOnLoad:false,securityLevel:'loose'});mermaid.run();` +
+	`</script>`
+
+// MarkdownHasMermaid reports whether a markdown page has a Mermaid diagram, and so
+// needs the Mermaid bundle.
+func MarkdownHasMermaid(data []byte) bool {
+	return bytes.Contains(data, []byte("```mermaid"))
+}
@@ -0,0 +1,25 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMarkdownHasMermaid(t *testing.T) {
+	tests := []struct {
+		name string
+		md   string
+		want bool
+	}{
+		{name: "mermaid fence", md: "# Title\n\n```mermaid\nclassDiagram\n```\n", want: true},
+		{name: "no mermaid", md: "# Title\n\nPlain text only.\n", want: false},
+		{name: "other fence", md: "# Title\n\n```go\npackage main\n```\n", want: false},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.want, MarkdownHasMermaid([]byte(tc.md)))
+		})
+	}
+}
@@ -0,0 +1,26 @@
+package generate
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/metrics"
+)
+
+// metricsBaseline is the earlier metrics snapshot the metrics page shows the trend against.
+// Nil shows no trend.
+var metricsBaseline *metrics.Metrics
+
+// SetMetricsBaseline chooses the metrics snapshot the generated metrics page compares
+// the model against. Nil shows the metrics without a trend.
+func SetMetricsBaseline(baseline *metrics.Metrics) {
+	metricsBaseline = baseline
+}
+
+// MetricsBaseline returns the metrics snapshot set with SetMetricsBaseline, or nil.
+func MetricsBaseline() *metrics.Metrics {
+	return metricsBaseline
+}
+
+// generateMetricsMdContents renders the metrics page of a model.
+func generateMetricsMdContents(model core.Model) string {
+	return "[⇦ " + model.Name + "](model.md)\n\n" + metrics.Markdown(metrics.Compute(model), metricsBaseline)
+}
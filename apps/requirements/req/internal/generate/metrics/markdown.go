@@ -0,0 +1,120 @@
+package metrics
+
+import (
+	"fmt"
+	"strings"
+)
+
+// column is one column of a metrics table.
+type column struct {
+	Heading string
+	Value   func(Counts) int
+	Percent bool // The value is a percentage.
+}
+
+// _columns are the columns of every metrics table, with completeness first as the
+// number people plan around.
+var _columns = []column{
+	{Heading: "Completeness", Value: func(c Counts) int { return c.Completeness }, Percent: true},
+	{Heading: "Classes", Value: func(c Counts) int { return c.Classes }},
+	{Heading: "Without details", Value: func(c Counts) int { return c.ClassesWithoutDetails }},
+	{Heading: "Attributes", Value: func(c Counts) int { return c.Attributes }},
+	{Heading: "Untyped", Value: func(c Counts) int { return c.UntypedAttributes }},
+	{Heading: "States", Value: func(c Counts) int { return c.States }},
+	{Heading: "Transitions", Value: func(c Counts) int { return c.Transitions }},
+	{Heading: "Events", Value: func(c Counts) int { return c.Events }},
+	{Heading: "Without transitions", Value: func(c Counts) int { return c.EventsWithoutTransitions }},
+	{Heading: "Logic", Value: func(c Counts) int { return c.Logic }},
+	{Heading: "Without specification", Value: func(c Counts) int { return c.LogicWithoutSpecification }},
+	{Heading: "Use cases", Value: func(c Counts) int { return c.UseCases }},
+	{Heading: "Unfinished", Value: func(c Counts) int { return c.UnfinishedNotes }},
+}
+
+// _newMarker follows the name of a domain or subdomain that is not in the previous snapshot.
+const _newMarker = " *(new)*"
+
+// Markdown renders metrics as a markdown page: a table of domains, then a table of
+// subdomains for each domain. When previous is not nil each number is followed by its
+// change since that snapshot, matched by key; rows new since the snapshot are marked.
+func Markdown(current Metrics, previous *Metrics) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Metrics — %s\n\n", current.Model)
+	b.WriteString("Completeness is the percentage of health checks that pass: classes have details, attributes have data type rules that parse, ")
+	b.WriteString("events have transitions, logic has specifications, and nothing has unfinished notes.\n")
+	if previous != nil {
+		b.WriteString("Changes in parentheses are since the previous snapshot; rows marked new were not in it.\n")
+	}
+
+	var previousModel *Counts
+	previousDomains := map[string]Domain{}
+	if previous != nil {
+		previousModel = &previous.Counts
+		for _, domain := range previous.Domains {
+			previousDomains[domain.Key] = domain
+		}
+	}
+
+	b.WriteString("\n## Domains\n\n")
+	writeHeader(&b, "Domain")
+	writeRow(&b, "**Model**", current.Counts, previousModel)
+	for _, domain := range current.Domains {
+		name, before := domain.Name, (*Counts)(nil)
+		if previousDomain, ok := previousDomains[domain.Key]; ok {
+			before = &previousDomain.Counts
+		} else if previous != nil {
+			name += _newMarker
+		}
+		writeRow(&b, name, domain.Counts, before)
+	}
+
+	for _, domain := range current.Domains {
+		previousSubdomains := map[string]Counts{}
+		for _, subdomain := range previousDomains[domain.Key].Subdomains {
+			previousSubdomains[subdomain.Key] = subdomain.Counts
+		}
+
+		fmt.Fprintf(&b, "\n## %s\n\n", domain.Name)
+		writeHeader(&b, "Subdomain")
+		for _, subdomain := range domain.Subdomains {
+			name, before := subdomain.Name, (*Counts)(nil)
+			if previousSubdomain, ok := previousSubdomains[subdomain.Key]; ok {
+				before = &previousSubdomain
+			} else if previous != nil {
+				name += _newMarker
+			}
+			writeRow(&b, name, subdomain.Counts, before)
+		}
+	}
+	return b.String()
+}
+
+func writeHeader(b *strings.Builder, first string) {
+	b.WriteString("| " + first)
+	for _, col := range _columns {
+		b.WriteString(" | " + col.Heading)
+	}
+	b.WriteString(" |\n|---")
+	for range _columns {
+		b.WriteString("|--:")
+	}
+	b.WriteString("|\n")
+}
+
+// writeRow writes a table row. A nil before shows no trend.
+func writeRow(b *strings.Builder, name string, counts Counts, before *Counts) {
+	b.WriteString("| " + strings.ReplaceAll(name, "|", `\|`))
+	for _, col := range _columns {
+		value := col.Value(counts)
+		cell := fmt.Sprintf("%d", value)
+		if col.Percent {
+			cell += "%"
+		}
+		if before != nil {
+			if delta := value - col.Value(*before); delta != 0 {
+				cell += fmt.Sprintf(" (%+d)", delta)
+			}
+		}
+		b.WriteString(" | " + cell)
+	}
+	b.WriteString(" |\n")
+}
@@ -0,0 +1,259 @@
+// Package metrics counts the elements of a model and how complete each subdomain is.
+//
+// Counts are gathered per subdomain and totalled per domain and for the model. Each
+// subdomain gets a completeness percentage: the share of its health checks that pass.
+// The checks are that every class has details, every attribute has data type rules
+// that parse, every event has a transition, every logic entry has a specification, and
+// that the subdomain, its classes, its use cases and their generalizations carry no
+// unfinished notes. Metrics are written as JSON so a later run can show the trend
+// against an earlier snapshot.
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// The files Generate writes.
+const (
+	JSONFilename     = "metrics.json"
+	MarkdownFilename = "metrics.md"
+)
+
+// Counts are the element counts and health indicators of a subdomain, domain or model.
+type Counts struct {
+	Classes                   int `json:"classes"`
+	ClassesWithoutDetails     int `json:"classes_without_details"`
+	Attributes                int `json:"attributes"`
+	UntypedAttributes         int `json:"untyped_attributes"` // No data type rules, or rules that did not parse.
+	States                    int `json:"states"`
+	Transitions               int `json:"transitions"`
+	Events                    int `json:"events"`
+	EventsWithoutTransitions  int `json:"events_without_transitions"`
+	Logic                     int `json:"logic"`
+	LogicWithoutSpecification int `json:"logic_without_specification"`
+	UseCases                  int `json:"use_cases"`
+	UnfinishedNotes           int `json:"unfinished_notes"` // Elements with unfinished notes.
+	Checks                    int `json:"checks"`
+	Passed                    int `json:"passed"`
+	Completeness              int `json:"completeness"` // The percentage of checks passed.
+}
+
+// Subdomain holds the metrics of one subdomain.
+type Subdomain struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Counts
+}
+
+// Domain holds the metrics of one domain, totalled over its subdomains.
+type Domain struct {
+	Key  string `json:"key"`
+	Name string `json:"name"`
+	Counts
+	Subdomains []Subdomain `json:"subdomains"`
+}
+
+// Metrics holds the metrics of a model, totalled over its domains.
+type Metrics struct {
+	Model string `json:"model"`
+	Counts
+	Domains []Domain `json:"domains"`
+}
+
+// Compute gathers the metrics of a model. Domains and subdomains are sorted by name.
+func Compute(model core.Model) Metrics {
+	metrics := Metrics{Model: model.Name}
+	for _, domain := range sortedDomains(model.Domains) {
+		domainMetrics := Domain{Key: domain.Key.String(), Name: domain.Name}
+		for _, subdomain := range sortedSubdomains(domain.Subdomains) {
+			subdomainMetrics := Subdomain{Key: subdomain.Key.String(), Name: subdomain.Name, Counts: subdomainCounts(subdomain)}
+			domainMetrics.add(subdomainMetrics.Counts)
+			domainMetrics.Subdomains = append(domainMetrics.Subdomains, subdomainMetrics)
+		}
+		metrics.add(domainMetrics.Counts)
+		metrics.Domains = append(metrics.Domains, domainMetrics)
+	}
+	return metrics
+}
+
+func subdomainCounts(subdomain model_domain.Subdomain) Counts {
+	var counts Counts
+	counts.note(subdomain.UnfinishedNotes)
+	for _, useCase := range subdomain.UseCases {
+		counts.UseCases++
+		counts.note(useCase.UnfinishedNotes)
+	}
+	for _, generalization := range subdomain.UseCaseGeneralizations {
+		counts.note(generalization.UnfinishedNotes)
+	}
+	for _, generalization := range subdomain.Generalizations {
+		counts.note(generalization.UnfinishedNotes)
+	}
+	for _, class := range subdomain.Classes {
+		counts.addClass(class)
+	}
+	counts.Completeness = completeness(counts.Passed, counts.Checks)
+	return counts
+}
+
+func (c *Counts) addClass(class model_class.Class) {
+	c.Classes++
+	c.check(strings.TrimSpace(class.Details) != "", &c.ClassesWithoutDetails)
+	c.note(class.UnfinishedNotes)
+
+	c.logic(class.Invariants...)
+	for _, attr := range class.Attributes {
+		c.Attributes++
+		c.check(strings.TrimSpace(attr.DataTypeRules) != "" && attr.DataType != nil, &c.UntypedAttributes)
+		if attr.DerivationPolicy != nil {
+			c.logic(*attr.DerivationPolicy)
+		}
+		c.logic(attr.Invariants...)
+	}
+
+	c.States += len(class.States)
+	c.Transitions += len(class.Transitions)
+	transitioned := map[string]bool{}
+	for _, transition := range class.Transitions {
+		transitioned[transition.EventKey.String()] = true
+	}
+	for key := range class.Events {
+		c.Events++
+		c.check(transitioned[key.String()], &c.EventsWithoutTransitions)
+	}
+
+	for _, guard := range class.Guards {
+		c.logic(guard.Logic)
+	}
+	for _, action := range class.Actions {
+		c.logic(action.Requires...)
+		c.logic(action.Guarantees...)
+		c.logic(action.SafetyRules...)
+	}
+	for _, query := range class.Queries {
+		c.logic(query.Requires...)
+		c.logic(query.Guarantees...)
+	}
+}
+
+// check records a health check, counting it against failures when it does not pass.
+func (c *Counts) check(passed bool, failures *int) {
+	c.Checks++
+	if passed {
+		c.Passed++
+		return
+	}
+	*failures++
+}
+
+// note checks that an element has no unfinished notes.
+func (c *Counts) note(unfinishedNotes string) {
+	c.check(strings.TrimSpace(unfinishedNotes) == "", &c.UnfinishedNotes)
+}
+
+// logic checks that each logic entry has a specification.
+func (c *Counts) logic(logics ...model_logic.Logic) {
+	for _, logic := range logics {
+		c.Logic++
+		c.check(strings.TrimSpace(logic.Spec.Specification) != "", &c.LogicWithoutSpecification)
+	}
+}
+
+// add totals other counts into these and updates the completeness.
+func (c *Counts) add(other Counts) {
+	c.Classes += other.Classes
+	c.ClassesWithoutDetails += other.ClassesWithoutDetails
+	c.Attributes += other.Attributes
+	c.UntypedAttributes += other.UntypedAttributes
+	c.States += other.States
+	c.Transitions += other.Transitions
+	c.Events += other.Events
+	c.EventsWithoutTransitions += other.EventsWithoutTransitions
+	c.Logic += other.Logic
+	c.LogicWithoutSpecification += other.LogicWithoutSpecification
+	c.UseCases += other.UseCases
+	c.UnfinishedNotes += other.UnfinishedNotes
+	c.Checks += other.Checks
+	c.Passed += other.Passed
+	c.Completeness = completeness(c.Passed, c.Checks)
+}
+
+// completeness is the percentage of checks passed, rounded down. With nothing to check
+// there is nothing missing.
+func completeness(passed, checks int) int {
+	if checks == 0 {
+		return 100
+	}
+	return passed * 100 / checks
+}
+
+func sortedDomains(domains map[identity.Key]model_domain.Domain) []model_domain.Domain {
+	sorted := make([]model_domain.Domain, 0, len(domains))
+	for _, domain := range domains {
+		sorted = append(sorted, domain)
+	}
+	slices.SortFunc(sorted, func(a, b model_domain.Domain) int {
+		return compareNameThenKey(a.Name, b.Name, a.Key.String(), b.Key.String())
+	})
+	return sorted
+}
+
+func sortedSubdomains(subdomains map[identity.Key]model_domain.Subdomain) []model_domain.Subdomain {
+	sorted := make([]model_domain.Subdomain, 0, len(subdomains))
+	for _, subdomain := range subdomains {
+		sorted = append(sorted, subdomain)
+	}
+	slices.SortFunc(sorted, func(a, b model_domain.Subdomain) int {
+		return compareNameThenKey(a.Name, b.Name, a.Key.String(), b.Key.String())
+	})
+	return sorted
+}
+
+func compareNameThenKey(aName, bName, aKey, bKey string) int {
+	if c := strings.Compare(aName, bName); c != 0 {
+		return c
+	}
+	return strings.Compare(aKey, bKey)
+}
+
+// Read loads a metrics JSON snapshot.
+func Read(path string) (*Metrics, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the snapshot path is chosen by the user
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var metrics Metrics
+	if err := json.Unmarshal(data, &metrics); err != nil {
+		return nil, errors.Wrapf(err, "read metrics snapshot '%s'", path)
+	}
+	return &metrics, nil
+}
+
+// Generate writes the metrics of a model into outputPath as JSON and markdown. The
+// markdown shows the trend against previous, when not nil.
+func Generate(model core.Model, outputPath string, previous *Metrics) error {
+	metrics := Compute(model)
+	data, err := json.MarshalIndent(metrics, "", "  ")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.WriteFile(filepath.Join(outputPath, JSONFilename), append(data, '\n'), 0o644); err != nil { //nolint:gosec // generated metrics are intentionally world-readable
+		return errors.WithStack(err)
+	}
+	return errors.WithStack(os.WriteFile(filepath.Join(outputPath, MarkdownFilename), []byte(Markdown(metrics, previous)), 0o644)) //nolint:gosec // generated metrics are intentionally world-readable
+}
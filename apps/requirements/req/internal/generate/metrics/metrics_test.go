@@ -0,0 +1,117 @@
+package metrics
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type MetricsSuite struct {
+	suite.Suite
+}
+
+func TestMetricsSuite(t *testing.T) {
+	suite.Run(t, new(MetricsSuite))
+}
+
+func (suite *MetricsSuite) TestCompute() {
+	metrics := Compute(test_helper.GetBankModel())
+
+	// The accounts subdomain has thirty checks: its notes and those of its use case and
+	// generalization; the details and notes of five classes; five attributes, six events
+	// and six logic entries. Only the account has details, its nickname attribute has no
+	// type and its audit event no transition.
+	accounts := Counts{
+		Classes:                  5,
+		ClassesWithoutDetails:    4,
+		Attributes:               5,
+		UntypedAttributes:        1,
+		States:                   3,
+		Transitions:              5,
+		Events:                   6,
+		EventsWithoutTransitions: 1,
+		Logic:                    6,
+		UseCases:                 1,
+		Checks:                   30,
+		Passed:                   24,
+		Completeness:             80,
+	}
+	// The ledger has three: its notes and the details and notes of its entry, which has
+	// no details and unfinished notes.
+	ledger := Counts{
+		Classes:               1,
+		ClassesWithoutDetails: 1,
+		UnfinishedNotes:       1,
+		Checks:                3,
+		Passed:                1,
+		Completeness:          33,
+	}
+	expected := Counts{
+		Classes:                  6,
+		ClassesWithoutDetails:    5,
+		Attributes:               5,
+		UntypedAttributes:        1,
+		States:                   3,
+		Transitions:              5,
+		Events:                   6,
+		EventsWithoutTransitions: 1,
+		Logic:                    6,
+		UseCases:                 1,
+		UnfinishedNotes:          1,
+		Checks:                   33,
+		Passed:                   25,
+		Completeness:             75,
+	}
+	suite.Equal("Bank", metrics.Model)
+	suite.Equal(expected, metrics.Counts)
+	suite.Require().Len(metrics.Domains, 1)
+	suite.Equal(expected, metrics.Domains[0].Counts)
+	suite.Require().Len(metrics.Domains[0].Subdomains, 2)
+	suite.Equal("domain/bank/subdomain/accounts", metrics.Domains[0].Subdomains[0].Key)
+	suite.Equal(accounts, metrics.Domains[0].Subdomains[0].Counts)
+	suite.Equal("domain/bank/subdomain/ledger", metrics.Domains[0].Subdomains[1].Key)
+	suite.Equal(ledger, metrics.Domains[0].Subdomains[1].Counts)
+}
+
+func (suite *MetricsSuite) TestCompletenessOfEmptySubdomain() {
+	suite.Equal(100, completeness(0, 0))
+	suite.Equal(66, completeness(2, 3))
+}
+
+func (suite *MetricsSuite) TestMarkdown() {
+	current := Compute(test_helper.GetBankModel())
+
+	page := Markdown(current, nil)
+	suite.Contains(page, "# Metrics — Bank\n")
+	suite.Contains(page, "| **Model** | 75% | 6 | 5 | 5 | 1 | 3 | 5 | 6 | 1 | 6 | 0 | 1 | 1 |\n")
+	suite.Contains(page, "\n## Bank\n\n| Subdomain | Completeness |")
+	suite.Contains(page, "| Accounts | 80% | 5 |")
+	suite.NotContains(page, "(+")
+
+	// Against an earlier snapshot the entry was missing, everything was finished and the
+	// accounts subdomain had another key.
+	previous := Compute(test_helper.GetBankModel())
+	previous.Completeness = 100
+	previous.Classes = 5
+	previous.Domains[0].Subdomains[0].Key = "domain/bank/subdomain/old"
+	page = Markdown(current, &previous)
+	suite.Contains(page, "| **Model** | 75% (-25) | 6 (+1) | 5 | 5 |")
+	suite.Contains(page, "| Bank | 75% | 6 |")
+	suite.Contains(page, "| Accounts *(new)* | 80% | 5 |")
+}
+
+func (suite *MetricsSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath, nil))
+
+	written, err := Read(filepath.Join(outputPath, JSONFilename))
+	suite.Require().NoError(err)
+	suite.Equal(Compute(test_helper.GetBankModel()), *written)
+
+	page, err := os.ReadFile(filepath.Join(outputPath, MarkdownFilename))
+	suite.Require().NoError(err)
+	suite.Equal(Markdown(*written, nil), string(page))
+}
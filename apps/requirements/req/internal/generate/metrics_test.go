@@ -0,0 +1,28 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/metrics"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateMetricsPage(t *testing.T) {
+	model := test_helper.GetTestModel()
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	assert.Contains(t, string(writer.md["model.md"]), "[Metrics](metrics.md)")
+	page := string(writer.md["metrics.md"])
+	assert.Contains(t, page, "[⇦ "+model.Name+"](model.md)\n\n# Metrics — "+model.Name)
+	assert.NotContains(t, page, "previous snapshot")
+
+	// With a baseline the page shows the trend against it.
+	baseline := metrics.Compute(model)
+	baseline.Classes++
+	SetMetricsBaseline(&baseline)
+	defer SetMetricsBaseline(nil)
+	assert.Contains(t, generateMetricsMdContents(model), "(-1)")
+}
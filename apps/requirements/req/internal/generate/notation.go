@@ -0,0 +1,40 @@
+package generate
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+
+	"github.com/pkg/errors"
+)
+
+// displayNotation is the notation logic specifications are shown in.
+// Empty shows every specification in the notation its file was written in.
+var displayNotation string
+
+// SetDisplayNotation chooses the notation generated markdown shows logic specifications in
+// (tla_plus or infix). An empty notation shows each specification as written.
+func SetDisplayNotation(notation string) error {
+	if notation != "" && !logic_spec.IsExpressionNotation(notation) {
+		return errors.Errorf("notation '%s' is not valid, want one of: %s, %s", notation, logic_spec.NotationTLAPlus, logic_spec.NotationInfix)
+	}
+	displayNotation = notation
+	return nil
+}
+
+// displayedNotation returns the notation a specification will be shown in.
+func displayedNotation(spec logic_spec.ExpressionSpec) string {
+	if displayNotation == "" {
+		return spec.Notation
+	}
+	return displayNotation
+}
+
+// specificationForDisplay returns the specification text in the display notation.
+// Text that cannot be translated (for example, it does not parse) is shown as written.
+func specificationForDisplay(spec logic_spec.ExpressionSpec) string {
+	translated, err := convert.TranslateSpecification(spec.Specification, spec.Notation, displayedNotation(spec))
+	if err != nil {
+		return spec.Specification
+	}
+	return translated
+}
@@ -0,0 +1,47 @@
+package generate
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetDisplayNotation(t *testing.T) {
+	t.Cleanup(func() { displayNotation = "" })
+
+	require.NoError(t, SetDisplayNotation(logic_spec.NotationInfix))
+	require.NoError(t, SetDisplayNotation(""))
+	require.EqualError(t, SetDisplayNotation("z"), "notation 'z' is not valid, want one of: tla_plus, infix")
+}
+
+func TestExpressionSpecDisplayNotation(t *testing.T) {
+	t.Cleanup(func() { displayNotation = "" })
+
+	tla := logic_spec.ExpressionSpec{Notation: logic_spec.NotationTLAPlus, Specification: `\A x \in S : x > 0 /\ y`}
+	infix := logic_spec.ExpressionSpec{Notation: logic_spec.NotationInfix, Specification: "r with {a: 1}"}
+	broken := logic_spec.ExpressionSpec{Notation: logic_spec.NotationTLAPlus, Specification: `x \in`}
+
+	// As written.
+	require.Equal(t, `\A x \in S : x > 0 /\ y`, expressionSpecDisplay(tla))
+	require.Equal(t, "r with {a: 1}", expressionSpecDisplay(infix))
+
+	require.NoError(t, SetDisplayNotation(logic_spec.NotationInfix))
+	require.Equal(t, "all x in S: x > 0 && y", expressionSpecDisplay(tla))
+	require.Equal(t, "r with {a: 1}", expressionSpecDisplay(infix))
+	require.Equal(t, `x \in`, expressionSpecDisplay(broken))
+
+	require.NoError(t, SetDisplayNotation(logic_spec.NotationTLAPlus))
+	require.Equal(t, `\A x \in S : x > 0 /\ y`, expressionSpecDisplay(tla))
+	require.Equal(t, "[r EXCEPT !.a = 1]", expressionSpecDisplay(infix))
+}
+
+func TestDestroyEventSpecDisplayNotation(t *testing.T) {
+	t.Cleanup(func() { displayNotation = "" })
+
+	spec := logic_spec.ExpressionSpec{Notation: logic_spec.NotationTLAPlus, Specification: "_destroy(b)"}
+	require.Equal(t, "**«destroy»(b)**", destroyEventSpecBoldDisplay(spec))
+
+	require.NoError(t, SetDisplayNotation(logic_spec.NotationInfix))
+	require.Equal(t, "**_destroy(b)**", destroyEventSpecBoldDisplay(spec))
+}
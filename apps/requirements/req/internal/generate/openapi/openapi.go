@@ -0,0 +1,139 @@
+// Package openapi generates OpenAPI 3.1 documents from a model.
+//
+// Each subdomain becomes one document describing the contract of its classes. An event
+// that an actor-backed class sends to a class of the subdomain in some scenario becomes
+// an operation, and every query becomes a GET operation. Request schemas come from the
+// parameter data types, and the requires and guarantees of the actions and queries
+// behind each operation are its description, so the contract stays traceable to the model.
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/jsonschema"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// Version is the OpenAPI version of generated documents.
+const Version = "3.1.0"
+
+// FileExtension is the extension of generated document files.
+const FileExtension = ".openapi.json"
+
+// Document is an OpenAPI document.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Tags       []Tag                `json:"tags,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components *Components          `json:"components,omitempty"`
+}
+
+// Info describes the API of a document.
+type Info struct {
+	Title       string `json:"title"`
+	Description string `json:"description,omitempty"`
+	Version     string `json:"version"`
+}
+
+// Tag groups the operations of one class.
+type Tag struct {
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PathItem holds the operations on one path.
+type PathItem struct {
+	Get    *Operation `json:"get,omitempty"`
+	Post   *Operation `json:"post,omitempty"`
+	Delete *Operation `json:"delete,omitempty"`
+}
+
+// Operation is one operation of the API.
+type Operation struct {
+	OperationID string               `json:"operationId"`
+	Summary     string               `json:"summary,omitempty"`
+	Description string               `json:"description,omitempty"`
+	Tags        []string             `json:"tags,omitempty"`
+	Parameters  []Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody         `json:"requestBody,omitempty"`
+	Responses   map[string]*Response `json:"responses"`
+	ReqKey      string               `json:"x-req-key,omitempty"` // The key of the event or query the operation comes from.
+}
+
+// Parameter is a path or query parameter of an operation.
+type Parameter struct {
+	Name        string             `json:"name"`
+	In          string             `json:"in"`
+	Description string             `json:"description,omitempty"`
+	Required    bool               `json:"required"`
+	Schema      *jsonschema.Schema `json:"schema"`
+}
+
+// RequestBody is the JSON body of an operation.
+type RequestBody struct {
+	Required bool                  `json:"required"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+// Response is one response of an operation.
+type Response struct {
+	Description string                `json:"description"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+// MediaType holds the schema of a body.
+type MediaType struct {
+	Schema *jsonschema.Schema `json:"schema"`
+}
+
+// Components holds the schemas operations refer to.
+type Components struct {
+	Schemas map[string]*jsonschema.Schema `json:"schemas,omitempty"`
+}
+
+// File is a generated document, named for its subdomain.
+type File struct {
+	Name     string // The file name without its extension.
+	Document Document
+}
+
+// Generate writes one document per subdomain into outputPath.
+func Generate(model core.Model, outputPath string) error {
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, file := range Files(model) {
+		content, err := json.MarshalIndent(file.Document, "", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		path := filepath.Join(outputPath, file.Name+FileExtension)
+		if err := os.WriteFile(path, append(content, '\n'), 0o644); err != nil { //nolint:gosec // generated documents are intentionally world-readable
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Files returns the document of every subdomain that has at least one operation,
+// ordered by domain and subdomain key.
+func Files(model core.Model) []File {
+	sent := actorSentEvents(model)
+	var files []File
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			document := SubdomainDocument(model, domain, subdomain, sent)
+			if len(document.Paths) == 0 {
+				continue
+			}
+			files = append(files, File{Name: domain.Key.SubKey + "." + subdomain.Key.SubKey, Document: document})
+		}
+	}
+	return files
+}
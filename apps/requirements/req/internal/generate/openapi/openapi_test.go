@@ -0,0 +1,105 @@
+package openapi
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type OpenAPISuite struct {
+	suite.Suite
+}
+
+func TestOpenAPISuite(t *testing.T) {
+	suite.Run(t, new(OpenAPISuite))
+}
+
+func (suite *OpenAPISuite) marshal(value any) string {
+	content, err := json.Marshal(value)
+	suite.Require().NoError(err)
+	return string(content)
+}
+
+func (suite *OpenAPISuite) TestSubdomainDocument() {
+	files := Files(test_helper.GetShopModel())
+	suite.Require().Len(files, 1)
+	suite.Equal("shop.orders", files[0].Name)
+	document := files[0].Document
+	suite.Equal(Version, document.OpenAPI)
+	suite.Equal(Info{Title: "Shop / Orders", Version: "shop"}, document.Info)
+	suite.Equal([]Tag{{Name: "Order", Description: "A basket being bought."}}, document.Tags)
+
+	// The ship event is never sent by an actor, so it is not an operation.
+	suite.Len(document.Paths, 2)
+	pay := document.Paths["/order/{id}/pay"].Post
+	suite.Require().NotNil(pay)
+	suite.Equal("order_pay", pay.OperationID)
+	suite.Equal("domain/shop/subdomain/orders/class/order/event/pay", pay.ReqKey)
+	suite.Equal("Money arrives.\n\nSent by Shopper.\n\nFrom Open to Paid, running Pay.\n\nRequires:\n\n- `amount = self.total`: Pays the whole total.\n\nGuarantees:\n\n- `status' = \"paid\"`", pay.Description)
+	suite.JSONEq(`{"required": true, "content": {"application/json": {"schema": {
+		"type": "object",
+		"properties": {"amount": {"type": "integer", "minimum": 1, "maximum": 500, "description": "In unit."}},
+		"required": ["amount"]
+	}}}}`, suite.marshal(pay.RequestBody))
+
+	total := document.Paths["/order/total"].Get
+	suite.Require().NotNil(total)
+	suite.JSONEq(`{"200": {"description": "The query result.", "content": {"application/json": {"schema": {
+		"type": "object",
+		"properties": {"total": {"type": "integer"}},
+		"required": ["total"]
+	}}}}}`, suite.marshal(total.Responses))
+}
+
+func (suite *OpenAPISuite) TestClassSchemas() {
+	document := Files(test_helper.GetShopModel())[0].Document
+	suite.JSONEq(`{
+		"type": "object",
+		"description": "A basket being bought.",
+		"properties": {
+			"total": {"type": "number", "minimum": 0, "exclusiveMaximum": 1000, "multipleOf": 0.01, "description": "In dollar."},
+			"status": {"type": ["string", "null"], "enum": ["open", "paid", null]},
+			"buyers": {
+				"type": "array",
+				"items": {"$ref": "#/components/schemas/Customer"},
+				"minItems": 1,
+				"maxItems": 3,
+				"uniqueItems": true,
+				"description": "In no particular order."
+			},
+			"address": {
+				"type": "object",
+				"properties": {
+					"street": {},
+					"floor": {"type": "integer", "minimum": 0, "maximum": 100, "description": "In floor."}
+				},
+				"required": ["street", "floor"]
+			}
+		},
+		"required": ["total", "buyers", "address"],
+		"x-req-key": "domain/shop/subdomain/orders/class/order"
+	}`, suite.marshal(document.Components.Schemas["Order"]))
+	suite.Contains(document.Components.Schemas, "Customer")
+}
+
+func (suite *OpenAPISuite) TestTestModel() {
+	files := Files(test_helper.GetTestModel())
+	suite.Require().NotEmpty(files)
+	for _, file := range files {
+		suite.NotEmpty(file.Document.Paths, file.Name)
+	}
+}
+
+func (suite *OpenAPISuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetShopModel(), outputPath))
+	content, err := os.ReadFile(filepath.Join(outputPath, "shop.orders"+FileExtension))
+	suite.Require().NoError(err)
+	var document Document
+	suite.Require().NoError(json.Unmarshal(content, &document))
+	suite.Equal(Version, document.OpenAPI)
+}
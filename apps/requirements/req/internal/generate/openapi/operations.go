@@ -0,0 +1,390 @@
+package openapi
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/jsonschema"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+const (
+	contentTypeJSON = "application/json"
+	idParameter     = "id"
+)
+
+// SubdomainDocument returns the document for the classes of one subdomain. sent
+// holds, for each event an actor-backed class sends, the names of the actors sending it.
+func SubdomainDocument(model core.Model, domain model_domain.Domain, subdomain model_domain.Subdomain, sent map[identity.Key][]string) Document {
+	classes := identity.SortedValues(subdomain.Classes)
+	components := componentNames(classes)
+	schemas := jsonschema.NewBuilder(model, subdomain, func(classKey identity.Key) (string, bool) {
+		name, ok := components[classKey]
+		return componentRef(name), ok
+	})
+
+	document := Document{
+		OpenAPI: Version,
+		Info: Info{
+			Title:       domain.Name + " / " + subdomain.Name,
+			Description: strings.TrimSpace(subdomain.Details),
+			Version:     model.Key,
+		},
+		Paths:      make(map[string]*PathItem),
+		Components: &Components{Schemas: make(map[string]*jsonschema.Schema)},
+	}
+	for _, class := range classes {
+		w := &operationWriter{document: &document, schemas: schemas, class: class}
+		for _, event := range identity.SortedValues(class.Events) {
+			if actors, ok := sent[event.Key]; ok {
+				w.event(event, actors)
+			}
+		}
+		for _, query := range identity.SortedValues(class.Queries) {
+			w.query(query)
+		}
+		if w.operations > 0 {
+			document.Tags = append(document.Tags, Tag{Name: class.Name, Description: strings.TrimSpace(class.Details)})
+		}
+		document.Components.Schemas[components[class.Key]] = schemas.Class(class)
+	}
+	return document
+}
+
+// componentNames returns the component schema name of each class, numbering
+// classes whose names would collide.
+func componentNames(classes []model_class.Class) map[identity.Key]string {
+	names := make(map[identity.Key]string)
+	used := make(map[string]bool)
+	for _, class := range classes {
+		name := componentName(class.Name)
+		for i := 2; used[name]; i++ {
+			name = componentName(class.Name) + strconv.Itoa(i)
+		}
+		used[name] = true
+		names[class.Key] = name
+	}
+	return names
+}
+
+// componentRef is the reference to a component schema.
+func componentRef(name string) string {
+	return "#/components/schemas/" + name
+}
+
+// operationWriter adds the operations of one class to a document.
+type operationWriter struct {
+	document   *Document
+	schemas    *jsonschema.Builder
+	class      model_class.Class
+	operations int
+}
+
+// event adds the operation for an event: creation posts to the class, destruction
+// deletes an object, and any other event posts to the object.
+func (w *operationWriter) event(event model_state.Event, actors []string) {
+	transitions := w.transitionsOn(event.Key)
+	operation := &Operation{
+		OperationID: w.class.Key.SubKey + "_" + strings.TrimPrefix(event.Key.SubKey, "_"),
+		Summary:     event.Name,
+		Description: w.eventDescription(event, actors, transitions),
+		Tags:        []string{w.class.Name},
+		RequestBody: w.eventBody(event, transitions),
+		Responses: map[string]*Response{
+			"409": {Description: "No transition from the current state of the object accepts the event, or the requires of its action do not hold."},
+		},
+		ReqKey: event.Key.String(),
+	}
+
+	classPath := "/" + w.class.Key.SubKey
+	objectPath := classPath + "/{" + idParameter + "}"
+	switch event.Name {
+	case model_state.EventNameNew:
+		operation.Responses["201"] = &Response{Description: "The object was created."}
+		w.path(classPath).Post = operation
+	case model_state.EventNameDestroy:
+		operation.Parameters = []Parameter{w.idParameter()}
+		operation.Responses["204"] = &Response{Description: "The object was destroyed."}
+		w.path(objectPath).Delete = operation
+	default:
+		operation.Parameters = []Parameter{w.idParameter()}
+		operation.Responses["204"] = &Response{Description: "The event was accepted."}
+		w.path(objectPath + "/" + event.Key.SubKey).Post = operation
+	}
+	w.operations++
+}
+
+// query adds the GET operation for a query, whose parameters are query parameters and
+// whose result holds the targets of its query guarantees.
+func (w *operationWriter) query(query model_state.Query) {
+	operation := &Operation{
+		OperationID: w.class.Key.SubKey + "_" + query.Key.SubKey,
+		Summary:     query.Name,
+		Description: joinParagraphs(strings.TrimSpace(query.Details), logicList("Requires", query.Requires), logicList("Guarantees", query.Guarantees)),
+		Tags:        []string{w.class.Name},
+		Responses:   make(map[string]*Response),
+		ReqKey:      query.Key.String(),
+	}
+	for _, param := range query.Parameters {
+		operation.Parameters = append(operation.Parameters, Parameter{
+			Name:        param.Key.SubKey,
+			In:          "query",
+			Description: strings.TrimSpace(param.DataTypeRules),
+			Required:    !param.Nullable,
+			Schema:      w.schemas.Field(param.DataType, false),
+		})
+	}
+
+	result := &jsonschema.Schema{Type: "object", Properties: make(map[string]*jsonschema.Schema)}
+	for _, guarantee := range query.Guarantees {
+		if guarantee.Type != model_logic.LogicTypeQuery {
+			continue
+		}
+		property := w.schemas.TypeSpec(guarantee.TargetTypeSpec)
+		property.Description = strings.TrimSpace(guarantee.Description)
+		result.Properties[guarantee.Target] = property
+		result.Required = append(result.Required, guarantee.Target)
+	}
+	if len(result.Properties) > 0 {
+		operation.Responses["200"] = &Response{Description: "The query result.", Content: jsonContent(result)}
+	} else {
+		operation.Responses["204"] = &Response{Description: "The query ran."}
+	}
+	if len(query.Requires) > 0 {
+		operation.Responses["409"] = &Response{Description: "The requires of the query do not hold."}
+	}
+
+	w.path("/" + w.class.Key.SubKey + "/" + query.Key.SubKey).Get = operation
+	w.operations++
+}
+
+// eventBody returns the body of an event's parameters, or nil if it has none. Each
+// parameter is typed by the parameter of the same name of an action the event runs.
+func (w *operationWriter) eventBody(event model_state.Event, transitions []model_state.Transition) *RequestBody {
+	if len(event.ParameterNames) == 0 {
+		return nil
+	}
+	schema := &jsonschema.Schema{Type: "object", Properties: make(map[string]*jsonschema.Schema)}
+	for _, name := range event.ParameterNames {
+		property := &jsonschema.Schema{}
+		required := true
+		if param := w.actionParameter(transitions, name); param != nil {
+			property = w.schemas.Field(param.DataType, param.Nullable)
+			required = !param.Nullable
+		}
+		schema.Properties[name] = property
+		if required {
+			schema.Required = append(schema.Required, name)
+		}
+	}
+	return &RequestBody{Required: true, Content: jsonContent(schema)}
+}
+
+func (w *operationWriter) actionParameter(transitions []model_state.Transition, name string) *model_state.Parameter {
+	for _, transition := range transitions {
+		if transition.ActionKey == nil {
+			continue
+		}
+		for _, param := range w.class.Actions[*transition.ActionKey].Parameters {
+			if param.Name == name {
+				return &param
+			}
+		}
+	}
+	return nil
+}
+
+// eventDescription describes an event: its details, the actors sending it, and each
+// transition on it with the requires and guarantees of the action it runs.
+func (w *operationWriter) eventDescription(event model_state.Event, actors []string, transitions []model_state.Transition) string {
+	paragraphs := []string{strings.TrimSpace(event.Details), "Sent by " + strings.Join(actors, ", ") + "."}
+	for _, transition := range transitions {
+		text := fmt.Sprintf("From %s to %s", w.stateName(transition.FromStateKey), w.stateName(transition.ToStateKey))
+		if transition.GuardKey != nil {
+			if guard, ok := w.class.Guards[*transition.GuardKey]; ok {
+				text += ", when " + guard.Name
+				if spec := logicText(guard.Logic); spec != "" {
+					text += " (`" + spec + "`)"
+				}
+			}
+		}
+		if transition.ActionKey == nil {
+			paragraphs = append(paragraphs, text+".")
+			continue
+		}
+		action := w.class.Actions[*transition.ActionKey]
+		paragraphs = append(paragraphs, text+", running "+action.Name+".",
+			logicList("Requires", action.Requires),
+			logicList("Guarantees", action.Guarantees))
+	}
+	return joinParagraphs(paragraphs...)
+}
+
+func (w *operationWriter) transitionsOn(eventKey identity.Key) []model_state.Transition {
+	var transitions []model_state.Transition
+	for _, transition := range identity.SortedValues(w.class.Transitions) {
+		if transition.EventKey == eventKey {
+			transitions = append(transitions, transition)
+		}
+	}
+	return transitions
+}
+
+func (w *operationWriter) stateName(stateKey *identity.Key) string {
+	if stateKey == nil {
+		return "none"
+	}
+	if state, ok := w.class.States[*stateKey]; ok {
+		return state.Name
+	}
+	return "none"
+}
+
+func (w *operationWriter) idParameter() Parameter {
+	return Parameter{
+		Name:        idParameter,
+		In:          "path",
+		Description: "The identifier of the " + w.class.Name + ".",
+		Required:    true,
+		Schema:      &jsonschema.Schema{Type: "string"},
+	}
+}
+
+func (w *operationWriter) path(path string) *PathItem {
+	item, ok := w.document.Paths[path]
+	if !ok {
+		item = &PathItem{}
+		w.document.Paths[path] = item
+	}
+	return item
+}
+
+// actorSentEvents finds the events sent from objects of actor-backed classes in the
+// scenario steps of the model, with the names of the actors sending each.
+func actorSentEvents(model core.Model) map[identity.Key][]string {
+	actorOf := make(map[identity.Key]string)
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for classKey, class := range subdomain.Classes {
+				if class.ActorKey == nil {
+					continue
+				}
+				name := class.Name
+				if actor, ok := model.Actors[*class.ActorKey]; ok {
+					name = actor.Name
+				}
+				actorOf[classKey] = name
+			}
+		}
+	}
+
+	sent := make(map[identity.Key][]string)
+	var walk func(step model_scenario.Step, objects map[identity.Key]model_scenario.Object)
+	walk = func(step model_scenario.Step, objects map[identity.Key]model_scenario.Object) {
+		for _, statement := range step.Statements {
+			walk(statement, objects)
+		}
+		if step.LeafType == nil || *step.LeafType != model_scenario.LEAF_TYPE_EVENT || step.EventKey == nil || step.FromObjectKey == nil {
+			return
+		}
+		actor, ok := actorOf[objects[*step.FromObjectKey].ClassKey]
+		if ok && !slices.Contains(sent[*step.EventKey], actor) {
+			sent[*step.EventKey] = append(sent[*step.EventKey], actor)
+			slices.Sort(sent[*step.EventKey])
+		}
+	}
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for _, useCase := range subdomain.UseCases {
+				for _, scenario := range useCase.Scenarios {
+					if scenario.Steps != nil {
+						walk(*scenario.Steps, scenario.Objects)
+					}
+				}
+			}
+		}
+	}
+	return sent
+}
+
+// logicList renders logic as a markdown list led by title, or nothing if there is none.
+func logicList(title string, logics []model_logic.Logic) string {
+	var items []string
+	for _, logic := range logics {
+		text := logicText(logic)
+		if text == "" {
+			continue
+		}
+		item := "- `" + text + "`"
+		if description := strings.TrimSpace(logic.Description); description != "" {
+			item += ": " + description
+		}
+		items = append(items, item)
+	}
+	if len(items) == 0 {
+		return ""
+	}
+	return title + ":\n\n" + strings.Join(items, "\n")
+}
+
+// logicText renders a logic as its specification on one line, with its target
+// where it has one.
+func logicText(logic model_logic.Logic) string {
+	spec := strings.Join(strings.Fields(logic.Spec.Specification), " ")
+	if spec == "" {
+		return ""
+	}
+	switch logic.Type {
+	case model_logic.LogicTypeStateChange:
+		return logic.Target + "' = " + spec
+	case model_logic.LogicTypeQuery:
+		return logic.Target + " = " + spec
+	case model_logic.LogicTypeLet:
+		return "LET " + logic.Target + " == " + spec
+	}
+	return spec
+}
+
+func joinParagraphs(paragraphs ...string) string {
+	var kept []string
+	for _, paragraph := range paragraphs {
+		if paragraph != "" {
+			kept = append(kept, paragraph)
+		}
+	}
+	return strings.Join(kept, "\n\n")
+}
+
+func jsonContent(schema *jsonschema.Schema) map[string]*MediaType {
+	return map[string]*MediaType{contentTypeJSON: {Schema: schema}}
+}
+
+// componentName turns a class name into a schema name, such as "Line Item" into "LineItem".
+func componentName(name string) string {
+	var b strings.Builder
+	upper := true
+	for _, r := range name {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		b.WriteRune(r)
+	}
+	if b.Len() == 0 {
+		return "Class"
+	}
+	return b.String()
+}
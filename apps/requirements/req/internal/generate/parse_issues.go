@@ -11,6 +11,7 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 )
 
 // ParseIssueIndex groups every parse or expression error for one model generation pass.
@@ -26,15 +27,16 @@ type ParseIssueIndex struct {
 var activeParseIssues *ParseIssueIndex
 
 // BuildParseIssueIndex merges per-class file failures with expression issues found in the model.
-func BuildParseIssueIndex(model *core.Model, fileErrors map[string]string) *ParseIssueIndex {
+// When sources is not nil, each expression issue is led by its position in the source files.
+func BuildParseIssueIndex(model *core.Model, fileErrors map[string]string, sources *sourcepos.Index) *ParseIssueIndex {
 	idx := &ParseIssueIndex{
 		FileErrors:  copyStringMap(fileErrors),
 		ExprErrors:  make(map[string][]string),
 		failedSpecs: make(map[string]bool),
 	}
 
-	for _, issue := range convert.CollectUnparsedExpressionIssues(model) {
-		line := issue.Location + ": " + issue.Message
+	for _, issue := range convert.CollectUnparsedExpressionIssues(model, sources) {
+		line := issue.Position.Prefix() + issue.Location + ": " + issue.Message
 		if issue.SpecText != "" {
 			idx.failedSpecs[issue.SpecText] = true
 		}
@@ -224,10 +226,11 @@ func expressionSpecDisplay(spec logic_spec.ExpressionSpec) string {
 	}
 	// Leave > and < unescaped: gomarkdown escapes them once when rendering markdown to HTML.
 	// Pre-escaping here produces &amp;gt; / &amp;lt;, which browsers show as literal entity text.
+	display := specificationForDisplay(spec)
 	if activeParseIssues == nil || !activeParseIssues.failedSpecs[spec.Specification] {
-		return spec.Specification
+		return display
 	}
-	return `<span class="parse-error-spec">` + spec.Specification + `</span>`
+	return `<span class="parse-error-spec">` + display + `</span>`
 }
 
 func copyStringMap(in map[string]string) map[string]string {
@@ -8,6 +8,7 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
 	"github.com/gomarkdown/markdown"
 )
@@ -106,6 +107,44 @@ func TestGenerateShowsExpressionParseErrors(t *testing.T) {
 	}
 }
 
+func TestBuildParseIssueIndexPositions(t *testing.T) {
+	model := test_helper.GetTestModel()
+
+	domainKey := helper.Must(identity.NewDomainKey("domain_a"))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "subdomain_a"))
+	classKey := helper.Must(identity.NewClassKey(subdomainKey, "order"))
+	target, ok := model.Domains[domainKey].Subdomains[subdomainKey].Classes[classKey]
+	if !ok {
+		t.Fatalf("expected Order class in fixture model at %s", classKey)
+	}
+	invKey := helper.Must(identity.NewClassInvariantKey(target.Key, "99"))
+	spec := helper.Must(logic_spec.NewExpressionSpec(model_logic.NotationTLAPlus, "1 = 1 /\\ (", nil))
+	target.SetInvariants(append(target.Invariants, model_logic.NewLogic(invKey, model_logic.LogicTypeAssessment, "broken invariant", "", spec, nil)))
+	subdomain := model.Domains[domainKey].Subdomains[subdomainKey]
+	subdomain.Classes[classKey] = target
+
+	sources := sourcepos.NewIndex()
+	if err := sources.AddYAML("order.class", "◇\ninvariants:\n    - specification: \"1 = 1 /\\\\ (\"\n", len("◇\n")); err != nil {
+		t.Fatalf("AddYAML: %v", err)
+	}
+	sources.SetScope(classKey.String(), "order.class")
+
+	idx := BuildParseIssueIndex(&model, nil, sources)
+	var found bool
+	for _, line := range idx.ExprErrors[classKey.String()] {
+		if !strings.Contains(line, `"1 = 1 /\\ ("`) {
+			continue
+		}
+		found = true
+		if !strings.HasPrefix(line, "order.class:3:34: class invariant") {
+			t.Errorf("expected the error to lead with its source position, got: %s", line)
+		}
+	}
+	if !found {
+		t.Errorf("expected an expression error for the broken invariant, got %v", idx.ExprErrors[classKey.String()])
+	}
+}
+
 func TestModelSummaryBannerHub(t *testing.T) {
 	model := test_helper.GetTestModel()
 
@@ -168,7 +207,7 @@ found:
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			idx := BuildParseIssueIndex(&model, tc.fileErrors)
+			idx := BuildParseIssueIndex(&model, tc.fileErrors, nil)
 			banner := idx.ModelSummaryBanner(&model)
 			if banner == "" {
 				t.Fatal("expected model.md hub banner")
@@ -0,0 +1,522 @@
+// Package plantuml generates PlantUML diagram sources from a model.
+//
+// Each subdomain gets a class diagram and a use case diagram, each of its classes with a
+// state machine a state diagram, and each scenario of its use cases a sequence diagram.
+// Every diagram is its own file so a UML tool or the PlantUML renderer can take them one
+// at a time. Elements are aliased by their model keys, so re-exported sources diff cleanly.
+package plantuml
+
+import (
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_actor"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// FileExtension is the extension of generated diagram source files.
+const FileExtension = ".puml"
+
+// File is a generated diagram source.
+type File struct {
+	Name   string // The file name without its extension.
+	Source string // The complete diagram text, from @startuml to @enduml.
+}
+
+// Generate writes the diagram sources of every subdomain into outputPath.
+func Generate(model core.Model, outputPath string) error {
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, file := range Files(model) {
+		path := filepath.Join(outputPath, file.Name+FileExtension)
+		if err := os.WriteFile(path, []byte(file.Source), 0o644); err != nil { //nolint:gosec // generated diagram sources are intentionally world-readable
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Files returns the diagram sources of every subdomain, ordered by domain and subdomain
+// key. A subdomain's files are named for it, such as "bank.accounts.classes", with the
+// state and sequence diagrams further named for their class or use case and scenario.
+func Files(model core.Model) []File {
+	index := newModelIndex(model)
+	var files []File
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			prefix := domain.Key.SubKey + "." + subdomain.Key.SubKey
+			if len(subdomain.Classes) > 0 {
+				files = append(files, File{Name: prefix + ".classes", Source: classDiagram(index, domain, subdomain)})
+			}
+			if len(subdomain.UseCases) > 0 {
+				files = append(files, File{Name: prefix + ".use-cases", Source: useCaseDiagram(index, domain, subdomain)})
+			}
+			for _, class := range identity.SortedValues(subdomain.Classes) {
+				if len(class.States) > 0 {
+					files = append(files, File{Name: prefix + "." + class.Key.SubKey + ".states", Source: stateDiagram(class)})
+				}
+			}
+			for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+				for _, scenario := range identity.SortedValues(useCase.Scenarios) {
+					files = append(files, File{
+						Name:   prefix + "." + useCase.Key.SubKey + "." + scenario.Key.SubKey + ".sequence",
+						Source: sequenceDiagram(index, useCase.Name, scenario),
+					})
+				}
+			}
+		}
+	}
+	return files
+}
+
+// placedClass is a class with the domain and subdomain it is in.
+type placedClass struct {
+	class     model_class.Class
+	domain    model_domain.Domain
+	subdomain model_domain.Subdomain
+}
+
+// modelIndex looks up the model elements diagrams refer to across subdomains.
+type modelIndex struct {
+	model        core.Model
+	classes      map[identity.Key]placedClass
+	associations []model_class.Association // Every class association of the model, ordered by key.
+	scenarios    map[identity.Key]model_scenario.Scenario
+}
+
+func newModelIndex(model core.Model) modelIndex {
+	index := modelIndex{
+		model:     model,
+		classes:   map[identity.Key]placedClass{},
+		scenarios: map[identity.Key]model_scenario.Scenario{},
+	}
+	index.associations = append(index.associations, identity.SortedValues(model.ClassAssociations)...)
+	for _, domain := range model.Domains {
+		index.associations = append(index.associations, identity.SortedValues(domain.ClassAssociations)...)
+		for _, subdomain := range domain.Subdomains {
+			index.associations = append(index.associations, identity.SortedValues(subdomain.ClassAssociations)...)
+			for key, class := range subdomain.Classes {
+				index.classes[key] = placedClass{class: class, domain: domain, subdomain: subdomain}
+			}
+			for _, useCase := range subdomain.UseCases {
+				for key, scenario := range useCase.Scenarios {
+					index.scenarios[key] = scenario
+				}
+			}
+		}
+	}
+	slices.SortFunc(index.associations, func(a, b model_class.Association) int {
+		return strings.Compare(a.Key.String(), b.Key.String())
+	})
+	return index
+}
+
+// classDiagram draws the classes of a subdomain with their attributes, generalizations
+// and associations. Classes of other subdomains at the far end of an association are
+// drawn in a package named for their domain and subdomain.
+func classDiagram(index modelIndex, domain model_domain.Domain, subdomain model_domain.Subdomain) string {
+	d := newDiagram(domain.Name + " / " + subdomain.Name)
+	d.line("hide empty members")
+
+	var associations []model_class.Association
+	foreign := map[identity.Key]placedClass{}
+	addForeign := func(key identity.Key) {
+		if _, local := subdomain.Classes[key]; local {
+			return
+		}
+		if placed, ok := index.classes[key]; ok {
+			foreign[key] = placed
+		}
+	}
+	for _, assoc := range index.associations {
+		_, fromLocal := subdomain.Classes[assoc.FromClassKey]
+		_, toLocal := subdomain.Classes[assoc.ToClassKey]
+		if !fromLocal && !toLocal {
+			continue
+		}
+		associations = append(associations, assoc)
+		addForeign(assoc.FromClassKey)
+		addForeign(assoc.ToClassKey)
+		if assoc.AssociationClassKey != nil {
+			addForeign(*assoc.AssociationClassKey)
+		}
+	}
+
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		writeClass(d, class)
+	}
+	var packages []string
+	byPackage := map[string][]model_class.Class{}
+	for _, placed := range identity.SortedValues(foreign) {
+		name := placed.domain.Name + " / " + placed.subdomain.Name
+		if _, ok := byPackage[name]; !ok {
+			packages = append(packages, name)
+		}
+		byPackage[name] = append(byPackage[name], placed.class)
+	}
+	for _, name := range packages {
+		d.line("package " + quote(name) + " {")
+		d.indent++
+		for _, class := range byPackage[name] {
+			d.line("class " + quote(class.Name) + " as " + alias(class.Key))
+		}
+		d.indent--
+		d.line("}")
+	}
+
+	for _, generalization := range identity.SortedValues(subdomain.Generalizations) {
+		var superclass *model_class.Class
+		var subclasses []model_class.Class
+		for _, class := range identity.SortedValues(subdomain.Classes) {
+			switch {
+			case class.SuperclassOfKey != nil && *class.SuperclassOfKey == generalization.Key:
+				superclass = &class
+			case class.SubclassOfKey != nil && *class.SubclassOfKey == generalization.Key:
+				subclasses = append(subclasses, class)
+			}
+		}
+		if superclass == nil {
+			continue
+		}
+		for _, subclass := range subclasses {
+			d.line(alias(superclass.Key) + " <|-- " + alias(subclass.Key))
+		}
+	}
+
+	for _, assoc := range associations {
+		line := alias(assoc.FromClassKey) + " " + quote(assoc.FromMultiplicity.String()) + " -- " + quote(assoc.ToMultiplicity.String()) + " " + alias(assoc.ToClassKey)
+		if assoc.Name != "" {
+			line += " : " + label(assoc.Name)
+		}
+		d.line(line)
+		if assoc.AssociationClassKey != nil {
+			d.line("(" + alias(assoc.FromClassKey) + ", " + alias(assoc.ToClassKey) + ") .. " + alias(*assoc.AssociationClassKey))
+		}
+	}
+
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		d.note(class.Key, class.UmlComment)
+	}
+	return d.String()
+}
+
+// writeClass writes a class with an «actor» stereotype when it is backed by an actor
+// and an attribute per line, typed by its data type rules.
+func writeClass(d *diagram, class model_class.Class) {
+	head := "class " + quote(class.Name) + " as " + alias(class.Key)
+	if class.ActorKey != nil {
+		head += " <<actor>>"
+	}
+	if len(class.Attributes) == 0 {
+		d.line(head)
+		return
+	}
+	d.line(head + " {")
+	d.indent++
+	for _, attr := range class.Attributes {
+		member := attr.Name
+		if attr.DataTypeRules != "" {
+			member += " : " + attr.DataTypeRules
+		}
+		if attr.Nullable {
+			member += " [0..1]"
+		}
+		member = label(member)
+		// Parentheses would make PlantUML read the member as a method.
+		if strings.ContainsAny(member, "()") {
+			member = "{field} " + member
+		}
+		if attr.DerivationPolicy != nil {
+			member = "/" + member
+		}
+		d.line(member)
+	}
+	d.indent--
+	d.line("}")
+}
+
+// useCaseDiagram draws the use cases of a subdomain inside its boundary, the actors
+// taking part in them, and the generalizations among both.
+func useCaseDiagram(index modelIndex, domain model_domain.Domain, subdomain model_domain.Subdomain) string {
+	d := newDiagram(domain.Name + " / " + subdomain.Name)
+	d.line("left to right direction")
+
+	actors := map[identity.Key]model_actor.Actor{}
+	type participation struct{ actorKey, useCaseKey identity.Key }
+	var participations []participation
+	for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+		for _, actorClassKey := range identity.SortedKeys(useCase.Actors) {
+			placed, ok := index.classes[actorClassKey]
+			if !ok || placed.class.ActorKey == nil {
+				continue
+			}
+			actor, ok := index.model.Actors[*placed.class.ActorKey]
+			if !ok {
+				continue
+			}
+			actors[actor.Key] = actor
+			participations = append(participations, participation{actorKey: actor.Key, useCaseKey: useCase.Key})
+		}
+	}
+
+	for _, actor := range identity.SortedValues(actors) {
+		line := "actor " + quote(actor.Name) + " as " + alias(actor.Key)
+		if actor.Type != "" {
+			line += " <<" + actor.Type + ">>"
+		}
+		d.line(line)
+	}
+	d.line("rectangle " + quote(subdomain.Name) + " {")
+	d.indent++
+	for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+		line := "usecase " + quote(useCase.Name) + " as " + alias(useCase.Key)
+		if useCase.Level != "" {
+			line += " <<" + useCase.Level + ">>"
+		}
+		d.line(line)
+	}
+	d.indent--
+	d.line("}")
+
+	for _, p := range participations {
+		d.line(alias(p.actorKey) + " -- " + alias(p.useCaseKey))
+	}
+	for _, generalizationKey := range identity.SortedKeys(subdomain.UseCaseGeneralizations) {
+		var superKey *identity.Key
+		var subKeys []identity.Key
+		for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+			switch {
+			case useCase.SuperclassOfKey != nil && *useCase.SuperclassOfKey == generalizationKey:
+				superKey = &useCase.Key
+			case useCase.SubclassOfKey != nil && *useCase.SubclassOfKey == generalizationKey:
+				subKeys = append(subKeys, useCase.Key)
+			}
+		}
+		for _, subKey := range subKeys {
+			if superKey != nil {
+				d.line(alias(*superKey) + " <|-- " + alias(subKey))
+			}
+		}
+	}
+	for _, generalizationKey := range identity.SortedKeys(index.model.ActorGeneralizations) {
+		var superKey *identity.Key
+		for _, actor := range identity.SortedValues(actors) {
+			if actor.SuperclassOfKey != nil && *actor.SuperclassOfKey == generalizationKey {
+				superKey = &actor.Key
+			}
+		}
+		for _, actor := range identity.SortedValues(actors) {
+			if superKey != nil && actor.SubclassOfKey != nil && *actor.SubclassOfKey == generalizationKey {
+				d.line(alias(*superKey) + " <|-- " + alias(actor.Key))
+			}
+		}
+	}
+
+	for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+		d.note(useCase.Key, useCase.UmlComment)
+	}
+	return d.String()
+}
+
+// stateDiagram draws the state machine of a class. States list their entry/, do/ and
+// exit/ actions, creation starts at the initial pseudostate, destruction ends at the
+// final one, and transitions are labeled "event [guard] / action".
+func stateDiagram(class model_class.Class) string {
+	d := newDiagram(class.Name)
+	d.line("hide empty description")
+	for _, state := range identity.SortedValues(class.States) {
+		d.line("state " + quote(state.Name) + " as " + alias(state.Key))
+		for _, stateAction := range state.Actions {
+			d.line(alias(state.Key) + " : " + label(stateAction.When+"/ "+class.Actions[stateAction.ActionKey].Name))
+		}
+	}
+	for _, transition := range identity.SortedValues(class.Transitions) {
+		from, to := "[*]", "[*]"
+		if transition.FromStateKey != nil {
+			from = alias(*transition.FromStateKey)
+		}
+		if transition.ToStateKey != nil {
+			to = alias(*transition.ToStateKey)
+		}
+		text := model_state.SystemEventDisplayName(class.Events[transition.EventKey].Name)
+		if transition.GuardKey != nil {
+			text += " [" + class.Guards[*transition.GuardKey].Logic.Description + "]"
+		}
+		if transition.ActionKey != nil {
+			text += " / " + class.Actions[*transition.ActionKey].Name
+		}
+		d.line(from + " --> " + to + " : " + label(text))
+	}
+	for _, state := range identity.SortedValues(class.States) {
+		d.note(state.Key, state.UmlComment)
+	}
+	return d.String()
+}
+
+// sequenceDiagram draws a scenario as the messages between its objects, with switches
+// as alt or opt fragments and loops as loop fragments.
+func sequenceDiagram(index modelIndex, useCaseName string, scenario model_scenario.Scenario) string {
+	d := newDiagram(useCaseName + ": " + scenario.Name)
+	objects := make([]model_scenario.Object, 0, len(scenario.Objects))
+	for _, object := range scenario.Objects {
+		objects = append(objects, object)
+	}
+	slices.SortFunc(objects, func(a, b model_scenario.Object) int { return int(a.ObjectNumber) - int(b.ObjectNumber) })
+	for _, object := range objects {
+		class := index.classes[object.ClassKey].class
+		keyword := "participant"
+		if class.ActorKey != nil {
+			keyword = "actor"
+		}
+		d.line(keyword + " " + quote(object.GetName(class)) + " as " + alias(object.Key))
+	}
+	if scenario.Steps != nil {
+		writeSteps(d, index, scenario.Steps.Statements)
+	}
+	return d.String()
+}
+
+func writeSteps(d *diagram, index modelIndex, steps []model_scenario.Step) {
+	for _, step := range steps {
+		switch step.StepType {
+		case model_scenario.STEP_TYPE_LEAF:
+			writeLeafStep(d, index, step)
+		case model_scenario.STEP_TYPE_SEQUENCE:
+			writeSteps(d, index, step.Statements)
+		case model_scenario.STEP_TYPE_SWITCH:
+			if len(step.Statements) == 0 {
+				continue
+			}
+			keyword := "alt "
+			if len(step.Statements) == 1 {
+				keyword = "opt "
+			}
+			for i, caseStep := range step.Statements {
+				if i > 0 {
+					keyword = "else "
+				}
+				d.line(keyword + label(caseStep.Condition))
+				d.indent++
+				writeSteps(d, index, caseStep.Statements)
+				d.indent--
+			}
+			d.line("end")
+		case model_scenario.STEP_TYPE_LOOP:
+			d.line("loop " + label(step.Condition))
+			d.indent++
+			writeSteps(d, index, step.Statements)
+			d.indent--
+			d.line("end")
+		}
+	}
+}
+
+func writeLeafStep(d *diagram, index modelIndex, step model_scenario.Step) {
+	if step.LeafType == nil || step.FromObjectKey == nil {
+		return
+	}
+	from := alias(*step.FromObjectKey)
+	if *step.LeafType == model_scenario.LEAF_TYPE_DESTROY {
+		d.line("destroy " + from)
+		return
+	}
+	if step.ToObjectKey == nil {
+		return
+	}
+	text := step.Description
+	switch *step.LeafType {
+	case model_scenario.LEAF_TYPE_EVENT:
+		if step.EventKey != nil {
+			if placed, ok := index.classes[classKeyOf(*step.EventKey)]; ok {
+				event := placed.class.Events[*step.EventKey]
+				name := model_state.SystemEventDisplayName(event.Name)
+				if len(event.ParameterNames) > 0 {
+					name += "(" + strings.Join(event.ParameterNames, ", ") + ")"
+				}
+				text += name
+			}
+		}
+	case model_scenario.LEAF_TYPE_QUERY:
+		if text == "" && step.QueryKey != nil {
+			if placed, ok := index.classes[classKeyOf(*step.QueryKey)]; ok {
+				text = placed.class.Queries[*step.QueryKey].Name + "?"
+			}
+		}
+	case model_scenario.LEAF_TYPE_SCENARIO:
+		if step.ScenarioKey != nil {
+			text = "Scenario: " + index.scenarios[*step.ScenarioKey].Name
+		}
+	}
+	d.line(from + " -> " + alias(*step.ToObjectKey) + " : " + label(text))
+}
+
+// classKeyOf returns the key of the class an event or query belongs to.
+func classKeyOf(key identity.Key) identity.Key {
+	classKey, err := identity.ParseKey(key.ParentKey)
+	if err != nil {
+		return identity.Key{}
+	}
+	return classKey
+}
+
+// diagram accumulates the indented lines of one diagram.
+type diagram struct {
+	lines  []string
+	indent int
+}
+
+func newDiagram(title string) *diagram {
+	d := &diagram{}
+	d.line("@startuml")
+	d.line("title " + label(title))
+	return d
+}
+
+func (d *diagram) line(text string) {
+	d.lines = append(d.lines, strings.Repeat("  ", d.indent)+text)
+}
+
+// note attaches a uml_comment to the element it annotates.
+func (d *diagram) note(key identity.Key, comment string) {
+	text := strings.TrimSpace(comment)
+	if text == "" {
+		return
+	}
+	d.line("note right of " + alias(key))
+	for _, line := range strings.Split(text, "\n") {
+		d.line("  " + strings.TrimRight(line, " \t"))
+	}
+	d.line("end note")
+}
+
+func (d *diagram) String() string {
+	return strings.Join(append(d.lines, "@enduml"), "\n") + "\n"
+}
+
+var _unaliasable = regexp.MustCompile(`[^A-Za-z0-9_]`)
+
+// alias is the PlantUML name of a model element, derived from its key.
+func alias(key identity.Key) string {
+	return _unaliasable.ReplaceAllString(key.String(), "_")
+}
+
+// quote quotes a display name. PlantUML has no escape for a double quote inside one.
+func quote(text string) string {
+	return `"` + strings.ReplaceAll(label(text), `"`, "'") + `"`
+}
+
+// label keeps text on one source line, using PlantUML's \n for its line breaks.
+func label(text string) string {
+	return strings.ReplaceAll(strings.TrimSpace(text), "\n", `\n`)
+}
@@ -0,0 +1,122 @@
+package plantuml
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type PlantUMLSuite struct {
+	suite.Suite
+}
+
+func TestPlantUMLSuite(t *testing.T) {
+	suite.Run(t, new(PlantUMLSuite))
+}
+
+func (suite *PlantUMLSuite) files() map[string]string {
+	sources := map[string]string{}
+	for _, file := range Files(test_helper.GetBankModel()) {
+		sources[file.Name] = file.Source
+	}
+	return sources
+}
+
+func (suite *PlantUMLSuite) TestFileNames() {
+	var names []string
+	for _, file := range Files(test_helper.GetBankModel()) {
+		names = append(names, file.Name)
+	}
+	suite.Equal([]string{
+		"bank.accounts.classes",
+		"bank.accounts.use-cases",
+		"bank.accounts.account.states",
+		"bank.accounts.open_account.happy.sequence",
+		"bank.ledger.classes",
+	}, names)
+}
+
+func (suite *PlantUMLSuite) TestClassDiagram() {
+	source := suite.files()["bank.accounts.classes"]
+	suite.Contains(source, "@startuml\ntitle Bank / Accounts\nhide empty members\n")
+	suite.Contains(source, `class "Account" as domain_bank_subdomain_accounts_class_account {
+  balance : [0 .. 1000] at 1 dollar
+  {field} rate : [0 .. 1) at 0.01 percent [0..1]
+  status : enum of open, frozen [0..1]
+  owners : unique 1-3 unordered of obj of customer [0..1]
+  nickname [0..1]
+}
+`)
+	suite.Contains(source, `class "Clerk" as domain_bank_subdomain_accounts_class_clerk <<actor>>`+"\n")
+	suite.Contains(source, `package "Bank / Ledger" {
+  class "Entry" as domain_bank_subdomain_ledger_class_entry
+}
+`)
+	suite.Contains(source, "domain_bank_subdomain_accounts_class_account <|-- domain_bank_subdomain_accounts_class_savings\n")
+	suite.Contains(source, `domain_bank_subdomain_accounts_class_customer "*" -- "*" domain_bank_subdomain_accounts_class_account : holds`+"\n")
+	suite.Contains(source, "(domain_bank_subdomain_accounts_class_customer, domain_bank_subdomain_accounts_class_account) .. domain_bank_subdomain_accounts_class_holding\n")
+	suite.Contains(source, `domain_bank_subdomain_accounts_class_account "1" -- "*" domain_bank_subdomain_ledger_class_entry : posts`+"\n")
+	suite.Contains(source, "note right of domain_bank_subdomain_accounts_class_account\n  Never shared.\nend note\n@enduml\n")
+}
+
+func (suite *PlantUMLSuite) TestUseCaseDiagram() {
+	suite.Equal(`@startuml
+title Bank / Accounts
+left to right direction
+actor "Clerk" as actor_clerk <<person>>
+rectangle "Accounts" {
+  usecase "Open account" as domain_bank_subdomain_accounts_usecase_open_account <<sea>>
+}
+actor_clerk -- domain_bank_subdomain_accounts_usecase_open_account
+@enduml
+`, suite.files()["bank.accounts.use-cases"])
+}
+
+func (suite *PlantUMLSuite) TestStateDiagram() {
+	source := suite.files()["bank.accounts.account.states"]
+	open := "domain_bank_subdomain_accounts_class_account_state_open"
+	closed := "domain_bank_subdomain_accounts_class_account_state_closed"
+	suite.Contains(source, "title Account\nhide empty description\n")
+	suite.Contains(source, `state "Open" as `+open+"\n"+open+" : entry/ Log\n")
+	suite.Contains(source, "[*] --> "+open+" : «new» / Open\n")
+	suite.Contains(source, open+" --> "+open+" : deposit / Deposit\n")
+	suite.Contains(source, open+" --> "+closed+" : close [balance > 50] / Close\n")
+	suite.Contains(source, closed+" --> [*] : «destroy»\n")
+}
+
+func (suite *PlantUMLSuite) TestSequenceDiagram() {
+	clerk := "domain_bank_subdomain_accounts_usecase_open_account_scenario_happy_sobject_clerk"
+	account := "domain_bank_subdomain_accounts_usecase_open_account_scenario_happy_sobject_account"
+	suite.Equal(`@startuml
+title Open account: Happy
+actor "Ann:Clerk" as `+clerk+`
+participant ":Account" as `+account+`
+`+clerk+` -> `+account+` : «new»(opening)
+alt cash on hand
+  `+clerk+` -> `+account+` : Pay in deposit(amount, type)
+else no cash
+  destroy `+account+`
+end
+@enduml
+`, suite.files()["bank.accounts.open_account.happy.sequence"])
+}
+
+func (suite *PlantUMLSuite) TestTestModel() {
+	files := Files(test_helper.GetTestModel())
+	suite.Require().NotEmpty(files)
+	for _, file := range files {
+		suite.Contains(file.Source, "@startuml\n", file.Name)
+		suite.Contains(file.Source, "@enduml\n", file.Name)
+	}
+}
+
+func (suite *PlantUMLSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath))
+	content, err := os.ReadFile(filepath.Join(outputPath, "bank.accounts.classes"+FileExtension))
+	suite.Require().NoError(err)
+	suite.Contains(string(content), "@startuml\n")
+}
@@ -0,0 +1,117 @@
+package proto
+
+import (
+	"io/fs"
+	"os"
+	"slices"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// LockFileExtension is the extension of the lock file kept beside a model, such as
+// "model_a.protolock.yaml" beside the "model_a" folder.
+const LockFileExtension = ".protolock.yaml"
+
+// _lockHeader opens every written lock file.
+const _lockHeader = "# Field numbers of the generated .proto files. Keep this file with the model so\n# regenerating keeps the wire format; numbers of removed fields stay reserved.\n"
+
+// Lock records the number of every message field and enum value ever generated, so
+// regenerating after model edits keeps the numbers of existing fields and never reuses
+// the numbers of removed ones. Both maps are keyed by a scope naming the message or
+// enum, such as a class key, and then by field name or enum value.
+type Lock struct {
+	Messages map[string]map[string]int `yaml:"messages,omitempty"`
+	Enums    map[string]map[string]int `yaml:"enums,omitempty"`
+}
+
+// lockEntry is one numbered name of a lock scope.
+type lockEntry struct {
+	name   string
+	number int
+}
+
+// NewLock returns an empty lock, for a model generated for the first time.
+func NewLock() *Lock {
+	return &Lock{
+		Messages: make(map[string]map[string]int),
+		Enums:    make(map[string]map[string]int),
+	}
+}
+
+// ReadLock reads the lock file at path, or returns an empty lock if there is none.
+func ReadLock(path string) (*Lock, error) {
+	content, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return NewLock(), nil
+	}
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	lock := NewLock()
+	if err := yaml.Unmarshal(content, lock); err != nil {
+		return nil, errors.Wrapf(err, "lock file '%s'", path)
+	}
+	if lock.Messages == nil {
+		lock.Messages = make(map[string]map[string]int)
+	}
+	if lock.Enums == nil {
+		lock.Enums = make(map[string]map[string]int)
+	}
+	return lock, nil
+}
+
+// Write writes the lock to path.
+func (l *Lock) Write(path string) error {
+	content, err := yaml.Marshal(l)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if err := os.WriteFile(path, append([]byte(_lockHeader), content...), 0o644); err != nil { //nolint:gosec // the lock file is kept with the model source, which is world-readable
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// fieldNumber returns the number of a message field, assigning the next unused one
+// to a new field.
+func (l *Lock) fieldNumber(scope, name string) int {
+	return assign(l.Messages, scope, name)
+}
+
+// enumNumber returns the number of an enum value, assigning the next unused one to a
+// new value. Zero is left for the unspecified value proto3 requires.
+func (l *Lock) enumNumber(scope, value string) int {
+	return assign(l.Enums, scope, value)
+}
+
+// assign returns the number of name in scope, giving a new name one more than the
+// highest number the scope has ever used.
+func assign(numbers map[string]map[string]int, scope, name string) int {
+	scoped, ok := numbers[scope]
+	if !ok {
+		scoped = make(map[string]int)
+		numbers[scope] = scoped
+	}
+	if number, ok := scoped[name]; ok {
+		return number
+	}
+	number := 1
+	for _, used := range scoped {
+		number = max(number, used+1)
+	}
+	scoped[name] = number
+	return number
+}
+
+// retired returns the entries of scope whose names are not in current, ordered by number.
+func retired(numbers map[string]map[string]int, scope string, current []string) []lockEntry {
+	var entries []lockEntry
+	for name, number := range numbers[scope] {
+		if !slices.Contains(current, name) {
+			entries = append(entries, lockEntry{name: name, number: number})
+		}
+	}
+	slices.SortFunc(entries, func(a, b lockEntry) int { return a.number - b.number })
+	return entries
+}
@@ -0,0 +1,135 @@
+package proto
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// _indent is the indentation of one nesting level, as the protobuf style guide has it.
+const _indent = "  "
+
+// message is a message declaration with its nested enums and messages.
+type message struct {
+	name      string
+	comment   []string
+	lockScope string // The lock scope of the field numbers, or empty to number fields in order.
+	fields    []field
+	reserved  []lockEntry // Fields removed from the model, whose numbers must not be reused.
+	enums     []*enum
+	messages  []*message
+	types     *namer // Names of the nested enums and messages.
+}
+
+// field is one field of a message.
+type field struct {
+	comment []string
+	label   string // Empty, optional or repeated.
+	typ     string
+	name    string
+	number  int
+}
+
+// enum is an enum declaration. Its first value is the unspecified zero value.
+type enum struct {
+	name      string
+	lockScope string
+	values    []enumValue
+	reserved  []lockEntry // Values removed from the model, named by model value.
+}
+
+type enumValue struct {
+	name   string
+	number int
+}
+
+func newMessage(name, lockScope string, comment []string) *message {
+	return &message{name: name, comment: comment, lockScope: lockScope, types: newNamer()}
+}
+
+// protoWriter accumulates the text of one .proto file.
+type protoWriter struct {
+	strings.Builder
+	depth int
+}
+
+func (w *protoWriter) line(format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+	if text != "" {
+		w.WriteString(strings.Repeat(_indent, w.depth))
+	}
+	w.WriteString(text)
+	w.WriteString("\n")
+}
+
+// comment writes each line of text as a line comment.
+func (w *protoWriter) comment(lines []string) {
+	for _, text := range lines {
+		for _, part := range strings.Split(text, "\n") {
+			part = strings.TrimRight(part, " \t\r")
+			if part == "" {
+				w.line("//")
+				continue
+			}
+			w.line("// %s", part)
+		}
+	}
+}
+
+func (w *protoWriter) message(m *message) {
+	w.comment(m.comment)
+	w.line("message %s {", m.name)
+	w.depth++
+	if len(m.reserved) > 0 {
+		numbers := make([]string, 0, len(m.reserved))
+		names := make([]string, 0, len(m.reserved))
+		for _, entry := range m.reserved {
+			numbers = append(numbers, strconv.Itoa(entry.number))
+			names = append(names, strconv.Quote(entry.name))
+		}
+		w.line("reserved %s;", strings.Join(numbers, ", "))
+		w.line("reserved %s;", strings.Join(names, ", "))
+		if len(m.fields) > 0 {
+			w.line("")
+		}
+	}
+	for _, f := range m.fields {
+		w.comment(f.comment)
+		if f.label != "" {
+			w.line("%s %s %s = %d;", f.label, f.typ, f.name, f.number)
+			continue
+		}
+		w.line("%s %s = %d;", f.typ, f.name, f.number)
+	}
+	for _, e := range m.enums {
+		w.line("")
+		w.enum(e)
+	}
+	for _, nested := range m.messages {
+		w.line("")
+		w.message(nested)
+	}
+	w.depth--
+	w.line("}")
+}
+
+func (w *protoWriter) enum(e *enum) {
+	w.line("enum %s {", e.name)
+	w.depth++
+	if len(e.reserved) > 0 {
+		numbers := make([]string, 0, len(e.reserved))
+		names := make([]string, 0, len(e.reserved))
+		for _, entry := range e.reserved {
+			numbers = append(numbers, strconv.Itoa(entry.number))
+			names = append(names, strconv.Quote(enumValueName(e.name, entry.name)))
+		}
+		w.line("reserved %s;", strings.Join(numbers, ", "))
+		w.line("reserved %s;", strings.Join(names, ", "))
+	}
+	w.line("%s = 0;", enumValueName(e.name, "unspecified"))
+	for _, value := range e.values {
+		w.line("%s = %d;", value.name, value.number)
+	}
+	w.depth--
+	w.line("}")
+}
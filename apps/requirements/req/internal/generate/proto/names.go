@@ -0,0 +1,90 @@
+package proto
+
+import (
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// words splits a model name into its alphanumeric runs, such as "line_item" into
+// "line" and "item".
+func words(name string) []string {
+	return strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// messageName turns a model name into a message or enum name, such as "Line Item"
+// into "LineItem" and "_new" into "New".
+func messageName(name string) string {
+	var b strings.Builder
+	for _, word := range words(name) {
+		runes := []rune(word)
+		b.WriteRune(unicode.ToUpper(runes[0]))
+		b.WriteString(string(runes[1:]))
+	}
+	identifier := b.String()
+	if identifier == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		return "X" + identifier
+	}
+	return identifier
+}
+
+// fieldName turns a model name into a field or package name, such as "Product ID"
+// into "product_id".
+func fieldName(name string) string {
+	identifier := strings.ToLower(strings.Join(words(name), "_"))
+	if identifier == "" {
+		return "x"
+	}
+	if unicode.IsDigit([]rune(identifier)[0]) {
+		return "x_" + identifier
+	}
+	return identifier
+}
+
+// constantName turns a message or enum name into the prefix of its enum values, such
+// as "OrderStatus" into "ORDER_STATUS".
+func constantName(name string) string {
+	var b strings.Builder
+	runes := []rune(name)
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) && !unicode.IsUpper(runes[i-1]) {
+			b.WriteRune('_')
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// enumValueName prefixes an enum value with its enum, since proto enum values share
+// the scope of the enum, such as "open" of "Status" into "STATUS_OPEN".
+func enumValueName(enumName, value string) string {
+	suffix := strings.ToUpper(strings.Join(words(value), "_"))
+	if suffix == "" {
+		suffix = "X"
+	}
+	return constantName(enumName) + "_" + suffix
+}
+
+// namer hands out identifiers unique within one scope, numbering repeats.
+type namer struct {
+	used map[string]bool
+}
+
+func newNamer() *namer {
+	return &namer{used: make(map[string]bool)}
+}
+
+// name returns base, or base followed by the lowest number that makes it unique.
+func (n *namer) name(base string) string {
+	name := base
+	for i := 2; n.used[name]; i++ {
+		name = base + strconv.Itoa(i)
+	}
+	n.used[name] = true
+	return name
+}
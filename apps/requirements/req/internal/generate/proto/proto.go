@@ -0,0 +1,218 @@
+// Package proto generates Protocol Buffers (proto3) definitions from a model.
+//
+// Each subdomain becomes one .proto file. Every class gets a message with a field per
+// attribute, and every event a message with a field per parameter, typed by the action
+// parameter of the same name. Records become nested messages, enumerations nested enums,
+// and collections repeated fields. Field numbers are kept in a lock file beside the
+// model, so regenerating after model edits keeps the wire format of earlier output.
+package proto
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// FileExtension is the extension of generated definition files.
+const FileExtension = ".proto"
+
+// File is a generated definition file, named for its subdomain.
+type File struct {
+	Name   string // The file name, with its extension.
+	Source string
+}
+
+// Generate writes one definition file per subdomain into outputPath, numbering fields
+// from the lock file at lockPath and writing it back with any new numbers.
+func Generate(model core.Model, outputPath, lockPath string) error {
+	lock, err := ReadLock(lockPath)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, file := range Files(model, lock) {
+		if err := os.WriteFile(filepath.Join(outputPath, file.Name), []byte(file.Source), 0o644); err != nil { //nolint:gosec // generated definitions are intentionally world-readable
+			return errors.WithStack(err)
+		}
+	}
+	return lock.Write(lockPath)
+}
+
+// Files returns a definition file for each subdomain with classes, in the key order of
+// domains and then subdomains. Fields new to the lock are given numbers in it.
+func Files(model core.Model, lock *Lock) []File {
+	scope := newModelScope(model)
+	var files []File
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			if len(subdomain.Classes) == 0 {
+				continue
+			}
+			files = append(files, subdomainFile(model, scope, lock, domain, subdomain))
+		}
+	}
+	return files
+}
+
+// newModelScope names the messages of each subdomain: first its classes, then its events,
+// so class messages keep their names when an event message would collide with one.
+func newModelScope(model core.Model) *modelScope {
+	scope := &modelScope{
+		classes: make(map[identity.Key]messageRef),
+		events:  make(map[identity.Key]string),
+	}
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			file, pkg := fileName(domain, subdomain), packageName(domain, subdomain)
+			names := newNamer()
+			classes := identity.SortedValues(subdomain.Classes)
+			for _, class := range classes {
+				scope.classes[class.Key] = messageRef{file: file, pkg: pkg, name: names.name(messageName(class.Name))}
+			}
+			for _, class := range classes {
+				for _, event := range identity.SortedValues(class.Events) {
+					scope.events[event.Key] = names.name(messageName(class.Name) + messageName(event.Name) + "Event")
+				}
+			}
+		}
+	}
+	return scope
+}
+
+// fileName names the file of a subdomain from the domain and subdomain keys.
+func fileName(domain model_domain.Domain, subdomain model_domain.Subdomain) string {
+	return domain.Key.SubKey + "." + subdomain.Key.SubKey + FileExtension
+}
+
+// packageName joins the domain and subdomain keys into a package name.
+func packageName(domain model_domain.Domain, subdomain model_domain.Subdomain) string {
+	return fieldName(domain.Key.SubKey) + "." + fieldName(subdomain.Key.SubKey)
+}
+
+func subdomainFile(model core.Model, scope *modelScope, lock *Lock, domain model_domain.Domain, subdomain model_domain.Subdomain) File {
+	types := &typeMapper{
+		scope:    scope,
+		lock:     lock,
+		file:     fileName(domain, subdomain),
+		bySubKey: make(map[string]identity.Key),
+	}
+	for classKey := range subdomain.Classes {
+		types.bySubKey[classKey.SubKey] = classKey
+	}
+
+	classes := identity.SortedValues(subdomain.Classes)
+	var messages []*message
+	for _, class := range classes {
+		messages = append(messages, classMessage(types, class))
+	}
+	for _, class := range classes {
+		for _, event := range identity.SortedValues(class.Events) {
+			messages = append(messages, eventMessage(types, class, event))
+		}
+	}
+
+	w := &protoWriter{}
+	header := []string{"Messages of the " + domain.Name + " / " + subdomain.Name + " subdomain of the " + model.Name + " model."}
+	if details := strings.TrimSpace(subdomain.Details); details != "" {
+		header = append(header, "", details)
+	}
+	header = append(header, "", "Generated by req. Field numbers are kept in the lock file beside the model.")
+	w.comment(header)
+	w.line(`syntax = "proto3";`)
+	w.line("")
+	w.line("package %s;", packageName(domain, subdomain))
+	if len(types.imports) > 0 {
+		w.line("")
+		slices.Sort(types.imports)
+		for _, path := range types.imports {
+			w.line("import %q;", path)
+		}
+	}
+	for _, m := range messages {
+		w.line("")
+		w.message(m)
+	}
+	return File{Name: types.file, Source: w.String()}
+}
+
+// classMessage returns the message of a class, with a field per attribute.
+func classMessage(types *typeMapper, class model_class.Class) *message {
+	m := newMessage(types.scope.classes[class.Key].name, class.Key.String(), keyComment(class.Details, class.Key))
+	for _, attr := range class.Attributes {
+		comment := fieldComment(attr.Details, attr.DataTypeRules)
+		if attr.DerivationPolicy != nil {
+			comment = append(comment, "Derived.")
+		}
+		types.dataTypeField(m, attr.Key.SubKey, attr.DataType, attr.Nullable, comment)
+	}
+	types.finish(m)
+	return m
+}
+
+// eventMessage returns the message of an event, with a field per parameter. Each
+// parameter is typed by the parameter of the same name of an action the event runs.
+func eventMessage(types *typeMapper, class model_class.Class, event model_state.Event) *message {
+	details := "The " + model_state.SystemEventDisplayName(event.Name) + " event of " + class.Name + "."
+	if trimmed := strings.TrimSpace(event.Details); trimmed != "" {
+		details += "\n\n" + trimmed
+	}
+	m := newMessage(types.scope.events[event.Key], event.Key.String(), keyComment(details, event.Key))
+	for _, name := range event.ParameterNames {
+		param := actionParameter(class, event.Key, name)
+		if param == nil {
+			types.dataTypeField(m, name, nil, false, nil)
+			continue
+		}
+		types.dataTypeField(m, name, param.DataType, param.Nullable, fieldComment("", param.DataTypeRules))
+	}
+	types.finish(m)
+	return m
+}
+
+func actionParameter(class model_class.Class, eventKey identity.Key, name string) *model_state.Parameter {
+	for _, transition := range identity.SortedValues(class.Transitions) {
+		if transition.EventKey != eventKey || transition.ActionKey == nil {
+			continue
+		}
+		for _, param := range class.Actions[*transition.ActionKey].Parameters {
+			if param.Name == name {
+				return &param
+			}
+		}
+	}
+	return nil
+}
+
+// keyComment is the comment of a top-level message: its details and the model key
+// it comes from.
+func keyComment(details string, key identity.Key) []string {
+	var comment []string
+	if details = strings.TrimSpace(details); details != "" {
+		comment = append(comment, details, "")
+	}
+	return append(comment, "Model key: "+key.String())
+}
+
+// fieldComment is the comment of a field: its details and the data type rules that
+// proto cannot express, such as span bounds.
+func fieldComment(details, rules string) []string {
+	var comment []string
+	if details = strings.TrimSpace(details); details != "" {
+		comment = append(comment, details)
+	}
+	if rules = strings.TrimSpace(rules); rules != "" {
+		comment = append(comment, "Data type: "+rules)
+	}
+	return comment
+}
@@ -0,0 +1,169 @@
+package proto
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type ProtoSuite struct {
+	suite.Suite
+}
+
+func TestProtoSuite(t *testing.T) {
+	suite.Run(t, new(ProtoSuite))
+}
+
+// editOrder applies edit to the order class of a shop model.
+func editOrder(model core.Model, edit func(order *model_class.Class)) core.Model {
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for classKey, class := range subdomain.Classes {
+				if classKey.SubKey == "order" {
+					edit(&class)
+					subdomain.Classes[classKey] = class
+				}
+			}
+		}
+	}
+	return model
+}
+
+func (suite *ProtoSuite) TestSubdomainFile() {
+	files := Files(test_helper.GetShopModel(), NewLock())
+	suite.Require().Len(files, 1)
+	suite.Equal("shop.orders.proto", files[0].Name)
+	suite.Equal(`// Messages of the Shop / Orders subdomain of the Shop model.
+//
+// Generated by req. Field numbers are kept in the lock file beside the model.
+syntax = "proto3";
+
+package shop.orders;
+
+import "google/protobuf/struct.proto";
+
+// Model key: domain/shop/subdomain/orders/class/customer
+message Customer {
+  // What they are called.
+  // Data type: unconstrained
+  google.protobuf.Value name = 1;
+}
+
+// A basket being bought.
+//
+// Model key: domain/shop/subdomain/orders/class/order
+message Order {
+  // What is owed.
+  // Data type: [0 .. 1000) at 0.01 dollar
+  double total = 1;
+  // Data type: enum of open, paid
+  optional Status status = 2;
+  // Data type: unique 1-3 unordered of obj of customer
+  repeated Customer buyers = 3;
+  // Data type: { street: unconstrained ; floor: [0 .. 100] at 1 floor }
+  Address address = 4;
+
+  enum Status {
+    STATUS_UNSPECIFIED = 0;
+    STATUS_OPEN = 1;
+    STATUS_PAID = 2;
+  }
+
+  message Address {
+    google.protobuf.Value street = 1;
+    int64 floor = 2;
+  }
+}
+
+// The pay event of Order.
+//
+// Money arrives.
+//
+// Model key: domain/shop/subdomain/orders/class/order/event/pay
+message OrderPayEvent {
+  // Data type: [1 .. 500] at 1 unit
+  int64 amount = 1;
+}
+
+// The ship event of Order.
+//
+// Model key: domain/shop/subdomain/orders/class/order/event/ship
+message OrderShipEvent {
+}
+`, files[0].Source)
+}
+
+// TestLockKeepsNumbers checks that regenerating after model edits keeps the numbers
+// of remaining fields and values, and reserves those of removed ones.
+func (suite *ProtoSuite) TestLockKeepsNumbers() {
+	lock := NewLock()
+	Files(test_helper.GetShopModel(), lock)
+
+	edited := editOrder(test_helper.GetShopModel(), func(order *model_class.Class) {
+		noteKey := helper.Must(identity.NewAttributeKey(order.Key, "note"))
+		note := helper.Must(model_class.NewAttribute(noteKey, model_class.AttributeDetails{Name: "Note"}, "unconstrained", nil, false, model_class.AttributeAnnotations{}))
+		status := helper.Must(model_class.NewAttribute(order.Attributes[1].Key, model_class.AttributeDetails{Name: "Status"}, "enum of paid, shipped", nil, true, model_class.AttributeAnnotations{}))
+		order.Attributes = []model_class.Attribute{note, status, order.Attributes[0]}
+	})
+	source := Files(edited, lock)[0].Source
+	suite.Contains(source, `message Order {
+  reserved 3, 4;
+  reserved "buyers", "address";
+
+  // Data type: unconstrained
+  google.protobuf.Value note = 5;
+  // Data type: enum of paid, shipped
+  optional Status status = 2;
+  // What is owed.
+  // Data type: [0 .. 1000) at 0.01 dollar
+  double total = 1;
+
+  enum Status {
+    reserved 1;
+    reserved "STATUS_OPEN";
+    STATUS_UNSPECIFIED = 0;
+    STATUS_PAID = 2;
+    STATUS_SHIPPED = 3;
+  }
+}`)
+
+	// Removed fields stay in the lock, so restoring one brings back its number.
+	restored := Files(test_helper.GetShopModel(), lock)[0].Source
+	suite.Contains(restored, "repeated Customer buyers = 3;")
+	suite.Contains(restored, "STATUS_OPEN = 1;")
+}
+
+func (suite *ProtoSuite) TestTestModel() {
+	files := Files(test_helper.GetTestModel(), NewLock())
+	suite.Require().NotEmpty(files)
+	for _, file := range files {
+		suite.Contains(file.Source, `syntax = "proto3";`, file.Name)
+	}
+}
+
+func (suite *ProtoSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	lockPath := filepath.Join(suite.T().TempDir(), "shop"+LockFileExtension)
+	suite.Require().NoError(Generate(test_helper.GetShopModel(), outputPath, lockPath))
+	first, err := os.ReadFile(filepath.Join(outputPath, "shop.orders"+FileExtension))
+	suite.Require().NoError(err)
+
+	lock, err := ReadLock(lockPath)
+	suite.Require().NoError(err)
+	suite.Equal(map[string]int{"total": 1, "status": 2, "buyers": 3, "address": 4}, lock.Messages["domain/shop/subdomain/orders/class/order"])
+	suite.Equal(map[string]int{"street": 1, "floor": 2}, lock.Messages["domain/shop/subdomain/orders/class/order.address"])
+	suite.Equal(map[string]int{"open": 1, "paid": 2}, lock.Enums["domain/shop/subdomain/orders/class/order.status"])
+
+	// Regenerating from the written lock is stable.
+	suite.Require().NoError(Generate(test_helper.GetShopModel(), outputPath, lockPath))
+	second, err := os.ReadFile(filepath.Join(outputPath, "shop.orders"+FileExtension))
+	suite.Require().NoError(err)
+	suite.Equal(string(first), string(second))
+}
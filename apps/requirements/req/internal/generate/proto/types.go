@@ -0,0 +1,273 @@
+package proto
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// Well-known types for values proto has no scalar for.
+const (
+	_typeTimestamp   = "google.protobuf.Timestamp"
+	_typeValue       = "google.protobuf.Value"
+	_importTimestamp = "google/protobuf/timestamp.proto"
+	_importValue     = "google/protobuf/struct.proto"
+)
+
+// Labels of repeated and nullable fields.
+const (
+	_labelOptional = "optional"
+	_labelRepeated = "repeated"
+)
+
+// modelScope names the message of every class and event in the model, so a file can
+// refer to the messages of other subdomains.
+type modelScope struct {
+	classes map[identity.Key]messageRef
+	events  map[identity.Key]string // Message name of each event, within the file of its class.
+}
+
+// messageRef locates a top-level message.
+type messageRef struct {
+	file string // The file name, with its extension.
+	pkg  string
+	name string
+}
+
+// typeMapper maps model data types to the field types of one file, declaring nested
+// enums and messages in the message being built, and recording the imports it needs.
+type typeMapper struct {
+	scope    *modelScope
+	lock     *Lock
+	file     string
+	bySubKey map[string]identity.Key // Classes of the subdomain by subkey, as object data types name them.
+	imports  []string
+}
+
+// add appends a field to m, numbered from the lock unless m numbers fields in order.
+func (t *typeMapper) add(m *message, f field) {
+	f.number = len(m.fields) + 1
+	if m.lockScope != "" {
+		f.number = t.lock.fieldNumber(m.lockScope, f.name)
+	}
+	m.fields = append(m.fields, f)
+}
+
+// dataTypeField appends the field for an attribute or parameter to m.
+func (t *typeMapper) dataTypeField(m *message, name string, dataType *model_data_type.DataType, nullable bool, comment []string) {
+	f := field{name: fieldName(name), comment: comment}
+	f.label, f.typ = t.dataType(m, f.name, dataType)
+	if nullable && f.label == "" {
+		f.label = _labelOptional
+	}
+	t.add(m, f)
+}
+
+// finish reserves the numbers of fields removed from m since the lock last saw it.
+func (t *typeMapper) finish(m *message) {
+	if m.lockScope == "" {
+		return
+	}
+	names := make([]string, 0, len(m.fields))
+	for _, f := range m.fields {
+		names = append(names, f.name)
+	}
+	m.reserved = retired(t.lock.Messages, m.lockScope, names)
+}
+
+// dataType returns the label and type of a field of a data type. The data type carries
+// the constraints, such as enum values, so its type spec is only used when the data type
+// is unconstrained. Ordered, unordered, queue and stack collections are all repeated.
+func (t *typeMapper) dataType(parent *message, name string, dataType *model_data_type.DataType) (label, typ string) {
+	if dataType == nil {
+		return "", t.value()
+	}
+	switch dataType.CollectionType {
+	case model_data_type.COLLECTION_TYPE_ATOMIC:
+		if unconstrained(dataType.Atomic) && dataType.TypeSpec != nil {
+			return t.typeSpec(parent, name, dataType.TypeSpec)
+		}
+		return "", t.atomic(parent, name, dataType.Atomic)
+	case model_data_type.COLLECTION_TYPE_RECORD:
+		return "", t.record(parent, name, dataType.RecordFields)
+	}
+
+	switch {
+	case dataType.ElementDataType != nil:
+		label, typ = t.dataType(parent, name, dataType.ElementDataType)
+	case len(dataType.RecordFields) > 0:
+		typ = t.record(parent, name, dataType.RecordFields)
+	default:
+		typ = t.atomic(parent, name, dataType.Atomic)
+	}
+	if label == _labelRepeated {
+		typ = t.list(parent, name, typ)
+	}
+	return _labelRepeated, typ
+}
+
+func (t *typeMapper) atomic(parent *message, name string, atomic *model_data_type.Atomic) string {
+	if atomic == nil {
+		return t.value()
+	}
+	switch atomic.ConstraintType {
+	case model_data_type.CONSTRAINT_TYPE_DATETIME:
+		t.use(_importTimestamp)
+		return _typeTimestamp
+	case model_data_type.CONSTRAINT_TYPE_SPAN:
+		if atomic.Span.Fractional() {
+			return "double"
+		}
+		return "int64"
+	case model_data_type.CONSTRAINT_TYPE_ENUMERATION:
+		values := make([]string, 0, len(atomic.Enums))
+		for _, enum := range atomic.Enums {
+			values = append(values, enum.Value)
+		}
+		return t.enum(parent, name, values)
+	case model_data_type.CONSTRAINT_TYPE_REFERENCE:
+		return "string"
+	case model_data_type.CONSTRAINT_TYPE_OBJECT:
+		if atomic.ObjectClassKey != nil {
+			if classKey, ok := t.bySubKey[*atomic.ObjectClassKey]; ok {
+				return t.object(classKey)
+			}
+		}
+	}
+	return t.value()
+}
+
+// typeSpec returns the label and type of a field of a type spec, or a dynamic value if it did not parse.
+func (t *typeMapper) typeSpec(parent *message, name string, typeSpec *logic_spec.TypeSpec) (label, typ string) {
+	if typeSpec == nil || typeSpec.ExpressionType == nil {
+		return "", t.value()
+	}
+	return t.expressionType(parent, name, typeSpec.ExpressionType)
+}
+
+func (t *typeMapper) expressionType(parent *message, name string, expressionType logic_expression_type.ExpressionType) (label, typ string) {
+	switch et := expressionType.(type) {
+	case *logic_expression_type.BooleanType:
+		return "", "bool"
+	case *logic_expression_type.IntegerType:
+		return "", "int64"
+	case *logic_expression_type.RationalType:
+		return "", "double"
+	case *logic_expression_type.StringType:
+		return "", "string"
+	case *logic_expression_type.EnumType:
+		return "", t.enum(parent, name, et.Values)
+	case *logic_expression_type.SequenceType:
+		label, typ = t.expressionType(parent, name, et.ElementType)
+		if label == _labelRepeated {
+			typ = t.list(parent, name, typ)
+		}
+		return _labelRepeated, typ
+	case *logic_expression_type.TupleType:
+		// Tuple positions never change, so their fields are numbered in order.
+		m := newMessage(parent.types.name(messageName(name)), "", nil)
+		for i, elementType := range et.ElementTypes {
+			f := field{name: "item_" + strconv.Itoa(i+1)}
+			f.label, f.typ = t.expressionType(m, f.name, elementType)
+			t.add(m, f)
+		}
+		parent.messages = append(parent.messages, m)
+		return "", m.name
+	case *logic_expression_type.RecordType:
+		m := newMessage(parent.types.name(messageName(name)), nestedScope(parent, name), nil)
+		for _, recordField := range et.Fields {
+			f := field{name: fieldName(recordField.Name)}
+			f.label, f.typ = t.expressionType(m, f.name, recordField.Type)
+			t.add(m, f)
+		}
+		t.finish(m)
+		parent.messages = append(parent.messages, m)
+		return "", m.name
+	case *logic_expression_type.ObjectType:
+		return "", t.object(et.ClassKey)
+	}
+	// Functions have no wire representation.
+	return "", t.value()
+}
+
+// record declares a nested message with a field per record field.
+func (t *typeMapper) record(parent *message, name string, recordFields []model_data_type.Field) string {
+	m := newMessage(parent.types.name(messageName(name)), nestedScope(parent, name), nil)
+	for _, recordField := range recordFields {
+		t.dataTypeField(m, recordField.Name, recordField.FieldDataType, false, nil)
+	}
+	t.finish(m)
+	parent.messages = append(parent.messages, m)
+	return m.name
+}
+
+// enum declares a nested enum with a value per model value.
+func (t *typeMapper) enum(parent *message, name string, values []string) string {
+	e := &enum{name: parent.types.name(messageName(name)), lockScope: nestedScope(parent, name)}
+	valueNames := newNamer()
+	valueNames.name(enumValueName(e.name, "unspecified"))
+	for i, value := range values {
+		number := i + 1
+		if e.lockScope != "" {
+			number = t.lock.enumNumber(e.lockScope, value)
+		}
+		e.values = append(e.values, enumValue{name: valueNames.name(enumValueName(e.name, value)), number: number})
+	}
+	if e.lockScope != "" {
+		e.reserved = retired(t.lock.Enums, e.lockScope, values)
+	}
+	parent.enums = append(parent.enums, e)
+	return e.name
+}
+
+// list declares a nested message holding one repeated field, since proto has no
+// repeated field of repeated values.
+func (t *typeMapper) list(parent *message, name, elementType string) string {
+	m := newMessage(parent.types.name(messageName(name)+"List"), "", nil)
+	t.add(m, field{label: _labelRepeated, typ: elementType, name: "values"})
+	parent.messages = append(parent.messages, m)
+	return m.name
+}
+
+// object returns the message of a class, qualified and imported when it is declared
+// in another file.
+func (t *typeMapper) object(classKey identity.Key) string {
+	ref, ok := t.scope.classes[classKey]
+	if !ok {
+		return t.value()
+	}
+	if ref.file == t.file {
+		return ref.name
+	}
+	t.use(ref.file)
+	return "." + ref.pkg + "." + ref.name
+}
+
+// value is the type of a value of any JSON shape, for data types proto cannot express.
+func (t *typeMapper) value() string {
+	t.use(_importValue)
+	return _typeValue
+}
+
+// use records that the file imports the file at path.
+func (t *typeMapper) use(path string) {
+	if !slices.Contains(t.imports, path) {
+		t.imports = append(t.imports, path)
+	}
+}
+
+// nestedScope is the lock scope of an enum or message declared for a field of parent.
+func nestedScope(parent *message, name string) string {
+	if parent.lockScope == "" {
+		return ""
+	}
+	return parent.lockScope + "." + name
+}
+
+func unconstrained(atomic *model_data_type.Atomic) bool {
+	return atomic == nil || atomic.ConstraintType == model_data_type.CONSTRAINT_TYPE_UNCONSTRAINED
+}
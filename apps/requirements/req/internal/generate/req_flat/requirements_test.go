@@ -80,7 +80,7 @@ func buildTestModel() core.Model {
 	gfLogic := model_logic.NewLogic(tGlobalFuncKey, model_logic.LogicTypeValue, "Max function.", "", logic_spec.ExpressionSpec{Notation: model_logic.NotationTLAPlus, Specification: "IF a > b THEN a ELSE b"}, nil)
 
 	// Global function.
-	globalFunc := model_logic.NewGlobalFunction(tGlobalFuncKey, "_Max", []string{"a", "b"}, gfLogic)
+	globalFunc := model_logic.NewGlobalFunction(tGlobalFuncKey, "_Max", []string{"a", "b"}, false, gfLogic)
 
 	// State machine elements.
 	eventCreate := model_state.NewEvent(tEventCreateKey, "create", "", nil)
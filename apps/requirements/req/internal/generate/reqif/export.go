@@ -0,0 +1,390 @@
+package reqif
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// Kinds of logic entries, the values of a logic object's Kind attribute.
+const (
+	LogicKindInvariant = "invariant"
+	LogicKindRequire   = "require"
+	LogicKindGuarantee = "guarantee"
+)
+
+// Names of the attributes beyond the standard ones.
+const (
+	_attributeLevel         = "Level"
+	_attributeReadOnly      = "Read only"
+	_attributeActor         = "Actor"
+	_attributeParameters    = "Parameters"
+	_attributeKind          = "Kind"
+	_attributeNotation      = "Notation"
+	_attributeSpecification = "Specification"
+)
+
+// datatype is a ReqIF datatype definition; the kind names its DATATYPE-DEFINITION-<kind> element.
+type datatype struct {
+	id, name, kind string
+	values         []string // The literals of an enumeration.
+}
+
+// enumValueID returns the identifier of one of an enumeration's literals.
+func (d datatype) enumValueID(value string) string {
+	return d.id + "." + value
+}
+
+var (
+	_stringType    = datatype{id: "_datatype.string", name: "String", kind: "STRING"}
+	_booleanType   = datatype{id: "_datatype.boolean", name: "Boolean", kind: "BOOLEAN"}
+	_levelType     = datatype{id: "_datatype.level", name: "Level", kind: "ENUMERATION", values: []string{model_use_case.UseCaseLevelSky, model_use_case.UseCaseLevelSea, model_use_case.UseCaseLevelMud}}
+	_logicKindType = datatype{id: "_datatype.logic_kind", name: "Logic kind", kind: "ENUMERATION", values: []string{LogicKindInvariant, LogicKindRequire, LogicKindGuarantee}}
+
+	_datatypes = []datatype{_stringType, _booleanType, _levelType, _logicKindType}
+)
+
+// attributeDefinition is one typed attribute of a SpecObject type.
+type attributeDefinition struct {
+	id, name string
+	datatype datatype
+}
+
+// objectType is a SpecObject type and its attribute definitions.
+type objectType struct {
+	id, name   string
+	attributes []attributeDefinition
+}
+
+// attr declares an attribute of a SpecObject type; newObjectType gives it its identifier.
+func attr(name string, d datatype) attributeDefinition {
+	return attributeDefinition{name: name, datatype: d}
+}
+
+// newObjectType creates a SpecObject type with the attributes declared for it.
+func newObjectType(slug, name string, attributes ...attributeDefinition) *objectType {
+	t := &objectType{id: "_type." + slug, name: name}
+	for _, a := range attributes {
+		a.id = t.id + "." + identifier(a.name)
+		t.attributes = append(t.attributes, a)
+	}
+	return t
+}
+
+// attribute returns the definition of one of the type's attributes.
+func (t *objectType) attribute(name string) attributeDefinition {
+	i := slices.IndexFunc(t.attributes, func(a attributeDefinition) bool { return a.name == name })
+	return t.attributes[i]
+}
+
+var (
+	_sectionType  = newObjectType("section", TypeSection, attr(AttributeForeignID, _stringType), attr(AttributeChapterName, _stringType), attr(AttributeText, _stringType))
+	_useCaseType  = newObjectType("use_case", TypeUseCase, attr(AttributeForeignID, _stringType), attr(AttributeName, _stringType), attr(AttributeText, _stringType), attr(_attributeLevel, _levelType), attr(_attributeReadOnly, _booleanType))
+	_scenarioType = newObjectType("scenario", TypeScenario, attr(AttributeForeignID, _stringType), attr(AttributeName, _stringType), attr(AttributeText, _stringType))
+	_classType    = newObjectType("class", TypeClass, attr(AttributeForeignID, _stringType), attr(AttributeName, _stringType), attr(AttributeText, _stringType), attr(_attributeActor, _booleanType))
+	_eventType    = newObjectType("event", TypeEvent, attr(AttributeForeignID, _stringType), attr(AttributeName, _stringType), attr(AttributeText, _stringType), attr(_attributeParameters, _stringType))
+	_logicType    = newObjectType("logic", TypeLogic, attr(AttributeForeignID, _stringType), attr(AttributeName, _stringType), attr(_attributeKind, _logicKindType), attr(_attributeNotation, _stringType), attr(_attributeSpecification, _stringType))
+
+	_objectTypes = []*objectType{_sectionType, _useCaseType, _scenarioType, _classType, _eventType, _logicType}
+)
+
+// relationType is a SpecRelation type.
+type relationType struct {
+	id, name string
+}
+
+var (
+	_involvesType = relationType{id: "_relation_type.involves", name: RelationInvolves}
+	_triggersType = relationType{id: "_relation_type.triggers", name: RelationTriggers}
+	_receivesType = relationType{id: "_relation_type.receives", name: RelationReceives}
+
+	_relationTypes = []relationType{_involvesType, _triggersType, _receivesType}
+)
+
+// specObject is one exported model entity with its place in the specification hierarchy.
+type specObject struct {
+	key      string
+	typ      *objectType
+	values   []attributeValue
+	children []*specObject
+}
+
+// attributeValue is the value of one attribute of a SpecObject.
+type attributeValue struct {
+	definition attributeDefinition
+	value      string
+}
+
+// set gives the object a value for one of its type's attributes, unless the value is empty.
+func (o *specObject) set(name, value string) *specObject {
+	if value != "" {
+		o.values = append(o.values, attributeValue{definition: o.typ.attribute(name), value: value})
+	}
+	return o
+}
+
+// relation is a SpecRelation between two exported entities.
+type relation struct {
+	source, target string
+	typ            relationType
+}
+
+// export is the content of the document of one model.
+type export struct {
+	model     core.Model
+	roots     []*specObject
+	objects   []*specObject // Every object in document order.
+	relations []relation
+}
+
+func newExport(model core.Model) *export {
+	e := &export{model: model}
+	for _, logic := range model.Invariants {
+		e.roots = append(e.roots, e.logic(logic, LogicKindInvariant))
+	}
+	for _, domain := range identity.SortedValues(model.Domains) {
+		e.roots = append(e.roots, e.domain(domain))
+	}
+
+	// Drop traces to entities that are not in the document.
+	exported := map[string]bool{}
+	for _, object := range e.objects {
+		exported[object.key] = true
+	}
+	e.relations = slices.DeleteFunc(e.relations, func(r relation) bool { return !exported[r.target] })
+	return e
+}
+
+// object creates a SpecObject for an entity, adding it to the document.
+func (e *export) object(key identity.Key, typ *objectType) *specObject {
+	object := &specObject{key: key.String(), typ: typ}
+	e.objects = append(e.objects, object)
+	return object.set(AttributeForeignID, key.String())
+}
+
+func (e *export) domain(domain model_domain.Domain) *specObject {
+	object := e.object(domain.Key, _sectionType).set(AttributeChapterName, domain.Name).set(AttributeText, domain.Details)
+	for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+		object.children = append(object.children, e.subdomain(subdomain))
+	}
+	return object
+}
+
+func (e *export) subdomain(subdomain model_domain.Subdomain) *specObject {
+	object := e.object(subdomain.Key, _sectionType).set(AttributeChapterName, subdomain.Name).set(AttributeText, subdomain.Details)
+	for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+		object.children = append(object.children, e.useCase(useCase))
+	}
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		object.children = append(object.children, e.class(class))
+	}
+	return object
+}
+
+func (e *export) useCase(useCase model_use_case.UseCase) *specObject {
+	object := e.object(useCase.Key, _useCaseType).
+		set(AttributeName, useCase.Name).
+		set(AttributeText, useCase.Details).
+		set(_attributeLevel, useCase.Level).
+		set(_attributeReadOnly, strconv.FormatBool(useCase.ReadOnly))
+
+	classKeys := identity.SortedKeys(useCase.Actors)
+	var eventKeys []identity.Key
+	for _, scenario := range identity.SortedValues(useCase.Scenarios) {
+		object.children = append(object.children, e.object(scenario.Key, _scenarioType).set(AttributeName, scenario.Name).set(AttributeText, scenario.Details))
+		for _, obj := range scenario.Objects {
+			classKeys = append(classKeys, obj.ClassKey)
+		}
+		eventKeys = append(eventKeys, stepEventKeys(scenario.Steps)...)
+	}
+	for _, classKey := range uniqueKeys(classKeys) {
+		e.relations = append(e.relations, relation{source: object.key, target: classKey.String(), typ: _involvesType})
+	}
+	for _, eventKey := range uniqueKeys(eventKeys) {
+		e.relations = append(e.relations, relation{source: object.key, target: eventKey.String(), typ: _triggersType})
+	}
+	return object
+}
+
+func (e *export) class(class model_class.Class) *specObject {
+	object := e.object(class.Key, _classType).
+		set(AttributeName, class.Name).
+		set(AttributeText, class.Details).
+		set(_attributeActor, strconv.FormatBool(class.ActorKey != nil))
+
+	var children []*specObject
+	for _, logic := range class.Invariants {
+		children = append(children, e.logic(logic, LogicKindInvariant))
+	}
+	for _, attribute := range class.Attributes {
+		for _, logic := range attribute.Invariants {
+			children = append(children, e.logic(logic, LogicKindInvariant))
+		}
+	}
+	for _, event := range identity.SortedValues(class.Events) {
+		children = append(children, e.object(event.Key, _eventType).
+			set(AttributeName, event.Name).
+			set(AttributeText, event.Details).
+			set(_attributeParameters, strings.Join(event.ParameterNames, ", ")))
+		e.relations = append(e.relations, relation{source: object.key, target: event.Key.String(), typ: _receivesType})
+	}
+	for _, action := range identity.SortedValues(class.Actions) {
+		children = append(children, e.logics(action.Requires, LogicKindRequire)...)
+		children = append(children, e.logics(action.Guarantees, LogicKindGuarantee)...)
+	}
+	for _, query := range identity.SortedValues(class.Queries) {
+		children = append(children, e.logics(query.Requires, LogicKindRequire)...)
+		children = append(children, e.logics(query.Guarantees, LogicKindGuarantee)...)
+	}
+	object.children = children
+	return object
+}
+
+func (e *export) logics(logics []model_logic.Logic, kind string) []*specObject {
+	objects := make([]*specObject, 0, len(logics))
+	for _, logic := range logics {
+		objects = append(objects, e.logic(logic, kind))
+	}
+	return objects
+}
+
+func (e *export) logic(logic model_logic.Logic, kind string) *specObject {
+	return e.object(logic.Key, _logicType).
+		set(AttributeName, logic.Description).
+		set(_attributeKind, kind).
+		set(_attributeNotation, logic.Spec.Notation).
+		set(_attributeSpecification, logic.Spec.Specification)
+}
+
+// stepEventKeys returns the keys of the events sent in a scenario's steps.
+func stepEventKeys(step *model_scenario.Step) []identity.Key {
+	if step == nil {
+		return nil
+	}
+	var keys []identity.Key
+	if step.EventKey != nil {
+		keys = append(keys, *step.EventKey)
+	}
+	for i := range step.Statements {
+		keys = append(keys, stepEventKeys(&step.Statements[i])...)
+	}
+	return keys
+}
+
+// uniqueKeys returns keys in order without duplicates.
+func uniqueKeys(keys []identity.Key) []identity.Key {
+	slices.SortFunc(keys, func(a, b identity.Key) int { return strings.Compare(a.String(), b.String()) })
+	return slices.Compact(keys)
+}
+
+// element returns the REQ-IF element of the document.
+func (e *export) element(lastChange string) *element {
+	identifiable := func(name, id, longName string) *element {
+		el := newElement(name, "IDENTIFIER", id, "LAST-CHANGE", lastChange)
+		if longName != "" {
+			el.attrs = append(el.attrs, "LONG-NAME", longName)
+		}
+		return el
+	}
+
+	header := newElement("REQ-IF-HEADER", "IDENTIFIER", identifier("header/"+e.model.Key)).add(
+		&element{name: "CREATION-TIME", text: lastChange},
+		&element{name: "REQ-IF-TOOL-ID", text: "req"},
+		&element{name: "REQ-IF-VERSION", text: "1.0"},
+		&element{name: "SOURCE-TOOL-ID", text: "req"},
+		&element{name: "TITLE", text: e.model.Name},
+	)
+
+	datatypes := newElement("DATATYPES")
+	for _, d := range _datatypes {
+		definition := identifiable("DATATYPE-DEFINITION-"+d.kind, d.id, d.name)
+		switch d.kind {
+		case "STRING":
+			definition.attrs = append(definition.attrs, "MAX-LENGTH", "1000000")
+		case "ENUMERATION":
+			values := newElement("SPECIFIED-VALUES")
+			for i, value := range d.values {
+				values.add(identifiable("ENUM-VALUE", d.enumValueID(value), value).add(
+					newElement("PROPERTIES").add(newElement("EMBEDDED-VALUE", "KEY", strconv.Itoa(i), "OTHER-CONTENT", value))))
+			}
+			definition.add(values)
+		}
+		datatypes.add(definition)
+	}
+
+	specTypes := newElement("SPEC-TYPES")
+	for _, t := range _objectTypes {
+		attributes := newElement("SPEC-ATTRIBUTES")
+		for _, a := range t.attributes {
+			definition := identifiable("ATTRIBUTE-DEFINITION-"+a.datatype.kind, a.id, a.name)
+			if a.datatype.kind == "ENUMERATION" {
+				definition.attrs = append(definition.attrs, "MULTI-VALUED", "false")
+			}
+			attributes.add(definition.add(ref("TYPE", "DATATYPE-DEFINITION-"+a.datatype.kind+"-REF", a.datatype.id)))
+		}
+		specTypes.add(identifiable("SPEC-OBJECT-TYPE", t.id, t.name).add(attributes))
+	}
+	for _, t := range _relationTypes {
+		specTypes.add(identifiable("SPEC-RELATION-TYPE", t.id, t.name))
+	}
+	specTypes.add(identifiable("SPECIFICATION-TYPE", "_type.specification", "Specification"))
+
+	objects := newElement("SPEC-OBJECTS")
+	for _, object := range e.objects {
+		values := newElement("VALUES")
+		for _, v := range object.values {
+			kind := v.definition.datatype.kind
+			definition := ref("DEFINITION", "ATTRIBUTE-DEFINITION-"+kind+"-REF", v.definition.id)
+			if kind == "ENUMERATION" {
+				values.add(newElement("ATTRIBUTE-VALUE-ENUMERATION").add(definition, ref("VALUES", "ENUM-VALUE-REF", v.definition.datatype.enumValueID(v.value))))
+				continue
+			}
+			values.add(newElement("ATTRIBUTE-VALUE-"+kind, "THE-VALUE", v.value).add(definition))
+		}
+		spec := identifiable("SPEC-OBJECT", identifier(object.key), "")
+		if len(values.children) > 0 {
+			spec.add(values)
+		}
+		objects.add(spec.add(ref("TYPE", "SPEC-OBJECT-TYPE-REF", object.typ.id)))
+	}
+
+	relations := newElement("SPEC-RELATIONS")
+	for _, r := range e.relations {
+		relations.add(identifiable("SPEC-RELATION", identifier(r.source+"/"+r.typ.id+"/"+r.target), "").add(
+			ref("SOURCE", "SPEC-OBJECT-REF", identifier(r.source)),
+			ref("TARGET", "SPEC-OBJECT-REF", identifier(r.target)),
+			ref("TYPE", "SPEC-RELATION-TYPE-REF", r.typ.id),
+		))
+	}
+
+	var hierarchy func(objects []*specObject) *element
+	hierarchy = func(objects []*specObject) *element {
+		children := newElement("CHILDREN")
+		for _, object := range objects {
+			node := identifiable("SPEC-HIERARCHY", identifier("hierarchy/"+object.key), "").add(ref("OBJECT", "SPEC-OBJECT-REF", identifier(object.key)))
+			if len(object.children) > 0 {
+				node.add(hierarchy(object.children))
+			}
+			children.add(node)
+		}
+		return children
+	}
+	specification := identifiable("SPECIFICATION", identifier("specification/"+e.model.Key), e.model.Name).add(ref("TYPE", "SPECIFICATION-TYPE-REF", "_type.specification"))
+	if len(e.roots) > 0 {
+		specification.add(hierarchy(e.roots))
+	}
+
+	content := newElement("REQ-IF-CONTENT").add(datatypes, specTypes, objects, relations, newElement("SPECIFICATIONS").add(specification))
+	return newElement("REQ-IF", "xmlns", Namespace).add(
+		newElement("THE-HEADER").add(header),
+		newElement("CORE-CONTENT").add(content),
+	)
+}
@@ -0,0 +1,390 @@
+package reqif
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// ImportDomain is the domain import places its stubs in, in the domain's default subdomain.
+const ImportDomain = "imported"
+
+// _importedFrom begins the unfinished notes of a stub, followed by the identifier of its object.
+const _importedFrom = "Imported from ReqIF object "
+
+// Import adds a stub to the model for every object of a ReqIF document it does not have yet,
+// and returns the keys of the stubs.
+//
+// Objects whose ReqIF.ForeignID is the key of an entity export writes are already in the
+// model and left alone, as are objects an earlier import made stubs for, and sections and
+// other headings. Objects of the Class type become
+// classes and all others use cases. The object's text, its other attributes and its relations
+// become the stub's unfinished notes, for analysts to work from as they move it into place.
+func Import(model *core.Model, document []byte) ([]identity.Key, error) {
+	var doc reqIFDocument
+	if err := xml.Unmarshal(document, &doc); err != nil {
+		return nil, errors.WithStack(err)
+	}
+	content := doc.Content
+
+	known := map[string]bool{}
+	stubbed := map[string]bool{}
+	for _, object := range newExport(*model).objects {
+		known[object.key] = true
+	}
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for _, class := range subdomain.Classes {
+				stubbed[stubIdentifier(class.UnfinishedNotes)] = true
+			}
+			for _, useCase := range subdomain.UseCases {
+				stubbed[stubIdentifier(useCase.UnfinishedNotes)] = true
+			}
+		}
+	}
+	names := map[string]string{}
+	for _, enumeration := range content.Enumerations {
+		for _, value := range enumeration.Values {
+			names[value.Identifier] = value.LongName
+		}
+	}
+	for _, t := range content.ObjectTypes {
+		names[t.Identifier] = t.LongName
+		for _, definition := range t.Attributes.Definitions {
+			names[definition.Identifier] = definition.LongName
+		}
+	}
+	for _, t := range content.RelationTypes {
+		names[t.Identifier] = t.LongName
+	}
+	for _, object := range content.Objects {
+		names[object.Identifier] = object.values(names).name(object)
+	}
+
+	stubs := &stubSubdomain{model: model}
+	var keys []identity.Key
+	for _, object := range content.Objects {
+		values := object.values(names)
+		if known[values.get(AttributeForeignID)] || stubbed[object.Identifier] || values.isHeading(names[object.Type]) {
+			continue
+		}
+		notes := importNotes(doc.Title, object, names, values, content.Relations)
+		key, err := stubs.add(names[object.Type] == TypeClass, values, values.name(object), notes)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, key)
+	}
+	return keys, nil
+}
+
+// importNotes returns the unfinished notes of the stub of an imported object.
+func importNotes(title string, object reqIFObject, names map[string]string, values importValues, relations []reqIFRelation) string {
+	var b strings.Builder
+	b.WriteString(_importedFrom + object.Identifier)
+	if typeName := names[object.Type]; typeName != "" {
+		fmt.Fprintf(&b, " (%s)", typeName)
+	}
+	if title != "" {
+		fmt.Fprintf(&b, " of %q", title)
+	}
+	b.WriteString(".\n")
+	if text := values.get(AttributeText); text != "" {
+		b.WriteString("\n" + text + "\n")
+	}
+
+	var items []string
+	for _, v := range values {
+		if v.name != AttributeName && v.name != AttributeText {
+			items = append(items, fmt.Sprintf("- %s: %s", v.name, v.value))
+		}
+	}
+	for _, r := range relations {
+		if r.Source == object.Identifier {
+			items = append(items, fmt.Sprintf("- %s → %s", names[r.Type], names[r.Target]))
+		}
+		if r.Target == object.Identifier {
+			items = append(items, fmt.Sprintf("- %s ← %s", names[r.Type], names[r.Source]))
+		}
+	}
+	if len(items) > 0 {
+		b.WriteString("\n" + strings.Join(items, "\n") + "\n")
+	}
+	return b.String()
+}
+
+// stubIdentifier returns the identifier of the object a stub was imported from, given its
+// unfinished notes, or "" if it is not a stub.
+func stubIdentifier(notes string) string {
+	rest, ok := strings.CutPrefix(notes, _importedFrom)
+	if !ok {
+		return ""
+	}
+	identifier, _, _ := strings.Cut(rest, " ")
+	return strings.TrimSuffix(strings.TrimSuffix(identifier, "\n"), ".")
+}
+
+// stubSubdomain adds stubs to the default subdomain of the import domain, creating it when needed.
+type stubSubdomain struct {
+	model     *core.Model
+	domain    model_domain.Domain
+	subdomain model_domain.Subdomain
+	created   bool
+}
+
+func (s *stubSubdomain) add(class bool, values importValues, name, notes string) (identity.Key, error) {
+	if err := s.create(); err != nil {
+		return identity.Key{}, err
+	}
+	newKey := identity.NewUseCaseKey
+	if class {
+		newKey = identity.NewClassKey
+	}
+	base := subKey(name)
+	key, err := newKey(s.subdomain.Key, base)
+	for n := 2; err == nil && s.taken(key); n++ {
+		key, err = newKey(s.subdomain.Key, fmt.Sprintf("%s_%d", base, n))
+	}
+	if err != nil {
+		return identity.Key{}, errors.WithStack(err)
+	}
+
+	if class {
+		if s.subdomain.Classes == nil {
+			s.subdomain.Classes = map[identity.Key]model_class.Class{}
+		}
+		s.subdomain.Classes[key] = model_class.NewClass(key, model_class.ClassLinks{}, model_class.ClassDetails{Name: name, UnfinishedNotes: notes})
+	} else {
+		level := values.get(_attributeLevel)
+		if !slices.Contains(_levelType.values, level) {
+			level = model_use_case.UseCaseLevelSea
+		}
+		if s.subdomain.UseCases == nil {
+			s.subdomain.UseCases = map[identity.Key]model_use_case.UseCase{}
+		}
+		s.subdomain.UseCases[key] = model_use_case.NewUseCase(key,
+			model_use_case.UseCaseTraits{Level: level, ReadOnly: values.get(_attributeReadOnly) == "true"},
+			model_use_case.GeneralizationRefs{},
+			model_use_case.UseCaseDetails{Name: name, UnfinishedNotes: notes})
+	}
+
+	s.domain.Subdomains[s.subdomain.Key] = s.subdomain
+	s.model.Domains[s.domain.Key] = s.domain
+	return key, nil
+}
+
+// create finds or creates the import domain and its default subdomain.
+func (s *stubSubdomain) create() error {
+	if s.created {
+		return nil
+	}
+	domainKey, err := identity.NewDomainKey(ImportDomain)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	subdomainKey, err := identity.NewSubdomainKey(domainKey, "default")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if s.model.Domains == nil {
+		s.model.Domains = map[identity.Key]model_domain.Domain{}
+	}
+	domain, ok := s.model.Domains[domainKey]
+	if !ok {
+		domain = model_domain.NewDomain(domainKey, "Imported", "", "Stubs imported from ReqIF documents, to move to their places in the model.", false, "")
+	}
+	if domain.Subdomains == nil {
+		domain.Subdomains = map[identity.Key]model_domain.Subdomain{}
+	}
+	subdomain, ok := domain.Subdomains[subdomainKey]
+	if !ok {
+		subdomain = model_domain.NewSubdomain(subdomainKey, "Default", "", "", "")
+	}
+	s.domain, s.subdomain, s.created = domain, subdomain, true
+	return nil
+}
+
+// taken reports whether a key is already used in the stub subdomain.
+func (s *stubSubdomain) taken(key identity.Key) bool {
+	_, isClass := s.subdomain.Classes[key]
+	_, isUseCase := s.subdomain.UseCases[key]
+	return isClass || isUseCase
+}
+
+// subKey returns an identifier sub key made from a name.
+func subKey(name string) string {
+	var b strings.Builder
+	underscore := false
+	for _, r := range strings.ToLower(name) {
+		if (r >= 'a' && r <= 'z') || (r >= '0' && r <= '9') {
+			if underscore && b.Len() > 0 {
+				b.WriteByte('_')
+			}
+			b.WriteRune(r)
+			underscore = false
+			continue
+		}
+		underscore = true
+	}
+	s := b.String()
+	if s == "" || s[0] < 'a' {
+		s = strings.TrimSuffix("requirement_"+s, "_")
+	}
+	return s
+}
+
+// importValue is an attribute value of an imported object, by attribute name.
+type importValue struct {
+	name, value string
+}
+
+// importValues are the attribute values of an imported object in document order.
+type importValues []importValue
+
+func (vs importValues) get(name string) string {
+	for _, v := range vs {
+		if v.name == name {
+			return v.value
+		}
+	}
+	return ""
+}
+
+// name returns what to call an imported object.
+func (vs importValues) name(object reqIFObject) string {
+	for _, name := range []string{vs.get(AttributeName), vs.get(AttributeChapterName), object.LongName, object.Identifier} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// isHeading reports whether an imported object only structures the document.
+func (vs importValues) isHeading(typeName string) bool {
+	return typeName == TypeSection || (vs.get(AttributeChapterName) != "" && vs.get(AttributeName) == "" && vs.get(AttributeText) == "")
+}
+
+// The parts of a ReqIF document import reads.
+type (
+	reqIFDocument struct {
+		Title   string       `xml:"THE-HEADER>REQ-IF-HEADER>TITLE"`
+		Content reqIFContent `xml:"CORE-CONTENT>REQ-IF-CONTENT"`
+	}
+	reqIFContent struct {
+		Enumerations  []reqIFEnumeration  `xml:"DATATYPES>DATATYPE-DEFINITION-ENUMERATION"`
+		ObjectTypes   []reqIFObjectType   `xml:"SPEC-TYPES>SPEC-OBJECT-TYPE"`
+		RelationTypes []reqIFIdentifiable `xml:"SPEC-TYPES>SPEC-RELATION-TYPE"`
+		Objects       []reqIFObject       `xml:"SPEC-OBJECTS>SPEC-OBJECT"`
+		Relations     []reqIFRelation     `xml:"SPEC-RELATIONS>SPEC-RELATION"`
+	}
+	reqIFIdentifiable struct {
+		Identifier string `xml:"IDENTIFIER,attr"`
+		LongName   string `xml:"LONG-NAME,attr"`
+	}
+	reqIFEnumeration struct {
+		Values []reqIFIdentifiable `xml:"SPECIFIED-VALUES>ENUM-VALUE"`
+	}
+	reqIFObjectType struct {
+		reqIFIdentifiable
+		Attributes struct {
+			Definitions []reqIFIdentifiable `xml:",any"`
+		} `xml:"SPEC-ATTRIBUTES"`
+	}
+	reqIFObject struct {
+		reqIFIdentifiable
+		Values struct {
+			Values []reqIFValue `xml:",any"`
+		} `xml:"VALUES"`
+		Type string `xml:"TYPE>SPEC-OBJECT-TYPE-REF"`
+	}
+	reqIFValue struct {
+		XMLName    xml.Name
+		TheValue   string `xml:"THE-VALUE,attr"`
+		Definition struct {
+			Refs []reqIFRef `xml:",any"`
+		} `xml:"DEFINITION"`
+		XHTML struct {
+			Inner []byte `xml:",innerxml"`
+		} `xml:"THE-VALUE"`
+		EnumRefs []string `xml:"VALUES>ENUM-VALUE-REF"`
+	}
+	reqIFRef struct {
+		Value string `xml:",chardata"`
+	}
+	reqIFRelation struct {
+		Source string `xml:"SOURCE>SPEC-OBJECT-REF"`
+		Target string `xml:"TARGET>SPEC-OBJECT-REF"`
+		Type   string `xml:"TYPE>SPEC-RELATION-TYPE-REF"`
+	}
+)
+
+// values returns the object's attribute values named by their definitions.
+func (o reqIFObject) values(names map[string]string) importValues {
+	var values importValues
+	for _, v := range o.Values.Values {
+		if len(v.Definition.Refs) == 0 {
+			continue
+		}
+		var value string
+		switch v.XMLName.Local {
+		case "ATTRIBUTE-VALUE-XHTML":
+			value = xhtmlText(v.XHTML.Inner)
+		case "ATTRIBUTE-VALUE-ENUMERATION":
+			literals := make([]string, 0, len(v.EnumRefs))
+			for _, ref := range v.EnumRefs {
+				literals = append(literals, names[strings.TrimSpace(ref)])
+			}
+			value = strings.Join(literals, ", ")
+		default:
+			value = v.TheValue
+		}
+		if value != "" {
+			values = append(values, importValue{name: names[strings.TrimSpace(v.Definition.Refs[0].Value)], value: value})
+		}
+	}
+	return values
+}
+
+// xhtmlText returns the text of an XHTML value, with its paragraphs on their own lines.
+func xhtmlText(inner []byte) string {
+	decoder := xml.NewDecoder(bytes.NewReader(inner))
+	decoder.Strict = false
+	var b strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return strings.TrimSpace(string(inner))
+			}
+			break
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			b.Write(t)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "p", "div", "li", "br", "h1", "h2", "h3", "h4", "h5", "h6", "tr":
+				b.WriteString("\n")
+			}
+		}
+	}
+	var lines []string
+	for _, line := range strings.Split(b.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,134 @@
+// Package reqif exchanges a model with requirements tools as ReqIF 1.2 documents.
+//
+// Export writes domains and subdomains as sections, and use cases, scenarios, classes,
+// events and logic entries (invariants, requires and guarantees) as typed SpecObjects
+// in one specification hierarchy. SpecRelations trace use cases to the classes they
+// involve and the events they trigger, and classes to the events they receive. Every
+// object carries its model key as ReqIF.ForeignID, so re-exports line up in the tools.
+//
+// Import reads a ReqIF document back and adds a stub class or use case, carrying the
+// incoming text as unfinished notes, for every object the model does not have yet.
+package reqif
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/pkg/errors"
+)
+
+// Namespace is the namespace of ReqIF documents.
+const Namespace = "http://www.omg.org/spec/ReqIF/20110401/reqif.xsd"
+
+// FileExtension is the extension of the generated document file.
+const FileExtension = ".reqif"
+
+// Standard attribute names that requirements tools map to their own fields.
+const (
+	AttributeForeignID   = "ReqIF.ForeignID"
+	AttributeName        = "ReqIF.Name"
+	AttributeText        = "ReqIF.Text"
+	AttributeChapterName = "ReqIF.ChapterName"
+)
+
+// Names of the SpecObject types.
+const (
+	TypeSection  = "Section"
+	TypeUseCase  = "Use case"
+	TypeScenario = "Scenario"
+	TypeClass    = "Class"
+	TypeEvent    = "Event"
+	TypeLogic    = "Logic"
+)
+
+// Names of the SpecRelation types.
+const (
+	RelationInvolves = "Involves class"
+	RelationTriggers = "Triggers event"
+	RelationReceives = "Receives event"
+)
+
+// Generate writes the document of the model into outputPath, named for the model key.
+func Generate(model core.Model, outputPath string) error {
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	path := filepath.Join(outputPath, model.Key+FileExtension)
+	if err := os.WriteFile(path, []byte(Document(model, time.Now())), 0o644); err != nil { //nolint:gosec // generated documents are intentionally world-readable
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Document returns the ReqIF document of a model, with every element last changed at lastChange.
+func Document(model core.Model, lastChange time.Time) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	newExport(model).element(lastChange.UTC().Format(time.RFC3339)).write(&b, 0)
+	return b.String()
+}
+
+// identifier returns the ReqIF identifier of a model key. Identifiers are XML ids, so the
+// key's slashes become dots and anything else an XML name cannot hold becomes an underscore.
+func identifier(key string) string {
+	return "_" + strings.Map(func(r rune) rune {
+		switch {
+		case r == '/':
+			return '.'
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, key)
+}
+
+// element is an XML element written with its attributes in the order they were given.
+type element struct {
+	name     string
+	attrs    []string // Alternating names and values.
+	text     string
+	children []*element
+}
+
+// newElement creates an element from its name and alternating attribute names and values.
+func newElement(name string, attrs ...string) *element {
+	return &element{name: name, attrs: attrs}
+}
+
+// ref creates an element wrapping a reference to another element, as <TYPE><SPEC-OBJECT-TYPE-REF>id</...></TYPE>.
+func ref(name, refName, id string) *element {
+	return newElement(name).add(&element{name: refName, text: id})
+}
+
+func (e *element) add(children ...*element) *element {
+	e.children = append(e.children, children...)
+	return e
+}
+
+var (
+	_attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\n", "&#10;", "\t", "&#9;", "\r", "&#13;")
+	_textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+func (e *element) write(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	b.WriteString(indent + "<" + e.name)
+	for i := 0; i+1 < len(e.attrs); i += 2 {
+		b.WriteString(" " + e.attrs[i] + `="` + _attrEscaper.Replace(e.attrs[i+1]) + `"`)
+	}
+	switch {
+	case len(e.children) > 0:
+		b.WriteString(">\n")
+		for _, child := range e.children {
+			child.write(b, depth+1)
+		}
+		b.WriteString(indent + "</" + e.name + ">\n")
+	case e.text != "":
+		b.WriteString(">" + _textEscaper.Replace(e.text) + "</" + e.name + ">\n")
+	default:
+		b.WriteString("/>\n")
+	}
+}
@@ -0,0 +1,309 @@
+package reqif
+
+import (
+	"encoding/xml"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/pkg/errors"
+	"github.com/stretchr/testify/suite"
+)
+
+type ReqIFSuite struct {
+	suite.Suite
+}
+
+func TestReqIFSuite(t *testing.T) {
+	suite.Run(t, new(ReqIFSuite))
+}
+
+var _lastChange = time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+// identifiers checks a document is well-formed XML and returns its IDENTIFIER values in order.
+func (suite *ReqIFSuite) identifiers(document string) []string {
+	var ids []string
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			return ids
+		}
+		suite.Require().NoError(err)
+		if start, ok := token.(xml.StartElement); ok {
+			for _, attr := range start.Attr {
+				if attr.Name.Local == "IDENTIFIER" {
+					ids = append(ids, attr.Value)
+				}
+			}
+		}
+	}
+}
+
+func (suite *ReqIFSuite) TestWellFormedWithUniqueIdentifiers() {
+	for _, model := range []core.Model{test_helper.GetBankModel(), test_helper.GetTestModel()} {
+		ids := suite.identifiers(Document(model, _lastChange))
+		seen := map[string]bool{}
+		for _, id := range ids {
+			suite.False(seen[id], "duplicate identifier %s", id)
+			seen[id] = true
+		}
+	}
+}
+
+func (suite *ReqIFSuite) TestStable() {
+	suite.Equal(Document(test_helper.GetBankModel(), _lastChange), Document(test_helper.GetBankModel(), _lastChange))
+}
+
+func (suite *ReqIFSuite) TestHeaderAndTypes() {
+	document := Document(test_helper.GetBankModel(), _lastChange)
+	suite.True(strings.HasPrefix(document, `<?xml version="1.0" encoding="UTF-8"?>
+<REQ-IF xmlns="http://www.omg.org/spec/ReqIF/20110401/reqif.xsd">
+  <THE-HEADER>
+    <REQ-IF-HEADER IDENTIFIER="_header.bank">
+      <CREATION-TIME>2026-10-18T09:30:00Z</CREATION-TIME>
+`), document)
+	suite.Contains(document, `<DATATYPE-DEFINITION-ENUMERATION IDENTIFIER="_datatype.level" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Level">
+          <SPECIFIED-VALUES>
+            <ENUM-VALUE IDENTIFIER="_datatype.level.sky" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="sky">
+              <PROPERTIES>
+                <EMBEDDED-VALUE KEY="0" OTHER-CONTENT="sky"/>
+              </PROPERTIES>
+            </ENUM-VALUE>`)
+	suite.Contains(document, `<ATTRIBUTE-DEFINITION-BOOLEAN IDENTIFIER="_type.use_case._Read_only" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Read only">
+              <TYPE>
+                <DATATYPE-DEFINITION-BOOLEAN-REF>_datatype.boolean</DATATYPE-DEFINITION-BOOLEAN-REF>
+              </TYPE>
+            </ATTRIBUTE-DEFINITION-BOOLEAN>`)
+	suite.Contains(document, `<SPEC-RELATION-TYPE IDENTIFIER="_relation_type.involves" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Involves class"/>`)
+}
+
+func (suite *ReqIFSuite) TestSpecObjects() {
+	document := Document(test_helper.GetBankModel(), _lastChange)
+	suite.Contains(document, `<SPEC-OBJECT IDENTIFIER="_domain.bank.subdomain.accounts.usecase.open_account" LAST-CHANGE="2026-10-18T09:30:00Z">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="domain/bank/subdomain/accounts/usecase/open_account">
+              <DEFINITION>
+                <ATTRIBUTE-DEFINITION-STRING-REF>_type.use_case._ReqIF.ForeignID</ATTRIBUTE-DEFINITION-STRING-REF>
+              </DEFINITION>
+            </ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="Open account">`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-STRING THE-VALUE="A clerk opens an account &amp; pays in.">`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-ENUMERATION>
+              <DEFINITION>
+                <ATTRIBUTE-DEFINITION-ENUMERATION-REF>_type.use_case._Level</ATTRIBUTE-DEFINITION-ENUMERATION-REF>
+              </DEFINITION>
+              <VALUES>
+                <ENUM-VALUE-REF>_datatype.level.sea</ENUM-VALUE-REF>
+              </VALUES>
+            </ATTRIBUTE-VALUE-ENUMERATION>`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-BOOLEAN THE-VALUE="false">`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-STRING THE-VALUE="amount, type">`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-STRING THE-VALUE="balance &gt;= 0">`)
+	for _, kind := range []string{"invariant", "require", "guarantee"} {
+		suite.Contains(document, "<ENUM-VALUE-REF>_datatype.logic_kind."+kind+"</ENUM-VALUE-REF>")
+	}
+}
+
+func (suite *ReqIFSuite) TestSpecRelations() {
+	document := Document(test_helper.GetBankModel(), _lastChange)
+	suite.Contains(document, `<SPEC-RELATION IDENTIFIER="_domain.bank.subdomain.accounts.usecase.open_account._relation_type.involves.domain.bank.subdomain.accounts.class.account" LAST-CHANGE="2026-10-18T09:30:00Z">
+          <SOURCE>
+            <SPEC-OBJECT-REF>_domain.bank.subdomain.accounts.usecase.open_account</SPEC-OBJECT-REF>
+          </SOURCE>
+          <TARGET>
+            <SPEC-OBJECT-REF>_domain.bank.subdomain.accounts.class.account</SPEC-OBJECT-REF>
+          </TARGET>
+          <TYPE>
+            <SPEC-RELATION-TYPE-REF>_relation_type.involves</SPEC-RELATION-TYPE-REF>
+          </TYPE>
+        </SPEC-RELATION>`)
+	suite.Contains(document, "_domain.bank.subdomain.accounts.usecase.open_account._relation_type.involves.domain.bank.subdomain.accounts.class.clerk")
+	suite.Contains(document, "_domain.bank.subdomain.accounts.usecase.open_account._relation_type.triggers.domain.bank.subdomain.accounts.class.account.event.deposit")
+	suite.Contains(document, "_domain.bank.subdomain.accounts.class.account._relation_type.receives.domain.bank.subdomain.accounts.class.account.event.deposit")
+}
+
+func (suite *ReqIFSuite) TestSpecificationHierarchy() {
+	document := Document(test_helper.GetBankModel(), _lastChange)
+	suite.Contains(document, `<SPECIFICATION IDENTIFIER="_specification.bank" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Bank">
+          <TYPE>
+            <SPECIFICATION-TYPE-REF>_type.specification</SPECIFICATION-TYPE-REF>
+          </TYPE>
+          <CHILDREN>
+            <SPEC-HIERARCHY IDENTIFIER="_hierarchy.invariant.0" LAST-CHANGE="2026-10-18T09:30:00Z">
+              <OBJECT>
+                <SPEC-OBJECT-REF>_invariant.0</SPEC-OBJECT-REF>
+              </OBJECT>
+            </SPEC-HIERARCHY>
+            <SPEC-HIERARCHY IDENTIFIER="_hierarchy.domain.bank" LAST-CHANGE="2026-10-18T09:30:00Z">
+              <OBJECT>
+                <SPEC-OBJECT-REF>_domain.bank</SPEC-OBJECT-REF>
+              </OBJECT>
+              <CHILDREN>
+                <SPEC-HIERARCHY IDENTIFIER="_hierarchy.domain.bank.subdomain.accounts" LAST-CHANGE="2026-10-18T09:30:00Z">`)
+	suite.Contains(document, `<SPEC-HIERARCHY IDENTIFIER="_hierarchy.domain.bank.subdomain.accounts.usecase.open_account.scenario.happy"`)
+}
+
+func (suite *ReqIFSuite) TestImportOwnExportAddsNothing() {
+	for _, model := range []core.Model{test_helper.GetBankModel(), test_helper.GetTestModel()} {
+		before := Document(model, _lastChange)
+		keys, err := Import(&model, []byte(before))
+		suite.Require().NoError(err)
+		suite.Empty(keys)
+		suite.Equal(before, Document(model, _lastChange))
+	}
+}
+
+// _foreignDocument is a ReqIF document from another tool, with a heading, requirements with
+// XHTML text and enumerated attributes, and a trace from a requirement to a component.
+const _foreignDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<REQ-IF xmlns="http://www.omg.org/spec/ReqIF/20110401/reqif.xsd" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <THE-HEADER><REQ-IF-HEADER IDENTIFIER="h"><TITLE>Customer spec</TITLE></REQ-IF-HEADER></THE-HEADER>
+  <CORE-CONTENT>
+    <REQ-IF-CONTENT>
+      <DATATYPES>
+        <DATATYPE-DEFINITION-STRING IDENTIFIER="dt-string" MAX-LENGTH="255"/>
+        <DATATYPE-DEFINITION-XHTML IDENTIFIER="dt-xhtml"/>
+        <DATATYPE-DEFINITION-ENUMERATION IDENTIFIER="dt-priority">
+          <SPECIFIED-VALUES>
+            <ENUM-VALUE IDENTIFIER="p-high" LONG-NAME="High"/>
+          </SPECIFIED-VALUES>
+        </DATATYPE-DEFINITION-ENUMERATION>
+      </DATATYPES>
+      <SPEC-TYPES>
+        <SPEC-OBJECT-TYPE IDENTIFIER="t-req" LONG-NAME="Requirement">
+          <SPEC-ATTRIBUTES>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="a-id" LONG-NAME="ReqIF.ForeignID"/>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="a-heading" LONG-NAME="ReqIF.ChapterName"/>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="a-name" LONG-NAME="ReqIF.Name"/>
+            <ATTRIBUTE-DEFINITION-XHTML IDENTIFIER="a-text" LONG-NAME="ReqIF.Text"/>
+            <ATTRIBUTE-DEFINITION-ENUMERATION IDENTIFIER="a-priority" LONG-NAME="Priority"/>
+          </SPEC-ATTRIBUTES>
+        </SPEC-OBJECT-TYPE>
+        <SPEC-OBJECT-TYPE IDENTIFIER="t-class" LONG-NAME="Class">
+          <SPEC-ATTRIBUTES>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="c-name" LONG-NAME="ReqIF.Name"/>
+          </SPEC-ATTRIBUTES>
+        </SPEC-OBJECT-TYPE>
+        <SPEC-RELATION-TYPE IDENTIFIER="r-satisfies" LONG-NAME="Satisfied by"/>
+      </SPEC-TYPES>
+      <SPEC-OBJECTS>
+        <SPEC-OBJECT IDENTIFIER="o-1">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="1 Statements"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-heading</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-req</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o-2">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="CUS-12"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-id</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="Monthly statement"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-name</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-XHTML>
+              <DEFINITION><ATTRIBUTE-DEFINITION-XHTML-REF>a-text</ATTRIBUTE-DEFINITION-XHTML-REF></DEFINITION>
+              <THE-VALUE><xhtml:div><xhtml:p>Send a statement &amp; a summary</xhtml:p><xhtml:p>every <xhtml:b>month</xhtml:b>.</xhtml:p></xhtml:div></THE-VALUE>
+            </ATTRIBUTE-VALUE-XHTML>
+            <ATTRIBUTE-VALUE-ENUMERATION>
+              <DEFINITION><ATTRIBUTE-DEFINITION-ENUMERATION-REF>a-priority</ATTRIBUTE-DEFINITION-ENUMERATION-REF></DEFINITION>
+              <VALUES><ENUM-VALUE-REF>p-high</ENUM-VALUE-REF></VALUES>
+            </ATTRIBUTE-VALUE-ENUMERATION>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-req</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o-3">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="Statement"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>c-name</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-class</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o-4">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="Monthly statement"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-name</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-req</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+      </SPEC-OBJECTS>
+      <SPEC-RELATIONS>
+        <SPEC-RELATION IDENTIFIER="r-1">
+          <SOURCE><SPEC-OBJECT-REF>o-2</SPEC-OBJECT-REF></SOURCE>
+          <TARGET><SPEC-OBJECT-REF>o-3</SPEC-OBJECT-REF></TARGET>
+          <TYPE><SPEC-RELATION-TYPE-REF>r-satisfies</SPEC-RELATION-TYPE-REF></TYPE>
+        </SPEC-RELATION>
+      </SPEC-RELATIONS>
+    </REQ-IF-CONTENT>
+  </CORE-CONTENT>
+</REQ-IF>`
+
+func (suite *ReqIFSuite) TestImportStubs() {
+	model := test_helper.GetTestModel()
+	keys, err := Import(&model, []byte(_foreignDocument))
+	suite.Require().NoError(err)
+
+	domainKey := helper.Must(identity.NewDomainKey(ImportDomain))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "default"))
+	useCaseKey := helper.Must(identity.NewUseCaseKey(subdomainKey, "monthly_statement"))
+	classKey := helper.Must(identity.NewClassKey(subdomainKey, "statement"))
+	duplicateKey := helper.Must(identity.NewUseCaseKey(subdomainKey, "monthly_statement_2"))
+	suite.Equal([]identity.Key{useCaseKey, classKey, duplicateKey}, keys)
+
+	domain := model.Domains[domainKey]
+	suite.Equal("Imported", domain.Name)
+	subdomain := domain.Subdomains[subdomainKey]
+
+	useCase := subdomain.UseCases[useCaseKey]
+	suite.Equal("Monthly statement", useCase.Name)
+	suite.Equal(model_use_case.UseCaseLevelSea, useCase.Level)
+	suite.Equal(`Imported from ReqIF object o-2 (Requirement) of "Customer spec".
+
+Send a statement & a summary
+every month.
+
+- ReqIF.ForeignID: CUS-12
+- Priority: High
+- Satisfied by → Statement
+`, useCase.UnfinishedNotes)
+
+	class := subdomain.Classes[classKey]
+	suite.Equal("Statement", class.Name)
+	suite.Equal(`Imported from ReqIF object o-3 (Class) of "Customer spec".
+
+- Satisfied by ← Monthly statement
+`, class.UnfinishedNotes)
+
+	suite.Require().NoError(model.Validate())
+
+	// The stubs are in the model now, so importing the document again, or the model's own
+	// export with the stubs in it, adds nothing more.
+	for _, document := range []string{_foreignDocument, Document(model, _lastChange)} {
+		keys, err = Import(&model, []byte(document))
+		suite.Require().NoError(err)
+		suite.Empty(keys)
+	}
+}
+
+func (suite *ReqIFSuite) TestImportInvalid() {
+	model := test_helper.GetBankModel()
+	_, err := Import(&model, []byte("<REQ-IF>"))
+	suite.Error(err)
+}
+
+func (suite *ReqIFSuite) TestSubKey() {
+	suite.Equal("monthly_statement", subKey("Monthly  statement!"))
+	suite.Equal("requirement_12_a", subKey("12 a"))
+	suite.Equal("requirement", subKey("§"))
+}
+
+func (suite *ReqIFSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath))
+	contents, err := os.ReadFile(filepath.Join(outputPath, "bank.reqif"))
+	suite.Require().NoError(err)
+	suite.Contains(string(contents), `<TITLE>Bank</TITLE>`)
+}
@@ -310,3 +310,96 @@ func addMermaidDestroyLeaf(ctx stepContext, builder *mermaidSequence, stmt model
 	builder.writeMessage(participantID, participantID, "(destroy)")
 	return nil
 }
+
+// ScenarioStepLine is one leaf step of a scenario as listed under its sequence diagram.
+// The anchor lets other pages, such as the traceability matrices, link to the step.
+type ScenarioStepLine struct {
+	Anchor string
+	Text   string
+}
+
+// scenarioStepAnchor is the HTML id of a scenario step on its use case page.
+func scenarioStepAnchor(stepKey identity.Key) string {
+	return mermaidNodeID("step", stepKey)
+}
+
+// scenarioLeafSteps returns the leaf steps of a scenario in the order they appear.
+func scenarioLeafSteps(scenario model_scenario.Scenario) []model_scenario.Step {
+	var leaves []model_scenario.Step
+	var walk func(step model_scenario.Step)
+	walk = func(step model_scenario.Step) {
+		if step.StepType == model_scenario.STEP_TYPE_LEAF {
+			leaves = append(leaves, step)
+			return
+		}
+		for _, stmt := range step.Statements {
+			walk(stmt)
+		}
+	}
+	if scenario.Steps != nil {
+		walk(*scenario.Steps)
+	}
+	return leaves
+}
+
+// generateScenarioStepLines describes each leaf step of a scenario, such as
+// "Alice:Customer → :Order: Customer pays — Pay(amount)".
+func generateScenarioStepLines(reqs *req_flat.Requirements, scenario model_scenario.Scenario) []ScenarioStepLine {
+	ctx := newStepContext(reqs)
+	queryLookup := reqs.QueryLookup()
+	objectName := func(key *identity.Key) string {
+		if key == nil {
+			return "?"
+		}
+		object, found := ctx.objectLookup[key.String()]
+		if !found {
+			return "?"
+		}
+		class, found := ctx.classLookup[object.ClassKey.String()]
+		if !found {
+			return "?"
+		}
+		// Multi objects start with "*", which markdown would read as emphasis.
+		return strings.ReplaceAll(object.GetName(class), "*", `\*`)
+	}
+	describe := func(description, name string) string {
+		switch {
+		case name == "":
+			return description
+		case description == "":
+			return name
+		}
+		return description + " — " + name
+	}
+
+	var lines []ScenarioStepLine
+	for _, step := range scenarioLeafSteps(scenario) {
+		var text string
+		switch {
+		case step.LeafType == nil:
+			continue
+		case *step.LeafType == model_scenario.LEAF_TYPE_EVENT:
+			signature := step
+			signature.Description = ""
+			text = describe(step.Description, buildEventText(ctx, signature))
+		case *step.LeafType == model_scenario.LEAF_TYPE_QUERY:
+			if step.QueryKey != nil {
+				text = describe(step.Description, queryLookup[step.QueryKey.String()].Name+"?")
+			} else {
+				text = step.Description
+			}
+		case *step.LeafType == model_scenario.LEAF_TYPE_SCENARIO:
+			if step.ScenarioKey != nil {
+				text = "Scenario: " + ctx.scenarioLookup[step.ScenarioKey.String()].Name
+			}
+		case *step.LeafType == model_scenario.LEAF_TYPE_DESTROY:
+			lines = append(lines, ScenarioStepLine{Anchor: scenarioStepAnchor(step.Key), Text: objectName(step.FromObjectKey) + " (destroy)"})
+			continue
+		}
+		lines = append(lines, ScenarioStepLine{
+			Anchor: scenarioStepAnchor(step.Key),
+			Text:   objectName(step.FromObjectKey) + " → " + objectName(step.ToObjectKey) + ": " + text,
+		})
+	}
+	return lines
+}
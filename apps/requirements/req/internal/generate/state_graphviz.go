@@ -0,0 +1,266 @@
+package generate
+
+import (
+	"encoding/json"
+	"fmt"
+	"html"
+	"os"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/trace"
+
+	"github.com/pkg/errors"
+)
+
+// The renderers state diagrams can be drawn with.
+const (
+	StateDiagramsMermaid  = "mermaid"  // Mermaid markup embedded in the page, rendered in the browser.
+	StateDiagramsGraphviz = "graphviz" // SVG laid out by Graphviz, linked from the page.
+)
+
+// stateDiagrams is the renderer state diagrams are drawn with.
+var stateDiagrams = StateDiagramsMermaid
+
+// stateCoverage is the simulation trace Graphviz state diagrams color transitions by.
+// Nil draws them without coverage.
+var stateCoverage *trace.SimulationTrace
+
+// SetStateDiagrams chooses the renderer generated markdown draws state diagrams with
+// (mermaid or graphviz). An empty renderer is mermaid.
+func SetStateDiagrams(renderer string) error {
+	switch renderer {
+	case "":
+		stateDiagrams = StateDiagramsMermaid
+	case StateDiagramsMermaid, StateDiagramsGraphviz:
+		stateDiagrams = renderer
+	default:
+		return errors.Errorf("state diagram renderer '%s' is not valid, want one of: %s, %s", renderer, StateDiagramsMermaid, StateDiagramsGraphviz)
+	}
+	return nil
+}
+
+// SetStateCoverage chooses the simulation trace whose transition counts Graphviz state
+// diagrams are colored by. Nil draws them without coverage.
+func SetStateCoverage(simTrace *trace.SimulationTrace) {
+	stateCoverage = simTrace
+}
+
+// ReadStateCoverage reads the trace from the JSON output of the simulate command, run
+// with -output json -trace.
+func ReadStateCoverage(path string) (*trace.SimulationTrace, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // the simulation output path is chosen by the user
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var output struct {
+		Trace *trace.SimulationTrace `json:"trace"`
+	}
+	if err := json.Unmarshal(data, &output); err != nil {
+		return nil, errors.Wrapf(err, "read simulation output '%s'", path)
+	}
+	if output.Trace == nil {
+		return nil, errors.Errorf("simulation output '%s' has no trace, run simulate with -output json -trace", path)
+	}
+	return output.Trace, nil
+}
+
+// graphvizStateDiagrams reports whether state diagrams are drawn by Graphviz, in which case
+// a class page's state diagram is the filename of its SVG rather than Mermaid markup.
+func graphvizStateDiagrams() bool {
+	return stateDiagrams == StateDiagramsGraphviz
+}
+
+// generateClassStateDiagram draws the state machine of a class with the chosen renderer.
+// With Mermaid it returns the markup. With Graphviz it writes the diagram to the class's
+// states SVG and returns that filename.
+func generateClassStateDiagram(reqs *req_flat.Requirements, writer ContentWriter, class model_class.Class) (string, error) {
+	if !graphvizStateDiagrams() {
+		return generateClassStateMermaidContents(reqs, class)
+	}
+	var counts map[identity.Key]int
+	if stateCoverage != nil {
+		counts = transitionCoverageCounts(class, stateCoverage.Steps)
+	}
+	svg, err := renderGraphvizSVG(generateClassStateGraphvizContents(class, counts))
+	if err != nil {
+		return "", err
+	}
+	svgFilename := convertKeyToFilename("class", class.Key.String(), "states", ".svg")
+	if err := writer.WriteSVG(svgFilename, svg); err != nil {
+		return "", err
+	}
+	return svgFilename, nil
+}
+
+// transitionCoverageCounts counts how many steps of a simulation trace took each
+// transition of a class, matching a step by its event and the names of the states it
+// moved between. Transitions that differ only by guard cannot be told apart in a trace,
+// so a step counts for each of them.
+func transitionCoverageCounts(class model_class.Class, steps []trace.TraceStep) map[identity.Key]int {
+	stateName := func(key *identity.Key) string {
+		if key == nil {
+			return ""
+		}
+		return class.States[*key].Name
+	}
+	counts := make(map[identity.Key]int, len(class.Transitions))
+	for key := range class.Transitions {
+		counts[key] = 0
+	}
+	var count func(steps []trace.TraceStep)
+	count = func(steps []trace.TraceStep) {
+		for _, step := range steps {
+			if step.ClassKey == class.Key.String() && step.EventName != "" {
+				for key, transition := range class.Transitions {
+					if class.Events[transition.EventKey].Name == step.EventName &&
+						stateName(transition.FromStateKey) == step.FromState &&
+						stateName(transition.ToStateKey) == step.ToState {
+						counts[key]++
+					}
+				}
+			}
+			count(step.CascadedSteps)
+		}
+	}
+	count(steps)
+	return counts
+}
+
+// Node ids of the pseudostates.
+const (
+	_stateGraphvizInitial = "initial"
+	_stateGraphvizFinal   = "final"
+)
+
+// generateClassStateGraphvizContents generates a UML state machine as DOT. States are
+// rounded boxes with a compartment of their entry/, do/ and exit/ actions. Creation
+// transitions start at an initial pseudostate and destruction transitions end at a
+// final one. Transitions are labeled "event [guard] / action"; the self-transitions of a
+// state are drawn as one loop with a line per transition, so a state with many of them
+// stays readable. With coverage counts each label ends with how often the simulation
+// took it and each edge is colored by its least taken transition.
+func generateClassStateGraphvizContents(class model_class.Class, coverage map[identity.Key]int) string {
+	var b strings.Builder
+	b.WriteString("digraph StateMachine {\n")
+	b.WriteString("    graph [rankdir=LR, nodesep=0.5, ranksep=0.7, fontname=\"Sans-Serif\", fontsize=11];\n")
+	b.WriteString("    node [shape=plain, fontname=\"Sans-Serif\", fontsize=11];\n")
+	b.WriteString("    edge [fontname=\"Sans-Serif\", fontsize=9, arrowhead=vee];\n")
+
+	transitionKeys := identity.SortedKeys(class.Transitions)
+	var hasInitial, hasFinal bool
+	for _, key := range transitionKeys {
+		hasInitial = hasInitial || class.Transitions[key].FromStateKey == nil
+		hasFinal = hasFinal || class.Transitions[key].ToStateKey == nil
+	}
+	if hasInitial {
+		fmt.Fprintf(&b, "    %s [shape=circle, style=filled, fillcolor=black, label=\"\", width=0.2, fixedsize=true];\n", _stateGraphvizInitial)
+	}
+	if hasFinal {
+		fmt.Fprintf(&b, "    %s [shape=doublecircle, style=filled, fillcolor=black, label=\"\", width=0.15, fixedsize=true];\n", _stateGraphvizFinal)
+	}
+
+	for _, key := range identity.SortedKeys(class.States) {
+		writeStateGraphvizNode(&b, class, class.States[key])
+	}
+
+	// Group transitions into edges; only self-transitions share one.
+	type stateEdge struct {
+		from, to string
+		keys     []identity.Key
+	}
+	var edges []*stateEdge
+	loops := map[string]*stateEdge{}
+	for _, key := range transitionKeys {
+		transition := class.Transitions[key]
+		from, to := _stateGraphvizInitial, _stateGraphvizFinal
+		if transition.FromStateKey != nil {
+			from = mermaidNodeID("state", *transition.FromStateKey)
+		}
+		if transition.ToStateKey != nil {
+			to = mermaidNodeID("state", *transition.ToStateKey)
+		}
+		if from == to {
+			if loop, ok := loops[from]; ok {
+				loop.keys = append(loop.keys, key)
+				continue
+			}
+			loops[from] = &stateEdge{from: from, to: to, keys: []identity.Key{key}}
+			edges = append(edges, loops[from])
+			continue
+		}
+		edges = append(edges, &stateEdge{from: from, to: to, keys: []identity.Key{key}})
+	}
+
+	for _, edge := range edges {
+		lines := make([]string, len(edge.keys))
+		least := -1
+		for i, key := range edge.keys {
+			lines[i] = stateTransitionLabel(class, class.Transitions[key])
+			if coverage != nil {
+				lines[i] += fmt.Sprintf(" (×%d)", coverage[key])
+				if least < 0 || coverage[key] < least {
+					least = coverage[key]
+				}
+			}
+		}
+		style := ""
+		if coverage != nil {
+			color := stateCoverageColor(least)
+			style = fmt.Sprintf(", color=%s, fontcolor=%s", dotQuote(color), dotQuote(color))
+		}
+		fmt.Fprintf(&b, "    %s -> %s [label=%s%s];\n", edge.from, edge.to, dotQuote(strings.Join(lines, "\n")), style)
+	}
+
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// writeStateGraphvizNode writes a state as a rounded HTML-like table with its name and,
+// when it has any, a compartment of its state actions in entry, do, exit order.
+func writeStateGraphvizNode(b *strings.Builder, class model_class.Class, state model_state.State) {
+	var label strings.Builder
+	label.WriteString(`<TABLE STYLE="ROUNDED" BORDER="1" CELLBORDER="0" CELLSPACING="0" CELLPADDING="4" BGCOLOR="#ECECFF">`)
+	label.WriteString(`<TR><TD><B>` + html.EscapeString(state.Name) + `</B></TD></TR>`)
+	if len(state.Actions) > 0 {
+		label.WriteString(`<TR><TD BORDER="1" SIDES="T" ALIGN="LEFT" BALIGN="LEFT">`)
+		for i, stateAction := range state.Actions {
+			if i > 0 {
+				label.WriteString(`<BR/>`)
+			}
+			label.WriteString(html.EscapeString(stateAction.When + "/ " + class.Actions[stateAction.ActionKey].Name))
+		}
+		label.WriteString(`</TD></TR>`)
+	}
+	label.WriteString(`</TABLE>`)
+	fmt.Fprintf(b, "    %s [tooltip=%s, label=<%s>];\n", mermaidNodeID("state", state.Key), dotQuote(state.Name), label.String())
+}
+
+// stateTransitionLabel labels a transition "event [guard] / action", leaving out a
+// missing guard or action.
+func stateTransitionLabel(class model_class.Class, transition model_state.Transition) string {
+	label := model_state.SystemEventDisplayName(class.Events[transition.EventKey].Name)
+	if transition.GuardKey != nil {
+		label += " [" + class.Guards[*transition.GuardKey].Logic.Description + "]"
+	}
+	if transition.ActionKey != nil {
+		label += " / " + class.Actions[*transition.ActionKey].Name
+	}
+	return label
+}
+
+// stateCoverageColor colors a transition by how often a simulation took it: red never,
+// orange once, green more often.
+func stateCoverageColor(count int) string {
+	switch {
+	case count <= 0:
+		return "#CC0000"
+	case count == 1:
+		return "#E69500"
+	default:
+		return "#2E8B57"
+	}
+}
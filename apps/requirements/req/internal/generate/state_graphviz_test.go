@@ -0,0 +1,167 @@
+package generate
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/trace"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// accountStateClass is an account that opens, takes deposits and audits while open,
+// closes when rich, and is then destroyed. The open state logs on entry and exit.
+func accountStateClass() model_class.Class {
+	subdomainKey := helper.Must(identity.NewSubdomainKey(helper.Must(identity.NewDomainKey("bank")), "accounts"))
+	classKey := helper.Must(identity.NewClassKey(subdomainKey, "account"))
+
+	openKey := helper.Must(identity.NewStateKey(classKey, "open"))
+	closedKey := helper.Must(identity.NewStateKey(classKey, "closed"))
+	newKey := helper.Must(identity.NewEventKey(classKey, "_new"))
+	depositKey := helper.Must(identity.NewEventKey(classKey, "deposit"))
+	auditKey := helper.Must(identity.NewEventKey(classKey, "audit"))
+	closeKey := helper.Must(identity.NewEventKey(classKey, "close"))
+	destroyKey := helper.Must(identity.NewEventKey(classKey, "_destroy"))
+	richKey := helper.Must(identity.NewGuardKey(classKey, "rich"))
+	openActionKey := helper.Must(identity.NewActionKey(classKey, "open"))
+	logActionKey := helper.Must(identity.NewActionKey(classKey, "log"))
+
+	class := model_class.NewClass(classKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Account"})
+	open := model_state.NewState(openKey, "Open", "", "")
+	open.SetActions([]model_state.StateAction{
+		model_state.NewStateAction(helper.Must(identity.NewStateActionKey(openKey, "exit", "log")), logActionKey, "exit"),
+		model_state.NewStateAction(helper.Must(identity.NewStateActionKey(openKey, "entry", "log")), logActionKey, "entry"),
+	})
+	class.States = map[identity.Key]model_state.State{
+		openKey:   open,
+		closedKey: model_state.NewState(closedKey, "Closed", "", ""),
+	}
+	class.Events = map[identity.Key]model_state.Event{
+		newKey:     model_state.NewEvent(newKey, "_new", "", nil),
+		depositKey: model_state.NewEvent(depositKey, "deposit", "", nil),
+		auditKey:   model_state.NewEvent(auditKey, "audit", "", nil),
+		closeKey:   model_state.NewEvent(closeKey, "close", "", nil),
+		destroyKey: model_state.NewEvent(destroyKey, "_destroy", "", nil),
+	}
+	class.Guards = map[identity.Key]model_state.Guard{
+		richKey: model_state.NewGuard(richKey, "rich", model_logic.Logic{Key: richKey, Type: model_logic.LogicTypeAssessment, Description: "balance > 50"}),
+	}
+	class.Actions = map[identity.Key]model_state.Action{
+		openActionKey: model_state.NewAction(openActionKey, model_state.ActionDetails{Name: "Open"}, nil, nil, nil, nil),
+		logActionKey:  model_state.NewAction(logActionKey, model_state.ActionDetails{Name: "Log"}, nil, nil, nil, nil),
+	}
+	class.Transitions = map[identity.Key]model_state.Transition{}
+	addTransition := func(from *identity.Key, event identity.Key, to *identity.Key, guard, action *identity.Key) {
+		subKey := func(key *identity.Key) string {
+			if key == nil {
+				return ""
+			}
+			return key.SubKey
+		}
+		key := helper.Must(identity.NewTransitionKey(classKey, subKey(from), event.SubKey, subKey(guard), subKey(action), subKey(to)))
+		class.Transitions[key] = model_state.NewTransition(key, event,
+			model_state.TransitionStateKeys{FromStateKey: from, ToStateKey: to},
+			model_state.TransitionLogicKeys{GuardKey: guard, ActionKey: action}, "")
+	}
+	addTransition(nil, newKey, &openKey, nil, &openActionKey)
+	addTransition(&openKey, depositKey, &openKey, nil, nil)
+	addTransition(&openKey, auditKey, &openKey, nil, nil)
+	addTransition(&openKey, closeKey, &closedKey, &richKey, nil)
+	addTransition(&closedKey, destroyKey, nil, nil, nil)
+	return class
+}
+
+func TestGenerateClassStateGraphvizContents(t *testing.T) {
+	class := accountStateClass()
+	openID := mermaidNodeID("state", helper.Must(identity.NewStateKey(class.Key, "open")))
+	closedID := mermaidNodeID("state", helper.Must(identity.NewStateKey(class.Key, "closed")))
+
+	dot := generateClassStateGraphvizContents(class, nil)
+
+	// Creation starts at the initial pseudostate and destruction ends at the final one.
+	assert.Contains(t, dot, "    initial [shape=circle, style=filled, fillcolor=black, label=\"\", width=0.2, fixedsize=true];\n")
+	assert.Contains(t, dot, "    final [shape=doublecircle, style=filled, fillcolor=black, label=\"\", width=0.15, fixedsize=true];\n")
+	assert.Contains(t, dot, "    initial -> "+openID+" [label=\"«new» / Open\"];\n")
+	assert.Contains(t, dot, "    "+closedID+" -> final [label=\"«destroy»\"];\n")
+
+	// State actions are listed entry first; both self-transitions share one loop.
+	assert.Contains(t, dot, `<TR><TD><B>Open</B></TD></TR><TR><TD BORDER="1" SIDES="T" ALIGN="LEFT" BALIGN="LEFT">entry/ Log<BR/>exit/ Log</TD></TR>`)
+	assert.Contains(t, dot, "    "+openID+" -> "+openID+" [label=\"audit\\ndeposit\"];\n")
+	assert.Contains(t, dot, "    "+openID+" -> "+closedID+" [label=\"close [balance > 50]\"];\n")
+
+	svg, err := renderGraphvizSVG(dot)
+	require.NoError(t, err)
+	assert.Contains(t, string(svg), "<svg")
+}
+
+func TestTransitionCoverageCounts(t *testing.T) {
+	class := accountStateClass()
+	steps := []trace.TraceStep{
+		{ClassKey: class.Key.String(), EventName: "_new", ToState: "Open", CascadedSteps: []trace.TraceStep{
+			{ClassKey: class.Key.String(), EventName: "deposit", FromState: "Open", ToState: "Open"},
+		}},
+		{ClassKey: class.Key.String(), EventName: "deposit", FromState: "Open", ToState: "Open"},
+		{ClassKey: "domain/bank/subdomain/accounts/class/other", EventName: "deposit", FromState: "Open", ToState: "Open"},
+		{ClassKey: class.Key.String(), Kind: "do"},
+	}
+
+	counts := transitionCoverageCounts(class, steps)
+	byLabel := map[string]int{}
+	for key, count := range counts {
+		byLabel[stateTransitionLabel(class, class.Transitions[key])] = count
+	}
+	assert.Equal(t, map[string]int{"«new» / Open": 1, "deposit": 2, "audit": 0, "close [balance > 50]": 0, "«destroy»": 0}, byLabel)
+
+	// Edges are colored by their least taken transition.
+	openID := mermaidNodeID("state", helper.Must(identity.NewStateKey(class.Key, "open")))
+	dot := generateClassStateGraphvizContents(class, counts)
+	assert.Contains(t, dot, "    initial -> "+openID+" [label=\"«new» / Open (×1)\", color=\"#E69500\", fontcolor=\"#E69500\"];\n")
+	assert.Contains(t, dot, "    "+openID+" -> "+openID+" [label=\"audit (×0)\\ndeposit (×2)\", color=\"#CC0000\", fontcolor=\"#CC0000\"];\n")
+}
+
+func TestReadStateCoverage(t *testing.T) {
+	dir := t.TempDir()
+	withTrace := filepath.Join(dir, "trace.json")
+	require.NoError(t, os.WriteFile(withTrace, []byte(`{"summary": {"steps_taken": 1}, "trace": {"steps_taken": 1, "steps": [{"step_number": 1, "event_name": "_new"}]}}`), 0o600))
+	simTrace, err := ReadStateCoverage(withTrace)
+	require.NoError(t, err)
+	require.Len(t, simTrace.Steps, 1)
+	assert.Equal(t, "_new", simTrace.Steps[0].EventName)
+
+	withoutTrace := filepath.Join(dir, "summary.json")
+	require.NoError(t, os.WriteFile(withoutTrace, []byte(`{"summary": {"steps_taken": 1}}`), 0o600))
+	_, err = ReadStateCoverage(withoutTrace)
+	assert.ErrorContains(t, err, "-output json -trace")
+}
+
+func TestGenerateMdWithGraphvizStateDiagrams(t *testing.T) {
+	require.NoError(t, SetStateDiagrams(StateDiagramsGraphviz))
+	defer func() { require.NoError(t, SetStateDiagrams("")) }()
+	assert.Error(t, SetStateDiagrams("plantuml"))
+
+	class := accountStateClass()
+	subdomainKey := helper.Must(identity.ParseKey(class.Key.ParentKey))
+	domainKey := helper.Must(identity.ParseKey(subdomainKey.ParentKey))
+	subdomain := model_domain.Subdomain{Key: subdomainKey, Name: "Accounts", Classes: map[identity.Key]model_class.Class{class.Key: class}}
+	model := core.Model{
+		Key:     "test_graphviz_states",
+		Name:    "Test",
+		Domains: map[identity.Key]model_domain.Domain{domainKey: {Key: domainKey, Name: "Bank", Subdomains: map[identity.Key]model_domain.Subdomain{subdomainKey: subdomain}}},
+	}
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	svg := convertKeyToFilename("class", class.Key.String(), "states", ".svg")
+	body := string(writer.md[convertKeyToFilename("class", class.Key.String(), "", ".md")])
+	assert.Contains(t, body, "# State Machine\n[![State diagram]("+svg+")]("+svg+")\n")
+	assert.NotContains(t, body, "stateDiagram-v2")
+	assert.Contains(t, string(writer.svg[svg]), "<svg")
+}
@@ -51,4 +51,9 @@ th, td {
   padding: 12px 16px;
   margin-bottom: 1.5em;
 }
+
+.traceability-uncovered {
+  color: #cc0000;
+  font-weight: bold;
+}
 `
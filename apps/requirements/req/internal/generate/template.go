@@ -48,11 +48,22 @@ var _templateRegistry = map[string]**template.Template{
 	"subdomain.md.template":        &_subdomainMdTemplate,
 	"subdomains.mermaid.template":  &_subdomainsMermaidTemplate,
 	"facts.md.template":            &_factsMdTemplate,
+	"data_dictionary.md.template":  &_dataDictionaryMdTemplate,
+	"traceability.md.template":     &_traceabilityMdTemplate,
+	"glossary.md.template":         &_glossaryMdTemplate,
+	"model.tex.template":           &_modelTexTemplate,
 }
 
 func init() {
+	if err := parseEmbeddedTemplates(); err != nil {
+		log.Fatalf("Failed to parse templates: %+v", err)
+	}
+}
+
+// parseEmbeddedTemplates parses the embedded templates into the registry.
+func parseEmbeddedTemplates() error {
 	// Walk through the embedded file system to find and parse all .template files.
-	err := fs.WalkDir(_templateFS, "templates", func(path string, d fs.DirEntry, err error) error {
+	return fs.WalkDir(_templateFS, "templates", func(path string, d fs.DirEntry, err error) error {
 		// Report any error walking into this path.
 		if err != nil {
 			return errors.WithStack(err)
@@ -70,9 +81,6 @@ func init() {
 
 		return parseAndRegisterTemplate(path)
 	})
-	if err != nil {
-		log.Fatalf("Failed to parse templates: %+v", err)
-	}
 }
 
 // parseAndRegisterTemplate reads, parses, and registers a single template file.
@@ -83,25 +91,47 @@ func parseAndRegisterTemplate(path string) error {
 		return errors.WithStack(err)
 	}
 
-	// Parse the template and add it to the set.
 	tmplName := filepath.Base(path)
-	tmpl, err := template.New(tmplName).Funcs(_funcMap).Parse(string(content))
-	if err != nil {
-		return errors.WithStack(err)
+	if err := registerTemplate(tmplName, content); err != nil {
+		return err
 	}
 
 	log.Printf("Parsed template: %s", tmplName)
 
+	return nil
+}
+
+// registerTemplate parses a template and stores it in the registry under its filename.
+func registerTemplate(tmplName string, content []byte) error {
 	// Register the template using the registry.
 	target, found := _templateRegistry[tmplName]
 	if !found {
 		return errors.WithStack(errors.Errorf(`unknown template filename: '%s'`, tmplName))
 	}
+
+	tmpl, err := parseTemplate(tmplName, content)
+	if err != nil {
+		return err
+	}
 	*target = tmpl
 
 	return nil
 }
 
+// parseTemplate parses a template with the template functions. LaTeX templates use << >>
+// as their delimiters, since LaTeX arguments are written in braces.
+func parseTemplate(tmplName string, content []byte) (*template.Template, error) {
+	tmpl := template.New(tmplName).Funcs(_funcMap)
+	if strings.HasSuffix(tmplName, ".tex.template") {
+		tmpl = tmpl.Delims("<<", ">>")
+	}
+	tmpl, err := tmpl.Parse(string(content))
+	if err != nil {
+		return nil, errors.Wrapf(err, "template '%s'", tmplName)
+	}
+	return tmpl, nil
+}
+
 // The templates in the system.
 var _modelMdTemplate *template.Template
 var _actorMdTemplate *template.Template
@@ -115,8 +145,14 @@ var _useCaseMdTemplate *template.Template
 var _subdomainMdTemplate *template.Template
 var _subdomainsMermaidTemplate *template.Template
 var _factsMdTemplate *template.Template
-
-// Define some function for our templates.
+var _dataDictionaryMdTemplate *template.Template
+var _traceabilityMdTemplate *template.Template
+var _glossaryMdTemplate *template.Template
+var _modelTexTemplate *template.Template
+
+// Define some function for our templates. These are the functions override and custom
+// templates can call (see SetTemplateDir), so each is documented in docs/templates.md and
+// keeps its name and arguments once added.
 var _funcMap = template.FuncMap{
 	"nodeid": func(idtype string, key identity.Key) string {
 		keyStr := key.String()
@@ -143,6 +179,9 @@ var _funcMap = template.FuncMap{
 	"parameter_data_type_display":         parameterDataTypeDisplay,
 	"parameter_simulation_markdown_lines": parameterSimulationMarkdownLines,
 	"first_md_paragraph":                  firstMdParagraph,
+	"join":                                strings.Join,
+	"table_text":                          markdownTableText,
+	"glossary_links":                      glossaryLinks,
 	"first_md_sentence": func(md string) (paragraph string) {
 		return firstSentence(firstMdParagraph(md))
 	},
@@ -187,6 +226,8 @@ var _funcMap = template.FuncMap{
 	"classes_mermaid_association_link_note": classesMermaidAssociationLinkNote,
 	"classes_mermaid_class_box_style":       classesMermaidClassBoxStyle,
 	"classes_mermaid_focal_class_style":     func() string { return classesMermaidFocalClassStyle },
+	"graphviz_class_diagrams":               graphvizClassDiagrams,
+	"graphviz_state_diagrams":               graphvizStateDiagrams,
 	"has_mermaid_focal_class":               hasMermaidFocalClass,
 	"mermaid_focal_class_key":               mermaidFocalClassKey,
 	"generalization_label": func(reqs *req_flat.Requirements, generalizationKey identity.Key) (value string) {
@@ -298,6 +339,10 @@ var _funcMap = template.FuncMap{
 		}
 		return contents
 	},
+	"scenario_steps": func(reqs *req_flat.Requirements, key identity.Key) (lines []ScenarioStepLine) {
+		lookup := reqs.ScenarioLookup()
+		return generateScenarioStepLines(reqs, lookup[key.String()])
+	},
 	"actor_lookup": func(reqs *req_flat.Requirements, key identity.Key) (actor model_actor.Actor) {
 		lookup := reqs.ActorLookup()
 		return lookup[key.String()]
@@ -468,6 +513,14 @@ var _funcMap = template.FuncMap{
 		return reqs.ClassAssociationTaggedInvariantGroups(classKey)
 	},
 	"class_invariants_without_association_tag": req_flat.ClassInvariantsWithoutAssociationTag,
+
+	// Formatting of the LaTeX document.
+	"tex":       latexText,
+	"tex_md":    markdownToLaTeX,
+	"tex_spec":  latexSpec,
+	"tex_index": latexIndex,
+	"tex_label": latexLabel,
+	"tex_ref":   latexRef,
 }
 
 func formatDataTypeRules(rules string, dataType *model_data_type.DataType) string {
@@ -0,0 +1,205 @@
+package generate
+
+import (
+	"io/fs"
+	"log"
+	"maps"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"strings"
+	"text/template"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+
+	"github.com/pkg/errors"
+)
+
+// The folder of a template directory holding custom templates, with a folder per scope
+// they are rendered for.
+const (
+	CustomTemplateDir       = "custom"
+	CustomTemplateModel     = "model"     // Rendered once for the model.
+	CustomTemplateSubdomain = "subdomain" // Rendered for each subdomain.
+	CustomTemplateClass     = "class"     // Rendered for each class.
+)
+
+var _customTemplateScopes = []string{CustomTemplateModel, CustomTemplateSubdomain, CustomTemplateClass}
+
+// _customTemplates are the custom templates of each scope, named by the file they render.
+var _customTemplates = map[string][]*template.Template{}
+
+// CustomPage is the data a custom template is rendered with. Domain and Subdomain are
+// set for subdomain and class templates, and Class for class templates.
+type CustomPage struct {
+	Reqs      *req_flat.Requirements
+	Model     core.Model
+	Domain    model_domain.Domain
+	Subdomain model_domain.Subdomain
+	Class     model_class.Class
+}
+
+// TemplateFuncs returns the functions templates can call, for tools that check or render
+// templates outside the generator. They are documented in docs/templates.md.
+func TemplateFuncs() template.FuncMap {
+	return maps.Clone(_funcMap)
+}
+
+// SetTemplateDir loads templates from a directory over the embedded ones. A file named
+// like an embedded template (class.md.template) replaces it, and the custom folder's
+// model, subdomain and class folders hold templates of additional pages, each named by
+// the file it renders (audit.md.template renders audit.md for the model, and a page
+// such as class-<key>-audit.md for each class). An empty directory keeps the embedded
+// templates.
+func SetTemplateDir(dir string) error {
+	if dir == "" {
+		return nil
+	}
+	info, err := os.Stat(dir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	if !info.IsDir() {
+		return errors.Errorf("template directory '%s' is not a directory", dir)
+	}
+	return loadTemplateOverrides(os.DirFS(dir))
+}
+
+// loadTemplateOverrides loads the override and custom templates of a template directory.
+func loadTemplateOverrides(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() {
+			if name == CustomTemplateDir {
+				if err := loadCustomTemplates(fsys); err != nil {
+					return err
+				}
+			}
+			continue
+		}
+
+		// Skip non-template files, such as a README.
+		if filepath.Ext(name) != ".template" {
+			continue
+		}
+		content, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := registerTemplate(name, content); err != nil {
+			return err
+		}
+		log.Printf("Overrode template: %s", name)
+	}
+	return nil
+}
+
+// loadCustomTemplates loads the templates of each scope folder of the custom folder.
+func loadCustomTemplates(fsys fs.FS) error {
+	entries, err := fs.ReadDir(fsys, CustomTemplateDir)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+	for _, entry := range entries {
+		scope := entry.Name()
+		if !entry.IsDir() {
+			continue
+		}
+		if !slices.Contains(_customTemplateScopes, scope) {
+			return errors.Errorf("unknown custom template folder '%s', want one of: %s", scope, strings.Join(_customTemplateScopes, ", "))
+		}
+
+		files, err := fs.ReadDir(fsys, path.Join(CustomTemplateDir, scope))
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		for _, file := range files {
+			name := file.Name()
+			if file.IsDir() || filepath.Ext(name) != ".template" {
+				continue
+			}
+			switch filepath.Ext(strings.TrimSuffix(name, ".template")) {
+			case ".md", ".csv", ".svg":
+			default:
+				return errors.Errorf("custom template '%s' must render a .md, .csv or .svg file", path.Join(scope, name))
+			}
+			content, err := fs.ReadFile(fsys, path.Join(CustomTemplateDir, scope, name))
+			if err != nil {
+				return errors.WithStack(err)
+			}
+			tmpl, err := parseTemplate(name, content)
+			if err != nil {
+				return err
+			}
+			_customTemplates[scope] = append(_customTemplates[scope], tmpl)
+			log.Printf("Parsed custom template: %s", path.Join(scope, name))
+		}
+	}
+	return nil
+}
+
+// generateCustomFilesToWriter renders the custom templates for the model, each subdomain
+// and each class.
+func generateCustomFilesToWriter(reqs *req_flat.Requirements, writer ContentWriter) error {
+	for _, tmpl := range _customTemplates[CustomTemplateModel] {
+		page := CustomPage{Reqs: reqs, Model: reqs.Model}
+		if err := writeCustomPage(writer, tmpl, customPageFilename(tmpl, "", ""), page); err != nil {
+			return err
+		}
+	}
+
+	for _, domain := range reqs.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for _, tmpl := range _customTemplates[CustomTemplateSubdomain] {
+				page := CustomPage{Reqs: reqs, Model: reqs.Model, Domain: domain, Subdomain: subdomain}
+				if err := writeCustomPage(writer, tmpl, customPageFilename(tmpl, "subdomain", subdomain.Key.String()), page); err != nil {
+					return err
+				}
+			}
+			for _, class := range subdomain.Classes {
+				for _, tmpl := range _customTemplates[CustomTemplateClass] {
+					page := CustomPage{Reqs: reqs, Model: reqs.Model, Domain: domain, Subdomain: subdomain, Class: class}
+					if err := writeCustomPage(writer, tmpl, customPageFilename(tmpl, "class", class.Key.String()), page); err != nil {
+						return err
+					}
+				}
+			}
+		}
+	}
+
+	return nil
+}
+
+// customPageFilename is the file a custom template renders: the template's name for the
+// model, and the page of the subdomain or class suffixed with it otherwise.
+func customPageFilename(tmpl *template.Template, objType, key string) string {
+	name := strings.TrimSuffix(tmpl.Name(), ".template")
+	if objType == "" {
+		return name
+	}
+	ext := filepath.Ext(name)
+	return convertKeyToFilename(objType, key, strings.TrimSuffix(name, ext), ext)
+}
+
+func writeCustomPage(writer ContentWriter, tmpl *template.Template, filename string, page CustomPage) error {
+	contents, err := generateFromTemplate(tmpl, page)
+	if err != nil {
+		return err
+	}
+	switch filepath.Ext(filename) {
+	case ".csv":
+		return writer.WriteCSV(filename, []byte(contents))
+	case ".svg":
+		return writer.WriteSVG(filename, []byte(contents))
+	default:
+		return writer.WriteMarkdown(filename, []byte(contents))
+	}
+}
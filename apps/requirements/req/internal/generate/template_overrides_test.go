@@ -0,0 +1,113 @@
+package generate
+
+import (
+	"os"
+	"testing"
+	"testing/fstest"
+	"text/template"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// restoreTemplates puts back the embedded templates once a test overrides them.
+func restoreTemplates(t *testing.T) {
+	t.Cleanup(func() {
+		require.NoError(t, parseEmbeddedTemplates())
+		_customTemplates = map[string][]*template.Template{}
+	})
+}
+
+func TestLoadTemplateOverrides(t *testing.T) {
+	restoreTemplates(t)
+
+	require.NoError(t, loadTemplateOverrides(fstest.MapFS{
+		"actor.md.template": {Data: []byte(`Actor {{ .Actor.Name }} of {{ .Reqs.Model.Name }}`)},
+		"README.md":         {Data: []byte(`Not a template.`)},
+	}))
+
+	reqs := req_flat.NewRequirements(test_helper.GetTestModel())
+	reqs.PrepLookups()
+	writer := newCollectWriter()
+	require.NoError(t, generateActorFilesToWriter(reqs, writer))
+	require.NotEmpty(t, writer.md)
+	for filename, contents := range writer.md {
+		assert.Regexp(t, `^Actor .+ of Test Model$`, string(contents), filename)
+	}
+
+	// The templates that aren't overridden are still the embedded ones.
+	assert.Contains(t, _classMdTemplate.Tree.Root.String(), "class_domain")
+}
+
+func TestLoadTemplateOverridesErrors(t *testing.T) {
+	restoreTemplates(t)
+
+	err := loadTemplateOverrides(fstest.MapFS{"clas.md.template": {Data: []byte(``)}})
+	assert.ErrorContains(t, err, "unknown template filename: 'clas.md.template'")
+
+	err = loadTemplateOverrides(fstest.MapFS{"class.md.template": {Data: []byte(`{{ .Class.Name`)}})
+	assert.ErrorContains(t, err, "template 'class.md.template'")
+
+	err = loadTemplateOverrides(fstest.MapFS{"custom/domain/page.md.template": {Data: []byte(``)}})
+	assert.ErrorContains(t, err, "unknown custom template folder 'domain'")
+
+	err = loadTemplateOverrides(fstest.MapFS{"custom/class/page.html.template": {Data: []byte(``)}})
+	assert.ErrorContains(t, err, "custom template 'class/page.html.template' must render a .md, .csv or .svg file")
+}
+
+func TestGenerateCustomFiles(t *testing.T) {
+	restoreTemplates(t)
+
+	require.NoError(t, loadTemplateOverrides(fstest.MapFS{
+		"custom/model/audit.md.template":       {Data: []byte(`Audit of {{ .Model.Name }}`)},
+		"custom/subdomain/owners.csv.template": {Data: []byte(`{{ .Domain.Name }},{{ .Subdomain.Name }}`)},
+		"custom/class/review.md.template":      {Data: []byte(`Review of {{ .Class.Name }} in {{ .Subdomain.Name }}`)},
+	}))
+
+	reqs := req_flat.NewRequirements(test_helper.GetTestModel())
+	reqs.PrepLookups()
+	writer := newCollectWriter()
+	require.NoError(t, generateCustomFilesToWriter(reqs, writer))
+
+	assert.Equal(t, "Audit of Test Model", string(writer.md["audit.md"]))
+
+	subdomains, classes := 0, 0
+	for _, domain := range reqs.Domains {
+		for _, subdomain := range domain.Subdomains {
+			subdomains++
+			filename := convertKeyToFilename("subdomain", subdomain.Key.String(), "owners", ".csv")
+			assert.Equal(t, domain.Name+","+subdomain.Name, string(writer.csv[filename]), filename)
+			for _, class := range subdomain.Classes {
+				classes++
+				filename := convertKeyToFilename("class", class.Key.String(), "review", ".md")
+				assert.Equal(t, "Review of "+class.Name+" in "+subdomain.Name, string(writer.md[filename]), filename)
+			}
+		}
+	}
+	assert.Len(t, writer.csv, subdomains)
+	assert.Len(t, writer.md, classes+1)
+}
+
+func TestSetTemplateDir(t *testing.T) {
+	assert.NoError(t, SetTemplateDir(""))
+
+	assert.Error(t, SetTemplateDir("missing"))
+
+	file := t.TempDir() + "/file"
+	require.NoError(t, os.WriteFile(file, nil, 0o644))
+	assert.ErrorContains(t, SetTemplateDir(file), "is not a directory")
+}
+
+func TestTemplateFuncsDocumented(t *testing.T) {
+	doc, err := os.ReadFile("../../docs/templates.md")
+	require.NoError(t, err)
+
+	for name := range TemplateFuncs() {
+		assert.Contains(t, string(doc), "| `"+name+"` |", "function '%s' is not in docs/templates.md", name)
+	}
+	for name := range _templateRegistry {
+		assert.Contains(t, string(doc), "| `"+name+"` |", "template '%s' is not in docs/templates.md", name)
+	}
+}
@@ -0,0 +1,100 @@
+package testcases
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// _indent is the indentation of one Gherkin nesting level.
+const _indent = "  "
+
+// Feature renders a suite as a Gherkin feature with a scenario per case. The events
+// before the transition are givens, the transition is the when, and its expected state
+// and attribute values are the thens. Uncovered transitions are listed as comments.
+func Feature(suite *Suite) string {
+	w := &featureWriter{}
+	w.line(0, "# Transition coverage of the %s state machine. Generated by req.", suite.Class)
+	w.line(0, "Feature: %s state machine", suite.Class)
+	if suite.Details != "" {
+		for _, line := range strings.Split(suite.Details, "\n") {
+			w.line(1, "%s", strings.TrimRight(line, " \t\r"))
+		}
+	}
+	subject := "the " + suite.Class
+	for _, c := range suite.Cases {
+		w.line(0, "")
+		w.line(1, "Scenario: %s", c.Name)
+		last := len(c.Steps) - 1
+		for i, step := range c.Steps[:last] {
+			keyword := "And"
+			if i == 0 {
+				keyword = "Given"
+			}
+			w.event(keyword, subject+" received", step)
+		}
+		target := c.Steps[last]
+		w.event("When", subject+" receives", target)
+		if target.ToState == "" {
+			w.line(2, "Then %s is destroyed", subject)
+		} else {
+			w.line(2, "Then %s is in state %q", subject, target.ToState)
+		}
+		for _, name := range sortedNames(target.Assignments) {
+			w.line(2, "And its %s is %s", name, target.Assignments[name])
+		}
+	}
+	if len(suite.Uncovered) > 0 {
+		w.line(0, "")
+		w.line(1, "# Transitions without a scenario:")
+		for _, uncovered := range suite.Uncovered {
+			w.line(1, "#   %s: %s", uncovered.Name, uncovered.Reason)
+		}
+	}
+	return w.String()
+}
+
+// featureWriter accumulates the text of one feature file.
+type featureWriter struct {
+	strings.Builder
+}
+
+func (w *featureWriter) line(depth int, format string, args ...any) {
+	text := fmt.Sprintf(format, args...)
+	if text != "" {
+		w.WriteString(strings.Repeat(_indent, depth))
+	}
+	w.WriteString(text)
+	w.WriteString("\n")
+}
+
+// event writes the step of an event, with its parameters as a data table.
+func (w *featureWriter) event(keyword, verb string, step Step) {
+	if len(step.Parameters) == 0 {
+		w.line(2, "%s %s %q", keyword, verb, step.Event)
+		return
+	}
+	w.line(2, "%s %s %q with:", keyword, verb, step.Event)
+	names := sortedNames(step.Parameters)
+	width := 0
+	for _, name := range names {
+		width = max(width, len(cell(name)))
+	}
+	for _, name := range names {
+		w.line(3, "| %-*s | %s |", width, cell(name), cell(step.Parameters[name]))
+	}
+}
+
+// cell escapes a value for a data table cell.
+func cell(value string) string {
+	return strings.NewReplacer(`\`, `\\`, "|", `\|`, "\n", `\n`).Replace(value)
+}
+
+func sortedNames(values map[string]string) []string {
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
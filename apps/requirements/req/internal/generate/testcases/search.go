@@ -0,0 +1,185 @@
+package testcases
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/engine"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/state"
+
+	"github.com/pkg/errors"
+)
+
+// Bounds of the search for the case of one transition.
+const (
+	_maxCandidates = 8    // Event sequences tried, shortest first.
+	_maxExpansions = 1000 // Partial sequences explored looking for candidates.
+	_seeds         = 8    // Simulator seeds each candidate is replayed with.
+)
+
+// classSuite finds a case for every transition of a class.
+func classSuite(model *core.Model, config engine.SimulationConfig, class model_class.Class) (*Suite, error) {
+	// A model the simulator cannot load fails every replay, so report it once.
+	if _, err := engine.NewSimulationEngine(model, config); err != nil {
+		return nil, errors.Wrapf(err, "simulating class %s", class.Name)
+	}
+
+	suite := &Suite{Class: class.Name, ClassKey: class.Key.String(), Details: strings.TrimSpace(class.Details)}
+	for _, transition := range identity.SortedValues(class.Transitions) {
+		name := caseName(class, transition)
+		found, reason := findCase(model, config, class, transition)
+		if found == nil {
+			suite.Uncovered = append(suite.Uncovered, Uncovered{Name: name, TransitionKey: transition.Key.String(), Reason: reason})
+			continue
+		}
+		found.Name = name
+		suite.Cases = append(suite.Cases, *found)
+	}
+	return suite, nil
+}
+
+// findCase replays the candidate sequences of a transition, shortest first, until one
+// takes every transition it names. Otherwise it returns why none did.
+func findCase(model *core.Model, config engine.SimulationConfig, class model_class.Class, target model_state.Transition) (*Case, string) {
+	candidates := candidatePaths(class, target)
+	if len(candidates) == 0 {
+		return nil, "no event sequence from creation reaches " + stateName(class, target.FromStateKey)
+	}
+	var lastErr error
+	for _, path := range candidates {
+		for seed := range int64(_seeds) {
+			steps, err := replay(model, config, class, path, seed)
+			if err != nil {
+				lastErr = err
+				continue
+			}
+			return &Case{TransitionKey: target.Key.String(), Seed: seed, Steps: steps}, ""
+		}
+	}
+	return nil, "no replay took the transition: " + lastErr.Error()
+}
+
+// candidatePaths returns transition sequences that start with a creation and end with
+// target, shortest first. Sequences may revisit states, since guards can depend on
+// attributes that only a loop changes.
+func candidatePaths(class model_class.Class, target model_state.Transition) [][]model_state.Transition {
+	if target.FromStateKey == nil {
+		return [][]model_state.Transition{{target}}
+	}
+	transitions := identity.SortedValues(class.Transitions)
+	var queue [][]model_state.Transition
+	for _, transition := range transitions {
+		if transition.FromStateKey == nil && transition.ToStateKey != nil {
+			queue = append(queue, []model_state.Transition{transition})
+		}
+	}
+
+	var paths [][]model_state.Transition
+	for expansions := 0; len(queue) > 0 && len(paths) < _maxCandidates && expansions < _maxExpansions; expansions++ {
+		path := queue[0]
+		queue = queue[1:]
+		at := *path[len(path)-1].ToStateKey
+		if at == *target.FromStateKey {
+			paths = append(paths, append(slices.Clone(path), target))
+		}
+		if len(path) >= len(transitions) {
+			continue
+		}
+		for _, transition := range transitions {
+			if transition.FromStateKey != nil && *transition.FromStateKey == at && transition.ToStateKey != nil {
+				queue = append(queue, append(slices.Clone(path), transition))
+			}
+		}
+	}
+	return paths
+}
+
+// replay fires the events of path on one new instance in a fresh simulation, checking
+// that each takes its transition without violating an invariant.
+func replay(model *core.Model, config engine.SimulationConfig, class model_class.Class, path []model_state.Transition, seed int64) ([]Step, error) {
+	config.RandomSeed = seed
+	simulation, err := engine.NewSimulationEngine(model, config)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+	var instance *state.ClassInstance
+	steps := make([]Step, 0, len(path))
+	for _, transition := range path {
+		event := class.Events[transition.EventKey]
+		simStep, err := simulation.FireEvent(class.Key, transition.EventKey, instance)
+		if err != nil {
+			return nil, errors.Wrapf(err, "event %s", event.Name)
+		}
+		if simStep.TransitionResult == nil || simStep.TransitionResult.TransitionKey != transition.Key {
+			return nil, errors.Errorf("event %s did not take transition %s", event.Name, transition.Key.String())
+		}
+		if simStep.Violations.HasViolations() {
+			return nil, errors.Errorf("event %s: %s", event.Name, simStep.Violations.Error())
+		}
+		steps = append(steps, newStep(event, simStep))
+		instance = simulation.State().GetInstance(simStep.InstanceID)
+	}
+	return steps, nil
+}
+
+// newStep records what a simulated step did to its instance.
+func newStep(event model_state.Event, simStep *engine.SimulationStep) Step {
+	step := Step{
+		Event:     event.Name,
+		EventKey:  event.Key.String(),
+		FromState: simStep.FromState,
+		ToState:   simStep.ToState,
+	}
+	if len(simStep.Parameters) > 0 {
+		step.Parameters = make(map[string]string, len(simStep.Parameters))
+		for name, value := range simStep.Parameters {
+			step.Parameters[name] = value.Inspect()
+		}
+	}
+	if result := simStep.TransitionResult.ActionResult; result != nil {
+		for name, value := range result.PrimedAssignments[simStep.InstanceID] {
+			if name == "_state" {
+				continue
+			}
+			if step.Assignments == nil {
+				step.Assignments = make(map[string]string)
+			}
+			step.Assignments[name] = value.Inspect()
+		}
+	}
+	return step
+}
+
+// caseName describes a transition, such as "close takes Open to Closed when paid up".
+func caseName(class model_class.Class, transition model_state.Transition) string {
+	event := class.Events[transition.EventKey].Name
+	var name string
+	switch {
+	case transition.FromStateKey == nil:
+		name = event + " creates in " + stateName(class, transition.ToStateKey)
+	case transition.ToStateKey == nil:
+		name = event + " destroys in " + stateName(class, transition.FromStateKey)
+	default:
+		name = event + " takes " + stateName(class, transition.FromStateKey) + " to " + stateName(class, transition.ToStateKey)
+	}
+	if transition.GuardKey != nil {
+		guard := class.Guards[*transition.GuardKey]
+		name += " when " + guard.Name
+	}
+	return name
+}
+
+// stateName names a state of a class by its key.
+func stateName(class model_class.Class, stateKey *identity.Key) string {
+	if stateKey == nil {
+		return ""
+	}
+	if s, ok := class.States[*stateKey]; ok {
+		return s.Name
+	}
+	return stateKey.SubKey
+}
@@ -0,0 +1,158 @@
+// Package testcases generates transition-coverage test suites from class state machines.
+//
+// Each class with transitions gets one suite, with a test case per transition: the
+// shortest event sequence from creation that takes it. Candidate sequences are replayed
+// through the simulator, whose parameter sampler finds event parameters that satisfy
+// the guards on the way, and each step records the state and primed attribute values
+// it is expected to produce. Suites are written as Gherkin feature files and as JSON.
+package testcases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/engine"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/surface"
+
+	"github.com/pkg/errors"
+)
+
+// Extensions of the two files generated for each suite.
+const (
+	FeatureExtension = ".feature"
+	JSONExtension    = ".testcases.json"
+)
+
+// Suite is the transition-coverage test suite of one class.
+type Suite struct {
+	Class     string      `json:"class"`
+	ClassKey  string      `json:"class_key"`
+	Details   string      `json:"details,omitempty"`
+	Cases     []Case      `json:"cases"`
+	Uncovered []Uncovered `json:"uncovered,omitempty"`
+}
+
+// Case is the event sequence that takes one transition. Its last step is the transition.
+type Case struct {
+	Name          string `json:"name"`
+	TransitionKey string `json:"transition_key"`
+	Seed          int64  `json:"seed"` // The simulator seed the sequence was replayed with.
+	Steps         []Step `json:"steps"`
+}
+
+// Step is one event of a case and what it is expected to do. Values are written in
+// the simulator's notation.
+type Step struct {
+	Event       string            `json:"event"`
+	EventKey    string            `json:"event_key"`
+	Parameters  map[string]string `json:"parameters,omitempty"`
+	FromState   string            `json:"from_state,omitempty"` // Empty for creation.
+	ToState     string            `json:"to_state,omitempty"`   // Empty for destruction.
+	Assignments map[string]string `json:"assignments,omitempty"`
+}
+
+// Uncovered is a transition no test case could be found for.
+type Uncovered struct {
+	Name          string `json:"name"`
+	TransitionKey string `json:"transition_key"`
+	Reason        string `json:"reason"`
+}
+
+// File is a generated suite, named for its class.
+type File struct {
+	Name  string // The file name without its extension.
+	Suite *Suite
+}
+
+// Generate writes the feature file and JSON form of every suite into outputPath.
+func Generate(model core.Model, outputPath string) error {
+	files, err := Files(model)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, file := range files {
+		content, err := json.MarshalIndent(file.Suite, "", "  ")
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		if err := os.WriteFile(filepath.Join(outputPath, file.Name+JSONExtension), append(content, '\n'), 0o644); err != nil { //nolint:gosec // generated test cases are intentionally world-readable
+			return errors.WithStack(err)
+		}
+		if err := os.WriteFile(filepath.Join(outputPath, file.Name+FeatureExtension), []byte(Feature(file.Suite)), 0o644); err != nil { //nolint:gosec // generated test cases are intentionally world-readable
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Files returns the suite of every class with transitions, ordered by domain, subdomain
+// and class key. Files are named "<domain>.<subdomain>.<class>" from the subkeys. The
+// model's logic is lowered in place so the simulator can evaluate it. A class whose
+// logic does not lower is left out of the simulation, and its suite reports the error
+// for each of its transitions.
+func Files(model core.Model) ([]File, error) {
+	classErrs, err := convert.LowerModelByClass(&model)
+	if err != nil {
+		return nil, errors.Wrap(err, "lowering model logic")
+	}
+	config := simulationConfig(model, classErrs)
+	var files []File
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			for _, class := range identity.SortedValues(subdomain.Classes) {
+				if len(class.Transitions) == 0 {
+					continue
+				}
+				var suite *Suite
+				if classErr, ok := classErrs[class.Key]; ok {
+					suite = unloweredSuite(class, classErr)
+				} else {
+					suite, err = classSuite(&model, config, class)
+					if err != nil {
+						return nil, err
+					}
+				}
+				files = append(files, File{Name: domain.Key.SubKey + "." + subdomain.Key.SubKey + "." + class.Key.SubKey, Suite: suite})
+			}
+		}
+	}
+	return files, nil
+}
+
+// simulationConfig limits the simulation to the classes whose logic lowered.
+func simulationConfig(model core.Model, classErrs map[identity.Key]error) engine.SimulationConfig {
+	if len(classErrs) == 0 {
+		return engine.SimulationConfig{}
+	}
+	include := &surface.SurfaceSpecification{}
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			for _, class := range identity.SortedValues(subdomain.Classes) {
+				if _, ok := classErrs[class.Key]; !ok {
+					include.IncludeClasses = append(include.IncludeClasses, class.Key)
+				}
+			}
+		}
+	}
+	return engine.SimulationConfig{Surface: include}
+}
+
+// unloweredSuite is the suite of a class whose logic does not lower, so none of its
+// transitions can be replayed.
+func unloweredSuite(class model_class.Class, classErr error) *Suite {
+	suite := &Suite{Class: class.Name, ClassKey: class.Key.String(), Details: strings.TrimSpace(class.Details)}
+	reason := "the class logic does not lower: " + classErr.Error()
+	for _, transition := range identity.SortedValues(class.Transitions) {
+		suite.Uncovered = append(suite.Uncovered, Uncovered{Name: caseName(class, transition), TransitionKey: transition.Key.String(), Reason: reason})
+	}
+	return suite
+}
@@ -0,0 +1,191 @@
+package testcases
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type TestCasesSuite struct {
+	suite.Suite
+}
+
+func TestTestCasesSuite(t *testing.T) {
+	suite.Run(t, new(TestCasesSuite))
+}
+
+func (suite *TestCasesSuite) TestSuite() {
+	files, err := Files(test_helper.GetBankModel())
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+	suite.Equal("bank.accounts.account", files[0].Name)
+
+	result := files[0].Suite
+	suite.Equal("Account", result.Class)
+	suite.Equal("domain/bank/subdomain/accounts/class/account", result.ClassKey)
+
+	cases := make(map[string]Case)
+	for _, c := range result.Cases {
+		cases[c.Name] = c
+	}
+	suite.Len(cases, 4)
+
+	created := cases["_new creates in Open"]
+	suite.Require().Len(created.Steps, 1)
+	suite.Equal(Step{
+		Event:       "_new",
+		EventKey:    "domain/bank/subdomain/accounts/class/account/event/_new",
+		Parameters:  map[string]string{"opening": created.Steps[0].Parameters["opening"]},
+		ToState:     "Open",
+		Assignments: map[string]string{"balance": created.Steps[0].Parameters["opening"]},
+	}, created.Steps[0])
+
+	deposited := cases["deposit takes Open to Open"]
+	suite.Require().Len(deposited.Steps, 2)
+	suite.Equal("deposit", deposited.Steps[1].Event)
+	suite.Equal("Open", deposited.Steps[1].FromState)
+	suite.Equal(strconv.Itoa(balance(suite, deposited.Steps[0])+amount(suite, deposited.Steps[1])), deposited.Steps[1].Assignments["balance"])
+
+	// The guard holds only once deposits have taken the balance over 50.
+	closed := cases["close takes Open to Closed when rich"]
+	suite.Require().GreaterOrEqual(len(closed.Steps), 3)
+	last := closed.Steps[len(closed.Steps)-2]
+	suite.Greater(balance(suite, last), 50)
+	suite.Equal(Step{
+		Event:     "close",
+		EventKey:  "domain/bank/subdomain/accounts/class/account/event/close",
+		FromState: "Open",
+		ToState:   "Closed",
+	}, closed.Steps[len(closed.Steps)-1])
+
+	destroyed := cases["_destroy destroys in Closed"]
+	suite.Equal(len(closed.Steps)+1, len(destroyed.Steps))
+	suite.Equal("", destroyed.Steps[len(destroyed.Steps)-1].ToState)
+
+	suite.Equal([]Uncovered{{
+		Name:          "thaw takes Frozen to Open",
+		TransitionKey: "domain/bank/subdomain/accounts/class/account/transition/frozen/thaw///open",
+		Reason:        "no event sequence from creation reaches Frozen",
+	}}, result.Uncovered)
+}
+
+func (suite *TestCasesSuite) TestUnloweredClass() {
+	unlowered := helper.Must(logic_spec.NewExpressionSpec(logic_spec.NotationTLAPlus, "missing > 0", nil))
+
+	// A class without transitions whose logic does not lower leaves the other suites whole.
+	model := test_helper.GetBankModel()
+	test_helper.EditClass(suite.T(), model, "Entry", func(class *model_class.Class) {
+		key := helper.Must(identity.NewClassInvariantKey(class.Key, "0"))
+		class.Invariants = append(class.Invariants, model_logic.NewLogic(key, model_logic.LogicTypeAssessment, "", "", unlowered, nil))
+	})
+	files, err := Files(model)
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+	suite.Len(files[0].Suite.Cases, 4)
+
+	// A class with transitions reports the error for each of them.
+	model = test_helper.GetBankModel()
+	test_helper.EditClass(suite.T(), model, "Account", func(class *model_class.Class) {
+		for key, guard := range class.Guards {
+			guard.Logic.Spec = unlowered
+			class.Guards[key] = guard
+		}
+	})
+	files, err = Files(model)
+	suite.Require().NoError(err)
+	suite.Require().Len(files, 1)
+	result := files[0].Suite
+	suite.Empty(result.Cases)
+	suite.Len(result.Uncovered, 5)
+	for _, uncovered := range result.Uncovered {
+		suite.Contains(uncovered.Reason, "the class logic does not lower: class \"domain/bank/subdomain/accounts/class/account\"")
+	}
+}
+
+// balance is the balance a step leaves an account with.
+func balance(suite *TestCasesSuite, step Step) int {
+	value, err := strconv.Atoi(step.Assignments["balance"])
+	suite.Require().NoError(err)
+	return value
+}
+
+// amount is the deposit a step makes.
+func amount(suite *TestCasesSuite, step Step) int {
+	value, err := strconv.Atoi(step.Parameters["amount"])
+	suite.Require().NoError(err)
+	return value
+}
+
+func (suite *TestCasesSuite) TestFeature() {
+	feature := Feature(&Suite{
+		Class:   "Account",
+		Details: "Money held for a customer.",
+		Cases: []Case{{
+			Name: "close takes Open to Closed when rich",
+			Steps: []Step{
+				{Event: "_new", Parameters: map[string]string{"opening": "40"}, ToState: "Open", Assignments: map[string]string{"balance": "40"}},
+				{Event: "deposit", Parameters: map[string]string{"amount": "30", "memo": `"a|b"`}, FromState: "Open", ToState: "Open", Assignments: map[string]string{"balance": "70"}},
+				{Event: "close", FromState: "Open", ToState: "Closed"},
+			},
+		}, {
+			Name: "_destroy destroys in Closed",
+			Steps: []Step{
+				{Event: "_new", ToState: "Open"},
+				{Event: "_destroy", FromState: "Open"},
+			},
+		}},
+		Uncovered: []Uncovered{{Name: "thaw takes Frozen to Open", Reason: "no event sequence from creation reaches Frozen"}},
+	})
+	suite.Equal(`# Transition coverage of the Account state machine. Generated by req.
+Feature: Account state machine
+  Money held for a customer.
+
+  Scenario: close takes Open to Closed when rich
+    Given the Account received "_new" with:
+      | opening | 40 |
+    And the Account received "deposit" with:
+      | amount | 30 |
+      | memo   | "a\|b" |
+    When the Account receives "close"
+    Then the Account is in state "Closed"
+
+  Scenario: _destroy destroys in Closed
+    Given the Account received "_new"
+    When the Account receives "_destroy"
+    Then the Account is destroyed
+
+  # Transitions without a scenario:
+  #   thaw takes Frozen to Open: no event sequence from creation reaches Frozen
+`, feature)
+}
+
+func (suite *TestCasesSuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath))
+
+	content, err := os.ReadFile(filepath.Join(outputPath, "bank.accounts.account"+JSONExtension))
+	suite.Require().NoError(err)
+	var written Suite
+	suite.Require().NoError(json.Unmarshal(content, &written))
+	suite.Len(written.Cases, 4)
+
+	feature, err := os.ReadFile(filepath.Join(outputPath, "bank.accounts.account"+FeatureExtension))
+	suite.Require().NoError(err)
+	suite.Equal(Feature(&written), string(feature))
+
+	// Replays are seeded, so generating again gives the same cases.
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath))
+	again, err := os.ReadFile(filepath.Join(outputPath, "bank.accounts.account"+JSONExtension))
+	suite.Require().NoError(err)
+	suite.Equal(string(content), string(again))
+}
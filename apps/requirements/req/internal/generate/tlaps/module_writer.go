@@ -0,0 +1,281 @@
+package tlaps
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+)
+
+const (
+	indent      = "    "
+	moduleRule  = "------------------------------"
+	moduleClose = "============================================================================="
+)
+
+// moduleWriter accumulates the text of one proof module.
+type moduleWriter struct {
+	strings.Builder
+}
+
+// conjunct is one entry of a definition body: a LET definition when target is set,
+// otherwise a formula joined to the others with /\.
+type conjunct struct {
+	comment string
+	target  string
+	formula string
+}
+
+func (w *moduleWriter) line(format string, args ...any) {
+	fmt.Fprintf(w, format, args...)
+	w.WriteString("\n")
+}
+
+func (w *moduleWriter) header(name, title string) {
+	w.line("%s MODULE %s %s", moduleRule, name, moduleRule)
+	w.line("(* Proof obligations for the actions of %s.", title)
+	w.line("   Generated by req: each theorem states that an action preserves the invariants.")
+	w.line("   self is the object the action runs on; attributes a guarantee does not mention")
+	w.line("   are unchanged. Replace each OMITTED step with a proof. *)")
+	w.line("EXTENDS Integers, Sequences, FiniteSets, Bags, TLAPS")
+	w.line("")
+	w.line("_Seq == INSTANCE Sequences")
+	w.line("_FiniteSets == INSTANCE FiniteSets")
+	w.line("_Bags == INSTANCE Bags")
+	w.line("")
+	w.line("VARIABLE self")
+}
+
+func (w *moduleWriter) footer() {
+	w.line("")
+	w.line("%s", moduleClose)
+}
+
+// globalFunctions writes every global function as an operator definition,
+// declaring the recursive ones RECURSIVE first.
+func (w *moduleWriter) globalFunctions(model core.Model) {
+	functions := identity.SortedValues(model.GlobalFunctions)
+	if len(functions) == 0 {
+		return
+	}
+	w.line("")
+	w.line("\\* Global functions.")
+	for _, gf := range functions {
+		w.line("")
+		w.comment(gf.Logic.Description)
+		if gf.Recursive {
+			placeholders := make([]string, len(gf.Parameters))
+			for i := range placeholders {
+				placeholders[i] = "_"
+			}
+			w.line("RECURSIVE %s", operator(gf.Name, placeholders))
+		}
+		w.line("%s ==", operator(gf.Name, gf.Parameters))
+		w.line("%s%s", indent, formula(gf.Logic.Spec))
+	}
+}
+
+// namedSets writes every named set as a definition.
+func (w *moduleWriter) namedSets(model core.Model) {
+	sets := identity.SortedValues(model.NamedSets)
+	if len(sets) == 0 {
+		return
+	}
+	w.line("")
+	w.line("\\* Named sets.")
+	for _, ns := range sets {
+		w.line("")
+		w.comment(ns.Description)
+		w.line("%s == %s", ns.Name, formula(ns.Spec))
+	}
+}
+
+// invariants writes one definition per invariant and returns the names of the
+// definitions that are invariants proper. LET invariants define their target by name.
+func (w *moduleWriter) invariants(title, prefix string, invariants []model_logic.Logic) []string {
+	if len(invariants) == 0 {
+		return nil
+	}
+	w.line("")
+	w.line("\\* %s", title)
+	var names []string
+	for _, inv := range invariants {
+		w.line("")
+		w.comment(inv.Description)
+		if inv.Type == model_logic.LogicTypeLet {
+			w.line("%s == %s", inv.Target, formula(inv.Spec))
+			continue
+		}
+		name := fmt.Sprintf("%s%d", prefix, len(names)+1)
+		names = append(names, name)
+		w.line("%s == %s", name, formula(inv.Spec))
+	}
+	return names
+}
+
+// conjunctionDefinition defines name as the conjunction of the named operators.
+func (w *moduleWriter) conjunctionDefinition(name string, operands []string) {
+	w.line("")
+	if len(operands) == 0 {
+		w.line("%s == TRUE", name)
+		return
+	}
+	w.line("%s ==", name)
+	for _, operand := range operands {
+		w.line("%s/\\ %s", indent, operand)
+	}
+}
+
+// action writes the requires and guarantees of one action and the theorem that it preserves the invariants.
+func (w *moduleWriter) action(class model_class.Class, action model_state.Action, invariantsName string, invariants []string) {
+	prefix := identifier(class.Name) + "_" + identifier(action.Name)
+	params := parameterNames(action)
+	requires := operator(prefix+"_Requires", params)
+	guarantees := operator(prefix+"_Guarantees", params)
+
+	w.line("")
+	w.line("\\* Action %s %s.", class.Name, action.Name)
+	w.comment(action.Details)
+	w.definition(requires, requiresConjuncts(action))
+	w.definition(guarantees, guaranteesConjuncts(action))
+
+	w.line("")
+	w.line("THEOREM %s_PreservesInvariants ==", prefix)
+	hypotheses := make([]string, 0, len(params)+3)
+	for _, param := range params {
+		hypotheses = append(hypotheses, "NEW "+param)
+	}
+	hypotheses = append(hypotheses, invariantsName, requires, guarantees)
+	w.line("%sASSUME %s", indent, strings.Join(hypotheses, ",\n"+indent+"       "))
+	w.line("%sPROVE  %s'", indent, invariantsName)
+	steps := make([]string, 0, len(invariants))
+	for i, inv := range invariants {
+		step := fmt.Sprintf("<1>%d", i+1)
+		steps = append(steps, step)
+		w.line("%s. %s'", step, inv)
+		w.line("  OMITTED")
+	}
+	if len(steps) == 0 {
+		w.line("  BY DEF %s", invariantsName)
+		return
+	}
+	w.line("<1>%d. QED", len(steps)+1)
+	w.line("  BY %s DEF %s", strings.Join(steps, ", "), invariantsName)
+}
+
+// definition writes name as LET definitions over the conjunction of the formulas,
+// with each entry's description as a trailing comment.
+func (w *moduleWriter) definition(name string, conjuncts []conjunct) {
+	var lets, formulas []conjunct
+	for _, c := range conjuncts {
+		if c.target != "" {
+			lets = append(lets, c)
+		} else {
+			formulas = append(formulas, c)
+		}
+	}
+
+	w.line("")
+	w.line("%s ==", name)
+	bullet := indent
+	for i, let := range lets {
+		keyword := "    "
+		if i == 0 {
+			keyword = "LET "
+		}
+		w.line("%s%s%s == %s%s", indent, keyword, let.target, let.formula, trailingComment(let.comment))
+		bullet = indent + "IN  "
+	}
+	if len(formulas) == 0 {
+		w.line("%sTRUE", bullet)
+		return
+	}
+	for i, f := range formulas {
+		if i > 0 {
+			bullet = strings.Repeat(" ", len(bullet))
+		}
+		w.line("%s/\\ %s%s", bullet, f.formula, trailingComment(f.comment))
+	}
+}
+
+// requiresConjuncts returns the action's requires followed by its parameters' invariants.
+func requiresConjuncts(action model_state.Action) []conjunct {
+	conjuncts := make([]conjunct, 0, len(action.Requires))
+	for _, logic := range action.Requires {
+		conjuncts = append(conjuncts, logicConjunct(logic, false))
+	}
+	for _, param := range action.Parameters {
+		for _, inv := range param.Invariants {
+			conjuncts = append(conjuncts, logicConjunct(inv, false))
+		}
+	}
+	return conjuncts
+}
+
+// guaranteesConjuncts returns the action's guarantees, with state changes written as primed attributes of self.
+func guaranteesConjuncts(action model_state.Action) []conjunct {
+	conjuncts := make([]conjunct, 0, len(action.Guarantees))
+	for _, logic := range action.Guarantees {
+		conjuncts = append(conjuncts, logicConjunct(logic, true))
+	}
+	return conjuncts
+}
+
+func logicConjunct(logic model_logic.Logic, primeTarget bool) conjunct {
+	c := conjunct{comment: logic.Description, formula: formula(logic.Spec)}
+	switch {
+	case logic.Type == model_logic.LogicTypeLet:
+		c.target = logic.Target
+	case primeTarget && logic.Target != "":
+		c.formula = "self." + logic.Target + "' = " + c.formula
+	}
+	return c
+}
+
+// comment writes text as end-of-line comments, one per line.
+func (w *moduleWriter) comment(text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	for _, line := range strings.Split(text, "\n") {
+		w.line("\\* %s", strings.TrimSpace(line))
+	}
+}
+
+// trailingComment returns text as a comment to end a line with, folded onto one line.
+func trailingComment(text string) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if text == "" {
+		return ""
+	}
+	return "  \\* " + text
+}
+
+// operator returns an operator name applied to its parameters, or the bare name when it has none.
+func operator(name string, params []string) string {
+	if len(params) == 0 {
+		return name
+	}
+	return name + "(" + strings.Join(params, ", ") + ")"
+}
+
+// formula returns a specification as ASCII TLA+. A specification that does not parse is
+// kept as written so the prover reports it, and a missing one stands for TRUE.
+func formula(spec logic_spec.ExpressionSpec) string {
+	text := strings.TrimSpace(spec.Specification)
+	if text == "" {
+		return "TRUE (* no specification *)"
+	}
+	expr, err := convert.ParseNotation(spec.Notation, text)
+	if err != nil {
+		return strings.Join(strings.Fields(text), " ")
+	}
+	return expr.ASCII()
+}
@@ -0,0 +1,116 @@
+// Package tlaps generates TLAPS proof obligation skeletons from a model.
+//
+// Each subdomain with actions becomes one standalone TLA+ module. For every class action the
+// module states a theorem: the model and class invariants, together with the action's requires
+// and guarantees, imply the invariants in the primed state. Each invariant gets its own proof
+// step left OMITTED, so teams with the TLA+ proof system can discharge the obligations one at a time.
+package tlaps
+
+import (
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// FileExtension is the extension of generated proof module files.
+const FileExtension = ".tla"
+
+// Module is a generated TLA+ proof module.
+type Module struct {
+	Name   string // The module name, also the file name without its extension.
+	Source string // The complete module text.
+}
+
+// Generate writes one proof module per subdomain into outputPath.
+func Generate(model core.Model, outputPath string) error {
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	for _, module := range Modules(model) {
+		path := filepath.Join(outputPath, module.Name+FileExtension)
+		if err := os.WriteFile(path, []byte(module.Source), 0o644); err != nil { //nolint:gosec // generated proof modules are intentionally world-readable
+			return errors.WithStack(err)
+		}
+	}
+	return nil
+}
+
+// Modules returns the proof module of every subdomain that has at least one action,
+// ordered by domain and subdomain key.
+func Modules(model core.Model) []Module {
+	var modules []Module
+	for _, domain := range identity.SortedValues(model.Domains) {
+		for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+			if !hasActions(subdomain) {
+				continue
+			}
+			modules = append(modules, SubdomainModule(model, domain, subdomain))
+		}
+	}
+	return modules
+}
+
+// SubdomainModule returns the proof module for the actions of one subdomain.
+func SubdomainModule(model core.Model, domain model_domain.Domain, subdomain model_domain.Subdomain) Module {
+	name := identifier(domain.Name) + "_" + identifier(subdomain.Name) + "_Proofs"
+	w := &moduleWriter{}
+	w.header(name, domain.Name+" / "+subdomain.Name)
+	w.globalFunctions(model)
+	w.namedSets(model)
+
+	modelInvariants := w.invariants("Model invariants.", "ModelInvariant", model.Invariants)
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		if len(class.Actions) == 0 {
+			continue
+		}
+		classInvariants := w.invariants("Class "+class.Name+" invariants.", identifier(class.Name)+"_Invariant", class.Invariants)
+		invariants := append(slices.Clone(modelInvariants), classInvariants...)
+		invariantsName := identifier(class.Name) + "_Invariants"
+		w.conjunctionDefinition(invariantsName, invariants)
+		for _, action := range identity.SortedValues(class.Actions) {
+			w.action(class, action, invariantsName, invariants)
+		}
+	}
+	w.footer()
+	return Module{Name: name, Source: w.String()}
+}
+
+// hasActions reports whether any class of the subdomain has an action to prove.
+func hasActions(subdomain model_domain.Subdomain) bool {
+	for _, class := range subdomain.Classes {
+		if len(class.Actions) > 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// identifier turns a display name into a TLA+ identifier: spaces are dropped
+// and any other character that cannot appear in an identifier becomes an underscore.
+func identifier(name string) string {
+	name = model_class.ClassTLAName(name)
+	return strings.Map(func(r rune) rune {
+		if (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || (r >= '0' && r <= '9') || r == '_' {
+			return r
+		}
+		return '_'
+	}, name)
+}
+
+// parameterNames returns the names of an action's parameters in declaration order.
+func parameterNames(action model_state.Action) []string {
+	names := make([]string, 0, len(action.Parameters))
+	for _, param := range action.Parameters {
+		names = append(names, param.Name)
+	}
+	return names
+}
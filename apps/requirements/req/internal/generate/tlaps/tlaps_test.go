@@ -0,0 +1,205 @@
+package tlaps
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type TlapsSuite struct {
+	suite.Suite
+}
+
+func TestTlapsSuite(t *testing.T) {
+	suite.Run(t, new(TlapsSuite))
+}
+
+func (suite *TlapsSuite) TestSubdomainModule() {
+	modules := Modules(test_helper.GetBankModel())
+	suite.Require().Len(modules, 1)
+	suite.Equal("Bank_Accounts_Proofs", modules[0].Name)
+	suite.Equal(`------------------------------ MODULE Bank_Accounts_Proofs ------------------------------
+(* Proof obligations for the actions of Bank / Accounts.
+   Generated by req: each theorem states that an action preserves the invariants.
+   self is the object the action runs on; attributes a guarantee does not mention
+   are unchanged. Replace each OMITTED step with a proof. *)
+EXTENDS Integers, Sequences, FiniteSets, Bags, TLAPS
+
+_Seq == INSTANCE Sequences
+_FiniteSets == INSTANCE FiniteSets
+_Bags == INSTANCE Bags
+
+VARIABLE self
+
+\* Global functions.
+
+\* Sum of a set.
+RECURSIVE _Total(_)
+_Total(s) ==
+    IF s = {} THEN 0 ELSE LET x == CHOOSE y \in s : TRUE IN x + _Total(s \ {x})
+
+\* Model invariants.
+
+ModelInvariant1 == TRUE
+
+\* Class Account invariants.
+
+\* Balance is never negative.
+Account_Invariant1 == self.balance >= 0
+
+Account_Invariants ==
+    /\ ModelInvariant1
+    /\ Account_Invariant1
+
+\* Action Account Close.
+\* Closes the account.
+
+Account_Close_Requires ==
+    /\ balance >= 0  \* Nothing is owed.
+
+Account_Close_Guarantees ==
+    TRUE
+
+THEOREM Account_Close_PreservesInvariants ==
+    ASSUME Account_Invariants,
+           Account_Close_Requires,
+           Account_Close_Guarantees
+    PROVE  Account_Invariants'
+<1>1. ModelInvariant1'
+  OMITTED
+<1>2. Account_Invariant1'
+  OMITTED
+<1>3. QED
+  BY <1>1, <1>2 DEF Account_Invariants
+
+\* Action Account Deposit.
+
+Account_Deposit_Requires(amount) ==
+    TRUE
+
+Account_Deposit_Guarantees(amount) ==
+    /\ self.balance' = balance + amount
+
+THEOREM Account_Deposit_PreservesInvariants ==
+    ASSUME NEW amount,
+           Account_Invariants,
+           Account_Deposit_Requires(amount),
+           Account_Deposit_Guarantees(amount)
+    PROVE  Account_Invariants'
+<1>1. ModelInvariant1'
+  OMITTED
+<1>2. Account_Invariant1'
+  OMITTED
+<1>3. QED
+  BY <1>1, <1>2 DEF Account_Invariants
+
+\* Action Account Log.
+
+Account_Log_Requires ==
+    TRUE
+
+Account_Log_Guarantees ==
+    TRUE
+
+THEOREM Account_Log_PreservesInvariants ==
+    ASSUME Account_Invariants,
+           Account_Log_Requires,
+           Account_Log_Guarantees
+    PROVE  Account_Invariants'
+<1>1. ModelInvariant1'
+  OMITTED
+<1>2. Account_Invariant1'
+  OMITTED
+<1>3. QED
+  BY <1>1, <1>2 DEF Account_Invariants
+
+\* Action Account Open.
+
+Account_Open_Requires(opening) ==
+    TRUE
+
+Account_Open_Guarantees(opening) ==
+    /\ self.balance' = opening
+
+THEOREM Account_Open_PreservesInvariants ==
+    ASSUME NEW opening,
+           Account_Invariants,
+           Account_Open_Requires(opening),
+           Account_Open_Guarantees(opening)
+    PROVE  Account_Invariants'
+<1>1. ModelInvariant1'
+  OMITTED
+<1>2. Account_Invariant1'
+  OMITTED
+<1>3. QED
+  BY <1>1, <1>2 DEF Account_Invariants
+
+=============================================================================
+`, modules[0].Source)
+}
+
+func (suite *TlapsSuite) TestNoInvariants() {
+	model := test_helper.GetBankModel()
+	model.Invariants = nil
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for classKey, class := range subdomain.Classes {
+				class.Invariants = nil
+				subdomain.Classes[classKey] = class
+			}
+		}
+	}
+	source := Modules(model)[0].Source
+	suite.Contains(source, "Account_Invariants == TRUE\n")
+	suite.Contains(source, "    PROVE  Account_Invariants'\n  BY DEF Account_Invariants\n")
+	suite.NotContains(source, "OMITTED\n")
+}
+
+func (suite *TlapsSuite) TestSubdomainsWithoutActionsAreSkipped() {
+	model := test_helper.GetBankModel()
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for classKey, class := range subdomain.Classes {
+				class.Actions = nil
+				subdomain.Classes[classKey] = class
+			}
+		}
+	}
+	suite.Empty(Modules(model))
+}
+
+func (suite *TlapsSuite) TestIdentifier() {
+	tests := []struct {
+		name     string
+		expected string
+	}{
+		{name: "Account", expected: "Account"},
+		{name: "Bank Account", expected: "BankAccount"},
+		{name: "Order-Line", expected: "Order_Line"},
+		{name: " padded ", expected: "padded"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.name, func() {
+			suite.Equal(tt.expected, identifier(tt.name))
+		})
+	}
+}
+
+func (suite *TlapsSuite) TestGenerate() {
+	outputPath := filepath.Join(suite.T().TempDir(), "proofs")
+	suite.Require().NoError(Generate(test_helper.GetTestModel(), outputPath))
+
+	entries, err := os.ReadDir(outputPath)
+	suite.Require().NoError(err)
+	suite.Require().NotEmpty(entries)
+	for _, entry := range entries {
+		suite.Equal(FileExtension, filepath.Ext(entry.Name()))
+		contents, err := os.ReadFile(filepath.Join(outputPath, entry.Name()))
+		suite.Require().NoError(err)
+		suite.Contains(string(contents), "MODULE "+entry.Name()[:len(entry.Name())-len(FileExtension)]+" ")
+		suite.Contains(string(contents), "_PreservesInvariants ==")
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"os"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 
 	"github.com/pkg/errors"
 )
@@ -13,7 +14,8 @@ import (
 //
 // classErrors maps a class key string to a parse-error message; those classes'
 // pages are rendered as red-bold error blocks. Pass nil when there are none.
-func GenerateMdFromModel(outputPath string, parsedModel core.Model, classErrors map[string]string) (err error) { //nolint:revive // public API name
+// sources, when not nil, gives expression errors their positions in the model's files.
+func GenerateMdFromModel(outputPath string, parsedModel core.Model, classErrors map[string]string, sources *sourcepos.Index) (err error) { //nolint:revive // public API name
 	// Create necessary output paths if we don't have them.
 	if err = createMissingPaths([]string{outputPath}); err != nil {
 		return err
@@ -23,7 +25,7 @@ func GenerateMdFromModel(outputPath string, parsedModel core.Model, classErrors
 
 	// Use FileWriter to write to filesystem via ContentWriter interface.
 	writer := NewFileWriter(outputPath)
-	err = GenerateMdToWriter(parsedModel, writer, classErrors)
+	err = GenerateMdWithIssuesToWriter(parsedModel, writer, BuildParseIssueIndex(&parsedModel, classErrors, sources))
 	if err != nil {
 		return err
 	}
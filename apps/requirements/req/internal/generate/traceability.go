@@ -0,0 +1,343 @@
+package generate
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate/req_flat"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+
+	"github.com/pkg/errors"
+)
+
+// The traceability matrix pages of a model.
+const (
+	_traceabilityClassesFilename = "traceability-classes.md" // Classes × use cases.
+	_traceabilityEventsFilename  = "traceability-events.md"  // Events and queries × scenarios.
+	_traceabilityActorsFilename  = "traceability-actors.md"  // Use cases × actors.
+)
+
+// Cell marks of a traceability matrix.
+const (
+	_traceabilityStepMark     = "●" // Links to the scenario step that creates the relationship.
+	_traceabilityDeclaredMark = "○" // Links to the use case that declares the relationship without a step.
+)
+
+// TraceabilityLink is a row or column heading, or a cell, of a traceability matrix.
+type TraceabilityLink struct {
+	Text string
+	Link string
+}
+
+// TraceabilityRow is one row of a traceability matrix with a cell per column. Cells
+// without a relationship have no link. Rows without any relationship are uncovered.
+type TraceabilityRow struct {
+	Heading TraceabilityLink
+	Cells   []TraceabilityLink
+	Covered bool
+}
+
+// TraceabilityMatrix is the content of one traceability matrix page.
+type TraceabilityMatrix struct {
+	Filename    string
+	Title       string
+	Description string
+	RowHeading  string
+	Columns     []TraceabilityLink
+	Rows        []TraceabilityRow
+}
+
+// traceabilityStep is a leaf scenario step with the scenario and use case it belongs to.
+type traceabilityStep struct {
+	UseCase  model_use_case.UseCase
+	Scenario model_scenario.Scenario
+	Step     model_scenario.Step
+}
+
+// Link is the use case page anchor of the step.
+func (s traceabilityStep) Link() string {
+	return convertKeyToFilename("use_case", s.UseCase.Key.String(), "", ".md") + "#" + scenarioStepAnchor(s.Step.Key)
+}
+
+// traceabilityIndex holds every leaf scenario step of a model in a stable order: use cases
+// by name, their scenarios by name, then the steps of each scenario in order.
+type traceabilityIndex struct {
+	reqs        *req_flat.Requirements
+	useCases    []model_use_case.UseCase
+	scenarios   []traceabilityStep // The scenarios, one per entry; Step is unset.
+	steps       []traceabilityStep
+	classLookup map[string]model_class.Class
+	objects     map[string]model_scenario.Object
+}
+
+func newTraceabilityIndex(reqs *req_flat.Requirements) traceabilityIndex {
+	classLookup, _ := reqs.ClassLookup()
+	index := traceabilityIndex{
+		reqs:        reqs,
+		classLookup: classLookup,
+		objects:     reqs.ObjectLookup(),
+	}
+	for _, useCase := range reqs.UseCaseLookup() {
+		index.useCases = append(index.useCases, useCase)
+	}
+	slices.SortFunc(index.useCases, func(a, b model_use_case.UseCase) int {
+		return compareNameThenKey(a.Name, b.Name, a.Key, b.Key)
+	})
+	for _, useCase := range index.useCases {
+		var scenarios []model_scenario.Scenario
+		for _, scenario := range useCase.Scenarios {
+			scenarios = append(scenarios, scenario)
+		}
+		slices.SortFunc(scenarios, func(a, b model_scenario.Scenario) int {
+			return compareNameThenKey(a.Name, b.Name, a.Key, b.Key)
+		})
+		for _, scenario := range scenarios {
+			index.scenarios = append(index.scenarios, traceabilityStep{UseCase: useCase, Scenario: scenario})
+			for _, step := range scenarioLeafSteps(scenario) {
+				index.steps = append(index.steps, traceabilityStep{UseCase: useCase, Scenario: scenario, Step: step})
+			}
+		}
+	}
+	return index
+}
+
+func compareNameThenKey(aName, bName string, aKey, bKey identity.Key) int {
+	if c := strings.Compare(aName, bName); c != 0 {
+		return c
+	}
+	return strings.Compare(aKey.String(), bKey.String())
+}
+
+// stepClassKeys returns the classes a step involves: the classes of the objects it passes
+// between and the class owning its event or query.
+func (index traceabilityIndex) stepClassKeys(step model_scenario.Step) []string {
+	var keys []string
+	for _, objectKey := range []*identity.Key{step.FromObjectKey, step.ToObjectKey} {
+		if objectKey == nil {
+			continue
+		}
+		if object, found := index.objects[objectKey.String()]; found {
+			keys = append(keys, object.ClassKey.String())
+		}
+	}
+	for _, memberKey := range []*identity.Key{step.EventKey, step.QueryKey} {
+		if memberKey != nil {
+			keys = append(keys, memberKey.GetParentKey())
+		}
+	}
+	return keys
+}
+
+// eventCoverage counts the events of the model and those sent by at least one scenario step.
+func (index traceabilityIndex) eventCoverage() (exercised, total int) {
+	sent := map[string]bool{}
+	for _, step := range index.steps {
+		if step.Step.EventKey != nil {
+			sent[step.Step.EventKey.String()] = true
+		}
+	}
+	for key := range index.reqs.EventLookup() {
+		total++
+		if sent[key] {
+			exercised++
+		}
+	}
+	return exercised, total
+}
+
+// classesMatrix relates each class to the use cases whose scenarios involve it.
+func (index traceabilityIndex) classesMatrix() TraceabilityMatrix {
+	matrix := TraceabilityMatrix{
+		Filename:    _traceabilityClassesFilename,
+		Title:       "Use Cases × Classes",
+		Description: "Which use cases touch each class. A class is touched by a scenario step that passes between its objects, or sends one of its events or queries.",
+		RowHeading:  "Class",
+	}
+	columnOf := map[string]int{}
+	for i, useCase := range index.useCases {
+		columnOf[useCase.Key.String()] = i
+		matrix.Columns = append(matrix.Columns, TraceabilityLink{Text: useCase.Name, Link: convertKeyToFilename("use_case", useCase.Key.String(), "", ".md")})
+	}
+
+	var classes []model_class.Class
+	for _, class := range index.classLookup {
+		classes = append(classes, class)
+	}
+	for _, class := range classesSortedByName(classes) {
+		row := newTraceabilityRow(class.Name, convertKeyToFilename("class", class.Key.String(), "", ".md"), len(matrix.Columns))
+		for _, step := range index.steps {
+			if slices.Contains(index.stepClassKeys(step.Step), class.Key.String()) {
+				row.mark(columnOf[step.UseCase.Key.String()], _traceabilityStepMark, step.Link())
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+	return matrix
+}
+
+// eventsMatrix relates each event and query to the scenarios whose steps send it.
+func (index traceabilityIndex) eventsMatrix() TraceabilityMatrix {
+	matrix := TraceabilityMatrix{
+		Filename:    _traceabilityEventsFilename,
+		Title:       "Scenarios × Events and Queries",
+		Description: "Which scenarios exercise each event and query.",
+		RowHeading:  "Event or query",
+	}
+	columnOf := map[string]int{}
+	for i, scenario := range index.scenarios {
+		columnOf[scenario.Scenario.Key.String()] = i
+		matrix.Columns = append(matrix.Columns, TraceabilityLink{
+			Text: scenario.UseCase.Name + ": " + scenario.Scenario.Name,
+			Link: convertKeyToFilename("use_case", scenario.UseCase.Key.String(), "", ".md"),
+		})
+	}
+
+	type member struct {
+		class model_class.Class
+		key   identity.Key
+		name  string
+	}
+	var members []member
+	for _, event := range index.reqs.EventLookup() {
+		members = append(members, member{class: index.classLookup[event.Key.GetParentKey()], key: event.Key, name: model_state.SystemEventDisplayName(event.Name)})
+	}
+	for _, query := range index.reqs.QueryLookup() {
+		members = append(members, member{class: index.classLookup[query.Key.GetParentKey()], key: query.Key, name: query.Name + "?"})
+	}
+	slices.SortFunc(members, func(a, b member) int {
+		if c := compareNameThenKey(a.class.Name, b.class.Name, a.class.Key, b.class.Key); c != 0 {
+			return c
+		}
+		return compareNameThenKey(a.name, b.name, a.key, b.key)
+	})
+
+	for _, member := range members {
+		row := newTraceabilityRow(member.class.Name+" · "+member.name, convertKeyToFilename("class", member.class.Key.String(), "", ".md"), len(matrix.Columns))
+		for _, step := range index.steps {
+			sends := (step.Step.EventKey != nil && *step.Step.EventKey == member.key) ||
+				(step.Step.QueryKey != nil && *step.Step.QueryKey == member.key)
+			if sends {
+				row.mark(columnOf[step.Scenario.Key.String()], _traceabilityStepMark, step.Link())
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+	return matrix
+}
+
+// actorsMatrix relates each use case to its actors. A cell links to the first scenario
+// step involving an object of the actor's class, or to the use case when the actor is
+// only declared on it.
+func (index traceabilityIndex) actorsMatrix() TraceabilityMatrix {
+	matrix := TraceabilityMatrix{
+		Filename:    _traceabilityActorsFilename,
+		Title:       "Actors × Use Cases",
+		Description: "Which actors take part in each use case.",
+		RowHeading:  "Use case",
+	}
+
+	actorLookup := index.reqs.ActorLookup()
+	var actorKeys []string
+	for key := range actorLookup {
+		actorKeys = append(actorKeys, key)
+	}
+	slices.SortFunc(actorKeys, func(a, b string) int {
+		return compareNameThenKey(actorLookup[a].Name, actorLookup[b].Name, actorLookup[a].Key, actorLookup[b].Key)
+	})
+	columnOf := map[string]int{}
+	for i, key := range actorKeys {
+		columnOf[key] = i
+		matrix.Columns = append(matrix.Columns, TraceabilityLink{Text: actorLookup[key].Name, Link: convertKeyToFilename("actor", key, "", ".md")})
+	}
+
+	// The actor each actor class implements.
+	actorOfClass := map[string]string{}
+	for key, class := range index.classLookup {
+		if class.ActorKey != nil {
+			actorOfClass[key] = class.ActorKey.String()
+		}
+	}
+
+	for _, useCase := range index.useCases {
+		useCaseLink := convertKeyToFilename("use_case", useCase.Key.String(), "", ".md")
+		row := newTraceabilityRow(useCase.Name, useCaseLink, len(matrix.Columns))
+		for _, step := range index.steps {
+			if step.UseCase.Key != useCase.Key {
+				continue
+			}
+			for _, classKey := range index.stepClassKeys(step.Step) {
+				if actorKey, isActor := actorOfClass[classKey]; isActor {
+					row.mark(columnOf[actorKey], _traceabilityStepMark, step.Link())
+				}
+			}
+		}
+		for classKey := range useCase.Actors {
+			if actorKey, isActor := actorOfClass[classKey.String()]; isActor {
+				row.mark(columnOf[actorKey], _traceabilityDeclaredMark, useCaseLink)
+			}
+		}
+		matrix.Rows = append(matrix.Rows, row)
+	}
+	return matrix
+}
+
+func newTraceabilityRow(text, link string, columns int) TraceabilityRow {
+	return TraceabilityRow{
+		Heading: TraceabilityLink{Text: text, Link: link},
+		Cells:   make([]TraceabilityLink, columns),
+	}
+}
+
+// mark relates the row to a column, keeping the first relationship found.
+func (row *TraceabilityRow) mark(column int, text, link string) {
+	row.Covered = true
+	if row.Cells[column].Link == "" {
+		row.Cells[column] = TraceabilityLink{Text: text, Link: link}
+	}
+}
+
+// traceabilityCoverageSummary describes how many of the model's events scenarios exercise.
+func traceabilityCoverageSummary(exercised, total int) string {
+	if total == 0 {
+		return "The model has no events."
+	}
+	return fmt.Sprintf("%d of %d events (%d%%) are exercised by at least one scenario.", exercised, total, exercised*100/total)
+}
+
+// markdownTableText escapes text for a cell of a markdown table.
+func markdownTableText(text string) string {
+	text = strings.ReplaceAll(text, "|", `\|`)
+	return strings.ReplaceAll(text, "\n", " ")
+}
+
+// generateTraceabilityMdContents renders the traceability matrix pages of a model, by filename.
+func generateTraceabilityMdContents(reqs *req_flat.Requirements) (pages map[string]string, err error) {
+	index := newTraceabilityIndex(reqs)
+	matrices := []TraceabilityMatrix{index.classesMatrix(), index.eventsMatrix(), index.actorsMatrix()}
+	summary := traceabilityCoverageSummary(index.eventCoverage())
+
+	pages = map[string]string{}
+	for _, matrix := range matrices {
+		contents, err := generateFromTemplate(_traceabilityMdTemplate, struct {
+			Reqs     *req_flat.Requirements
+			Matrix   TraceabilityMatrix
+			Matrices []TraceabilityMatrix
+			Summary  string
+		}{
+			Reqs:     reqs,
+			Matrix:   matrix,
+			Matrices: matrices,
+			Summary:  summary,
+		})
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		pages[matrix.Filename] = contents
+	}
+	return pages, nil
+}
@@ -0,0 +1,50 @@
+package generate
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTraceabilityCoverageSummary(t *testing.T) {
+	assert.Equal(t, "The model has no events.", traceabilityCoverageSummary(0, 0))
+	assert.Equal(t, "2 of 3 events (66%) are exercised by at least one scenario.", traceabilityCoverageSummary(2, 3))
+	assert.Equal(t, `a \| b c`, markdownTableText("a | b\nc"))
+}
+
+func TestGenerateTraceabilityPages(t *testing.T) {
+	model := test_helper.GetTestModel()
+	writer := newCollectWriter()
+	require.NoError(t, GenerateMdToWriter(model, writer, nil))
+
+	assert.Contains(t, string(writer.md["model.md"]), "[Traceability](traceability-classes.md)")
+
+	const placeOrder = "use_case-domain.domain_a.subdomain.subdomain_a.usecase.place_order.md"
+	const submitStep = "step_domain_domain_a_subdomain_subdomain_a_usecase_place_order_scenario_happy_path_sstep_1"
+
+	// Every page summarizes event coverage and links to the others.
+	for _, filename := range []string{_traceabilityClassesFilename, _traceabilityEventsFilename, _traceabilityActorsFilename} {
+		text := string(writer.md[filename])
+		require.NotEmpty(t, text, filename)
+		assert.Contains(t, text, "3 of 5 events (60%) are exercised by at least one scenario.", filename)
+	}
+
+	events := string(writer.md[_traceabilityEventsFilename])
+	assert.Contains(t, events, "| [Place Order: Happy Path]("+placeOrder+") |")
+	assert.Contains(t, events, "[Order · Submit](class-domain.domain_a.subdomain.subdomain_a.class.order.md) |  |  | [●]("+placeOrder+"#"+submitStep+") |  |")
+	assert.Contains(t, events, `<span class="traceability-uncovered">[Order · «new»](class-domain.domain_a.subdomain.subdomain_a.class.order.md) — not covered</span>`)
+
+	actors := string(writer.md[_traceabilityActorsFilename])
+	assert.Regexp(t, regexp.MustCompile(`\[Place Order\]\(`+regexp.QuoteMeta(placeOrder)+`\) \|.*\[○\]\(`+regexp.QuoteMeta(placeOrder)+`\) \|`), actors)
+	assert.Contains(t, actors, `<span class="traceability-uncovered">[View Orders]`)
+
+	classes := string(writer.md[_traceabilityClassesFilename])
+	assert.Contains(t, classes, "Class | [Cancel Order](")
+
+	// The linked step has an anchor on the use case page.
+	assert.Contains(t, string(writer.md[placeOrder]), `1. <a id="`+submitStep+`"></a>Alice:Customer → Order 42: Customer submits order — Submit(quantity, product_id, reason, extra_telemetry)`)
+	assert.Contains(t, string(writer.md[placeOrder]), `Order 42 → \*:Product: Order queries product details — Get Count?`)
+}
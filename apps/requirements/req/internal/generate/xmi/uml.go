@@ -0,0 +1,478 @@
+package xmi
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_actor"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// _primitiveTypes is the library of the UML primitive types attributes refer to.
+const _primitiveTypes = "http://www.omg.org/spec/UML/20161101/PrimitiveTypes.xmi"
+
+// The behaviors a state action is in UML, by when it runs.
+var _stateActionBehaviors = map[string]string{
+	"entry": "entry",
+	"do":    "doActivity",
+	"exit":  "exit",
+}
+
+// umlModel maps a model onto UML elements.
+type umlModel struct {
+	model             core.Model
+	classes           map[identity.Key]model_class.Class
+	associationsByKey map[identity.Key]model_class.Association // The association each association class stands for, by class key.
+}
+
+func newUMLModel(model core.Model) umlModel {
+	m := umlModel{
+		model:             model,
+		classes:           map[identity.Key]model_class.Class{},
+		associationsByKey: map[identity.Key]model_class.Association{},
+	}
+	associations := []map[identity.Key]model_class.Association{model.ClassAssociations}
+	for _, domain := range model.Domains {
+		associations = append(associations, domain.ClassAssociations)
+		for _, subdomain := range domain.Subdomains {
+			associations = append(associations, subdomain.ClassAssociations)
+			for key, class := range subdomain.Classes {
+				m.classes[key] = class
+			}
+		}
+	}
+	for _, level := range associations {
+		for _, assoc := range level {
+			if assoc.AssociationClassKey != nil {
+				m.associationsByKey[*assoc.AssociationClassKey] = assoc
+			}
+		}
+	}
+	return m
+}
+
+// element returns the UML model with the actors, then the domain packages, then the
+// associations that bridge domains.
+func (m umlModel) element() *element {
+	e := newElement("uml:Model", "xmi:id", m.model.Key, "name", m.model.Name)
+	for _, actor := range identity.SortedValues(m.model.Actors) {
+		e.add(m.actor(actor))
+	}
+	for _, generalization := range identity.SortedValues(m.model.ActorGeneralizations) {
+		var subclassKeys []identity.Key
+		for _, actor := range identity.SortedValues(m.model.Actors) {
+			if actor.SubclassOfKey != nil && *actor.SubclassOfKey == generalization.Key {
+				subclassKeys = append(subclassKeys, actor.Key)
+			}
+		}
+		e.add(generalizationSet(generalization.Key, generalization.Name, generalization.IsComplete, subclassKeys))
+	}
+	for _, domain := range identity.SortedValues(m.model.Domains) {
+		e.add(m.domain(domain))
+	}
+	e.add(m.associations(m.model.ClassAssociations)...)
+	return e
+}
+
+func (m umlModel) actor(actor model_actor.Actor) *element {
+	e := packaged("Actor", actor.Key.String(), actor.Name)
+	comment(e, actor.Key, actor.UmlComment)
+	if actor.SubclassOfKey != nil {
+		for _, super := range identity.SortedValues(m.model.Actors) {
+			if super.SuperclassOfKey != nil && *super.SuperclassOfKey == *actor.SubclassOfKey {
+				e.add(generalization(actor.Key, super.Key))
+			}
+		}
+	}
+	return e
+}
+
+func (m umlModel) domain(domain model_domain.Domain) *element {
+	e := packaged("Package", domain.Key.String(), domain.Name)
+	comment(e, domain.Key, domain.UmlComment)
+	for _, subdomain := range identity.SortedValues(domain.Subdomains) {
+		e.add(m.subdomain(subdomain))
+	}
+	e.add(m.associations(domain.ClassAssociations)...)
+	return e
+}
+
+// subdomain returns the package of a subdomain: its classes with the data types and
+// signal events they own, its generalization sets and associations, then its use cases
+// and the associations of their actors.
+func (m umlModel) subdomain(subdomain model_domain.Subdomain) *element {
+	e := packaged("Package", subdomain.Key.String(), subdomain.Name)
+	comment(e, subdomain.Key, subdomain.UmlComment)
+	for _, class := range identity.SortedValues(subdomain.Classes) {
+		classElement, owned := m.class(class)
+		e.add(classElement)
+		e.add(owned...)
+		for _, event := range identity.SortedValues(class.Events) {
+			e.add(packaged("SignalEvent", event.Key.String(), event.Name))
+		}
+	}
+	for _, gen := range identity.SortedValues(subdomain.Generalizations) {
+		var subclassKeys []identity.Key
+		for _, class := range identity.SortedValues(subdomain.Classes) {
+			if class.SubclassOfKey != nil && *class.SubclassOfKey == gen.Key {
+				subclassKeys = append(subclassKeys, class.Key)
+			}
+		}
+		e.add(generalizationSet(gen.Key, gen.Name, gen.IsComplete, subclassKeys))
+	}
+	e.add(m.associations(subdomain.ClassAssociations)...)
+
+	for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+		e.add(useCaseElement(subdomain, useCase))
+	}
+	for _, gen := range identity.SortedValues(subdomain.UseCaseGeneralizations) {
+		var subKeys []identity.Key
+		for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+			if useCase.SubclassOfKey != nil && *useCase.SubclassOfKey == gen.Key {
+				subKeys = append(subKeys, useCase.Key)
+			}
+		}
+		e.add(generalizationSet(gen.Key, gen.Name, gen.IsComplete, subKeys))
+	}
+	for _, useCase := range identity.SortedValues(subdomain.UseCases) {
+		for _, actorClassKey := range identity.SortedKeys(useCase.Actors) {
+			class, ok := m.classes[actorClassKey]
+			if !ok || class.ActorKey == nil {
+				continue
+			}
+			id := useCase.Key.String() + "/" + class.ActorKey.String()
+			e.add(packaged("Association", id, "").
+				set("memberEnd", id+"/actor "+id+"/usecase").
+				add(
+					newElement("ownedEnd", "xmi:type", "uml:Property", "xmi:id", id+"/actor", "type", class.ActorKey.String(), "association", id),
+					newElement("ownedEnd", "xmi:type", "uml:Property", "xmi:id", id+"/usecase", "type", useCase.Key.String(), "association", id),
+				))
+		}
+	}
+	return e
+}
+
+func useCaseElement(subdomain model_domain.Subdomain, useCase model_use_case.UseCase) *element {
+	e := packaged("UseCase", useCase.Key.String(), useCase.Name)
+	comment(e, useCase.Key, useCase.UmlComment)
+	if useCase.SubclassOfKey != nil {
+		for _, super := range identity.SortedValues(subdomain.UseCases) {
+			if super.SuperclassOfKey != nil && *super.SuperclassOfKey == *useCase.SubclassOfKey {
+				e.add(generalization(useCase.Key, super.Key))
+			}
+		}
+	}
+	return e
+}
+
+// class returns the element of a class, an association class when it stands for an
+// association, along with the data types its attributes own.
+func (m umlModel) class(class model_class.Class) (*element, []*element) {
+	var e *element
+	assoc, isAssociationClass := m.associationsByKey[class.Key]
+	if isAssociationClass {
+		e = packaged("AssociationClass", class.Key.String(), class.Name)
+		e.set("memberEnd", assoc.Key.String()+"/from "+assoc.Key.String()+"/to")
+	} else {
+		e = packaged("Class", class.Key.String(), class.Name)
+	}
+	if len(class.States) > 0 {
+		e.set("classifierBehavior", class.Key.String()+"/statemachine")
+	}
+	comment(e, class.Key, class.UmlComment)
+
+	if class.SubclassOfKey != nil {
+		for _, super := range identity.SortedValues(m.classes) {
+			if super.SuperclassOfKey != nil && *super.SuperclassOfKey == *class.SubclassOfKey {
+				e.add(generalization(class.Key, super.Key))
+			}
+		}
+	}
+	var owned []*element
+	for _, attr := range class.Attributes {
+		property, types := m.attribute(class, attr)
+		e.add(property)
+		owned = append(owned, types...)
+	}
+	if isAssociationClass {
+		e.add(associationEnds(assoc, class.Key.String())...)
+	}
+	if len(class.States) > 0 {
+		e.add(stateMachine(class))
+	}
+	return e, owned
+}
+
+// associations returns the elements of associations, leaving out those that an
+// association class stands for.
+func (m umlModel) associations(associations map[identity.Key]model_class.Association) []*element {
+	var elements []*element
+	for _, assoc := range identity.SortedValues(associations) {
+		if assoc.AssociationClassKey != nil {
+			if _, ok := m.classes[*assoc.AssociationClassKey]; ok {
+				continue
+			}
+		}
+		e := packaged("Association", assoc.Key.String(), assoc.Name)
+		e.set("memberEnd", assoc.Key.String()+"/from "+assoc.Key.String()+"/to")
+		comment(e, assoc.Key, assoc.UmlComment)
+		e.add(associationEnds(assoc, assoc.Key.String())...)
+		elements = append(elements, e)
+	}
+	return elements
+}
+
+// associationEnds returns the ends of an association, each typed by the class at that
+// end and bounded by that end's multiplicity.
+func associationEnds(assoc model_class.Association, associationID string) []*element {
+	end := func(suffix string, classKey identity.Key, multiplicity model_class.Multiplicity) *element {
+		id := assoc.Key.String() + "/" + suffix
+		e := newElement("ownedEnd", "xmi:type", "uml:Property", "xmi:id", id, "type", classKey.String(), "association", associationID)
+		upper := "*"
+		if multiplicity.HigherBound > 0 {
+			upper = strconv.FormatUint(uint64(multiplicity.HigherBound), 10)
+		}
+		return e.add(bounds(id, strconv.FormatUint(uint64(multiplicity.LowerBound), 10), upper)...)
+	}
+	return []*element{
+		end("from", assoc.FromClassKey, assoc.FromMultiplicity),
+		end("to", assoc.ToClassKey, assoc.ToMultiplicity),
+	}
+}
+
+// bounds returns the lower and upper values of a multiplicity element.
+func bounds(id, lower, upper string) []*element {
+	return []*element{
+		newElement("lowerValue", "xmi:type", "uml:LiteralInteger", "xmi:id", id+"/lower", "value", lower),
+		newElement("upperValue", "xmi:type", "uml:LiteralUnlimitedNatural", "xmi:id", id+"/upper", "value", upper),
+	}
+}
+
+// attribute returns the property of an attribute, typed by its data type, with the data
+// types it owns. A nullable attribute has a lower bound of zero and a collection is
+// bounded by its size.
+func (m umlModel) attribute(class model_class.Class, attr model_class.Attribute) (*element, []*element) {
+	id := attr.Key.String()
+	e := newElement("ownedAttribute", "xmi:type", "uml:Property", "xmi:id", id, "name", attr.Name)
+	if attr.DerivationPolicy != nil {
+		e.set("isDerived", "true")
+	}
+	comment(e, attr.Key, attr.UmlComment)
+
+	dataType := attr.DataType
+	lower, upper := "1", "1"
+	if dataType != nil && isCollection(dataType.CollectionType) {
+		lower, upper = "0", "*"
+		if dataType.CollectionMin != nil {
+			lower = strconv.Itoa(*dataType.CollectionMin)
+		}
+		if dataType.CollectionMax != nil {
+			upper = strconv.Itoa(*dataType.CollectionMax)
+		}
+		if dataType.CollectionType != model_data_type.COLLECTION_TYPE_UNORDERED {
+			e.set("isOrdered", "true")
+		}
+		if dataType.CollectionUnique == nil || !*dataType.CollectionUnique {
+			e.set("isUnique", "false")
+		}
+		dataType = elementDataType(dataType)
+	}
+	if attr.Nullable {
+		lower = "0"
+	}
+
+	var owned []*element
+	switch {
+	case dataType != nil:
+		owned = m.setType(e, class, dataType, id+"/type", attr.Name)
+	case attr.DataTypeRules != "":
+		// Rules that do not parse are kept as the name of a data type.
+		e.set("type", id+"/type")
+		owned = []*element{packaged("DataType", id+"/type", attr.DataTypeRules)}
+	}
+	if lower != "1" || upper != "1" {
+		e.add(bounds(id, lower, upper)...)
+	}
+	return e, owned
+}
+
+// setType types a property or parameter by a data type, returning any data types it
+// needs to own: an enumeration for an enumeration, a data type with a property per
+// field for a record, or a data type named for what it cannot express otherwise.
+func (m umlModel) setType(e *element, class model_class.Class, dataType *model_data_type.DataType, typeID, name string) []*element {
+	if dataType.CollectionType == model_data_type.COLLECTION_TYPE_RECORD {
+		record := packaged("DataType", typeID, name)
+		var owned []*element
+		for _, field := range dataType.RecordFields {
+			property := newElement("ownedAttribute", "xmi:type", "uml:Property", "xmi:id", typeID+"/"+field.Name, "name", field.Name)
+			if field.FieldDataType != nil {
+				owned = append(owned, m.setType(property, class, field.FieldDataType, typeID+"/"+field.Name+"/type", field.Name)...)
+			}
+			record.add(property)
+		}
+		return append([]*element{record}, owned...)
+	}
+	atomic := dataType.Atomic
+	if atomic == nil {
+		e.set("type", typeID)
+		return []*element{packaged("DataType", typeID, name)}
+	}
+	switch atomic.ConstraintType {
+	case model_data_type.CONSTRAINT_TYPE_SPAN:
+		primitive := "Integer"
+		if atomic.Span.Fractional() {
+			primitive = "Real"
+		}
+		e.add(newElement("type", "href", _primitiveTypes+"#"+primitive))
+		return nil
+	case model_data_type.CONSTRAINT_TYPE_REFERENCE, model_data_type.CONSTRAINT_TYPE_DATETIME:
+		e.add(newElement("type", "href", _primitiveTypes+"#String"))
+		return nil
+	case model_data_type.CONSTRAINT_TYPE_ENUMERATION:
+		enumeration := packaged("Enumeration", typeID, name)
+		for _, enum := range atomic.Enums {
+			enumeration.add(newElement("ownedLiteral", "xmi:type", "uml:EnumerationLiteral", "xmi:id", typeID+"/"+enum.Value, "name", enum.Value))
+		}
+		e.set("type", typeID)
+		return []*element{enumeration}
+	case model_data_type.CONSTRAINT_TYPE_OBJECT:
+		if atomic.ObjectClassKey != nil {
+			if classKey, ok := m.classBySubKey(class, *atomic.ObjectClassKey); ok {
+				e.set("type", classKey.String())
+				return nil
+			}
+		}
+	}
+	e.set("type", typeID)
+	return []*element{packaged("DataType", typeID, name)}
+}
+
+// classBySubKey finds the class an object data type refers to, preferring a class of
+// the same subdomain as the class whose attribute it types.
+func (m umlModel) classBySubKey(class model_class.Class, subKey string) (identity.Key, bool) {
+	var found *identity.Key
+	for _, key := range identity.SortedKeys(m.classes) {
+		if key.SubKey != subKey {
+			continue
+		}
+		if key.ParentKey == class.Key.ParentKey {
+			return key, true
+		}
+		if found == nil {
+			found = &key
+		}
+	}
+	if found == nil {
+		return identity.Key{}, false
+	}
+	return *found, true
+}
+
+func isCollection(collectionType string) bool {
+	return collectionType != "" &&
+		collectionType != model_data_type.COLLECTION_TYPE_ATOMIC &&
+		collectionType != model_data_type.COLLECTION_TYPE_RECORD
+}
+
+// elementDataType returns the data type of the elements of a collection.
+func elementDataType(collection *model_data_type.DataType) *model_data_type.DataType {
+	if collection.ElementDataType != nil {
+		return collection.ElementDataType
+	}
+	if collection.Atomic != nil {
+		return &model_data_type.DataType{CollectionType: model_data_type.COLLECTION_TYPE_ATOMIC, Atomic: collection.Atomic}
+	}
+	if len(collection.RecordFields) > 0 {
+		return &model_data_type.DataType{CollectionType: model_data_type.COLLECTION_TYPE_RECORD, RecordFields: collection.RecordFields}
+	}
+	return nil
+}
+
+// stateMachine returns the state machine of a class as one region. Creation starts at
+// an initial pseudostate and destruction ends at a final state; transitions are
+// triggered by the class's signal events, guarded by constraints and have their
+// actions as effects.
+func stateMachine(class model_class.Class) *element {
+	id := class.Key.String()
+	region := newElement("region", "xmi:type", "uml:Region", "xmi:id", id+"/region", "name", class.Name)
+	initial, final := id+"/initial", id+"/final"
+	var hasInitial, hasFinal bool
+	for _, transition := range class.Transitions {
+		hasInitial = hasInitial || transition.FromStateKey == nil
+		hasFinal = hasFinal || transition.ToStateKey == nil
+	}
+	if hasInitial {
+		region.add(newElement("subvertex", "xmi:type", "uml:Pseudostate", "xmi:id", initial, "kind", "initial"))
+	}
+	for _, state := range identity.SortedValues(class.States) {
+		e := newElement("subvertex", "xmi:type", "uml:State", "xmi:id", state.Key.String(), "name", state.Name)
+		comment(e, state.Key, state.UmlComment)
+		for _, stateAction := range state.Actions {
+			e.add(newElement(_stateActionBehaviors[stateAction.When], "xmi:type", "uml:OpaqueBehavior", "xmi:id", stateAction.Key.String(), "name", class.Actions[stateAction.ActionKey].Name))
+		}
+		region.add(e)
+	}
+	if hasFinal {
+		region.add(newElement("subvertex", "xmi:type", "uml:FinalState", "xmi:id", final))
+	}
+
+	for _, transition := range identity.SortedValues(class.Transitions) {
+		transitionID := transition.Key.String()
+		source, target := initial, final
+		if transition.FromStateKey != nil {
+			source = transition.FromStateKey.String()
+		}
+		if transition.ToStateKey != nil {
+			target = transition.ToStateKey.String()
+		}
+		e := newElement("transition", "xmi:type", "uml:Transition", "xmi:id", transitionID, "source", source, "target", target, "kind", "external")
+		comment(e, transition.Key, transition.UmlComment)
+		if transition.GuardKey != nil {
+			guard := class.Guards[*transition.GuardKey]
+			e.set("guard", transitionID+"/guard")
+			specification := newElement("specification", "xmi:type", "uml:OpaqueExpression", "xmi:id", transitionID+"/guard/specification")
+			specification.add(&element{name: "body", text: guard.Logic.Description})
+			e.add(newElement("ownedRule", "xmi:type", "uml:Constraint", "xmi:id", transitionID+"/guard", "name", guard.Name).add(specification))
+		}
+		if transition.ActionKey != nil {
+			e.add(newElement("effect", "xmi:type", "uml:OpaqueBehavior", "xmi:id", transitionID+"/effect", "name", class.Actions[*transition.ActionKey].Name))
+		}
+		e.add(newElement("trigger", "xmi:type", "uml:Trigger", "xmi:id", transitionID+"/trigger", "name", class.Events[transition.EventKey].Name, "event", transition.EventKey.String()))
+		region.add(e)
+	}
+
+	return newElement("ownedBehavior", "xmi:type", "uml:StateMachine", "xmi:id", id+"/statemachine", "name", class.Name).add(region)
+}
+
+// generalization returns the generalization a specific element owns toward its general one.
+func generalization(specificKey, generalKey identity.Key) *element {
+	return newElement("generalization", "xmi:type", "uml:Generalization", "xmi:id", specificKey.String()+"/generalization", "general", generalKey.String())
+}
+
+// generalizationSet groups the generalizations of the subclasses of one model
+// generalization. Each subclass specializes one superclass, so the set is disjoint.
+func generalizationSet(key identity.Key, name string, complete bool, subclassKeys []identity.Key) *element {
+	generalizations := make([]string, len(subclassKeys))
+	for i, subclassKey := range subclassKeys {
+		generalizations[i] = subclassKey.String() + "/generalization"
+	}
+	return packaged("GeneralizationSet", key.String(), name).
+		set("isCovering", strconv.FormatBool(complete)).
+		set("isDisjoint", "true").
+		set("generalization", strings.Join(generalizations, " "))
+}
+
+// comment attaches a uml_comment to the element it annotates.
+func comment(e *element, key identity.Key, text string) {
+	text = strings.TrimSpace(text)
+	if text == "" {
+		return
+	}
+	e.add(newElement("ownedComment", "xmi:type", "uml:Comment", "xmi:id", key.String()+"/comment", "annotatedElement", key.String()).
+		add(&element{name: "body", text: text}))
+}
@@ -0,0 +1,102 @@
+// Package xmi generates an XMI 2.5.1 document of a model for UML tools.
+//
+// The whole model becomes one document. Domains and subdomains are packages; actors,
+// use cases, classes with typed attributes, associations with their multiplicities,
+// association classes, generalizations and state machines map to their standard UML
+// metaclasses. Every element's xmi:id is its model key, and the ids of elements the
+// model has no key for are derived from their owner's key, so re-exports diff cleanly.
+package xmi
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/pkg/errors"
+)
+
+// Namespaces of generated documents.
+const (
+	XMINamespace = "http://www.omg.org/spec/XMI/20131001"
+	UMLNamespace = "http://www.omg.org/spec/UML/20161101"
+)
+
+// FileExtension is the extension of the generated document file.
+const FileExtension = ".xmi"
+
+// Generate writes the document of the model into outputPath, named for the model key.
+func Generate(model core.Model, outputPath string) error {
+	if err := os.MkdirAll(outputPath, 0755); err != nil {
+		return errors.WithStack(err)
+	}
+	path := filepath.Join(outputPath, model.Key+FileExtension)
+	if err := os.WriteFile(path, []byte(Document(model)), 0o644); err != nil { //nolint:gosec // generated documents are intentionally world-readable
+		return errors.WithStack(err)
+	}
+	return nil
+}
+
+// Document returns the XMI document of a model.
+func Document(model core.Model) string {
+	root := newElement("xmi:XMI", "xmlns:xmi", XMINamespace, "xmlns:uml", UMLNamespace)
+	root.add(newUMLModel(model).element())
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	root.write(&b, 0)
+	return b.String()
+}
+
+// element is an XML element written with its attributes in the order they were given.
+type element struct {
+	name     string
+	attrs    []string // Alternating names and values.
+	text     string
+	children []*element
+}
+
+// newElement creates an element from its name and alternating attribute names and values.
+func newElement(name string, attrs ...string) *element {
+	return &element{name: name, attrs: attrs}
+}
+
+// packaged creates a packagedElement of a UML metaclass.
+func packaged(metaclass, id, name string) *element {
+	return newElement("packagedElement", "xmi:type", "uml:"+metaclass, "xmi:id", id, "name", name)
+}
+
+func (e *element) add(children ...*element) *element {
+	e.children = append(e.children, children...)
+	return e
+}
+
+func (e *element) set(name, value string) *element {
+	e.attrs = append(e.attrs, name, value)
+	return e
+}
+
+var (
+	_attrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\n", "&#10;", "\t", "&#9;")
+	_textEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+func (e *element) write(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	b.WriteString(indent + "<" + e.name)
+	for i := 0; i+1 < len(e.attrs); i += 2 {
+		b.WriteString(" " + e.attrs[i] + `="` + _attrEscaper.Replace(e.attrs[i+1]) + `"`)
+	}
+	switch {
+	case len(e.children) > 0:
+		b.WriteString(">\n")
+		for _, child := range e.children {
+			child.write(b, depth+1)
+		}
+		b.WriteString(indent + "</" + e.name + ">\n")
+	case e.text != "":
+		b.WriteString(">" + _textEscaper.Replace(e.text) + "</" + e.name + ">\n")
+	default:
+		b.WriteString("/>\n")
+	}
+}
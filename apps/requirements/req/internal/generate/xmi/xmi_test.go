@@ -0,0 +1,145 @@
+package xmi
+
+import (
+	"encoding/xml"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/suite"
+)
+
+type XMISuite struct {
+	suite.Suite
+}
+
+func TestXMISuite(t *testing.T) {
+	suite.Run(t, new(XMISuite))
+}
+
+// ids parses a document, failing on malformed XML, and returns its xmi:ids in order.
+func (suite *XMISuite) ids(document string) []string {
+	decoder := xml.NewDecoder(strings.NewReader(document))
+	var ids []string
+	for {
+		token, err := decoder.Token()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		suite.Require().NoError(err)
+		if start, ok := token.(xml.StartElement); ok {
+			for _, attr := range start.Attr {
+				if attr.Name.Space == XMINamespace && attr.Name.Local == "id" {
+					ids = append(ids, attr.Value)
+				}
+			}
+		}
+	}
+	return ids
+}
+
+func (suite *XMISuite) TestDocumentIsWellFormedWithUniqueIDs() {
+	ids := suite.ids(Document(test_helper.GetBankModel()))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		suite.False(seen[id], id)
+		seen[id] = true
+	}
+	suite.Equal("bank", ids[0])
+	suite.True(seen["actor/clerk"])
+	suite.True(seen["domain/bank/subdomain/accounts/class/account/attribute/balance"])
+}
+
+func (suite *XMISuite) TestDocumentIsStable() {
+	suite.Equal(Document(test_helper.GetBankModel()), Document(test_helper.GetBankModel()))
+}
+
+func (suite *XMISuite) TestClassesAndAttributes() {
+	model := test_helper.GetBankModel()
+	model.Name = "Bank & Trust"
+	document := Document(model)
+	suite.Contains(document, `<uml:Model xmi:id="bank" name="Bank &amp; Trust">`)
+	suite.Contains(document, `<packagedElement xmi:type="uml:Class" xmi:id="domain/bank/subdomain/accounts/class/account" name="Account" classifierBehavior="domain/bank/subdomain/accounts/class/account/statemachine">`)
+	suite.Contains(document, `<ownedComment xmi:type="uml:Comment" xmi:id="domain/bank/subdomain/accounts/class/account/comment" annotatedElement="domain/bank/subdomain/accounts/class/account">
+            <body>Never shared.</body>
+          </ownedComment>`)
+
+	// Spans are reals or integers, enumerations are owned, and collections are bounded.
+	balance := "domain/bank/subdomain/accounts/class/account/attribute/balance"
+	suite.Contains(document, `<ownedAttribute xmi:type="uml:Property" xmi:id="`+balance+`" name="balance">
+            <type href="http://www.omg.org/spec/UML/20161101/PrimitiveTypes.xmi#Integer"/>
+          </ownedAttribute>`)
+	suite.Contains(document, `name="rate">
+            <type href="http://www.omg.org/spec/UML/20161101/PrimitiveTypes.xmi#Real"/>`)
+	status := "domain/bank/subdomain/accounts/class/account/attribute/status"
+	suite.Contains(document, `<ownedAttribute xmi:type="uml:Property" xmi:id="`+status+`" name="status" type="`+status+`/type">
+            <lowerValue xmi:type="uml:LiteralInteger" xmi:id="`+status+`/lower" value="0"/>
+            <upperValue xmi:type="uml:LiteralUnlimitedNatural" xmi:id="`+status+`/upper" value="1"/>
+          </ownedAttribute>`)
+	suite.Contains(document, `<packagedElement xmi:type="uml:Enumeration" xmi:id="`+status+`/type" name="status">
+          <ownedLiteral xmi:type="uml:EnumerationLiteral" xmi:id="`+status+`/type/open" name="open"/>
+          <ownedLiteral xmi:type="uml:EnumerationLiteral" xmi:id="`+status+`/type/frozen" name="frozen"/>
+        </packagedElement>`)
+	owners := "domain/bank/subdomain/accounts/class/account/attribute/owners"
+	suite.Contains(document, `<ownedAttribute xmi:type="uml:Property" xmi:id="`+owners+`" name="owners" type="domain/bank/subdomain/accounts/class/customer">
+            <lowerValue xmi:type="uml:LiteralInteger" xmi:id="`+owners+`/lower" value="0"/>
+            <upperValue xmi:type="uml:LiteralUnlimitedNatural" xmi:id="`+owners+`/upper" value="3"/>`)
+}
+
+func (suite *XMISuite) TestAssociationsAndGeneralizations() {
+	document := Document(test_helper.GetBankModel())
+	holds := "domain/bank/subdomain/accounts/cassociation/class/customer/class/account/holds"
+	suite.Contains(document, `<packagedElement xmi:type="uml:AssociationClass" xmi:id="domain/bank/subdomain/accounts/class/holding" name="Holding" memberEnd="`+holds+`/from `+holds+`/to">
+          <ownedEnd xmi:type="uml:Property" xmi:id="`+holds+`/from" type="domain/bank/subdomain/accounts/class/customer" association="domain/bank/subdomain/accounts/class/holding">
+            <lowerValue xmi:type="uml:LiteralInteger" xmi:id="`+holds+`/from/lower" value="0"/>
+            <upperValue xmi:type="uml:LiteralUnlimitedNatural" xmi:id="`+holds+`/from/upper" value="*"/>
+          </ownedEnd>`)
+	suite.NotContains(document, `xmi:type="uml:Association" xmi:id="`+holds+`"`)
+	suite.Contains(document, `name="serves" memberEnd=`)
+
+	suite.Contains(document, `<generalization xmi:type="uml:Generalization" xmi:id="domain/bank/subdomain/accounts/class/savings/generalization" general="domain/bank/subdomain/accounts/class/account"/>`)
+	suite.Contains(document, `<packagedElement xmi:type="uml:GeneralizationSet" xmi:id="domain/bank/subdomain/accounts/cgeneralization/account_kinds" name="Account kinds" isCovering="true" isDisjoint="true" generalization="domain/bank/subdomain/accounts/class/savings/generalization"/>`)
+}
+
+func (suite *XMISuite) TestActorsAndUseCases() {
+	document := Document(test_helper.GetBankModel())
+	suite.Contains(document, `<packagedElement xmi:type="uml:Actor" xmi:id="actor/clerk" name="Clerk"/>`)
+	suite.Contains(document, `<packagedElement xmi:type="uml:UseCase" xmi:id="domain/bank/subdomain/accounts/usecase/open_account" name="Open account"/>`)
+	link := "domain/bank/subdomain/accounts/usecase/open_account/actor/clerk"
+	suite.Contains(document, `<packagedElement xmi:type="uml:Association" xmi:id="`+link+`" name="" memberEnd="`+link+`/actor `+link+`/usecase">`)
+}
+
+func (suite *XMISuite) TestStateMachine() {
+	document := Document(test_helper.GetBankModel())
+	class := "domain/bank/subdomain/accounts/class/account"
+	suite.Contains(document, `<subvertex xmi:type="uml:Pseudostate" xmi:id="`+class+`/initial" kind="initial"/>`)
+	suite.Contains(document, `<subvertex xmi:type="uml:State" xmi:id="`+class+`/state/open" name="Open">
+                <entry xmi:type="uml:OpaqueBehavior" xmi:id="`+class+`/state/open/saction/entry/log" name="Log"/>`)
+	suite.Contains(document, `<subvertex xmi:type="uml:FinalState" xmi:id="`+class+`/final"/>`)
+	suite.Contains(document, `source="`+class+`/state/closed" target="`+class+`/final" kind="external">`)
+	suite.Contains(document, `source="`+class+`/state/open" target="`+class+`/state/closed" kind="external" guard=`)
+	suite.Contains(document, `<body>balance &gt; 50</body>`)
+	suite.Contains(document, `name="close" event="`+class+`/event/close"/>`)
+	suite.Contains(document, `<packagedElement xmi:type="uml:SignalEvent" xmi:id="`+class+`/event/close" name="close"/>`)
+}
+
+func (suite *XMISuite) TestTestModel() {
+	ids := suite.ids(Document(test_helper.GetTestModel()))
+	seen := map[string]bool{}
+	for _, id := range ids {
+		suite.False(seen[id], id)
+		seen[id] = true
+	}
+}
+
+func (suite *XMISuite) TestGenerate() {
+	outputPath := suite.T().TempDir()
+	suite.Require().NoError(Generate(test_helper.GetBankModel(), outputPath))
+	content, err := os.ReadFile(filepath.Join(outputPath, "bank"+FileExtension))
+	suite.Require().NoError(err)
+	suite.Equal(Document(test_helper.GetBankModel()), string(content))
+}
@@ -155,6 +155,9 @@ func (s *Server) mainHandler(w http.ResponseWriter, r *http.Request) {
 		case strings.HasSuffix(file, ".svg"):
 			s.serveSVG(ctx, model, file, w, r)
 			return
+		case strings.HasSuffix(file, ".csv"):
+			s.serveCSV(ctx, model, file, w, r)
+			return
 		case strings.HasSuffix(file, ".css"):
 			s.serveCSS(ctx, model, w, r)
 			return
@@ -218,9 +221,16 @@ func (s *Server) renderMD(ctx context.Context, model, file string, w http.Respon
 		mdHTML = markdown.ToHTML(data, nil, nil)
 	})
 
+	var panel []byte
+	perftrack.Run(ctx, "impact.build", func() {
+		if parsed, ok := s.store.GetModel(model); ok {
+			panel = impactPanelHTML(parsed, file)
+		}
+	})
+
 	var body []byte
 	perftrack.Run(ctx, "html.build", func() {
-		body = buildMDPageHTML(model, file, data, mdHTML)
+		body = buildMDPageHTML(model, file, data, mdHTML, panel)
 	})
 
 	w.Header().Set("Content-Type", "text/html; charset=utf-8")
@@ -229,7 +239,9 @@ func (s *Server) renderMD(ctx context.Context, model, file string, w http.Respon
 	})
 }
 
-func buildMDPageHTML(model, file string, mdSource, mdHTML []byte) []byte {
+// buildMDPageHTML wraps a rendered markdown page, with any panel after it, in a page
+// that reloads when the model changes.
+func buildMDPageHTML(model, file string, mdSource, mdHTML, panel []byte) []byte {
 	escapedModel := html.EscapeString(model)
 	_ = file
 
@@ -238,20 +250,16 @@ func buildMDPageHTML(model, file string, mdSource, mdHTML []byte) []byte {
 	buf.WriteString(escapedModel)
 	buf.WriteString(`/style.css">`)
 	buf.WriteString(generate.ReloadEventsScript(model))
-	if markdownHasMermaid(mdSource) {
+	if generate.MarkdownHasMermaid(mdSource) {
 		buf.WriteString(`<script src="`)
 		buf.WriteString(mermaidJSPath)
 		buf.WriteString(`"></script>`)
 	}
 	buf.WriteString(`</head><body>`)
 	buf.Write(mdHTML)
-	if markdownHasMermaid(mdSource) {
-		buf.WriteString(`<script>`)
-		buf.WriteString(`document.querySelectorAll('pre code.language-mermaid').forEach(function(el){`)
-		buf.WriteString(`var d=document.createElement('div');d.className='mermaid';`)
-		buf.WriteString(`d.textContent=el.textContent;el.parentElement.replaceWith(d);});`)
-		buf.WriteString(`mermaid.initialize({startOnLoad:false,securityLevel:'loose'});mermaid.run();`)
-		buf.WriteString(`</script>`)
+	buf.Write(panel)
+	if generate.MarkdownHasMermaid(mdSource) {
+		buf.WriteString(generate.MermaidRenderScript)
 	}
 	buf.WriteString(`</body></html>`)
 	return []byte(buf.String())
@@ -275,6 +283,25 @@ func (s *Server) serveSVG(ctx context.Context, model, file string, w http.Respon
 	})
 }
 
+// serveCSV serves a CSV export from the in-memory store as a download.
+func (s *Server) serveCSV(ctx context.Context, model, file string, w http.ResponseWriter, r *http.Request) {
+	var data []byte
+	var ok bool
+	perftrack.Run(ctx, "store.getCSV", func() {
+		data, ok = s.store.GetCSV(ctx, model, file)
+	})
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", file))
+	perftrack.Run(ctx, "response.write", func() {
+		_, _ = w.Write(data)
+	})
+}
+
 // serveCSS serves the CSS file from the in-memory store.
 func (s *Server) serveCSS(ctx context.Context, model string, w http.ResponseWriter, r *http.Request) {
 	var data []byte
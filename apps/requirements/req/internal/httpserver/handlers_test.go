@@ -93,3 +93,29 @@ func TestRenderMDUsesModelWideEventSource(t *testing.T) {
 		t.Errorf("expected pagehide listener to close EventSource, got: %s", body)
 	}
 }
+
+// Class pages end with a panel of what depends on the class and its parts; other pages have none.
+func TestRenderMDClassImpactPanel(t *testing.T) {
+	store := NewModelStore()
+	model := test_helper.GetTestModel()
+	if err := store.SetModel("test_model", &model, nil); err != nil {
+		t.Fatalf("SetModel failed: %v", err)
+	}
+	server := NewServer(store)
+
+	code, body := requestMD(server, "/test_model/class-domain.domain_a.subdomain.subdomain_a.class.order.md")
+	if code != http.StatusOK {
+		t.Fatalf("expected 200, got %d", code)
+	}
+	if !strings.Contains(body, `<section class="impact-panel"><h2>Impact</h2>`) {
+		t.Errorf("expected an impact panel on the class page, got: %s", body)
+	}
+	if !strings.Contains(body, "<summary>attribute Total (5)</summary>") {
+		t.Errorf("expected the impact of the Total attribute, got: %s", body)
+	}
+
+	_, body = requestMD(server, "/test_model/model.md")
+	if strings.Contains(body, "impact-panel") {
+		t.Errorf("expected no impact panel on the model page, got: %s", body)
+	}
+}
@@ -0,0 +1,68 @@
+package httpserver
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/impact"
+	"github.com/gomarkdown/markdown"
+)
+
+// impactPanelHTML renders, after a class page, what depends on the class and on each of
+// its attributes, states, events, guards, actions and queries, each in a section of its
+// own. Pages of anything but a class have no panel.
+func impactPanelHTML(model *core.Model, file string) []byte {
+	class, ok := pageClass(model, file)
+	if !ok {
+		return nil
+	}
+
+	keys := []identity.Key{class.Key}
+	for _, attr := range class.Attributes {
+		keys = append(keys, attr.Key)
+	}
+	keys = append(keys, identity.SortedKeys(class.States)...)
+	keys = append(keys, identity.SortedKeys(class.Events)...)
+	keys = append(keys, identity.SortedKeys(class.Guards)...)
+	keys = append(keys, identity.SortedKeys(class.Actions)...)
+	keys = append(keys, identity.SortedKeys(class.Queries)...)
+
+	var buf strings.Builder
+	buf.WriteString(`<section class="impact-panel"><h2>Impact</h2>`)
+	for i, key := range keys {
+		found, err := impact.Analyze(*model, key)
+		if err != nil || found.Count() == 0 {
+			if i == 0 {
+				buf.WriteString(`<p>Nothing in the model depends on this class.</p></section>`)
+				return []byte(buf.String())
+			}
+			continue
+		}
+		fmt.Fprintf(&buf, `<details><summary>%s %s (%d)</summary>`, html.EscapeString(found.Element), html.EscapeString(found.Name), found.Count())
+		buf.Write(markdown.ToHTML([]byte(impact.Markdown(found, 3)), nil, nil))
+		buf.WriteString(`</details>`)
+	}
+	buf.WriteString(`</section>`)
+	return []byte(buf.String())
+}
+
+// pageClass finds the class a page is of.
+func pageClass(model *core.Model, file string) (model_class.Class, bool) {
+	if model == nil || !strings.HasPrefix(file, "class-") {
+		return model_class.Class{}, false
+	}
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for key, class := range subdomain.Classes {
+				if file == "class-"+strings.ReplaceAll(key.String(), "/", ".")+".md" {
+					return class, true
+				}
+			}
+		}
+	}
+	return model_class.Class{}, false
+}
@@ -7,6 +7,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -21,26 +22,8 @@ func TestServeMermaidJS(t *testing.T) {
 	require.Equal(t, http.StatusOK, rec.Code)
 	assert.Equal(t, "application/javascript; charset=utf-8", rec.Header().Get("Content-Type"))
 	assert.Equal(t, mermaidCacheControl, rec.Header().Get("Cache-Control"))
-	require.NotEmpty(t, mermaidJS)
-	assert.Equal(t, mermaidJS, rec.Body.Bytes())
-}
-
-func TestMarkdownHasMermaid(t *testing.T) {
-	tests := []struct {
-		name string
-		md   string
-		want bool
-	}{
-		{name: "mermaid fence", md: "# Title\n\n```mermaid\nclassDiagram\n```\n", want: true},
-		{name: "no mermaid", md: "# Title\n\nPlain text only.\n", want: false},
-		{name: "other fence", md: "# Title\n\n```go\npackage main\n```\n", want: false},
-	}
-
-	for _, tc := range tests {
-		t.Run(tc.name, func(t *testing.T) {
-			assert.Equal(t, tc.want, markdownHasMermaid([]byte(tc.md)))
-		})
-	}
+	require.NotEmpty(t, generate.MermaidJS)
+	assert.Equal(t, generate.MermaidJS, rec.Body.Bytes())
 }
 
 func TestRenderMDUsesLocalMermaidOnlyWhenNeeded(t *testing.T) {
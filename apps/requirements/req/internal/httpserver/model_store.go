@@ -7,6 +7,7 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/generate"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/perftrack"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 )
 
 // ModelStore manages in-memory models and their generated markdown content.
@@ -25,12 +26,13 @@ func NewModelStore() *ModelStore {
 // classErrors maps a class key string to a parse-error message; those classes'
 // pages render as red-bold error blocks. Pass nil when there are no failures.
 func (s *ModelStore) SetModel(name string, model *core.Model, classErrors map[string]string) error {
-	return s.SetModelTracked(name, model, classErrors, nil)
+	return s.SetModelTracked(name, model, classErrors, nil, nil)
 }
 
 // SetModelTracked is SetModel with optional phase timings recorded on tracker.
-func (s *ModelStore) SetModelTracked(name string, model *core.Model, classErrors map[string]string, tracker *perftrack.Tracker) error {
-	snapshot, err := s.generateSnapshot(model, classErrors, tracker)
+// sources, when not nil, gives expression errors their positions in the model's files.
+func (s *ModelStore) SetModelTracked(name string, model *core.Model, classErrors map[string]string, sources *sourcepos.Index, tracker *perftrack.Tracker) error {
+	snapshot, err := s.generateSnapshot(model, classErrors, sources, tracker)
 	if err != nil {
 		return err
 	}
@@ -74,51 +76,57 @@ func (s *ModelStore) GetSVG(ctx context.Context, model, file string) ([]byte, bo
 	return s.state.svgFile(ctx, model, file)
 }
 
+// GetCSV returns CSV content for a specific model and file.
+func (s *ModelStore) GetCSV(ctx context.Context, model, file string) ([]byte, bool) {
+	return s.state.csvFile(ctx, model, file)
+}
+
 // ListModels returns a list of all model names.
 func (s *ModelStore) ListModels(ctx context.Context) []string {
 	return s.state.modelNames(ctx)
 }
 
-func (s *ModelStore) generateSnapshot(model *core.Model, classErrors map[string]string, tracker *perftrack.Tracker) (publishedSnapshot, error) {
+func (s *ModelStore) generateSnapshot(model *core.Model, classErrors map[string]string, sources *sourcepos.Index, tracker *perftrack.Tracker) (publishedSnapshot, error) {
 	var (
-		mdContent   map[string][]byte
-		svgContent  map[string][]byte
+		collector   *ContentCollector
 		cssContent  []byte
 		parseIssues *generate.ParseIssueIndex
 		err         error
 	)
 	perftrack.RunOn(tracker, "store.generate", func() {
-		mdContent, svgContent, cssContent, parseIssues, err = generateModelContent(model, classErrors, tracker)
+		collector, cssContent, parseIssues, err = generateModelContent(model, classErrors, sources, tracker)
 	})
 	if err != nil {
 		return publishedSnapshot{}, err
 	}
 	return publishedSnapshot{
 		model:       model,
-		markdown:    mdContent,
-		svg:         svgContent,
+		markdown:    collector.Markdown,
+		svg:         collector.SVG,
+		csv:         collector.CSV,
 		css:         cssContent,
 		parseIssues: parseIssues,
 	}, nil
 }
 
-func generateModelContent(model *core.Model, classErrors map[string]string, tracker *perftrack.Tracker) (map[string][]byte, map[string][]byte, []byte, *generate.ParseIssueIndex, error) {
+func generateModelContent(model *core.Model, classErrors map[string]string, sources *sourcepos.Index, tracker *perftrack.Tracker) (*ContentCollector, []byte, *generate.ParseIssueIndex, error) {
 	var parseIssues *generate.ParseIssueIndex
 	perftrack.RunOn(tracker, "generate.parseIssues", func() {
-		parseIssues = generate.BuildParseIssueIndex(model, classErrors)
+		parseIssues = generate.BuildParseIssueIndex(model, classErrors, sources)
 	})
 
 	collector := &ContentCollector{
 		Markdown: make(map[string][]byte),
 		SVG:      make(map[string][]byte),
+		CSV:      make(map[string][]byte),
 	}
 
 	var err error
 	perftrack.RunOn(tracker, "generate.markdown", func() {
-		err = generate.GenerateMdToWriter(*model, collector, classErrors)
+		err = generate.GenerateMdWithIssuesToWriter(*model, collector, parseIssues)
 	})
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	var cssContent []byte
@@ -128,13 +136,14 @@ func generateModelContent(model *core.Model, classErrors map[string]string, trac
 		cssContent = cssBuffer.Bytes()
 	})
 
-	return collector.Markdown, collector.SVG, cssContent, parseIssues, nil
+	return collector, cssContent, parseIssues, nil
 }
 
 // ContentCollector implements generate.ContentWriter to collect content in memory.
 type ContentCollector struct {
 	Markdown map[string][]byte
 	SVG      map[string][]byte
+	CSV      map[string][]byte
 	CSS      []byte
 }
 
@@ -150,6 +159,12 @@ func (c *ContentCollector) WriteSVG(filename string, content []byte) error {
 	return nil
 }
 
+// WriteCSV stores CSV content.
+func (c *ContentCollector) WriteCSV(filename string, content []byte) error {
+	c.CSV[filename] = content
+	return nil
+}
+
 // WriteCSS stores CSS content.
 func (c *ContentCollector) WriteCSS(content []byte) error {
 	c.CSS = content
@@ -20,6 +20,7 @@ type storeState struct {
 	markdown    map[string]map[string][]byte
 	css         map[string][]byte
 	svg         map[string]map[string][]byte
+	csv         map[string]map[string][]byte
 	modelErrors map[string]string
 	parseIssues map[string]*generate.ParseIssueIndex
 }
@@ -30,6 +31,7 @@ func newStoreState() storeState {
 		markdown:    make(map[string]map[string][]byte),
 		css:         make(map[string][]byte),
 		svg:         make(map[string]map[string][]byte),
+		csv:         make(map[string]map[string][]byte),
 		modelErrors: make(map[string]string),
 		parseIssues: make(map[string]*generate.ParseIssueIndex),
 	}
@@ -40,6 +42,7 @@ type publishedSnapshot struct {
 	model       *core.Model
 	markdown    map[string][]byte
 	svg         map[string][]byte
+	csv         map[string][]byte
 	css         []byte
 	parseIssues *generate.ParseIssueIndex
 }
@@ -72,6 +75,7 @@ func (st *storeState) publish(name string, snapshot publishedSnapshot, tracker *
 		st.models[name] = snapshot.model
 		st.markdown[name] = snapshot.markdown
 		st.svg[name] = snapshot.svg
+		st.csv[name] = snapshot.csv
 		st.css[name] = snapshot.css
 		st.parseIssues[name] = snapshot.parseIssues
 		delete(st.modelErrors, name)
@@ -147,6 +151,17 @@ func (st *storeState) svgFile(ctx context.Context, model, file string) ([]byte,
 	return content, ok
 }
 
+func (st *storeState) csvFile(ctx context.Context, model, file string) ([]byte, bool) {
+	var content []byte
+	var ok bool
+	st.withReadLock(ctx, func() {
+		if files, found := st.csv[model]; found {
+			content, ok = files[file]
+		}
+	})
+	return content, ok
+}
+
 func (st *storeState) modelNames(ctx context.Context) []string {
 	var names []string
 	st.withReadLock(ctx, func() {
@@ -13,6 +13,7 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_ai"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/parser_human"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/perftrack"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 )
 
 // Input format constants.
@@ -188,8 +189,13 @@ func (sw *SourceWatcher) updateModelFromJSON(tracker *perftrack.Tracker) error {
 		return err
 	}
 
+	var sources *sourcepos.Index
+	perftrack.RunOn(tracker, "parse.sources", func() {
+		sources = usableSourceIndex(parser_ai.SourceIndex(sw.modelPath))
+	})
+
 	perftrack.RunOn(tracker, "store.setModel", func() {
-		err = sw.store.SetModelTracked(sw.modelName, &parsedModel, nil, tracker)
+		err = sw.store.SetModelTracked(sw.modelName, &parsedModel, nil, sources, tracker)
 	})
 	if err != nil {
 		return err
@@ -215,8 +221,13 @@ func (sw *SourceWatcher) updateModelFromYAML(tracker *perftrack.Tracker) error {
 		return err
 	}
 
+	var sources *sourcepos.Index
+	perftrack.RunOn(tracker, "parse.sources", func() {
+		sources = usableSourceIndex(parser_human.SourceIndex(sw.modelPath))
+	})
+
 	perftrack.RunOn(tracker, "store.setModel", func() {
-		err = sw.store.SetModelTracked(sw.modelName, &parsedModel, classErrorMap(failures), tracker)
+		err = sw.store.SetModelTracked(sw.modelName, &parsedModel, classErrorMap(failures), sources, tracker)
 	})
 	if err != nil {
 		return err
@@ -234,12 +245,22 @@ func classErrorMap(failures []parser_human.ParseFailure) map[string]string {
 	}
 	m := make(map[string]string, len(failures))
 	for _, f := range failures {
-		log.Printf("parse failure: %s: %s", f.Path, f.Err)
-		m[f.ClassKey.String()] = f.Err
+		log.Printf("parse failure: %s", f.Message())
+		m[f.ClassKey.String()] = f.Message()
 	}
 	return m
 }
 
+// usableSourceIndex returns sources, or nil when the model's files could not be indexed.
+// Without an index, errors are still shown, just without their positions.
+func usableSourceIndex(sources *sourcepos.Index, err error) *sourcepos.Index {
+	if err != nil {
+		log.Printf("source index: %v", err)
+		return nil
+	}
+	return sources
+}
+
 // LoadModel loads the model from the source path into the store.
 func (sw *SourceWatcher) LoadModel() error {
 	return sw.updateModel()
@@ -194,6 +194,26 @@ func (k Key) String() string {
 	return result
 }
 
+// SortedKeys returns the keys of a keyed map ordered by their string form, so that
+// what is generated from the map is the same every time.
+func SortedKeys[V any](m map[Key]V) []Key {
+	keys := make([]Key, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.SortFunc(keys, func(a, b Key) int { return strings.Compare(a.String(), b.String()) })
+	return keys
+}
+
+// SortedValues returns the values of a keyed map ordered by key, as SortedKeys.
+func SortedValues[V any](m map[Key]V) []V {
+	values := make([]V, 0, len(m))
+	for _, key := range SortedKeys(m) {
+		values = append(values, m[key])
+	}
+	return values
+}
+
 // GetSubKey returns the SubKey of the Key.
 func (k *Key) GetSubKey() string {
 	return k.SubKey
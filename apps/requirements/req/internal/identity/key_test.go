@@ -1964,3 +1964,19 @@ func (suite *KeySuite) TestYAMLUnmarshalInvalid() {
 		}
 	}
 }
+
+func (suite *KeySuite) TestSortedKeys() {
+	b := mustParseKey(suite, "domain/b")
+	a := mustParseKey(suite, "domain/a")
+	c := mustParseKey(suite, "domain/a/subdomain/c")
+	m := map[Key]string{b: "b", c: "c", a: "a"}
+	suite.Equal([]Key{a, c, b}, SortedKeys(m))
+	suite.Equal([]string{"a", "c", "b"}, SortedValues(m))
+	suite.Empty(SortedKeys(map[Key]string{}))
+}
+
+func mustParseKey(suite *KeySuite, s string) Key {
+	key, err := ParseKey(s)
+	suite.Require().NoError(err)
+	return key
+}
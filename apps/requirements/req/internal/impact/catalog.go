@@ -0,0 +1,179 @@
+package impact
+
+import (
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// entry is what an element of a model is and what it is named.
+type entry struct {
+	kind   string
+	name   string
+	parent string // The key of the element it is part of, if any.
+}
+
+// catalog holds the elements of a model by key, to find the analyzed element and to name
+// the elements that dependents refer to.
+type catalog map[string]entry
+
+func newCatalog(model core.Model) catalog {
+	c := catalog{}
+	c.logics("invariant", "", model.Invariants)
+	for key, actor := range model.Actors {
+		c.add("actor", key, actor.Name, "")
+	}
+	for key, generalization := range model.ActorGeneralizations {
+		c.add("actor generalization", key, generalization.Name, "")
+	}
+	for key, function := range model.GlobalFunctions {
+		c.add("global function", key, function.Name, "")
+	}
+	for key, set := range model.NamedSets {
+		c.add("named set", key, set.Name, "")
+	}
+	for key, association := range model.GetClassAssociations() {
+		c.add("association", key, association.Name, "")
+		c.logics("invariant", key.String(), association.Invariants)
+	}
+	for key, domain := range model.Domains {
+		c.add("domain", key, domain.Name, "")
+		for key, subdomain := range domain.Subdomains {
+			c.add("subdomain", key, subdomain.Name, domain.Key.String())
+			for key, generalization := range subdomain.Generalizations {
+				c.add("generalization", key, generalization.Name, subdomain.Key.String())
+			}
+			for key, generalization := range subdomain.UseCaseGeneralizations {
+				c.add("use case generalization", key, generalization.Name, subdomain.Key.String())
+			}
+			for _, class := range subdomain.Classes {
+				c.class(class)
+			}
+			for key, useCase := range subdomain.UseCases {
+				c.add("use case", key, useCase.Name, subdomain.Key.String())
+				for key, scenario := range useCase.Scenarios {
+					c.add("scenario", key, scenario.Name, useCase.Key.String())
+					for key, object := range scenario.Objects {
+						c.add("object", key, object.Name+":"+className(model, object.ClassKey), scenario.Key.String())
+					}
+				}
+			}
+		}
+	}
+	return c
+}
+
+func (c catalog) class(class model_class.Class) {
+	classKey := class.Key.String()
+	c.add("class", class.Key, class.Name, class.Key.GetParentKey())
+	c.logics("invariant", classKey, class.Invariants)
+	for _, attr := range class.Attributes {
+		c.add("attribute", attr.Key, attr.Name, classKey)
+		if attr.DerivationPolicy != nil {
+			c.add("derivation", attr.DerivationPolicy.Key, attr.Name, classKey)
+		}
+		c.logics("invariant", attr.Key.String(), attr.Invariants)
+	}
+	for key, state := range class.States {
+		c.add("state", key, state.Name, classKey)
+	}
+	for key, event := range class.Events {
+		c.add("event", key, event.Name, classKey)
+	}
+	for key, guard := range class.Guards {
+		c.add("guard", key, guard.Name, classKey)
+	}
+	for key, action := range class.Actions {
+		c.add("action", key, action.Name, classKey)
+		c.parameters(key.String(), action.Parameters)
+		c.logics("requires", key.String(), action.Requires)
+		c.logics("guarantee", key.String(), action.Guarantees)
+		c.logics("safety rule", key.String(), action.SafetyRules)
+	}
+	for key, query := range class.Queries {
+		c.add("query", key, query.Name, classKey)
+		c.parameters(key.String(), query.Parameters)
+		c.logics("requires", key.String(), query.Requires)
+		c.logics("guarantee", key.String(), query.Guarantees)
+	}
+	// Transitions are named by the states, event, guard and action named above.
+	for key, transition := range class.Transitions {
+		c.add("transition", key, c.transitionName(transition), classKey)
+	}
+}
+
+func (c catalog) parameters(parentKey string, parameters []model_state.Parameter) {
+	for _, parameter := range parameters {
+		c.add("parameter", parameter.Key, parameter.Name, parentKey)
+		c.logics("invariant", parameter.Key.String(), parameter.Invariants)
+	}
+}
+
+func (c catalog) logics(kind, parentKey string, logics []model_logic.Logic) {
+	for _, logic := range logics {
+		c.add(kind, logic.Key, logicName(logic), parentKey)
+	}
+}
+
+func (c catalog) add(kind string, key identity.Key, name, parentKey string) {
+	c[key.String()] = entry{kind: kind, name: name, parent: parentKey}
+}
+
+// transitionName names a transition as "From → Event [guard] / action → To".
+func (c catalog) transitionName(transition model_state.Transition) string {
+	name := c.name(transition.EventKey.String())
+	if transition.GuardKey != nil {
+		name += " [" + c.name(transition.GuardKey.String()) + "]"
+	}
+	if transition.ActionKey != nil {
+		name += " / " + c.name(transition.ActionKey.String())
+	}
+	from, to := "(initial)", "(final)"
+	if transition.FromStateKey != nil {
+		from = c.name(transition.FromStateKey.String())
+	}
+	if transition.ToStateKey != nil {
+		to = c.name(transition.ToStateKey.String())
+	}
+	return from + " → " + name + " → " + to
+}
+
+// name is the name of the element with a key, or nothing when there is none.
+func (c catalog) name(key string) string {
+	return c[key].name
+}
+
+// names are the names of the elements with keys.
+func (c catalog) names(keys []identity.Key) string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, c.name(key.String()))
+	}
+	return strings.Join(names, ", ")
+}
+
+// qualifiedName is the name of an element with the name of the element it is part of,
+// such as Order.Submit for an event.
+func (c catalog) qualifiedName(key string) string {
+	e := c[key]
+	if parent := c.name(e.parent); parent != "" {
+		return parent + "." + e.name
+	}
+	return e.name
+}
+
+// className is the name of a class, wherever in the model it is.
+func className(model core.Model, classKey identity.Key) string {
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			if class, ok := subdomain.Classes[classKey]; ok {
+				return class.Name
+			}
+		}
+	}
+	return ""
+}
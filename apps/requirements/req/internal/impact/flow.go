@@ -0,0 +1,171 @@
+package impact
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// _maxChainEvents is the most events a peer event chain is followed back through.
+const _maxChainEvents = 8
+
+// steps adds the scenario steps that send an event reaching a target, call a query that
+// is or depends on one, include a scenario that is one, or have an object of a class that is one.
+func (a *analyzer) steps() {
+	for _, domainKey := range identity.SortedKeys(a.model.Domains) {
+		domain := a.model.Domains[domainKey]
+		for _, subdomainKey := range identity.SortedKeys(domain.Subdomains) {
+			subdomain := domain.Subdomains[subdomainKey]
+			for _, useCaseKey := range identity.SortedKeys(subdomain.UseCases) {
+				useCase := subdomain.UseCases[useCaseKey]
+				for _, scenarioKey := range identity.SortedKeys(useCase.Scenarios) {
+					scenario := useCase.Scenarios[scenarioKey]
+					if scenario.Steps != nil {
+						a.scenarioSteps(scenario, scenario.Steps.Statements, "")
+					}
+				}
+			}
+		}
+	}
+}
+
+func (a *analyzer) scenarioSteps(scenario model_scenario.Scenario, statements []model_scenario.Step, prefix string) {
+	for i, step := range statements {
+		position := prefix + strconv.Itoa(i+1)
+		if via, ok := a.stepReaches(scenario, step); ok {
+			a.impact.Steps = append(a.impact.Steps, Dependent{Kind: "scenario step", Key: step.Key.String(), Name: "Step " + position, Parent: scenario.Name, Detail: a.stepText(step), Via: via})
+		}
+		a.scenarioSteps(scenario, step.Statements, position+".")
+	}
+}
+
+// stepReaches reports whether a leaf step reaches a target.
+func (a *analyzer) stepReaches(scenario model_scenario.Scenario, step model_scenario.Step) (via string, ok bool) {
+	if step.StepType != model_scenario.STEP_TYPE_LEAF {
+		return "", false
+	}
+	consider := func(v string, found bool) {
+		if found && (!ok || v == "") {
+			via, ok = v, true
+		}
+	}
+	if step.EventKey != nil {
+		consider(a.match(step.EventKey.String()))
+		v, found := a.reached[step.EventKey.String()]
+		consider(v, found)
+	}
+	if step.QueryKey != nil {
+		consider(a.match(step.QueryKey.String()))
+		v, found := a.owners[step.QueryKey.String()]
+		consider(v, found)
+	}
+	if step.ScenarioKey != nil {
+		consider(a.match(step.ScenarioKey.String()))
+	}
+	for _, objectKey := range []*identity.Key{step.FromObjectKey, step.ToObjectKey} {
+		if objectKey == nil {
+			continue
+		}
+		if object, found := scenario.Objects[*objectKey]; found {
+			consider(a.match(object.ClassKey.String()))
+		}
+	}
+	return via, ok
+}
+
+// stepText describes a leaf step as "From → To: description (Event)".
+func (a *analyzer) stepText(step model_scenario.Step) string {
+	name := func(key *identity.Key) string {
+		if key == nil {
+			return ""
+		}
+		return a.catalog.name(key.String())
+	}
+	text := name(step.FromObjectKey)
+	if step.ToObjectKey != nil {
+		text += " → " + name(step.ToObjectKey)
+	}
+	if step.Description != "" {
+		text += ": " + step.Description
+	}
+	for _, ref := range []*identity.Key{step.EventKey, step.QueryKey, step.ScenarioKey} {
+		if ref != nil {
+			text += " (" + name(ref) + ")"
+		}
+	}
+	if step.LeafType != nil && *step.LeafType == model_scenario.LEAF_TYPE_DESTROY {
+		text += " (destroy)"
+	}
+	return text
+}
+
+// peerEventChains adds the chains of events, each sent by an action of a transition on
+// the one before, that end in an event whose transitions reach a target. A chain has at
+// least two events; a single event is already among the transitions.
+func (a *analyzer) peerEventChains() {
+	// senders maps an event to the events whose transitions run an action that sends it.
+	senders := map[string][]string{}
+	a.eachClass(func(class model_class.Class) {
+		for _, key := range identity.SortedKeys(class.Transitions) {
+			transition := class.Transitions[key]
+			if transition.ActionKey == nil {
+				continue
+			}
+			action, ok := class.Actions[*transition.ActionKey]
+			if !ok {
+				continue
+			}
+			from := transition.EventKey.String()
+			for _, sent := range sentEvents(slices.Concat(action.Requires, action.Guarantees, action.SafetyRules)) {
+				if sent != from && !slices.Contains(senders[sent], from) {
+					senders[sent] = append(senders[sent], from)
+				}
+			}
+		}
+	})
+
+	var follow func(chain []string, via string)
+	follow = func(chain []string, via string) {
+		if len(chain) == _maxChainEvents {
+			return
+		}
+		for _, sender := range senders[chain[0]] {
+			if slices.Contains(chain, sender) {
+				continue
+			}
+			longer := append([]string{sender}, chain...)
+			names := make([]string, len(longer))
+			for i, key := range longer {
+				names[i] = a.catalog.qualifiedName(key)
+			}
+			a.impact.PeerEventChains = append(a.impact.PeerEventChains, Dependent{Kind: "peer event chain", Key: sender, Name: strings.Join(names, " → "), Via: via})
+			follow(longer, via)
+		}
+	}
+	for _, key := range slices.Sorted(maps.Keys(a.reached)) {
+		follow([]string{key}, a.reached[key])
+	}
+}
+
+// sentEvents are the keys of the events that logic sends, in the order it sends them.
+func sentEvents(logics []model_logic.Logic) []string {
+	var keys []string
+	for _, logic := range logics {
+		for _, spec := range []me.Expression{logic.Spec.Expression, logic.DestroyEventSpec.Expression, logic.EndpointSelectorSpec.Expression} {
+			me.Walk(spec, func(e me.Expression) bool {
+				if call, ok := e.(*me.EventCall); ok && !slices.Contains(keys, call.EventKey.String()) {
+					keys = append(keys, call.EventKey.String())
+				}
+				return true
+			})
+		}
+	}
+	return keys
+}
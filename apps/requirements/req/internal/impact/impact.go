@@ -0,0 +1,427 @@
+// Package impact finds what in a model depends on one of its elements, to know what a
+// change to the element would touch.
+//
+// Logic is searched in its lowered logic_expression form: invariants, derivations,
+// guards, requires, guarantees, safety rules, global functions and named sets that refer
+// to the element or to any part of it. Within a class, self.field resolves to the class's
+// attributes and outgoing associations as lowering does; fields of other values are not
+// typed and are not followed. An attribute derived from the element depends on it too,
+// and so does everything that depends on that attribute, transitively.
+//
+// Beyond logic, the transitions whose states, event, guard or action are the element or
+// depend on it, the scenario steps that send those events or call the queries, the
+// association uniqueness tuples and indexes it is part of, and the chains of peer events
+// whose actions send one another's events until one reaches it are listed.
+package impact
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// Dependent is an element of the model that depends on the analyzed element.
+type Dependent struct {
+	Kind   string `json:"kind"` // What the dependent is, such as guarantee, transition or index.
+	Key    string `json:"key"`
+	Name   string `json:"name"`
+	Parent string `json:"parent,omitempty"` // The name of the element it is part of, such as the action of a guarantee.
+	Detail string `json:"detail,omitempty"` // The specification, step or chain that shows the dependency.
+	Via    string `json:"via,omitempty"`    // The name of the derived attribute it depends through, when not directly.
+}
+
+// Impact is what depends on an element of a model.
+type Impact struct {
+	Key             string      `json:"key"`
+	Element         string      `json:"element"` // What the element is, such as attribute or event.
+	Name            string      `json:"name"`
+	Parent          string      `json:"parent,omitempty"`
+	Derived         []Dependent `json:"derived,omitempty"` // Derived attributes, directly or through each other.
+	Logic           []Dependent `json:"logic,omitempty"`
+	Transitions     []Dependent `json:"transitions,omitempty"`
+	Steps           []Dependent `json:"steps,omitempty"`
+	Uniqueness      []Dependent `json:"uniqueness,omitempty"`
+	Indexes         []Dependent `json:"indexes,omitempty"`
+	PeerEventChains []Dependent `json:"peer_event_chains,omitempty"`
+}
+
+// Count is the number of dependents.
+func (i Impact) Count() int {
+	return len(i.Derived) + len(i.Logic) + len(i.Transitions) + len(i.Steps) + len(i.Uniqueness) + len(i.Indexes) + len(i.PeerEventChains)
+}
+
+// target is the element analyzed, or an attribute derived from it.
+type target struct {
+	key string
+	via string // The name of the derived attribute, empty for the element itself.
+}
+
+// analyzer gathers the dependents of an element.
+type analyzer struct {
+	model        core.Model
+	catalog      catalog
+	classes      map[identity.Key]model_class.Class
+	associations map[identity.Key]model_class.Association
+	fields       map[identity.Key]map[string]identity.Key // For each class, what self.field names.
+	targets      []target
+	owners       map[string]string // Keys of guards, actions and queries whose logic depends on a target, to the via of the first.
+	reached      map[string]string // Keys of events whose transitions depend on a target, to the via of the first.
+	impact       Impact
+}
+
+// Analyze finds what in a model depends on the element with a key. The model's logic must
+// be lowered, as parsing a model does.
+func Analyze(model core.Model, key identity.Key) (Impact, error) {
+	c := newCatalog(model)
+	entry, ok := c[key.String()]
+	if !ok {
+		return Impact{}, fmt.Errorf("no element %q in model %q", key.String(), model.Key)
+	}
+
+	a := &analyzer{
+		model:        model,
+		catalog:      c,
+		classes:      map[identity.Key]model_class.Class{},
+		associations: model.GetClassAssociations(),
+		fields:       map[identity.Key]map[string]identity.Key{},
+		targets:      []target{{key: key.String()}},
+		owners:       map[string]string{},
+		reached:      map[string]string{},
+		impact:       Impact{Key: key.String(), Element: entry.kind, Name: entry.name, Parent: c.name(entry.parent)},
+	}
+	a.eachClass(func(class model_class.Class) {
+		a.classes[class.Key] = class
+		a.fields[class.Key] = selfFields(class, a.associations)
+	})
+	a.derived()
+	a.logic()
+	a.transitions()
+	a.steps()
+	a.uniqueness()
+	a.indexes()
+	a.peerEventChains()
+	return a.impact, nil
+}
+
+// match reports whether a key is a target or part of one, preferring the element itself
+// to an attribute derived from it.
+func (a *analyzer) match(key string) (via string, ok bool) {
+	for _, t := range a.targets {
+		if key != t.key && !strings.HasPrefix(key, t.key+"/") {
+			continue
+		}
+		if t.via == "" {
+			return "", true
+		}
+		if !ok {
+			via, ok = t.via, true
+		}
+	}
+	return via, ok
+}
+
+// refersTo reports whether an expression refers to a target. Within a class, self.field
+// is the class's attribute or outgoing association of that name.
+func (a *analyzer) refersTo(expr me.Expression, class *model_class.Class) (via string, ok bool) {
+	var fields map[string]identity.Key
+	if class != nil {
+		fields = a.fields[class.Key]
+	}
+	consider := func(key identity.Key) {
+		if v, found := a.match(key.String()); found && (!ok || v == "") {
+			via, ok = v, true
+		}
+	}
+	me.Walk(expr, func(e me.Expression) bool {
+		switch n := e.(type) {
+		case *me.AttributeRef:
+			consider(n.AttributeKey)
+		case *me.AssociationRef:
+			consider(n.AssociationKey)
+		case *me.ActionCall:
+			consider(n.ActionKey)
+		case *me.EventCall:
+			consider(n.EventKey)
+		case *me.GlobalCall:
+			consider(n.FunctionKey)
+		case *me.NamedSetRef:
+			consider(n.SetKey)
+		case *me.ClassRef:
+			consider(n.ClassKey)
+		case *me.FieldAccess:
+			if _, self := n.Base.(*me.SelfRef); self {
+				if key, found := fields[n.Field]; found {
+					consider(key)
+				}
+			}
+		}
+		return true
+	})
+	return via, ok
+}
+
+// logicRefersTo reports whether any specification of a logic entry refers to a target.
+func (a *analyzer) logicRefersTo(logic model_logic.Logic, class *model_class.Class) (via string, ok bool) {
+	for _, spec := range []me.Expression{logic.Spec.Expression, logic.DestroyEventSpec.Expression, logic.EndpointSelectorSpec.Expression} {
+		if spec == nil {
+			continue
+		}
+		if v, found := a.refersTo(spec, class); found && (!ok || v == "") {
+			via, ok = v, true
+		}
+	}
+	return via, ok
+}
+
+// derived adds, until there are no more, the attributes derived from a target as targets.
+func (a *analyzer) derived() {
+	for added := true; added; {
+		added = false
+		a.eachClass(func(class model_class.Class) {
+			for _, attr := range class.Attributes {
+				if attr.DerivationPolicy == nil {
+					continue
+				}
+				if _, already := a.match(attr.Key.String()); already {
+					continue
+				}
+				if via, ok := a.logicRefersTo(*attr.DerivationPolicy, &class); ok {
+					a.targets = append(a.targets, target{key: attr.Key.String(), via: attr.Name})
+					a.impact.Derived = append(a.impact.Derived, Dependent{Kind: "derived attribute", Key: attr.Key.String(), Name: attr.Name, Parent: class.Name, Via: via})
+					added = true
+				}
+			}
+		})
+	}
+}
+
+// logic adds the logic entries that refer to a target.
+func (a *analyzer) logic() {
+	a.logics("invariant", "", a.model.Invariants, nil)
+	for _, key := range identity.SortedKeys(a.model.GlobalFunctions) {
+		function := a.model.GlobalFunctions[key]
+		if via, ok := a.logicRefersTo(function.Logic, nil); ok {
+			a.impact.Logic = append(a.impact.Logic, Dependent{Kind: "global function", Key: key.String(), Name: function.Name, Detail: function.Logic.Spec.Specification, Via: via})
+		}
+	}
+	for _, key := range identity.SortedKeys(a.model.NamedSets) {
+		set := a.model.NamedSets[key]
+		if set.Spec.Expression == nil {
+			continue
+		}
+		if via, ok := a.refersTo(set.Spec.Expression, nil); ok {
+			a.impact.Logic = append(a.impact.Logic, Dependent{Kind: "named set", Key: key.String(), Name: set.Name, Detail: set.Spec.Specification, Via: via})
+		}
+	}
+
+	a.eachClass(func(class model_class.Class) {
+		a.logics("invariant", class.Name, class.Invariants, &class)
+		for _, attr := range class.Attributes {
+			if attr.DerivationPolicy != nil {
+				if via, ok := a.logicRefersTo(*attr.DerivationPolicy, &class); ok {
+					a.impact.Logic = append(a.impact.Logic, Dependent{Kind: "derivation", Key: attr.DerivationPolicy.Key.String(), Name: attr.Name, Parent: class.Name, Detail: attr.DerivationPolicy.Spec.Specification, Via: via})
+				}
+			}
+			a.logics("invariant", attr.Name, attr.Invariants, &class)
+		}
+		for _, key := range identity.SortedKeys(class.Guards) {
+			guard := class.Guards[key]
+			if via, ok := a.logicRefersTo(guard.Logic, &class); ok {
+				a.impact.Logic = append(a.impact.Logic, Dependent{Kind: "guard", Key: key.String(), Name: guard.Name, Parent: class.Name, Detail: guard.Logic.Spec.Specification, Via: via})
+				a.own(key.String(), via)
+			}
+		}
+		for _, key := range identity.SortedKeys(class.Actions) {
+			action := class.Actions[key]
+			for _, logics := range []struct {
+				kind   string
+				logics []model_logic.Logic
+			}{{"requires", action.Requires}, {"guarantee", action.Guarantees}, {"safety rule", action.SafetyRules}} {
+				if via, ok := a.logics(logics.kind, action.Name, logics.logics, &class); ok {
+					a.own(key.String(), via)
+				}
+			}
+			for _, parameter := range action.Parameters {
+				a.logics("invariant", parameter.Name, parameter.Invariants, &class)
+			}
+		}
+		for _, key := range identity.SortedKeys(class.Queries) {
+			query := class.Queries[key]
+			for _, logics := range []struct {
+				kind   string
+				logics []model_logic.Logic
+			}{{"requires", query.Requires}, {"guarantee", query.Guarantees}} {
+				if via, ok := a.logics(logics.kind, query.Name, logics.logics, &class); ok {
+					a.own(key.String(), via)
+				}
+			}
+			for _, parameter := range query.Parameters {
+				a.logics("invariant", parameter.Name, parameter.Invariants, &class)
+			}
+		}
+	})
+
+	for _, key := range identity.SortedKeys(a.associations) {
+		association := a.associations[key]
+		var from *model_class.Class
+		if class, ok := a.classes[association.FromClassKey]; ok {
+			from = &class
+		}
+		a.logics("invariant", association.Name, association.Invariants, from)
+	}
+}
+
+// logics adds the logic entries of a kind that refer to a target, reporting whether any
+// did and the via of the first.
+func (a *analyzer) logics(kind, parent string, logics []model_logic.Logic, class *model_class.Class) (via string, found bool) {
+	for _, logic := range logics {
+		v, ok := a.logicRefersTo(logic, class)
+		if !ok {
+			continue
+		}
+		if !found {
+			via, found = v, true
+		}
+		a.impact.Logic = append(a.impact.Logic, Dependent{Kind: kind, Key: logic.Key.String(), Name: logicName(logic), Parent: parent, Detail: logic.Spec.Specification, Via: v})
+	}
+	return via, found
+}
+
+// own records a guard, action or query whose logic depends on a target.
+func (a *analyzer) own(key, via string) {
+	if _, ok := a.owners[key]; !ok {
+		a.owners[key] = via
+	}
+}
+
+// transitions adds the transitions with a state, event, guard or action that is a target
+// or depends on one. Their events reach the target.
+func (a *analyzer) transitions() {
+	a.eachClass(func(class model_class.Class) {
+		for _, key := range identity.SortedKeys(class.Events) {
+			if via, ok := a.match(key.String()); ok {
+				a.reach(key.String(), via)
+			}
+		}
+		for _, key := range identity.SortedKeys(class.Transitions) {
+			transition := class.Transitions[key]
+			var via string
+			var ok bool
+			for _, ref := range []*identity.Key{transition.FromStateKey, &transition.EventKey, transition.GuardKey, transition.ActionKey, transition.ToStateKey} {
+				if ref == nil {
+					continue
+				}
+				v, found := a.match(ref.String())
+				if !found {
+					v, found = a.owners[ref.String()]
+				}
+				if found && (!ok || v == "") {
+					via, ok = v, true
+				}
+			}
+			if !ok {
+				continue
+			}
+			a.impact.Transitions = append(a.impact.Transitions, Dependent{Kind: "transition", Key: key.String(), Name: a.catalog.name(key.String()), Parent: class.Name, Via: via})
+			a.reach(transition.EventKey.String(), via)
+		}
+	})
+}
+
+// reach records an event whose transitions depend on a target.
+func (a *analyzer) reach(eventKey, via string) {
+	if _, ok := a.reached[eventKey]; !ok {
+		a.reached[eventKey] = via
+	}
+}
+
+// uniqueness adds the associations with a uniqueness tuple that has a target in it.
+func (a *analyzer) uniqueness() {
+	for _, key := range identity.SortedKeys(a.associations) {
+		association := a.associations[key]
+		if association.Uniqueness == nil {
+			continue
+		}
+		keys := slices.Concat(association.Uniqueness.FromAttributeKeys, association.Uniqueness.ToAttributeKeys)
+		if via, ok := a.matchAny(keys); ok {
+			detail := a.catalog.names(association.Uniqueness.FromAttributeKeys) + " → " + a.catalog.names(association.Uniqueness.ToAttributeKeys)
+			a.impact.Uniqueness = append(a.impact.Uniqueness, Dependent{Kind: "uniqueness", Key: key.String(), Name: association.Name, Detail: detail, Via: via})
+		}
+	}
+}
+
+// indexes adds the indexes of each class that have a target in them.
+func (a *analyzer) indexes() {
+	a.eachClass(func(class model_class.Class) {
+		byIndex := map[uint][]identity.Key{}
+		for _, attr := range class.Attributes {
+			for _, index := range attr.IndexNums {
+				byIndex[index] = append(byIndex[index], attr.Key)
+			}
+		}
+		for _, index := range slices.Sorted(maps.Keys(byIndex)) {
+			if via, ok := a.matchAny(byIndex[index]); ok {
+				a.impact.Indexes = append(a.impact.Indexes, Dependent{Kind: "index", Key: class.Key.String(), Name: fmt.Sprintf("Index %d", index), Parent: class.Name, Detail: a.catalog.names(byIndex[index]), Via: via})
+			}
+		}
+	})
+}
+
+// matchAny reports whether any of the keys is a target or part of one.
+func (a *analyzer) matchAny(keys []identity.Key) (via string, ok bool) {
+	for _, key := range keys {
+		if v, found := a.match(key.String()); found && (!ok || v == "") {
+			via, ok = v, true
+		}
+	}
+	return via, ok
+}
+
+// selfFields maps the names self.field may use in a class's logic to its attributes and
+// outgoing associations. Attributes are named as written or by their key.
+func selfFields(class model_class.Class, associations map[identity.Key]model_class.Association) map[string]identity.Key {
+	fields := map[string]identity.Key{}
+	for _, attr := range class.Attributes {
+		fields[attr.Key.SubKey] = attr.Key
+		fields[attr.Name] = attr.Key
+	}
+	for key, association := range associations {
+		if association.FromClassKey == class.Key {
+			fields[model_class.AssociationTLAFieldName(association.Name)] = key
+		}
+	}
+	return fields
+}
+
+// eachClass calls fn with each class of the model, in key order.
+func (a *analyzer) eachClass(fn func(class model_class.Class)) {
+	for _, domainKey := range identity.SortedKeys(a.model.Domains) {
+		domain := a.model.Domains[domainKey]
+		for _, subdomainKey := range identity.SortedKeys(domain.Subdomains) {
+			subdomain := domain.Subdomains[subdomainKey]
+			for _, classKey := range identity.SortedKeys(subdomain.Classes) {
+				fn(subdomain.Classes[classKey])
+			}
+		}
+	}
+}
+
+// logicName names a logic entry by its description, or its target or specification
+// when it has none.
+func logicName(logic model_logic.Logic) string {
+	switch {
+	case logic.Description != "":
+		return logic.Description
+	case logic.Target != "":
+		return logic.Target
+	default:
+		return logic.Spec.Specification
+	}
+}
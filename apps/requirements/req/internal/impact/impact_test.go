@@ -0,0 +1,140 @@
+package impact
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// loweredLogic is a logic entry with an already lowered specification.
+func loweredLogic(key identity.Key, description, specification string, expr me.Expression) model_logic.Logic {
+	return model_logic.Logic{Key: key, Type: model_logic.LogicTypeAssessment, Description: description,
+		Spec: logic_spec.ExpressionSpec{Notation: "tla_plus", Specification: specification, Expression: expr}}
+}
+
+// names lists the names of dependents, with what they are through.
+func names(dependents []Dependent) []string {
+	var list []string
+	for _, dependent := range dependents {
+		name := dependent.Name
+		if dependent.Via != "" {
+			name += " through " + dependent.Via
+		}
+		list = append(list, name)
+	}
+	return list
+}
+
+func TestAnalyzeAttribute(t *testing.T) {
+	model := test_helper.GetTestModel()
+	var totalKey identity.Key
+	test_helper.EditClass(t, model, "Order", func(class *model_class.Class) {
+		for _, attr := range class.Attributes {
+			if attr.Name == "Total" {
+				totalKey = attr.Key
+			}
+		}
+
+		// Tax is derived from the total, and the grand total from the tax.
+		taxKey := helper.Must(identity.NewAttributeKey(class.Key, "tax"))
+		tax := helper.Must(model_class.NewAttribute(taxKey, model_class.AttributeDetails{Name: "Tax"}, "", nil, false, model_class.AttributeAnnotations{}))
+		taxDerivation := loweredLogic(helper.Must(identity.NewAttributeDerivationKey(taxKey, "0")), "Tax on the total", "self.total * 2", &me.FieldAccess{Base: &me.SelfRef{}, Field: "total"})
+		tax.DerivationPolicy = &taxDerivation
+
+		grandKey := helper.Must(identity.NewAttributeKey(class.Key, "grand_total"))
+		grand := helper.Must(model_class.NewAttribute(grandKey, model_class.AttributeDetails{Name: "Grand Total"}, "", nil, false, model_class.AttributeAnnotations{}))
+		grandDerivation := loweredLogic(helper.Must(identity.NewAttributeDerivationKey(grandKey, "0")), "Total with tax", "tax", &me.AttributeRef{AttributeKey: taxKey})
+		grand.DerivationPolicy = &grandDerivation
+		class.Attributes = append(class.Attributes, tax, grand)
+
+		class.Invariants = append(class.Invariants, loweredLogic(helper.Must(identity.NewClassInvariantKey(class.Key, "9")), "Grand total is capped", "grand_total < 100", &me.AttributeRef{AttributeKey: grandKey}))
+	})
+
+	impact, err := Analyze(model, totalKey)
+	require.NoError(t, err)
+	assert.Equal(t, "attribute", impact.Element)
+	assert.Equal(t, "Total", impact.Name)
+	assert.Equal(t, "Order", impact.Parent)
+	assert.Equal(t, []string{"Tax", "Grand Total through Tax"}, names(impact.Derived))
+	assert.Equal(t, []string{
+		"Grand total is capped through Grand Total",
+		"Total must be non-negative",
+		"Total must not exceed one million",
+		"Total must be a multiple of the cent",
+		"Tax",
+		"Grand Total through Tax",
+	}, names(impact.Logic))
+	assert.Equal(t, []string{"Index 1", "Index 2"}, names(impact.Indexes))
+	assert.Empty(t, impact.Transitions)
+
+	md := Markdown(impact, 1)
+	assert.Contains(t, md, "# Impact of attribute **Total** of **Order**\n\n`"+totalKey.String()+"`\n\n10 dependents.\n")
+	assert.Contains(t, md, "\n## Derived attributes\n\n- **Tax** of **Order**\n- **Grand Total** of **Order**, through **Tax**\n")
+	assert.Contains(t, md, "- invariant **Grand total is capped** of **Order**: `grand_total < 100`, through **Grand Total**\n")
+	assert.Contains(t, md, "\n## Indexes\n\n- **Index 1** of **Order**: Total\n")
+}
+
+func TestAnalyzeEvent(t *testing.T) {
+	model := test_helper.GetTestModel()
+	var submitKey identity.Key
+	test_helper.EditClass(t, model, "Order", func(class *model_class.Class) {
+		for key, event := range class.Events {
+			if event.Name == "Submit" {
+				submitKey = key
+			}
+		}
+		// Shipping an order, on Fulfill, submits it again.
+		for key, action := range class.Actions {
+			if action.Name == "Ship Order" {
+				action.Guarantees = append(action.Guarantees, loweredLogic(helper.Must(identity.NewActionGuaranteeKey(key, "9")), "Resubmit", "Submit()", &me.EventCall{EventKey: submitKey}))
+				class.Actions[key] = action
+			}
+		}
+	})
+
+	impact, err := Analyze(model, submitKey)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Resubmit"}, names(impact.Logic))
+	assert.Equal(t, []string{
+		"New → Submit [has_items] / Process Order → Processing",
+		"Processing → Fulfill / Ship Order → Complete",
+	}, names(impact.Transitions))
+	assert.Equal(t, []string{"Step 1", "Step 4.1.1"}, names(impact.Steps))
+	assert.Equal(t, "Alice:Customer → 42:Order: Customer submits order (Submit)", impact.Steps[0].Detail)
+	assert.Equal(t, []string{"Order.Fulfill → Order.Submit"}, names(impact.PeerEventChains))
+}
+
+func TestAnalyzeClass(t *testing.T) {
+	model := test_helper.GetTestModel()
+	var orderKey identity.Key
+	test_helper.EditClass(t, model, "Order", func(class *model_class.Class) {
+		orderKey = class.Key
+	})
+
+	impact, err := Analyze(model, orderKey)
+	require.NoError(t, err)
+	assert.Equal(t, "class", impact.Element)
+	assert.Len(t, impact.Transitions, 4)
+	assert.Equal(t, []string{"order has shipment"}, names(impact.Uniqueness))
+	assert.Equal(t, "Order Date → Tracking ID", impact.Uniqueness[0].Detail)
+	assert.Contains(t, names(impact.Steps), "Step 4.2.2")
+}
+
+func TestAnalyzeUnknownKey(t *testing.T) {
+	model := test_helper.GetTestModel()
+	_, err := Analyze(model, helper.Must(identity.NewDomainKey("missing")))
+	assert.ErrorContains(t, err, `no element "domain/missing"`)
+}
+
+func TestMarkdownNothing(t *testing.T) {
+	assert.Equal(t, "### Impact of event **Cancel** of **Order**\n\n`domain/a/subdomain/b/class/order/event/cancel`\n\nNothing depends on it.\n",
+		Markdown(Impact{Key: "domain/a/subdomain/b/class/order/event/cancel", Element: "event", Name: "Cancel", Parent: "Order"}, 3))
+}
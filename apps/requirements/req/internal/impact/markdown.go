@@ -0,0 +1,78 @@
+package impact
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Markdown renders an impact as markdown, its title at a heading level: 1 for a page of
+// its own, deeper to place it within another page.
+func Markdown(impact Impact, level int) string {
+	heading := strings.Repeat("#", level)
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s Impact of %s **%s**", heading, impact.Element, impact.Name)
+	if impact.Parent != "" {
+		fmt.Fprintf(&b, " of **%s**", impact.Parent)
+	}
+	fmt.Fprintf(&b, "\n\n`%s`\n\n", impact.Key)
+
+	switch count := impact.Count(); count {
+	case 0:
+		b.WriteString("Nothing depends on it.\n")
+		return b.String()
+	case 1:
+		b.WriteString("1 dependent.\n")
+	default:
+		fmt.Fprintf(&b, "%d dependents.\n", count)
+	}
+
+	sections := []struct {
+		title      string
+		dependents []Dependent
+		kinds      bool // Whether the section has several kinds of dependent.
+		code       bool // Whether the details are specifications.
+	}{
+		{"Derived attributes", impact.Derived, false, false},
+		{"Logic", impact.Logic, true, true},
+		{"Transitions", impact.Transitions, false, false},
+		{"Scenario steps", impact.Steps, false, false},
+		{"Association uniqueness", impact.Uniqueness, false, false},
+		{"Indexes", impact.Indexes, false, false},
+		{"Peer event chains", impact.PeerEventChains, false, false},
+	}
+	for _, section := range sections {
+		if len(section.dependents) == 0 {
+			continue
+		}
+		fmt.Fprintf(&b, "\n%s# %s\n\n", heading, section.title)
+		for _, dependent := range section.dependents {
+			b.WriteString("- ")
+			if section.kinds {
+				b.WriteString(dependent.Kind + " ")
+			}
+			fmt.Fprintf(&b, "**%s**", dependent.Name)
+			if dependent.Parent != "" {
+				fmt.Fprintf(&b, " of **%s**", dependent.Parent)
+			}
+			if detail := strings.Join(strings.Fields(dependent.Detail), " "); detail != "" {
+				if section.code {
+					detail = codeSpan(detail)
+				}
+				b.WriteString(": " + detail)
+			}
+			if dependent.Via != "" {
+				fmt.Fprintf(&b, ", through **%s**", dependent.Via)
+			}
+			b.WriteString("\n")
+		}
+	}
+	return b.String()
+}
+
+// codeSpan shows a specification as code.
+func codeSpan(value string) string {
+	if strings.Contains(value, "`") {
+		return "`` " + value + " ``"
+	}
+	return "`" + value + "`"
+}
@@ -0,0 +1,470 @@
+package modeldiff
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_data_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+)
+
+// element is a part of a model with the fields it is compared by.
+type element struct {
+	element    string // What the element is, such as class or attribute.
+	key        string
+	name       string
+	parent     string // The key of the element this is part of, if any.
+	parentName string
+	domain     string // The key of the domain the element is in, if any.
+	subdomain  string // The key of the subdomain the element is in, if any.
+	fields     []field
+}
+
+// field is a named value of an element. Values refer to other elements by name.
+type field struct {
+	name  string
+	value string
+}
+
+// flattener gathers the elements of a model.
+type flattener struct {
+	names     map[string]string // Element key to name, for fields that refer to other elements.
+	domain    string            // The domain of the elements being added.
+	subdomain string            // The subdomain of the elements being added.
+	elements  []element
+}
+
+// flatten lists the elements of a model, each after the element it is part of.
+func flatten(model core.Model) []element {
+	f := &flattener{names: elementNames(model)}
+
+	f.add("model", "model", model.Name, "", field{"details", model.Details})
+	f.logics("invariant", "", model.Invariants)
+	for _, key := range identity.SortedKeys(model.GlobalFunctions) {
+		function := model.GlobalFunctions[key]
+		f.add("global function", key.String(), function.Name, "",
+			append([]field{{"parameters", strings.Join(function.Parameters, ", ")}, {"recursive", strconv.FormatBool(function.Recursive)}}, logicFields(function.Logic)...)...)
+	}
+	for _, key := range identity.SortedKeys(model.NamedSets) {
+		set := model.NamedSets[key]
+		f.add("named set", key.String(), set.Name, "",
+			field{"description", set.Description}, field{"specification", normalizedSpecification(set.Spec)}, field{"type", typeSpecification(set.TypeSpec)})
+	}
+	for _, key := range identity.SortedKeys(model.Actors) {
+		actor := model.Actors[key]
+		f.add("actor", key.String(), actor.Name, "",
+			field{"type", actor.Type}, field{"details", actor.Details}, field{"superclass of", f.name(actor.SuperclassOfKey)}, field{"subclass of", f.name(actor.SubclassOfKey)})
+	}
+	for _, key := range identity.SortedKeys(model.ActorGeneralizations) {
+		generalization := model.ActorGeneralizations[key]
+		f.generalization("actor generalization", key, generalization.Name, generalization.Details, generalization.IsComplete, generalization.IsStatic)
+	}
+	for _, key := range identity.SortedKeys(model.DomainAssociations) {
+		association := model.DomainAssociations[key]
+		f.add("domain association", key.String(), f.names[association.ProblemDomainKey.String()]+" → "+f.names[association.SolutionDomainKey.String()], "",
+			field{"problem", f.names[association.ProblemDomainKey.String()]}, field{"solution", f.names[association.SolutionDomainKey.String()]})
+	}
+	f.associations(model.ClassAssociations)
+
+	for _, key := range identity.SortedKeys(model.Domains) {
+		f.domainElements(model.Domains[key])
+	}
+	return f.elements
+}
+
+func (f *flattener) domainElements(domain model_domain.Domain) {
+	f.domain, f.subdomain = "", ""
+	f.add("domain", domain.Key.String(), domain.Name, "",
+		field{"details", domain.Details}, field{"realized", strconv.FormatBool(domain.Realized)})
+
+	f.domain = domain.Key.String()
+	for _, key := range identity.SortedKeys(domain.SubdomainAssociations) {
+		association := domain.SubdomainAssociations[key]
+		problem, solution := f.names[association.ProblemSubdomainKey.String()], f.names[association.SolutionSubdomainKey.String()]
+		f.add("subdomain association", key.String(), problem+" → "+solution, "", field{"problem", problem}, field{"solution", solution})
+	}
+	f.associations(domain.ClassAssociations)
+
+	for _, key := range identity.SortedKeys(domain.Subdomains) {
+		subdomain := domain.Subdomains[key]
+		f.subdomain = ""
+		f.add("subdomain", key.String(), subdomain.Name, "", field{"details", subdomain.Details})
+
+		f.subdomain = key.String()
+		for _, key := range identity.SortedKeys(subdomain.Generalizations) {
+			generalization := subdomain.Generalizations[key]
+			f.generalization("generalization", key, generalization.Name, generalization.Details, generalization.IsComplete, generalization.IsStatic)
+		}
+		for _, key := range identity.SortedKeys(subdomain.Classes) {
+			f.class(subdomain.Classes[key])
+		}
+		f.associations(subdomain.ClassAssociations)
+		for _, key := range identity.SortedKeys(subdomain.UseCaseGeneralizations) {
+			generalization := subdomain.UseCaseGeneralizations[key]
+			f.generalization("use case generalization", key, generalization.Name, generalization.Details, generalization.IsComplete, generalization.IsStatic)
+		}
+		for _, key := range identity.SortedKeys(subdomain.UseCases) {
+			f.useCase(subdomain, subdomain.UseCases[key])
+		}
+	}
+}
+
+func (f *flattener) class(class model_class.Class) {
+	classKey := class.Key.String()
+	f.add("class", classKey, class.Name, "",
+		field{"details", class.Details}, field{"actor", f.name(class.ActorKey)},
+		field{"superclass of", f.name(class.SuperclassOfKey)}, field{"subclass of", f.name(class.SubclassOfKey)})
+	f.logics("invariant", classKey, class.Invariants)
+
+	for _, attr := range class.Attributes {
+		indexes := make([]string, 0, len(attr.IndexNums))
+		for _, index := range attr.IndexNums {
+			indexes = append(indexes, strconv.FormatUint(uint64(index), 10))
+		}
+		f.add("attribute", attr.Key.String(), attr.Name, classKey,
+			field{"details", attr.Details}, field{"data type", dataType(attr.DataTypeRules, attr.DataType)},
+			field{"nullable", strconv.FormatBool(attr.Nullable)}, field{"indexes", strings.Join(indexes, ", ")})
+		if attr.DerivationPolicy != nil {
+			f.logics("derivation", attr.Key.String(), []model_logic.Logic{*attr.DerivationPolicy})
+		}
+		f.logics("invariant", attr.Key.String(), attr.Invariants)
+	}
+
+	for _, key := range identity.SortedKeys(class.States) {
+		state := class.States[key]
+		var actions []string
+		for _, stateAction := range state.Actions {
+			actions = append(actions, stateAction.When+" / "+f.names[stateAction.ActionKey.String()])
+		}
+		f.add("state", key.String(), state.Name, classKey, field{"details", state.Details}, field{"actions", strings.Join(actions, ", ")})
+	}
+	for _, key := range identity.SortedKeys(class.Events) {
+		event := class.Events[key]
+		f.add("event", key.String(), event.Name, classKey, field{"details", event.Details}, field{"parameters", strings.Join(event.ParameterNames, ", ")})
+	}
+	for _, key := range identity.SortedKeys(class.Guards) {
+		guard := class.Guards[key]
+		f.add("guard", key.String(), guard.Name, classKey, logicFields(guard.Logic)...)
+	}
+	for _, key := range identity.SortedKeys(class.Actions) {
+		action := class.Actions[key]
+		f.add("action", key.String(), action.Name, classKey, field{"details", action.Details})
+		f.parameters(key.String(), action.Parameters)
+		f.logics("requires", key.String(), action.Requires)
+		f.logics("guarantee", key.String(), action.Guarantees)
+		f.logics("safety rule", key.String(), action.SafetyRules)
+	}
+	for _, key := range identity.SortedKeys(class.Queries) {
+		query := class.Queries[key]
+		f.add("query", key.String(), query.Name, classKey, field{"details", query.Details})
+		f.parameters(key.String(), query.Parameters)
+		f.logics("requires", key.String(), query.Requires)
+		f.logics("guarantee", key.String(), query.Guarantees)
+	}
+	for _, key := range identity.SortedKeys(class.Transitions) {
+		f.transition(classKey, class.Transitions[key])
+	}
+}
+
+func (f *flattener) transition(classKey string, transition model_state.Transition) {
+	from, to := f.name(transition.FromStateKey), f.name(transition.ToStateKey)
+	name := f.names[transition.EventKey.String()]
+	if guard := f.name(transition.GuardKey); guard != "" {
+		name += " [" + guard + "]"
+	}
+	if action := f.name(transition.ActionKey); action != "" {
+		name += " / " + action
+	}
+	f.add("transition", transition.Key.String(), stateName(from, "initial")+" → "+name+" → "+stateName(to, "final"), classKey,
+		field{"from", from}, field{"event", f.names[transition.EventKey.String()]}, field{"guard", f.name(transition.GuardKey)},
+		field{"action", f.name(transition.ActionKey)}, field{"to", to})
+}
+
+// stateName is the name of a state a transition is from or to, or the pseudostate when
+// it has none.
+func stateName(name, pseudostate string) string {
+	if name == "" {
+		return "(" + pseudostate + ")"
+	}
+	return name
+}
+
+func (f *flattener) parameters(parentKey string, parameters []model_state.Parameter) {
+	for _, parameter := range parameters {
+		f.add("parameter", parameter.Key.String(), parameter.Name, parentKey,
+			field{"data type", dataType(parameter.DataTypeRules, parameter.DataType)}, field{"nullable", strconv.FormatBool(parameter.Nullable)})
+		f.logics("invariant", parameter.Key.String(), parameter.Invariants)
+	}
+}
+
+func (f *flattener) associations(associations map[identity.Key]model_class.Association) {
+	for _, key := range identity.SortedKeys(associations) {
+		association := associations[key]
+		var uniqueness string
+		if association.Uniqueness != nil {
+			uniqueness = f.keyNames(association.Uniqueness.FromAttributeKeys) + " → " + f.keyNames(association.Uniqueness.ToAttributeKeys)
+		}
+		f.add("association", key.String(), association.Name, "",
+			field{"details", association.Details},
+			field{"from", f.names[association.FromClassKey.String()]}, field{"from multiplicity", association.FromMultiplicity.String()},
+			field{"to", f.names[association.ToClassKey.String()]}, field{"to multiplicity", association.ToMultiplicity.String()},
+			field{"association class", f.name(association.AssociationClassKey)}, field{"uniqueness", uniqueness})
+		f.logics("invariant", key.String(), association.Invariants)
+	}
+}
+
+func (f *flattener) generalization(kind string, key identity.Key, name, details string, isComplete, isStatic bool) {
+	f.add(kind, key.String(), name, "",
+		field{"details", details}, field{"complete", strconv.FormatBool(isComplete)}, field{"static", strconv.FormatBool(isStatic)})
+}
+
+func (f *flattener) useCase(subdomain model_domain.Subdomain, useCase model_use_case.UseCase) {
+	useCaseKey := useCase.Key.String()
+	actors := make([]string, 0, len(useCase.Actors))
+	for _, key := range identity.SortedKeys(useCase.Actors) {
+		actors = append(actors, f.names[key.String()])
+	}
+	var includes []string
+	for _, key := range identity.SortedKeys(subdomain.UseCaseShares[useCase.Key]) {
+		includes = append(includes, subdomain.UseCaseShares[useCase.Key][key].ShareType+" "+f.names[key.String()])
+	}
+	f.add("use case", useCaseKey, useCase.Name, "",
+		field{"details", useCase.Details}, field{"level", useCase.Level}, field{"read only", strconv.FormatBool(useCase.ReadOnly)},
+		field{"actors", strings.Join(actors, ", ")}, field{"includes", strings.Join(includes, ", ")},
+		field{"superclass of", f.name(useCase.SuperclassOfKey)}, field{"subclass of", f.name(useCase.SubclassOfKey)})
+
+	for _, key := range identity.SortedKeys(useCase.Scenarios) {
+		scenario := useCase.Scenarios[key]
+		f.add("scenario", key.String(), scenario.Name, useCaseKey,
+			field{"details", scenario.Details}, field{"steps", f.steps(scenario.Steps)})
+		for _, objectKey := range identity.SortedKeys(scenario.Objects) {
+			object := scenario.Objects[objectKey]
+			f.add("object", objectKey.String(), f.names[objectKey.String()], key.String(),
+				field{"class", f.names[object.ClassKey.String()]}, field{"multi", strconv.FormatBool(object.Multi)})
+		}
+	}
+}
+
+// steps lists the steps of a scenario, a line each numbered by its place in the
+// scenario. Steps are compared as a whole, since they are keyed by their position.
+func (f *flattener) steps(root *model_scenario.Step) string {
+	if root == nil {
+		return ""
+	}
+	var lines []string
+	var walk func(statements []model_scenario.Step, prefix string)
+	walk = func(statements []model_scenario.Step, prefix string) {
+		for i, step := range statements {
+			position := prefix + strconv.Itoa(i+1)
+			lines = append(lines, position+". "+f.step(step))
+			walk(step.Statements, position+".")
+		}
+	}
+	walk(root.Statements, "")
+	return strings.Join(lines, "\n")
+}
+
+// step describes one step of a scenario, without the steps it holds.
+func (f *flattener) step(step model_scenario.Step) string {
+	if step.StepType != model_scenario.STEP_TYPE_LEAF {
+		return strings.TrimSpace(step.StepType + " " + step.Condition)
+	}
+	text := f.name(step.FromObjectKey) + " → " + f.name(step.ToObjectKey) + ": " + step.Description
+	for _, ref := range []*identity.Key{step.EventKey, step.QueryKey, step.ScenarioKey} {
+		if name := f.name(ref); name != "" {
+			text += " (" + name + ")"
+		}
+	}
+	if step.LeafType != nil && *step.LeafType == model_scenario.LEAF_TYPE_DESTROY {
+		text += " (destroy)"
+	}
+	return text
+}
+
+// logics adds logic entries of a kind, such as invariant or guarantee.
+func (f *flattener) logics(kind, parentKey string, logics []model_logic.Logic) {
+	for _, logic := range logics {
+		name := logic.Description
+		if name == "" {
+			name = logic.Target
+		}
+		if name == "" {
+			name = normalizedSpecification(logic.Spec)
+		}
+		f.add(kind, logic.Key.String(), name, parentKey, logicFields(logic)...)
+	}
+}
+
+// logicFields are the fields of a logic entry, with its specifications normalized.
+func logicFields(logic model_logic.Logic) []field {
+	return []field{
+		{"type", logic.Type},
+		{"description", logic.Description},
+		{"target", logic.Target},
+		{"specification", normalizedSpecification(logic.Spec)},
+		{"destroy event", normalizedSpecification(logic.DestroyEventSpec)},
+		{"endpoint selector", normalizedSpecification(logic.EndpointSelectorSpec)},
+		{"target type", typeSpecification(logic.TargetTypeSpec)},
+	}
+}
+
+// normalizedSpecification is a specification printed as TLA+ from its parsed form, so
+// its layout and notation don't matter. A specification that does not parse is compared
+// as written, apart from its whitespace.
+func normalizedSpecification(spec logic_spec.ExpressionSpec) string {
+	text := strings.TrimSpace(spec.Specification)
+	if text == "" {
+		return ""
+	}
+	expr, err := convert.ParseNotation(spec.Notation, text)
+	if err != nil {
+		return strings.Join(strings.Fields(text), " ")
+	}
+	return ast.PrintNormalized(expr)
+}
+
+func typeSpecification(spec *logic_spec.TypeSpec) string {
+	if spec == nil {
+		return ""
+	}
+	return strings.Join(strings.Fields(spec.Specification), " ")
+}
+
+// dataType is a data type as parsed from its rules, or the rules as written when they
+// do not parse.
+func dataType(rules string, parsed *model_data_type.DataType) string {
+	if parsed != nil {
+		return parsed.String()
+	}
+	return strings.TrimSpace(rules)
+}
+
+// add adds an element in the current domain and subdomain.
+func (f *flattener) add(kind, key, name, parentKey string, fields ...field) {
+	f.elements = append(f.elements, element{
+		element:    kind,
+		key:        key,
+		name:       name,
+		parent:     parentKey,
+		parentName: f.names[parentKey],
+		domain:     f.domain,
+		subdomain:  f.subdomain,
+		fields:     fields,
+	})
+}
+
+// name is the name of an optional reference to another element.
+func (f *flattener) name(key *identity.Key) string {
+	if key == nil {
+		return ""
+	}
+	return f.names[key.String()]
+}
+
+// keyNames are the names of references to other elements.
+func (f *flattener) keyNames(keys []identity.Key) string {
+	names := make([]string, 0, len(keys))
+	for _, key := range keys {
+		names = append(names, f.names[key.String()])
+	}
+	return strings.Join(names, ", ")
+}
+
+// elementNames maps the key of each element that others refer to, or that has parts, to
+// its name.
+func elementNames(model core.Model) map[string]string {
+	names := map[string]string{}
+	for key, actor := range model.Actors {
+		names[key.String()] = actor.Name
+	}
+	for key, generalization := range model.ActorGeneralizations {
+		names[key.String()] = generalization.Name
+	}
+	for key, function := range model.GlobalFunctions {
+		names[key.String()] = function.Name
+	}
+	for key, association := range model.ClassAssociations {
+		names[key.String()] = association.Name
+	}
+	for key, domain := range model.Domains {
+		names[key.String()] = domain.Name
+		for key, association := range domain.ClassAssociations {
+			names[key.String()] = association.Name
+		}
+		for key, subdomain := range domain.Subdomains {
+			names[key.String()] = subdomain.Name
+			for key, association := range subdomain.ClassAssociations {
+				names[key.String()] = association.Name
+			}
+			for key, generalization := range subdomain.Generalizations {
+				names[key.String()] = generalization.Name
+			}
+			for key, generalization := range subdomain.UseCaseGeneralizations {
+				names[key.String()] = generalization.Name
+			}
+			for key, class := range subdomain.Classes {
+				addClassNames(names, key, class)
+			}
+			for key, useCase := range subdomain.UseCases {
+				names[key.String()] = useCase.Name
+				for key, scenario := range useCase.Scenarios {
+					names[key.String()] = scenario.Name
+					for key, object := range scenario.Objects {
+						names[key.String()] = object.Name + ":" + className(model, object.ClassKey)
+					}
+				}
+			}
+		}
+	}
+	return names
+}
+
+func addClassNames(names map[string]string, key identity.Key, class model_class.Class) {
+	names[key.String()] = class.Name
+	for _, attr := range class.Attributes {
+		names[attr.Key.String()] = attr.Name
+	}
+	for key, state := range class.States {
+		names[key.String()] = state.Name
+	}
+	for key, event := range class.Events {
+		names[key.String()] = event.Name
+	}
+	for key, guard := range class.Guards {
+		names[key.String()] = guard.Name
+	}
+	for key, action := range class.Actions {
+		names[key.String()] = action.Name
+		for _, parameter := range action.Parameters {
+			names[parameter.Key.String()] = parameter.Name
+		}
+	}
+	for key, query := range class.Queries {
+		names[key.String()] = query.Name
+		for _, parameter := range query.Parameters {
+			names[parameter.Key.String()] = parameter.Name
+		}
+	}
+}
+
+// className is the name of a class, wherever in the model it is.
+func className(model core.Model, classKey identity.Key) string {
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			if class, ok := subdomain.Classes[classKey]; ok {
+				return class.Name
+			}
+		}
+	}
+	return ""
+}
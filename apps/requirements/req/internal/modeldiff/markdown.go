@@ -0,0 +1,129 @@
+package modeldiff
+
+import (
+	"fmt"
+	"strings"
+)
+
+// _kindLabels begin the line of each kind of change.
+var _kindLabels = map[string]string{
+	KindAdded:   "Added",
+	KindRemoved: "Removed",
+	KindChanged: "Changed",
+}
+
+// Markdown renders a diff as markdown for a pull request: a count of the changes, then
+// the changes to the model and in each domain and subdomain. Changed fields show their
+// values before and after, and fields of several lines, such as details and scenario
+// steps, show the lines removed and added.
+func Markdown(diff Diff) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# Model changes — %s\n\n", diff.Head)
+	added, removed, changed := diff.Count()
+	if added+removed+changed == 0 {
+		b.WriteString("No changes.\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, "%d added, %d removed, %d changed.\n", added, removed, changed)
+
+	if len(diff.Changes) > 0 {
+		b.WriteString("\n## Model\n")
+		writeChanges(&b, diff.Changes)
+	}
+	for _, domain := range diff.Domains {
+		fmt.Fprintf(&b, "\n## %s\n", domain.Name)
+		writeChanges(&b, domain.Changes)
+		for _, subdomain := range domain.Subdomains {
+			fmt.Fprintf(&b, "\n### %s\n", subdomain.Name)
+			writeChanges(&b, subdomain.Changes)
+		}
+	}
+	return b.String()
+}
+
+func writeChanges(b *strings.Builder, changes []Change) {
+	if len(changes) == 0 {
+		return
+	}
+	b.WriteString("\n")
+	for _, change := range changes {
+		fmt.Fprintf(b, "- %s %s **%s**", _kindLabels[change.Kind], change.Element, change.Name)
+		if change.Parent != "" {
+			fmt.Fprintf(b, " of **%s**", change.Parent)
+		}
+		if len(change.Fields) == 0 {
+			b.WriteString(".\n")
+			continue
+		}
+		b.WriteString(":\n")
+		for _, f := range change.Fields {
+			if strings.Contains(f.Base, "\n") || strings.Contains(f.Head, "\n") {
+				fmt.Fprintf(b, "  - %s:\n\n    ```diff\n", f.Field)
+				for _, line := range lineDiff(f.Base, f.Head) {
+					b.WriteString("    " + line + "\n")
+				}
+				b.WriteString("    ```\n\n")
+				continue
+			}
+			fmt.Fprintf(b, "  - %s: %s → %s\n", f.Field, codeSpan(f.Base), codeSpan(f.Head))
+		}
+	}
+}
+
+// codeSpan shows a value as code, or as none when it is empty.
+func codeSpan(value string) string {
+	switch {
+	case value == "":
+		return "*none*"
+	case strings.Contains(value, "`"):
+		return "`` " + value + " ``"
+	default:
+		return "`" + value + "`"
+	}
+}
+
+// lineDiff lists the lines of two texts as a unified diff without its headers, marking
+// those only in the first with "-", those only in the second with "+" and those in both
+// with " ".
+func lineDiff(base, head string) []string {
+	var a, b []string
+	if base != "" {
+		a = strings.Split(base, "\n")
+	}
+	if head != "" {
+		b = strings.Split(head, "\n")
+	}
+
+	// common[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	common := make([][]int, len(a)+1)
+	for i := range common {
+		common[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				common[i][j] = common[i+1][j+1] + 1
+			} else {
+				common[i][j] = max(common[i+1][j], common[i][j+1])
+			}
+		}
+	}
+
+	var lines []string
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			lines = append(lines, " "+a[i])
+			i++
+			j++
+		case i < len(a) && (j == len(b) || common[i+1][j] >= common[i][j+1]):
+			lines = append(lines, "-"+a[i])
+			i++
+		default:
+			lines = append(lines, "+"+b[j])
+			j++
+		}
+	}
+	return lines
+}
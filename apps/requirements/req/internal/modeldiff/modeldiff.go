@@ -0,0 +1,219 @@
+// Package modeldiff compares two versions of a model by the identity keys of their elements.
+//
+// Each version is flattened into its elements, each with the fields a reviewer cares
+// about. The elements are domains and subdomains, classes with their attributes, states,
+// events, guards, actions, queries, transitions and logic, associations, use cases with
+// their scenarios, actors, and the model's own logic. Elements with the same key are
+// compared field by field. Logic specifications are compared in their normalized TLA+
+// form, so layout, redundant parentheses and notation do not show as changes. Elements in
+// only one version are added or removed, and the parts of an added or removed element are
+// not listed again. Changes are grouped by the domain and subdomain they are in, and
+// rendered as JSON or as markdown for a pull request.
+package modeldiff
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+)
+
+// The kinds of change.
+const (
+	KindAdded   = "added"
+	KindRemoved = "removed"
+	KindChanged = "changed"
+)
+
+// FieldChange is a field of an element whose value changed.
+type FieldChange struct {
+	Field string `json:"field"`
+	Base  string `json:"base"`
+	Head  string `json:"head"`
+}
+
+// Change is an element added, removed or changed between the versions.
+type Change struct {
+	Kind    string        `json:"kind"`
+	Element string        `json:"element"` // What the element is, such as class, attribute or guarantee.
+	Key     string        `json:"key"`
+	Name    string        `json:"name"`
+	Parent  string        `json:"parent,omitempty"` // The name of the element it is part of, such as the class of an attribute.
+	Fields  []FieldChange `json:"fields,omitempty"` // The fields of a changed element that changed.
+}
+
+// Subdomain holds the changes in one subdomain.
+type Subdomain struct {
+	Key     string   `json:"key"`
+	Name    string   `json:"name"`
+	Changes []Change `json:"changes"`
+}
+
+// Domain holds the changes in one domain: to the domain itself and its associations, then
+// in each subdomain.
+type Domain struct {
+	Key        string      `json:"key"`
+	Name       string      `json:"name"`
+	Changes    []Change    `json:"changes,omitempty"`
+	Subdomains []Subdomain `json:"subdomains,omitempty"`
+}
+
+// Diff holds the changes between two versions of a model: to the model itself, its actors,
+// logic and associations across domains, then in each domain.
+type Diff struct {
+	Base    string   `json:"base"` // The name of the base model.
+	Head    string   `json:"head"` // The name of the head model.
+	Changes []Change `json:"changes,omitempty"`
+	Domains []Domain `json:"domains,omitempty"`
+}
+
+// Count is the number of changes of each kind.
+func (d Diff) Count() (added, removed, changed int) {
+	count := func(changes []Change) {
+		for _, change := range changes {
+			switch change.Kind {
+			case KindAdded:
+				added++
+			case KindRemoved:
+				removed++
+			case KindChanged:
+				changed++
+			}
+		}
+	}
+	count(d.Changes)
+	for _, domain := range d.Domains {
+		count(domain.Changes)
+		for _, subdomain := range domain.Subdomains {
+			count(subdomain.Changes)
+		}
+	}
+	return added, removed, changed
+}
+
+// Compare finds the changes from the base version of a model to the head version. Domains,
+// subdomains and the changes in each are sorted by key, so each element's parts follow it.
+func Compare(base, head core.Model) Diff {
+	diff := Diff{Base: base.Name, Head: head.Name}
+
+	baseElements := flatten(base)
+	headElements := flatten(head)
+	baseByKey := map[string]element{}
+	for _, elem := range baseElements {
+		baseByKey[elem.key] = elem
+	}
+	headByKey := map[string]element{}
+	for _, elem := range headElements {
+		headByKey[elem.key] = elem
+	}
+
+	var changes []locatedChange
+	for _, elem := range headElements {
+		if before, ok := baseByKey[elem.key]; ok {
+			if fields := changedFields(before, elem); len(fields) > 0 {
+				changes = append(changes, locatedChange{elem, Change{Kind: KindChanged, Element: elem.element, Key: elem.key, Name: elem.name, Parent: elem.parentName, Fields: fields}})
+			}
+			continue
+		}
+		changes = append(changes, locatedChange{elem, Change{Kind: KindAdded, Element: elem.element, Key: elem.key, Name: elem.name, Parent: elem.parentName}})
+	}
+	for _, elem := range baseElements {
+		if _, ok := headByKey[elem.key]; !ok {
+			changes = append(changes, locatedChange{elem, Change{Kind: KindRemoved, Element: elem.element, Key: elem.key, Name: elem.name, Parent: elem.parentName}})
+		}
+	}
+	slices.SortFunc(changes, func(a, b locatedChange) int {
+		return strings.Compare(a.change.Key, b.change.Key)
+	})
+
+	// Parts of added and removed elements are in the change of the element itself.
+	whole := map[string]bool{}
+	for _, located := range changes {
+		if located.change.Kind != KindChanged {
+			whole[located.change.Key] = true
+		}
+	}
+	partOfWhole := func(elem element) bool {
+		for parent := elem.parent; parent != ""; {
+			if whole[parent] {
+				return true
+			}
+			if p, ok := headByKey[parent]; ok {
+				parent = p.parent
+			} else {
+				parent = baseByKey[parent].parent
+			}
+		}
+		return false
+	}
+
+	domains := map[string]*Domain{}
+	subdomains := map[string]*Subdomain{}
+	for _, located := range changes {
+		elem, change := located.element, located.change
+		if partOfWhole(elem) {
+			continue
+		}
+
+		if elem.domain == "" {
+			diff.Changes = append(diff.Changes, change)
+			continue
+		}
+		domain, ok := domains[elem.domain]
+		if !ok {
+			diff.Domains = append(diff.Domains, Domain{Key: elem.domain, Name: groupName(elem.domain, baseByKey, headByKey)})
+			domain = &diff.Domains[len(diff.Domains)-1]
+			domains[elem.domain] = domain
+		}
+		if elem.subdomain == "" {
+			domain.Changes = append(domain.Changes, change)
+			continue
+		}
+		subdomain, ok := subdomains[elem.subdomain]
+		if !ok {
+			domain.Subdomains = append(domain.Subdomains, Subdomain{Key: elem.subdomain, Name: groupName(elem.subdomain, baseByKey, headByKey)})
+			subdomain = &domain.Subdomains[len(domain.Subdomains)-1]
+			subdomains[elem.subdomain] = subdomain
+		}
+		subdomain.Changes = append(subdomain.Changes, change)
+	}
+
+	return diff
+}
+
+// locatedChange is a change with the element it is of, to group it by.
+type locatedChange struct {
+	element element
+	change  Change
+}
+
+// groupName is the name of the domain or subdomain a change is grouped in, as it is in
+// the head version unless it was removed.
+func groupName(key string, baseByKey, headByKey map[string]element) string {
+	if elem, ok := headByKey[key]; ok {
+		return elem.name
+	}
+	return baseByKey[key].name
+}
+
+// changedFields are the fields whose values differ between two versions of an element,
+// in the order the element lists them.
+func changedFields(base, head element) (changes []FieldChange) {
+	baseValues := map[string]string{}
+	for _, f := range base.fields {
+		baseValues[f.name] = f.value
+	}
+	seen := map[string]bool{}
+	for _, f := range head.fields {
+		seen[f.name] = true
+		if baseValues[f.name] != f.value {
+			changes = append(changes, FieldChange{Field: f.name, Base: baseValues[f.name], Head: f.value})
+		}
+	}
+	for _, f := range base.fields {
+		if !seen[f.name] && f.value != "" {
+			changes = append(changes, FieldChange{Field: f.name, Base: f.value})
+		}
+	}
+	return changes
+}
@@ -0,0 +1,110 @@
+package modeldiff
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/test_helper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareUnchanged(t *testing.T) {
+	diff := Compare(test_helper.GetTestModel(), test_helper.GetTestModel())
+	assert.Empty(t, diff.Changes)
+	assert.Empty(t, diff.Domains)
+	assert.Equal(t, "# Model changes — Test Model\n\nNo changes.\n", Markdown(diff))
+}
+
+func TestCompare(t *testing.T) {
+	base := test_helper.GetTestModel()
+	head := test_helper.GetTestModel()
+
+	var orderKey identity.Key
+	test_helper.EditClass(t, head, "Order", func(class *model_class.Class) {
+		orderKey = class.Key
+		for i, attr := range class.Attributes {
+			if attr.Name == "Total" {
+				class.Attributes[i].DataTypeRules = "[0 .. 500] at 0.01 dollar"
+				class.Attributes[i].DataType = nil
+			}
+		}
+		for key, event := range class.Events {
+			if event.Name == "Cancel" {
+				delete(class.Events, key)
+			}
+		}
+	})
+
+	// A class added with its attributes is one change.
+	var subdomainKey identity.Key
+	for _, domain := range head.Domains {
+		for key, subdomain := range domain.Subdomains {
+			if _, ok := subdomain.Classes[orderKey]; ok {
+				subdomainKey = key
+				invoiceKey := helper.Must(identity.NewClassKey(key, "invoice"))
+				invoice := model_class.NewClass(invoiceKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Invoice"})
+				invoice.Attributes = []model_class.Attribute{
+					helper.Must(model_class.NewAttribute(helper.Must(identity.NewAttributeKey(invoiceKey, "amount")), model_class.AttributeDetails{Name: "amount"}, "", nil, false, model_class.AttributeAnnotations{})),
+				}
+				subdomain.Classes[invoiceKey] = invoice
+			}
+		}
+	}
+
+	diff := Compare(base, head)
+	require.Len(t, diff.Domains, 1)
+	require.Len(t, diff.Domains[0].Subdomains, 1)
+	subdomain := diff.Domains[0].Subdomains[0]
+	assert.Equal(t, subdomainKey.String(), subdomain.Key)
+
+	byName := map[string]Change{}
+	for _, change := range subdomain.Changes {
+		byName[change.Element+" "+change.Name] = change
+	}
+	assert.Equal(t, KindAdded, byName["class Invoice"].Kind)
+	assert.NotContains(t, byName, "attribute amount")
+	assert.Equal(t, Change{Kind: KindRemoved, Element: "event", Key: byName["event Cancel"].Key, Name: "Cancel", Parent: "Order"}, byName["event Cancel"])
+	assert.Equal(t, []FieldChange{{Field: "data type", Base: byName["attribute Total"].Fields[0].Base, Head: "[0 .. 500] at 0.01 dollar"}}, byName["attribute Total"].Fields)
+
+	added, removed, changed := diff.Count()
+	assert.Equal(t, 1, added)
+	assert.Equal(t, 1, removed)
+	assert.Equal(t, 2, changed) // The attribute, and the scenario that sent the removed event.
+
+	md := Markdown(diff)
+	assert.Contains(t, md, "- Added class **Invoice**.\n")
+	assert.Contains(t, md, "- Removed event **Cancel** of **Order**.\n")
+	assert.Contains(t, md, "- Changed attribute **Total** of **Order**:\n  - data type: `")
+}
+
+func TestCompareLogic(t *testing.T) {
+	key := helper.Must(identity.NewInvariantKey("0"))
+	model := func(notation, specification, details string) core.Model {
+		spec := helper.Must(logic_spec.NewExpressionSpec(notation, specification, nil))
+		m := core.NewModel("bank", core.ModelDetails{Name: "Bank", Details: details}, "", nil, nil, nil)
+		m.Invariants = []model_logic.Logic{{Key: key, Type: model_logic.LogicTypeAssessment, Description: "Positive", Spec: spec}}
+		return m
+	}
+
+	// Layout, redundant parentheses and notation are not changes.
+	base := model("tla_plus", `\A x \in S : x >= 0`, "One.\nTwo.")
+	assert.Empty(t, Compare(base, model("tla_plus", "\\A x \\in S :\n    (x >= 0)", "One.\nTwo.")).Changes)
+	assert.Empty(t, Compare(base, model("infix", `all x in S: x >= 0`, "One.\nTwo.")).Changes)
+
+	diff := Compare(base, model("tla_plus", `\A x \in S : x > 0`, "One.\nThree."))
+	md := Markdown(diff)
+	assert.Contains(t, md, "- Changed model **Bank**:\n  - details:\n\n    ```diff\n     One.\n    -Two.\n    +Three.\n    ```\n")
+	assert.Contains(t, md, "- Changed invariant **Positive**:\n  - specification: `∀ x ∈ S : x ≥ 0` → `∀ x ∈ S : x > 0`\n")
+}
+
+func TestLineDiff(t *testing.T) {
+	assert.Equal(t, []string{" a", "-b", "+c", " d"}, lineDiff("a\nb\nd", "a\nc\nd"))
+	assert.Equal(t, []string{"+a"}, lineDiff("", "a"))
+	assert.Equal(t, []string{"-a"}, lineDiff("a", ""))
+}
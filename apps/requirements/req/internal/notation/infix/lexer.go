@@ -0,0 +1,251 @@
+package infix
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// tokenKind classifies a lexical token of the infix notation.
+type tokenKind int
+
+const (
+	tokenEOF        tokenKind = iota // End of input
+	tokenIdentifier                  // Names and keywords
+	tokenNumber                      // Decimal, 0x, 0o, and 0b numbers
+	tokenString                      // Double-quoted string (Value holds the unescaped text)
+	tokenSymbol                      // Operators and punctuation
+)
+
+// token is one lexical token with its 1-based source position.
+type token struct {
+	Kind   tokenKind
+	Value  string
+	Line   int
+	Column int
+	Offset int // Byte offset of the token in the input.
+}
+
+// SyntaxError is a problem at one position of infix specification text.
+type SyntaxError struct {
+	Offset  int // Byte offset in the input.
+	Line    int
+	Column  int
+	Message string
+}
+
+// Error formats the error with its line and column.
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("line %d, column %d: %s", e.Line, e.Column, e.Message)
+}
+
+// syntaxErrorAt returns a SyntaxError at the position of tok.
+func syntaxErrorAt(tok token, format string, args ...any) error {
+	return &SyntaxError{Offset: tok.Offset, Line: tok.Line, Column: tok.Column, Message: fmt.Sprintf(format, args...)}
+}
+
+// String describes the token for error messages.
+func (t token) String() string {
+	switch t.Kind {
+	case tokenEOF:
+		return "end of input"
+	case tokenString:
+		return `string "` + t.Value + `"`
+	case tokenIdentifier, tokenNumber, tokenSymbol:
+		return `"` + t.Value + `"`
+	}
+	return `"` + t.Value + `"`
+}
+
+// Symbols ordered longest first so the lexer always takes the longest match.
+var _symbols = []string{
+	"<=>", "(+)", "(-)",
+	"=>", "==", "!=", "<=", ">=", "&&", "||", "->", "++", "..", "::",
+	"<", ">", "!", "+", "-", "*", "/", "%", "^", "'", ".", ",", ":", ";",
+	"(", ")", "[", "]", "{", "}", "=", "@",
+}
+
+// lexer splits infix specification text into tokens.
+type lexer struct {
+	input  string
+	pos    int
+	line   int
+	column int
+}
+
+// tokenize returns every token in the input followed by a tokenEOF token.
+func tokenize(input string) ([]token, error) {
+	l := &lexer{input: input, line: 1, column: 1}
+	var tokens []token
+	for {
+		tok, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, tok)
+		if tok.Kind == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+// next scans the next token.
+func (l *lexer) next() (token, error) {
+	l.skipWhitespace()
+	if l.pos >= len(l.input) {
+		return token{Kind: tokenEOF, Line: l.line, Column: l.column, Offset: l.pos}, nil
+	}
+
+	here := token{Line: l.line, Column: l.column, Offset: l.pos}
+	ch := l.input[l.pos]
+	switch {
+	case isIdentifierStart(ch):
+		start := l.pos
+		for l.pos < len(l.input) && isIdentifierPart(l.input[l.pos]) {
+			l.advance(1)
+		}
+		return here.with(tokenIdentifier, l.input[start:l.pos]), nil
+	case isDigit(ch):
+		return l.scanNumber(here)
+	case ch == '"':
+		return l.scanString(here)
+	}
+
+	for _, sym := range _symbols {
+		if strings.HasPrefix(l.input[l.pos:], sym) {
+			l.advance(len(sym))
+			return here.with(tokenSymbol, sym), nil
+		}
+	}
+
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos:])
+	return token{}, syntaxErrorAt(here, "unexpected character %q", r)
+}
+
+// with returns the token at this position with the given kind and text.
+func (t token) with(kind tokenKind, value string) token {
+	t.Kind = kind
+	t.Value = value
+	return t
+}
+
+// scanNumber scans a decimal, hexadecimal (0x), octal (0o), or binary (0b) number.
+func (l *lexer) scanNumber(here token) (token, error) {
+	start := l.pos
+	if l.input[l.pos] == '0' && l.pos+1 < len(l.input) {
+		if digitOk := basePrefixDigits(l.input[l.pos+1]); digitOk != nil {
+			l.advance(2)
+			digitsStart := l.pos
+			for l.pos < len(l.input) && digitOk(l.input[l.pos]) {
+				l.advance(1)
+			}
+			if l.pos == digitsStart {
+				return token{}, syntaxErrorAt(here, "number prefix %q has no digits", l.input[start:l.pos])
+			}
+			return here.with(tokenNumber, l.input[start:l.pos]), nil
+		}
+	}
+	for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+		l.advance(1)
+	}
+	// A decimal point must be followed by a digit; "1..3" is a range.
+	if l.pos+1 < len(l.input) && l.input[l.pos] == '.' && isDigit(l.input[l.pos+1]) {
+		l.advance(1)
+		for l.pos < len(l.input) && isDigit(l.input[l.pos]) {
+			l.advance(1)
+		}
+	}
+	return here.with(tokenNumber, l.input[start:l.pos]), nil
+}
+
+// basePrefixDigits returns the digit test for a 0x/0o/0b prefix letter, or nil.
+func basePrefixDigits(prefix byte) func(byte) bool {
+	switch prefix {
+	case 'x':
+		return func(c byte) bool { return isDigit(c) || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F') }
+	case 'o':
+		return func(c byte) bool { return c >= '0' && c <= '7' }
+	case 'b':
+		return func(c byte) bool { return c == '0' || c == '1' }
+	default:
+		return nil
+	}
+}
+
+// scanString scans a double-quoted string with \" \\ \n \t \r \f escapes.
+func (l *lexer) scanString(here token) (token, error) {
+	l.advance(1) // Opening quote.
+	var sb strings.Builder
+	for l.pos < len(l.input) {
+		ch := l.input[l.pos]
+		switch ch {
+		case '"':
+			l.advance(1)
+			return here.with(tokenString, sb.String()), nil
+		case '\\':
+			if l.pos+1 >= len(l.input) {
+				return token{}, syntaxErrorAt(here, "unterminated string")
+			}
+			escaped, ok := _stringEscapes[l.input[l.pos+1]]
+			if !ok {
+				escape := token{Line: l.line, Column: l.column, Offset: l.pos}
+				return token{}, syntaxErrorAt(escape, "unknown string escape \\%c", l.input[l.pos+1])
+			}
+			sb.WriteByte(escaped)
+			l.advance(2)
+		default:
+			r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+			sb.WriteRune(r)
+			l.advance(size)
+		}
+	}
+	return token{}, syntaxErrorAt(here, "unterminated string")
+}
+
+// _stringEscapes maps the character after a backslash to the character it stands for.
+var _stringEscapes = map[byte]byte{
+	'"':  '"',
+	'\\': '\\',
+	'n':  '\n',
+	't':  '\t',
+	'r':  '\r',
+	'f':  '\f',
+}
+
+func (l *lexer) skipWhitespace() {
+	for l.pos < len(l.input) {
+		switch l.input[l.pos] {
+		case ' ', '\t', '\r', '\n':
+			l.advance(1)
+		default:
+			return
+		}
+	}
+}
+
+// advance moves forward n bytes, tracking line and column (counted in runes).
+func (l *lexer) advance(n int) {
+	end := l.pos + n
+	for l.pos < end {
+		r, size := utf8.DecodeRuneInString(l.input[l.pos:])
+		l.pos += size
+		if r == '\n' {
+			l.line++
+			l.column = 1
+		} else {
+			l.column++
+		}
+	}
+}
+
+func isIdentifierStart(ch byte) bool {
+	return ch == '_' || (ch >= 'a' && ch <= 'z') || (ch >= 'A' && ch <= 'Z')
+}
+
+func isIdentifierPart(ch byte) bool {
+	return isIdentifierStart(ch) || isDigit(ch)
+}
+
+func isDigit(ch byte) bool {
+	return ch >= '0' && ch <= '9'
+}
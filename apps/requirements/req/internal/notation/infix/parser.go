@@ -0,0 +1,1009 @@
+// Package infix implements a programmer-friendly notation for logic specifications.
+//
+// The notation uses familiar infix syntax (&&, ||, !, ==, !=) and keyword forms
+// such as "all x in S: P" and "r with {f: v}" instead of TLA+ symbols. It is
+// parsed into, and printed from, the same AST as TLA+ (internal/notation/tla_plus/ast),
+// so both notations lower to and raise from the notation-neutral logic_expression IR
+// through the same conversion code, and any specification can be translated
+// between the two notations without model context.
+package infix
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+)
+
+// Keywords of the infix notation. They cannot be used as identifiers.
+const (
+	keywordAll        = "all"
+	keywordExists     = "exists"
+	keywordChoose     = "choose"
+	keywordIf         = "if"
+	keywordThen       = "then"
+	keywordElse       = "else"
+	keywordLet        = "let"
+	keywordCase       = "case"
+	keywordOtherwise  = "otherwise"
+	keywordIn         = "in"
+	keywordNot        = "not"
+	keywordWhere      = "where"
+	keywordFor        = "for"
+	keywordWith       = "with"
+	keywordTrue       = "true"
+	keywordFalse      = "false"
+	keywordUnion      = "union"
+	keywordIntersect  = "intersect"
+	keywordWithout    = "without"
+	keywordCross      = "cross"
+	keywordDiv        = "div"
+	keywordSubset     = "subset"
+	keywordSubsetEq   = "subseteq"
+	keywordSuperset   = "superset"
+	keywordSupersetEq = "superseteq"
+	keywordSubBag     = "subbag"
+	keywordSubBagEq   = "subbageq"
+	keywordSuperBag   = "superbag"
+	keywordSuperBagEq = "superbageq"
+)
+
+var _keywords = map[string]bool{
+	keywordAll: true, keywordExists: true, keywordChoose: true,
+	keywordIf: true, keywordThen: true, keywordElse: true,
+	keywordLet: true, keywordCase: true, keywordOtherwise: true,
+	keywordIn: true, keywordNot: true, keywordWhere: true, keywordFor: true, keywordWith: true,
+	keywordTrue: true, keywordFalse: true,
+	keywordUnion: true, keywordIntersect: true, keywordWithout: true, keywordCross: true, keywordDiv: true,
+	keywordSubset: true, keywordSubsetEq: true, keywordSuperset: true, keywordSupersetEq: true,
+	keywordSubBag: true, keywordSubBagEq: true, keywordSuperBag: true, keywordSuperBagEq: true,
+}
+
+// System event constructors are spelled _new/_destroy in the infix notation and
+// «new»/«destroy» in the shared AST (the canonical TLA+ spelling).
+var (
+	_systemEventToAST   = map[string]string{"_new": "«new»", "_destroy": "«destroy»"}
+	_systemEventToInfix = map[string]string{"«new»": "_new", "«destroy»": "_destroy"}
+)
+
+// Comparison operators (all at one non-associative precedence level) mapped to
+// the AST node that represents them.
+var (
+	_equalityOps      = map[string]string{"==": ast.EqualityOperatorEqual, "!=": ast.EqualityOperatorNotEqual}
+	_numComparisonOps = map[string]string{"<": ast.ComparisonLessThan, ">": ast.ComparisonGreaterThan, "<=": ast.ComparisonLessThanOrEqual, ">=": ast.ComparisonGreaterThanOrEqual}
+	_setComparisonOps = map[string]string{keywordSubsetEq: ast.SetComparisonSubsetEq, keywordSubset: ast.SetComparisonSubset, keywordSupersetEq: ast.SetComparisonSupersetEq, keywordSuperset: ast.SetComparisonSuperset}
+	_bagComparisonOps = map[string]string{keywordSubBag: ast.BagComparisonProperSubBag, keywordSubBagEq: ast.BagComparisonSubBag, keywordSuperBag: ast.BagComparisonProperSupBag, keywordSuperBagEq: ast.BagComparisonSupBag}
+	_setOperationOps  = map[string]string{keywordUnion: ast.SetOperatorUnion, keywordIntersect: ast.SetOperatorIntersection, keywordWithout: ast.SetOperatorDifference}
+)
+
+// Parse parses an infix specification into a TLA+ AST expression.
+// Returns an error with the line and column of the first problem.
+func Parse(input string) (ast.Expression, error) {
+	return parse(input, nil)
+}
+
+// ParsePositions is like Parse but also returns the byte offset in input at which
+// each node of the expression starts.
+func ParsePositions(input string) (ast.Expression, ast.Positions, error) {
+	positions := ast.Positions{}
+	expr, err := parse(input, positions)
+	if err != nil {
+		return nil, nil, err
+	}
+	return expr, positions, nil
+}
+
+// ErrorOffset returns the byte offset in the input of the problem a Parse error reports.
+func ErrorOffset(err error) (int, bool) {
+	var syntaxErr *SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		return 0, false
+	}
+	return syntaxErr.Offset, true
+}
+
+func parse(input string, positions ast.Positions) (ast.Expression, error) {
+	tokens, err := tokenize(input)
+	if err != nil {
+		return nil, fmt.Errorf("infix parse error: %w", err)
+	}
+	p := &parser{tokens: tokens, positions: positions}
+	expr, err := p.parseExpression()
+	if err != nil {
+		return nil, fmt.Errorf("infix parse error: %w", err)
+	}
+	if !p.at(tokenEOF, "") {
+		return nil, fmt.Errorf("infix parse error: %w", p.unexpected("end of input"))
+	}
+	return expr, nil
+}
+
+// MustParse is like Parse but panics on error.
+// Useful for tests and static initialization.
+func MustParse(input string) ast.Expression {
+	expr, err := Parse(input)
+	if err != nil {
+		panic(err)
+	}
+	return expr
+}
+
+// parser is a recursive-descent parser over a token slice.
+// Each parse method handles one precedence level, lowest first.
+type parser struct {
+	tokens    []token
+	pos       int
+	positions ast.Positions // Where each node starts, when the caller wants to know.
+}
+
+// mark records that expr starts at the token start and returns expr.
+func (p *parser) mark(start token, expr ast.Expression) ast.Expression {
+	p.positions.Record(expr, start.Offset)
+	return expr
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) peekAt(offset int) token {
+	if p.pos+offset >= len(p.tokens) {
+		return p.tokens[len(p.tokens)-1]
+	}
+	return p.tokens[p.pos+offset]
+}
+
+func (p *parser) advance() token {
+	tok := p.tokens[p.pos]
+	if tok.Kind != tokenEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// at reports whether the current token has the given kind and (if non-empty) value.
+func (p *parser) at(kind tokenKind, value string) bool {
+	tok := p.peek()
+	return tok.Kind == kind && (value == "" || tok.Value == value)
+}
+
+func (p *parser) atKeyword(value string) bool {
+	return p.at(tokenIdentifier, value)
+}
+
+// accept consumes the current token if it is the given symbol or keyword.
+func (p *parser) accept(value string) bool {
+	tok := p.peek()
+	if (tok.Kind == tokenSymbol || tok.Kind == tokenIdentifier) && tok.Value == value {
+		p.advance()
+		return true
+	}
+	return false
+}
+
+// expect consumes the given symbol or keyword or returns an error.
+func (p *parser) expect(value string) error {
+	if p.accept(value) {
+		return nil
+	}
+	return p.unexpected(`"` + value + `"`)
+}
+
+func (p *parser) unexpected(expected string) error {
+	tok := p.peek()
+	return syntaxErrorAt(tok, "expected %s, found %s", expected, tok)
+}
+
+// expectName consumes a non-keyword identifier and returns its text.
+func (p *parser) expectName(what string) (string, error) {
+	tok := p.peek()
+	if tok.Kind != tokenIdentifier || _keywords[tok.Value] {
+		return "", p.unexpected(what)
+	}
+	p.advance()
+	return tok.Value, nil
+}
+
+// parseExpression parses a full expression (the lowest precedence level).
+func (p *parser) parseExpression() (ast.Expression, error) {
+	return p.parseImplies()
+}
+
+// parseImplies parses a => b (right-associative).
+func (p *parser) parseImplies() (ast.Expression, error) {
+	start := p.peek()
+	left, err := p.parseEquiv()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("=>") {
+		return left, nil
+	}
+	right, err := p.parseImplies()
+	if err != nil {
+		return nil, err
+	}
+	return p.mark(start, &ast.BinaryLogic{Operator: ast.LogicOperatorImplies, Left: left, Right: right}), nil
+}
+
+// parseEquiv parses a <=> b (left-associative).
+func (p *parser) parseEquiv() (ast.Expression, error) {
+	return p.parseLeftLogic("<=>", ast.LogicOperatorEquiv, p.parseOr)
+}
+
+// parseOr parses a || b (left-associative).
+func (p *parser) parseOr() (ast.Expression, error) {
+	return p.parseLeftLogic("||", ast.LogicOperatorOr, p.parseAnd)
+}
+
+// parseAnd parses a && b (left-associative).
+func (p *parser) parseAnd() (ast.Expression, error) {
+	return p.parseLeftLogic("&&", ast.LogicOperatorAnd, p.parseComparison)
+}
+
+// parseLeftLogic parses a left-associative chain of one logic operator.
+func (p *parser) parseLeftLogic(symbol, operator string, operand func() (ast.Expression, error)) (ast.Expression, error) {
+	start := p.peek()
+	left, err := operand()
+	if err != nil {
+		return nil, err
+	}
+	for p.accept(symbol) {
+		right, err := operand()
+		if err != nil {
+			return nil, err
+		}
+		left = p.mark(start, &ast.BinaryLogic{Operator: operator, Left: left, Right: right})
+	}
+	return left, nil
+}
+
+// parseComparison parses one (non-associative) comparison, membership, or subset test.
+func (p *parser) parseComparison() (ast.Expression, error) {
+	start := p.peek()
+	left, err := p.parseSetOperation()
+	if err != nil {
+		return nil, err
+	}
+	build, ok := p.comparisonBuilder()
+	if !ok {
+		return left, nil
+	}
+	right, err := p.parseSetOperation()
+	if err != nil {
+		return nil, err
+	}
+	return p.mark(start, build(left, right)), nil
+}
+
+// comparisonBuilder consumes a comparison operator and returns the node constructor for it.
+func (p *parser) comparisonBuilder() (func(left, right ast.Expression) ast.Expression, bool) {
+	tok := p.peek()
+	if tok.Kind == tokenIdentifier && tok.Value == keywordNot && p.peekAt(1).Kind == tokenIdentifier && p.peekAt(1).Value == keywordIn {
+		p.advance()
+		p.advance()
+		return func(l, r ast.Expression) ast.Expression {
+			return &ast.Membership{Operator: ast.MembershipOperatorNotIn, Left: l, Right: r}
+		}, true
+	}
+	if tok.Kind != tokenSymbol && tok.Kind != tokenIdentifier {
+		return nil, false
+	}
+	if tok.Kind == tokenIdentifier && tok.Value == keywordIn {
+		p.advance()
+		return func(l, r ast.Expression) ast.Expression {
+			return &ast.Membership{Operator: ast.MembershipOperatorIn, Left: l, Right: r}
+		}, true
+	}
+	if op, ok := _equalityOps[tok.Value]; ok && tok.Kind == tokenSymbol {
+		p.advance()
+		return func(l, r ast.Expression) ast.Expression { return &ast.BinaryEquality{Operator: op, Left: l, Right: r} }, true
+	}
+	if op, ok := _numComparisonOps[tok.Value]; ok && tok.Kind == tokenSymbol {
+		p.advance()
+		return func(l, r ast.Expression) ast.Expression {
+			return &ast.BinaryComparison{Operator: op, Left: l, Right: r}
+		}, true
+	}
+	if op, ok := _setComparisonOps[tok.Value]; ok && tok.Kind == tokenIdentifier {
+		p.advance()
+		return func(l, r ast.Expression) ast.Expression {
+			return &ast.BinarySetComparison{Operator: op, Left: l, Right: r}
+		}, true
+	}
+	if op, ok := _bagComparisonOps[tok.Value]; ok && tok.Kind == tokenIdentifier {
+		p.advance()
+		return func(l, r ast.Expression) ast.Expression {
+			return &ast.BinaryBagComparison{Operator: op, Left: l, Right: r}
+		}, true
+	}
+	return nil, false
+}
+
+// parseSetOperation parses union, intersect, and without (one left-associative level).
+func (p *parser) parseSetOperation() (ast.Expression, error) {
+	start := p.peek()
+	left, err := p.parseCross()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		op, ok := _setOperationOps[tok.Value]
+		if !ok || tok.Kind != tokenIdentifier {
+			return left, nil
+		}
+		p.advance()
+		right, err := p.parseCross()
+		if err != nil {
+			return nil, err
+		}
+		left = p.mark(start, &ast.BinarySetOperation{Operator: op, Left: left, Right: right})
+	}
+}
+
+// parseCross parses A cross B cross C into one n-ary cartesian product.
+func (p *parser) parseCross() (ast.Expression, error) {
+	start := p.peek()
+	first, err := p.parseRange()
+	if err != nil {
+		return nil, err
+	}
+	if !p.atKeyword(keywordCross) {
+		return first, nil
+	}
+	operands := []ast.Expression{first}
+	for p.accept(keywordCross) {
+		next, err := p.parseRange()
+		if err != nil {
+			return nil, err
+		}
+		operands = append(operands, next)
+	}
+	return p.mark(start, &ast.CartesianProduct{Operands: operands}), nil
+}
+
+// parseRange parses a..b (non-associative).
+func (p *parser) parseRange() (ast.Expression, error) {
+	first := p.peek()
+	start, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("..") {
+		return start, nil
+	}
+	end, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	return p.mark(first, &ast.SetRangeExpr{Start: start, End: end}), nil
+}
+
+// parseAdditive parses +, -, ++ (concatenation), (+) and (-) (bag sum and difference).
+// Consecutive ++ operators collect into a single n-ary concatenation, as in TLA+.
+func (p *parser) parseAdditive() (ast.Expression, error) {
+	start := p.peek()
+	left, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	var concat *ast.TupleConcat
+	for {
+		tok := p.peek()
+		if tok.Kind != tokenSymbol {
+			return left, nil
+		}
+		var build func(right ast.Expression) ast.Expression
+		switch tok.Value {
+		case "+", "-":
+			op := tok.Value
+			build = func(r ast.Expression) ast.Expression {
+				return &ast.BinaryArithmetic{Operator: op, Left: left, Right: r}
+			}
+		case "(+)":
+			build = func(r ast.Expression) ast.Expression {
+				return &ast.BinaryBagOperation{Operator: ast.BagOperatorUnion, Left: left, Right: r}
+			}
+		case "(-)":
+			build = func(r ast.Expression) ast.Expression {
+				return &ast.BinaryBagOperation{Operator: ast.BagOperatorSubtraction, Left: left, Right: r}
+			}
+		case "++":
+			build = func(r ast.Expression) ast.Expression {
+				if concat != nil && left == ast.Expression(concat) {
+					concat.Operands = append(concat.Operands, r)
+					return concat
+				}
+				concat = &ast.TupleConcat{Operator: "∘", Operands: []ast.Expression{left, r}}
+				return concat
+			}
+		default:
+			return left, nil
+		}
+		p.advance()
+		right, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		left = p.mark(start, build(right))
+	}
+}
+
+// parseMultiplicative parses *, / (fraction), div, and %.
+func (p *parser) parseMultiplicative() (ast.Expression, error) {
+	start := p.peek()
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		tok := p.peek()
+		var build func(right ast.Expression) ast.Expression
+		switch {
+		case tok.Kind == tokenSymbol && (tok.Value == "*" || tok.Value == "%"):
+			op := tok.Value
+			build = func(r ast.Expression) ast.Expression {
+				return &ast.BinaryArithmetic{Operator: op, Left: left, Right: r}
+			}
+		case tok.Kind == tokenSymbol && tok.Value == "/":
+			build = func(r ast.Expression) ast.Expression { return ast.NewFraction(left, r) }
+		case tok.Kind == tokenIdentifier && tok.Value == keywordDiv:
+			build = func(r ast.Expression) ast.Expression {
+				return &ast.BinaryArithmetic{Operator: ast.ArithmeticOperatorDivide, Left: left, Right: r}
+			}
+		default:
+			return left, nil
+		}
+		p.advance()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = p.mark(start, build(right))
+	}
+}
+
+// parseUnary parses prefix - (negation) and ! (logical not).
+func (p *parser) parseUnary() (ast.Expression, error) {
+	start := p.peek()
+	switch {
+	case p.accept("-"):
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return p.mark(start, ast.NewNegation(operand)), nil
+	case p.accept("!"):
+		operand, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return p.mark(start, &ast.UnaryLogic{Operator: ast.LogicOperatorNot, Right: operand}), nil
+	default:
+		return p.parsePower()
+	}
+}
+
+// parsePower parses a ^ b (right-associative; the exponent may be negated).
+func (p *parser) parsePower() (ast.Expression, error) {
+	start := p.peek()
+	base, err := p.parsePostfix()
+	if err != nil {
+		return nil, err
+	}
+	if !p.accept("^") {
+		return base, nil
+	}
+	exponent, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	return p.mark(start, &ast.BinaryArithmetic{Operator: ast.ArithmeticOperatorPower, Left: base, Right: exponent}), nil
+}
+
+// parsePostfix parses x', x.field, x[index], and r with {field: value, ...}.
+func (p *parser) parsePostfix() (ast.Expression, error) {
+	start := p.peek()
+	expr, err := p.parsePrimary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		p.mark(start, expr)
+		switch {
+		case p.accept("'"):
+			expr = &ast.Primed{Base: expr}
+		case p.accept("."):
+			member, err := p.expectName("field name")
+			if err != nil {
+				return nil, err
+			}
+			expr = &ast.FieldAccess{Base: expr, Member: member}
+		case p.accept("["):
+			index, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect("]"); err != nil {
+				return nil, err
+			}
+			expr = &ast.TupleIndex{Tuple: expr, Index: index}
+		case p.atKeyword(keywordWith):
+			expr, err = p.parseWith(expr)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return expr, nil
+		}
+	}
+}
+
+// parseWith parses the record update suffix: with {field: value, ...}.
+func (p *parser) parseWith(base ast.Expression) (ast.Expression, error) {
+	p.advance() // with
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var alterations []*ast.FieldAlteration
+	for {
+		field, err := p.expectName("field name")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		alterations = append(alterations, &ast.FieldAlteration{Field: &ast.FieldAccess{Member: field}, Expression: value})
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return &ast.RecordAltered{Base: base, Alterations: alterations}, nil
+}
+
+// parsePrimary parses literals, names, calls, and bracketed or keyword-introduced forms.
+//
+//complexity:cyclo:warn=30,fail=30 Simple routing switch.
+func (p *parser) parsePrimary() (ast.Expression, error) {
+	tok := p.peek()
+	switch tok.Kind {
+	case tokenNumber:
+		p.advance()
+		return numberLiteral(tok.Value), nil
+	case tokenString:
+		p.advance()
+		return &ast.StringLiteral{Value: tok.Value}, nil
+	case tokenIdentifier:
+		return p.parseWord()
+	case tokenSymbol:
+		switch tok.Value {
+		case "(":
+			p.advance()
+			inner, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			if err := p.expect(")"); err != nil {
+				return nil, err
+			}
+			return ast.NewParenthesized(inner), nil
+		case "[":
+			return p.parseBracket()
+		case "{":
+			return p.parseBrace()
+		case "@":
+			p.advance()
+			return &ast.ExistingValue{}, nil
+		}
+	case tokenEOF:
+	}
+	return nil, p.unexpected("an expression")
+}
+
+// parseWord parses everything that starts with an identifier token:
+// keyword forms, boolean literals, calls, and plain names.
+func (p *parser) parseWord() (ast.Expression, error) {
+	tok := p.peek()
+	switch tok.Value {
+	case keywordTrue, keywordFalse:
+		p.advance()
+		return &ast.BooleanLiteral{Value: tok.Value == keywordTrue}, nil
+	case keywordAll, keywordExists:
+		return p.parseQuantifier()
+	case keywordChoose:
+		return p.parseChoose()
+	case keywordIf:
+		return p.parseIf()
+	case keywordLet:
+		return p.parseLet()
+	case keywordCase:
+		return p.parseCase()
+	}
+	if _keywords[tok.Value] {
+		return nil, p.unexpected("an expression")
+	}
+
+	// A name followed by :: or ( is a (possibly scoped) call.
+	next := p.peekAt(1)
+	if next.Kind == tokenSymbol && (next.Value == "::" || next.Value == "(") {
+		return p.parseCall()
+	}
+	p.advance()
+	return &ast.Identifier{Value: tok.Value}, nil
+}
+
+// parseCall parses Scope::Scope::Name(args...).
+func (p *parser) parseCall() (ast.Expression, error) {
+	var scope []*ast.Identifier
+	name, err := p.expectName("function name")
+	if err != nil {
+		return nil, err
+	}
+	for p.accept("::") {
+		scope = append(scope, &ast.Identifier{Value: name})
+		if name, err = p.expectName("function name"); err != nil {
+			return nil, err
+		}
+	}
+	if tlaName, ok := _systemEventToAST[name]; ok {
+		name = tlaName
+	}
+	if err := p.expect("("); err != nil {
+		return nil, err
+	}
+	args, err := p.parseList(")")
+	if err != nil {
+		return nil, err
+	}
+	if scope == nil {
+		scope = []*ast.Identifier{}
+	}
+	return &ast.FunctionCall{ScopePath: scope, Name: &ast.Identifier{Value: name}, Args: args}, nil
+}
+
+// parseList parses comma-separated expressions up to and including the closing symbol.
+func (p *parser) parseList(closing string) ([]ast.Expression, error) {
+	elements := []ast.Expression{}
+	if p.accept(closing) {
+		return elements, nil
+	}
+	for {
+		elem, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, elem)
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect(closing); err != nil {
+		return nil, err
+	}
+	return elements, nil
+}
+
+// atFieldLabel reports whether the next tokens are "name :", which starts a record.
+func (p *parser) atFieldLabel() bool {
+	tok := p.peek()
+	next := p.peekAt(1)
+	return tok.Kind == tokenIdentifier && !_keywords[tok.Value] && next.Kind == tokenSymbol && next.Value == ":"
+}
+
+// parseBracket parses a tuple [a, b] or a record type [field: Type, ...].
+func (p *parser) parseBracket() (ast.Expression, error) {
+	p.advance() // [
+	if !p.atFieldLabel() {
+		elements, err := p.parseList("]")
+		if err != nil {
+			return nil, err
+		}
+		return &ast.TupleLiteral{Elements: elements}, nil
+	}
+	var fields []*ast.RecordTypeField
+	for {
+		name, err := p.expectName("field name")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		fieldType, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		fields = append(fields, &ast.RecordTypeField{Name: &ast.Identifier{Value: name}, Type: fieldType})
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	return &ast.RecordTypeExpr{Fields: fields}, nil
+}
+
+// parseBrace parses a record {f: v}, a set {a, b}, a filter {x in S where P},
+// or a map {e for x in S}.
+func (p *parser) parseBrace() (ast.Expression, error) {
+	p.advance() // {
+	if p.atFieldLabel() {
+		return p.parseRecord()
+	}
+	if p.accept("}") {
+		return &ast.SetLiteral{Elements: []ast.Expression{}}, nil
+	}
+	first, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case p.atKeyword(keywordWhere):
+		tok := p.advance()
+		if !isInMembership(first) {
+			return nil, syntaxErrorAt(tok, `"where" must follow a binding like "x in S"`)
+		}
+		predicate, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		return &ast.SetFilter{Membership: first, Predicate: predicate}, nil
+	case p.atKeyword(keywordFor):
+		p.advance()
+		binding, err := p.parseBinding()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("}"); err != nil {
+			return nil, err
+		}
+		return &ast.SetMap{Transform: first, Membership: binding}, nil
+	}
+	elements := []ast.Expression{first}
+	for p.accept(",") {
+		elem, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		elements = append(elements, elem)
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return &ast.SetLiteral{Elements: elements}, nil
+}
+
+// parseRecord parses the fields of a record literal after the opening brace.
+func (p *parser) parseRecord() (ast.Expression, error) {
+	var bindings []*ast.FieldBinding
+	for {
+		name, err := p.expectName("field name")
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect(":"); err != nil {
+			return nil, err
+		}
+		value, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		bindings = append(bindings, &ast.FieldBinding{Field: &ast.Identifier{Value: name}, Expression: value})
+		if !p.accept(",") {
+			break
+		}
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	return &ast.RecordInstance{Bindings: bindings}, nil
+}
+
+// parseBinding parses "x in S" as used by quantifiers, choose, and set maps.
+func (p *parser) parseBinding() (ast.Expression, error) {
+	tok := p.peek()
+	binding, err := p.parseComparison()
+	if err != nil {
+		return nil, err
+	}
+	if !isInMembership(binding) {
+		return nil, syntaxErrorAt(tok, `expected a binding like "x in S"`)
+	}
+	return binding, nil
+}
+
+// isInMembership reports whether expr is an "x in S" membership test.
+func isInMembership(expr ast.Expression) bool {
+	m, ok := expr.(*ast.Membership)
+	return ok && m.Operator == ast.MembershipOperatorIn
+}
+
+// parseQuantifier parses all x in S: P and exists x in S: P.
+func (p *parser) parseQuantifier() (ast.Expression, error) {
+	quantifier := ast.QuantifierForAll
+	if p.advance().Value == keywordExists {
+		quantifier = ast.QuantifierExists
+	}
+	binding, err := p.parseBinding()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	predicate, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.Quantifier{Quantifier: quantifier, Membership: binding, Predicate: predicate}, nil
+}
+
+// parseChoose parses choose x in S: P.
+func (p *parser) parseChoose() (ast.Expression, error) {
+	p.advance() // choose
+	binding, err := p.parseBinding()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(":"); err != nil {
+		return nil, err
+	}
+	predicate, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.ChooseExpr{Membership: binding, Predicate: predicate}, nil
+}
+
+// parseIf parses if c then a else b.
+func (p *parser) parseIf() (ast.Expression, error) {
+	p.advance() // if
+	condition, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(keywordThen); err != nil {
+		return nil, err
+	}
+	then, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(keywordElse); err != nil {
+		return nil, err
+	}
+	otherwise, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.IfThenElse{Condition: condition, Then: then, Else: otherwise}, nil
+}
+
+// parseLet parses let x = value; body, or the recursive function definition let f[x in S] = value; body.
+func (p *parser) parseLet() (ast.Expression, error) {
+	p.advance() // let
+	name, err := p.expectName("variable name")
+	if err != nil {
+		return nil, err
+	}
+	if p.accept("[") {
+		return p.parseLetFunction(name)
+	}
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.LetExpr{Variable: name, Value: value, Body: body}, nil
+}
+
+// parseLetFunction parses the rest of let f[x in S] = value; body after the opening bracket.
+func (p *parser) parseLetFunction(name string) (ast.Expression, error) {
+	binding, err := p.parseBinding()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect("]"); err != nil {
+		return nil, err
+	}
+	if err := p.expect("="); err != nil {
+		return nil, err
+	}
+	value, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expect(";"); err != nil {
+		return nil, err
+	}
+	body, err := p.parseExpression()
+	if err != nil {
+		return nil, err
+	}
+	return &ast.LetFunction{Name: name, Membership: binding, Value: value, Body: body}, nil
+}
+
+// parseCase parses case { c1 -> r1; c2 -> r2; otherwise -> r }.
+func (p *parser) parseCase() (ast.Expression, error) {
+	p.advance() // case
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	caseExpr := &ast.CaseExpr{}
+	for {
+		if p.accept(keywordOtherwise) {
+			if err := p.expect("->"); err != nil {
+				return nil, err
+			}
+			other, err := p.parseExpression()
+			if err != nil {
+				return nil, err
+			}
+			caseExpr.Other = other
+			break
+		}
+		condition, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expect("->"); err != nil {
+			return nil, err
+		}
+		result, err := p.parseExpression()
+		if err != nil {
+			return nil, err
+		}
+		caseExpr.Branches = append(caseExpr.Branches, &ast.CaseBranch{Condition: condition, Result: result})
+		if !p.accept(";") {
+			break
+		}
+	}
+	if err := p.expect("}"); err != nil {
+		return nil, err
+	}
+	if len(caseExpr.Branches) == 0 {
+		return nil, p.unexpected("at least one case branch")
+	}
+	return caseExpr, nil
+}
+
+// numberLiteral converts a lexed number into a NumberLiteral, mapping the
+// 0x/0o/0b prefixes onto the TLA+ \h, \o, \b prefixes.
+func numberLiteral(text string) *ast.NumberLiteral {
+	switch {
+	case strings.HasPrefix(text, "0x"):
+		return ast.NewHexNumberLiteral(`\h`, text[2:])
+	case strings.HasPrefix(text, "0o"):
+		return ast.NewOctalNumberLiteral(`\o`, text[2:])
+	case strings.HasPrefix(text, "0b"):
+		return ast.NewBinaryNumberLiteral(`\b`, text[2:])
+	}
+	whole, fraction, found := strings.Cut(text, ".")
+	if found {
+		return ast.NewDecimalNumberLiteral(whole, fraction)
+	}
+	return ast.NewNumberLiteral(whole)
+}
@@ -0,0 +1,254 @@
+package infix
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+)
+
+type ParserTestSuite struct {
+	suite.Suite
+}
+
+func TestParserSuite(t *testing.T) {
+	suite.Run(t, new(ParserTestSuite))
+}
+
+// TestRoundTrip checks that canonical infix text parses and prints back unchanged.
+func (s *ParserTestSuite) TestRoundTrip() {
+	tests := []string{
+		// Literals
+		"true",
+		"false",
+		"42",
+		"3.14",
+		"0xff",
+		"0o17",
+		"0b1010",
+		`"hello"`,
+		`"say \"hi\"\n"`,
+		"{}",
+		"{1, 2, 3}",
+		"[]",
+		"[1, 2]",
+		"{a: 1, b: \"x\"}",
+		"[a: Nat, b: STRING]",
+		"@",
+		// Logic
+		"a && b",
+		"a || b && c",
+		"(a || b) && c",
+		"a => b => c",
+		"(a => b) => c",
+		"a <=> b",
+		"!a",
+		"!(a == b)",
+		"!!a",
+		// Comparison
+		"a == b",
+		"a != b",
+		"a < b",
+		"a <= b",
+		"a >= b",
+		"x in S",
+		"x not in S",
+		"A subseteq B",
+		"A superset B",
+		"A subbageq B",
+		"(a == b) == c",
+		// Sets
+		"A union B",
+		"A union B intersect C",
+		"A union (B intersect C)",
+		"A without B",
+		"A cross B cross C",
+		"1..10",
+		"{x in S where x > 0}",
+		"{x * 2 for x in S}",
+		// Arithmetic
+		"a + b * c",
+		"(a + b) * c",
+		"a - (b - c)",
+		"a / b",
+		"a div b",
+		"a % b",
+		"a ^ b ^ c",
+		"(a ^ b) ^ c",
+		"-a",
+		"-(a + b)",
+		"(-a) ^ 2",
+		"B1 (+) B2 (-) B3",
+		"s ++ t ++ u",
+		// Postfix
+		"x'",
+		"self.balance",
+		"self.balance'",
+		"t[1]",
+		"r.a.b[2]",
+		"r with {a: 1, b: @ + 1}",
+		"self.items' == self.items with {count: 0}",
+		// Open forms
+		"all x in S: x > 0",
+		"exists x in S: x.active",
+		"choose x in S: x > 0",
+		"if a then b else c",
+		"let x = 1; x + 1",
+		"let f[n in Nat] = n; f[1]",
+		"a && (all x in S: P)",
+		"(if a then 1 else 2) + 3",
+		"case {a -> 1; b -> 2}",
+		"case {a -> 1; otherwise -> 2}",
+		// Calls
+		"_Max(a, b)",
+		"Withdraw(amount)",
+		"Banking::Accounts::Account::Close()",
+		"_Seq::Head(s)",
+		"_new(a, b)",
+	}
+	for _, input := range tests {
+		s.Run(input, func() {
+			expr, err := Parse(input)
+			s.Require().NoError(err)
+			s.Equal(input, Print(expr))
+		})
+	}
+}
+
+func (s *ParserTestSuite) TestPrecedence() {
+	tests := []struct {
+		testName string
+		input    string
+		expected ast.Expression
+	}{
+		{
+			testName: "and binds tighter than or",
+			input:    "a || b && c",
+			expected: &ast.BinaryLogic{
+				Operator: ast.LogicOperatorOr,
+				Left:     &ast.Identifier{Value: "a"},
+				Right: &ast.BinaryLogic{
+					Operator: ast.LogicOperatorAnd,
+					Left:     &ast.Identifier{Value: "b"},
+					Right:    &ast.Identifier{Value: "c"},
+				},
+			},
+		},
+		{
+			testName: "not binds tighter than comparison",
+			input:    "!a == b",
+			expected: &ast.BinaryEquality{
+				Operator: ast.EqualityOperatorEqual,
+				Left:     &ast.UnaryLogic{Operator: ast.LogicOperatorNot, Right: &ast.Identifier{Value: "a"}},
+				Right:    &ast.Identifier{Value: "b"},
+			},
+		},
+		{
+			testName: "negation binds looser than power",
+			input:    "-x ^ 2",
+			expected: ast.NewNegation(&ast.BinaryArithmetic{
+				Operator: ast.ArithmeticOperatorPower,
+				Left:     &ast.Identifier{Value: "x"},
+				Right:    ast.NewNumberLiteral("2"),
+			}),
+		},
+		{
+			testName: "concatenation is n-ary",
+			input:    "s ++ t ++ u",
+			expected: &ast.TupleConcat{Operator: "∘", Operands: []ast.Expression{
+				&ast.Identifier{Value: "s"},
+				&ast.Identifier{Value: "t"},
+				&ast.Identifier{Value: "u"},
+			}},
+		},
+		{
+			testName: "system event uses the TLA+ spelling",
+			input:    "_new(x)",
+			expected: &ast.FunctionCall{
+				ScopePath: []*ast.Identifier{},
+				Name:      &ast.Identifier{Value: "«new»"},
+				Args:      []ast.Expression{&ast.Identifier{Value: "x"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			expr, err := Parse(tt.input)
+			s.Require().NoError(err)
+			s.Equal(tt.expected, expr)
+		})
+	}
+}
+
+func (s *ParserTestSuite) TestErrors() {
+	tests := []struct {
+		testName string
+		input    string
+		offset   int
+		errstr   string
+	}{
+		{testName: "empty", input: "", offset: 0, errstr: "line 1, column 1: expected an expression, found end of input"},
+		{testName: "unexpected character", input: "a # b", offset: 2, errstr: "line 1, column 3: unexpected character '#'"},
+		{testName: "trailing operator", input: "a &&", offset: 4, errstr: "line 1, column 5: expected an expression, found end of input"},
+		{testName: "trailing tokens", input: "a b", offset: 2, errstr: `line 1, column 3: expected end of input, found "b"`},
+		{testName: "unclosed paren", input: "(a", offset: 2, errstr: `line 1, column 3: expected ")", found end of input`},
+		{testName: "second line", input: "a &&\n  b ||", offset: 11, errstr: "line 2, column 7: expected an expression, found end of input"},
+		{testName: "keyword as name", input: "x.in", offset: 2, errstr: `line 1, column 3: expected field name, found "in"`},
+		{testName: "quantifier without binding", input: "all x: P", offset: 4, errstr: `line 1, column 5: expected a binding like "x in S"`},
+		{testName: "filter without binding", input: "{x where P}", offset: 3, errstr: `line 1, column 4: "where" must follow a binding like "x in S"`},
+		{testName: "if without else", input: "if a then b", offset: 11, errstr: `expected "else", found end of input`},
+		{testName: "let without semicolon", input: "let x = 1 x", offset: 10, errstr: `line 1, column 11: expected ";", found "x"`},
+		{testName: "unterminated string", input: `"abc`, offset: 0, errstr: "line 1, column 1: unterminated string"},
+		{testName: "bad escape", input: `"a\qb"`, offset: 2, errstr: `line 1, column 3: unknown string escape \q`},
+		{testName: "hex without digits", input: "0x", offset: 0, errstr: `line 1, column 1: number prefix "0x" has no digits`},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			_, err := Parse(tt.input)
+			s.Require().Error(err)
+			s.Contains(err.Error(), tt.errstr)
+			offset, ok := ErrorOffset(err)
+			s.Require().True(ok)
+			s.Equal(tt.offset, offset)
+		})
+	}
+}
+
+func (s *ParserTestSuite) TestParsePositions() {
+	expr, positions, err := ParsePositions("self.a > 1 &&\n  !f(x, -y) == {1}")
+	s.Require().NoError(err)
+
+	and := expr.(*ast.BinaryLogic)
+	comparison := and.Left.(*ast.BinaryComparison)
+	equality := and.Right.(*ast.BinaryEquality)
+	not := equality.Left.(*ast.UnaryLogic)
+	call := not.Right.(*ast.FunctionCall)
+	tests := []struct {
+		testName string
+		node     ast.Node
+		offset   int
+	}{
+		{testName: "whole expression", node: and, offset: 0},
+		{testName: "comparison", node: comparison, offset: 0},
+		{testName: "field access", node: comparison.Left, offset: 0},
+		{testName: "literal", node: comparison.Right, offset: 9},
+		{testName: "equality", node: equality, offset: 16},
+		{testName: "negation", node: not, offset: 16},
+		{testName: "call", node: call, offset: 17},
+		{testName: "argument", node: call.Args[0], offset: 19},
+		{testName: "negated argument", node: call.Args[1], offset: 22},
+		{testName: "set", node: equality.Right, offset: 29},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			offset, ok := positions.Offset(tt.node)
+			s.Require().True(ok)
+			s.Equal(tt.offset, offset)
+		})
+	}
+
+	_, ok := ErrorOffset(errors.New("not a syntax error"))
+	s.False(ok)
+}
@@ -0,0 +1,405 @@
+package infix
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+)
+
+// Precedence levels of the infix grammar, matching the parse methods in parser.go.
+// Lower numbers bind less tightly.
+const (
+	precOpen       = 0  // all/exists/choose/if/let: extend as far right as possible
+	precImplies    = 1  // =>  right-associative
+	precEquiv      = 2  // <=>  left-associative
+	precOr         = 3  // ||  left-associative
+	precAnd        = 4  // &&  left-associative
+	precComparison = 5  // == != < > <= >= in, not in, subset...  non-associative
+	precSetOp      = 6  // union intersect without  left-associative
+	precCross      = 7  // cross  n-ary
+	precRange      = 8  // ..  non-associative
+	precAdditive   = 9  // + - ++ (+) (-)  left-associative
+	precMultiply   = 10 // * / div %  left-associative
+	precUnary      = 11 // - !  prefix
+	precPower      = 12 // ^  right-associative
+	precPostfix    = 13 // ' .f [i] with{}
+	precAtom       = 14 // literals, names, calls, bracketed forms
+)
+
+// associativity describes how an operator groups with repeated application.
+type associativity int
+
+const (
+	assocLeft associativity = iota
+	assocRight
+	assocNone
+)
+
+// Operator spellings for the AST operators that differ from the infix notation.
+var (
+	_logicSymbols = map[string]string{
+		ast.LogicOperatorImplies: "=>", ast.LogicOperatorEquiv: "<=>",
+		ast.LogicOperatorOr: "||", ast.LogicOperatorAnd: "&&",
+	}
+	_arithmeticSymbols = map[string]string{ast.ArithmeticOperatorDivide: keywordDiv}
+	_bagSymbols        = map[string]string{ast.BagOperatorUnion: "(+)", ast.BagOperatorSubtraction: "(-)"}
+	_comparisonSymbols = invert(_equalityOps, _numComparisonOps, _setComparisonOps, _bagComparisonOps)
+	_setOpSymbols      = invert(_setOperationOps)
+)
+
+// invert merges infix-to-AST operator maps into one AST-to-infix map.
+func invert(maps ...map[string]string) map[string]string {
+	inverted := map[string]string{}
+	for _, m := range maps {
+		for infixOp, astOp := range m {
+			inverted[astOp] = infixOp
+		}
+	}
+	return inverted
+}
+
+// Print converts a TLA+ AST expression into infix notation with minimal parentheses.
+func Print(expr ast.Expression) string {
+	return printExpr(expr)
+}
+
+// printExpr returns the infix string for an expression.
+//
+//complexity:cyclo:warn=60,fail=60 Mostly simple routing switch.
+//complexity:fanout:warn=60,fail=60 Mostly simple routing switch.
+func printExpr(expr ast.Expression) string {
+	switch e := expr.(type) {
+	// --- Literals ---
+	case *ast.BooleanLiteral:
+		if e.Value {
+			return keywordTrue
+		}
+		return keywordFalse
+	case *ast.NumberLiteral:
+		return printNumber(e)
+	case *ast.StringLiteral:
+		return `"` + escapeString(e.Value) + `"`
+	case *ast.SetLiteral:
+		return "{" + printList(e.Elements) + "}"
+	case *ast.SetLiteralEnum:
+		parts := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			parts[i] = `"` + escapeString(v) + `"`
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case *ast.SetLiteralInt:
+		parts := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			parts[i] = fmt.Sprintf("%d", v)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case *ast.SetRange:
+		return fmt.Sprintf("%d..%d", e.Start, e.End)
+	case *ast.TupleLiteral:
+		return "[" + printList(e.Elements) + "]"
+	case *ast.RecordInstance:
+		parts := make([]string, len(e.Bindings))
+		for i, b := range e.Bindings {
+			parts[i] = b.Field.Value + ": " + printExpr(b.Expression)
+		}
+		return "{" + strings.Join(parts, ", ") + "}"
+	case *ast.RecordTypeExpr:
+		parts := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			parts[i] = f.Name.Value + ": " + printExpr(f.Type)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+	case *ast.SetConstant:
+		return e.Value
+	case *ast.Identifier:
+		return e.Value
+	case *ast.ExistingValue:
+		return "@"
+	case *ast.Parenthesized:
+		return "(" + printExpr(e.Inner) + ")"
+
+	// --- Logic ---
+	case *ast.BinaryLogic:
+		prec, assoc := logicPrecedence(e.Operator)
+		return printBinary(e.Left, _logicSymbols[e.Operator], e.Right, prec, assoc)
+	case *ast.UnaryLogic:
+		return "!" + wrap(e.Right, precUnary, assocRight, false)
+
+	// --- Comparison ---
+	case *ast.BinaryEquality:
+		return printBinary(e.Left, _comparisonSymbols[e.Operator], e.Right, precComparison, assocNone)
+	case *ast.BinaryComparison:
+		return printBinary(e.Left, _comparisonSymbols[e.Operator], e.Right, precComparison, assocNone)
+	case *ast.BinarySetComparison:
+		return printBinary(e.Left, setComparisonSymbol(e.Operator), e.Right, precComparison, assocNone)
+	case *ast.BinaryBagComparison:
+		return printBinary(e.Left, _comparisonSymbols[e.Operator], e.Right, precComparison, assocNone)
+	case *ast.Membership:
+		op := keywordIn
+		if e.Operator == ast.MembershipOperatorNotIn {
+			op = keywordNot + " " + keywordIn
+		}
+		return printBinary(e.Left, op, e.Right, precComparison, assocNone)
+
+	// --- Sets ---
+	case *ast.BinarySetOperation:
+		return printBinary(e.Left, _setOpSymbols[e.Operator], e.Right, precSetOp, assocLeft)
+	case *ast.CartesianProduct:
+		return printNary(e.Operands, keywordCross, precCross)
+	case *ast.SetRangeExpr:
+		return wrap(e.Start, precRange, assocNone, true) + ".." + wrap(e.End, precRange, assocNone, false)
+	case *ast.SetMap:
+		return "{" + printExpr(e.Transform) + " " + keywordFor + " " + printExpr(e.Membership) + "}"
+	case *ast.SetFilter:
+		return "{" + printExpr(e.Membership) + " " + keywordWhere + " " + printExpr(e.Predicate) + "}"
+
+	// --- Arithmetic ---
+	case *ast.BinaryArithmetic:
+		op := e.Operator
+		if symbol, ok := _arithmeticSymbols[op]; ok {
+			op = symbol
+		}
+		prec, assoc := arithmeticPrecedence(e.Operator)
+		return printBinary(e.Left, op, e.Right, prec, assoc)
+	case *ast.Fraction:
+		return printBinary(e.Numerator, "/", e.Denominator, precMultiply, assocLeft)
+	case *ast.UnaryNegation:
+		return "-" + wrap(e.Right, precUnary, assocRight, false)
+	case *ast.BinaryBagOperation:
+		return printBinary(e.Left, _bagSymbols[e.Operator], e.Right, precAdditive, assocLeft)
+	case *ast.TupleConcat:
+		return printNary(e.Operands, "++", precAdditive)
+	case *ast.StringConcat:
+		return printNary(e.Operands, "++", precAdditive)
+
+	// --- Postfix ---
+	case *ast.Primed:
+		return wrap(e.Base, precPostfix, assocLeft, true) + "'"
+	case *ast.FieldAccess:
+		return wrap(e.GetBase(), precPostfix, assocLeft, true) + "." + e.Member
+	case *ast.TupleIndex:
+		return wrap(e.Tuple, precPostfix, assocLeft, true) + "[" + printExpr(e.Index) + "]"
+	case *ast.StringIndex:
+		return wrap(e.Str, precPostfix, assocLeft, true) + "[" + printExpr(e.Index) + "]"
+	case *ast.RecordAltered:
+		parts := make([]string, len(e.Alterations))
+		for i, alt := range e.Alterations {
+			parts[i] = alt.Field.Member + ": " + printExpr(alt.Expression)
+		}
+		return wrap(e.Base, precPostfix, assocLeft, true) + " " + keywordWith + " {" + strings.Join(parts, ", ") + "}"
+
+	// --- Open forms ---
+	case *ast.Quantifier:
+		word := keywordAll
+		if e.Quantifier == ast.QuantifierExists {
+			word = keywordExists
+		}
+		return word + " " + printExpr(e.Membership) + ": " + printExpr(e.Predicate)
+	case *ast.ChooseExpr:
+		return keywordChoose + " " + printExpr(e.Membership) + ": " + printExpr(e.Predicate)
+	case *ast.IfThenElse:
+		return keywordIf + " " + printExpr(e.Condition) +
+			" " + keywordThen + " " + printExpr(e.Then) +
+			" " + keywordElse + " " + printExpr(e.Else)
+	case *ast.LetExpr:
+		return keywordLet + " " + e.Variable + " = " + printExpr(e.Value) + "; " + printExpr(e.Body)
+	case *ast.LetFunction:
+		return keywordLet + " " + e.Name + "[" + printExpr(e.Membership) + "] = " + printExpr(e.Value) + "; " + printExpr(e.Body)
+	case *ast.CaseExpr:
+		return printCase(e)
+
+	// --- Calls ---
+	case *ast.FunctionCall:
+		var sb strings.Builder
+		for _, seg := range e.ScopePath {
+			sb.WriteString(seg.Value)
+			sb.WriteString("::")
+		}
+		name := e.Name.Value
+		if infixName, ok := _systemEventToInfix[name]; ok {
+			name = infixName
+		}
+		sb.WriteString(name)
+		return sb.String() + "(" + printList(e.Args) + ")"
+	case *ast.BuiltinCall:
+		return strings.ReplaceAll(e.Name, "!", "::") + "(" + printList(e.Args) + ")"
+
+	default:
+		// Fallback to the node's own TLA+ String() method.
+		return expr.String()
+	}
+}
+
+// precedenceOf returns the infix precedence of an expression used as an operand.
+func precedenceOf(expr ast.Expression) int {
+	switch e := expr.(type) {
+	case *ast.Quantifier, *ast.ChooseExpr, *ast.IfThenElse, *ast.LetExpr, *ast.LetFunction:
+		return precOpen
+	case *ast.BinaryLogic:
+		prec, _ := logicPrecedence(e.Operator)
+		return prec
+	case *ast.BinaryEquality, *ast.BinaryComparison, *ast.BinarySetComparison, *ast.BinaryBagComparison, *ast.Membership:
+		return precComparison
+	case *ast.BinarySetOperation:
+		return precSetOp
+	case *ast.CartesianProduct:
+		return precCross
+	case *ast.SetRangeExpr:
+		return precRange
+	case *ast.BinaryBagOperation, *ast.TupleConcat, *ast.StringConcat:
+		return precAdditive
+	case *ast.BinaryArithmetic:
+		prec, _ := arithmeticPrecedence(e.Operator)
+		return prec
+	case *ast.Fraction:
+		return precMultiply
+	case *ast.UnaryLogic, *ast.UnaryNegation:
+		return precUnary
+	case *ast.Primed, *ast.FieldAccess, *ast.TupleIndex, *ast.StringIndex, *ast.RecordAltered:
+		return precPostfix
+	default:
+		return precAtom
+	}
+}
+
+func logicPrecedence(op string) (int, associativity) {
+	switch op {
+	case ast.LogicOperatorImplies:
+		return precImplies, assocRight
+	case ast.LogicOperatorEquiv:
+		return precEquiv, assocLeft
+	case ast.LogicOperatorOr:
+		return precOr, assocLeft
+	default:
+		return precAnd, assocLeft
+	}
+}
+
+func arithmeticPrecedence(op string) (int, associativity) {
+	switch op {
+	case ast.ArithmeticOperatorPower:
+		return precPower, assocRight
+	case ast.ArithmeticOperatorAdd, ast.ArithmeticOperatorSubtract:
+		return precAdditive, assocLeft
+	default:
+		return precMultiply, assocLeft
+	}
+}
+
+// setComparisonSymbol spells a set comparison; set equality shares == and != with values.
+func setComparisonSymbol(op string) string {
+	switch op {
+	case ast.EqualityOperatorEqual:
+		return "=="
+	case ast.EqualityOperatorNotEqual:
+		return "!="
+	default:
+		return _comparisonSymbols[op]
+	}
+}
+
+// printBinary prints a binary operator, wrapping operands as the precedence requires.
+func printBinary(left ast.Expression, op string, right ast.Expression, prec int, assoc associativity) string {
+	return wrap(left, prec, assoc, true) + " " + op + " " + wrap(right, prec, assoc, false)
+}
+
+// printNary prints an n-ary operator whose operands all sit at one level.
+func printNary(operands []ast.Expression, op string, prec int) string {
+	parts := make([]string, len(operands))
+	for i, operand := range operands {
+		parts[i] = wrap(operand, prec, assocNone, i == 0)
+	}
+	return strings.Join(parts, " "+op+" ")
+}
+
+// wrap prints an operand, parenthesizing it when it binds less tightly than
+// its parent (or equally, on the side the parent's associativity does not group).
+// Open forms are always wrapped since they would swallow the rest of the expression.
+func wrap(child ast.Expression, parentPrec int, parentAssoc associativity, isLeft bool) string {
+	if child == nil {
+		return ""
+	}
+	s := printExpr(child)
+	childPrec := precedenceOf(child)
+	var needsParens bool
+	switch {
+	case childPrec == precOpen || childPrec < parentPrec:
+		needsParens = true
+	case childPrec > parentPrec:
+		needsParens = false
+	case parentAssoc == assocLeft:
+		needsParens = !isLeft
+	case parentAssoc == assocRight:
+		needsParens = isLeft
+	default:
+		needsParens = true
+	}
+	if needsParens {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// printList prints comma-separated expressions.
+func printList(elems []ast.Expression) string {
+	parts := make([]string, len(elems))
+	for i, e := range elems {
+		parts[i] = printExpr(e)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// printCase prints case { c1 -> r1; c2 -> r2; otherwise -> r }.
+func printCase(e *ast.CaseExpr) string {
+	parts := make([]string, 0, len(e.Branches)+1)
+	for _, branch := range e.Branches {
+		parts = append(parts, printExpr(branch.Condition)+" -> "+printExpr(branch.Result))
+	}
+	if e.Other != nil {
+		parts = append(parts, keywordOtherwise+" -> "+printExpr(e.Other))
+	}
+	return keywordCase + " {" + strings.Join(parts, "; ") + "}"
+}
+
+// printNumber prints a number literal, spelling TLA+ \h, \o, \b prefixes as 0x, 0o, 0b.
+// A decimal without an integer part (TLA+ ".5") gets a leading zero.
+func printNumber(n *ast.NumberLiteral) string {
+	switch n.Base {
+	case ast.BaseHex:
+		return "0x" + n.IntegerPart
+	case ast.BaseOctal:
+		return "0o" + n.IntegerPart
+	case ast.BaseBinary:
+		return "0b" + n.IntegerPart
+	case ast.BaseDecimal:
+	}
+	if n.IntegerPart == "" {
+		return "0" + n.String()
+	}
+	return n.String()
+}
+
+// escapeString escapes the characters the lexer treats specially inside strings.
+func escapeString(s string) string {
+	var sb strings.Builder
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\t':
+			sb.WriteString(`\t`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\f':
+			sb.WriteString(`\f`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
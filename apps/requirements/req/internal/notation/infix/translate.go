@@ -0,0 +1,32 @@
+package infix
+
+import (
+	"fmt"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+	tlaparser "github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
+)
+
+// ToTLAPlus translates an infix specification into TLA+ text.
+func ToTLAPlus(specification string) (string, error) {
+	expr, err := Parse(specification)
+	if err != nil {
+		return "", err
+	}
+	return ast.Print(expr), nil
+}
+
+// FromTLAPlus translates a TLA+ specification into infix text.
+// The result is re-parsed so that TLA+ names that are infix keywords
+// (e.g. a variable named "in") are reported rather than printed ambiguously.
+func FromTLAPlus(specification string) (string, error) {
+	expr, err := tlaparser.ParseExpression(specification)
+	if err != nil {
+		return "", err
+	}
+	text := Print(expr)
+	if _, err := Parse(text); err != nil {
+		return "", fmt.Errorf("no infix equivalent for %q: %w", specification, err)
+	}
+	return text, nil
+}
@@ -0,0 +1,82 @@
+package infix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type TranslateTestSuite struct {
+	suite.Suite
+}
+
+func TestTranslateSuite(t *testing.T) {
+	suite.Run(t, new(TranslateTestSuite))
+}
+
+func (s *TranslateTestSuite) TestTranslate() {
+	tests := []struct {
+		infix string
+		tla   string
+	}{
+		{infix: "a && b || !c", tla: "a ∧ b ∨ ¬c"},
+		{infix: "a => b", tla: "a ⇒ b"},
+		{infix: "x == 1 && y != 2", tla: "x = 1 ∧ y ≠ 2"},
+		{infix: "x not in S", tla: "x ∉ S"},
+		{infix: "A subseteq B", tla: "A ⊆ B"},
+		{infix: "(A union B) without C", tla: "(A ∪ B) \\ C"},
+		{infix: "A cross B", tla: "A × B"},
+		{infix: "a div b + 1", tla: "a ÷ b + 1"},
+		{infix: "a % b", tla: "a % b"},
+		{infix: "1..n", tla: "1..n"},
+		{infix: "B1 (+) B2", tla: "B1 ⊕ B2"},
+		{infix: "s ++ t", tla: "s ∘ t"},
+		{infix: "[1, 2]", tla: "⟨1, 2⟩"},
+		{infix: "{a: 1}", tla: "[a ↦ 1]"},
+		{infix: "[a: Nat]", tla: "[a: Nat]"},
+		{infix: "self.count' == self.count + 1", tla: "self.count' = self.count + 1"},
+		{infix: "r with {count: @ + 1}", tla: "[r EXCEPT !.count = @ + 1]"},
+		{infix: "all x in S: x > 0", tla: "∀ x ∈ S : x > 0"},
+		{infix: "exists x in S: x.active", tla: "∃ x ∈ S : x.active"},
+		{infix: "choose x in S: x > 0", tla: "CHOOSE x ∈ S : x > 0"},
+		{infix: "{x in S where x > 0}", tla: "{x ∈ S : x > 0}"},
+		{infix: "{x * 2 for x in S}", tla: "{x * 2 : x ∈ S}"},
+		{infix: "if a then 1 else 2", tla: "IF a THEN 1 ELSE 2"},
+		{infix: "let x = 1; x + 1", tla: "LET x == 1 IN x + 1"},
+		{infix: "let f[n in Nat] = if n == 0 then 1 else n * f[n - 1]; f[5]", tla: "LET f[n ∈ Nat] == IF n = 0 THEN 1 ELSE n * f[n - 1] IN f[5]"},
+		{infix: "case {a -> 1; otherwise -> 2}", tla: "CASE a → 1 □ OTHER → 2"},
+		{infix: "Accounts::Account::Close()", tla: "Accounts!Account!Close()"},
+		{infix: "_new(x)", tla: "«new»(x)"},
+		{infix: "_Bags::EmptyBag()", tla: "_Bags!EmptyBag"},
+		{infix: "_Seq::SelectSeq(s, _IsEven)", tla: "_Seq!SelectSeq(s, _IsEven)"},
+		{infix: "0xff", tla: "\\hff"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.infix, func() {
+			tla, err := ToTLAPlus(tt.infix)
+			s.Require().NoError(err)
+			s.Equal(tt.tla, tla)
+
+			infix, err := FromTLAPlus(tt.tla)
+			s.Require().NoError(err)
+			s.Equal(tt.infix, infix)
+		})
+	}
+}
+
+func (s *TranslateTestSuite) TestFromTLAPlusASCII() {
+	infix, err := FromTLAPlus(`x \in S /\ y' = [y EXCEPT !.n = 1] /\ \A z \in T : z >= 0`)
+	s.Require().NoError(err)
+	s.Equal("x in S && y' == y with {n: 1} && (all z in T: z >= 0)", infix)
+}
+
+func (s *TranslateTestSuite) TestErrors() {
+	_, err := ToTLAPlus("a &&")
+	s.Require().ErrorContains(err, "infix parse error: line 1, column 5")
+
+	_, err = FromTLAPlus("a ∧")
+	s.Require().ErrorContains(err, "parse error")
+
+	_, err = FromTLAPlus("in ∧ b")
+	s.Require().ErrorContains(err, `no infix equivalent for "in ∧ b"`)
+}
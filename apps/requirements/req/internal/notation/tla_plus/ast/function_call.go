@@ -59,6 +59,18 @@ func (f *FunctionCall) IsGlobalOrBuiltin() bool {
 	return strings.HasPrefix(f.Name.Value, "_")
 }
 
+// moduleConstants holds the nullary standard-module operators, by full name.
+// Nat, Int, and Real are set constants rather than module calls, so are not listed.
+var moduleConstants = map[string]bool{
+	"_Bags!EmptyBag": true,
+}
+
+// IsModuleConstant reports whether this is a nullary standard-module operator such as
+// _Bags!EmptyBag. TLA+ writes constant operators without parentheses.
+func (f *FunctionCall) IsModuleConstant() bool {
+	return len(f.ScopePath) == 1 && len(f.Args) == 0 && moduleConstants[f.FullName()]
+}
+
 // IsSystemEvent reports whether this call is a reserved system event constructor.
 // Canonical TLA uses guillemets («new»); ASCII authoring uses a leading underscore (_new).
 func (f *FunctionCall) IsSystemEvent() bool {
@@ -72,6 +84,9 @@ func (f *FunctionCall) String() string {
 		out.WriteString("!")
 	}
 	out.WriteString(f.Name.String())
+	if f.IsModuleConstant() {
+		return out.String()
+	}
 	out.WriteString("(")
 	for i, arg := range f.Args {
 		if i > 0 {
@@ -99,6 +114,9 @@ func (f *FunctionCall) ASCII() string {
 		name = ascii
 	}
 	out.WriteString(name)
+	if f.IsModuleConstant() {
+		return out.String()
+	}
 	out.WriteString("(")
 	for i, arg := range f.Args {
 		if i > 0 {
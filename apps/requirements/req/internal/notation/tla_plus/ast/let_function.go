@@ -0,0 +1,59 @@
+package ast
+
+import (
+	"bytes"
+	"fmt"
+)
+
+// LetFunction is a local recursive function definition: LET f[x ∈ S] == value IN body.
+// Within value and body, f[arg] applies the function.
+type LetFunction struct {
+	Name       string     `validate:"required"`
+	Membership Expression `validate:"required"` // The parameter binding: x ∈ S.
+	Value      Expression `validate:"required"`
+	Body       Expression `validate:"required"`
+}
+
+func (e *LetFunction) expressionNode() {}
+
+func (e *LetFunction) String() string {
+	var out bytes.Buffer
+	out.WriteString("LET ")
+	out.WriteString(e.Name)
+	out.WriteString("[")
+	out.WriteString(e.Membership.String())
+	out.WriteString("] == ")
+	out.WriteString(e.Value.String())
+	out.WriteString(" IN ")
+	out.WriteString(e.Body.String())
+	return out.String()
+}
+
+func (e *LetFunction) ASCII() string {
+	var out bytes.Buffer
+	out.WriteString("LET ")
+	out.WriteString(e.Name)
+	out.WriteString("[")
+	out.WriteString(e.Membership.ASCII())
+	out.WriteString("] == ")
+	out.WriteString(e.Value.ASCII())
+	out.WriteString(" IN ")
+	out.WriteString(e.Body.ASCII())
+	return out.String()
+}
+
+func (e *LetFunction) Validate() error {
+	if err := _validate.Struct(e); err != nil {
+		return err
+	}
+	if err := e.Membership.Validate(); err != nil {
+		return fmt.Errorf("membership: %w", err)
+	}
+	if err := e.Value.Validate(); err != nil {
+		return fmt.Errorf("value: %w", err)
+	}
+	if err := e.Body.Validate(); err != nil {
+		return fmt.Errorf("body: %w", err)
+	}
+	return nil
+}
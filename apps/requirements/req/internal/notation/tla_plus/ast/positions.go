@@ -0,0 +1,28 @@
+package ast
+
+// Positions maps parsed nodes to the byte offset in the source text at which each starts.
+// Parsers record the nodes they build directly; a node assembled from others, such as one
+// link of a chain of additions, may have no entry of its own.
+type Positions map[Node]int
+
+// Record notes that node starts at offset, keeping an earlier record. Parsers build
+// inner nodes first, so the first record is where the node itself starts rather than
+// where an enclosing rule that passed it along began.
+func (p Positions) Record(node Node, offset int) {
+	if p == nil || node == nil {
+		return
+	}
+	if _, ok := p[node]; !ok {
+		p[node] = offset
+	}
+}
+
+// Offset returns the offset of the first node that has one.
+func (p Positions) Offset(nodes ...Node) (int, bool) {
+	for _, node := range nodes {
+		if offset, ok := p[node]; ok {
+			return offset, true
+		}
+	}
+	return 0, false
+}
@@ -16,7 +16,29 @@ func Print(expr Expression) string {
 	return p.print(expr)
 }
 
-type printer struct{}
+// PrintNormalized is like Print, but drops the parentheses an expression was written
+// with, so expressions that differ only in redundant parentheses print the same.
+func PrintNormalized(expr Expression) string {
+	p := &printer{normalize: true}
+	return p.print(expr)
+}
+
+type printer struct {
+	normalize bool // Parenthesize by precedence alone.
+}
+
+// unparenthesized is an expression without the parentheses it was written with, when
+// normalizing.
+func (p *printer) unparenthesized(expr Expression) Expression {
+	for p.normalize {
+		parenthesized, ok := expr.(*Parenthesized)
+		if !ok {
+			break
+		}
+		expr = parenthesized.Inner
+	}
+	return expr
+}
 
 // print returns the TLA+ string for an expression.
 //
@@ -82,6 +104,9 @@ func (p *printer) print(expr Expression) string {
 
 	// --- Parenthesized ---
 	case *Parenthesized:
+		if p.normalize {
+			return p.print(e.Inner)
+		}
 		return "(" + p.print(e.Inner) + ")"
 
 	// --- Binary logic ---
@@ -232,6 +257,8 @@ func (p *printer) print(expr Expression) string {
 	// --- LET / CHOOSE ---
 	case *LetExpr:
 		return "LET " + e.Variable + " == " + p.print(e.Value) + " IN " + p.print(e.Body)
+	case *LetFunction:
+		return "LET " + e.Name + "[" + p.print(e.Membership) + "] == " + p.print(e.Value) + " IN " + p.print(e.Body)
 	case *ChooseExpr:
 		return "CHOOSE " + p.print(e.Membership) + " : " + p.print(e.Predicate)
 
@@ -264,6 +291,9 @@ func (p *printer) print(expr Expression) string {
 			sb.WriteString("!")
 		}
 		sb.WriteString(e.Name.Value)
+		if e.IsModuleConstant() {
+			return sb.String()
+		}
 		sb.WriteString("(")
 		for i, arg := range e.Args {
 			if i > 0 {
@@ -300,6 +330,7 @@ func (p *printer) wrap(child Expression, parentPrec int, parentAssoc associativi
 	if child == nil {
 		return ""
 	}
+	child = p.unparenthesized(child)
 	childInfo := precedenceOf(child)
 	s := p.print(child)
 	if needsParens(childInfo.prec, parentPrec, pos, parentAssoc) {
@@ -354,6 +385,7 @@ func (p *printer) printCase(e *CaseExpr) string {
 // wrapCaseExpr wraps a CASE condition/result expression in parentheses
 // if it would be ambiguous at the OrExpr precedence level.
 func (p *printer) wrapCaseExpr(expr Expression) string {
+	expr = p.unparenthesized(expr)
 	info := precedenceOf(expr)
 	s := p.print(expr)
 	// CASE internals are parsed at OrExpr level, so anything with
@@ -0,0 +1,387 @@
+package ast
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// _latexOperators spells the TLA+ operators in LaTeX math, in the style of
+// TLA+ pretty-printing (∀, ∈, ≜ and so on). Operators missing here are
+// written as they are, such as + and <.
+var _latexOperators = map[string]string{
+	LogicOperatorAnd:             `\land`,
+	LogicOperatorOr:              `\lor`,
+	LogicOperatorImplies:         `\Rightarrow`,
+	LogicOperatorEquiv:           `\equiv`,
+	LogicOperatorNot:             `\lnot`,
+	QuantifierForAll:             `\forall`,
+	QuantifierExists:             `\exists`,
+	MembershipOperatorIn:         `\in`,
+	MembershipOperatorNotIn:      `\notin`,
+	EqualityOperatorNotEqual:     `\neq`,
+	ComparisonLessThanOrEqual:    `\leq`,
+	ComparisonGreaterThanOrEqual: `\geq`,
+	SetComparisonSubsetEq:        `\subseteq`,
+	SetComparisonSubset:          `\subset`,
+	SetComparisonSupersetEq:      `\supseteq`,
+	SetComparisonSuperset:        `\supset`,
+	BagComparisonProperSubBag:    `\sqsubset`,
+	BagComparisonSubBag:          `\sqsubseteq`,
+	BagComparisonProperSupBag:    `\sqsupset`,
+	BagComparisonSupBag:          `\sqsupseteq`,
+	SetOperatorUnion:             `\cup`,
+	SetOperatorIntersection:      `\cap`,
+	SetOperatorDifference:        `\setminus`,
+	BagOperatorUnion:             `\oplus`,
+	BagOperatorSubtraction:       `\ominus`,
+	ArithmeticOperatorDivide:     `\div`,
+	ArithmeticOperatorModulo:     `\%`,
+	TupleOperatorConcat:          `\circ`,
+}
+
+// PrintLaTeX converts a TLA+ AST expression into LaTeX math, typeset in the
+// style of TLA+ pretty-printing: ∧, ∀, ∈ and primes as math symbols, keywords
+// in small capitals, and names in italics. It parenthesizes like Print.
+//
+// The result goes inside math mode and needs the amsmath and amssymb packages.
+func PrintLaTeX(expr Expression) string {
+	p := &latexPrinter{}
+	return p.print(expr)
+}
+
+type latexPrinter struct{}
+
+// print returns the LaTeX math for an expression.
+//
+//complexity:cyclo:warn=70,fail=70 Mostly simple routing switch.
+//complexity:fanout:warn=70,fail=70 Mostly simple routing switch.
+func (p *latexPrinter) print(expr Expression) string {
+	switch e := expr.(type) {
+	// --- Literals ---
+	case *BooleanLiteral:
+		if e.Value {
+			return latexKeyword(LiteralTrue)
+		}
+		return latexKeyword(LiteralFalse)
+
+	case *NumberLiteral:
+		return e.String()
+
+	case *StringLiteral:
+		return latexString(e.Value)
+
+	case *SetLiteral:
+		return p.printDelimited(`\{`, e.Elements, `\}`)
+
+	case *SetLiteralEnum:
+		parts := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			parts[i] = latexString(v)
+		}
+		return `\{` + strings.Join(parts, ", ") + `\}`
+
+	case *SetLiteralInt:
+		parts := make([]string, len(e.Values))
+		for i, v := range e.Values {
+			parts[i] = fmt.Sprintf("%d", v)
+		}
+		return `\{` + strings.Join(parts, ", ") + `\}`
+
+	case *TupleLiteral:
+		return p.printDelimited(`\langle `, e.Elements, ` \rangle`)
+
+	case *RecordInstance:
+		parts := make([]string, len(e.Bindings))
+		for i, b := range e.Bindings {
+			parts[i] = latexName(b.Field.Value) + ` \mapsto ` + p.print(b.Expression)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+
+	case *RecordTypeExpr:
+		parts := make([]string, len(e.Fields))
+		for i, f := range e.Fields {
+			parts[i] = latexName(f.Name.Value) + " : " + p.print(f.Type)
+		}
+		return "[" + strings.Join(parts, ", ") + "]"
+
+	case *SetConstant:
+		if e.Value == SetConstantBoolean {
+			return latexKeyword(e.Value)
+		}
+		return latexName(e.Value)
+
+	case *Identifier:
+		return latexName(e.Value)
+
+	case *ExistingValue:
+		return "@"
+
+	case *Parenthesized:
+		return "(" + p.print(e.Inner) + ")"
+
+	// --- Operators ---
+	case *BinaryLogic:
+		info := logicOpInfo(e.Operator)
+		return p.printBinary(e.Left, e.Operator, e.Right, info.prec, info.assoc)
+
+	case *UnaryLogic:
+		return latexOperator(LogicOperatorNot) + " " + p.wrap(e.Right, precNot, assocPrefix, posOnly)
+
+	case *BinaryEquality:
+		return p.printBinary(e.Left, e.Operator, e.Right, precEquality, assocNone)
+
+	case *BinaryComparison:
+		return p.printBinary(e.Left, e.Operator, e.Right, precNumCompare, assocNone)
+
+	case *BinaryArithmetic:
+		info := arithOpInfo(e.Operator)
+		if e.Operator == ArithmeticOperatorPower {
+			return p.wrap(e.Left, info.prec, info.assoc, posLeft) + "^{" + p.print(e.Right) + "}"
+		}
+		return p.printBinary(e.Left, e.Operator, e.Right, info.prec, info.assoc)
+
+	case *Fraction:
+		return p.printBinary(e.Numerator, "/", e.Denominator, precFraction, assocLeft)
+
+	case *UnaryNegation:
+		return "-" + p.wrap(e.Right, precNegate, assocPrefix, posOnly)
+
+	case *BinarySetOperation:
+		info := setOpInfo(e.Operator)
+		return p.printBinary(e.Left, e.Operator, e.Right, info.prec, info.assoc)
+
+	case *BinarySetComparison:
+		return p.printBinary(e.Left, e.Operator, e.Right, precSetCompare, assocNone)
+
+	case *BinaryBagOperation:
+		info := bagOpInfo(e.Operator)
+		return p.printBinary(e.Left, e.Operator, e.Right, info.prec, info.assoc)
+
+	case *BinaryBagComparison:
+		return p.printBinary(e.Left, e.Operator, e.Right, precBagCompare, assocNone)
+
+	case *Membership:
+		return p.printBinary(e.Left, e.Operator, e.Right, precMembership, assocNone)
+
+	case *CartesianProduct:
+		return p.printNaryOp(e.Operands, `\times`, precCartesian, assocLeft)
+
+	case *SetRangeExpr:
+		return p.wrap(e.Start, precRange, assocNone, posLeft) + ` \mathrel{..} ` + p.wrap(e.End, precRange, assocNone, posRight)
+
+	case *SetRange:
+		return fmt.Sprintf(`%d \mathrel{..} %d`, e.Start, e.End)
+
+	case *TupleConcat:
+		return p.printNaryOp(e.Operands, latexOperator(TupleOperatorConcat), precConcat, assocLeft)
+
+	case *StringConcat:
+		return p.printNaryOp(e.Operands, latexOperator(StringOperatorConcat), precConcat, assocLeft)
+
+	// --- Access ---
+	case *FieldAccess:
+		base := p.wrap(e.GetBase(), precFieldIndex, assocLeft, posLeft)
+		if e.GetBase() == nil {
+			base = "!"
+		}
+		return base + "." + latexName(e.Member)
+
+	case *TupleIndex:
+		return p.wrap(e.Tuple, precFieldIndex, assocLeft, posLeft) + "[" + p.print(e.Index) + "]"
+
+	case *StringIndex:
+		return p.wrap(e.Str, precFieldIndex, assocLeft, posLeft) + "[" + p.print(e.Index) + "]"
+
+	case *Primed:
+		return p.wrap(e.Base, precPrime, assocPostfix, posOnly) + "'"
+
+	// --- Set map / filter ---
+	case *SetMap:
+		return `\{` + p.print(e.Transform) + " : " + p.print(e.Membership) + `\}`
+	case *SetFilter:
+		return `\{` + p.print(e.Membership) + " : " + p.print(e.Predicate) + `\}`
+
+	// --- Open forms ---
+	case *Quantifier:
+		return latexOperator(e.Quantifier) + " " + p.print(e.Membership) + " : " + p.print(e.Predicate)
+
+	case *IfThenElse:
+		return latexKeyword("IF") + `\; ` + p.print(e.Condition) +
+			`\; ` + latexKeyword("THEN") + `\; ` + p.print(e.Then) +
+			`\; ` + latexKeyword("ELSE") + `\; ` + p.print(e.Else)
+
+	case *LetExpr:
+		return latexKeyword("LET") + `\; ` + latexName(e.Variable) + ` \triangleq ` + p.print(e.Value) +
+			`\; ` + latexKeyword("IN") + `\; ` + p.print(e.Body)
+	case *LetFunction:
+		return latexKeyword("LET") + `\; ` + latexName(e.Name) + "[" + p.print(e.Membership) + `] \triangleq ` + p.print(e.Value) +
+			`\; ` + latexKeyword("IN") + `\; ` + p.print(e.Body)
+	case *ChooseExpr:
+		return latexKeyword("CHOOSE") + `\; ` + p.print(e.Membership) + " : " + p.print(e.Predicate)
+
+	case *CaseExpr:
+		return p.printCase(e)
+
+	case *RecordAltered:
+		parts := make([]string, len(e.Alterations))
+		for i, alt := range e.Alterations {
+			parts[i] = p.print(alt.Field) + " = " + p.print(alt.Expression)
+		}
+		return "[" + p.print(e.Base) + `\; ` + latexKeyword("EXCEPT") + `\; ` + strings.Join(parts, ", ") + "]"
+
+	// --- Calls ---
+	case *FunctionCall:
+		var sb strings.Builder
+		for _, seg := range e.ScopePath {
+			sb.WriteString(latexName(seg.Value))
+			sb.WriteString("!")
+		}
+		sb.WriteString(latexName(e.Name.Value))
+		if e.IsModuleConstant() {
+			return sb.String()
+		}
+		return sb.String() + p.printDelimited("(", e.Args, ")")
+
+	case *BuiltinCall:
+		segments := strings.Split(e.Name, "!")
+		for i, segment := range segments {
+			segments[i] = latexName(segment)
+		}
+		return strings.Join(segments, "!") + p.printDelimited("(", e.Args, ")")
+
+	default:
+		// Fallback to the node's own String() method, as text.
+		return `\text{` + latexText(expr.String()) + `}`
+	}
+}
+
+// wrap prints a child expression, wrapping it in parentheses as Print would.
+func (p *latexPrinter) wrap(child Expression, parentPrec int, parentAssoc associativity, pos childPosition) string {
+	if child == nil {
+		return ""
+	}
+	s := p.print(child)
+	if needsParens(precedenceOf(child).prec, parentPrec, pos, parentAssoc) {
+		return "(" + s + ")"
+	}
+	return s
+}
+
+// printBinary prints a binary operator between its wrapped operands.
+func (p *latexPrinter) printBinary(left Expression, op string, right Expression, prec int, assoc associativity) string {
+	return p.wrap(left, prec, assoc, posLeft) + " " + latexOperator(op) + " " + p.wrap(right, prec, assoc, posRight)
+}
+
+// printDelimited prints a list of expressions with delimiters.
+func (p *latexPrinter) printDelimited(open string, elems []Expression, closeStr string) string {
+	parts := make([]string, len(elems))
+	for i, e := range elems {
+		parts[i] = p.print(e)
+	}
+	return open + strings.Join(parts, ", ") + closeStr
+}
+
+// printNaryOp prints n-ary operators with correct wrapping.
+func (p *latexPrinter) printNaryOp(operands []Expression, op string, prec int, assoc associativity) string {
+	parts := make([]string, len(operands))
+	for i, operand := range operands {
+		pos := posLeft
+		if i == len(operands)-1 {
+			pos = posRight
+		}
+		parts[i] = p.wrap(operand, prec, assoc, pos)
+	}
+	return strings.Join(parts, " "+op+" ")
+}
+
+// printCase prints a CASE expression, wrapping its parts as Print does.
+func (p *latexPrinter) printCase(e *CaseExpr) string {
+	part := func(expr Expression) string {
+		if precedenceOf(expr).prec < precOr {
+			return "(" + p.print(expr) + ")"
+		}
+		return p.print(expr)
+	}
+	var sb strings.Builder
+	sb.WriteString(latexKeyword("CASE") + `\; `)
+	for i, branch := range e.Branches {
+		if i > 0 {
+			sb.WriteString(` \;\Box\; `)
+		}
+		sb.WriteString(part(branch.Condition) + ` \rightarrow ` + part(branch.Result))
+	}
+	if e.Other != nil {
+		sb.WriteString(` \;\Box\; ` + latexKeyword("OTHER") + ` \rightarrow ` + part(e.Other))
+	}
+	return sb.String()
+}
+
+// latexOperator spells an operator in LaTeX math.
+func latexOperator(op string) string {
+	if symbol, ok := _latexOperators[op]; ok {
+		return symbol
+	}
+	return op
+}
+
+// latexKeyword sets a keyword such as IF or TRUE in small capitals.
+func latexKeyword(keyword string) string {
+	return `\textsc{` + strings.ToLower(keyword) + `}`
+}
+
+// latexName sets a name in italics: a single letter as a math variable, a longer
+// name as one italic word, and a name with other characters (such as «new») as text.
+func latexName(name string) string {
+	switch {
+	case len(name) == 1 && isLatexLetter(name[0]):
+		return name
+	case isASCII(name):
+		return `\mathit{` + latexText(name) + `}`
+	default:
+		return `\textit{` + latexText(name) + `}`
+	}
+}
+
+// latexString sets a string literal as quoted text.
+func latexString(value string) string {
+	return "\\text{``" + latexText(value) + "''}"
+}
+
+// latexText escapes text for LaTeX text mode.
+func latexText(text string) string {
+	var sb strings.Builder
+	for _, r := range text {
+		switch r {
+		case '\\':
+			sb.WriteString(`\textbackslash{}`)
+		case '{', '}', '$', '&', '#', '%', '_':
+			sb.WriteRune('\\')
+			sb.WriteRune(r)
+		case '^':
+			sb.WriteString(`\textasciicircum{}`)
+		case '~':
+			sb.WriteString(`\textasciitilde{}`)
+		case '«':
+			sb.WriteString(`\guillemotleft{}`)
+		case '»':
+			sb.WriteString(`\guillemotright{}`)
+		default:
+			sb.WriteRune(r)
+		}
+	}
+	return sb.String()
+}
+
+func isLatexLetter(c byte) bool {
+	return (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z')
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] >= utf8.RuneSelf {
+			return false
+		}
+	}
+	return true
+}
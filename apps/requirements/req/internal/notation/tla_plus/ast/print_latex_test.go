@@ -0,0 +1,138 @@
+package ast
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PrintLaTeXTestSuite struct {
+	suite.Suite
+}
+
+func TestPrintLaTeXSuite(t *testing.T) {
+	suite.Run(t, new(PrintLaTeXTestSuite))
+}
+
+// --- Literals and names ---
+
+func (s *PrintLaTeXTestSuite) TestPrintLiterals() {
+	s.Equal(`\textsc{true}`, PrintLaTeX(&BooleanLiteral{Value: true}))
+	s.Equal(`3.14`, PrintLaTeX(NewDecimalNumberLiteral("3", "14")))
+	s.Equal("\\text{``50\\% off''}", PrintLaTeX(&StringLiteral{Value: "50% off"}))
+	s.Equal(`\{1, 2\}`, PrintLaTeX(&SetLiteral{Elements: []Expression{NewNumberLiteral("1"), NewNumberLiteral("2")}}))
+	s.Equal(`\langle 1 \rangle`, PrintLaTeX(&TupleLiteral{Elements: []Expression{NewNumberLiteral("1")}}))
+	s.Equal(`[a \mapsto 1]`, PrintLaTeX(&RecordInstance{
+		Bindings: []*FieldBinding{{Field: &Identifier{Value: "a"}, Expression: NewNumberLiteral("1")}},
+	}))
+	s.Equal(`\mathit{Nat}`, PrintLaTeX(&SetConstant{Value: SetConstantNat}))
+	s.Equal(`\textsc{boolean}`, PrintLaTeX(&SetConstant{Value: SetConstantBoolean}))
+}
+
+func (s *PrintLaTeXTestSuite) TestPrintNames() {
+	s.Equal(`x`, PrintLaTeX(&Identifier{Value: "x"}))
+	s.Equal(`\mathit{is\_open}`, PrintLaTeX(&Identifier{Value: "is_open"}))
+	s.Equal(`\textit{\guillemotleft{}new\guillemotright{}}()`, PrintLaTeX(&FunctionCall{Name: &Identifier{Value: "«new»"}}))
+	s.Equal(`\mathit{\_Seq}!\mathit{Len}(s)`, PrintLaTeX(&BuiltinCall{Name: "_Seq!Len", Args: []Expression{&Identifier{Value: "s"}}}))
+}
+
+// --- Operators ---
+
+func (s *PrintLaTeXTestSuite) TestPrintOperators() {
+	s.Equal(`a \land b \lor \lnot c`, PrintLaTeX(&BinaryLogic{
+		Operator: "∨",
+		Left:     &BinaryLogic{Operator: "∧", Left: &Identifier{Value: "a"}, Right: &Identifier{Value: "b"}},
+		Right:    &UnaryLogic{Operator: "¬", Right: &Identifier{Value: "c"}},
+	}))
+	s.Equal(`(a \Rightarrow b) \Rightarrow c`, PrintLaTeX(&BinaryLogic{
+		Operator: "⇒",
+		Left:     &BinaryLogic{Operator: "⇒", Left: &Identifier{Value: "a"}, Right: &Identifier{Value: "b"}},
+		Right:    &Identifier{Value: "c"},
+	}))
+	s.Equal(`x \neq 1`, PrintLaTeX(&BinaryEquality{Operator: "≠", Left: &Identifier{Value: "x"}, Right: NewNumberLiteral("1")}))
+	s.Equal(`x \leq 1`, PrintLaTeX(&BinaryComparison{Operator: "≤", Left: &Identifier{Value: "x"}, Right: NewNumberLiteral("1")}))
+	s.Equal(`A \setminus (B \setminus C) \cap D`, PrintLaTeX(&BinarySetOperation{
+		Operator: `\`,
+		Left:     &Identifier{Value: "A"},
+		Right: &BinarySetOperation{
+			Operator: "∩",
+			Left:     &BinarySetOperation{Operator: `\`, Left: &Identifier{Value: "B"}, Right: &Identifier{Value: "C"}},
+			Right:    &Identifier{Value: "D"},
+		},
+	}))
+	s.Equal(`A \subseteq B`, PrintLaTeX(&BinarySetComparison{Operator: "⊆", Left: &Identifier{Value: "A"}, Right: &Identifier{Value: "B"}}))
+	s.Equal(`x \notin S`, PrintLaTeX(&Membership{Operator: "∉", Left: &Identifier{Value: "x"}, Right: &Identifier{Value: "S"}}))
+	s.Equal(`A \times B`, PrintLaTeX(&CartesianProduct{Operands: []Expression{&Identifier{Value: "A"}, &Identifier{Value: "B"}}}))
+	s.Equal(`1 \mathrel{..} n`, PrintLaTeX(&SetRangeExpr{Start: NewNumberLiteral("1"), End: &Identifier{Value: "n"}}))
+	s.Equal(`a \div b \% c`, PrintLaTeX(&BinaryArithmetic{
+		Operator: "%",
+		Left:     &BinaryArithmetic{Operator: "÷", Left: &Identifier{Value: "a"}, Right: &Identifier{Value: "b"}},
+		Right:    &Identifier{Value: "c"},
+	}))
+}
+
+func (s *PrintLaTeXTestSuite) TestPrintPower() {
+	s.Equal(`(a + 1)^{b^{c}}`, PrintLaTeX(&BinaryArithmetic{
+		Operator: "^",
+		Left:     &BinaryArithmetic{Operator: "+", Left: &Identifier{Value: "a"}, Right: NewNumberLiteral("1")},
+		Right:    &BinaryArithmetic{Operator: "^", Left: &Identifier{Value: "b"}, Right: &Identifier{Value: "c"}},
+	}))
+}
+
+// --- Access and prime ---
+
+func (s *PrintLaTeXTestSuite) TestPrintPrimedField() {
+	s.Equal(`\mathit{self}.\mathit{count}' = \mathit{self}.\mathit{count} + 1`, PrintLaTeX(&BinaryEquality{
+		Operator: "=",
+		Left:     &Primed{Base: &FieldAccess{Base: &Identifier{Value: "self"}, Member: "count"}},
+		Right: &BinaryArithmetic{
+			Operator: "+",
+			Left:     &FieldAccess{Base: &Identifier{Value: "self"}, Member: "count"},
+			Right:    NewNumberLiteral("1"),
+		},
+	}))
+}
+
+func (s *PrintLaTeXTestSuite) TestPrintRecordAltered() {
+	s.Equal(`[r\; \textsc{except}\; !.\mathit{count} = @ + 1]`, PrintLaTeX(&RecordAltered{
+		Base: &Identifier{Value: "r"},
+		Alterations: []*FieldAlteration{{
+			Field:      &FieldIdentifier{Member: "count"},
+			Expression: &BinaryArithmetic{Operator: "+", Left: &ExistingValue{}, Right: NewNumberLiteral("1")},
+		}},
+	}))
+}
+
+// --- Open forms ---
+
+func (s *PrintLaTeXTestSuite) TestPrintQuantifier() {
+	s.Equal(`\forall x \in S : x > 0`, PrintLaTeX(&Quantifier{
+		Quantifier: "∀",
+		Membership: &Membership{Operator: "∈", Left: &Identifier{Value: "x"}, Right: &Identifier{Value: "S"}},
+		Predicate:  &BinaryComparison{Operator: ">", Left: &Identifier{Value: "x"}, Right: NewNumberLiteral("0")},
+	}))
+	s.Equal(`\{x \in S : x > 0\}`, PrintLaTeX(&SetFilter{
+		Membership: &Membership{Operator: "∈", Left: &Identifier{Value: "x"}, Right: &Identifier{Value: "S"}},
+		Predicate:  &BinaryComparison{Operator: ">", Left: &Identifier{Value: "x"}, Right: NewNumberLiteral("0")},
+	}))
+}
+
+func (s *PrintLaTeXTestSuite) TestPrintKeywords() {
+	s.Equal(`\textsc{if}\; x > 0\; \textsc{then}\; x\; \textsc{else}\; 0`, PrintLaTeX(&IfThenElse{
+		Condition: &BinaryComparison{Operator: ">", Left: &Identifier{Value: "x"}, Right: NewNumberLiteral("0")},
+		Then:      &Identifier{Value: "x"},
+		Else:      NewNumberLiteral("0"),
+	}))
+	s.Equal(`\textsc{let}\; y \triangleq 2\; \textsc{in}\; y`, PrintLaTeX(&LetExpr{
+		Variable: "y",
+		Value:    NewNumberLiteral("2"),
+		Body:     &Identifier{Value: "y"},
+	}))
+	s.Equal(`\textsc{case}\; (a \Rightarrow b) \rightarrow 1 \;\Box\; \textsc{other} \rightarrow 0`, PrintLaTeX(&CaseExpr{
+		Branches: []*CaseBranch{{
+			Condition: &BinaryLogic{Operator: "⇒", Left: &Identifier{Value: "a"}, Right: &Identifier{Value: "b"}},
+			Result:    NewNumberLiteral("1"),
+		}},
+		Other: NewNumberLiteral("0"),
+	}))
+}
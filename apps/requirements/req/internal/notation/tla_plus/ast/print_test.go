@@ -496,6 +496,34 @@ func (s *PrintTestSuite) TestPrintFunctionCallScoped() {
 	}))
 }
 
+func (s *PrintTestSuite) TestPrintFunctionCallModuleConstant() {
+	call := &FunctionCall{
+		ScopePath: []*Identifier{{Value: "_Bags"}},
+		Name:      &Identifier{Value: "EmptyBag"},
+		Args:      []Expression{},
+	}
+	s.Equal("_Bags!EmptyBag", Print(call))
+	s.Equal("_Bags!EmptyBag", call.String())
+	s.Equal("_Bags!EmptyBag", call.ASCII())
+
+	// Zero-argument class actions keep their parentheses.
+	s.Equal("Account!Close()", Print(&FunctionCall{
+		ScopePath: []*Identifier{{Value: "Account"}},
+		Name:      &Identifier{Value: "Close"},
+		Args:      []Expression{},
+	}))
+
+	// So do zero-argument module calls that are not standard-module constants.
+	call = &FunctionCall{
+		ScopePath: []*Identifier{{Value: "_Foo"}},
+		Name:      &Identifier{Value: "_bar"},
+		Args:      []Expression{},
+	}
+	s.False(call.IsModuleConstant())
+	s.Equal("_Foo!_bar()", Print(call))
+	s.Equal("_Foo!_bar()", call.String())
+}
+
 // --- Equality ---
 
 func (s *PrintTestSuite) TestPrintEquality() {
@@ -578,3 +606,31 @@ func (s *PrintTestSuite) TestPrintSetLiteralInt() {
 		Values: []int{1, 2, 3},
 	}))
 }
+
+// --- PrintNormalized ---
+
+func (s *PrintTestSuite) TestPrintNormalized() {
+	// (a - (b)) - c: the written parentheses are redundant.
+	redundant := &BinaryArithmetic{
+		Operator: "-",
+		Left: NewParenthesized(&BinaryArithmetic{
+			Operator: "-",
+			Left:     &Identifier{Value: "a"},
+			Right:    NewParenthesized(&Identifier{Value: "b"}),
+		}),
+		Right: &Identifier{Value: "c"},
+	}
+	s.Equal("(a - (b)) - c", Print(redundant))
+	s.Equal("a - b - c", PrintNormalized(redundant))
+
+	// a - (b - c): the written parentheses are needed, and kept.
+	s.Equal("a - (b - c)", PrintNormalized(&BinaryArithmetic{
+		Operator: "-",
+		Left:     &Identifier{Value: "a"},
+		Right: NewParenthesized(&BinaryArithmetic{
+			Operator: "-",
+			Left:     &Identifier{Value: "b"},
+			Right:    &Identifier{Value: "c"},
+		}),
+	}))
+}
@@ -1,12 +1,17 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/infix"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
 )
 
 // ExpressionParseIssue records one TLA+ specification that failed to parse or lower.
@@ -15,12 +20,15 @@ type ExpressionParseIssue struct {
 	Location string
 	Message  string
 	SpecText string
+	Offset   int                // Byte offset of the problem within SpecText.
+	Position sourcepos.Position // Where the problem is in the model's source files, if known.
 }
 
 // CollectUnparsedExpressionIssues finds every non-empty ExpressionSpec that did not
 // lower successfully and diagnoses each with the strict parser so the web display
-// can show actionable error text.
-func CollectUnparsedExpressionIssues(model *core.Model) []ExpressionParseIssue {
+// can show actionable error text. When sources is not nil each issue is also given
+// the position of its problem in the model's source files.
+func CollectUnparsedExpressionIssues(model *core.Model, sources *sourcepos.Index) []ExpressionParseIssue {
 	globalFunctions := BuildGlobalFunctionMap(model)
 	namedSets := BuildNamedSetMap(model)
 	allActions := BuildAllActionsMap(model)
@@ -32,10 +40,9 @@ func CollectUnparsedExpressionIssues(model *core.Model) []ExpressionParseIssue {
 		NamedSets:       namedSets,
 		AllActions:      allActions,
 	}
-	modelPF := NewExpressionParseFuncStrict(modelCtx)
 
 	for i := range model.Invariants {
-		if issue := diagnoseUnparsedSpec(&model.Invariants[i].Spec, modelPF, identity.Key{}, fmt.Sprintf("model invariant %d", i)); issue != nil {
+		if issue := diagnoseUnparsedSpec(&model.Invariants[i].Spec, modelCtx, identity.Key{}, fmt.Sprintf("model invariant %d", i)); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
@@ -48,16 +55,15 @@ func CollectUnparsedExpressionIssues(model *core.Model) []ExpressionParseIssue {
 			AllActions:      allActions,
 			Parameters:      params,
 		}
-		gfPF := NewExpressionParseFuncStrict(gfCtx)
 		loc := fmt.Sprintf("global function %q", gfKey.String())
-		if issue := diagnoseUnparsedSpec(&gf.Logic.Spec, gfPF, identity.Key{}, loc); issue != nil {
+		if issue := diagnoseUnparsedSpec(&gf.Logic.Spec, gfCtx, identity.Key{}, loc); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
 
 	for nsKey, ns := range model.NamedSets {
 		loc := fmt.Sprintf("named set %q", nsKey.String())
-		if issue := diagnoseUnparsedSpec(&ns.Spec, modelPF, identity.Key{}, loc); issue != nil {
+		if issue := diagnoseUnparsedSpec(&ns.Spec, modelCtx, identity.Key{}, loc); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
@@ -76,6 +82,10 @@ func CollectUnparsedExpressionIssues(model *core.Model) []ExpressionParseIssue {
 		}
 	}
 
+	for i := range issues {
+		issues[i].Position = sources.Locate(issues[i].ClassKey.String(), issues[i].SpecText, issues[i].Offset)
+	}
+
 	return issues
 }
 
@@ -86,13 +96,12 @@ func collectClassExpressionIssues(
 	classes map[identity.Key]model_class.Class,
 ) []ExpressionParseIssue {
 	classCtx := NewClassLowerContext(class, globalFunctions, namedSets, allActions, associations, classes)
-	classPF := NewExpressionParseFuncStrict(classCtx)
 
 	var issues []ExpressionParseIssue
 
 	for i := range class.Invariants {
 		loc := fmt.Sprintf("class invariant %d", i)
-		if issue := diagnoseUnparsedSpec(&class.Invariants[i].Spec, classPF, class.Key, loc); issue != nil {
+		if issue := diagnoseUnparsedSpec(&class.Invariants[i].Spec, classCtx, class.Key, loc); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
@@ -100,13 +109,13 @@ func collectClassExpressionIssues(
 	for _, attr := range class.Attributes {
 		if attr.DerivationPolicy != nil {
 			loc := fmt.Sprintf("attribute %q derivation", attr.Key.String())
-			if issue := diagnoseUnparsedSpec(&attr.DerivationPolicy.Spec, classPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&attr.DerivationPolicy.Spec, classCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
 		for i := range attr.Invariants {
 			loc := fmt.Sprintf("attribute %q invariant %d", attr.Key.String(), i)
-			if issue := diagnoseUnparsedSpec(&attr.Invariants[i].Spec, classPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&attr.Invariants[i].Spec, classCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
@@ -114,7 +123,7 @@ func collectClassExpressionIssues(
 
 	for gKey, guard := range class.Guards {
 		loc := fmt.Sprintf("guard %q", gKey.String())
-		if issue := diagnoseUnparsedSpec(&guard.Logic.Spec, classPF, class.Key, loc); issue != nil {
+		if issue := diagnoseUnparsedSpec(&guard.Logic.Spec, classCtx, class.Key, loc); issue != nil {
 			issues = append(issues, *issue)
 		}
 	}
@@ -128,29 +137,29 @@ func collectClassExpressionIssues(
 func collectActionExpressionIssues(class *model_class.Class, classCtx *LowerContext) []ExpressionParseIssue {
 	var issues []ExpressionParseIssue
 	for actKey, action := range class.Actions {
-		actPF := NewExpressionParseFuncStrict(ContextWithParameters(classCtx, action.Parameters))
+		actCtx := ContextWithParameters(classCtx, action.Parameters)
 		for i := range action.Requires {
 			loc := fmt.Sprintf("action %q require %d", actKey.String(), i)
-			if issue := diagnoseUnparsedSpec(&action.Requires[i].Spec, actPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&action.Requires[i].Spec, actCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
 		for i := range action.Guarantees {
 			loc := fmt.Sprintf("action %q guarantee %d", actKey.String(), i)
-			if issue := diagnoseUnparsedSpec(&action.Guarantees[i].Spec, actPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&action.Guarantees[i].Spec, actCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
 		for i := range action.SafetyRules {
 			loc := fmt.Sprintf("action %q safety rule %d", actKey.String(), i)
-			if issue := diagnoseUnparsedSpec(&action.SafetyRules[i].Spec, actPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&action.SafetyRules[i].Spec, actCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
 		for i := range action.Parameters {
 			for j := range action.Parameters[i].Invariants {
 				loc := fmt.Sprintf("action %q parameter %q invariant %d", actKey.String(), action.Parameters[i].Name, j)
-				if issue := diagnoseUnparsedSpec(&action.Parameters[i].Invariants[j].Spec, actPF, class.Key, loc); issue != nil {
+				if issue := diagnoseUnparsedSpec(&action.Parameters[i].Invariants[j].Spec, actCtx, class.Key, loc); issue != nil {
 					issues = append(issues, *issue)
 				}
 			}
@@ -162,23 +171,23 @@ func collectActionExpressionIssues(class *model_class.Class, classCtx *LowerCont
 func collectQueryExpressionIssues(class *model_class.Class, classCtx *LowerContext) []ExpressionParseIssue {
 	var issues []ExpressionParseIssue
 	for qKey, query := range class.Queries {
-		qPF := NewExpressionParseFuncStrict(ContextWithParameters(classCtx, query.Parameters))
+		qCtx := ContextWithParameters(classCtx, query.Parameters)
 		for i := range query.Requires {
 			loc := fmt.Sprintf("query %q require %d", qKey.String(), i)
-			if issue := diagnoseUnparsedSpec(&query.Requires[i].Spec, qPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&query.Requires[i].Spec, qCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
 		for i := range query.Guarantees {
 			loc := fmt.Sprintf("query %q guarantee %d", qKey.String(), i)
-			if issue := diagnoseUnparsedSpec(&query.Guarantees[i].Spec, qPF, class.Key, loc); issue != nil {
+			if issue := diagnoseUnparsedSpec(&query.Guarantees[i].Spec, qCtx, class.Key, loc); issue != nil {
 				issues = append(issues, *issue)
 			}
 		}
 		for i := range query.Parameters {
 			for j := range query.Parameters[i].Invariants {
 				loc := fmt.Sprintf("query %q parameter %q invariant %d", qKey.String(), query.Parameters[i].Name, j)
-				if issue := diagnoseUnparsedSpec(&query.Parameters[i].Invariants[j].Spec, qPF, class.Key, loc); issue != nil {
+				if issue := diagnoseUnparsedSpec(&query.Parameters[i].Invariants[j].Spec, qCtx, class.Key, loc); issue != nil {
 					issues = append(issues, *issue)
 				}
 			}
@@ -189,14 +198,14 @@ func collectQueryExpressionIssues(class *model_class.Class, classCtx *LowerConte
 
 func diagnoseUnparsedSpec(
 	spec *logic_spec.ExpressionSpec,
-	pf StrictExpressionParseFunc,
+	ctx *LowerContext,
 	classKey identity.Key,
 	location string,
 ) *ExpressionParseIssue {
 	if spec == nil || spec.Specification == "" || spec.ParseOk() {
 		return nil
 	}
-	_, _, err := pf(spec.Specification)
+	_, _, err := NewNotationExpressionParseFuncStrict(spec.Notation, ctx)(spec.Specification)
 	if err == nil {
 		return nil
 	}
@@ -205,7 +214,37 @@ func diagnoseUnparsedSpec(
 		Location: location,
 		Message:  err.Error(),
 		SpecText: spec.Specification,
+		Offset:   problemOffset(spec, ctx, err),
+	}
+}
+
+// problemOffset returns the byte offset in the specification of the problem err reports.
+// Parse errors carry their own offset. For lowering errors the specification is parsed
+// again in its own notation, recording node positions, and lowered again so the error
+// can be traced to the node that failed. Without either the problem is placed at the start.
+func problemOffset(spec *logic_spec.ExpressionSpec, ctx *LowerContext, err error) int {
+	if spec.Notation == logic_spec.NotationInfix {
+		if offset, ok := infix.ErrorOffset(err); ok {
+			return offset
+		}
+	} else if offset, ok := parser.ErrorOffset(err); ok {
+		return offset
+	}
+
+	expr, positions, parseErr := parseNotationPositions(spec.Notation, spec.Specification)
+	if parseErr != nil {
+		return 0
+	}
+	var lowerErr *lowerError
+	if _, err := Lower(expr, ctx); !errors.As(err, &lowerErr) {
+		return 0
+	}
+	nodes := make([]ast.Node, len(lowerErr.nodes))
+	for i, node := range lowerErr.nodes {
+		nodes[i] = node
 	}
+	offset, _ := positions.Offset(nodes...)
+	return offset
 }
 
 func parameterNameSet(params []string) map[string]bool {
@@ -0,0 +1,42 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+type ExpressionParseIssuesSuite struct {
+	suite.Suite
+}
+
+func TestExpressionParseIssuesSuite(t *testing.T) {
+	suite.Run(t, new(ExpressionParseIssuesSuite))
+}
+
+func (s *ExpressionParseIssuesSuite) TestDiagnoseOffset() {
+	tests := []struct {
+		testName      string
+		notation      string
+		specification string
+		offset        int
+		errstr        string
+	}{
+		{testName: "tla parse error", notation: logic_spec.NotationTLAPlus, specification: "1 = 1 /\\ #", offset: 9, errstr: "TLA+ parse error"},
+		{testName: "tla lowering error", notation: logic_spec.NotationTLAPlus, specification: "1 = 1 /\\\n  missing > 0", offset: 11, errstr: "missing"},
+		{testName: "infix parse error", notation: logic_spec.NotationInfix, specification: "1 == 1 && #", offset: 10, errstr: "line 1, column 11"},
+		{testName: "infix lowering error", notation: logic_spec.NotationInfix, specification: "1 == 1 &&\n  {1, @}", offset: 16, errstr: "ExistingValue"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			spec := &logic_spec.ExpressionSpec{Notation: tt.notation, Specification: tt.specification}
+			issue := diagnoseUnparsedSpec(spec, &LowerContext{}, identity.Key{}, "model invariant 0")
+			s.Require().NotNil(issue)
+			s.Contains(issue.Message, tt.errstr)
+			s.Equal(tt.offset, issue.Offset)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package convert
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"math/big"
@@ -83,6 +84,9 @@ type LowerContext struct {
 	// localVars tracks quantifier-bound variables in scope. Managed internally.
 	localVars map[string]bool
 
+	// localFunctions tracks LET-defined recursive functions in scope. Managed internally.
+	localFunctions map[string]bool
+
 	// exceptField tracks the current field name inside a RecordAltered alteration
 	// so ExistingValue (@) can be lowered to PriorFieldValue.
 	exceptField string
@@ -91,10 +95,42 @@ type LowerContext struct {
 // Lower converts a TLA+ AST expression into a notation-independent model expression.
 // The LowerContext provides semantic resolution: identifiers are resolved to AttributeRef,
 // SelfRef, LocalVar, etc. based on what names are in scope.
+func Lower(expr ast.Expression, ctx *LowerContext) (me.Expression, error) {
+	result, err := lowerNode(expr, ctx)
+	if err != nil {
+		return nil, withLowerNode(err, expr)
+	}
+	return result, nil
+}
+
+// lowerError remembers the nodes that were being lowered when an error occurred,
+// innermost first, so the error can be traced back to a place in the source text.
+// Its message is the message of the error it wraps.
+type lowerError struct {
+	nodes []ast.Expression
+	err   error
+}
+
+func (e *lowerError) Error() string { return e.err.Error() }
+
+func (e *lowerError) Unwrap() error { return e.err }
+
+// withLowerNode adds expr to the nodes of the lowerError in err, wrapping err in one
+// if it has none yet.
+func withLowerNode(err error, expr ast.Expression) error {
+	var lowerErr *lowerError
+	if errors.As(err, &lowerErr) {
+		lowerErr.nodes = append(lowerErr.nodes, expr)
+		return err
+	}
+	return &lowerError{nodes: []ast.Expression{expr}, err: err}
+}
+
+// lowerNode lowers one node, calling Lower for its children.
 //
 //complexity:cyclo:warn=60,fail=60 Simple routing switch.
 //complexity:fanout:warn=60,fail=60 Simple routing switch.
-func Lower(expr ast.Expression, ctx *LowerContext) (me.Expression, error) {
+func lowerNode(expr ast.Expression, ctx *LowerContext) (me.Expression, error) {
 	if expr == nil {
 		return nil, fmt.Errorf("cannot lower nil expression")
 	}
@@ -185,6 +221,8 @@ func Lower(expr ast.Expression, ctx *LowerContext) (me.Expression, error) {
 		return lowerIfThenElse(e, ctx)
 	case *ast.LetExpr:
 		return lowerLetExpr(e, ctx)
+	case *ast.LetFunction:
+		return lowerLetFunction(e, ctx)
 	case *ast.ChooseExpr:
 		return lowerChooseExpr(e, ctx)
 	case *ast.CaseExpr:
@@ -264,7 +302,7 @@ func lowerFraction(e *ast.Fraction, ctx *LowerContext) (me.Expression, error) {
 		return &me.RationalLiteral{Value: rat}, nil
 	}
 
-	// Otherwise, treat as arithmetic division.
+	// Otherwise, treat as real division.
 	return &me.BinaryArith{Op: me.ArithDiv, Left: num, Right: den}, nil
 }
 
@@ -411,6 +449,11 @@ func lowerIdentifier(e *ast.Identifier, ctx *LowerContext) (me.Expression, error
 		return &me.SetConstant{Kind: kind}, nil
 	}
 
+	// A LET-defined function is only meaningful when applied.
+	if ctx.localFunctions[name] {
+		return nil, fmt.Errorf("function %q must be applied to an argument, as in %s[x]", name, name)
+	}
+
 	// Build list of all available names for error message.
 	var available []string
 	available = append(available, mapKeys(ctx.AttributeNames)...)
@@ -481,7 +524,7 @@ var arithOpMap = map[string]me.ArithOp{
 	"+": me.ArithAdd,
 	"-": me.ArithSub,
 	"*": me.ArithMul,
-	"÷": me.ArithDiv,
+	"÷": me.ArithIntDiv,
 	"^": me.ArithPow,
 	"%": me.ArithMod,
 }
@@ -723,7 +766,15 @@ func lowerTupleConcat(e *ast.TupleConcat, ctx *LowerContext) (*me.TupleConcat, e
 
 // --- Indexing and field access lowering ---
 
-func lowerTupleIndex(e *ast.TupleIndex, ctx *LowerContext) (*me.TupleIndex, error) {
+func lowerTupleIndex(e *ast.TupleIndex, ctx *LowerContext) (me.Expression, error) {
+	// f[arg] applies a LET-defined function rather than indexing a tuple.
+	if ident, ok := e.Tuple.(*ast.Identifier); ok && ctx.localFunctions[ident.Value] {
+		arg, err := Lower(e.Index, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("FunctionApply.Arg: %w", err)
+		}
+		return &me.FunctionApply{Name: ident.Value, Arg: arg}, nil
+	}
 	tuple, err := Lower(e.Tuple, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("TupleIndex.Tuple: %w", err)
@@ -826,6 +877,24 @@ func lowerLetExpr(e *ast.LetExpr, ctx *LowerContext) (*me.LetExpr, error) {
 	return &me.LetExpr{Variable: e.Variable, Value: value, Body: body}, nil
 }
 
+func lowerLetFunction(e *ast.LetFunction, ctx *LowerContext) (*me.FunctionDef, error) {
+	varName, domain, err := extractMembershipBinding(e.Membership, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("LetFunction: %w", err)
+	}
+	// The function is in scope in its own value (recursion) and in the body.
+	fnCtx := withLocalFunction(ctx, e.Name)
+	value, err := Lower(e.Value, withLocalVar(fnCtx, varName))
+	if err != nil {
+		return nil, fmt.Errorf("LetFunction.Value: %w", err)
+	}
+	body, err := Lower(e.Body, fnCtx)
+	if err != nil {
+		return nil, fmt.Errorf("LetFunction.Body: %w", err)
+	}
+	return &me.FunctionDef{Name: e.Name, Variable: varName, Domain: domain, Value: value, Body: body}, nil
+}
+
 func lowerChooseExpr(e *ast.ChooseExpr, ctx *LowerContext) (*me.Choose, error) {
 	varName, set, err := extractMembershipBinding(e.Membership, ctx)
 	if err != nil {
@@ -894,6 +963,17 @@ func withLocalVar(ctx *LowerContext, name string) *LowerContext {
 	return &child
 }
 
+// withLocalFunction returns a copy of the context with the given LET-defined function in scope.
+func withLocalFunction(ctx *LowerContext, name string) *LowerContext {
+	child := *ctx
+	child.localFunctions = make(map[string]bool)
+	if ctx.localFunctions != nil {
+		maps.Copy(child.localFunctions, ctx.localFunctions)
+	}
+	child.localFunctions[name] = true
+	return &child
+}
+
 func lowerQuantifier(e *ast.Quantifier, ctx *LowerContext) (*me.Quantifier, error) {
 	var kind me.QuantifierKind
 	switch e.Quantifier {
@@ -956,6 +1036,17 @@ func lowerFunctionCall(e *ast.FunctionCall, ctx *LowerContext) (me.Expression, e
 }
 
 func lowerGlobalOrBuiltinFunctionCall(e *ast.FunctionCall, ctx *LowerContext) (me.Expression, error) {
+	if len(e.ScopePath) > 0 {
+		// Built-in module call: _Module!Function(args...)
+		module := e.ScopePath[0].Value
+		function := e.Name.Value
+		args, err := lowerBuiltinArgs(module, function, e.Args, ctx)
+		if err != nil {
+			return nil, fmt.Errorf("FunctionCall.%w", err)
+		}
+		return &me.BuiltinCall{Module: module, Function: function, Args: args}, nil
+	}
+
 	// Lower arguments.
 	args := make([]me.Expression, len(e.Args))
 	for i, arg := range e.Args {
@@ -966,13 +1057,6 @@ func lowerGlobalOrBuiltinFunctionCall(e *ast.FunctionCall, ctx *LowerContext) (m
 		args[i] = lowered
 	}
 
-	if len(e.ScopePath) > 0 {
-		// Built-in module call: _Module!Function(args...)
-		module := e.ScopePath[0].Value
-		function := e.Name.Value
-		return &me.BuiltinCall{Module: module, Function: function, Args: args}, nil
-	}
-
 	// System event constructor: «new»(args...) or _new(args...) (ASCII authoring).
 	name := e.Name.Value
 	if key, ok := ctx.SystemEventNames[name]; ok {
@@ -1045,16 +1129,47 @@ func lowerBuiltinCall(e *ast.BuiltinCall, ctx *LowerContext) (*me.BuiltinCall, e
 		return nil, fmt.Errorf("invalid builtin call name format: %q (expected _Module!Function)", e.Name)
 	}
 
-	args := make([]me.Expression, len(e.Args))
-	for i, arg := range e.Args {
+	args, err := lowerBuiltinArgs(parts[0], parts[1], e.Args, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("BuiltinCall.%w", err)
+	}
+	return &me.BuiltinCall{Module: parts[0], Function: parts[1], Args: args}, nil
+}
+
+// lowerBuiltinArgs lowers the arguments of a built-in module call. The operator
+// argument of a higher-order builtin (e.g., the Test of SelectSeq) must name a
+// global function and lowers to a GlobalCall with no arguments.
+func lowerBuiltinArgs(module, function string, astArgs []ast.Expression, ctx *LowerContext) ([]me.Expression, error) {
+	operatorIndex, hasOperator := me.BuiltinOperatorArg(module, function)
+	args := make([]me.Expression, len(astArgs))
+	for i, arg := range astArgs {
+		if hasOperator && i == operatorIndex {
+			operator, err := lowerOperatorArg(arg, ctx)
+			if err != nil {
+				return nil, fmt.Errorf("Args[%d]: %s!%s operator: %w", i, module, function, err)
+			}
+			args[i] = operator
+			continue
+		}
 		lowered, err := Lower(arg, ctx)
 		if err != nil {
-			return nil, fmt.Errorf("BuiltinCall.Args[%d]: %w", i, err)
+			return nil, fmt.Errorf("Args[%d]: %w", i, err)
 		}
 		args[i] = lowered
 	}
+	return args, nil
+}
 
-	return &me.BuiltinCall{Module: parts[0], Function: parts[1], Args: args}, nil
+func lowerOperatorArg(arg ast.Expression, ctx *LowerContext) (*me.GlobalCall, error) {
+	ident, ok := arg.(*ast.Identifier)
+	if !ok {
+		return nil, fmt.Errorf("must name a global function, got %s", arg.String())
+	}
+	key, ok := ctx.GlobalFunctions[ident.Value]
+	if !ok {
+		return nil, unresolvedError("global function", ident.Value, mapKeys(ctx.GlobalFunctions))
+	}
+	return &me.GlobalCall{FunctionKey: key}, nil
 }
 
 func lowerScopedCall(e *ast.ScopedCall, ctx *LowerContext) (me.Expression, error) {
@@ -30,9 +30,8 @@ func LowerAllExpressions(model *core.Model) error {
 		ClassNames:      classNames,
 		AllActions:      allActions,
 	}
-	modelPF := NewExpressionParseFunc(modelCtx)
 	for i := range model.Invariants {
-		if err := relowerSpec(&model.Invariants[i].Spec, modelPF); err != nil {
+		if err := relowerSpec(&model.Invariants[i].Spec, modelCtx); err != nil {
 			return fmt.Errorf("model invariant %d: %w", i, err)
 		}
 	}
@@ -50,8 +49,7 @@ func LowerAllExpressions(model *core.Model) error {
 			AllActions:      allActions,
 			Parameters:      params,
 		}
-		gfPF := NewExpressionParseFunc(gfCtx)
-		if err := relowerSpec(&gf.Logic.Spec, gfPF); err != nil {
+		if err := relowerSpec(&gf.Logic.Spec, gfCtx); err != nil {
 			return fmt.Errorf("global function %q: %w", gfKey.String(), err)
 		}
 		model.GlobalFunctions[gfKey] = gf
@@ -59,7 +57,7 @@ func LowerAllExpressions(model *core.Model) error {
 
 	// 3. Lower named sets.
 	for nsKey, ns := range model.NamedSets {
-		if err := relowerSpec(&ns.Spec, modelPF); err != nil {
+		if err := relowerSpec(&ns.Spec, modelCtx); err != nil {
 			return fmt.Errorf("named set %q: %w", nsKey.String(), err)
 		}
 		model.NamedSets[nsKey] = ns
@@ -89,11 +87,10 @@ func LowerAllExpressions(model *core.Model) error {
 func lowerAllClassExpressions(class *model_class.Class, globalFunctions, namedSets, classNames, allActions map[string]identity.Key, subdomainMaps SubdomainClassMaps) error {
 	classCtx := NewClassLowerContext(class, globalFunctions, namedSets, allActions, subdomainMaps.Associations, subdomainMaps.Classes)
 	classCtx.ClassNames = classNames
-	classPF := NewExpressionParseFunc(classCtx)
 
 	// Class invariants.
 	for i := range class.Invariants {
-		if err := relowerSpec(&class.Invariants[i].Spec, classPF); err != nil {
+		if err := relowerSpec(&class.Invariants[i].Spec, classCtx); err != nil {
 			return fmt.Errorf("class invariant %d: %w", i, err)
 		}
 	}
@@ -102,12 +99,12 @@ func lowerAllClassExpressions(class *model_class.Class, globalFunctions, namedSe
 	for i := range class.Attributes {
 		attr := &class.Attributes[i]
 		if attr.DerivationPolicy != nil {
-			if err := relowerSpec(&attr.DerivationPolicy.Spec, classPF); err != nil {
+			if err := relowerSpec(&attr.DerivationPolicy.Spec, classCtx); err != nil {
 				return fmt.Errorf("attribute %q derivation: %w", attr.Key.String(), err)
 			}
 		}
 		for j := range attr.Invariants {
-			if err := relowerSpec(&attr.Invariants[j].Spec, classPF); err != nil {
+			if err := relowerSpec(&attr.Invariants[j].Spec, classCtx); err != nil {
 				return fmt.Errorf("attribute %q invariant %d: %w", attr.Key.String(), j, err)
 			}
 		}
@@ -115,7 +112,7 @@ func lowerAllClassExpressions(class *model_class.Class, globalFunctions, namedSe
 
 	// Guards.
 	for gKey, guard := range class.Guards {
-		if err := relowerSpec(&guard.Logic.Spec, classPF); err != nil {
+		if err := relowerSpec(&guard.Logic.Spec, classCtx); err != nil {
 			return fmt.Errorf("guard %q: %w", gKey.String(), err)
 		}
 		class.Guards[gKey] = guard
@@ -139,62 +136,61 @@ func lowerAllClassExpressions(class *model_class.Class, globalFunctions, namedSe
 }
 
 func relowerActionExpressions(actKey identity.Key, action *model_state.Action, classCtx *LowerContext) error {
-	actPF := NewExpressionParseFunc(ContextWithParameters(classCtx, action.Parameters))
+	actCtx := ContextWithParameters(classCtx, action.Parameters)
 	for i := range action.Requires {
-		if err := relowerSpec(&action.Requires[i].Spec, actPF); err != nil {
+		if err := relowerSpec(&action.Requires[i].Spec, actCtx); err != nil {
 			return fmt.Errorf("action %q require %d: %w", actKey.String(), i, err)
 		}
 	}
 	for i := range action.Guarantees {
 		guar := &action.Guarantees[i]
 		if model_logic.IsAssociationClassReify(*guar) {
-			if err := relowerSpec(&guar.EndpointSelectorSpec, actPF); err != nil {
+			if err := relowerSpec(&guar.EndpointSelectorSpec, actCtx); err != nil {
 				return fmt.Errorf("action %q guarantee %d endpoint_selector: %w", actKey.String(), i, err)
 			}
-			reifyPF := actPF
+			reifyCtx := actCtx
 			if setMap, ok := guar.EndpointSelectorSpec.Expression.(*me.SetMap); ok && setMap.Variable != "" {
-				reifyCtx := withLocalVar(ContextWithParameters(classCtx, action.Parameters), setMap.Variable)
-				reifyPF = NewExpressionParseFunc(reifyCtx)
+				reifyCtx = withLocalVar(actCtx, setMap.Variable)
 			}
-			if err := relowerSpec(&guar.Spec, reifyPF); err != nil {
+			if err := relowerSpec(&guar.Spec, reifyCtx); err != nil {
 				return fmt.Errorf("action %q guarantee %d: %w", actKey.String(), i, err)
 			}
 			continue
 		}
-		if err := relowerSpec(&guar.Spec, actPF); err != nil {
+		if err := relowerSpec(&guar.Spec, actCtx); err != nil {
 			return fmt.Errorf("action %q guarantee %d: %w", actKey.String(), i, err)
 		}
 	}
 	for i := range action.SafetyRules {
-		if err := relowerSpec(&action.SafetyRules[i].Spec, actPF); err != nil {
+		if err := relowerSpec(&action.SafetyRules[i].Spec, actCtx); err != nil {
 			return fmt.Errorf("action %q safety rule %d: %w", actKey.String(), i, err)
 		}
 	}
-	if err := relowerParameterInvariants(actKey.String(), "action", action.Parameters, actPF); err != nil {
+	if err := relowerParameterInvariants(actKey.String(), "action", action.Parameters, actCtx); err != nil {
 		return err
 	}
 	return relowerParameterSimulation(actKey.String(), "action", action.Parameters, classCtx)
 }
 
 func relowerQueryExpressions(qKey identity.Key, query *model_state.Query, classCtx *LowerContext) error {
-	qPF := NewExpressionParseFunc(ContextWithParameters(classCtx, query.Parameters))
+	qCtx := ContextWithParameters(classCtx, query.Parameters)
 	for i := range query.Requires {
-		if err := relowerSpec(&query.Requires[i].Spec, qPF); err != nil {
+		if err := relowerSpec(&query.Requires[i].Spec, qCtx); err != nil {
 			return fmt.Errorf("query %q require %d: %w", qKey.String(), i, err)
 		}
 	}
 	for i := range query.Guarantees {
-		if err := relowerSpec(&query.Guarantees[i].Spec, qPF); err != nil {
+		if err := relowerSpec(&query.Guarantees[i].Spec, qCtx); err != nil {
 			return fmt.Errorf("query %q guarantee %d: %w", qKey.String(), i, err)
 		}
 	}
-	return relowerParameterInvariants(qKey.String(), "query", query.Parameters, qPF)
+	return relowerParameterInvariants(qKey.String(), "query", query.Parameters, qCtx)
 }
 
-func relowerParameterInvariants(ownerKey, ownerKind string, params []model_state.Parameter, pf logic_spec.ExpressionParseFunc) error {
+func relowerParameterInvariants(ownerKey, ownerKind string, params []model_state.Parameter, ctx *LowerContext) error {
 	for i := range params {
 		for j := range params[i].Invariants {
-			if err := relowerSpec(&params[i].Invariants[j].Spec, pf); err != nil {
+			if err := relowerSpec(&params[i].Invariants[j].Spec, ctx); err != nil {
 				return fmt.Errorf("%s %q parameter %q invariant %d: %w", ownerKind, ownerKey, params[i].Name, j, err)
 			}
 		}
@@ -203,7 +199,6 @@ func relowerParameterInvariants(ownerKey, ownerKind string, params []model_state
 }
 
 func relowerParameterSimulation(ownerKey, ownerKind string, params []model_state.Parameter, classCtx *LowerContext) error {
-	pf := NewExpressionParseFunc(classCtx)
 	for i := range params {
 		if params[i].Simulation == nil {
 			continue
@@ -211,12 +206,12 @@ func relowerParameterSimulation(ownerKey, ownerKind string, params []model_state
 		for r := range params[i].Simulation.Rules {
 			rule := &params[i].Simulation.Rules[r]
 			for j := range rule.Requires {
-				if err := relowerSpec(&rule.Requires[j].Spec, pf); err != nil {
+				if err := relowerSpec(&rule.Requires[j].Spec, classCtx); err != nil {
 					return fmt.Errorf("%s %q parameter %q simulation rule %d require %d: %w", ownerKind, ownerKey, params[i].Name, r, j, err)
 				}
 			}
 			if rule.Specification != nil {
-				if err := relowerSpec(&rule.Specification.Spec, pf); err != nil {
+				if err := relowerSpec(&rule.Specification.Spec, classCtx); err != nil {
 					return fmt.Errorf("%s %q parameter %q simulation rule %d specification: %w", ownerKind, ownerKey, params[i].Name, r, err)
 				}
 			}
@@ -226,7 +221,6 @@ func relowerParameterSimulation(ownerKey, ownerKind string, params []model_state
 }
 
 func relowerParameterSimulationStrict(ownerKey, ownerKind string, params []model_state.Parameter, classCtx *LowerContext) []error {
-	pf := NewExpressionParseFuncStrict(classCtx)
 	var errs []error
 	for i := range params {
 		if params[i].Simulation == nil {
@@ -235,12 +229,12 @@ func relowerParameterSimulationStrict(ownerKey, ownerKind string, params []model
 		for r := range params[i].Simulation.Rules {
 			rule := &params[i].Simulation.Rules[r]
 			for j := range rule.Requires {
-				if err := relowerSpecStrict(&rule.Requires[j].Spec, pf); err != nil {
+				if err := relowerSpecStrict(&rule.Requires[j].Spec, classCtx); err != nil {
 					errs = append(errs, fmt.Errorf("%s %q parameter %q simulation rule %d require %d: %w", ownerKind, ownerKey, params[i].Name, r, j, err))
 				}
 			}
 			if rule.Specification != nil {
-				if err := relowerSpecStrict(&rule.Specification.Spec, pf); err != nil {
+				if err := relowerSpecStrict(&rule.Specification.Spec, classCtx); err != nil {
 					errs = append(errs, fmt.Errorf("%s %q parameter %q simulation rule %d specification: %w", ownerKind, ownerKey, params[i].Name, r, err))
 				}
 			}
@@ -249,13 +243,13 @@ func relowerParameterSimulationStrict(ownerKey, ownerKind string, params []model
 	return errs
 }
 
-// relowerSpec re-creates an ExpressionSpec using the given parse function.
-// If the spec has no specification text, it's a no-op.
-func relowerSpec(spec *logic_spec.ExpressionSpec, pf logic_spec.ExpressionParseFunc) error {
+// relowerSpec re-creates an ExpressionSpec by parsing it in its notation with the
+// given context. If the spec has no specification text, it's a no-op.
+func relowerSpec(spec *logic_spec.ExpressionSpec, ctx *LowerContext) error {
 	if spec.Specification == "" {
 		return nil
 	}
-	newSpec, err := logic_spec.NewExpressionSpec(spec.Notation, spec.Specification, pf)
+	newSpec, err := logic_spec.NewExpressionSpec(spec.Notation, spec.Specification, NewNotationExpressionParseFunc(spec.Notation, ctx))
 	if err != nil {
 		return err
 	}
@@ -281,9 +275,8 @@ func LowerAllExpressionsStrict(model *core.Model) error {
 		ClassNames:      classNames,
 		AllActions:      allActions,
 	}
-	modelPF := NewExpressionParseFuncStrict(modelCtx)
 	for i := range model.Invariants {
-		if err := relowerSpecStrict(&model.Invariants[i].Spec, modelPF); err != nil {
+		if err := relowerSpecStrict(&model.Invariants[i].Spec, modelCtx); err != nil {
 			errs = append(errs, fmt.Errorf("model invariant %d: %w", i, err))
 		}
 	}
@@ -301,8 +294,7 @@ func LowerAllExpressionsStrict(model *core.Model) error {
 			AllActions:      allActions,
 			Parameters:      params,
 		}
-		gfPF := NewExpressionParseFuncStrict(gfCtx)
-		if err := relowerSpecStrict(&gf.Logic.Spec, gfPF); err != nil {
+		if err := relowerSpecStrict(&gf.Logic.Spec, gfCtx); err != nil {
 			errs = append(errs, fmt.Errorf("global function %q: %w", gfKey.String(), err))
 		}
 		model.GlobalFunctions[gfKey] = gf
@@ -310,7 +302,7 @@ func LowerAllExpressionsStrict(model *core.Model) error {
 
 	// Named sets.
 	for nsKey, ns := range model.NamedSets {
-		if err := relowerSpecStrict(&ns.Spec, modelPF); err != nil {
+		if err := relowerSpecStrict(&ns.Spec, modelCtx); err != nil {
 			errs = append(errs, fmt.Errorf("named set %q: %w", nsKey.String(), err))
 		}
 		model.NamedSets[nsKey] = ns
@@ -342,13 +334,12 @@ func LowerAllExpressionsStrict(model *core.Model) error {
 func lowerAllClassExpressionsStrict(class *model_class.Class, globalFunctions, namedSets, classNames, allActions map[string]identity.Key, subdomainMaps SubdomainClassMaps) error {
 	classCtx := NewClassLowerContext(class, globalFunctions, namedSets, allActions, subdomainMaps.Associations, subdomainMaps.Classes)
 	classCtx.ClassNames = classNames
-	classPF := NewExpressionParseFuncStrict(classCtx)
 
 	var errs []error
 
 	// Class invariants.
 	for i := range class.Invariants {
-		if err := relowerSpecStrict(&class.Invariants[i].Spec, classPF); err != nil {
+		if err := relowerSpecStrict(&class.Invariants[i].Spec, classCtx); err != nil {
 			errs = append(errs, fmt.Errorf("invariant %d: %w", i, err))
 		}
 	}
@@ -357,12 +348,12 @@ func lowerAllClassExpressionsStrict(class *model_class.Class, globalFunctions, n
 	for i := range class.Attributes {
 		attr := &class.Attributes[i]
 		if attr.DerivationPolicy != nil {
-			if err := relowerSpecStrict(&attr.DerivationPolicy.Spec, classPF); err != nil {
+			if err := relowerSpecStrict(&attr.DerivationPolicy.Spec, classCtx); err != nil {
 				errs = append(errs, fmt.Errorf("attribute %q derivation: %w", attr.Key.String(), err))
 			}
 		}
 		for j := range attr.Invariants {
-			if err := relowerSpecStrict(&attr.Invariants[j].Spec, classPF); err != nil {
+			if err := relowerSpecStrict(&attr.Invariants[j].Spec, classCtx); err != nil {
 				errs = append(errs, fmt.Errorf("attribute %q invariant %d: %w", attr.Key.String(), j, err))
 			}
 		}
@@ -370,7 +361,7 @@ func lowerAllClassExpressionsStrict(class *model_class.Class, globalFunctions, n
 
 	// Guards.
 	for gKey, guard := range class.Guards {
-		if err := relowerSpecStrict(&guard.Logic.Spec, classPF); err != nil {
+		if err := relowerSpecStrict(&guard.Logic.Spec, classCtx); err != nil {
 			errs = append(errs, fmt.Errorf("guard %q: %w", gKey.String(), err))
 		}
 		class.Guards[gKey] = guard
@@ -398,21 +389,20 @@ func lowerActionExpressionsStrict(class *model_class.Class, classCtx *LowerConte
 func lowerOneActionExpressionsStrict(actKey identity.Key, action *model_state.Action, classCtx *LowerContext) []error {
 	var errs []error
 	actCtx := ContextWithParameters(classCtx, action.Parameters)
-	actPF := NewExpressionParseFuncStrict(actCtx)
 	for i := range action.Requires {
-		if err := relowerSpecStrict(&action.Requires[i].Spec, actPF); err != nil {
+		if err := relowerSpecStrict(&action.Requires[i].Spec, actCtx); err != nil {
 			errs = append(errs, fmt.Errorf("action %q require %d: %w", actKey.String(), i, err))
 		}
 	}
-	errs = append(errs, lowerActionGuaranteesStrict(actKey, action, actCtx, actPF)...)
+	errs = append(errs, lowerActionGuaranteesStrict(actKey, action, actCtx)...)
 	for i := range action.SafetyRules {
-		if err := relowerSpecStrict(&action.SafetyRules[i].Spec, actPF); err != nil {
+		if err := relowerSpecStrict(&action.SafetyRules[i].Spec, actCtx); err != nil {
 			errs = append(errs, fmt.Errorf("action %q safety rule %d: %w", actKey.String(), i, err))
 		}
 	}
 	for i := range action.Parameters {
 		for j := range action.Parameters[i].Invariants {
-			if err := relowerSpecStrict(&action.Parameters[i].Invariants[j].Spec, actPF); err != nil {
+			if err := relowerSpecStrict(&action.Parameters[i].Invariants[j].Spec, actCtx); err != nil {
 				errs = append(errs, fmt.Errorf("action %q parameter %q invariant %d: %w", actKey.String(), action.Parameters[i].Name, j, err))
 			}
 		}
@@ -425,16 +415,15 @@ func lowerActionGuaranteesStrict(
 	actKey identity.Key,
 	action *model_state.Action,
 	actCtx *LowerContext,
-	actPF StrictExpressionParseFunc,
 ) []error {
 	var errs []error
 	for i := range action.Guarantees {
 		guar := &action.Guarantees[i]
 		if model_logic.IsAssociationClassReify(*guar) {
-			errs = append(errs, relowerAssociationClassReifyStrict(actKey, i, guar, actCtx, actPF)...)
+			errs = append(errs, relowerAssociationClassReifyStrict(actKey, i, guar, actCtx)...)
 			continue
 		}
-		if err := relowerSpecStrict(&guar.Spec, actPF); err != nil {
+		if err := relowerSpecStrict(&guar.Spec, actCtx); err != nil {
 			errs = append(errs, fmt.Errorf("action %q guarantee %d: %w", actKey.String(), i, err))
 		}
 	}
@@ -446,18 +435,16 @@ func relowerAssociationClassReifyStrict(
 	index int,
 	guar *model_logic.Logic,
 	actCtx *LowerContext,
-	actPF StrictExpressionParseFunc,
 ) []error {
 	var errs []error
-	if err := relowerSpecStrict(&guar.EndpointSelectorSpec, actPF); err != nil {
+	if err := relowerSpecStrict(&guar.EndpointSelectorSpec, actCtx); err != nil {
 		errs = append(errs, fmt.Errorf("action %q guarantee %d endpoint_selector: %w", actKey.String(), index, err))
 	}
-	reifyPF := actPF
+	reifyCtx := actCtx
 	if setMap, ok := guar.EndpointSelectorSpec.Expression.(*me.SetMap); ok && setMap.Variable != "" {
-		reifyCtx := withLocalVar(actCtx, setMap.Variable)
-		reifyPF = NewExpressionParseFuncStrict(reifyCtx)
+		reifyCtx = withLocalVar(actCtx, setMap.Variable)
 	}
-	if err := relowerSpecStrict(&guar.Spec, reifyPF); err != nil {
+	if err := relowerSpecStrict(&guar.Spec, reifyCtx); err != nil {
 		errs = append(errs, fmt.Errorf("action %q guarantee %d: %w", actKey.String(), index, err))
 	}
 	return errs
@@ -468,20 +455,19 @@ func lowerQueryExpressionsStrict(class *model_class.Class, classCtx *LowerContex
 	var errs []error
 	for qKey, query := range class.Queries {
 		qCtx := ContextWithParameters(classCtx, query.Parameters)
-		qPF := NewExpressionParseFuncStrict(qCtx)
 		for i := range query.Requires {
-			if err := relowerSpecStrict(&query.Requires[i].Spec, qPF); err != nil {
+			if err := relowerSpecStrict(&query.Requires[i].Spec, qCtx); err != nil {
 				errs = append(errs, fmt.Errorf("query %q require %d: %w", qKey.String(), i, err))
 			}
 		}
 		for i := range query.Guarantees {
-			if err := relowerSpecStrict(&query.Guarantees[i].Spec, qPF); err != nil {
+			if err := relowerSpecStrict(&query.Guarantees[i].Spec, qCtx); err != nil {
 				errs = append(errs, fmt.Errorf("query %q guarantee %d: %w", qKey.String(), i, err))
 			}
 		}
 		for i := range query.Parameters {
 			for j := range query.Parameters[i].Invariants {
-				if err := relowerSpecStrict(&query.Parameters[i].Invariants[j].Spec, qPF); err != nil {
+				if err := relowerSpecStrict(&query.Parameters[i].Invariants[j].Spec, qCtx); err != nil {
 					errs = append(errs, fmt.Errorf("query %q parameter %q invariant %d: %w", qKey.String(), query.Parameters[i].Name, j, err))
 				}
 			}
@@ -491,13 +477,13 @@ func lowerQueryExpressionsStrict(class *model_class.Class, classCtx *LowerContex
 	return errs
 }
 
-// relowerSpecStrict re-creates an ExpressionSpec using the strict parse function.
-// Returns an error if parsing/lowering fails, instead of silently leaving Expression nil.
-func relowerSpecStrict(spec *logic_spec.ExpressionSpec, pf StrictExpressionParseFunc) error {
+// relowerSpecStrict re-creates an ExpressionSpec using the strict parse function of its
+// notation. Returns an error if parsing/lowering fails, instead of silently leaving Expression nil.
+func relowerSpecStrict(spec *logic_spec.ExpressionSpec, ctx *LowerContext) error {
 	if spec.Specification == "" {
 		return nil
 	}
-	expr, normalized, err := pf(spec.Specification)
+	expr, normalized, err := NewNotationExpressionParseFuncStrict(spec.Notation, ctx)(spec.Specification)
 	if err != nil {
 		return fmt.Errorf("specification %q: %w", spec.Specification, err)
 	}
@@ -15,7 +15,6 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
-	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
 )
 
 // LowerModel walks the entire model tree, parsing and lowering every ExpressionSpec
@@ -29,6 +28,62 @@ func LowerModel(model *core.Model) error {
 	// Build cross-class action lookup (AllActions) across the entire model.
 	allActions := BuildAllActionsMap(model)
 
+	if err := lowerModelLogic(model, globalFunctions, namedSets, allActions); err != nil {
+		return err
+	}
+
+	allAssociations := model.GetClassAssociations()
+
+	// 4. Walk domains → subdomains → classes.
+	for dKey, domain := range model.Domains {
+		for sKey, subdomain := range domain.Subdomains {
+			for cKey, class := range subdomain.Classes {
+				if err := lowerClass(&class, globalFunctions, namedSets, allActions, allAssociations, subdomain.Classes); err != nil {
+					return fmt.Errorf("class %q: %w", cKey.String(), err)
+				}
+				subdomain.Classes[cKey] = class
+			}
+			domain.Subdomains[sKey] = subdomain
+		}
+		model.Domains[dKey] = domain
+	}
+
+	return nil
+}
+
+// LowerModelByClass lowers the model like LowerModel, except that a class whose logic
+// does not lower leaves the other classes lowered. It returns the error of each such
+// class by class key, and an error only for logic outside the classes.
+func LowerModelByClass(model *core.Model) (map[identity.Key]error, error) {
+	globalFunctions := BuildGlobalFunctionMap(model)
+	namedSets := BuildNamedSetMap(model)
+	allActions := BuildAllActionsMap(model)
+
+	if err := lowerModelLogic(model, globalFunctions, namedSets, allActions); err != nil {
+		return nil, err
+	}
+
+	allAssociations := model.GetClassAssociations()
+
+	classErrs := make(map[identity.Key]error)
+	for dKey, domain := range model.Domains {
+		for sKey, subdomain := range domain.Subdomains {
+			for cKey, class := range subdomain.Classes {
+				if err := lowerClass(&class, globalFunctions, namedSets, allActions, allAssociations, subdomain.Classes); err != nil {
+					classErrs[cKey] = fmt.Errorf("class %q: %w", cKey.String(), err)
+				}
+				subdomain.Classes[cKey] = class
+			}
+			domain.Subdomains[sKey] = subdomain
+		}
+		model.Domains[dKey] = domain
+	}
+
+	return classErrs, nil
+}
+
+// lowerModelLogic lowers the model invariants, global functions, and named sets.
+func lowerModelLogic(model *core.Model, globalFunctions, namedSets, allActions map[string]identity.Key) error {
 	// 1. Lower model-level invariants (no class context).
 	modelCtx := &LowerContext{
 		GlobalFunctions: globalFunctions,
@@ -67,22 +122,6 @@ func LowerModel(model *core.Model) error {
 		model.NamedSets[nsKey] = ns
 	}
 
-	allAssociations := model.GetClassAssociations()
-
-	// 4. Walk domains → subdomains → classes.
-	for dKey, domain := range model.Domains {
-		for sKey, subdomain := range domain.Subdomains {
-			for cKey, class := range subdomain.Classes {
-				if err := lowerClass(&class, globalFunctions, namedSets, allActions, allAssociations, subdomain.Classes); err != nil {
-					return fmt.Errorf("class %q: %w", cKey.String(), err)
-				}
-				subdomain.Classes[cKey] = class
-			}
-			domain.Subdomains[sKey] = subdomain
-		}
-		model.Domains[dKey] = domain
-	}
-
 	return nil
 }
 
@@ -272,7 +311,7 @@ func lowerDestroyGuaranteeEvent(guar *model_logic.Logic, ctx *LowerContext) erro
 	if sf, ok := guar.Spec.Expression.(*me.SetFilter); ok {
 		deleteCtx = withLocalVar(ctx, sf.Variable)
 	}
-	if boundVar := destroyEventBoundVariable(guar.DestroyEventSpec.Specification); boundVar != "" {
+	if boundVar := destroyEventBoundVariable(guar.DestroyEventSpec); boundVar != "" {
 		deleteCtx = withLocalVar(deleteCtx, boundVar)
 	}
 	return lowerLogicSpec(&guar.DestroyEventSpec, deleteCtx)
@@ -296,11 +335,11 @@ func lowerAssociationClassReifyGuarantee(guar *model_logic.Logic, ctx *LowerCont
 
 // destroyEventBoundVariable returns the first destroy_event call argument name.
 // That identifier is a bound variable for lowering only; the simulator skips it at runtime.
-func destroyEventBoundVariable(specification string) string {
-	if specification == "" {
+func destroyEventBoundVariable(spec logic_spec.ExpressionSpec) string {
+	if spec.Specification == "" {
 		return ""
 	}
-	astExpr, err := parser.ParseExpression(specification)
+	astExpr, err := ParseNotation(spec.Notation, spec.Specification)
 	if err != nil {
 		return ""
 	}
@@ -315,16 +354,16 @@ func destroyEventBoundVariable(specification string) string {
 	return id.Value
 }
 
-// lowerLogicSpec parses and lowers a single ExpressionSpec if it has a TLA+ specification
-// and hasn't been lowered yet.
+// lowerLogicSpec parses and lowers a single ExpressionSpec if it has a TLA+ or infix
+// specification and hasn't been lowered yet.
 func lowerLogicSpec(spec *logic_spec.ExpressionSpec, ctx *LowerContext) error {
-	// Skip if not TLA+, no specification text, or already lowered.
-	if spec.Notation != "tla_plus" || spec.Specification == "" || spec.Expression != nil {
+	// Skip if not a logic notation, no specification text, or already lowered.
+	if !logic_spec.IsExpressionNotation(spec.Notation) || spec.Specification == "" || spec.Expression != nil {
 		return nil
 	}
 
-	// Parse the TLA+ specification string to AST.
-	astExpr, err := parser.ParseExpression(spec.Specification)
+	// Parse the specification string to AST.
+	astExpr, err := ParseNotation(spec.Notation, spec.Specification)
 	if err != nil {
 		return fmt.Errorf("parse %q: %w", spec.Specification, err)
 	}
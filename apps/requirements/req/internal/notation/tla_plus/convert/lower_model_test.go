@@ -286,6 +286,30 @@ func (s *LowerModelTestSuite) TestLowerModelSuccess() {
 	}
 }
 
+func (s *LowerModelTestSuite) TestLowerModelByClass() {
+	model := buildTestModel()
+	class := getClassFromModel(model)
+	for key, guard := range class.Guards {
+		guard.Logic.Spec = mustSpec("missing > 0")
+		class.Guards[key] = guard
+	}
+
+	classErrs, err := LowerModelByClass(model)
+	s.Require().NoError(err)
+	s.Require().Len(classErrs, 1)
+	s.Require().Contains(classErrs, class.Key)
+	s.ErrorContains(classErrs[class.Key], "missing")
+
+	// Logic outside the classes is still lowered.
+	s.NotNil(model.Invariants[0].Spec.Expression)
+
+	// An error outside the classes is returned as a whole.
+	model = buildTestModel()
+	model.Invariants[0].Spec = mustSpec("missing > 0")
+	_, err = LowerModelByClass(model)
+	s.Require().ErrorContains(err, "model invariant 0")
+}
+
 func (s *LowerModelTestSuite) TestLowerModelSkipsAlreadyLowered() {
 	model := buildTestModel()
 
@@ -127,6 +127,8 @@ func (s *LowerTestSuite) TestLowerFractionNonLiteral() {
 	arith, ok := result.(*me.BinaryArith)
 	s.True(ok)
 	s.Equal(me.ArithDiv, arith.Op)
+	// Stored models already use "div" for real division.
+	s.Equal(me.ArithOp("div"), arith.Op)
 }
 
 // --- Collection tests ---
@@ -325,7 +327,7 @@ func (s *LowerTestSuite) TestLowerBinaryArithmetic() {
 		{"+", me.ArithAdd},
 		{"-", me.ArithSub},
 		{"*", me.ArithMul},
-		{"÷", me.ArithDiv},
+		{"÷", me.ArithIntDiv},
 		{"^", me.ArithPow},
 		{"%", me.ArithMod},
 	}
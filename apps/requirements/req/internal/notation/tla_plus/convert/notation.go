@@ -0,0 +1,96 @@
+package convert
+
+import (
+	"fmt"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/infix"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
+)
+
+// ParseNotation parses specification text written in the given notation into the
+// shared AST. Both notations produce the same AST, so the result lowers with Lower.
+func ParseNotation(notation, specification string) (ast.Expression, error) {
+	if notation == logic_spec.NotationInfix {
+		return infix.Parse(specification)
+	}
+	return parser.ParseExpression(specification)
+}
+
+// parseNotationPositions is like ParseNotation but also returns where each node
+// starts in the specification text.
+func parseNotationPositions(notation, specification string) (ast.Expression, ast.Positions, error) {
+	if notation == logic_spec.NotationInfix {
+		return infix.ParsePositions(specification)
+	}
+	return parser.ParseExpressionPositions(specification)
+}
+
+// PrintNotation prints a raised AST in the given notation.
+func PrintNotation(notation string, expr ast.Expression) string {
+	if notation == logic_spec.NotationInfix {
+		return infix.Print(expr)
+	}
+	return ast.Print(expr)
+}
+
+// TranslateSpecification rewrites specification text from one notation into another.
+// Translation is purely syntactic and needs no model context.
+func TranslateSpecification(specification, from, to string) (string, error) {
+	if from == to || specification == "" {
+		return specification, nil
+	}
+	switch {
+	case from == logic_spec.NotationTLAPlus && to == logic_spec.NotationInfix:
+		return infix.FromTLAPlus(specification)
+	case from == logic_spec.NotationInfix && to == logic_spec.NotationTLAPlus:
+		return infix.ToTLAPlus(specification)
+	default:
+		return "", fmt.Errorf("cannot translate from notation %q to %q", from, to)
+	}
+}
+
+// NewNotationExpressionParseFunc is like NewExpressionParseFunc for specifications
+// written in the given notation. The normalized text is in the same notation.
+func NewNotationExpressionParseFunc(notation string, ctx *LowerContext) logic_spec.ExpressionParseFunc {
+	return newExpressionParseFunc(syntaxOf(notation), ctx)
+}
+
+// NewNotationExpressionParseFuncStrict is like NewExpressionParseFuncStrict for
+// specifications written in the given notation.
+func NewNotationExpressionParseFuncStrict(notation string, ctx *LowerContext) StrictExpressionParseFunc {
+	return newExpressionParseFuncStrict(syntaxOf(notation), ctx)
+}
+
+// notationSyntax reads and writes the specification text of one notation. Both
+// notations parse to the shared AST, so each lowers and raises directly and the
+// errors of a specification point into the text its author wrote.
+type notationSyntax struct {
+	name  string // The notation's name in error messages.
+	parse func(specification string) (ast.Expression, error)
+	print func(expr ast.Expression) string
+}
+
+var (
+	tlaPlusSyntax = notationSyntax{name: "TLA+", parse: parser.ParseExpression, print: ast.Print}
+	infixSyntax   = notationSyntax{name: "infix", parse: infix.Parse, print: infixPrint}
+)
+
+// syntaxOf returns the syntax of a notation. TLA+ is the default.
+func syntaxOf(notation string) notationSyntax {
+	if notation == logic_spec.NotationInfix {
+		return infixSyntax
+	}
+	return tlaPlusSyntax
+}
+
+// infixPrint prints a raised AST in infix. Text that does not parse back, such as a
+// TLA+ name that is an infix keyword, is dropped, which keeps the author's original text.
+func infixPrint(expr ast.Expression) string {
+	text := infix.Print(expr)
+	if _, err := infix.Parse(text); err != nil {
+		return ""
+	}
+	return text
+}
@@ -0,0 +1,115 @@
+package convert
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+type NotationTestSuite struct {
+	suite.Suite
+}
+
+func TestNotationSuite(t *testing.T) {
+	suite.Run(t, new(NotationTestSuite))
+}
+
+func (s *NotationTestSuite) TestTranslateSpecification() {
+	tests := []struct {
+		testName      string
+		specification string
+		from          string
+		to            string
+		expected      string
+		errstr        string
+	}{
+		{
+			testName:      "tla to infix",
+			specification: `\A x \in S : x.total >= 0 /\ x.open`,
+			from:          logic_spec.NotationTLAPlus,
+			to:            logic_spec.NotationInfix,
+			expected:      "all x in S: x.total >= 0 && x.open",
+		},
+		{
+			testName:      "infix to tla",
+			specification: "r with {total: @ + 1}",
+			from:          logic_spec.NotationInfix,
+			to:            logic_spec.NotationTLAPlus,
+			expected:      "[r EXCEPT !.total = @ + 1]",
+		},
+		{
+			testName:      "same notation is unchanged",
+			specification: `x  \in  S`,
+			from:          logic_spec.NotationTLAPlus,
+			to:            logic_spec.NotationTLAPlus,
+			expected:      `x  \in  S`,
+		},
+		{
+			testName:      "error unknown notation",
+			specification: "x",
+			from:          logic_spec.NotationTLAPlus,
+			to:            "z",
+			errstr:        `cannot translate from notation "tla_plus" to "z"`,
+		},
+		{
+			testName:      "error unparseable",
+			specification: "x &&",
+			from:          logic_spec.NotationInfix,
+			to:            logic_spec.NotationTLAPlus,
+			errstr:        "infix parse error",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			result, err := TranslateSpecification(tt.specification, tt.from, tt.to)
+			if tt.errstr != "" {
+				s.Require().ErrorContains(err, tt.errstr)
+				return
+			}
+			s.Require().NoError(err)
+			s.Equal(tt.expected, result)
+		})
+	}
+}
+
+func (s *NotationTestSuite) TestNotationExpressionParseFunc() {
+	attrKey := identity.Key{}
+	ctx := &LowerContext{AttributeNames: map[string]identity.Key{"total": attrKey}}
+
+	pf := NewNotationExpressionParseFunc(logic_spec.NotationInfix, ctx)
+	expr, normalized := pf("self.total'  ==  (self.total + 1)")
+	s.Require().NotNil(expr)
+	s.IsType(&me.Compare{}, expr)
+	s.Equal("self.total' == self.total + 1", normalized)
+
+	// The same expression written in TLA+ lowers to the same tree.
+	tlaExpr, _ := NewExpressionParseFunc(ctx)("self.total' = self.total + 1")
+	s.Equal(tlaExpr, expr)
+
+	expr, normalized = pf("self.total ==")
+	s.Nil(expr)
+	s.Empty(normalized)
+}
+
+func (s *NotationTestSuite) TestNotationExpressionParseFuncStrict() {
+	pf := NewNotationExpressionParseFuncStrict(logic_spec.NotationInfix, nil)
+
+	_, normalized, err := pf("1 + 2 > 2 && true")
+	s.Require().NoError(err)
+	s.Equal("1 + 2 > 2 && true", normalized)
+
+	_, _, err = pf("1 +")
+	s.Require().ErrorContains(err, "infix parse error: line 1, column 4")
+
+	// Positions are in the infix text the author wrote.
+	_, _, err = pf("all x in {1, 2}: x >= 1 &&\n  x <= ")
+	s.Require().ErrorContains(err, "infix parse error: line 2, column 8")
+
+	// Lowering errors quote the infix text rather than a TLA+ translation of it.
+	_, _, err = pf("unknown_name != 1")
+	s.Require().ErrorContains(err, `infix lowering error in "unknown_name != 1"`)
+}
@@ -7,8 +7,6 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
-	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
-	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
 )
 
 // StrictExpressionParseFunc parses a specification and returns an error on failure
@@ -20,57 +18,54 @@ type StrictExpressionParseFunc func(specification string) (me.Expression, string
 // is used (suitable for context-free expressions like literals and arithmetic).
 // Returns (expression, normalizedTLA) on success, (nil, "") on any failure.
 func NewExpressionParseFunc(ctx *LowerContext) logic_spec.ExpressionParseFunc {
-	if ctx == nil {
-		ctx = &LowerContext{}
-	}
+	return newExpressionParseFunc(tlaPlusSyntax, ctx)
+}
+
+// NewExpressionParseFuncStrict creates a strict parse function that returns errors
+// instead of silently swallowing them. Used by the AI parser path where parse
+// failures should be reported to the calling AI.
+func NewExpressionParseFuncStrict(ctx *LowerContext) StrictExpressionParseFunc {
+	return newExpressionParseFuncStrict(tlaPlusSyntax, ctx)
+}
+
+// newExpressionParseFunc is NewExpressionParseFunc for specifications written in
+// the given syntax. The normalized text is in the same syntax.
+func newExpressionParseFunc(syntax notationSyntax, ctx *LowerContext) logic_spec.ExpressionParseFunc {
+	strict := newExpressionParseFuncStrict(syntax, ctx)
 	return func(specification string) (me.Expression, string) {
-		// Parse TLA+ text to AST.
-		astExpr, err := parser.ParseExpression(specification)
+		expr, normalized, err := strict(specification)
 		if err != nil {
 			return nil, ""
 		}
-		// Lower AST to model expression.
-		expr, err := Lower(astExpr, ctx)
-		if err != nil {
-			return nil, ""
-		}
-		// Round-trip: raise back to TLA+ for normalized form.
-		raisedAST, err := Raise(expr, RaiseContextFromLower(ctx))
-		if err != nil {
-			// Lowering succeeded but raising failed — keep the expression
-			// with the original specification text.
-			return expr, ""
-		}
-		return expr, ast.Print(raisedAST)
+		return expr, normalized
 	}
 }
 
-// NewExpressionParseFuncStrict creates a strict parse function that returns errors
-// instead of silently swallowing them. Used by the AI parser path where parse
-// failures should be reported to the calling AI.
-func NewExpressionParseFuncStrict(ctx *LowerContext) StrictExpressionParseFunc {
+// newExpressionParseFuncStrict is NewExpressionParseFuncStrict for specifications
+// written in the given syntax.
+func newExpressionParseFuncStrict(syntax notationSyntax, ctx *LowerContext) StrictExpressionParseFunc {
 	if ctx == nil {
 		ctx = &LowerContext{}
 	}
 	return func(specification string) (me.Expression, string, error) {
-		// Parse TLA+ text to AST.
-		astExpr, err := parser.ParseExpression(specification)
+		// Parse the text to AST.
+		astExpr, err := syntax.parse(specification)
 		if err != nil {
-			return nil, "", fmt.Errorf("TLA+ parse error in %q: %w", specification, err)
+			return nil, "", fmt.Errorf("%s parse error in %q: %w", syntax.name, specification, err)
 		}
 		// Lower AST to model expression.
 		expr, err := Lower(astExpr, ctx)
 		if err != nil {
-			return nil, "", fmt.Errorf("TLA+ lowering error in %q: %w", specification, err)
+			return nil, "", fmt.Errorf("%s lowering error in %q: %w", syntax.name, specification, err)
 		}
-		// Round-trip: raise back to TLA+ for normalized form.
+		// Round-trip: raise back to the same syntax for normalized form.
 		raisedAST, err := Raise(expr, RaiseContextFromLower(ctx))
 		if err != nil {
 			// Lowering succeeded but raising failed — keep the expression
 			// with the original specification text.
 			return expr, "", nil
 		}
-		return expr, ast.Print(raisedAST), nil
+		return expr, syntax.print(raisedAST), nil
 	}
 }
 
@@ -173,6 +173,12 @@ func Raise(expr me.Expression, ctx *RaiseContext) (ast.Expression, error) {
 	case *me.Choose:
 		return raiseChoose(e, ctx)
 
+	case *me.FunctionDef:
+		return raiseFunctionDef(e, ctx)
+
+	case *me.FunctionApply:
+		return raiseFunctionApply(e, ctx)
+
 	case *me.Case:
 		return raiseCase(e, ctx)
 
@@ -207,12 +213,12 @@ func Raise(expr me.Expression, ctx *RaiseContext) (ast.Expression, error) {
 // --- Operator enum → Unicode string tables ---
 
 var raiseArithOp = map[me.ArithOp]string{
-	me.ArithAdd: "+",
-	me.ArithSub: "-",
-	me.ArithMul: "*",
-	me.ArithDiv: "÷",
-	me.ArithMod: "%",
-	me.ArithPow: "^",
+	me.ArithAdd:    "+",
+	me.ArithSub:    "-",
+	me.ArithMul:    "*",
+	me.ArithIntDiv: "÷",
+	me.ArithMod:    "%",
+	me.ArithPow:    "^",
 }
 
 var raiseLogicOp = map[me.LogicOp]string{
@@ -444,10 +450,6 @@ func raiseNot(e *me.Not, ctx *RaiseContext) (ast.Expression, error) {
 // --- Binary operator raising ---
 
 func raiseBinaryArith(e *me.BinaryArith, ctx *RaiseContext) (ast.Expression, error) {
-	op, ok := raiseArithOp[e.Op]
-	if !ok {
-		return nil, fmt.Errorf("unknown ArithOp: %q", e.Op)
-	}
 	left, err := Raise(e.Left, ctx)
 	if err != nil {
 		return nil, fmt.Errorf("BinaryArith.Left: %w", err)
@@ -456,6 +458,14 @@ func raiseBinaryArith(e *me.BinaryArith, ctx *RaiseContext) (ast.Expression, err
 	if err != nil {
 		return nil, fmt.Errorf("BinaryArith.Right: %w", err)
 	}
+	// Real division is the / operator, which the AST keeps as a Fraction.
+	if e.Op == me.ArithDiv {
+		return ast.NewFraction(left, right), nil
+	}
+	op, ok := raiseArithOp[e.Op]
+	if !ok {
+		return nil, fmt.Errorf("unknown ArithOp: %q", e.Op)
+	}
 	return &ast.BinaryArithmetic{Operator: op, Left: left, Right: right}, nil
 }
 
@@ -709,6 +719,35 @@ func raiseChoose(e *me.Choose, ctx *RaiseContext) (ast.Expression, error) {
 	return &ast.ChooseExpr{Membership: membership, Predicate: predicate}, nil
 }
 
+func raiseFunctionDef(e *me.FunctionDef, ctx *RaiseContext) (ast.Expression, error) {
+	domain, err := Raise(e.Domain, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FunctionDef.Domain: %w", err)
+	}
+	value, err := Raise(e.Value, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FunctionDef.Value: %w", err)
+	}
+	body, err := Raise(e.Body, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FunctionDef.Body: %w", err)
+	}
+	membership := &ast.Membership{
+		Operator: "∈",
+		Left:     &ast.Identifier{Value: e.Variable},
+		Right:    domain,
+	}
+	return &ast.LetFunction{Name: e.Name, Membership: membership, Value: value, Body: body}, nil
+}
+
+func raiseFunctionApply(e *me.FunctionApply, ctx *RaiseContext) (ast.Expression, error) {
+	arg, err := Raise(e.Arg, ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FunctionApply.Arg: %w", err)
+	}
+	return &ast.TupleIndex{Tuple: &ast.Identifier{Value: e.Name}, Index: arg}, nil
+}
+
 func raiseCase(e *me.Case, ctx *RaiseContext) (ast.Expression, error) {
 	branches := make([]*ast.CaseBranch, len(e.Branches))
 	for i, branch := range e.Branches {
@@ -904,8 +943,18 @@ func raiseGlobalCall(e *me.GlobalCall, ctx *RaiseContext) (ast.Expression, error
 }
 
 func raiseBuiltinCall(e *me.BuiltinCall, ctx *RaiseContext) (ast.Expression, error) {
+	operatorIndex, hasOperator := me.BuiltinOperatorArg(e.Module, e.Function)
 	args := make([]ast.Expression, len(e.Args))
 	for i, arg := range e.Args {
+		// The operator argument of a higher-order builtin is the bare global function name.
+		if call, ok := arg.(*me.GlobalCall); ok && hasOperator && i == operatorIndex && len(call.Args) == 0 {
+			name, ok := ctx.GlobalFunctions[call.FunctionKey]
+			if !ok {
+				return nil, fmt.Errorf("unresolved global function key: %v", call.FunctionKey)
+			}
+			args[i] = &ast.Identifier{Value: name}
+			continue
+		}
 		raised, err := Raise(arg, ctx)
 		if err != nil {
 			return nil, fmt.Errorf("BuiltinCall.Args[%d]: %w", i, err)
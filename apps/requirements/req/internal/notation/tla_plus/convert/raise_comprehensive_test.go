@@ -166,7 +166,7 @@ func mul(l, r me.Expression) *me.BinaryArith {
 	return &me.BinaryArith{Op: me.ArithMul, Left: l, Right: r}
 }
 func div(l, r me.Expression) *me.BinaryArith {
-	return &me.BinaryArith{Op: me.ArithDiv, Left: l, Right: r}
+	return &me.BinaryArith{Op: me.ArithIntDiv, Left: l, Right: r}
 }
 func mod(l, r me.Expression) *me.BinaryArith {
 	return &me.BinaryArith{Op: me.ArithMod, Left: l, Right: r}
@@ -258,7 +258,7 @@ func (s *RaiseTestSuite) TestRoundTripNextState() {
 // --- Binary operator round-trips ---
 
 func (s *RaiseTestSuite) TestRoundTripBinaryArith() {
-	ops := []me.ArithOp{me.ArithAdd, me.ArithSub, me.ArithMul, me.ArithDiv, me.ArithMod, me.ArithPow}
+	ops := []me.ArithOp{me.ArithAdd, me.ArithSub, me.ArithMul, me.ArithIntDiv, me.ArithMod, me.ArithPow}
 	for _, op := range ops {
 		s.assertRoundTrip(&me.BinaryArith{
 			Op:    op,
@@ -268,6 +268,23 @@ func (s *RaiseTestSuite) TestRoundTripBinaryArith() {
 	}
 }
 
+func (s *RaiseTestSuite) TestRoundTripRealDivision() {
+	// Real division raises to the / operator; integer division keeps ÷.
+	printed := s.assertRoundTrip(&me.BinaryArith{
+		Op:    me.ArithDiv,
+		Left:  &me.LocalVar{Name: "amount"},
+		Right: &me.IntLiteral{Value: big.NewInt(4)},
+	})
+	s.Equal("amount / 4", printed)
+
+	printed = s.assertRoundTrip(&me.BinaryArith{
+		Op:    me.ArithIntDiv,
+		Left:  &me.LocalVar{Name: "amount"},
+		Right: &me.IntLiteral{Value: big.NewInt(4)},
+	})
+	s.Equal("amount ÷ 4", printed)
+}
+
 func (s *RaiseTestSuite) TestRoundTripBinaryLogic() {
 	ops := []me.LogicOp{me.LogicAnd, me.LogicOr, me.LogicImplies, me.LogicEquiv}
 	for _, op := range ops {
@@ -506,6 +523,30 @@ func (s *RaiseTestSuite) TestRoundTripQuantifierExists() {
 	})
 }
 
+// --- Function definition round-trips ---
+
+func (s *RaiseTestSuite) TestRoundTripFunctionDef() {
+	printed := s.assertRoundTrip(&me.FunctionDef{
+		Name:     "fact",
+		Variable: "n",
+		Domain:   &me.SetConstant{Kind: me.SetConstantNat},
+		Value: &me.BinaryArith{
+			Op:   me.ArithMul,
+			Left: &me.LocalVar{Name: "n"},
+			Right: &me.FunctionApply{
+				Name: "fact",
+				Arg: &me.BinaryArith{
+					Op:    me.ArithSub,
+					Left:  &me.LocalVar{Name: "n"},
+					Right: &me.IntLiteral{Value: big.NewInt(1)},
+				},
+			},
+		},
+		Body: &me.FunctionApply{Name: "fact", Arg: &me.IntLiteral{Value: big.NewInt(5)}},
+	})
+	s.Equal("LET fact[n ∈ Nat] == n * fact[n - 1] IN fact[5]", printed)
+}
+
 func (s *RaiseTestSuite) TestRaiseSetFilter() {
 	// SetFilter cannot do a full round-trip because the PEG parser does not
 	// support the {x ∈ S : P(x)} syntax. Instead, verify raise + print output.
@@ -565,6 +606,30 @@ func (s *RaiseTestSuite) TestRoundTripBuiltinCall() {
 	})
 }
 
+func (s *RaiseTestSuite) TestRoundTripBuiltinOperatorArg() {
+	// The operator argument of a higher-order builtin is the bare global function name.
+	attrKey := s.lowerCtx.AttributeNames["balance"]
+	globalKey := s.lowerCtx.GlobalFunctions["_Helper"]
+	printed := s.assertRoundTrip(&me.BuiltinCall{
+		Module:   "_Seq",
+		Function: "SelectSeq",
+		Args: []me.Expression{
+			&me.AttributeRef{AttributeKey: attrKey},
+			&me.GlobalCall{FunctionKey: globalKey},
+		},
+	})
+	s.Equal("_Seq!SelectSeq(balance, _Helper)", printed)
+}
+
+func (s *RaiseTestSuite) TestRoundTripBuiltinConstant() {
+	printed := s.assertRoundTrip(&me.BuiltinCall{
+		Module:   "_Bags",
+		Function: "EmptyBag",
+		Args:     []me.Expression{},
+	})
+	s.Equal("_Bags!EmptyBag", printed)
+}
+
 func (s *RaiseTestSuite) TestRoundTripCrossClassActionCall() {
 	crossKey := s.lowerCtx.AllActions["s2!c2!OtherAction"]
 	s.assertRoundTrip(&me.ActionCall{
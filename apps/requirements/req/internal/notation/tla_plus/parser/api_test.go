@@ -1,6 +1,7 @@
 package parser
 
 import (
+	"fmt"
 	"testing"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
@@ -60,3 +61,66 @@ func (s *APITestSuite) TestMustParseExpressionPanics() {
 		MustParseExpression("invalid@#$")
 	})
 }
+
+// =============================================================================
+// ParseExpressionPositions
+// =============================================================================
+
+func (s *APITestSuite) TestParseExpressionPositions() {
+	input := "x > 1 /\\\n  _Seq!Len(self.items) = y"
+	expr, positions, err := ParseExpressionPositions(input)
+	s.Require().NoError(err)
+
+	and := expr.(*ast.LogicInfixExpression)
+	compare := and.Left.(*ast.BinaryComparison)
+	equality := and.Right.(*ast.BinaryEquality)
+	call := equality.Left.(*ast.FunctionCall)
+	field := call.Args[0].(*ast.FieldAccess)
+
+	tests := []struct {
+		testName string
+		node     ast.Node
+		offset   int
+	}{
+		{testName: "root", node: and, offset: 0},
+		{testName: "first operand", node: compare.Left, offset: 0},
+		{testName: "literal", node: compare.Right, offset: 4},
+		{testName: "second line", node: equality, offset: 11},
+		{testName: "call argument", node: field, offset: 20},
+		{testName: "last identifier", node: equality.Right, offset: 34},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			offset, ok := positions.Offset(tt.node)
+			s.Require().True(ok)
+			s.Equal(tt.offset, offset)
+		})
+	}
+
+	// Plain parsing records nothing.
+	_, err = ParseExpression(input)
+	s.Require().NoError(err)
+}
+
+func (s *APITestSuite) TestErrorOffset() {
+	tests := []struct {
+		testName string
+		input    string
+		offset   int
+	}{
+		{testName: "unexpected character", input: "x > #", offset: 4},
+		{testName: "second line", input: "x = 1 /\\\n  y = )", offset: 15},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			_, err := ParseExpression(tt.input)
+			s.Require().Error(err)
+			offset, ok := ErrorOffset(err)
+			s.Require().True(ok)
+			s.Equal(tt.offset, offset)
+		})
+	}
+
+	_, ok := ErrorOffset(fmt.Errorf("plain error"))
+	s.False(ok)
+}
@@ -297,3 +297,39 @@ func (s *BagSuite) TestPrecedence_BagOpHigherThanComparison() {
 	_, ok = cmp.Left.(*ast.BinaryBagOperation)
 	s.True(ok, "expected left to be BinaryBagOperation, got %T", cmp.Left)
 }
+
+// =============================================================================
+// Bag Constants
+// =============================================================================
+
+// Test _Bags!EmptyBag parses without parentheses as a nullary module call.
+func (s *BagSuite) TestModuleConstant_EmptyBag() {
+	expr, err := ParseExpression("B ⊕ _Bags!EmptyBag")
+	s.Require().NoError(err)
+
+	sum, ok := expr.(*ast.BinaryBagOperation)
+	s.Require().True(ok, "expected *ast.BinaryBagOperation, got %T", expr)
+
+	call, ok := sum.Right.(*ast.FunctionCall)
+	s.Require().True(ok, "expected *ast.FunctionCall, got %T", sum.Right)
+	s.Equal("_Bags!EmptyBag", call.FullName())
+	s.Empty(call.Args)
+	s.True(call.IsModuleConstant())
+	s.Equal("B ⊕ _Bags!EmptyBag", ast.Print(expr))
+}
+
+// Test the parenthesized form parses to the same node.
+func (s *BagSuite) TestModuleConstant_EmptyBagParenthesized() {
+	expr, err := ParseExpression("_Bags!EmptyBag()")
+	s.Require().NoError(err)
+
+	call, ok := expr.(*ast.FunctionCall)
+	s.Require().True(ok, "expected *ast.FunctionCall, got %T", expr)
+	s.True(call.IsModuleConstant())
+}
+
+// Test a bare scoped name that is not a built-in module is rejected.
+func (s *BagSuite) TestModuleConstant_RequiresBuiltinModule() {
+	_, err := ParseExpression("Account!Close")
+	s.Require().Error(err)
+}
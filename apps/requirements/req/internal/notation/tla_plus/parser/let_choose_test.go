@@ -16,6 +16,15 @@ func TestParseLetExpr(t *testing.T) {
 	require.Equal(t, "x", let.Variable)
 }
 
+func TestParseLetFunction(t *testing.T) {
+	expr, err := parser.ParseExpression(`LET f[n \in Nat] == IF n = 0 THEN 1 ELSE n * f[n - 1] IN f[5]`)
+	require.NoError(t, err)
+	fn, ok := expr.(*ast.LetFunction)
+	require.True(t, ok)
+	require.Equal(t, "f", fn.Name)
+	require.Equal(t, "LET f[n ∈ Nat] == IF n = 0 THEN 1 ELSE n * f[n - 1] IN f[5]", fn.String())
+}
+
 func TestParseChooseExpr(t *testing.T) {
 	expr, err := parser.ParseExpression(`CHOOSE x \in {3, 1, 2} : TRUE`)
 	require.NoError(t, err)
@@ -3,6 +3,7 @@
 package parser
 
 import (
+	"errors"
 	"fmt"
 
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
@@ -24,6 +25,39 @@ func ParseExpression(input string) (ast.Expression, error) {
 	return expr, nil
 }
 
+// ParseExpressionPositions is like ParseExpression and also returns the byte offset
+// in input at which each node the parser built starts.
+func ParseExpressionPositions(input string) (ast.Expression, ast.Positions, error) {
+	positions := ast.Positions{}
+	result, err := Parse("", []byte(input), GlobalStore(positionsKey, positions))
+	if err != nil {
+		return nil, nil, fmt.Errorf("parse error: %w", err)
+	}
+
+	expr, ok := result.(ast.Expression)
+	if !ok {
+		return nil, nil, fmt.Errorf("unexpected parse result type: %T", result)
+	}
+
+	return expr, positions, nil
+}
+
+// ErrorOffset returns the byte offset in the input at which a parse error from this
+// package was found, or false when err carries no position.
+func ErrorOffset(err error) (int, bool) {
+	var list errList
+	if !errors.As(err, &list) {
+		return 0, false
+	}
+	for _, e := range list {
+		var pe *parserError
+		if errors.As(e, &pe) {
+			return pe.pos.offset, true
+		}
+	}
+	return 0, false
+}
+
 // ParseExpressionList parses multiple TLA+ expression strings.
 // Returns the list of parsed expressions, or an error if any expression is invalid.
 func ParseExpressionList(inputs []string) ([]ast.Expression, error) {
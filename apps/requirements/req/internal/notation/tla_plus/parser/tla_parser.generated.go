@@ -21,43 +21,57 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/ast"
 )
 
+// positionsKey is the global store entry holding the ast.Positions to fill, if any.
+const positionsKey = "positions"
+
+// node returns an action's result, recording where the node starts when the
+// caller asked for positions.
+func (c *current) node(result any) (any, error) {
+	if positions, ok := c.globalStore[positionsKey].(ast.Positions); ok {
+		if n, ok := result.(ast.Node); ok {
+			positions.Record(n, c.pos.offset)
+		}
+	}
+	return result, nil
+}
+
 var g = &grammar{
 	rules: []*rule{
 		{
 			name: "RootExpression",
-			pos:  position{line: 20, col: 1, offset: 562},
+			pos:  position{line: 34, col: 1, offset: 1015},
 			expr: &actionExpr{
-				pos: position{line: 20, col: 19, offset: 580},
+				pos: position{line: 34, col: 19, offset: 1033},
 				run: (*parser).callonRootExpression1,
 				expr: &seqExpr{
-					pos: position{line: 20, col: 19, offset: 580},
+					pos: position{line: 34, col: 19, offset: 1033},
 					exprs: []any{
 						&zeroOrOneExpr{
-							pos: position{line: 20, col: 19, offset: 580},
+							pos: position{line: 34, col: 19, offset: 1033},
 							expr: &ruleRefExpr{
-								pos:  position{line: 20, col: 19, offset: 580},
+								pos:  position{line: 34, col: 19, offset: 1033},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 20, col: 23, offset: 584},
+							pos:   position{line: 34, col: 23, offset: 1037},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 20, col: 28, offset: 589},
+								pos:  position{line: 34, col: 28, offset: 1042},
 								name: "Expression",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 20, col: 39, offset: 600},
+							pos: position{line: 34, col: 39, offset: 1053},
 							expr: &ruleRefExpr{
-								pos:  position{line: 20, col: 39, offset: 600},
+								pos:  position{line: 34, col: 39, offset: 1053},
 								name: "ws",
 							},
 						},
 						&notExpr{
-							pos: position{line: 20, col: 43, offset: 604},
+							pos: position{line: 34, col: 43, offset: 1057},
 							expr: &anyMatcher{
-								line: 20, col: 44, offset: 605,
+								line: 34, col: 44, offset: 1058,
 							},
 						},
 					},
@@ -66,60 +80,60 @@ var g = &grammar{
 		},
 		{
 			name: "Expression",
-			pos:  position{line: 72, col: 1, offset: 2305},
+			pos:  position{line: 86, col: 1, offset: 2758},
 			expr: &ruleRefExpr{
-				pos:  position{line: 72, col: 15, offset: 2319},
+				pos:  position{line: 86, col: 15, offset: 2772},
 				name: "ImpliesExpr",
 			},
 		},
 		{
 			name: "ImpliesExpr",
-			pos:  position{line: 80, col: 1, offset: 2637},
+			pos:  position{line: 94, col: 1, offset: 3090},
 			expr: &actionExpr{
-				pos: position{line: 80, col: 16, offset: 2652},
+				pos: position{line: 94, col: 16, offset: 3105},
 				run: (*parser).callonImpliesExpr1,
 				expr: &seqExpr{
-					pos: position{line: 80, col: 16, offset: 2652},
+					pos: position{line: 94, col: 16, offset: 3105},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 80, col: 16, offset: 2652},
+							pos:   position{line: 94, col: 16, offset: 3105},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 80, col: 21, offset: 2657},
+								pos:  position{line: 94, col: 21, offset: 3110},
 								name: "EquivExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 80, col: 31, offset: 2667},
+							pos:   position{line: 94, col: 31, offset: 3120},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 80, col: 36, offset: 2672},
+								pos: position{line: 94, col: 36, offset: 3125},
 								expr: &seqExpr{
-									pos: position{line: 80, col: 38, offset: 2674},
+									pos: position{line: 94, col: 38, offset: 3127},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 80, col: 38, offset: 2674},
+											pos: position{line: 94, col: 38, offset: 3127},
 											expr: &ruleRefExpr{
-												pos:  position{line: 80, col: 38, offset: 2674},
+												pos:  position{line: 94, col: 38, offset: 3127},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 80, col: 42, offset: 2678},
+											pos:  position{line: 94, col: 42, offset: 3131},
 											name: "ImpliesOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 80, col: 52, offset: 2688},
+											pos: position{line: 94, col: 52, offset: 3141},
 											expr: &ruleRefExpr{
-												pos:  position{line: 80, col: 52, offset: 2688},
+												pos:  position{line: 94, col: 52, offset: 3141},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 80, col: 56, offset: 2692},
+											pos:   position{line: 94, col: 56, offset: 3145},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 80, col: 62, offset: 2698},
+												pos:  position{line: 94, col: 62, offset: 3151},
 												name: "ImpliesExpr",
 											},
 										},
@@ -133,18 +147,18 @@ var g = &grammar{
 		},
 		{
 			name: "ImpliesOp",
-			pos:  position{line: 93, col: 1, offset: 2958},
+			pos:  position{line: 107, col: 1, offset: 3414},
 			expr: &choiceExpr{
-				pos: position{line: 93, col: 16, offset: 2973},
+				pos: position{line: 107, col: 16, offset: 3429},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 93, col: 16, offset: 2973},
+						pos:        position{line: 107, col: 16, offset: 3429},
 						val:        "⇒",
 						ignoreCase: false,
 						want:       "\"⇒\"",
 					},
 					&litMatcher{
-						pos:        position{line: 93, col: 22, offset: 2981},
+						pos:        position{line: 107, col: 22, offset: 3437},
 						val:        "=>",
 						ignoreCase: false,
 						want:       "\"=>\"",
@@ -154,52 +168,52 @@ var g = &grammar{
 		},
 		{
 			name: "EquivExpr",
-			pos:  position{line: 97, col: 1, offset: 3077},
+			pos:  position{line: 111, col: 1, offset: 3533},
 			expr: &actionExpr{
-				pos: position{line: 97, col: 14, offset: 3090},
+				pos: position{line: 111, col: 14, offset: 3546},
 				run: (*parser).callonEquivExpr1,
 				expr: &seqExpr{
-					pos: position{line: 97, col: 14, offset: 3090},
+					pos: position{line: 111, col: 14, offset: 3546},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 97, col: 14, offset: 3090},
+							pos:   position{line: 111, col: 14, offset: 3546},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 97, col: 19, offset: 3095},
+								pos:  position{line: 111, col: 19, offset: 3551},
 								name: "OrExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 97, col: 26, offset: 3102},
+							pos:   position{line: 111, col: 26, offset: 3558},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 97, col: 31, offset: 3107},
+								pos: position{line: 111, col: 31, offset: 3563},
 								expr: &seqExpr{
-									pos: position{line: 97, col: 33, offset: 3109},
+									pos: position{line: 111, col: 33, offset: 3565},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 97, col: 33, offset: 3109},
+											pos: position{line: 111, col: 33, offset: 3565},
 											expr: &ruleRefExpr{
-												pos:  position{line: 97, col: 33, offset: 3109},
+												pos:  position{line: 111, col: 33, offset: 3565},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 97, col: 37, offset: 3113},
+											pos:  position{line: 111, col: 37, offset: 3569},
 											name: "EquivOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 97, col: 45, offset: 3121},
+											pos: position{line: 111, col: 45, offset: 3577},
 											expr: &ruleRefExpr{
-												pos:  position{line: 97, col: 45, offset: 3121},
+												pos:  position{line: 111, col: 45, offset: 3577},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 97, col: 49, offset: 3125},
+											pos:   position{line: 111, col: 49, offset: 3581},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 97, col: 55, offset: 3131},
+												pos:  position{line: 111, col: 55, offset: 3587},
 												name: "OrExpr",
 											},
 										},
@@ -213,18 +227,18 @@ var g = &grammar{
 		},
 		{
 			name: "EquivOp",
-			pos:  position{line: 113, col: 1, offset: 3443},
+			pos:  position{line: 127, col: 1, offset: 3902},
 			expr: &choiceExpr{
-				pos: position{line: 113, col: 14, offset: 3456},
+				pos: position{line: 127, col: 14, offset: 3915},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 113, col: 14, offset: 3456},
+						pos:        position{line: 127, col: 14, offset: 3915},
 						val:        "≡",
 						ignoreCase: false,
 						want:       "\"≡\"",
 					},
 					&litMatcher{
-						pos:        position{line: 113, col: 20, offset: 3464},
+						pos:        position{line: 127, col: 20, offset: 3923},
 						val:        "<=>",
 						ignoreCase: false,
 						want:       "\"<=>\"",
@@ -234,52 +248,52 @@ var g = &grammar{
 		},
 		{
 			name: "OrExpr",
-			pos:  position{line: 117, col: 1, offset: 3549},
+			pos:  position{line: 131, col: 1, offset: 4008},
 			expr: &actionExpr{
-				pos: position{line: 117, col: 11, offset: 3559},
+				pos: position{line: 131, col: 11, offset: 4018},
 				run: (*parser).callonOrExpr1,
 				expr: &seqExpr{
-					pos: position{line: 117, col: 11, offset: 3559},
+					pos: position{line: 131, col: 11, offset: 4018},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 117, col: 11, offset: 3559},
+							pos:   position{line: 131, col: 11, offset: 4018},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 117, col: 16, offset: 3564},
+								pos:  position{line: 131, col: 16, offset: 4023},
 								name: "AndExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 117, col: 24, offset: 3572},
+							pos:   position{line: 131, col: 24, offset: 4031},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 117, col: 29, offset: 3577},
+								pos: position{line: 131, col: 29, offset: 4036},
 								expr: &seqExpr{
-									pos: position{line: 117, col: 31, offset: 3579},
+									pos: position{line: 131, col: 31, offset: 4038},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 117, col: 31, offset: 3579},
+											pos: position{line: 131, col: 31, offset: 4038},
 											expr: &ruleRefExpr{
-												pos:  position{line: 117, col: 31, offset: 3579},
+												pos:  position{line: 131, col: 31, offset: 4038},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 117, col: 35, offset: 3583},
+											pos:  position{line: 131, col: 35, offset: 4042},
 											name: "OrOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 117, col: 40, offset: 3588},
+											pos: position{line: 131, col: 40, offset: 4047},
 											expr: &ruleRefExpr{
-												pos:  position{line: 117, col: 40, offset: 3588},
+												pos:  position{line: 131, col: 40, offset: 4047},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 117, col: 44, offset: 3592},
+											pos:   position{line: 131, col: 44, offset: 4051},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 117, col: 50, offset: 3598},
+												pos:  position{line: 131, col: 50, offset: 4057},
 												name: "AndExpr",
 											},
 										},
@@ -293,18 +307,18 @@ var g = &grammar{
 		},
 		{
 			name: "OrOp",
-			pos:  position{line: 133, col: 1, offset: 3911},
+			pos:  position{line: 147, col: 1, offset: 4373},
 			expr: &choiceExpr{
-				pos: position{line: 133, col: 11, offset: 3921},
+				pos: position{line: 147, col: 11, offset: 4383},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 133, col: 11, offset: 3921},
+						pos:        position{line: 147, col: 11, offset: 4383},
 						val:        "∨",
 						ignoreCase: false,
 						want:       "\"∨\"",
 					},
 					&litMatcher{
-						pos:        position{line: 133, col: 17, offset: 3929},
+						pos:        position{line: 147, col: 17, offset: 4391},
 						val:        "\\/",
 						ignoreCase: false,
 						want:       "\"\\\\/\"",
@@ -314,52 +328,52 @@ var g = &grammar{
 		},
 		{
 			name: "AndExpr",
-			pos:  position{line: 137, col: 1, offset: 4015},
+			pos:  position{line: 151, col: 1, offset: 4477},
 			expr: &actionExpr{
-				pos: position{line: 137, col: 12, offset: 4026},
+				pos: position{line: 151, col: 12, offset: 4488},
 				run: (*parser).callonAndExpr1,
 				expr: &seqExpr{
-					pos: position{line: 137, col: 12, offset: 4026},
+					pos: position{line: 151, col: 12, offset: 4488},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 137, col: 12, offset: 4026},
+							pos:   position{line: 151, col: 12, offset: 4488},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 137, col: 17, offset: 4031},
+								pos:  position{line: 151, col: 17, offset: 4493},
 								name: "NotExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 137, col: 25, offset: 4039},
+							pos:   position{line: 151, col: 25, offset: 4501},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 137, col: 30, offset: 4044},
+								pos: position{line: 151, col: 30, offset: 4506},
 								expr: &seqExpr{
-									pos: position{line: 137, col: 32, offset: 4046},
+									pos: position{line: 151, col: 32, offset: 4508},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 137, col: 32, offset: 4046},
+											pos: position{line: 151, col: 32, offset: 4508},
 											expr: &ruleRefExpr{
-												pos:  position{line: 137, col: 32, offset: 4046},
+												pos:  position{line: 151, col: 32, offset: 4508},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 137, col: 36, offset: 4050},
+											pos:  position{line: 151, col: 36, offset: 4512},
 											name: "AndOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 137, col: 42, offset: 4056},
+											pos: position{line: 151, col: 42, offset: 4518},
 											expr: &ruleRefExpr{
-												pos:  position{line: 137, col: 42, offset: 4056},
+												pos:  position{line: 151, col: 42, offset: 4518},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 137, col: 46, offset: 4060},
+											pos:   position{line: 151, col: 46, offset: 4522},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 137, col: 52, offset: 4066},
+												pos:  position{line: 151, col: 52, offset: 4528},
 												name: "NotExpr",
 											},
 										},
@@ -373,18 +387,18 @@ var g = &grammar{
 		},
 		{
 			name: "AndOp",
-			pos:  position{line: 153, col: 1, offset: 4379},
+			pos:  position{line: 167, col: 1, offset: 4844},
 			expr: &choiceExpr{
-				pos: position{line: 153, col: 12, offset: 4390},
+				pos: position{line: 167, col: 12, offset: 4855},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 153, col: 12, offset: 4390},
+						pos:        position{line: 167, col: 12, offset: 4855},
 						val:        "∧",
 						ignoreCase: false,
 						want:       "\"∧\"",
 					},
 					&litMatcher{
-						pos:        position{line: 153, col: 18, offset: 4398},
+						pos:        position{line: 167, col: 18, offset: 4863},
 						val:        "/\\",
 						ignoreCase: false,
 						want:       "\"/\\\\\"",
@@ -394,32 +408,32 @@ var g = &grammar{
 		},
 		{
 			name: "NotExpr",
-			pos:  position{line: 157, col: 1, offset: 4461},
+			pos:  position{line: 171, col: 1, offset: 4926},
 			expr: &choiceExpr{
-				pos: position{line: 157, col: 12, offset: 4472},
+				pos: position{line: 171, col: 12, offset: 4937},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 157, col: 12, offset: 4472},
+						pos: position{line: 171, col: 12, offset: 4937},
 						run: (*parser).callonNotExpr2,
 						expr: &seqExpr{
-							pos: position{line: 157, col: 12, offset: 4472},
+							pos: position{line: 171, col: 12, offset: 4937},
 							exprs: []any{
 								&ruleRefExpr{
-									pos:  position{line: 157, col: 12, offset: 4472},
+									pos:  position{line: 171, col: 12, offset: 4937},
 									name: "NotOp",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 157, col: 18, offset: 4478},
+									pos: position{line: 171, col: 18, offset: 4943},
 									expr: &ruleRefExpr{
-										pos:  position{line: 157, col: 18, offset: 4478},
+										pos:  position{line: 171, col: 18, offset: 4943},
 										name: "ws",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 157, col: 22, offset: 4482},
+									pos:   position{line: 171, col: 22, offset: 4947},
 									label: "right",
 									expr: &ruleRefExpr{
-										pos:  position{line: 157, col: 28, offset: 4488},
+										pos:  position{line: 171, col: 28, offset: 4953},
 										name: "NotExpr",
 									},
 								},
@@ -427,7 +441,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 162, col: 5, offset: 4600},
+						pos:  position{line: 176, col: 5, offset: 5068},
 						name: "QuantifierExpr",
 					},
 				},
@@ -435,18 +449,18 @@ var g = &grammar{
 		},
 		{
 			name: "NotOp",
-			pos:  position{line: 164, col: 1, offset: 4616},
+			pos:  position{line: 178, col: 1, offset: 5084},
 			expr: &choiceExpr{
-				pos: position{line: 164, col: 12, offset: 4627},
+				pos: position{line: 178, col: 12, offset: 5095},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 164, col: 12, offset: 4627},
+						pos:        position{line: 178, col: 12, offset: 5095},
 						val:        "¬",
 						ignoreCase: false,
 						want:       "\"¬\"",
 					},
 					&litMatcher{
-						pos:        position{line: 164, col: 18, offset: 4634},
+						pos:        position{line: 178, col: 18, offset: 5102},
 						val:        "~",
 						ignoreCase: false,
 						want:       "\"~\"",
@@ -456,64 +470,64 @@ var g = &grammar{
 		},
 		{
 			name: "QuantifierExpr",
-			pos:  position{line: 174, col: 1, offset: 5062},
+			pos:  position{line: 188, col: 1, offset: 5530},
 			expr: &choiceExpr{
-				pos: position{line: 174, col: 19, offset: 5080},
+				pos: position{line: 188, col: 19, offset: 5548},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 174, col: 19, offset: 5080},
+						pos: position{line: 188, col: 19, offset: 5548},
 						run: (*parser).callonQuantifierExpr2,
 						expr: &seqExpr{
-							pos: position{line: 174, col: 19, offset: 5080},
+							pos: position{line: 188, col: 19, offset: 5548},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 174, col: 19, offset: 5080},
+									pos:   position{line: 188, col: 19, offset: 5548},
 									label: "op",
 									expr: &ruleRefExpr{
-										pos:  position{line: 174, col: 22, offset: 5083},
+										pos:  position{line: 188, col: 22, offset: 5551},
 										name: "QuantifierOp",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 174, col: 35, offset: 5096},
+									pos: position{line: 188, col: 35, offset: 5564},
 									expr: &ruleRefExpr{
-										pos:  position{line: 174, col: 35, offset: 5096},
+										pos:  position{line: 188, col: 35, offset: 5564},
 										name: "ws",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 174, col: 39, offset: 5100},
+									pos:   position{line: 188, col: 39, offset: 5568},
 									label: "membership",
 									expr: &ruleRefExpr{
-										pos:  position{line: 174, col: 50, offset: 5111},
+										pos:  position{line: 188, col: 50, offset: 5579},
 										name: "SetMembershipExpr",
 									},
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 174, col: 68, offset: 5129},
+									pos: position{line: 188, col: 68, offset: 5597},
 									expr: &ruleRefExpr{
-										pos:  position{line: 174, col: 68, offset: 5129},
+										pos:  position{line: 188, col: 68, offset: 5597},
 										name: "ws",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 174, col: 72, offset: 5133},
+									pos:        position{line: 188, col: 72, offset: 5601},
 									val:        ":",
 									ignoreCase: false,
 									want:       "\":\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 174, col: 76, offset: 5137},
+									pos: position{line: 188, col: 76, offset: 5605},
 									expr: &ruleRefExpr{
-										pos:  position{line: 174, col: 76, offset: 5137},
+										pos:  position{line: 188, col: 76, offset: 5605},
 										name: "ws",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 174, col: 80, offset: 5141},
+									pos:   position{line: 188, col: 80, offset: 5609},
 									label: "predicate",
 									expr: &ruleRefExpr{
-										pos:  position{line: 174, col: 90, offset: 5151},
+										pos:  position{line: 188, col: 90, offset: 5619},
 										name: "Expression",
 									},
 								},
@@ -521,7 +535,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 180, col: 5, offset: 5313},
+						pos:  position{line: 194, col: 5, offset: 5784},
 						name: "ComparisonExpr",
 					},
 				},
@@ -529,33 +543,33 @@ var g = &grammar{
 		},
 		{
 			name: "QuantifierOp",
-			pos:  position{line: 183, col: 1, offset: 5393},
+			pos:  position{line: 197, col: 1, offset: 5864},
 			expr: &actionExpr{
-				pos: position{line: 183, col: 17, offset: 5409},
+				pos: position{line: 197, col: 17, offset: 5880},
 				run: (*parser).callonQuantifierOp1,
 				expr: &choiceExpr{
-					pos: position{line: 183, col: 19, offset: 5411},
+					pos: position{line: 197, col: 19, offset: 5882},
 					alternatives: []any{
 						&litMatcher{
-							pos:        position{line: 183, col: 19, offset: 5411},
+							pos:        position{line: 197, col: 19, offset: 5882},
 							val:        "∀",
 							ignoreCase: false,
 							want:       "\"∀\"",
 						},
 						&litMatcher{
-							pos:        position{line: 183, col: 25, offset: 5419},
+							pos:        position{line: 197, col: 25, offset: 5890},
 							val:        "\\A",
 							ignoreCase: false,
 							want:       "\"\\\\A\"",
 						},
 						&litMatcher{
-							pos:        position{line: 183, col: 33, offset: 5427},
+							pos:        position{line: 197, col: 33, offset: 5898},
 							val:        "∃",
 							ignoreCase: false,
 							want:       "\"∃\"",
 						},
 						&litMatcher{
-							pos:        position{line: 183, col: 39, offset: 5435},
+							pos:        position{line: 197, col: 39, offset: 5906},
 							val:        "\\E",
 							ignoreCase: false,
 							want:       "\"\\\\E\"",
@@ -566,64 +580,64 @@ var g = &grammar{
 		},
 		{
 			name: "ComparisonExpr",
-			pos:  position{line: 205, col: 1, offset: 6068},
+			pos:  position{line: 219, col: 1, offset: 6539},
 			expr: &ruleRefExpr{
-				pos:  position{line: 205, col: 19, offset: 6086},
+				pos:  position{line: 219, col: 19, offset: 6557},
 				name: "SetMembershipExpr",
 			},
 		},
 		{
 			name: "SetMembershipExpr",
-			pos:  position{line: 208, col: 1, offset: 6147},
+			pos:  position{line: 222, col: 1, offset: 6618},
 			expr: &actionExpr{
-				pos: position{line: 208, col: 22, offset: 6168},
+				pos: position{line: 222, col: 22, offset: 6639},
 				run: (*parser).callonSetMembershipExpr1,
 				expr: &seqExpr{
-					pos: position{line: 208, col: 22, offset: 6168},
+					pos: position{line: 222, col: 22, offset: 6639},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 208, col: 22, offset: 6168},
+							pos:   position{line: 222, col: 22, offset: 6639},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 208, col: 27, offset: 6173},
+								pos:  position{line: 222, col: 27, offset: 6644},
 								name: "SetComparisonExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 208, col: 45, offset: 6191},
+							pos:   position{line: 222, col: 45, offset: 6662},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 208, col: 50, offset: 6196},
+								pos: position{line: 222, col: 50, offset: 6667},
 								expr: &seqExpr{
-									pos: position{line: 208, col: 52, offset: 6198},
+									pos: position{line: 222, col: 52, offset: 6669},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 208, col: 52, offset: 6198},
+											pos: position{line: 222, col: 52, offset: 6669},
 											expr: &ruleRefExpr{
-												pos:  position{line: 208, col: 52, offset: 6198},
+												pos:  position{line: 222, col: 52, offset: 6669},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 208, col: 56, offset: 6202},
+											pos:   position{line: 222, col: 56, offset: 6673},
 											label: "op",
 											expr: &ruleRefExpr{
-												pos:  position{line: 208, col: 59, offset: 6205},
+												pos:  position{line: 222, col: 59, offset: 6676},
 												name: "SetMembershipOp",
 											},
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 208, col: 75, offset: 6221},
+											pos: position{line: 222, col: 75, offset: 6692},
 											expr: &ruleRefExpr{
-												pos:  position{line: 208, col: 75, offset: 6221},
+												pos:  position{line: 222, col: 75, offset: 6692},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 208, col: 79, offset: 6225},
+											pos:   position{line: 222, col: 79, offset: 6696},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 208, col: 85, offset: 6231},
+												pos:  position{line: 222, col: 85, offset: 6702},
 												name: "SetComparisonExpr",
 											},
 										},
@@ -637,33 +651,33 @@ var g = &grammar{
 		},
 		{
 			name: "SetMembershipOp",
-			pos:  position{line: 223, col: 1, offset: 6555},
+			pos:  position{line: 237, col: 1, offset: 7029},
 			expr: &actionExpr{
-				pos: position{line: 223, col: 20, offset: 6574},
+				pos: position{line: 237, col: 20, offset: 7048},
 				run: (*parser).callonSetMembershipOp1,
 				expr: &choiceExpr{
-					pos: position{line: 223, col: 22, offset: 6576},
+					pos: position{line: 237, col: 22, offset: 7050},
 					alternatives: []any{
 						&litMatcher{
-							pos:        position{line: 223, col: 22, offset: 6576},
+							pos:        position{line: 237, col: 22, offset: 7050},
 							val:        "∈",
 							ignoreCase: false,
 							want:       "\"∈\"",
 						},
 						&litMatcher{
-							pos:        position{line: 223, col: 28, offset: 6584},
+							pos:        position{line: 237, col: 28, offset: 7058},
 							val:        "\\in",
 							ignoreCase: false,
 							want:       "\"\\\\in\"",
 						},
 						&litMatcher{
-							pos:        position{line: 223, col: 37, offset: 6593},
+							pos:        position{line: 237, col: 37, offset: 7067},
 							val:        "∉",
 							ignoreCase: false,
 							want:       "\"∉\"",
 						},
 						&litMatcher{
-							pos:        position{line: 223, col: 43, offset: 6601},
+							pos:        position{line: 237, col: 43, offset: 7075},
 							val:        "\\notin",
 							ignoreCase: false,
 							want:       "\"\\\\notin\"",
@@ -674,56 +688,56 @@ var g = &grammar{
 		},
 		{
 			name: "SetComparisonExpr",
-			pos:  position{line: 236, col: 1, offset: 6814},
+			pos:  position{line: 250, col: 1, offset: 7288},
 			expr: &actionExpr{
-				pos: position{line: 236, col: 22, offset: 6835},
+				pos: position{line: 250, col: 22, offset: 7309},
 				run: (*parser).callonSetComparisonExpr1,
 				expr: &seqExpr{
-					pos: position{line: 236, col: 22, offset: 6835},
+					pos: position{line: 250, col: 22, offset: 7309},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 236, col: 22, offset: 6835},
+							pos:   position{line: 250, col: 22, offset: 7309},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 236, col: 27, offset: 6840},
+								pos:  position{line: 250, col: 27, offset: 7314},
 								name: "BagComparisonExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 236, col: 45, offset: 6858},
+							pos:   position{line: 250, col: 45, offset: 7332},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 236, col: 50, offset: 6863},
+								pos: position{line: 250, col: 50, offset: 7337},
 								expr: &seqExpr{
-									pos: position{line: 236, col: 52, offset: 6865},
+									pos: position{line: 250, col: 52, offset: 7339},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 236, col: 52, offset: 6865},
+											pos: position{line: 250, col: 52, offset: 7339},
 											expr: &ruleRefExpr{
-												pos:  position{line: 236, col: 52, offset: 6865},
+												pos:  position{line: 250, col: 52, offset: 7339},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 236, col: 56, offset: 6869},
+											pos:   position{line: 250, col: 56, offset: 7343},
 											label: "op",
 											expr: &ruleRefExpr{
-												pos:  position{line: 236, col: 59, offset: 6872},
+												pos:  position{line: 250, col: 59, offset: 7346},
 												name: "SetComparisonOp",
 											},
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 236, col: 75, offset: 6888},
+											pos: position{line: 250, col: 75, offset: 7362},
 											expr: &ruleRefExpr{
-												pos:  position{line: 236, col: 75, offset: 6888},
+												pos:  position{line: 250, col: 75, offset: 7362},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 236, col: 79, offset: 6892},
+											pos:   position{line: 250, col: 79, offset: 7366},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 236, col: 85, offset: 6898},
+												pos:  position{line: 250, col: 85, offset: 7372},
 												name: "BagComparisonExpr",
 											},
 										},
@@ -737,57 +751,57 @@ var g = &grammar{
 		},
 		{
 			name: "SetComparisonOp",
-			pos:  position{line: 251, col: 1, offset: 7252},
+			pos:  position{line: 265, col: 1, offset: 7729},
 			expr: &actionExpr{
-				pos: position{line: 251, col: 20, offset: 7271},
+				pos: position{line: 265, col: 20, offset: 7748},
 				run: (*parser).callonSetComparisonOp1,
 				expr: &choiceExpr{
-					pos: position{line: 251, col: 22, offset: 7273},
+					pos: position{line: 265, col: 22, offset: 7750},
 					alternatives: []any{
 						&litMatcher{
-							pos:        position{line: 251, col: 22, offset: 7273},
+							pos:        position{line: 265, col: 22, offset: 7750},
 							val:        "⊆",
 							ignoreCase: false,
 							want:       "\"⊆\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 28, offset: 7281},
+							pos:        position{line: 265, col: 28, offset: 7758},
 							val:        "\\subseteq",
 							ignoreCase: false,
 							want:       "\"\\\\subseteq\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 43, offset: 7296},
+							pos:        position{line: 265, col: 43, offset: 7773},
 							val:        "⊇",
 							ignoreCase: false,
 							want:       "\"⊇\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 49, offset: 7304},
+							pos:        position{line: 265, col: 49, offset: 7781},
 							val:        "\\supseteq",
 							ignoreCase: false,
 							want:       "\"\\\\supseteq\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 64, offset: 7319},
+							pos:        position{line: 265, col: 64, offset: 7796},
 							val:        "⊂",
 							ignoreCase: false,
 							want:       "\"⊂\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 70, offset: 7327},
+							pos:        position{line: 265, col: 70, offset: 7804},
 							val:        "\\subset",
 							ignoreCase: false,
 							want:       "\"\\\\subset\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 83, offset: 7340},
+							pos:        position{line: 265, col: 83, offset: 7817},
 							val:        "⊃",
 							ignoreCase: false,
 							want:       "\"⊃\"",
 						},
 						&litMatcher{
-							pos:        position{line: 251, col: 89, offset: 7348},
+							pos:        position{line: 265, col: 89, offset: 7825},
 							val:        "\\supset",
 							ignoreCase: false,
 							want:       "\"\\\\supset\"",
@@ -798,56 +812,56 @@ var g = &grammar{
 		},
 		{
 			name: "BagComparisonExpr",
-			pos:  position{line: 268, col: 1, offset: 7678},
+			pos:  position{line: 282, col: 1, offset: 8155},
 			expr: &actionExpr{
-				pos: position{line: 268, col: 22, offset: 7699},
+				pos: position{line: 282, col: 22, offset: 8176},
 				run: (*parser).callonBagComparisonExpr1,
 				expr: &seqExpr{
-					pos: position{line: 268, col: 22, offset: 7699},
+					pos: position{line: 282, col: 22, offset: 8176},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 268, col: 22, offset: 7699},
+							pos:   position{line: 282, col: 22, offset: 8176},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 268, col: 27, offset: 7704},
+								pos:  position{line: 282, col: 27, offset: 8181},
 								name: "EqualityExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 268, col: 40, offset: 7717},
+							pos:   position{line: 282, col: 40, offset: 8194},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 268, col: 45, offset: 7722},
+								pos: position{line: 282, col: 45, offset: 8199},
 								expr: &seqExpr{
-									pos: position{line: 268, col: 47, offset: 7724},
+									pos: position{line: 282, col: 47, offset: 8201},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 268, col: 47, offset: 7724},
+											pos: position{line: 282, col: 47, offset: 8201},
 											expr: &ruleRefExpr{
-												pos:  position{line: 268, col: 47, offset: 7724},
+												pos:  position{line: 282, col: 47, offset: 8201},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 268, col: 51, offset: 7728},
+											pos:   position{line: 282, col: 51, offset: 8205},
 											label: "op",
 											expr: &ruleRefExpr{
-												pos:  position{line: 268, col: 54, offset: 7731},
+												pos:  position{line: 282, col: 54, offset: 8208},
 												name: "BagComparisonOp",
 											},
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 268, col: 70, offset: 7747},
+											pos: position{line: 282, col: 70, offset: 8224},
 											expr: &ruleRefExpr{
-												pos:  position{line: 268, col: 70, offset: 7747},
+												pos:  position{line: 282, col: 70, offset: 8224},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 268, col: 74, offset: 7751},
+											pos:   position{line: 282, col: 74, offset: 8228},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 268, col: 80, offset: 7757},
+												pos:  position{line: 282, col: 80, offset: 8234},
 												name: "EqualityExpr",
 											},
 										},
@@ -861,57 +875,57 @@ var g = &grammar{
 		},
 		{
 			name: "BagComparisonOp",
-			pos:  position{line: 284, col: 1, offset: 8201},
+			pos:  position{line: 298, col: 1, offset: 8681},
 			expr: &actionExpr{
-				pos: position{line: 284, col: 20, offset: 8220},
+				pos: position{line: 298, col: 20, offset: 8700},
 				run: (*parser).callonBagComparisonOp1,
 				expr: &choiceExpr{
-					pos: position{line: 284, col: 22, offset: 8222},
+					pos: position{line: 298, col: 22, offset: 8702},
 					alternatives: []any{
 						&litMatcher{
-							pos:        position{line: 284, col: 22, offset: 8222},
+							pos:        position{line: 298, col: 22, offset: 8702},
 							val:        "⊏",
 							ignoreCase: false,
 							want:       "\"⊏\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 28, offset: 8230},
+							pos:        position{line: 298, col: 28, offset: 8710},
 							val:        "\\sqsubseteq",
 							ignoreCase: false,
 							want:       "\"\\\\sqsubseteq\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 45, offset: 8247},
+							pos:        position{line: 298, col: 45, offset: 8727},
 							val:        "\\sqsubset",
 							ignoreCase: false,
 							want:       "\"\\\\sqsubset\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 60, offset: 8262},
+							pos:        position{line: 298, col: 60, offset: 8742},
 							val:        "⊑",
 							ignoreCase: false,
 							want:       "\"⊑\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 66, offset: 8270},
+							pos:        position{line: 298, col: 66, offset: 8750},
 							val:        "\\sqsupseteq",
 							ignoreCase: false,
 							want:       "\"\\\\sqsupseteq\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 83, offset: 8287},
+							pos:        position{line: 298, col: 83, offset: 8767},
 							val:        "\\sqsupset",
 							ignoreCase: false,
 							want:       "\"\\\\sqsupset\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 98, offset: 8302},
+							pos:        position{line: 298, col: 98, offset: 8782},
 							val:        "⊐",
 							ignoreCase: false,
 							want:       "\"⊐\"",
 						},
 						&litMatcher{
-							pos:        position{line: 284, col: 104, offset: 8310},
+							pos:        position{line: 298, col: 104, offset: 8790},
 							val:        "⊒",
 							ignoreCase: false,
 							want:       "\"⊒\"",
@@ -922,56 +936,56 @@ var g = &grammar{
 		},
 		{
 			name: "EqualityExpr",
-			pos:  position{line: 301, col: 1, offset: 8592},
+			pos:  position{line: 315, col: 1, offset: 9072},
 			expr: &actionExpr{
-				pos: position{line: 301, col: 17, offset: 8608},
+				pos: position{line: 315, col: 17, offset: 9088},
 				run: (*parser).callonEqualityExpr1,
 				expr: &seqExpr{
-					pos: position{line: 301, col: 17, offset: 8608},
+					pos: position{line: 315, col: 17, offset: 9088},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 301, col: 17, offset: 8608},
+							pos:   position{line: 315, col: 17, offset: 9088},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 301, col: 22, offset: 8613},
+								pos:  position{line: 315, col: 22, offset: 9093},
 								name: "NumericComparisonExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 301, col: 44, offset: 8635},
+							pos:   position{line: 315, col: 44, offset: 9115},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 301, col: 49, offset: 8640},
+								pos: position{line: 315, col: 49, offset: 9120},
 								expr: &seqExpr{
-									pos: position{line: 301, col: 51, offset: 8642},
+									pos: position{line: 315, col: 51, offset: 9122},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 301, col: 51, offset: 8642},
+											pos: position{line: 315, col: 51, offset: 9122},
 											expr: &ruleRefExpr{
-												pos:  position{line: 301, col: 51, offset: 8642},
+												pos:  position{line: 315, col: 51, offset: 9122},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 301, col: 55, offset: 8646},
+											pos:   position{line: 315, col: 55, offset: 9126},
 											label: "op",
 											expr: &ruleRefExpr{
-												pos:  position{line: 301, col: 58, offset: 8649},
+												pos:  position{line: 315, col: 58, offset: 9129},
 												name: "EqualityOp",
 											},
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 301, col: 69, offset: 8660},
+											pos: position{line: 315, col: 69, offset: 9140},
 											expr: &ruleRefExpr{
-												pos:  position{line: 301, col: 69, offset: 8660},
+												pos:  position{line: 315, col: 69, offset: 9140},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 301, col: 73, offset: 8664},
+											pos:   position{line: 315, col: 73, offset: 9144},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 301, col: 79, offset: 8670},
+												pos:  position{line: 315, col: 79, offset: 9150},
 												name: "NumericComparisonExpr",
 											},
 										},
@@ -985,33 +999,33 @@ var g = &grammar{
 		},
 		{
 			name: "EqualityOp",
-			pos:  position{line: 316, col: 1, offset: 8985},
+			pos:  position{line: 330, col: 1, offset: 9468},
 			expr: &actionExpr{
-				pos: position{line: 316, col: 15, offset: 8999},
+				pos: position{line: 330, col: 15, offset: 9482},
 				run: (*parser).callonEqualityOp1,
 				expr: &choiceExpr{
-					pos: position{line: 316, col: 17, offset: 9001},
+					pos: position{line: 330, col: 17, offset: 9484},
 					alternatives: []any{
 						&litMatcher{
-							pos:        position{line: 316, col: 17, offset: 9001},
+							pos:        position{line: 330, col: 17, offset: 9484},
 							val:        "≠",
 							ignoreCase: false,
 							want:       "\"≠\"",
 						},
 						&litMatcher{
-							pos:        position{line: 316, col: 23, offset: 9009},
+							pos:        position{line: 330, col: 23, offset: 9492},
 							val:        "/=",
 							ignoreCase: false,
 							want:       "\"/=\"",
 						},
 						&litMatcher{
-							pos:        position{line: 316, col: 30, offset: 9016},
+							pos:        position{line: 330, col: 30, offset: 9499},
 							val:        "#",
 							ignoreCase: false,
 							want:       "\"#\"",
 						},
 						&litMatcher{
-							pos:        position{line: 316, col: 36, offset: 9022},
+							pos:        position{line: 330, col: 36, offset: 9505},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
@@ -1022,56 +1036,56 @@ var g = &grammar{
 		},
 		{
 			name: "NumericComparisonExpr",
-			pos:  position{line: 327, col: 1, offset: 9194},
+			pos:  position{line: 341, col: 1, offset: 9677},
 			expr: &actionExpr{
-				pos: position{line: 327, col: 26, offset: 9219},
+				pos: position{line: 341, col: 26, offset: 9702},
 				run: (*parser).callonNumericComparisonExpr1,
 				expr: &seqExpr{
-					pos: position{line: 327, col: 26, offset: 9219},
+					pos: position{line: 341, col: 26, offset: 9702},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 327, col: 26, offset: 9219},
+							pos:   position{line: 341, col: 26, offset: 9702},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 327, col: 31, offset: 9224},
+								pos:  position{line: 341, col: 31, offset: 9707},
 								name: "SetDifferenceExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 327, col: 49, offset: 9242},
+							pos:   position{line: 341, col: 49, offset: 9725},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 327, col: 54, offset: 9247},
+								pos: position{line: 341, col: 54, offset: 9730},
 								expr: &seqExpr{
-									pos: position{line: 327, col: 56, offset: 9249},
+									pos: position{line: 341, col: 56, offset: 9732},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 327, col: 56, offset: 9249},
+											pos: position{line: 341, col: 56, offset: 9732},
 											expr: &ruleRefExpr{
-												pos:  position{line: 327, col: 56, offset: 9249},
+												pos:  position{line: 341, col: 56, offset: 9732},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 327, col: 60, offset: 9253},
+											pos:   position{line: 341, col: 60, offset: 9736},
 											label: "op",
 											expr: &ruleRefExpr{
-												pos:  position{line: 327, col: 63, offset: 9256},
+												pos:  position{line: 341, col: 63, offset: 9739},
 												name: "NumericComparisonOp",
 											},
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 327, col: 83, offset: 9276},
+											pos: position{line: 341, col: 83, offset: 9759},
 											expr: &ruleRefExpr{
-												pos:  position{line: 327, col: 83, offset: 9276},
+												pos:  position{line: 341, col: 83, offset: 9759},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 327, col: 87, offset: 9280},
+											pos:   position{line: 341, col: 87, offset: 9763},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 327, col: 93, offset: 9286},
+												pos:  position{line: 341, col: 93, offset: 9769},
 												name: "SetDifferenceExpr",
 											},
 										},
@@ -1085,51 +1099,51 @@ var g = &grammar{
 		},
 		{
 			name: "NumericComparisonOp",
-			pos:  position{line: 342, col: 1, offset: 9631},
+			pos:  position{line: 356, col: 1, offset: 10117},
 			expr: &actionExpr{
-				pos: position{line: 342, col: 24, offset: 9654},
+				pos: position{line: 356, col: 24, offset: 10140},
 				run: (*parser).callonNumericComparisonOp1,
 				expr: &choiceExpr{
-					pos: position{line: 342, col: 26, offset: 9656},
+					pos: position{line: 356, col: 26, offset: 10142},
 					alternatives: []any{
 						&litMatcher{
-							pos:        position{line: 342, col: 26, offset: 9656},
+							pos:        position{line: 356, col: 26, offset: 10142},
 							val:        "≤",
 							ignoreCase: false,
 							want:       "\"≤\"",
 						},
 						&litMatcher{
-							pos:        position{line: 342, col: 32, offset: 9664},
+							pos:        position{line: 356, col: 32, offset: 10150},
 							val:        "=<",
 							ignoreCase: false,
 							want:       "\"=<\"",
 						},
 						&litMatcher{
-							pos:        position{line: 342, col: 39, offset: 9671},
+							pos:        position{line: 356, col: 39, offset: 10157},
 							val:        "<=",
 							ignoreCase: false,
 							want:       "\"<=\"",
 						},
 						&litMatcher{
-							pos:        position{line: 342, col: 46, offset: 9678},
+							pos:        position{line: 356, col: 46, offset: 10164},
 							val:        "≥",
 							ignoreCase: false,
 							want:       "\"≥\"",
 						},
 						&litMatcher{
-							pos:        position{line: 342, col: 52, offset: 9686},
+							pos:        position{line: 356, col: 52, offset: 10172},
 							val:        ">=",
 							ignoreCase: false,
 							want:       "\">=\"",
 						},
 						&litMatcher{
-							pos:        position{line: 342, col: 59, offset: 9693},
+							pos:        position{line: 356, col: 59, offset: 10179},
 							val:        "<",
 							ignoreCase: false,
 							want:       "\"<\"",
 						},
 						&litMatcher{
-							pos:        position{line: 342, col: 65, offset: 9699},
+							pos:        position{line: 356, col: 65, offset: 10185},
 							val:        ">",
 							ignoreCase: false,
 							want:       "\">\"",
@@ -1140,121 +1154,121 @@ var g = &grammar{
 		},
 		{
 			name: "SetDifferenceExpr",
-			pos:  position{line: 360, col: 1, offset: 10128},
+			pos:  position{line: 374, col: 1, offset: 10614},
 			expr: &actionExpr{
-				pos: position{line: 360, col: 22, offset: 10149},
+				pos: position{line: 374, col: 22, offset: 10635},
 				run: (*parser).callonSetDifferenceExpr1,
 				expr: &seqExpr{
-					pos: position{line: 360, col: 22, offset: 10149},
+					pos: position{line: 374, col: 22, offset: 10635},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 360, col: 22, offset: 10149},
+							pos:   position{line: 374, col: 22, offset: 10635},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 360, col: 27, offset: 10154},
+								pos:  position{line: 374, col: 27, offset: 10640},
 								name: "SetIntersectionExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 360, col: 47, offset: 10174},
+							pos:   position{line: 374, col: 47, offset: 10660},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 360, col: 52, offset: 10179},
+								pos: position{line: 374, col: 52, offset: 10665},
 								expr: &seqExpr{
-									pos: position{line: 360, col: 54, offset: 10181},
+									pos: position{line: 374, col: 54, offset: 10667},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 360, col: 54, offset: 10181},
+											pos: position{line: 374, col: 54, offset: 10667},
 											expr: &ruleRefExpr{
-												pos:  position{line: 360, col: 54, offset: 10181},
+												pos:  position{line: 374, col: 54, offset: 10667},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 360, col: 58, offset: 10185},
+											pos:        position{line: 374, col: 58, offset: 10671},
 											val:        "\\",
 											ignoreCase: false,
 											want:       "\"\\\\\"",
 										},
 										&notExpr{
-											pos: position{line: 360, col: 63, offset: 10190},
+											pos: position{line: 374, col: 63, offset: 10676},
 											expr: &choiceExpr{
-												pos: position{line: 360, col: 66, offset: 10193},
+												pos: position{line: 374, col: 66, offset: 10679},
 												alternatives: []any{
 													&litMatcher{
-														pos:        position{line: 360, col: 66, offset: 10193},
+														pos:        position{line: 374, col: 66, offset: 10679},
 														val:        "/",
 														ignoreCase: false,
 														want:       "\"/\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 72, offset: 10199},
+														pos:        position{line: 374, col: 72, offset: 10685},
 														val:        "i",
 														ignoreCase: false,
 														want:       "\"i\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 78, offset: 10205},
+														pos:        position{line: 374, col: 78, offset: 10691},
 														val:        "c",
 														ignoreCase: false,
 														want:       "\"c\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 84, offset: 10211},
+														pos:        position{line: 374, col: 84, offset: 10697},
 														val:        "u",
 														ignoreCase: false,
 														want:       "\"u\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 90, offset: 10217},
+														pos:        position{line: 374, col: 90, offset: 10703},
 														val:        "s",
 														ignoreCase: false,
 														want:       "\"s\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 96, offset: 10223},
+														pos:        position{line: 374, col: 96, offset: 10709},
 														val:        "n",
 														ignoreCase: false,
 														want:       "\"n\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 102, offset: 10229},
+														pos:        position{line: 374, col: 102, offset: 10715},
 														val:        "d",
 														ignoreCase: false,
 														want:       "\"d\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 108, offset: 10235},
+														pos:        position{line: 374, col: 108, offset: 10721},
 														val:        "b",
 														ignoreCase: false,
 														want:       "\"b\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 114, offset: 10241},
+														pos:        position{line: 374, col: 114, offset: 10727},
 														val:        "o",
 														ignoreCase: false,
 														want:       "\"o\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 120, offset: 10247},
+														pos:        position{line: 374, col: 120, offset: 10733},
 														val:        "h",
 														ignoreCase: false,
 														want:       "\"h\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 126, offset: 10253},
+														pos:        position{line: 374, col: 126, offset: 10739},
 														val:        "B",
 														ignoreCase: false,
 														want:       "\"B\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 132, offset: 10259},
+														pos:        position{line: 374, col: 132, offset: 10745},
 														val:        "O",
 														ignoreCase: false,
 														want:       "\"O\"",
 													},
 													&litMatcher{
-														pos:        position{line: 360, col: 138, offset: 10265},
+														pos:        position{line: 374, col: 138, offset: 10751},
 														val:        "H",
 														ignoreCase: false,
 														want:       "\"H\"",
@@ -1263,17 +1277,17 @@ var g = &grammar{
 											},
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 360, col: 144, offset: 10271},
+											pos: position{line: 374, col: 144, offset: 10757},
 											expr: &ruleRefExpr{
-												pos:  position{line: 360, col: 144, offset: 10271},
+												pos:  position{line: 374, col: 144, offset: 10757},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 360, col: 148, offset: 10275},
+											pos:   position{line: 374, col: 148, offset: 10761},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 360, col: 154, offset: 10281},
+												pos:  position{line: 374, col: 154, offset: 10767},
 												name: "SetIntersectionExpr",
 											},
 										},
@@ -1287,52 +1301,52 @@ var g = &grammar{
 		},
 		{
 			name: "SetIntersectionExpr",
-			pos:  position{line: 377, col: 1, offset: 10654},
+			pos:  position{line: 391, col: 1, offset: 11143},
 			expr: &actionExpr{
-				pos: position{line: 377, col: 24, offset: 10677},
+				pos: position{line: 391, col: 24, offset: 11166},
 				run: (*parser).callonSetIntersectionExpr1,
 				expr: &seqExpr{
-					pos: position{line: 377, col: 24, offset: 10677},
+					pos: position{line: 391, col: 24, offset: 11166},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 377, col: 24, offset: 10677},
+							pos:   position{line: 391, col: 24, offset: 11166},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 377, col: 29, offset: 10682},
+								pos:  position{line: 391, col: 29, offset: 11171},
 								name: "SetUnionExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 377, col: 42, offset: 10695},
+							pos:   position{line: 391, col: 42, offset: 11184},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 377, col: 47, offset: 10700},
+								pos: position{line: 391, col: 47, offset: 11189},
 								expr: &seqExpr{
-									pos: position{line: 377, col: 49, offset: 10702},
+									pos: position{line: 391, col: 49, offset: 11191},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 377, col: 49, offset: 10702},
+											pos: position{line: 391, col: 49, offset: 11191},
 											expr: &ruleRefExpr{
-												pos:  position{line: 377, col: 49, offset: 10702},
+												pos:  position{line: 391, col: 49, offset: 11191},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 377, col: 53, offset: 10706},
+											pos:  position{line: 391, col: 53, offset: 11195},
 											name: "SetIntersectionOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 377, col: 71, offset: 10724},
+											pos: position{line: 391, col: 71, offset: 11213},
 											expr: &ruleRefExpr{
-												pos:  position{line: 377, col: 71, offset: 10724},
+												pos:  position{line: 391, col: 71, offset: 11213},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 377, col: 75, offset: 10728},
+											pos:   position{line: 391, col: 75, offset: 11217},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 377, col: 81, offset: 10734},
+												pos:  position{line: 391, col: 81, offset: 11223},
 												name: "SetUnionExpr",
 											},
 										},
@@ -1346,24 +1360,24 @@ var g = &grammar{
 		},
 		{
 			name: "SetIntersectionOp",
-			pos:  position{line: 393, col: 1, offset: 11050},
+			pos:  position{line: 407, col: 1, offset: 11542},
 			expr: &choiceExpr{
-				pos: position{line: 393, col: 24, offset: 11073},
+				pos: position{line: 407, col: 24, offset: 11565},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 393, col: 24, offset: 11073},
+						pos:        position{line: 407, col: 24, offset: 11565},
 						val:        "∩",
 						ignoreCase: false,
 						want:       "\"∩\"",
 					},
 					&litMatcher{
-						pos:        position{line: 393, col: 30, offset: 11081},
+						pos:        position{line: 407, col: 30, offset: 11573},
 						val:        "\\intersect",
 						ignoreCase: false,
 						want:       "\"\\\\intersect\"",
 					},
 					&litMatcher{
-						pos:        position{line: 393, col: 46, offset: 11097},
+						pos:        position{line: 407, col: 46, offset: 11589},
 						val:        "\\cap",
 						ignoreCase: false,
 						want:       "\"\\\\cap\"",
@@ -1373,52 +1387,52 @@ var g = &grammar{
 		},
 		{
 			name: "SetUnionExpr",
-			pos:  position{line: 396, col: 1, offset: 11153},
+			pos:  position{line: 410, col: 1, offset: 11645},
 			expr: &actionExpr{
-				pos: position{line: 396, col: 17, offset: 11169},
+				pos: position{line: 410, col: 17, offset: 11661},
 				run: (*parser).callonSetUnionExpr1,
 				expr: &seqExpr{
-					pos: position{line: 396, col: 17, offset: 11169},
+					pos: position{line: 410, col: 17, offset: 11661},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 396, col: 17, offset: 11169},
+							pos:   position{line: 410, col: 17, offset: 11661},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 396, col: 22, offset: 11174},
+								pos:  position{line: 410, col: 22, offset: 11666},
 								name: "CartesianProductExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 396, col: 43, offset: 11195},
+							pos:   position{line: 410, col: 43, offset: 11687},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 396, col: 48, offset: 11200},
+								pos: position{line: 410, col: 48, offset: 11692},
 								expr: &seqExpr{
-									pos: position{line: 396, col: 50, offset: 11202},
+									pos: position{line: 410, col: 50, offset: 11694},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 396, col: 50, offset: 11202},
+											pos: position{line: 410, col: 50, offset: 11694},
 											expr: &ruleRefExpr{
-												pos:  position{line: 396, col: 50, offset: 11202},
+												pos:  position{line: 410, col: 50, offset: 11694},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 396, col: 54, offset: 11206},
+											pos:  position{line: 410, col: 54, offset: 11698},
 											name: "SetUnionOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 396, col: 65, offset: 11217},
+											pos: position{line: 410, col: 65, offset: 11709},
 											expr: &ruleRefExpr{
-												pos:  position{line: 396, col: 65, offset: 11217},
+												pos:  position{line: 410, col: 65, offset: 11709},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 396, col: 69, offset: 11221},
+											pos:   position{line: 410, col: 69, offset: 11713},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 396, col: 75, offset: 11227},
+												pos:  position{line: 410, col: 75, offset: 11719},
 												name: "CartesianProductExpr",
 											},
 										},
@@ -1432,24 +1446,24 @@ var g = &grammar{
 		},
 		{
 			name: "SetUnionOp",
-			pos:  position{line: 412, col: 1, offset: 11551},
+			pos:  position{line: 426, col: 1, offset: 12046},
 			expr: &choiceExpr{
-				pos: position{line: 412, col: 17, offset: 11567},
+				pos: position{line: 426, col: 17, offset: 12062},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 412, col: 17, offset: 11567},
+						pos:        position{line: 426, col: 17, offset: 12062},
 						val:        "∪",
 						ignoreCase: false,
 						want:       "\"∪\"",
 					},
 					&litMatcher{
-						pos:        position{line: 412, col: 23, offset: 11575},
+						pos:        position{line: 426, col: 23, offset: 12070},
 						val:        "\\union",
 						ignoreCase: false,
 						want:       "\"\\\\union\"",
 					},
 					&litMatcher{
-						pos:        position{line: 412, col: 35, offset: 11587},
+						pos:        position{line: 426, col: 35, offset: 12082},
 						val:        "\\cup",
 						ignoreCase: false,
 						want:       "\"\\\\cup\"",
@@ -1459,55 +1473,55 @@ var g = &grammar{
 		},
 		{
 			name: "CartesianProductExpr",
-			pos:  position{line: 417, col: 1, offset: 11764},
+			pos:  position{line: 431, col: 1, offset: 12259},
 			expr: &choiceExpr{
-				pos: position{line: 417, col: 25, offset: 11788},
+				pos: position{line: 431, col: 25, offset: 12283},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 417, col: 25, offset: 11788},
+						pos: position{line: 431, col: 25, offset: 12283},
 						run: (*parser).callonCartesianProductExpr2,
 						expr: &seqExpr{
-							pos: position{line: 417, col: 25, offset: 11788},
+							pos: position{line: 431, col: 25, offset: 12283},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 417, col: 25, offset: 11788},
+									pos:   position{line: 431, col: 25, offset: 12283},
 									label: "left",
 									expr: &ruleRefExpr{
-										pos:  position{line: 417, col: 30, offset: 11793},
+										pos:  position{line: 431, col: 30, offset: 12288},
 										name: "SetRangeExpr",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 417, col: 43, offset: 11806},
+									pos:   position{line: 431, col: 43, offset: 12301},
 									label: "rest",
 									expr: &oneOrMoreExpr{
-										pos: position{line: 417, col: 48, offset: 11811},
+										pos: position{line: 431, col: 48, offset: 12306},
 										expr: &seqExpr{
-											pos: position{line: 417, col: 50, offset: 11813},
+											pos: position{line: 431, col: 50, offset: 12308},
 											exprs: []any{
 												&zeroOrOneExpr{
-													pos: position{line: 417, col: 50, offset: 11813},
+													pos: position{line: 431, col: 50, offset: 12308},
 													expr: &ruleRefExpr{
-														pos:  position{line: 417, col: 50, offset: 11813},
+														pos:  position{line: 431, col: 50, offset: 12308},
 														name: "ws",
 													},
 												},
 												&ruleRefExpr{
-													pos:  position{line: 417, col: 54, offset: 11817},
+													pos:  position{line: 431, col: 54, offset: 12312},
 													name: "CartesianProductOp",
 												},
 												&zeroOrOneExpr{
-													pos: position{line: 417, col: 73, offset: 11836},
+													pos: position{line: 431, col: 73, offset: 12331},
 													expr: &ruleRefExpr{
-														pos:  position{line: 417, col: 73, offset: 11836},
+														pos:  position{line: 431, col: 73, offset: 12331},
 														name: "ws",
 													},
 												},
 												&labeledExpr{
-													pos:   position{line: 417, col: 77, offset: 11840},
+													pos:   position{line: 431, col: 77, offset: 12335},
 													label: "right",
 													expr: &ruleRefExpr{
-														pos:  position{line: 417, col: 83, offset: 11846},
+														pos:  position{line: 431, col: 83, offset: 12341},
 														name: "SetRangeExpr",
 													},
 												},
@@ -1519,13 +1533,13 @@ var g = &grammar{
 						},
 					},
 					&actionExpr{
-						pos: position{line: 426, col: 5, offset: 12113},
+						pos: position{line: 440, col: 5, offset: 12611},
 						run: (*parser).callonCartesianProductExpr16,
 						expr: &labeledExpr{
-							pos:   position{line: 426, col: 5, offset: 12113},
+							pos:   position{line: 440, col: 5, offset: 12611},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 426, col: 10, offset: 12118},
+								pos:  position{line: 440, col: 10, offset: 12616},
 								name: "SetRangeExpr",
 							},
 						},
@@ -1535,24 +1549,24 @@ var g = &grammar{
 		},
 		{
 			name: "CartesianProductOp",
-			pos:  position{line: 430, col: 1, offset: 12171},
+			pos:  position{line: 444, col: 1, offset: 12669},
 			expr: &choiceExpr{
-				pos: position{line: 430, col: 25, offset: 12195},
+				pos: position{line: 444, col: 25, offset: 12693},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 430, col: 25, offset: 12195},
+						pos:        position{line: 444, col: 25, offset: 12693},
 						val:        "×",
 						ignoreCase: false,
 						want:       "\"×\"",
 					},
 					&litMatcher{
-						pos:        position{line: 430, col: 31, offset: 12202},
+						pos:        position{line: 444, col: 31, offset: 12700},
 						val:        "\\X",
 						ignoreCase: false,
 						want:       "\"\\\\X\"",
 					},
 					&litMatcher{
-						pos:        position{line: 430, col: 39, offset: 12210},
+						pos:        position{line: 444, col: 39, offset: 12708},
 						val:        "\\times",
 						ignoreCase: false,
 						want:       "\"\\\\times\"",
@@ -1562,54 +1576,54 @@ var g = &grammar{
 		},
 		{
 			name: "SetRangeExpr",
-			pos:  position{line: 438, col: 1, offset: 12482},
+			pos:  position{line: 452, col: 1, offset: 12980},
 			expr: &actionExpr{
-				pos: position{line: 438, col: 17, offset: 12498},
+				pos: position{line: 452, col: 17, offset: 12996},
 				run: (*parser).callonSetRangeExpr1,
 				expr: &seqExpr{
-					pos: position{line: 438, col: 17, offset: 12498},
+					pos: position{line: 452, col: 17, offset: 12996},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 438, col: 17, offset: 12498},
+							pos:   position{line: 452, col: 17, offset: 12996},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 438, col: 22, offset: 12503},
+								pos:  position{line: 452, col: 22, offset: 13001},
 								name: "BagSumExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 438, col: 33, offset: 12514},
+							pos:   position{line: 452, col: 33, offset: 13012},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 438, col: 38, offset: 12519},
+								pos: position{line: 452, col: 38, offset: 13017},
 								expr: &seqExpr{
-									pos: position{line: 438, col: 40, offset: 12521},
+									pos: position{line: 452, col: 40, offset: 13019},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 438, col: 40, offset: 12521},
+											pos: position{line: 452, col: 40, offset: 13019},
 											expr: &ruleRefExpr{
-												pos:  position{line: 438, col: 40, offset: 12521},
+												pos:  position{line: 452, col: 40, offset: 13019},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 438, col: 44, offset: 12525},
+											pos:        position{line: 452, col: 44, offset: 13023},
 											val:        "..",
 											ignoreCase: false,
 											want:       "\"..\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 438, col: 49, offset: 12530},
+											pos: position{line: 452, col: 49, offset: 13028},
 											expr: &ruleRefExpr{
-												pos:  position{line: 438, col: 49, offset: 12530},
+												pos:  position{line: 452, col: 49, offset: 13028},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 438, col: 53, offset: 12534},
+											pos:   position{line: 452, col: 53, offset: 13032},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 438, col: 59, offset: 12540},
+												pos:  position{line: 452, col: 59, offset: 13038},
 												name: "BagSumExpr",
 											},
 										},
@@ -1623,52 +1637,52 @@ var g = &grammar{
 		},
 		{
 			name: "BagSumExpr",
-			pos:  position{line: 467, col: 1, offset: 13372},
+			pos:  position{line: 481, col: 1, offset: 13873},
 			expr: &actionExpr{
-				pos: position{line: 467, col: 15, offset: 13386},
+				pos: position{line: 481, col: 15, offset: 13887},
 				run: (*parser).callonBagSumExpr1,
 				expr: &seqExpr{
-					pos: position{line: 467, col: 15, offset: 13386},
+					pos: position{line: 481, col: 15, offset: 13887},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 467, col: 15, offset: 13386},
+							pos:   position{line: 481, col: 15, offset: 13887},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 467, col: 20, offset: 13391},
+								pos:  position{line: 481, col: 20, offset: 13892},
 								name: "ModuloExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 467, col: 31, offset: 13402},
+							pos:   position{line: 481, col: 31, offset: 13903},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 467, col: 36, offset: 13407},
+								pos: position{line: 481, col: 36, offset: 13908},
 								expr: &seqExpr{
-									pos: position{line: 467, col: 38, offset: 13409},
+									pos: position{line: 481, col: 38, offset: 13910},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 467, col: 38, offset: 13409},
+											pos: position{line: 481, col: 38, offset: 13910},
 											expr: &ruleRefExpr{
-												pos:  position{line: 467, col: 38, offset: 13409},
+												pos:  position{line: 481, col: 38, offset: 13910},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 467, col: 42, offset: 13413},
+											pos:  position{line: 481, col: 42, offset: 13914},
 											name: "BagSumOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 467, col: 51, offset: 13422},
+											pos: position{line: 481, col: 51, offset: 13923},
 											expr: &ruleRefExpr{
-												pos:  position{line: 467, col: 51, offset: 13422},
+												pos:  position{line: 481, col: 51, offset: 13923},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 467, col: 55, offset: 13426},
+											pos:   position{line: 481, col: 55, offset: 13927},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 467, col: 61, offset: 13432},
+												pos:  position{line: 481, col: 61, offset: 13933},
 												name: "ModuloExpr",
 											},
 										},
@@ -1682,24 +1696,24 @@ var g = &grammar{
 		},
 		{
 			name: "BagSumOp",
-			pos:  position{line: 483, col: 1, offset: 13746},
+			pos:  position{line: 497, col: 1, offset: 14250},
 			expr: &choiceExpr{
-				pos: position{line: 483, col: 15, offset: 13760},
+				pos: position{line: 497, col: 15, offset: 14264},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 483, col: 15, offset: 13760},
+						pos:        position{line: 497, col: 15, offset: 14264},
 						val:        "⊕",
 						ignoreCase: false,
 						want:       "\"⊕\"",
 					},
 					&litMatcher{
-						pos:        position{line: 483, col: 21, offset: 13768},
+						pos:        position{line: 497, col: 21, offset: 14272},
 						val:        "(+)",
 						ignoreCase: false,
 						want:       "\"(+)\"",
 					},
 					&litMatcher{
-						pos:        position{line: 483, col: 29, offset: 13776},
+						pos:        position{line: 497, col: 29, offset: 14280},
 						val:        "\\oplus",
 						ignoreCase: false,
 						want:       "\"\\\\oplus\"",
@@ -1709,54 +1723,54 @@ var g = &grammar{
 		},
 		{
 			name: "ModuloExpr",
-			pos:  position{line: 486, col: 1, offset: 13824},
+			pos:  position{line: 500, col: 1, offset: 14328},
 			expr: &actionExpr{
-				pos: position{line: 486, col: 15, offset: 13838},
+				pos: position{line: 500, col: 15, offset: 14342},
 				run: (*parser).callonModuloExpr1,
 				expr: &seqExpr{
-					pos: position{line: 486, col: 15, offset: 13838},
+					pos: position{line: 500, col: 15, offset: 14342},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 486, col: 15, offset: 13838},
+							pos:   position{line: 500, col: 15, offset: 14342},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 486, col: 20, offset: 13843},
+								pos:  position{line: 500, col: 20, offset: 14347},
 								name: "AdditionExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 486, col: 33, offset: 13856},
+							pos:   position{line: 500, col: 33, offset: 14360},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 486, col: 38, offset: 13861},
+								pos: position{line: 500, col: 38, offset: 14365},
 								expr: &seqExpr{
-									pos: position{line: 486, col: 40, offset: 13863},
+									pos: position{line: 500, col: 40, offset: 14367},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 486, col: 40, offset: 13863},
+											pos: position{line: 500, col: 40, offset: 14367},
 											expr: &ruleRefExpr{
-												pos:  position{line: 486, col: 40, offset: 13863},
+												pos:  position{line: 500, col: 40, offset: 14367},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 486, col: 44, offset: 13867},
+											pos:        position{line: 500, col: 44, offset: 14371},
 											val:        "%",
 											ignoreCase: false,
 											want:       "\"%\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 486, col: 48, offset: 13871},
+											pos: position{line: 500, col: 48, offset: 14375},
 											expr: &ruleRefExpr{
-												pos:  position{line: 486, col: 48, offset: 13871},
+												pos:  position{line: 500, col: 48, offset: 14375},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 486, col: 52, offset: 13875},
+											pos:   position{line: 500, col: 52, offset: 14379},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 486, col: 58, offset: 13881},
+												pos:  position{line: 500, col: 58, offset: 14385},
 												name: "AdditionExpr",
 											},
 										},
@@ -1770,54 +1784,54 @@ var g = &grammar{
 		},
 		{
 			name: "AdditionExpr",
-			pos:  position{line: 504, col: 1, offset: 14291},
+			pos:  position{line: 518, col: 1, offset: 14798},
 			expr: &actionExpr{
-				pos: position{line: 504, col: 17, offset: 14307},
+				pos: position{line: 518, col: 17, offset: 14814},
 				run: (*parser).callonAdditionExpr1,
 				expr: &seqExpr{
-					pos: position{line: 504, col: 17, offset: 14307},
+					pos: position{line: 518, col: 17, offset: 14814},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 504, col: 17, offset: 14307},
+							pos:   position{line: 518, col: 17, offset: 14814},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 504, col: 22, offset: 14312},
+								pos:  position{line: 518, col: 22, offset: 14819},
 								name: "BagDiffExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 504, col: 34, offset: 14324},
+							pos:   position{line: 518, col: 34, offset: 14831},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 504, col: 39, offset: 14329},
+								pos: position{line: 518, col: 39, offset: 14836},
 								expr: &seqExpr{
-									pos: position{line: 504, col: 41, offset: 14331},
+									pos: position{line: 518, col: 41, offset: 14838},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 504, col: 41, offset: 14331},
+											pos: position{line: 518, col: 41, offset: 14838},
 											expr: &ruleRefExpr{
-												pos:  position{line: 504, col: 41, offset: 14331},
+												pos:  position{line: 518, col: 41, offset: 14838},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 504, col: 45, offset: 14335},
+											pos:        position{line: 518, col: 45, offset: 14842},
 											val:        "+",
 											ignoreCase: false,
 											want:       "\"+\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 504, col: 49, offset: 14339},
+											pos: position{line: 518, col: 49, offset: 14846},
 											expr: &ruleRefExpr{
-												pos:  position{line: 504, col: 49, offset: 14339},
+												pos:  position{line: 518, col: 49, offset: 14846},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 504, col: 53, offset: 14343},
+											pos:   position{line: 518, col: 53, offset: 14850},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 504, col: 59, offset: 14349},
+												pos:  position{line: 518, col: 59, offset: 14856},
 												name: "BagDiffExpr",
 											},
 										},
@@ -1831,52 +1845,52 @@ var g = &grammar{
 		},
 		{
 			name: "BagDiffExpr",
-			pos:  position{line: 521, col: 1, offset: 14714},
+			pos:  position{line: 535, col: 1, offset: 15224},
 			expr: &actionExpr{
-				pos: position{line: 521, col: 16, offset: 14729},
+				pos: position{line: 535, col: 16, offset: 15239},
 				run: (*parser).callonBagDiffExpr1,
 				expr: &seqExpr{
-					pos: position{line: 521, col: 16, offset: 14729},
+					pos: position{line: 535, col: 16, offset: 15239},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 521, col: 16, offset: 14729},
+							pos:   position{line: 535, col: 16, offset: 15239},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 521, col: 21, offset: 14734},
+								pos:  position{line: 535, col: 21, offset: 15244},
 								name: "SubtractionExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 521, col: 37, offset: 14750},
+							pos:   position{line: 535, col: 37, offset: 15260},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 521, col: 42, offset: 14755},
+								pos: position{line: 535, col: 42, offset: 15265},
 								expr: &seqExpr{
-									pos: position{line: 521, col: 44, offset: 14757},
+									pos: position{line: 535, col: 44, offset: 15267},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 521, col: 44, offset: 14757},
+											pos: position{line: 535, col: 44, offset: 15267},
 											expr: &ruleRefExpr{
-												pos:  position{line: 521, col: 44, offset: 14757},
+												pos:  position{line: 535, col: 44, offset: 15267},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 521, col: 48, offset: 14761},
+											pos:  position{line: 535, col: 48, offset: 15271},
 											name: "BagDiffOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 521, col: 58, offset: 14771},
+											pos: position{line: 535, col: 58, offset: 15281},
 											expr: &ruleRefExpr{
-												pos:  position{line: 521, col: 58, offset: 14771},
+												pos:  position{line: 535, col: 58, offset: 15281},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 521, col: 62, offset: 14775},
+											pos:   position{line: 535, col: 62, offset: 15285},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 521, col: 68, offset: 14781},
+												pos:  position{line: 535, col: 68, offset: 15291},
 												name: "SubtractionExpr",
 											},
 										},
@@ -1890,24 +1904,24 @@ var g = &grammar{
 		},
 		{
 			name: "BagDiffOp",
-			pos:  position{line: 537, col: 1, offset: 15100},
+			pos:  position{line: 551, col: 1, offset: 15613},
 			expr: &choiceExpr{
-				pos: position{line: 537, col: 16, offset: 15115},
+				pos: position{line: 551, col: 16, offset: 15628},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 537, col: 16, offset: 15115},
+						pos:        position{line: 551, col: 16, offset: 15628},
 						val:        "⊖",
 						ignoreCase: false,
 						want:       "\"⊖\"",
 					},
 					&litMatcher{
-						pos:        position{line: 537, col: 22, offset: 15123},
+						pos:        position{line: 551, col: 22, offset: 15636},
 						val:        "(-)",
 						ignoreCase: false,
 						want:       "\"(-)\"",
 					},
 					&litMatcher{
-						pos:        position{line: 537, col: 30, offset: 15131},
+						pos:        position{line: 551, col: 30, offset: 15644},
 						val:        "\\ominus",
 						ignoreCase: false,
 						want:       "\"\\\\ominus\"",
@@ -1917,54 +1931,54 @@ var g = &grammar{
 		},
 		{
 			name: "SubtractionExpr",
-			pos:  position{line: 541, col: 1, offset: 15249},
+			pos:  position{line: 555, col: 1, offset: 15762},
 			expr: &actionExpr{
-				pos: position{line: 541, col: 20, offset: 15268},
+				pos: position{line: 555, col: 20, offset: 15781},
 				run: (*parser).callonSubtractionExpr1,
 				expr: &seqExpr{
-					pos: position{line: 541, col: 20, offset: 15268},
+					pos: position{line: 555, col: 20, offset: 15781},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 541, col: 20, offset: 15268},
+							pos:   position{line: 555, col: 20, offset: 15781},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 541, col: 25, offset: 15273},
+								pos:  position{line: 555, col: 25, offset: 15786},
 								name: "NegationExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 541, col: 38, offset: 15286},
+							pos:   position{line: 555, col: 38, offset: 15799},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 541, col: 43, offset: 15291},
+								pos: position{line: 555, col: 43, offset: 15804},
 								expr: &seqExpr{
-									pos: position{line: 541, col: 45, offset: 15293},
+									pos: position{line: 555, col: 45, offset: 15806},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 541, col: 45, offset: 15293},
+											pos: position{line: 555, col: 45, offset: 15806},
 											expr: &ruleRefExpr{
-												pos:  position{line: 541, col: 45, offset: 15293},
+												pos:  position{line: 555, col: 45, offset: 15806},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 541, col: 49, offset: 15297},
+											pos:        position{line: 555, col: 49, offset: 15810},
 											val:        "-",
 											ignoreCase: false,
 											want:       "\"-\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 541, col: 53, offset: 15301},
+											pos: position{line: 555, col: 53, offset: 15814},
 											expr: &ruleRefExpr{
-												pos:  position{line: 541, col: 53, offset: 15301},
+												pos:  position{line: 555, col: 53, offset: 15814},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 541, col: 57, offset: 15305},
+											pos:   position{line: 555, col: 57, offset: 15818},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 541, col: 63, offset: 15311},
+												pos:  position{line: 555, col: 63, offset: 15824},
 												name: "NegationExpr",
 											},
 										},
@@ -1978,34 +1992,34 @@ var g = &grammar{
 		},
 		{
 			name: "NegationExpr",
-			pos:  position{line: 559, col: 1, offset: 15692},
+			pos:  position{line: 573, col: 1, offset: 16208},
 			expr: &choiceExpr{
-				pos: position{line: 559, col: 17, offset: 15708},
+				pos: position{line: 573, col: 17, offset: 16224},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 559, col: 17, offset: 15708},
+						pos: position{line: 573, col: 17, offset: 16224},
 						run: (*parser).callonNegationExpr2,
 						expr: &seqExpr{
-							pos: position{line: 559, col: 17, offset: 15708},
+							pos: position{line: 573, col: 17, offset: 16224},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 559, col: 17, offset: 15708},
+									pos:        position{line: 573, col: 17, offset: 16224},
 									val:        "-",
 									ignoreCase: false,
 									want:       "\"-\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 559, col: 21, offset: 15712},
+									pos: position{line: 573, col: 21, offset: 16228},
 									expr: &ruleRefExpr{
-										pos:  position{line: 559, col: 21, offset: 15712},
+										pos:  position{line: 573, col: 21, offset: 16228},
 										name: "ws",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 559, col: 25, offset: 15716},
+									pos:   position{line: 573, col: 25, offset: 16232},
 									label: "right",
 									expr: &ruleRefExpr{
-										pos:  position{line: 559, col: 31, offset: 15722},
+										pos:  position{line: 573, col: 31, offset: 16238},
 										name: "NegationExpr",
 									},
 								},
@@ -2013,7 +2027,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 561, col: 5, offset: 15794},
+						pos:  position{line: 575, col: 5, offset: 16313},
 						name: "DivisionExpr",
 					},
 				},
@@ -2021,52 +2035,52 @@ var g = &grammar{
 		},
 		{
 			name: "DivisionExpr",
-			pos:  position{line: 565, col: 1, offset: 15892},
+			pos:  position{line: 579, col: 1, offset: 16411},
 			expr: &actionExpr{
-				pos: position{line: 565, col: 17, offset: 15908},
+				pos: position{line: 579, col: 17, offset: 16427},
 				run: (*parser).callonDivisionExpr1,
 				expr: &seqExpr{
-					pos: position{line: 565, col: 17, offset: 15908},
+					pos: position{line: 579, col: 17, offset: 16427},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 565, col: 17, offset: 15908},
+							pos:   position{line: 579, col: 17, offset: 16427},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 565, col: 22, offset: 15913},
+								pos:  position{line: 579, col: 22, offset: 16432},
 								name: "ConcatExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 565, col: 33, offset: 15924},
+							pos:   position{line: 579, col: 33, offset: 16443},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 565, col: 38, offset: 15929},
+								pos: position{line: 579, col: 38, offset: 16448},
 								expr: &seqExpr{
-									pos: position{line: 565, col: 40, offset: 15931},
+									pos: position{line: 579, col: 40, offset: 16450},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 565, col: 40, offset: 15931},
+											pos: position{line: 579, col: 40, offset: 16450},
 											expr: &ruleRefExpr{
-												pos:  position{line: 565, col: 40, offset: 15931},
+												pos:  position{line: 579, col: 40, offset: 16450},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 565, col: 44, offset: 15935},
+											pos:  position{line: 579, col: 44, offset: 16454},
 											name: "DivisionOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 565, col: 55, offset: 15946},
+											pos: position{line: 579, col: 55, offset: 16465},
 											expr: &ruleRefExpr{
-												pos:  position{line: 565, col: 55, offset: 15946},
+												pos:  position{line: 579, col: 55, offset: 16465},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 565, col: 59, offset: 15950},
+											pos:   position{line: 579, col: 59, offset: 16469},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 565, col: 65, offset: 15956},
+												pos:  position{line: 579, col: 65, offset: 16475},
 												name: "ConcatExpr",
 											},
 										},
@@ -2080,18 +2094,18 @@ var g = &grammar{
 		},
 		{
 			name: "DivisionOp",
-			pos:  position{line: 582, col: 1, offset: 16328},
+			pos:  position{line: 596, col: 1, offset: 16850},
 			expr: &choiceExpr{
-				pos: position{line: 582, col: 17, offset: 16344},
+				pos: position{line: 596, col: 17, offset: 16866},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 582, col: 17, offset: 16344},
+						pos:        position{line: 596, col: 17, offset: 16866},
 						val:        "÷",
 						ignoreCase: false,
 						want:       "\"÷\"",
 					},
 					&litMatcher{
-						pos:        position{line: 582, col: 23, offset: 16351},
+						pos:        position{line: 596, col: 23, offset: 16873},
 						val:        "\\div",
 						ignoreCase: false,
 						want:       "\"\\\\div\"",
@@ -2101,52 +2115,52 @@ var g = &grammar{
 		},
 		{
 			name: "ConcatExpr",
-			pos:  position{line: 586, col: 1, offset: 16466},
+			pos:  position{line: 600, col: 1, offset: 16988},
 			expr: &actionExpr{
-				pos: position{line: 586, col: 15, offset: 16480},
+				pos: position{line: 600, col: 15, offset: 17002},
 				run: (*parser).callonConcatExpr1,
 				expr: &seqExpr{
-					pos: position{line: 586, col: 15, offset: 16480},
+					pos: position{line: 600, col: 15, offset: 17002},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 586, col: 15, offset: 16480},
+							pos:   position{line: 600, col: 15, offset: 17002},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 586, col: 20, offset: 16485},
+								pos:  position{line: 600, col: 20, offset: 17007},
 								name: "MultiplicationExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 586, col: 39, offset: 16504},
+							pos:   position{line: 600, col: 39, offset: 17026},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 586, col: 44, offset: 16509},
+								pos: position{line: 600, col: 44, offset: 17031},
 								expr: &seqExpr{
-									pos: position{line: 586, col: 46, offset: 16511},
+									pos: position{line: 600, col: 46, offset: 17033},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 586, col: 46, offset: 16511},
+											pos: position{line: 600, col: 46, offset: 17033},
 											expr: &ruleRefExpr{
-												pos:  position{line: 586, col: 46, offset: 16511},
+												pos:  position{line: 600, col: 46, offset: 17033},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 586, col: 50, offset: 16515},
+											pos:  position{line: 600, col: 50, offset: 17037},
 											name: "ConcatOp",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 586, col: 59, offset: 16524},
+											pos: position{line: 600, col: 59, offset: 17046},
 											expr: &ruleRefExpr{
-												pos:  position{line: 586, col: 59, offset: 16524},
+												pos:  position{line: 600, col: 59, offset: 17046},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 586, col: 63, offset: 16528},
+											pos:   position{line: 600, col: 63, offset: 17050},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 586, col: 69, offset: 16534},
+												pos:  position{line: 600, col: 69, offset: 17056},
 												name: "MultiplicationExpr",
 											},
 										},
@@ -2160,38 +2174,38 @@ var g = &grammar{
 		},
 		{
 			name: "ConcatOp",
-			pos:  position{line: 602, col: 1, offset: 16918},
+			pos:  position{line: 616, col: 1, offset: 17443},
 			expr: &choiceExpr{
-				pos: position{line: 602, col: 15, offset: 16932},
+				pos: position{line: 616, col: 15, offset: 17457},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 602, col: 15, offset: 16932},
+						pos:        position{line: 616, col: 15, offset: 17457},
 						val:        "∘",
 						ignoreCase: false,
 						want:       "\"∘\"",
 					},
 					&seqExpr{
-						pos: position{line: 602, col: 21, offset: 16940},
+						pos: position{line: 616, col: 21, offset: 17465},
 						exprs: []any{
 							&litMatcher{
-								pos:        position{line: 602, col: 21, offset: 16940},
+								pos:        position{line: 616, col: 21, offset: 17465},
 								val:        "\\o",
 								ignoreCase: false,
 								want:       "\"\\\\o\"",
 							},
 							&notExpr{
-								pos: position{line: 602, col: 27, offset: 16946},
+								pos: position{line: 616, col: 27, offset: 17471},
 								expr: &choiceExpr{
-									pos: position{line: 602, col: 30, offset: 16949},
+									pos: position{line: 616, col: 30, offset: 17474},
 									alternatives: []any{
 										&litMatcher{
-											pos:        position{line: 602, col: 30, offset: 16949},
+											pos:        position{line: 616, col: 30, offset: 17474},
 											val:        "m",
 											ignoreCase: false,
 											want:       "\"m\"",
 										},
 										&litMatcher{
-											pos:        position{line: 602, col: 36, offset: 16955},
+											pos:        position{line: 616, col: 36, offset: 17480},
 											val:        "p",
 											ignoreCase: false,
 											want:       "\"p\"",
@@ -2202,7 +2216,7 @@ var g = &grammar{
 						},
 					},
 					&litMatcher{
-						pos:        position{line: 602, col: 44, offset: 16963},
+						pos:        position{line: 616, col: 44, offset: 17488},
 						val:        "\\circ",
 						ignoreCase: false,
 						want:       "\"\\\\circ\"",
@@ -2212,54 +2226,54 @@ var g = &grammar{
 		},
 		{
 			name: "MultiplicationExpr",
-			pos:  position{line: 606, col: 1, offset: 17064},
+			pos:  position{line: 620, col: 1, offset: 17589},
 			expr: &actionExpr{
-				pos: position{line: 606, col: 23, offset: 17086},
+				pos: position{line: 620, col: 23, offset: 17611},
 				run: (*parser).callonMultiplicationExpr1,
 				expr: &seqExpr{
-					pos: position{line: 606, col: 23, offset: 17086},
+					pos: position{line: 620, col: 23, offset: 17611},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 606, col: 23, offset: 17086},
+							pos:   position{line: 620, col: 23, offset: 17611},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 606, col: 28, offset: 17091},
+								pos:  position{line: 620, col: 28, offset: 17616},
 								name: "FractionExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 606, col: 41, offset: 17104},
+							pos:   position{line: 620, col: 41, offset: 17629},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 606, col: 46, offset: 17109},
+								pos: position{line: 620, col: 46, offset: 17634},
 								expr: &seqExpr{
-									pos: position{line: 606, col: 48, offset: 17111},
+									pos: position{line: 620, col: 48, offset: 17636},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 606, col: 48, offset: 17111},
+											pos: position{line: 620, col: 48, offset: 17636},
 											expr: &ruleRefExpr{
-												pos:  position{line: 606, col: 48, offset: 17111},
+												pos:  position{line: 620, col: 48, offset: 17636},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 606, col: 52, offset: 17115},
+											pos:        position{line: 620, col: 52, offset: 17640},
 											val:        "*",
 											ignoreCase: false,
 											want:       "\"*\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 606, col: 56, offset: 17119},
+											pos: position{line: 620, col: 56, offset: 17644},
 											expr: &ruleRefExpr{
-												pos:  position{line: 606, col: 56, offset: 17119},
+												pos:  position{line: 620, col: 56, offset: 17644},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 606, col: 60, offset: 17123},
+											pos:   position{line: 620, col: 60, offset: 17648},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 606, col: 66, offset: 17129},
+												pos:  position{line: 620, col: 66, offset: 17654},
 												name: "FractionExpr",
 											},
 										},
@@ -2273,54 +2287,54 @@ var g = &grammar{
 		},
 		{
 			name: "FractionExpr",
-			pos:  position{line: 626, col: 1, offset: 17669},
+			pos:  position{line: 640, col: 1, offset: 18197},
 			expr: &actionExpr{
-				pos: position{line: 626, col: 17, offset: 17685},
+				pos: position{line: 640, col: 17, offset: 18213},
 				run: (*parser).callonFractionExpr1,
 				expr: &seqExpr{
-					pos: position{line: 626, col: 17, offset: 17685},
+					pos: position{line: 640, col: 17, offset: 18213},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 626, col: 17, offset: 17685},
+							pos:   position{line: 640, col: 17, offset: 18213},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 626, col: 22, offset: 17690},
+								pos:  position{line: 640, col: 22, offset: 18218},
 								name: "PowerExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 626, col: 32, offset: 17700},
+							pos:   position{line: 640, col: 32, offset: 18228},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 626, col: 37, offset: 17705},
+								pos: position{line: 640, col: 37, offset: 18233},
 								expr: &seqExpr{
-									pos: position{line: 626, col: 39, offset: 17707},
+									pos: position{line: 640, col: 39, offset: 18235},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 626, col: 39, offset: 17707},
+											pos: position{line: 640, col: 39, offset: 18235},
 											expr: &ruleRefExpr{
-												pos:  position{line: 626, col: 39, offset: 17707},
+												pos:  position{line: 640, col: 39, offset: 18235},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 626, col: 43, offset: 17711},
+											pos:        position{line: 640, col: 43, offset: 18239},
 											val:        "/",
 											ignoreCase: false,
 											want:       "\"/\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 626, col: 47, offset: 17715},
+											pos: position{line: 640, col: 47, offset: 18243},
 											expr: &ruleRefExpr{
-												pos:  position{line: 626, col: 47, offset: 17715},
+												pos:  position{line: 640, col: 47, offset: 18243},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 626, col: 51, offset: 17719},
+											pos:   position{line: 640, col: 51, offset: 18247},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 626, col: 57, offset: 17725},
+												pos:  position{line: 640, col: 57, offset: 18253},
 												name: "UnaryExpr",
 											},
 										},
@@ -2334,34 +2348,34 @@ var g = &grammar{
 		},
 		{
 			name: "UnaryExpr",
-			pos:  position{line: 640, col: 1, offset: 18092},
+			pos:  position{line: 654, col: 1, offset: 18623},
 			expr: &choiceExpr{
-				pos: position{line: 640, col: 14, offset: 18105},
+				pos: position{line: 654, col: 14, offset: 18636},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 640, col: 14, offset: 18105},
+						pos: position{line: 654, col: 14, offset: 18636},
 						run: (*parser).callonUnaryExpr2,
 						expr: &seqExpr{
-							pos: position{line: 640, col: 14, offset: 18105},
+							pos: position{line: 654, col: 14, offset: 18636},
 							exprs: []any{
 								&litMatcher{
-									pos:        position{line: 640, col: 14, offset: 18105},
+									pos:        position{line: 654, col: 14, offset: 18636},
 									val:        "-",
 									ignoreCase: false,
 									want:       "\"-\"",
 								},
 								&zeroOrOneExpr{
-									pos: position{line: 640, col: 18, offset: 18109},
+									pos: position{line: 654, col: 18, offset: 18640},
 									expr: &ruleRefExpr{
-										pos:  position{line: 640, col: 18, offset: 18109},
+										pos:  position{line: 654, col: 18, offset: 18640},
 										name: "ws",
 									},
 								},
 								&labeledExpr{
-									pos:   position{line: 640, col: 22, offset: 18113},
+									pos:   position{line: 654, col: 22, offset: 18644},
 									label: "right",
 									expr: &ruleRefExpr{
-										pos:  position{line: 640, col: 28, offset: 18119},
+										pos:  position{line: 654, col: 28, offset: 18650},
 										name: "UnaryExpr",
 									},
 								},
@@ -2369,7 +2383,7 @@ var g = &grammar{
 						},
 					},
 					&ruleRefExpr{
-						pos:  position{line: 642, col: 5, offset: 18188},
+						pos:  position{line: 656, col: 5, offset: 18722},
 						name: "PowerExpr",
 					},
 				},
@@ -2377,54 +2391,54 @@ var g = &grammar{
 		},
 		{
 			name: "PowerExpr",
-			pos:  position{line: 646, col: 1, offset: 18265},
+			pos:  position{line: 660, col: 1, offset: 18799},
 			expr: &actionExpr{
-				pos: position{line: 646, col: 14, offset: 18278},
+				pos: position{line: 660, col: 14, offset: 18812},
 				run: (*parser).callonPowerExpr1,
 				expr: &seqExpr{
-					pos: position{line: 646, col: 14, offset: 18278},
+					pos: position{line: 660, col: 14, offset: 18812},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 646, col: 14, offset: 18278},
+							pos:   position{line: 660, col: 14, offset: 18812},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 646, col: 19, offset: 18283},
+								pos:  position{line: 660, col: 19, offset: 18817},
 								name: "PrimedExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 646, col: 30, offset: 18294},
+							pos:   position{line: 660, col: 30, offset: 18828},
 							label: "rest",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 646, col: 35, offset: 18299},
+								pos: position{line: 660, col: 35, offset: 18833},
 								expr: &seqExpr{
-									pos: position{line: 646, col: 37, offset: 18301},
+									pos: position{line: 660, col: 37, offset: 18835},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 646, col: 37, offset: 18301},
+											pos: position{line: 660, col: 37, offset: 18835},
 											expr: &ruleRefExpr{
-												pos:  position{line: 646, col: 37, offset: 18301},
+												pos:  position{line: 660, col: 37, offset: 18835},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 646, col: 41, offset: 18305},
+											pos:        position{line: 660, col: 41, offset: 18839},
 											val:        "^",
 											ignoreCase: false,
 											want:       "\"^\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 646, col: 45, offset: 18309},
+											pos: position{line: 660, col: 45, offset: 18843},
 											expr: &ruleRefExpr{
-												pos:  position{line: 646, col: 45, offset: 18309},
+												pos:  position{line: 660, col: 45, offset: 18843},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 646, col: 49, offset: 18313},
+											pos:   position{line: 660, col: 49, offset: 18847},
 											label: "right",
 											expr: &ruleRefExpr{
-												pos:  position{line: 646, col: 55, offset: 18319},
+												pos:  position{line: 660, col: 55, offset: 18853},
 												name: "PowerExpr",
 											},
 										},
@@ -2438,28 +2452,28 @@ var g = &grammar{
 		},
 		{
 			name: "PrimedExpr",
-			pos:  position{line: 666, col: 1, offset: 18920},
+			pos:  position{line: 680, col: 1, offset: 19457},
 			expr: &actionExpr{
-				pos: position{line: 666, col: 15, offset: 18934},
+				pos: position{line: 680, col: 15, offset: 19471},
 				run: (*parser).callonPrimedExpr1,
 				expr: &seqExpr{
-					pos: position{line: 666, col: 15, offset: 18934},
+					pos: position{line: 680, col: 15, offset: 19471},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 666, col: 15, offset: 18934},
+							pos:   position{line: 680, col: 15, offset: 19471},
 							label: "base",
 							expr: &ruleRefExpr{
-								pos:  position{line: 666, col: 20, offset: 18939},
+								pos:  position{line: 680, col: 20, offset: 19476},
 								name: "FieldAccessExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 666, col: 36, offset: 18955},
+							pos:   position{line: 680, col: 36, offset: 19492},
 							label: "prime",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 666, col: 42, offset: 18961},
+								pos: position{line: 680, col: 42, offset: 19498},
 								expr: &litMatcher{
-									pos:        position{line: 666, col: 42, offset: 18961},
+									pos:        position{line: 680, col: 42, offset: 19498},
 									val:        "'",
 									ignoreCase: false,
 									want:       "\"'\"",
@@ -2472,35 +2486,35 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAccessExpr",
-			pos:  position{line: 677, col: 1, offset: 19278},
+			pos:  position{line: 691, col: 1, offset: 19818},
 			expr: &actionExpr{
-				pos: position{line: 677, col: 20, offset: 19297},
+				pos: position{line: 691, col: 20, offset: 19837},
 				run: (*parser).callonFieldAccessExpr1,
 				expr: &seqExpr{
-					pos: position{line: 677, col: 20, offset: 19297},
+					pos: position{line: 691, col: 20, offset: 19837},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 677, col: 20, offset: 19297},
+							pos:   position{line: 691, col: 20, offset: 19837},
 							label: "base",
 							expr: &ruleRefExpr{
-								pos:  position{line: 677, col: 25, offset: 19302},
+								pos:  position{line: 691, col: 25, offset: 19842},
 								name: "AtomicExpr",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 677, col: 36, offset: 19313},
+							pos:   position{line: 691, col: 36, offset: 19853},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 677, col: 41, offset: 19318},
+								pos: position{line: 691, col: 41, offset: 19858},
 								expr: &choiceExpr{
-									pos: position{line: 677, col: 43, offset: 19320},
+									pos: position{line: 691, col: 43, offset: 19860},
 									alternatives: []any{
 										&ruleRefExpr{
-											pos:  position{line: 677, col: 43, offset: 19320},
+											pos:  position{line: 691, col: 43, offset: 19860},
 											name: "FieldAccessSuffix",
 										},
 										&ruleRefExpr{
-											pos:  position{line: 677, col: 63, offset: 19340},
+											pos:  position{line: 691, col: 63, offset: 19880},
 											name: "TupleIndexSuffix",
 										},
 									},
@@ -2513,24 +2527,24 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAccessSuffix",
-			pos:  position{line: 701, col: 1, offset: 19804},
+			pos:  position{line: 715, col: 1, offset: 20347},
 			expr: &actionExpr{
-				pos: position{line: 701, col: 22, offset: 19825},
+				pos: position{line: 715, col: 22, offset: 20368},
 				run: (*parser).callonFieldAccessSuffix1,
 				expr: &seqExpr{
-					pos: position{line: 701, col: 22, offset: 19825},
+					pos: position{line: 715, col: 22, offset: 20368},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 701, col: 22, offset: 19825},
+							pos:        position{line: 715, col: 22, offset: 20368},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 701, col: 26, offset: 19829},
+							pos:   position{line: 715, col: 26, offset: 20372},
 							label: "member",
 							expr: &ruleRefExpr{
-								pos:  position{line: 701, col: 33, offset: 19836},
+								pos:  position{line: 715, col: 33, offset: 20379},
 								name: "IdentifierName",
 							},
 						},
@@ -2540,43 +2554,43 @@ var g = &grammar{
 		},
 		{
 			name: "TupleIndexSuffix",
-			pos:  position{line: 706, col: 1, offset: 19914},
+			pos:  position{line: 720, col: 1, offset: 20457},
 			expr: &actionExpr{
-				pos: position{line: 706, col: 21, offset: 19934},
+				pos: position{line: 720, col: 21, offset: 20477},
 				run: (*parser).callonTupleIndexSuffix1,
 				expr: &seqExpr{
-					pos: position{line: 706, col: 21, offset: 19934},
+					pos: position{line: 720, col: 21, offset: 20477},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 706, col: 21, offset: 19934},
+							pos:        position{line: 720, col: 21, offset: 20477},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 706, col: 25, offset: 19938},
+							pos: position{line: 720, col: 25, offset: 20481},
 							expr: &ruleRefExpr{
-								pos:  position{line: 706, col: 25, offset: 19938},
+								pos:  position{line: 720, col: 25, offset: 20481},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 706, col: 29, offset: 19942},
+							pos:   position{line: 720, col: 29, offset: 20485},
 							label: "index",
 							expr: &ruleRefExpr{
-								pos:  position{line: 706, col: 35, offset: 19948},
+								pos:  position{line: 720, col: 35, offset: 20491},
 								name: "Expression",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 706, col: 46, offset: 19959},
+							pos: position{line: 720, col: 46, offset: 20502},
 							expr: &ruleRefExpr{
-								pos:  position{line: 706, col: 46, offset: 19959},
+								pos:  position{line: 720, col: 46, offset: 20502},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 706, col: 50, offset: 19963},
+							pos:        position{line: 720, col: 50, offset: 20506},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -2587,64 +2601,72 @@ var g = &grammar{
 		},
 		{
 			name: "AtomicExpr",
-			pos:  position{line: 728, col: 1, offset: 21034},
+			pos:  position{line: 744, col: 1, offset: 21756},
 			expr: &choiceExpr{
-				pos: position{line: 728, col: 15, offset: 21048},
+				pos: position{line: 744, col: 15, offset: 21770},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 15, offset: 21048},
+						pos:  position{line: 744, col: 15, offset: 21770},
 						name: "ParenExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 27, offset: 21060},
+						pos:  position{line: 744, col: 27, offset: 21782},
 						name: "TupleLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 42, offset: 21075},
+						pos:  position{line: 744, col: 42, offset: 21797},
 						name: "RecordExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 55, offset: 21088},
+						pos:  position{line: 744, col: 55, offset: 21810},
 						name: "SetFilter",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 67, offset: 21100},
+						pos:  position{line: 744, col: 67, offset: 21822},
 						name: "SetMap",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 76, offset: 21109},
+						pos:  position{line: 744, col: 76, offset: 21831},
 						name: "SetLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 89, offset: 21122},
+						pos:  position{line: 744, col: 89, offset: 21844},
 						name: "IfThenElse",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 102, offset: 21135},
+						pos:  position{line: 744, col: 102, offset: 21857},
+						name: "LetFunction",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 744, col: 116, offset: 21871},
 						name: "LetExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 112, offset: 21145},
+						pos:  position{line: 744, col: 126, offset: 21881},
 						name: "ChooseExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 125, offset: 21158},
+						pos:  position{line: 744, col: 139, offset: 21894},
 						name: "CaseExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 136, offset: 21169},
+						pos:  position{line: 744, col: 150, offset: 21905},
 						name: "FunctionCall",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 151, offset: 21184},
+						pos:  position{line: 744, col: 165, offset: 21920},
+						name: "ModuleConstant",
+					},
+					&ruleRefExpr{
+						pos:  position{line: 744, col: 182, offset: 21937},
 						name: "ExistingValue",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 167, offset: 21200},
+						pos:  position{line: 744, col: 198, offset: 21953},
 						name: "Literal",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 728, col: 177, offset: 21210},
+						pos:  position{line: 744, col: 208, offset: 21963},
 						name: "Identifier",
 					},
 				},
@@ -2652,43 +2674,43 @@ var g = &grammar{
 		},
 		{
 			name: "ParenExpr",
-			pos:  position{line: 731, col: 1, offset: 21299},
+			pos:  position{line: 747, col: 1, offset: 22052},
 			expr: &actionExpr{
-				pos: position{line: 731, col: 14, offset: 21312},
+				pos: position{line: 747, col: 14, offset: 22065},
 				run: (*parser).callonParenExpr1,
 				expr: &seqExpr{
-					pos: position{line: 731, col: 14, offset: 21312},
+					pos: position{line: 747, col: 14, offset: 22065},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 731, col: 14, offset: 21312},
+							pos:        position{line: 747, col: 14, offset: 22065},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 731, col: 18, offset: 21316},
+							pos: position{line: 747, col: 18, offset: 22069},
 							expr: &ruleRefExpr{
-								pos:  position{line: 731, col: 18, offset: 21316},
+								pos:  position{line: 747, col: 18, offset: 22069},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 731, col: 22, offset: 21320},
+							pos:   position{line: 747, col: 22, offset: 22073},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 731, col: 27, offset: 21325},
+								pos:  position{line: 747, col: 27, offset: 22078},
 								name: "Expression",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 731, col: 38, offset: 21336},
+							pos: position{line: 747, col: 38, offset: 22089},
 							expr: &ruleRefExpr{
-								pos:  position{line: 731, col: 38, offset: 21336},
+								pos:  position{line: 747, col: 38, offset: 22089},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 731, col: 42, offset: 21340},
+							pos:        position{line: 747, col: 42, offset: 22093},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -2699,48 +2721,48 @@ var g = &grammar{
 		},
 		{
 			name: "SetBinding",
-			pos:  position{line: 740, col: 1, offset: 21685},
+			pos:  position{line: 756, col: 1, offset: 22441},
 			expr: &actionExpr{
-				pos: position{line: 740, col: 15, offset: 21699},
+				pos: position{line: 756, col: 15, offset: 22455},
 				run: (*parser).callonSetBinding1,
 				expr: &seqExpr{
-					pos: position{line: 740, col: 15, offset: 21699},
+					pos: position{line: 756, col: 15, offset: 22455},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 740, col: 15, offset: 21699},
+							pos:   position{line: 756, col: 15, offset: 22455},
 							label: "left",
 							expr: &ruleRefExpr{
-								pos:  position{line: 740, col: 20, offset: 21704},
+								pos:  position{line: 756, col: 20, offset: 22460},
 								name: "SetComparisonExpr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 740, col: 38, offset: 21722},
+							pos: position{line: 756, col: 38, offset: 22478},
 							expr: &ruleRefExpr{
-								pos:  position{line: 740, col: 38, offset: 21722},
+								pos:  position{line: 756, col: 38, offset: 22478},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 740, col: 42, offset: 21726},
+							pos:   position{line: 756, col: 42, offset: 22482},
 							label: "op",
 							expr: &ruleRefExpr{
-								pos:  position{line: 740, col: 45, offset: 21729},
+								pos:  position{line: 756, col: 45, offset: 22485},
 								name: "SetMembershipOp",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 740, col: 61, offset: 21745},
+							pos: position{line: 756, col: 61, offset: 22501},
 							expr: &ruleRefExpr{
-								pos:  position{line: 740, col: 61, offset: 21745},
+								pos:  position{line: 756, col: 61, offset: 22501},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 740, col: 65, offset: 21749},
+							pos:   position{line: 756, col: 65, offset: 22505},
 							label: "right",
 							expr: &ruleRefExpr{
-								pos:  position{line: 740, col: 71, offset: 21755},
+								pos:  position{line: 756, col: 71, offset: 22511},
 								name: "SetComparisonExpr",
 							},
 						},
@@ -2750,71 +2772,71 @@ var g = &grammar{
 		},
 		{
 			name: "SetMap",
-			pos:  position{line: 750, col: 1, offset: 22049},
+			pos:  position{line: 766, col: 1, offset: 22808},
 			expr: &actionExpr{
-				pos: position{line: 750, col: 11, offset: 22059},
+				pos: position{line: 766, col: 11, offset: 22818},
 				run: (*parser).callonSetMap1,
 				expr: &seqExpr{
-					pos: position{line: 750, col: 11, offset: 22059},
+					pos: position{line: 766, col: 11, offset: 22818},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 750, col: 11, offset: 22059},
+							pos:        position{line: 766, col: 11, offset: 22818},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 750, col: 15, offset: 22063},
+							pos: position{line: 766, col: 15, offset: 22822},
 							expr: &ruleRefExpr{
-								pos:  position{line: 750, col: 15, offset: 22063},
+								pos:  position{line: 766, col: 15, offset: 22822},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 750, col: 19, offset: 22067},
+							pos:   position{line: 766, col: 19, offset: 22826},
 							label: "transform",
 							expr: &ruleRefExpr{
-								pos:  position{line: 750, col: 29, offset: 22077},
+								pos:  position{line: 766, col: 29, offset: 22836},
 								name: "Expression",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 750, col: 40, offset: 22088},
+							pos: position{line: 766, col: 40, offset: 22847},
 							expr: &ruleRefExpr{
-								pos:  position{line: 750, col: 40, offset: 22088},
+								pos:  position{line: 766, col: 40, offset: 22847},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 750, col: 44, offset: 22092},
+							pos:        position{line: 766, col: 44, offset: 22851},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 750, col: 48, offset: 22096},
+							pos: position{line: 766, col: 48, offset: 22855},
 							expr: &ruleRefExpr{
-								pos:  position{line: 750, col: 48, offset: 22096},
+								pos:  position{line: 766, col: 48, offset: 22855},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 750, col: 52, offset: 22100},
+							pos:   position{line: 766, col: 52, offset: 22859},
 							label: "membership",
 							expr: &ruleRefExpr{
-								pos:  position{line: 750, col: 63, offset: 22111},
+								pos:  position{line: 766, col: 63, offset: 22870},
 								name: "SetBinding",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 750, col: 74, offset: 22122},
+							pos: position{line: 766, col: 74, offset: 22881},
 							expr: &ruleRefExpr{
-								pos:  position{line: 750, col: 74, offset: 22122},
+								pos:  position{line: 766, col: 74, offset: 22881},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 750, col: 78, offset: 22126},
+							pos:        position{line: 766, col: 78, offset: 22885},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -2825,71 +2847,71 @@ var g = &grammar{
 		},
 		{
 			name: "SetFilter",
-			pos:  position{line: 759, col: 1, offset: 22372},
+			pos:  position{line: 775, col: 1, offset: 23134},
 			expr: &actionExpr{
-				pos: position{line: 759, col: 14, offset: 22385},
+				pos: position{line: 775, col: 14, offset: 23147},
 				run: (*parser).callonSetFilter1,
 				expr: &seqExpr{
-					pos: position{line: 759, col: 14, offset: 22385},
+					pos: position{line: 775, col: 14, offset: 23147},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 759, col: 14, offset: 22385},
+							pos:        position{line: 775, col: 14, offset: 23147},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 759, col: 18, offset: 22389},
+							pos: position{line: 775, col: 18, offset: 23151},
 							expr: &ruleRefExpr{
-								pos:  position{line: 759, col: 18, offset: 22389},
+								pos:  position{line: 775, col: 18, offset: 23151},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 759, col: 22, offset: 22393},
+							pos:   position{line: 775, col: 22, offset: 23155},
 							label: "membership",
 							expr: &ruleRefExpr{
-								pos:  position{line: 759, col: 33, offset: 22404},
+								pos:  position{line: 775, col: 33, offset: 23166},
 								name: "SetBinding",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 759, col: 44, offset: 22415},
+							pos: position{line: 775, col: 44, offset: 23177},
 							expr: &ruleRefExpr{
-								pos:  position{line: 759, col: 44, offset: 22415},
+								pos:  position{line: 775, col: 44, offset: 23177},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 759, col: 48, offset: 22419},
+							pos:        position{line: 775, col: 48, offset: 23181},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 759, col: 52, offset: 22423},
+							pos: position{line: 775, col: 52, offset: 23185},
 							expr: &ruleRefExpr{
-								pos:  position{line: 759, col: 52, offset: 22423},
+								pos:  position{line: 775, col: 52, offset: 23185},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 759, col: 56, offset: 22427},
+							pos:   position{line: 775, col: 56, offset: 23189},
 							label: "predicate",
 							expr: &ruleRefExpr{
-								pos:  position{line: 759, col: 66, offset: 22437},
+								pos:  position{line: 775, col: 66, offset: 23199},
 								name: "Expression",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 759, col: 77, offset: 22448},
+							pos: position{line: 775, col: 77, offset: 23210},
 							expr: &ruleRefExpr{
-								pos:  position{line: 759, col: 77, offset: 22448},
+								pos:  position{line: 775, col: 77, offset: 23210},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 759, col: 81, offset: 22452},
+							pos:        position{line: 775, col: 81, offset: 23214},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -2900,46 +2922,46 @@ var g = &grammar{
 		},
 		{
 			name: "SetLiteral",
-			pos:  position{line: 767, col: 1, offset: 22631},
+			pos:  position{line: 783, col: 1, offset: 23396},
 			expr: &actionExpr{
-				pos: position{line: 767, col: 15, offset: 22645},
+				pos: position{line: 783, col: 15, offset: 23410},
 				run: (*parser).callonSetLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 767, col: 15, offset: 22645},
+					pos: position{line: 783, col: 15, offset: 23410},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 767, col: 15, offset: 22645},
+							pos:        position{line: 783, col: 15, offset: 23410},
 							val:        "{",
 							ignoreCase: false,
 							want:       "\"{\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 767, col: 19, offset: 22649},
+							pos: position{line: 783, col: 19, offset: 23414},
 							expr: &ruleRefExpr{
-								pos:  position{line: 767, col: 19, offset: 22649},
+								pos:  position{line: 783, col: 19, offset: 23414},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 767, col: 23, offset: 22653},
+							pos:   position{line: 783, col: 23, offset: 23418},
 							label: "elems",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 767, col: 29, offset: 22659},
+								pos: position{line: 783, col: 29, offset: 23424},
 								expr: &ruleRefExpr{
-									pos:  position{line: 767, col: 29, offset: 22659},
+									pos:  position{line: 783, col: 29, offset: 23424},
 									name: "SetElements",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 767, col: 42, offset: 22672},
+							pos: position{line: 783, col: 42, offset: 23437},
 							expr: &ruleRefExpr{
-								pos:  position{line: 767, col: 42, offset: 22672},
+								pos:  position{line: 783, col: 42, offset: 23437},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 767, col: 46, offset: 22676},
+							pos:        position{line: 783, col: 46, offset: 23441},
 							val:        "}",
 							ignoreCase: false,
 							want:       "\"}\"",
@@ -2950,54 +2972,54 @@ var g = &grammar{
 		},
 		{
 			name: "SetElements",
-			pos:  position{line: 775, col: 1, offset: 22884},
+			pos:  position{line: 791, col: 1, offset: 23655},
 			expr: &actionExpr{
-				pos: position{line: 775, col: 16, offset: 22899},
+				pos: position{line: 791, col: 16, offset: 23670},
 				run: (*parser).callonSetElements1,
 				expr: &seqExpr{
-					pos: position{line: 775, col: 16, offset: 22899},
+					pos: position{line: 791, col: 16, offset: 23670},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 775, col: 16, offset: 22899},
+							pos:   position{line: 791, col: 16, offset: 23670},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 775, col: 22, offset: 22905},
+								pos:  position{line: 791, col: 22, offset: 23676},
 								name: "Expression",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 775, col: 33, offset: 22916},
+							pos:   position{line: 791, col: 33, offset: 23687},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 775, col: 38, offset: 22921},
+								pos: position{line: 791, col: 38, offset: 23692},
 								expr: &seqExpr{
-									pos: position{line: 775, col: 40, offset: 22923},
+									pos: position{line: 791, col: 40, offset: 23694},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 775, col: 40, offset: 22923},
+											pos: position{line: 791, col: 40, offset: 23694},
 											expr: &ruleRefExpr{
-												pos:  position{line: 775, col: 40, offset: 22923},
+												pos:  position{line: 791, col: 40, offset: 23694},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 775, col: 44, offset: 22927},
+											pos:        position{line: 791, col: 44, offset: 23698},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 775, col: 48, offset: 22931},
+											pos: position{line: 791, col: 48, offset: 23702},
 											expr: &ruleRefExpr{
-												pos:  position{line: 775, col: 48, offset: 22931},
+												pos:  position{line: 791, col: 48, offset: 23702},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 775, col: 52, offset: 22935},
+											pos:   position{line: 791, col: 52, offset: 23706},
 											label: "expr",
 											expr: &ruleRefExpr{
-												pos:  position{line: 775, col: 57, offset: 22940},
+												pos:  position{line: 791, col: 57, offset: 23711},
 												name: "Expression",
 											},
 										},
@@ -3011,44 +3033,44 @@ var g = &grammar{
 		},
 		{
 			name: "TupleLiteral",
-			pos:  position{line: 791, col: 1, offset: 23462},
+			pos:  position{line: 807, col: 1, offset: 24233},
 			expr: &actionExpr{
-				pos: position{line: 791, col: 17, offset: 23478},
+				pos: position{line: 807, col: 17, offset: 24249},
 				run: (*parser).callonTupleLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 791, col: 17, offset: 23478},
+					pos: position{line: 807, col: 17, offset: 24249},
 					exprs: []any{
 						&ruleRefExpr{
-							pos:  position{line: 791, col: 17, offset: 23478},
+							pos:  position{line: 807, col: 17, offset: 24249},
 							name: "TupleOpen",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 791, col: 27, offset: 23488},
+							pos: position{line: 807, col: 27, offset: 24259},
 							expr: &ruleRefExpr{
-								pos:  position{line: 791, col: 27, offset: 23488},
+								pos:  position{line: 807, col: 27, offset: 24259},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 791, col: 31, offset: 23492},
+							pos:   position{line: 807, col: 31, offset: 24263},
 							label: "elems",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 791, col: 37, offset: 23498},
+								pos: position{line: 807, col: 37, offset: 24269},
 								expr: &ruleRefExpr{
-									pos:  position{line: 791, col: 37, offset: 23498},
+									pos:  position{line: 807, col: 37, offset: 24269},
 									name: "TupleElements",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 791, col: 52, offset: 23513},
+							pos: position{line: 807, col: 52, offset: 24284},
 							expr: &ruleRefExpr{
-								pos:  position{line: 791, col: 52, offset: 23513},
+								pos:  position{line: 807, col: 52, offset: 24284},
 								name: "ws",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 791, col: 56, offset: 23517},
+							pos:  position{line: 807, col: 56, offset: 24288},
 							name: "TupleClose",
 						},
 					},
@@ -3057,18 +3079,18 @@ var g = &grammar{
 		},
 		{
 			name: "TupleOpen",
-			pos:  position{line: 799, col: 1, offset: 23708},
+			pos:  position{line: 815, col: 1, offset: 24485},
 			expr: &choiceExpr{
-				pos: position{line: 799, col: 14, offset: 23721},
+				pos: position{line: 815, col: 14, offset: 24498},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 799, col: 14, offset: 23721},
+						pos:        position{line: 815, col: 14, offset: 24498},
 						val:        "<<",
 						ignoreCase: false,
 						want:       "\"<<\"",
 					},
 					&litMatcher{
-						pos:        position{line: 799, col: 21, offset: 23728},
+						pos:        position{line: 815, col: 21, offset: 24505},
 						val:        "⟨",
 						ignoreCase: false,
 						want:       "\"⟨\"",
@@ -3078,18 +3100,18 @@ var g = &grammar{
 		},
 		{
 			name: "TupleClose",
-			pos:  position{line: 802, col: 1, offset: 23760},
+			pos:  position{line: 818, col: 1, offset: 24537},
 			expr: &choiceExpr{
-				pos: position{line: 802, col: 15, offset: 23774},
+				pos: position{line: 818, col: 15, offset: 24551},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 802, col: 15, offset: 23774},
+						pos:        position{line: 818, col: 15, offset: 24551},
 						val:        ">>",
 						ignoreCase: false,
 						want:       "\">>\"",
 					},
 					&litMatcher{
-						pos:        position{line: 802, col: 22, offset: 23781},
+						pos:        position{line: 818, col: 22, offset: 24558},
 						val:        "⟩",
 						ignoreCase: false,
 						want:       "\"⟩\"",
@@ -3099,54 +3121,54 @@ var g = &grammar{
 		},
 		{
 			name: "TupleElements",
-			pos:  position{line: 805, col: 1, offset: 23842},
+			pos:  position{line: 821, col: 1, offset: 24619},
 			expr: &actionExpr{
-				pos: position{line: 805, col: 18, offset: 23859},
+				pos: position{line: 821, col: 18, offset: 24636},
 				run: (*parser).callonTupleElements1,
 				expr: &seqExpr{
-					pos: position{line: 805, col: 18, offset: 23859},
+					pos: position{line: 821, col: 18, offset: 24636},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 805, col: 18, offset: 23859},
+							pos:   position{line: 821, col: 18, offset: 24636},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 805, col: 24, offset: 23865},
+								pos:  position{line: 821, col: 24, offset: 24642},
 								name: "Expression",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 805, col: 35, offset: 23876},
+							pos:   position{line: 821, col: 35, offset: 24653},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 805, col: 40, offset: 23881},
+								pos: position{line: 821, col: 40, offset: 24658},
 								expr: &seqExpr{
-									pos: position{line: 805, col: 42, offset: 23883},
+									pos: position{line: 821, col: 42, offset: 24660},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 805, col: 42, offset: 23883},
+											pos: position{line: 821, col: 42, offset: 24660},
 											expr: &ruleRefExpr{
-												pos:  position{line: 805, col: 42, offset: 23883},
+												pos:  position{line: 821, col: 42, offset: 24660},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 805, col: 46, offset: 23887},
+											pos:        position{line: 821, col: 46, offset: 24664},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 805, col: 50, offset: 23891},
+											pos: position{line: 821, col: 50, offset: 24668},
 											expr: &ruleRefExpr{
-												pos:  position{line: 805, col: 50, offset: 23891},
+												pos:  position{line: 821, col: 50, offset: 24668},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 805, col: 54, offset: 23895},
+											pos:   position{line: 821, col: 54, offset: 24672},
 											label: "expr",
 											expr: &ruleRefExpr{
-												pos:  position{line: 805, col: 59, offset: 23900},
+												pos:  position{line: 821, col: 59, offset: 24677},
 												name: "Expression",
 											},
 										},
@@ -3160,20 +3182,20 @@ var g = &grammar{
 		},
 		{
 			name: "RecordExpr",
-			pos:  position{line: 823, col: 1, offset: 24618},
+			pos:  position{line: 839, col: 1, offset: 25395},
 			expr: &choiceExpr{
-				pos: position{line: 823, col: 15, offset: 24632},
+				pos: position{line: 839, col: 15, offset: 25409},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 823, col: 15, offset: 24632},
+						pos:  position{line: 839, col: 15, offset: 25409},
 						name: "RecordAltered",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 823, col: 31, offset: 24648},
+						pos:  position{line: 839, col: 31, offset: 25425},
 						name: "RecordTypeExpr",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 823, col: 48, offset: 24665},
+						pos:  position{line: 839, col: 48, offset: 25442},
 						name: "RecordInstance",
 					},
 				},
@@ -3181,71 +3203,71 @@ var g = &grammar{
 		},
 		{
 			name: "RecordAltered",
-			pos:  position{line: 829, col: 1, offset: 24912},
+			pos:  position{line: 845, col: 1, offset: 25689},
 			expr: &actionExpr{
-				pos: position{line: 829, col: 18, offset: 24929},
+				pos: position{line: 845, col: 18, offset: 25706},
 				run: (*parser).callonRecordAltered1,
 				expr: &seqExpr{
-					pos: position{line: 829, col: 18, offset: 24929},
+					pos: position{line: 845, col: 18, offset: 25706},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 829, col: 18, offset: 24929},
+							pos:        position{line: 845, col: 18, offset: 25706},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 829, col: 22, offset: 24933},
+							pos: position{line: 845, col: 22, offset: 25710},
 							expr: &ruleRefExpr{
-								pos:  position{line: 829, col: 22, offset: 24933},
+								pos:  position{line: 845, col: 22, offset: 25710},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 829, col: 26, offset: 24937},
+							pos:   position{line: 845, col: 26, offset: 25714},
 							label: "base",
 							expr: &ruleRefExpr{
-								pos:  position{line: 829, col: 31, offset: 24942},
+								pos:  position{line: 845, col: 31, offset: 25719},
 								name: "RecordAlteredBase",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 829, col: 49, offset: 24960},
+							pos: position{line: 845, col: 49, offset: 25737},
 							expr: &ruleRefExpr{
-								pos:  position{line: 829, col: 49, offset: 24960},
+								pos:  position{line: 845, col: 49, offset: 25737},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 829, col: 53, offset: 24964},
+							pos:        position{line: 845, col: 53, offset: 25741},
 							val:        "EXCEPT",
 							ignoreCase: false,
 							want:       "\"EXCEPT\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 829, col: 62, offset: 24973},
+							pos: position{line: 845, col: 62, offset: 25750},
 							expr: &ruleRefExpr{
-								pos:  position{line: 829, col: 62, offset: 24973},
+								pos:  position{line: 845, col: 62, offset: 25750},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 829, col: 66, offset: 24977},
+							pos:   position{line: 845, col: 66, offset: 25754},
 							label: "alts",
 							expr: &ruleRefExpr{
-								pos:  position{line: 829, col: 71, offset: 24982},
+								pos:  position{line: 845, col: 71, offset: 25759},
 								name: "FieldAlterations",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 829, col: 88, offset: 24999},
+							pos: position{line: 845, col: 88, offset: 25776},
 							expr: &ruleRefExpr{
-								pos:  position{line: 829, col: 88, offset: 24999},
+								pos:  position{line: 845, col: 88, offset: 25776},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 829, col: 92, offset: 25003},
+							pos:        position{line: 845, col: 92, offset: 25780},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -3256,16 +3278,16 @@ var g = &grammar{
 		},
 		{
 			name: "RecordAlteredBase",
-			pos:  position{line: 838, col: 1, offset: 25268},
+			pos:  position{line: 854, col: 1, offset: 26048},
 			expr: &choiceExpr{
-				pos: position{line: 838, col: 22, offset: 25289},
+				pos: position{line: 854, col: 22, offset: 26069},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 838, col: 22, offset: 25289},
+						pos:  position{line: 854, col: 22, offset: 26069},
 						name: "RecordAltered",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 838, col: 38, offset: 25305},
+						pos:  position{line: 854, col: 38, offset: 26085},
 						name: "Identifier",
 					},
 				},
@@ -3273,54 +3295,54 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAlterations",
-			pos:  position{line: 841, col: 1, offset: 25377},
+			pos:  position{line: 857, col: 1, offset: 26157},
 			expr: &actionExpr{
-				pos: position{line: 841, col: 21, offset: 25397},
+				pos: position{line: 857, col: 21, offset: 26177},
 				run: (*parser).callonFieldAlterations1,
 				expr: &seqExpr{
-					pos: position{line: 841, col: 21, offset: 25397},
+					pos: position{line: 857, col: 21, offset: 26177},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 841, col: 21, offset: 25397},
+							pos:   position{line: 857, col: 21, offset: 26177},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 841, col: 27, offset: 25403},
+								pos:  position{line: 857, col: 27, offset: 26183},
 								name: "FieldAlteration",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 841, col: 43, offset: 25419},
+							pos:   position{line: 857, col: 43, offset: 26199},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 841, col: 48, offset: 25424},
+								pos: position{line: 857, col: 48, offset: 26204},
 								expr: &seqExpr{
-									pos: position{line: 841, col: 50, offset: 25426},
+									pos: position{line: 857, col: 50, offset: 26206},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 841, col: 50, offset: 25426},
+											pos: position{line: 857, col: 50, offset: 26206},
 											expr: &ruleRefExpr{
-												pos:  position{line: 841, col: 50, offset: 25426},
+												pos:  position{line: 857, col: 50, offset: 26206},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 841, col: 54, offset: 25430},
+											pos:        position{line: 857, col: 54, offset: 26210},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 841, col: 58, offset: 25434},
+											pos: position{line: 857, col: 58, offset: 26214},
 											expr: &ruleRefExpr{
-												pos:  position{line: 841, col: 58, offset: 25434},
+												pos:  position{line: 857, col: 58, offset: 26214},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 841, col: 62, offset: 25438},
+											pos:   position{line: 857, col: 62, offset: 26218},
 											label: "alt",
 											expr: &ruleRefExpr{
-												pos:  position{line: 841, col: 66, offset: 25442},
+												pos:  position{line: 857, col: 66, offset: 26222},
 												name: "FieldAlteration",
 											},
 										},
@@ -3334,58 +3356,58 @@ var g = &grammar{
 		},
 		{
 			name: "FieldAlteration",
-			pos:  position{line: 853, col: 1, offset: 25763},
+			pos:  position{line: 869, col: 1, offset: 26543},
 			expr: &actionExpr{
-				pos: position{line: 853, col: 20, offset: 25782},
+				pos: position{line: 869, col: 20, offset: 26562},
 				run: (*parser).callonFieldAlteration1,
 				expr: &seqExpr{
-					pos: position{line: 853, col: 20, offset: 25782},
+					pos: position{line: 869, col: 20, offset: 26562},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 853, col: 20, offset: 25782},
+							pos:        position{line: 869, col: 20, offset: 26562},
 							val:        "!",
 							ignoreCase: false,
 							want:       "\"!\"",
 						},
 						&litMatcher{
-							pos:        position{line: 853, col: 24, offset: 25786},
+							pos:        position{line: 869, col: 24, offset: 26566},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 853, col: 28, offset: 25790},
+							pos:   position{line: 869, col: 28, offset: 26570},
 							label: "field",
 							expr: &ruleRefExpr{
-								pos:  position{line: 853, col: 34, offset: 25796},
+								pos:  position{line: 869, col: 34, offset: 26576},
 								name: "IdentifierName",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 853, col: 49, offset: 25811},
+							pos: position{line: 869, col: 49, offset: 26591},
 							expr: &ruleRefExpr{
-								pos:  position{line: 853, col: 49, offset: 25811},
+								pos:  position{line: 869, col: 49, offset: 26591},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 853, col: 53, offset: 25815},
+							pos:        position{line: 869, col: 53, offset: 26595},
 							val:        "=",
 							ignoreCase: false,
 							want:       "\"=\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 853, col: 57, offset: 25819},
+							pos: position{line: 869, col: 57, offset: 26599},
 							expr: &ruleRefExpr{
-								pos:  position{line: 853, col: 57, offset: 25819},
+								pos:  position{line: 869, col: 57, offset: 26599},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 853, col: 61, offset: 25823},
+							pos:   position{line: 869, col: 61, offset: 26603},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 853, col: 66, offset: 25828},
+								pos:  position{line: 869, col: 66, offset: 26608},
 								name: "Expression",
 							},
 						},
@@ -3395,43 +3417,43 @@ var g = &grammar{
 		},
 		{
 			name: "RecordTypeExpr",
-			pos:  position{line: 865, col: 1, offset: 26126},
+			pos:  position{line: 881, col: 1, offset: 26909},
 			expr: &actionExpr{
-				pos: position{line: 865, col: 19, offset: 26144},
+				pos: position{line: 881, col: 19, offset: 26927},
 				run: (*parser).callonRecordTypeExpr1,
 				expr: &seqExpr{
-					pos: position{line: 865, col: 19, offset: 26144},
+					pos: position{line: 881, col: 19, offset: 26927},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 865, col: 19, offset: 26144},
+							pos:        position{line: 881, col: 19, offset: 26927},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 865, col: 23, offset: 26148},
+							pos: position{line: 881, col: 23, offset: 26931},
 							expr: &ruleRefExpr{
-								pos:  position{line: 865, col: 23, offset: 26148},
+								pos:  position{line: 881, col: 23, offset: 26931},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 865, col: 27, offset: 26152},
+							pos:   position{line: 881, col: 27, offset: 26935},
 							label: "fields",
 							expr: &ruleRefExpr{
-								pos:  position{line: 865, col: 34, offset: 26159},
+								pos:  position{line: 881, col: 34, offset: 26942},
 								name: "RecordTypeFields",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 865, col: 51, offset: 26176},
+							pos: position{line: 881, col: 51, offset: 26959},
 							expr: &ruleRefExpr{
-								pos:  position{line: 865, col: 51, offset: 26176},
+								pos:  position{line: 881, col: 51, offset: 26959},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 865, col: 55, offset: 26180},
+							pos:        position{line: 881, col: 55, offset: 26963},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -3442,54 +3464,54 @@ var g = &grammar{
 		},
 		{
 			name: "RecordTypeFields",
-			pos:  position{line: 872, col: 1, offset: 26326},
+			pos:  position{line: 888, col: 1, offset: 27112},
 			expr: &actionExpr{
-				pos: position{line: 872, col: 21, offset: 26346},
+				pos: position{line: 888, col: 21, offset: 27132},
 				run: (*parser).callonRecordTypeFields1,
 				expr: &seqExpr{
-					pos: position{line: 872, col: 21, offset: 26346},
+					pos: position{line: 888, col: 21, offset: 27132},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 872, col: 21, offset: 26346},
+							pos:   position{line: 888, col: 21, offset: 27132},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 872, col: 27, offset: 26352},
+								pos:  position{line: 888, col: 27, offset: 27138},
 								name: "RecordTypeFieldBinding",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 872, col: 50, offset: 26375},
+							pos:   position{line: 888, col: 50, offset: 27161},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 872, col: 55, offset: 26380},
+								pos: position{line: 888, col: 55, offset: 27166},
 								expr: &seqExpr{
-									pos: position{line: 872, col: 57, offset: 26382},
+									pos: position{line: 888, col: 57, offset: 27168},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 872, col: 57, offset: 26382},
+											pos: position{line: 888, col: 57, offset: 27168},
 											expr: &ruleRefExpr{
-												pos:  position{line: 872, col: 57, offset: 26382},
+												pos:  position{line: 888, col: 57, offset: 27168},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 872, col: 61, offset: 26386},
+											pos:        position{line: 888, col: 61, offset: 27172},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 872, col: 65, offset: 26390},
+											pos: position{line: 888, col: 65, offset: 27176},
 											expr: &ruleRefExpr{
-												pos:  position{line: 872, col: 65, offset: 26390},
+												pos:  position{line: 888, col: 65, offset: 27176},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 872, col: 69, offset: 26394},
+											pos:   position{line: 888, col: 69, offset: 27180},
 											label: "field",
 											expr: &ruleRefExpr{
-												pos:  position{line: 872, col: 75, offset: 26400},
+												pos:  position{line: 888, col: 75, offset: 27186},
 												name: "RecordTypeFieldBinding",
 											},
 										},
@@ -3503,46 +3525,46 @@ var g = &grammar{
 		},
 		{
 			name: "RecordTypeFieldBinding",
-			pos:  position{line: 884, col: 1, offset: 26712},
+			pos:  position{line: 900, col: 1, offset: 27498},
 			expr: &actionExpr{
-				pos: position{line: 884, col: 27, offset: 26738},
+				pos: position{line: 900, col: 27, offset: 27524},
 				run: (*parser).callonRecordTypeFieldBinding1,
 				expr: &seqExpr{
-					pos: position{line: 884, col: 27, offset: 26738},
+					pos: position{line: 900, col: 27, offset: 27524},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 884, col: 27, offset: 26738},
+							pos:   position{line: 900, col: 27, offset: 27524},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 884, col: 32, offset: 26743},
+								pos:  position{line: 900, col: 32, offset: 27529},
 								name: "IdentifierName",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 884, col: 47, offset: 26758},
+							pos: position{line: 900, col: 47, offset: 27544},
 							expr: &ruleRefExpr{
-								pos:  position{line: 884, col: 47, offset: 26758},
+								pos:  position{line: 900, col: 47, offset: 27544},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 884, col: 51, offset: 26762},
+							pos:        position{line: 900, col: 51, offset: 27548},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 884, col: 55, offset: 26766},
+							pos: position{line: 900, col: 55, offset: 27552},
 							expr: &ruleRefExpr{
-								pos:  position{line: 884, col: 55, offset: 26766},
+								pos:  position{line: 900, col: 55, offset: 27552},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 884, col: 59, offset: 26770},
+							pos:   position{line: 900, col: 59, offset: 27556},
 							label: "typeExpr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 884, col: 68, offset: 26779},
+								pos:  position{line: 900, col: 68, offset: 27565},
 								name: "Expression",
 							},
 						},
@@ -3552,43 +3574,43 @@ var g = &grammar{
 		},
 		{
 			name: "RecordInstance",
-			pos:  position{line: 893, col: 1, offset: 27015},
+			pos:  position{line: 909, col: 1, offset: 27804},
 			expr: &actionExpr{
-				pos: position{line: 893, col: 19, offset: 27033},
+				pos: position{line: 909, col: 19, offset: 27822},
 				run: (*parser).callonRecordInstance1,
 				expr: &seqExpr{
-					pos: position{line: 893, col: 19, offset: 27033},
+					pos: position{line: 909, col: 19, offset: 27822},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 893, col: 19, offset: 27033},
+							pos:        position{line: 909, col: 19, offset: 27822},
 							val:        "[",
 							ignoreCase: false,
 							want:       "\"[\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 893, col: 23, offset: 27037},
+							pos: position{line: 909, col: 23, offset: 27826},
 							expr: &ruleRefExpr{
-								pos:  position{line: 893, col: 23, offset: 27037},
+								pos:  position{line: 909, col: 23, offset: 27826},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 893, col: 27, offset: 27041},
+							pos:   position{line: 909, col: 27, offset: 27830},
 							label: "bindings",
 							expr: &ruleRefExpr{
-								pos:  position{line: 893, col: 36, offset: 27050},
+								pos:  position{line: 909, col: 36, offset: 27839},
 								name: "FieldBindings",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 893, col: 50, offset: 27064},
+							pos: position{line: 909, col: 50, offset: 27853},
 							expr: &ruleRefExpr{
-								pos:  position{line: 893, col: 50, offset: 27064},
+								pos:  position{line: 909, col: 50, offset: 27853},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 893, col: 54, offset: 27068},
+							pos:        position{line: 909, col: 54, offset: 27857},
 							val:        "]",
 							ignoreCase: false,
 							want:       "\"]\"",
@@ -3599,54 +3621,54 @@ var g = &grammar{
 		},
 		{
 			name: "FieldBindings",
-			pos:  position{line: 900, col: 1, offset: 27215},
+			pos:  position{line: 916, col: 1, offset: 28007},
 			expr: &actionExpr{
-				pos: position{line: 900, col: 18, offset: 27232},
+				pos: position{line: 916, col: 18, offset: 28024},
 				run: (*parser).callonFieldBindings1,
 				expr: &seqExpr{
-					pos: position{line: 900, col: 18, offset: 27232},
+					pos: position{line: 916, col: 18, offset: 28024},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 900, col: 18, offset: 27232},
+							pos:   position{line: 916, col: 18, offset: 28024},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 900, col: 24, offset: 27238},
+								pos:  position{line: 916, col: 24, offset: 28030},
 								name: "FieldBinding",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 900, col: 37, offset: 27251},
+							pos:   position{line: 916, col: 37, offset: 28043},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 900, col: 42, offset: 27256},
+								pos: position{line: 916, col: 42, offset: 28048},
 								expr: &seqExpr{
-									pos: position{line: 900, col: 44, offset: 27258},
+									pos: position{line: 916, col: 44, offset: 28050},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 900, col: 44, offset: 27258},
+											pos: position{line: 916, col: 44, offset: 28050},
 											expr: &ruleRefExpr{
-												pos:  position{line: 900, col: 44, offset: 27258},
+												pos:  position{line: 916, col: 44, offset: 28050},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 900, col: 48, offset: 27262},
+											pos:        position{line: 916, col: 48, offset: 28054},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 900, col: 52, offset: 27266},
+											pos: position{line: 916, col: 52, offset: 28058},
 											expr: &ruleRefExpr{
-												pos:  position{line: 900, col: 52, offset: 27266},
+												pos:  position{line: 916, col: 52, offset: 28058},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 900, col: 56, offset: 27270},
+											pos:   position{line: 916, col: 56, offset: 28062},
 											label: "binding",
 											expr: &ruleRefExpr{
-												pos:  position{line: 900, col: 64, offset: 27278},
+												pos:  position{line: 916, col: 64, offset: 28070},
 												name: "FieldBinding",
 											},
 										},
@@ -3660,44 +3682,44 @@ var g = &grammar{
 		},
 		{
 			name: "FieldBinding",
-			pos:  position{line: 912, col: 1, offset: 27590},
+			pos:  position{line: 928, col: 1, offset: 28382},
 			expr: &actionExpr{
-				pos: position{line: 912, col: 17, offset: 27606},
+				pos: position{line: 928, col: 17, offset: 28398},
 				run: (*parser).callonFieldBinding1,
 				expr: &seqExpr{
-					pos: position{line: 912, col: 17, offset: 27606},
+					pos: position{line: 928, col: 17, offset: 28398},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 912, col: 17, offset: 27606},
+							pos:   position{line: 928, col: 17, offset: 28398},
 							label: "field",
 							expr: &ruleRefExpr{
-								pos:  position{line: 912, col: 23, offset: 27612},
+								pos:  position{line: 928, col: 23, offset: 28404},
 								name: "IdentifierName",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 912, col: 38, offset: 27627},
+							pos: position{line: 928, col: 38, offset: 28419},
 							expr: &ruleRefExpr{
-								pos:  position{line: 912, col: 38, offset: 27627},
+								pos:  position{line: 928, col: 38, offset: 28419},
 								name: "ws",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 912, col: 42, offset: 27631},
+							pos:  position{line: 928, col: 42, offset: 28423},
 							name: "MapsTo",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 912, col: 49, offset: 27638},
+							pos: position{line: 928, col: 49, offset: 28430},
 							expr: &ruleRefExpr{
-								pos:  position{line: 912, col: 49, offset: 27638},
+								pos:  position{line: 928, col: 49, offset: 28430},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 912, col: 53, offset: 27642},
+							pos:   position{line: 928, col: 53, offset: 28434},
 							label: "expr",
 							expr: &ruleRefExpr{
-								pos:  position{line: 912, col: 58, offset: 27647},
+								pos:  position{line: 928, col: 58, offset: 28439},
 								name: "Expression",
 							},
 						},
@@ -3707,18 +3729,18 @@ var g = &grammar{
 		},
 		{
 			name: "MapsTo",
-			pos:  position{line: 920, col: 1, offset: 27811},
+			pos:  position{line: 936, col: 1, offset: 28606},
 			expr: &choiceExpr{
-				pos: position{line: 920, col: 11, offset: 27821},
+				pos: position{line: 936, col: 11, offset: 28616},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 920, col: 11, offset: 27821},
+						pos:        position{line: 936, col: 11, offset: 28616},
 						val:        "|->",
 						ignoreCase: false,
 						want:       "\"|->\"",
 					},
 					&litMatcher{
-						pos:        position{line: 920, col: 19, offset: 27829},
+						pos:        position{line: 936, col: 19, offset: 28624},
 						val:        "↦",
 						ignoreCase: false,
 						want:       "\"↦\"",
@@ -3728,87 +3750,87 @@ var g = &grammar{
 		},
 		{
 			name: "IfThenElse",
-			pos:  position{line: 927, col: 1, offset: 28075},
+			pos:  position{line: 943, col: 1, offset: 28870},
 			expr: &actionExpr{
-				pos: position{line: 927, col: 15, offset: 28089},
+				pos: position{line: 943, col: 15, offset: 28884},
 				run: (*parser).callonIfThenElse1,
 				expr: &seqExpr{
-					pos: position{line: 927, col: 15, offset: 28089},
+					pos: position{line: 943, col: 15, offset: 28884},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 927, col: 15, offset: 28089},
+							pos:        position{line: 943, col: 15, offset: 28884},
 							val:        "IF",
 							ignoreCase: false,
 							want:       "\"IF\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 927, col: 20, offset: 28094},
+							pos: position{line: 943, col: 20, offset: 28889},
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 20, offset: 28094},
+								pos:  position{line: 943, col: 20, offset: 28889},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 927, col: 24, offset: 28098},
+							pos:   position{line: 943, col: 24, offset: 28893},
 							label: "cond",
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 29, offset: 28103},
+								pos:  position{line: 943, col: 29, offset: 28898},
 								name: "Expression",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 927, col: 40, offset: 28114},
+							pos: position{line: 943, col: 40, offset: 28909},
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 40, offset: 28114},
+								pos:  position{line: 943, col: 40, offset: 28909},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 927, col: 44, offset: 28118},
+							pos:        position{line: 943, col: 44, offset: 28913},
 							val:        "THEN",
 							ignoreCase: false,
 							want:       "\"THEN\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 927, col: 51, offset: 28125},
+							pos: position{line: 943, col: 51, offset: 28920},
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 51, offset: 28125},
+								pos:  position{line: 943, col: 51, offset: 28920},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 927, col: 55, offset: 28129},
+							pos:   position{line: 943, col: 55, offset: 28924},
 							label: "then",
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 60, offset: 28134},
+								pos:  position{line: 943, col: 60, offset: 28929},
 								name: "Expression",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 927, col: 71, offset: 28145},
+							pos: position{line: 943, col: 71, offset: 28940},
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 71, offset: 28145},
+								pos:  position{line: 943, col: 71, offset: 28940},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 927, col: 75, offset: 28149},
+							pos:        position{line: 943, col: 75, offset: 28944},
 							val:        "ELSE",
 							ignoreCase: false,
 							want:       "\"ELSE\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 927, col: 82, offset: 28156},
+							pos: position{line: 943, col: 82, offset: 28951},
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 82, offset: 28156},
+								pos:  position{line: 943, col: 82, offset: 28951},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 927, col: 86, offset: 28160},
+							pos:   position{line: 943, col: 86, offset: 28955},
 							label: "else_",
 							expr: &ruleRefExpr{
-								pos:  position{line: 927, col: 92, offset: 28166},
+								pos:  position{line: 943, col: 92, offset: 28961},
 								name: "Expression",
 							},
 						},
@@ -3818,87 +3840,218 @@ var g = &grammar{
 		},
 		{
 			name: "LetExpr",
-			pos:  position{line: 936, col: 1, offset: 28362},
+			pos:  position{line: 952, col: 1, offset: 29160},
 			expr: &actionExpr{
-				pos: position{line: 936, col: 12, offset: 28373},
+				pos: position{line: 952, col: 12, offset: 29171},
 				run: (*parser).callonLetExpr1,
 				expr: &seqExpr{
-					pos: position{line: 936, col: 12, offset: 28373},
+					pos: position{line: 952, col: 12, offset: 29171},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 936, col: 12, offset: 28373},
+							pos:        position{line: 952, col: 12, offset: 29171},
 							val:        "LET",
 							ignoreCase: false,
 							want:       "\"LET\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 936, col: 18, offset: 28379},
+							pos: position{line: 952, col: 18, offset: 29177},
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 18, offset: 28379},
+								pos:  position{line: 952, col: 18, offset: 29177},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 936, col: 22, offset: 28383},
+							pos:   position{line: 952, col: 22, offset: 29181},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 27, offset: 28388},
+								pos:  position{line: 952, col: 27, offset: 29186},
 								name: "IdentifierName",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 936, col: 42, offset: 28403},
+							pos: position{line: 952, col: 42, offset: 29201},
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 42, offset: 28403},
+								pos:  position{line: 952, col: 42, offset: 29201},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 936, col: 46, offset: 28407},
+							pos:        position{line: 952, col: 46, offset: 29205},
 							val:        "==",
 							ignoreCase: false,
 							want:       "\"==\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 936, col: 51, offset: 28412},
+							pos: position{line: 952, col: 51, offset: 29210},
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 51, offset: 28412},
+								pos:  position{line: 952, col: 51, offset: 29210},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 936, col: 55, offset: 28416},
+							pos:   position{line: 952, col: 55, offset: 29214},
 							label: "value",
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 61, offset: 28422},
+								pos:  position{line: 952, col: 61, offset: 29220},
 								name: "Expression",
 							},
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 936, col: 72, offset: 28433},
+							pos: position{line: 952, col: 72, offset: 29231},
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 72, offset: 28433},
+								pos:  position{line: 952, col: 72, offset: 29231},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 936, col: 76, offset: 28437},
+							pos:        position{line: 952, col: 76, offset: 29235},
 							val:        "IN",
 							ignoreCase: false,
 							want:       "\"IN\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 936, col: 81, offset: 28442},
+							pos: position{line: 952, col: 81, offset: 29240},
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 81, offset: 28442},
+								pos:  position{line: 952, col: 81, offset: 29240},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 936, col: 85, offset: 28446},
+							pos:   position{line: 952, col: 85, offset: 29244},
 							label: "body",
 							expr: &ruleRefExpr{
-								pos:  position{line: 936, col: 90, offset: 28451},
+								pos:  position{line: 952, col: 90, offset: 29249},
+								name: "Expression",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "LetFunction",
+			pos:  position{line: 962, col: 1, offset: 29516},
+			expr: &actionExpr{
+				pos: position{line: 962, col: 16, offset: 29531},
+				run: (*parser).callonLetFunction1,
+				expr: &seqExpr{
+					pos: position{line: 962, col: 16, offset: 29531},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 962, col: 16, offset: 29531},
+							val:        "LET",
+							ignoreCase: false,
+							want:       "\"LET\"",
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 962, col: 22, offset: 29537},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 22, offset: 29537},
+								name: "ws",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 962, col: 26, offset: 29541},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 31, offset: 29546},
+								name: "IdentifierName",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 962, col: 46, offset: 29561},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 46, offset: 29561},
+								name: "ws",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 962, col: 50, offset: 29565},
+							val:        "[",
+							ignoreCase: false,
+							want:       "\"[\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 962, col: 54, offset: 29569},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 54, offset: 29569},
+								name: "ws",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 962, col: 58, offset: 29573},
+							label: "membership",
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 69, offset: 29584},
+								name: "SetMembershipExpr",
+							},
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 962, col: 87, offset: 29602},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 87, offset: 29602},
+								name: "ws",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 962, col: 91, offset: 29606},
+							val:        "]",
+							ignoreCase: false,
+							want:       "\"]\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 962, col: 95, offset: 29610},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 95, offset: 29610},
+								name: "ws",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 962, col: 99, offset: 29614},
+							val:        "==",
+							ignoreCase: false,
+							want:       "\"==\"",
+						},
+						&zeroOrOneExpr{
+							pos: position{line: 962, col: 104, offset: 29619},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 104, offset: 29619},
+								name: "ws",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 962, col: 108, offset: 29623},
+							label: "value",
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 114, offset: 29629},
+								name: "Expression",
+							},
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 962, col: 125, offset: 29640},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 125, offset: 29640},
+								name: "ws",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 962, col: 129, offset: 29644},
+							val:        "IN",
+							ignoreCase: false,
+							want:       "\"IN\"",
+						},
+						&oneOrMoreExpr{
+							pos: position{line: 962, col: 134, offset: 29649},
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 134, offset: 29649},
+								name: "ws",
+							},
+						},
+						&labeledExpr{
+							pos:   position{line: 962, col: 138, offset: 29653},
+							label: "body",
+							expr: &ruleRefExpr{
+								pos:  position{line: 962, col: 143, offset: 29658},
 								name: "Expression",
 							},
 						},
@@ -3908,59 +4061,59 @@ var g = &grammar{
 		},
 		{
 			name: "ChooseExpr",
-			pos:  position{line: 945, col: 1, offset: 28641},
+			pos:  position{line: 972, col: 1, offset: 29904},
 			expr: &actionExpr{
-				pos: position{line: 945, col: 15, offset: 28655},
+				pos: position{line: 972, col: 15, offset: 29918},
 				run: (*parser).callonChooseExpr1,
 				expr: &seqExpr{
-					pos: position{line: 945, col: 15, offset: 28655},
+					pos: position{line: 972, col: 15, offset: 29918},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 945, col: 15, offset: 28655},
+							pos:        position{line: 972, col: 15, offset: 29918},
 							val:        "CHOOSE",
 							ignoreCase: false,
 							want:       "\"CHOOSE\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 945, col: 24, offset: 28664},
+							pos: position{line: 972, col: 24, offset: 29927},
 							expr: &ruleRefExpr{
-								pos:  position{line: 945, col: 24, offset: 28664},
+								pos:  position{line: 972, col: 24, offset: 29927},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 945, col: 28, offset: 28668},
+							pos:   position{line: 972, col: 28, offset: 29931},
 							label: "membership",
 							expr: &ruleRefExpr{
-								pos:  position{line: 945, col: 39, offset: 28679},
+								pos:  position{line: 972, col: 39, offset: 29942},
 								name: "SetMembershipExpr",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 945, col: 57, offset: 28697},
+							pos: position{line: 972, col: 57, offset: 29960},
 							expr: &ruleRefExpr{
-								pos:  position{line: 945, col: 57, offset: 28697},
+								pos:  position{line: 972, col: 57, offset: 29960},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 945, col: 61, offset: 28701},
+							pos:        position{line: 972, col: 61, offset: 29964},
 							val:        ":",
 							ignoreCase: false,
 							want:       "\":\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 945, col: 65, offset: 28705},
+							pos: position{line: 972, col: 65, offset: 29968},
 							expr: &ruleRefExpr{
-								pos:  position{line: 945, col: 65, offset: 28705},
+								pos:  position{line: 972, col: 65, offset: 29968},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 945, col: 69, offset: 28709},
+							pos:   position{line: 972, col: 69, offset: 29972},
 							label: "predicate",
 							expr: &ruleRefExpr{
-								pos:  position{line: 945, col: 79, offset: 28719},
+								pos:  position{line: 972, col: 79, offset: 29982},
 								name: "Expression",
 							},
 						},
@@ -3970,41 +4123,41 @@ var g = &grammar{
 		},
 		{
 			name: "CaseExpr",
-			pos:  position{line: 954, col: 1, offset: 28993},
+			pos:  position{line: 981, col: 1, offset: 30259},
 			expr: &actionExpr{
-				pos: position{line: 954, col: 13, offset: 29005},
+				pos: position{line: 981, col: 13, offset: 30271},
 				run: (*parser).callonCaseExpr1,
 				expr: &seqExpr{
-					pos: position{line: 954, col: 13, offset: 29005},
+					pos: position{line: 981, col: 13, offset: 30271},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 954, col: 13, offset: 29005},
+							pos:        position{line: 981, col: 13, offset: 30271},
 							val:        "CASE",
 							ignoreCase: false,
 							want:       "\"CASE\"",
 						},
 						&oneOrMoreExpr{
-							pos: position{line: 954, col: 20, offset: 29012},
+							pos: position{line: 981, col: 20, offset: 30278},
 							expr: &ruleRefExpr{
-								pos:  position{line: 954, col: 20, offset: 29012},
+								pos:  position{line: 981, col: 20, offset: 30278},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 954, col: 24, offset: 29016},
+							pos:   position{line: 981, col: 24, offset: 30282},
 							label: "branches",
 							expr: &ruleRefExpr{
-								pos:  position{line: 954, col: 33, offset: 29025},
+								pos:  position{line: 981, col: 33, offset: 30291},
 								name: "CaseBranches",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 954, col: 46, offset: 29038},
+							pos:   position{line: 981, col: 46, offset: 30304},
 							label: "other",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 954, col: 52, offset: 29044},
+								pos: position{line: 981, col: 52, offset: 30310},
 								expr: &ruleRefExpr{
-									pos:  position{line: 954, col: 52, offset: 29044},
+									pos:  position{line: 981, col: 52, offset: 30310},
 									name: "CaseOther",
 								},
 							},
@@ -4015,52 +4168,52 @@ var g = &grammar{
 		},
 		{
 			name: "CaseBranches",
-			pos:  position{line: 965, col: 1, offset: 29290},
+			pos:  position{line: 992, col: 1, offset: 30559},
 			expr: &actionExpr{
-				pos: position{line: 965, col: 17, offset: 29306},
+				pos: position{line: 992, col: 17, offset: 30575},
 				run: (*parser).callonCaseBranches1,
 				expr: &seqExpr{
-					pos: position{line: 965, col: 17, offset: 29306},
+					pos: position{line: 992, col: 17, offset: 30575},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 965, col: 17, offset: 29306},
+							pos:   position{line: 992, col: 17, offset: 30575},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 965, col: 23, offset: 29312},
+								pos:  position{line: 992, col: 23, offset: 30581},
 								name: "CaseBranch",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 965, col: 34, offset: 29323},
+							pos:   position{line: 992, col: 34, offset: 30592},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 965, col: 39, offset: 29328},
+								pos: position{line: 992, col: 39, offset: 30597},
 								expr: &seqExpr{
-									pos: position{line: 965, col: 41, offset: 29330},
+									pos: position{line: 992, col: 41, offset: 30599},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 965, col: 41, offset: 29330},
+											pos: position{line: 992, col: 41, offset: 30599},
 											expr: &ruleRefExpr{
-												pos:  position{line: 965, col: 41, offset: 29330},
+												pos:  position{line: 992, col: 41, offset: 30599},
 												name: "ws",
 											},
 										},
 										&ruleRefExpr{
-											pos:  position{line: 965, col: 45, offset: 29334},
+											pos:  position{line: 992, col: 45, offset: 30603},
 											name: "CaseSeparator",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 965, col: 59, offset: 29348},
+											pos: position{line: 992, col: 59, offset: 30617},
 											expr: &ruleRefExpr{
-												pos:  position{line: 965, col: 59, offset: 29348},
+												pos:  position{line: 992, col: 59, offset: 30617},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 965, col: 63, offset: 29352},
+											pos:   position{line: 992, col: 63, offset: 30621},
 											label: "branch",
 											expr: &ruleRefExpr{
-												pos:  position{line: 965, col: 70, offset: 29359},
+												pos:  position{line: 992, col: 70, offset: 30628},
 												name: "CaseBranch",
 											},
 										},
@@ -4074,44 +4227,44 @@ var g = &grammar{
 		},
 		{
 			name: "CaseBranch",
-			pos:  position{line: 977, col: 1, offset: 29648},
+			pos:  position{line: 1004, col: 1, offset: 30917},
 			expr: &actionExpr{
-				pos: position{line: 977, col: 15, offset: 29662},
+				pos: position{line: 1004, col: 15, offset: 30931},
 				run: (*parser).callonCaseBranch1,
 				expr: &seqExpr{
-					pos: position{line: 977, col: 15, offset: 29662},
+					pos: position{line: 1004, col: 15, offset: 30931},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 977, col: 15, offset: 29662},
+							pos:   position{line: 1004, col: 15, offset: 30931},
 							label: "cond",
 							expr: &ruleRefExpr{
-								pos:  position{line: 977, col: 20, offset: 29667},
+								pos:  position{line: 1004, col: 20, offset: 30936},
 								name: "CaseCondition",
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 977, col: 34, offset: 29681},
+							pos: position{line: 1004, col: 34, offset: 30950},
 							expr: &ruleRefExpr{
-								pos:  position{line: 977, col: 34, offset: 29681},
+								pos:  position{line: 1004, col: 34, offset: 30950},
 								name: "ws",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 977, col: 38, offset: 29685},
+							pos:  position{line: 1004, col: 38, offset: 30954},
 							name: "CaseArrow",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 977, col: 48, offset: 29695},
+							pos: position{line: 1004, col: 48, offset: 30964},
 							expr: &ruleRefExpr{
-								pos:  position{line: 977, col: 48, offset: 29695},
+								pos:  position{line: 1004, col: 48, offset: 30964},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 977, col: 52, offset: 29699},
+							pos:   position{line: 1004, col: 52, offset: 30968},
 							label: "result",
 							expr: &ruleRefExpr{
-								pos:  position{line: 977, col: 59, offset: 29706},
+								pos:  position{line: 1004, col: 59, offset: 30975},
 								name: "CaseResult",
 							},
 						},
@@ -4121,15 +4274,15 @@ var g = &grammar{
 		},
 		{
 			name: "CaseCondition",
-			pos:  position{line: 986, col: 1, offset: 29951},
+			pos:  position{line: 1013, col: 1, offset: 31223},
 			expr: &actionExpr{
-				pos: position{line: 986, col: 18, offset: 29968},
+				pos: position{line: 1013, col: 18, offset: 31240},
 				run: (*parser).callonCaseCondition1,
 				expr: &labeledExpr{
-					pos:   position{line: 986, col: 18, offset: 29968},
+					pos:   position{line: 1013, col: 18, offset: 31240},
 					label: "expr",
 					expr: &ruleRefExpr{
-						pos:  position{line: 986, col: 23, offset: 29973},
+						pos:  position{line: 1013, col: 23, offset: 31245},
 						name: "OrExpr",
 					},
 				},
@@ -4137,15 +4290,15 @@ var g = &grammar{
 		},
 		{
 			name: "CaseResult",
-			pos:  position{line: 992, col: 1, offset: 30119},
+			pos:  position{line: 1019, col: 1, offset: 31391},
 			expr: &actionExpr{
-				pos: position{line: 992, col: 15, offset: 30133},
+				pos: position{line: 1019, col: 15, offset: 31405},
 				run: (*parser).callonCaseResult1,
 				expr: &labeledExpr{
-					pos:   position{line: 992, col: 15, offset: 30133},
+					pos:   position{line: 1019, col: 15, offset: 31405},
 					label: "expr",
 					expr: &ruleRefExpr{
-						pos:  position{line: 992, col: 20, offset: 30138},
+						pos:  position{line: 1019, col: 20, offset: 31410},
 						name: "OrExpr",
 					},
 				},
@@ -4153,60 +4306,60 @@ var g = &grammar{
 		},
 		{
 			name: "CaseOther",
-			pos:  position{line: 997, col: 1, offset: 30218},
+			pos:  position{line: 1024, col: 1, offset: 31490},
 			expr: &actionExpr{
-				pos: position{line: 997, col: 14, offset: 30231},
+				pos: position{line: 1024, col: 14, offset: 31503},
 				run: (*parser).callonCaseOther1,
 				expr: &seqExpr{
-					pos: position{line: 997, col: 14, offset: 30231},
+					pos: position{line: 1024, col: 14, offset: 31503},
 					exprs: []any{
 						&zeroOrOneExpr{
-							pos: position{line: 997, col: 14, offset: 30231},
+							pos: position{line: 1024, col: 14, offset: 31503},
 							expr: &ruleRefExpr{
-								pos:  position{line: 997, col: 14, offset: 30231},
+								pos:  position{line: 1024, col: 14, offset: 31503},
 								name: "ws",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 997, col: 18, offset: 30235},
+							pos:  position{line: 1024, col: 18, offset: 31507},
 							name: "CaseSeparator",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 997, col: 32, offset: 30249},
+							pos: position{line: 1024, col: 32, offset: 31521},
 							expr: &ruleRefExpr{
-								pos:  position{line: 997, col: 32, offset: 30249},
+								pos:  position{line: 1024, col: 32, offset: 31521},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 997, col: 36, offset: 30253},
+							pos:        position{line: 1024, col: 36, offset: 31525},
 							val:        "OTHER",
 							ignoreCase: false,
 							want:       "\"OTHER\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 997, col: 44, offset: 30261},
+							pos: position{line: 1024, col: 44, offset: 31533},
 							expr: &ruleRefExpr{
-								pos:  position{line: 997, col: 44, offset: 30261},
+								pos:  position{line: 1024, col: 44, offset: 31533},
 								name: "ws",
 							},
 						},
 						&ruleRefExpr{
-							pos:  position{line: 997, col: 48, offset: 30265},
+							pos:  position{line: 1024, col: 48, offset: 31537},
 							name: "CaseArrow",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 997, col: 58, offset: 30275},
+							pos: position{line: 1024, col: 58, offset: 31547},
 							expr: &ruleRefExpr{
-								pos:  position{line: 997, col: 58, offset: 30275},
+								pos:  position{line: 1024, col: 58, offset: 31547},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 997, col: 62, offset: 30279},
+							pos:   position{line: 1024, col: 62, offset: 31551},
 							label: "result",
 							expr: &ruleRefExpr{
-								pos:  position{line: 997, col: 69, offset: 30286},
+								pos:  position{line: 1024, col: 69, offset: 31558},
 								name: "CaseResult",
 							},
 						},
@@ -4216,18 +4369,18 @@ var g = &grammar{
 		},
 		{
 			name: "CaseSeparator",
-			pos:  position{line: 1002, col: 1, offset: 30367},
+			pos:  position{line: 1029, col: 1, offset: 31639},
 			expr: &choiceExpr{
-				pos: position{line: 1002, col: 18, offset: 30384},
+				pos: position{line: 1029, col: 18, offset: 31656},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 1002, col: 18, offset: 30384},
+						pos:        position{line: 1029, col: 18, offset: 31656},
 						val:        "[]",
 						ignoreCase: false,
 						want:       "\"[]\"",
 					},
 					&litMatcher{
-						pos:        position{line: 1002, col: 25, offset: 30391},
+						pos:        position{line: 1029, col: 25, offset: 31663},
 						val:        "□",
 						ignoreCase: false,
 						want:       "\"□\"",
@@ -4237,18 +4390,18 @@ var g = &grammar{
 		},
 		{
 			name: "CaseArrow",
-			pos:  position{line: 1005, col: 1, offset: 30422},
+			pos:  position{line: 1032, col: 1, offset: 31694},
 			expr: &choiceExpr{
-				pos: position{line: 1005, col: 14, offset: 30435},
+				pos: position{line: 1032, col: 14, offset: 31707},
 				alternatives: []any{
 					&litMatcher{
-						pos:        position{line: 1005, col: 14, offset: 30435},
+						pos:        position{line: 1032, col: 14, offset: 31707},
 						val:        "->",
 						ignoreCase: false,
 						want:       "\"->\"",
 					},
 					&litMatcher{
-						pos:        position{line: 1005, col: 21, offset: 30442},
+						pos:        position{line: 1032, col: 21, offset: 31714},
 						val:        "→",
 						ignoreCase: false,
 						want:       "\"→\"",
@@ -4258,71 +4411,71 @@ var g = &grammar{
 		},
 		{
 			name: "FunctionCall",
-			pos:  position{line: 1017, col: 1, offset: 30860},
+			pos:  position{line: 1044, col: 1, offset: 32132},
 			expr: &actionExpr{
-				pos: position{line: 1017, col: 17, offset: 30876},
+				pos: position{line: 1044, col: 17, offset: 32148},
 				run: (*parser).callonFunctionCall1,
 				expr: &seqExpr{
-					pos: position{line: 1017, col: 17, offset: 30876},
+					pos: position{line: 1044, col: 17, offset: 32148},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 1017, col: 17, offset: 30876},
+							pos:   position{line: 1044, col: 17, offset: 32148},
 							label: "scopePath",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1017, col: 27, offset: 30886},
+								pos:  position{line: 1044, col: 27, offset: 32158},
 								name: "ScopePath",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1017, col: 37, offset: 30896},
+							pos:   position{line: 1044, col: 37, offset: 32168},
 							label: "funcName",
 							expr: &choiceExpr{
-								pos: position{line: 1017, col: 47, offset: 30906},
+								pos: position{line: 1044, col: 47, offset: 32178},
 								alternatives: []any{
 									&ruleRefExpr{
-										pos:  position{line: 1017, col: 47, offset: 30906},
+										pos:  position{line: 1044, col: 47, offset: 32178},
 										name: "SystemEventName",
 									},
 									&ruleRefExpr{
-										pos:  position{line: 1017, col: 65, offset: 30924},
+										pos:  position{line: 1044, col: 65, offset: 32196},
 										name: "IdentifierName",
 									},
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 1017, col: 81, offset: 30940},
+							pos:        position{line: 1044, col: 81, offset: 32212},
 							val:        "(",
 							ignoreCase: false,
 							want:       "\"(\"",
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 1017, col: 85, offset: 30944},
+							pos: position{line: 1044, col: 85, offset: 32216},
 							expr: &ruleRefExpr{
-								pos:  position{line: 1017, col: 85, offset: 30944},
+								pos:  position{line: 1044, col: 85, offset: 32216},
 								name: "ws",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1017, col: 89, offset: 30948},
+							pos:   position{line: 1044, col: 89, offset: 32220},
 							label: "args",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 1017, col: 94, offset: 30953},
+								pos: position{line: 1044, col: 94, offset: 32225},
 								expr: &ruleRefExpr{
-									pos:  position{line: 1017, col: 94, offset: 30953},
+									pos:  position{line: 1044, col: 94, offset: 32225},
 									name: "FunctionArgs",
 								},
 							},
 						},
 						&zeroOrOneExpr{
-							pos: position{line: 1017, col: 108, offset: 30967},
+							pos: position{line: 1044, col: 108, offset: 32239},
 							expr: &ruleRefExpr{
-								pos:  position{line: 1017, col: 108, offset: 30967},
+								pos:  position{line: 1044, col: 108, offset: 32239},
 								name: "ws",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 1017, col: 112, offset: 30971},
+							pos:        position{line: 1044, col: 112, offset: 32243},
 							val:        ")",
 							ignoreCase: false,
 							want:       "\")\"",
@@ -4331,30 +4484,115 @@ var g = &grammar{
 				},
 			},
 		},
+		{
+			name: "ModuleConstant",
+			pos:  position{line: 1064, col: 1, offset: 32777},
+			expr: &actionExpr{
+				pos: position{line: 1064, col: 19, offset: 32795},
+				run: (*parser).callonModuleConstant1,
+				expr: &seqExpr{
+					pos: position{line: 1064, col: 19, offset: 32795},
+					exprs: []any{
+						&labeledExpr{
+							pos:   position{line: 1064, col: 19, offset: 32795},
+							label: "module",
+							expr: &ruleRefExpr{
+								pos:  position{line: 1064, col: 26, offset: 32802},
+								name: "ModuleName",
+							},
+						},
+						&litMatcher{
+							pos:        position{line: 1064, col: 37, offset: 32813},
+							val:        "!",
+							ignoreCase: false,
+							want:       "\"!\"",
+						},
+						&labeledExpr{
+							pos:   position{line: 1064, col: 41, offset: 32817},
+							label: "name",
+							expr: &ruleRefExpr{
+								pos:  position{line: 1064, col: 46, offset: 32822},
+								name: "IdentifierName",
+							},
+						},
+						&notExpr{
+							pos: position{line: 1064, col: 61, offset: 32837},
+							expr: &choiceExpr{
+								pos: position{line: 1064, col: 64, offset: 32840},
+								alternatives: []any{
+									&litMatcher{
+										pos:        position{line: 1064, col: 64, offset: 32840},
+										val:        "(",
+										ignoreCase: false,
+										want:       "\"(\"",
+									},
+									&litMatcher{
+										pos:        position{line: 1064, col: 70, offset: 32846},
+										val:        "!",
+										ignoreCase: false,
+										want:       "\"!\"",
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "ModuleName",
+			pos:  position{line: 1073, col: 1, offset: 33099},
+			expr: &actionExpr{
+				pos: position{line: 1073, col: 15, offset: 33113},
+				run: (*parser).callonModuleName1,
+				expr: &seqExpr{
+					pos: position{line: 1073, col: 15, offset: 33113},
+					exprs: []any{
+						&litMatcher{
+							pos:        position{line: 1073, col: 15, offset: 33113},
+							val:        "_",
+							ignoreCase: false,
+							want:       "\"_\"",
+						},
+						&zeroOrMoreExpr{
+							pos: position{line: 1073, col: 19, offset: 33117},
+							expr: &charClassMatcher{
+								pos:        position{line: 1073, col: 19, offset: 33117},
+								val:        "[a-zA-Z0-9_]",
+								chars:      []rune{'_'},
+								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
+								ignoreCase: false,
+								inverted:   false,
+							},
+						},
+					},
+				},
+			},
+		},
 		{
 			name: "ScopePath",
-			pos:  position{line: 1037, col: 1, offset: 31478},
+			pos:  position{line: 1079, col: 1, offset: 33288},
 			expr: &actionExpr{
-				pos: position{line: 1037, col: 14, offset: 31491},
+				pos: position{line: 1079, col: 14, offset: 33301},
 				run: (*parser).callonScopePath1,
 				expr: &labeledExpr{
-					pos:   position{line: 1037, col: 14, offset: 31491},
+					pos:   position{line: 1079, col: 14, offset: 33301},
 					label: "segments",
 					expr: &zeroOrMoreExpr{
-						pos: position{line: 1037, col: 23, offset: 31500},
+						pos: position{line: 1079, col: 23, offset: 33310},
 						expr: &seqExpr{
-							pos: position{line: 1037, col: 25, offset: 31502},
+							pos: position{line: 1079, col: 25, offset: 33312},
 							exprs: []any{
 								&labeledExpr{
-									pos:   position{line: 1037, col: 25, offset: 31502},
+									pos:   position{line: 1079, col: 25, offset: 33312},
 									label: "name",
 									expr: &ruleRefExpr{
-										pos:  position{line: 1037, col: 30, offset: 31507},
+										pos:  position{line: 1079, col: 30, offset: 33317},
 										name: "IdentifierName",
 									},
 								},
 								&litMatcher{
-									pos:        position{line: 1037, col: 45, offset: 31522},
+									pos:        position{line: 1079, col: 45, offset: 33332},
 									val:        "!",
 									ignoreCase: false,
 									want:       "\"!\"",
@@ -4367,54 +4605,54 @@ var g = &grammar{
 		},
 		{
 			name: "FunctionArgs",
-			pos:  position{line: 1051, col: 1, offset: 31873},
+			pos:  position{line: 1093, col: 1, offset: 33683},
 			expr: &actionExpr{
-				pos: position{line: 1051, col: 17, offset: 31889},
+				pos: position{line: 1093, col: 17, offset: 33699},
 				run: (*parser).callonFunctionArgs1,
 				expr: &seqExpr{
-					pos: position{line: 1051, col: 17, offset: 31889},
+					pos: position{line: 1093, col: 17, offset: 33699},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 1051, col: 17, offset: 31889},
+							pos:   position{line: 1093, col: 17, offset: 33699},
 							label: "first",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1051, col: 23, offset: 31895},
+								pos:  position{line: 1093, col: 23, offset: 33705},
 								name: "Expression",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1051, col: 34, offset: 31906},
+							pos:   position{line: 1093, col: 34, offset: 33716},
 							label: "rest",
 							expr: &zeroOrMoreExpr{
-								pos: position{line: 1051, col: 39, offset: 31911},
+								pos: position{line: 1093, col: 39, offset: 33721},
 								expr: &seqExpr{
-									pos: position{line: 1051, col: 41, offset: 31913},
+									pos: position{line: 1093, col: 41, offset: 33723},
 									exprs: []any{
 										&zeroOrOneExpr{
-											pos: position{line: 1051, col: 41, offset: 31913},
+											pos: position{line: 1093, col: 41, offset: 33723},
 											expr: &ruleRefExpr{
-												pos:  position{line: 1051, col: 41, offset: 31913},
+												pos:  position{line: 1093, col: 41, offset: 33723},
 												name: "ws",
 											},
 										},
 										&litMatcher{
-											pos:        position{line: 1051, col: 45, offset: 31917},
+											pos:        position{line: 1093, col: 45, offset: 33727},
 											val:        ",",
 											ignoreCase: false,
 											want:       "\",\"",
 										},
 										&zeroOrOneExpr{
-											pos: position{line: 1051, col: 49, offset: 31921},
+											pos: position{line: 1093, col: 49, offset: 33731},
 											expr: &ruleRefExpr{
-												pos:  position{line: 1051, col: 49, offset: 31921},
+												pos:  position{line: 1093, col: 49, offset: 33731},
 												name: "ws",
 											},
 										},
 										&labeledExpr{
-											pos:   position{line: 1051, col: 53, offset: 31925},
+											pos:   position{line: 1093, col: 53, offset: 33735},
 											label: "expr",
 											expr: &ruleRefExpr{
-												pos:  position{line: 1051, col: 58, offset: 31930},
+												pos:  position{line: 1093, col: 58, offset: 33740},
 												name: "Expression",
 											},
 										},
@@ -4428,12 +4666,12 @@ var g = &grammar{
 		},
 		{
 			name: "ExistingValue",
-			pos:  position{line: 1067, col: 1, offset: 32432},
+			pos:  position{line: 1109, col: 1, offset: 34242},
 			expr: &actionExpr{
-				pos: position{line: 1067, col: 18, offset: 32449},
+				pos: position{line: 1109, col: 18, offset: 34259},
 				run: (*parser).callonExistingValue1,
 				expr: &litMatcher{
-					pos:        position{line: 1067, col: 18, offset: 32449},
+					pos:        position{line: 1109, col: 18, offset: 34259},
 					val:        "@",
 					ignoreCase: false,
 					want:       "\"@\"",
@@ -4442,25 +4680,25 @@ var g = &grammar{
 		},
 		{
 			name: "Identifier",
-			pos:  position{line: 1073, col: 1, offset: 32605},
+			pos:  position{line: 1115, col: 1, offset: 34418},
 			expr: &actionExpr{
-				pos: position{line: 1073, col: 15, offset: 32619},
+				pos: position{line: 1115, col: 15, offset: 34432},
 				run: (*parser).callonIdentifier1,
 				expr: &seqExpr{
-					pos: position{line: 1073, col: 15, offset: 32619},
+					pos: position{line: 1115, col: 15, offset: 34432},
 					exprs: []any{
 						&notExpr{
-							pos: position{line: 1073, col: 15, offset: 32619},
+							pos: position{line: 1115, col: 15, offset: 34432},
 							expr: &ruleRefExpr{
-								pos:  position{line: 1073, col: 16, offset: 32620},
+								pos:  position{line: 1115, col: 16, offset: 34433},
 								name: "ReservedKeyword",
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1073, col: 32, offset: 32636},
+							pos:   position{line: 1115, col: 32, offset: 34449},
 							label: "name",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1073, col: 37, offset: 32641},
+								pos:  position{line: 1115, col: 37, offset: 34454},
 								name: "IdentifierName",
 							},
 						},
@@ -4470,45 +4708,45 @@ var g = &grammar{
 		},
 		{
 			name: "SystemEventName",
-			pos:  position{line: 1079, col: 1, offset: 32879},
+			pos:  position{line: 1121, col: 1, offset: 34695},
 			expr: &choiceExpr{
-				pos: position{line: 1079, col: 20, offset: 32898},
+				pos: position{line: 1121, col: 20, offset: 34714},
 				alternatives: []any{
 					&actionExpr{
-						pos: position{line: 1079, col: 20, offset: 32898},
+						pos: position{line: 1121, col: 20, offset: 34714},
 						run: (*parser).callonSystemEventName2,
 						expr: &litMatcher{
-							pos:        position{line: 1079, col: 20, offset: 32898},
+							pos:        position{line: 1121, col: 20, offset: 34714},
 							val:        "_new",
 							ignoreCase: false,
 							want:       "\"_new\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 1081, col: 5, offset: 32934},
+						pos: position{line: 1123, col: 5, offset: 34750},
 						run: (*parser).callonSystemEventName4,
 						expr: &litMatcher{
-							pos:        position{line: 1081, col: 5, offset: 32934},
+							pos:        position{line: 1123, col: 5, offset: 34750},
 							val:        "«new»",
 							ignoreCase: false,
 							want:       "\"«new»\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 1083, col: 5, offset: 32973},
+						pos: position{line: 1125, col: 5, offset: 34789},
 						run: (*parser).callonSystemEventName6,
 						expr: &litMatcher{
-							pos:        position{line: 1083, col: 5, offset: 32973},
+							pos:        position{line: 1125, col: 5, offset: 34789},
 							val:        "_destroy",
 							ignoreCase: false,
 							want:       "\"_destroy\"",
 						},
 					},
 					&actionExpr{
-						pos: position{line: 1085, col: 5, offset: 33015},
+						pos: position{line: 1127, col: 5, offset: 34833},
 						run: (*parser).callonSystemEventName8,
 						expr: &litMatcher{
-							pos:        position{line: 1085, col: 5, offset: 33015},
+							pos:        position{line: 1127, col: 5, offset: 34833},
 							val:        "«destroy»",
 							ignoreCase: false,
 							want:       "\"«destroy»\"",
@@ -4519,15 +4757,15 @@ var g = &grammar{
 		},
 		{
 			name: "IdentifierName",
-			pos:  position{line: 1091, col: 1, offset: 33178},
+			pos:  position{line: 1133, col: 1, offset: 34998},
 			expr: &actionExpr{
-				pos: position{line: 1091, col: 19, offset: 33196},
+				pos: position{line: 1133, col: 19, offset: 35016},
 				run: (*parser).callonIdentifierName1,
 				expr: &seqExpr{
-					pos: position{line: 1091, col: 19, offset: 33196},
+					pos: position{line: 1133, col: 19, offset: 35016},
 					exprs: []any{
 						&charClassMatcher{
-							pos:        position{line: 1091, col: 19, offset: 33196},
+							pos:        position{line: 1133, col: 19, offset: 35016},
 							val:        "[a-zA-Z_]",
 							chars:      []rune{'_'},
 							ranges:     []rune{'a', 'z', 'A', 'Z'},
@@ -4535,9 +4773,9 @@ var g = &grammar{
 							inverted:   false,
 						},
 						&zeroOrMoreExpr{
-							pos: position{line: 1091, col: 28, offset: 33205},
+							pos: position{line: 1133, col: 28, offset: 35025},
 							expr: &charClassMatcher{
-								pos:        position{line: 1091, col: 28, offset: 33205},
+								pos:        position{line: 1133, col: 28, offset: 35025},
 								val:        "[a-zA-Z0-9_]",
 								chars:      []rune{'_'},
 								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4551,75 +4789,75 @@ var g = &grammar{
 		},
 		{
 			name: "ReservedKeyword",
-			pos:  position{line: 1097, col: 1, offset: 33385},
+			pos:  position{line: 1139, col: 1, offset: 35205},
 			expr: &seqExpr{
-				pos: position{line: 1097, col: 20, offset: 33404},
+				pos: position{line: 1139, col: 20, offset: 35224},
 				exprs: []any{
 					&choiceExpr{
-						pos: position{line: 1097, col: 22, offset: 33406},
+						pos: position{line: 1139, col: 22, offset: 35226},
 						alternatives: []any{
 							&litMatcher{
-								pos:        position{line: 1097, col: 22, offset: 33406},
+								pos:        position{line: 1139, col: 22, offset: 35226},
 								val:        "TRUE",
 								ignoreCase: false,
 								want:       "\"TRUE\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 31, offset: 33415},
+								pos:        position{line: 1139, col: 31, offset: 35235},
 								val:        "FALSE",
 								ignoreCase: false,
 								want:       "\"FALSE\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 41, offset: 33425},
+								pos:        position{line: 1139, col: 41, offset: 35245},
 								val:        "IF",
 								ignoreCase: false,
 								want:       "\"IF\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 48, offset: 33432},
+								pos:        position{line: 1139, col: 48, offset: 35252},
 								val:        "THEN",
 								ignoreCase: false,
 								want:       "\"THEN\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 57, offset: 33441},
+								pos:        position{line: 1139, col: 57, offset: 35261},
 								val:        "ELSE",
 								ignoreCase: false,
 								want:       "\"ELSE\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 66, offset: 33450},
+								pos:        position{line: 1139, col: 66, offset: 35270},
 								val:        "LET",
 								ignoreCase: false,
 								want:       "\"LET\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 74, offset: 33458},
+								pos:        position{line: 1139, col: 74, offset: 35278},
 								val:        "IN",
 								ignoreCase: false,
 								want:       "\"IN\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 81, offset: 33465},
+								pos:        position{line: 1139, col: 81, offset: 35285},
 								val:        "CHOOSE",
 								ignoreCase: false,
 								want:       "\"CHOOSE\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 92, offset: 33476},
+								pos:        position{line: 1139, col: 92, offset: 35296},
 								val:        "CASE",
 								ignoreCase: false,
 								want:       "\"CASE\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 101, offset: 33485},
+								pos:        position{line: 1139, col: 101, offset: 35305},
 								val:        "OTHER",
 								ignoreCase: false,
 								want:       "\"OTHER\"",
 							},
 							&litMatcher{
-								pos:        position{line: 1097, col: 111, offset: 33495},
+								pos:        position{line: 1139, col: 111, offset: 35315},
 								val:        "EXCEPT",
 								ignoreCase: false,
 								want:       "\"EXCEPT\"",
@@ -4627,9 +4865,9 @@ var g = &grammar{
 						},
 					},
 					&notExpr{
-						pos: position{line: 1097, col: 122, offset: 33506},
+						pos: position{line: 1139, col: 122, offset: 35326},
 						expr: &charClassMatcher{
-							pos:        position{line: 1097, col: 123, offset: 33507},
+							pos:        position{line: 1139, col: 123, offset: 35327},
 							val:        "[a-zA-Z0-9_]",
 							chars:      []rune{'_'},
 							ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4642,20 +4880,20 @@ var g = &grammar{
 		},
 		{
 			name: "Literal",
-			pos:  position{line: 1103, col: 1, offset: 33696},
+			pos:  position{line: 1145, col: 1, offset: 35516},
 			expr: &choiceExpr{
-				pos: position{line: 1103, col: 12, offset: 33707},
+				pos: position{line: 1145, col: 12, offset: 35527},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 1103, col: 12, offset: 33707},
+						pos:  position{line: 1145, col: 12, offset: 35527},
 						name: "BooleanLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1103, col: 29, offset: 33724},
+						pos:  position{line: 1145, col: 29, offset: 35544},
 						name: "NumberLiteral",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1103, col: 45, offset: 33740},
+						pos:  position{line: 1145, col: 45, offset: 35560},
 						name: "StringLiteral",
 					},
 				},
@@ -4663,24 +4901,24 @@ var g = &grammar{
 		},
 		{
 			name: "BooleanLiteral",
-			pos:  position{line: 1106, col: 1, offset: 33833},
+			pos:  position{line: 1148, col: 1, offset: 35653},
 			expr: &actionExpr{
-				pos: position{line: 1106, col: 19, offset: 33851},
+				pos: position{line: 1148, col: 19, offset: 35671},
 				run: (*parser).callonBooleanLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 1106, col: 19, offset: 33851},
+					pos: position{line: 1148, col: 19, offset: 35671},
 					exprs: []any{
 						&choiceExpr{
-							pos: position{line: 1106, col: 20, offset: 33852},
+							pos: position{line: 1148, col: 20, offset: 35672},
 							alternatives: []any{
 								&litMatcher{
-									pos:        position{line: 1106, col: 20, offset: 33852},
+									pos:        position{line: 1148, col: 20, offset: 35672},
 									val:        "TRUE",
 									ignoreCase: false,
 									want:       "\"TRUE\"",
 								},
 								&litMatcher{
-									pos:        position{line: 1106, col: 29, offset: 33861},
+									pos:        position{line: 1148, col: 29, offset: 35681},
 									val:        "FALSE",
 									ignoreCase: false,
 									want:       "\"FALSE\"",
@@ -4688,9 +4926,9 @@ var g = &grammar{
 							},
 						},
 						&notExpr{
-							pos: position{line: 1106, col: 38, offset: 33870},
+							pos: position{line: 1148, col: 38, offset: 35690},
 							expr: &charClassMatcher{
-								pos:        position{line: 1106, col: 39, offset: 33871},
+								pos:        position{line: 1148, col: 39, offset: 35691},
 								val:        "[a-zA-Z0-9_]",
 								chars:      []rune{'_'},
 								ranges:     []rune{'a', 'z', 'A', 'Z', '0', '9'},
@@ -4704,24 +4942,24 @@ var g = &grammar{
 		},
 		{
 			name: "NumberLiteral",
-			pos:  position{line: 1121, col: 1, offset: 34353},
+			pos:  position{line: 1163, col: 1, offset: 36176},
 			expr: &choiceExpr{
-				pos: position{line: 1121, col: 18, offset: 34370},
+				pos: position{line: 1163, col: 18, offset: 36193},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 1121, col: 18, offset: 34370},
+						pos:  position{line: 1163, col: 18, offset: 36193},
 						name: "HexNumber",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1121, col: 30, offset: 34382},
+						pos:  position{line: 1163, col: 30, offset: 36205},
 						name: "OctalNumber",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1121, col: 44, offset: 34396},
+						pos:  position{line: 1163, col: 44, offset: 36219},
 						name: "BinaryNumber",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1121, col: 59, offset: 34411},
+						pos:  position{line: 1163, col: 59, offset: 36234},
 						name: "DecimalNumber",
 					},
 				},
@@ -4729,27 +4967,27 @@ var g = &grammar{
 		},
 		{
 			name: "HexNumber",
-			pos:  position{line: 1124, col: 1, offset: 34481},
+			pos:  position{line: 1166, col: 1, offset: 36304},
 			expr: &actionExpr{
-				pos: position{line: 1124, col: 14, offset: 34494},
+				pos: position{line: 1166, col: 14, offset: 36317},
 				run: (*parser).callonHexNumber1,
 				expr: &seqExpr{
-					pos: position{line: 1124, col: 14, offset: 34494},
+					pos: position{line: 1166, col: 14, offset: 36317},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 1124, col: 14, offset: 34494},
+							pos:   position{line: 1166, col: 14, offset: 36317},
 							label: "prefix",
 							expr: &choiceExpr{
-								pos: position{line: 1124, col: 23, offset: 34503},
+								pos: position{line: 1166, col: 23, offset: 36326},
 								alternatives: []any{
 									&litMatcher{
-										pos:        position{line: 1124, col: 23, offset: 34503},
+										pos:        position{line: 1166, col: 23, offset: 36326},
 										val:        "\\h",
 										ignoreCase: false,
 										want:       "\"\\\\h\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1124, col: 31, offset: 34511},
+										pos:        position{line: 1166, col: 31, offset: 36334},
 										val:        "\\H",
 										ignoreCase: false,
 										want:       "\"\\\\H\"",
@@ -4758,10 +4996,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1124, col: 39, offset: 34519},
+							pos:   position{line: 1166, col: 39, offset: 36342},
 							label: "digits",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1124, col: 46, offset: 34526},
+								pos:  position{line: 1166, col: 46, offset: 36349},
 								name: "HexDigits",
 							},
 						},
@@ -4771,27 +5009,27 @@ var g = &grammar{
 		},
 		{
 			name: "OctalNumber",
-			pos:  position{line: 1129, col: 1, offset: 34671},
+			pos:  position{line: 1171, col: 1, offset: 36497},
 			expr: &actionExpr{
-				pos: position{line: 1129, col: 16, offset: 34686},
+				pos: position{line: 1171, col: 16, offset: 36512},
 				run: (*parser).callonOctalNumber1,
 				expr: &seqExpr{
-					pos: position{line: 1129, col: 16, offset: 34686},
+					pos: position{line: 1171, col: 16, offset: 36512},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 1129, col: 16, offset: 34686},
+							pos:   position{line: 1171, col: 16, offset: 36512},
 							label: "prefix",
 							expr: &choiceExpr{
-								pos: position{line: 1129, col: 25, offset: 34695},
+								pos: position{line: 1171, col: 25, offset: 36521},
 								alternatives: []any{
 									&litMatcher{
-										pos:        position{line: 1129, col: 25, offset: 34695},
+										pos:        position{line: 1171, col: 25, offset: 36521},
 										val:        "\\o",
 										ignoreCase: false,
 										want:       "\"\\\\o\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1129, col: 33, offset: 34703},
+										pos:        position{line: 1171, col: 33, offset: 36529},
 										val:        "\\O",
 										ignoreCase: false,
 										want:       "\"\\\\O\"",
@@ -4800,10 +5038,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1129, col: 41, offset: 34711},
+							pos:   position{line: 1171, col: 41, offset: 36537},
 							label: "digits",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1129, col: 48, offset: 34718},
+								pos:  position{line: 1171, col: 48, offset: 36544},
 								name: "OctalDigits",
 							},
 						},
@@ -4813,27 +5051,27 @@ var g = &grammar{
 		},
 		{
 			name: "BinaryNumber",
-			pos:  position{line: 1134, col: 1, offset: 34869},
+			pos:  position{line: 1176, col: 1, offset: 36698},
 			expr: &actionExpr{
-				pos: position{line: 1134, col: 17, offset: 34885},
+				pos: position{line: 1176, col: 17, offset: 36714},
 				run: (*parser).callonBinaryNumber1,
 				expr: &seqExpr{
-					pos: position{line: 1134, col: 17, offset: 34885},
+					pos: position{line: 1176, col: 17, offset: 36714},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 1134, col: 17, offset: 34885},
+							pos:   position{line: 1176, col: 17, offset: 36714},
 							label: "prefix",
 							expr: &choiceExpr{
-								pos: position{line: 1134, col: 26, offset: 34894},
+								pos: position{line: 1176, col: 26, offset: 36723},
 								alternatives: []any{
 									&litMatcher{
-										pos:        position{line: 1134, col: 26, offset: 34894},
+										pos:        position{line: 1176, col: 26, offset: 36723},
 										val:        "\\b",
 										ignoreCase: false,
 										want:       "\"\\\\b\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1134, col: 34, offset: 34902},
+										pos:        position{line: 1176, col: 34, offset: 36731},
 										val:        "\\B",
 										ignoreCase: false,
 										want:       "\"\\\\B\"",
@@ -4842,10 +5080,10 @@ var g = &grammar{
 							},
 						},
 						&labeledExpr{
-							pos:   position{line: 1134, col: 42, offset: 34910},
+							pos:   position{line: 1176, col: 42, offset: 36739},
 							label: "digits",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1134, col: 49, offset: 34917},
+								pos:  position{line: 1176, col: 49, offset: 36746},
 								name: "BinaryDigits",
 							},
 						},
@@ -4855,16 +5093,16 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalNumber",
-			pos:  position{line: 1140, col: 1, offset: 35122},
+			pos:  position{line: 1182, col: 1, offset: 36954},
 			expr: &choiceExpr{
-				pos: position{line: 1140, col: 18, offset: 35139},
+				pos: position{line: 1182, col: 18, offset: 36971},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 1140, col: 18, offset: 35139},
+						pos:  position{line: 1182, col: 18, offset: 36971},
 						name: "DecimalWithFraction",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1140, col: 40, offset: 35161},
+						pos:  position{line: 1182, col: 40, offset: 36993},
 						name: "DecimalInteger",
 					},
 				},
@@ -4872,35 +5110,35 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalWithFraction",
-			pos:  position{line: 1143, col: 1, offset: 35242},
+			pos:  position{line: 1185, col: 1, offset: 37074},
 			expr: &actionExpr{
-				pos: position{line: 1143, col: 24, offset: 35265},
+				pos: position{line: 1185, col: 24, offset: 37097},
 				run: (*parser).callonDecimalWithFraction1,
 				expr: &seqExpr{
-					pos: position{line: 1143, col: 24, offset: 35265},
+					pos: position{line: 1185, col: 24, offset: 37097},
 					exprs: []any{
 						&labeledExpr{
-							pos:   position{line: 1143, col: 24, offset: 35265},
+							pos:   position{line: 1185, col: 24, offset: 37097},
 							label: "integer",
 							expr: &zeroOrOneExpr{
-								pos: position{line: 1143, col: 32, offset: 35273},
+								pos: position{line: 1185, col: 32, offset: 37105},
 								expr: &ruleRefExpr{
-									pos:  position{line: 1143, col: 32, offset: 35273},
+									pos:  position{line: 1185, col: 32, offset: 37105},
 									name: "DecimalDigits",
 								},
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 1143, col: 47, offset: 35288},
+							pos:        position{line: 1185, col: 47, offset: 37120},
 							val:        ".",
 							ignoreCase: false,
 							want:       "\".\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 1143, col: 51, offset: 35292},
+							pos:   position{line: 1185, col: 51, offset: 37124},
 							label: "fractional",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1143, col: 62, offset: 35303},
+								pos:  position{line: 1185, col: 62, offset: 37135},
 								name: "DecimalDigits",
 							},
 						},
@@ -4910,15 +5148,15 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalInteger",
-			pos:  position{line: 1152, col: 1, offset: 35501},
+			pos:  position{line: 1194, col: 1, offset: 37336},
 			expr: &actionExpr{
-				pos: position{line: 1152, col: 19, offset: 35519},
+				pos: position{line: 1194, col: 19, offset: 37354},
 				run: (*parser).callonDecimalInteger1,
 				expr: &labeledExpr{
-					pos:   position{line: 1152, col: 19, offset: 35519},
+					pos:   position{line: 1194, col: 19, offset: 37354},
 					label: "digits",
 					expr: &ruleRefExpr{
-						pos:  position{line: 1152, col: 26, offset: 35526},
+						pos:  position{line: 1194, col: 26, offset: 37361},
 						name: "DecimalDigits",
 					},
 				},
@@ -4926,14 +5164,14 @@ var g = &grammar{
 		},
 		{
 			name: "DecimalDigits",
-			pos:  position{line: 1161, col: 1, offset: 35825},
+			pos:  position{line: 1203, col: 1, offset: 37663},
 			expr: &actionExpr{
-				pos: position{line: 1161, col: 18, offset: 35842},
+				pos: position{line: 1203, col: 18, offset: 37680},
 				run: (*parser).callonDecimalDigits1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 1161, col: 18, offset: 35842},
+					pos: position{line: 1203, col: 18, offset: 37680},
 					expr: &charClassMatcher{
-						pos:        position{line: 1161, col: 18, offset: 35842},
+						pos:        position{line: 1203, col: 18, offset: 37680},
 						val:        "[0-9]",
 						ranges:     []rune{'0', '9'},
 						ignoreCase: false,
@@ -4944,14 +5182,14 @@ var g = &grammar{
 		},
 		{
 			name: "HexDigits",
-			pos:  position{line: 1166, col: 1, offset: 35927},
+			pos:  position{line: 1208, col: 1, offset: 37765},
 			expr: &actionExpr{
-				pos: position{line: 1166, col: 14, offset: 35940},
+				pos: position{line: 1208, col: 14, offset: 37778},
 				run: (*parser).callonHexDigits1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 1166, col: 14, offset: 35940},
+					pos: position{line: 1208, col: 14, offset: 37778},
 					expr: &charClassMatcher{
-						pos:        position{line: 1166, col: 14, offset: 35940},
+						pos:        position{line: 1208, col: 14, offset: 37778},
 						val:        "[0-9a-fA-F]",
 						ranges:     []rune{'0', '9', 'a', 'f', 'A', 'F'},
 						ignoreCase: false,
@@ -4962,14 +5200,14 @@ var g = &grammar{
 		},
 		{
 			name: "OctalDigits",
-			pos:  position{line: 1171, col: 1, offset: 36027},
+			pos:  position{line: 1213, col: 1, offset: 37865},
 			expr: &actionExpr{
-				pos: position{line: 1171, col: 16, offset: 36042},
+				pos: position{line: 1213, col: 16, offset: 37880},
 				run: (*parser).callonOctalDigits1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 1171, col: 16, offset: 36042},
+					pos: position{line: 1213, col: 16, offset: 37880},
 					expr: &charClassMatcher{
-						pos:        position{line: 1171, col: 16, offset: 36042},
+						pos:        position{line: 1213, col: 16, offset: 37880},
 						val:        "[0-7]",
 						ranges:     []rune{'0', '7'},
 						ignoreCase: false,
@@ -4980,14 +5218,14 @@ var g = &grammar{
 		},
 		{
 			name: "BinaryDigits",
-			pos:  position{line: 1176, col: 1, offset: 36125},
+			pos:  position{line: 1218, col: 1, offset: 37963},
 			expr: &actionExpr{
-				pos: position{line: 1176, col: 17, offset: 36141},
+				pos: position{line: 1218, col: 17, offset: 37979},
 				run: (*parser).callonBinaryDigits1,
 				expr: &oneOrMoreExpr{
-					pos: position{line: 1176, col: 17, offset: 36141},
+					pos: position{line: 1218, col: 17, offset: 37979},
 					expr: &charClassMatcher{
-						pos:        position{line: 1176, col: 17, offset: 36141},
+						pos:        position{line: 1218, col: 17, offset: 37979},
 						val:        "[01]",
 						chars:      []rune{'0', '1'},
 						ignoreCase: false,
@@ -4998,29 +5236,29 @@ var g = &grammar{
 		},
 		{
 			name: "StringLiteral",
-			pos:  position{line: 1186, col: 1, offset: 36439},
+			pos:  position{line: 1228, col: 1, offset: 38277},
 			expr: &actionExpr{
-				pos: position{line: 1186, col: 18, offset: 36456},
+				pos: position{line: 1228, col: 18, offset: 38294},
 				run: (*parser).callonStringLiteral1,
 				expr: &seqExpr{
-					pos: position{line: 1186, col: 18, offset: 36456},
+					pos: position{line: 1228, col: 18, offset: 38294},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 1186, col: 18, offset: 36456},
+							pos:        position{line: 1228, col: 18, offset: 38294},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 1186, col: 22, offset: 36460},
+							pos:   position{line: 1228, col: 22, offset: 38298},
 							label: "content",
 							expr: &ruleRefExpr{
-								pos:  position{line: 1186, col: 30, offset: 36468},
+								pos:  position{line: 1228, col: 30, offset: 38306},
 								name: "StringContent",
 							},
 						},
 						&litMatcher{
-							pos:        position{line: 1186, col: 44, offset: 36482},
+							pos:        position{line: 1228, col: 44, offset: 38320},
 							val:        "\"",
 							ignoreCase: false,
 							want:       "\"\\\"\"",
@@ -5031,17 +5269,17 @@ var g = &grammar{
 		},
 		{
 			name: "StringContent",
-			pos:  position{line: 1191, col: 1, offset: 36602},
+			pos:  position{line: 1233, col: 1, offset: 38443},
 			expr: &actionExpr{
-				pos: position{line: 1191, col: 18, offset: 36619},
+				pos: position{line: 1233, col: 18, offset: 38460},
 				run: (*parser).callonStringContent1,
 				expr: &labeledExpr{
-					pos:   position{line: 1191, col: 18, offset: 36619},
+					pos:   position{line: 1233, col: 18, offset: 38460},
 					label: "chars",
 					expr: &zeroOrMoreExpr{
-						pos: position{line: 1191, col: 24, offset: 36625},
+						pos: position{line: 1233, col: 24, offset: 38466},
 						expr: &ruleRefExpr{
-							pos:  position{line: 1191, col: 24, offset: 36625},
+							pos:  position{line: 1233, col: 24, offset: 38466},
 							name: "StringChar",
 						},
 					},
@@ -5050,16 +5288,16 @@ var g = &grammar{
 		},
 		{
 			name: "StringChar",
-			pos:  position{line: 1203, col: 1, offset: 36871},
+			pos:  position{line: 1245, col: 1, offset: 38712},
 			expr: &choiceExpr{
-				pos: position{line: 1203, col: 15, offset: 36885},
+				pos: position{line: 1245, col: 15, offset: 38726},
 				alternatives: []any{
 					&ruleRefExpr{
-						pos:  position{line: 1203, col: 15, offset: 36885},
+						pos:  position{line: 1245, col: 15, offset: 38726},
 						name: "EscapeSequence",
 					},
 					&ruleRefExpr{
-						pos:  position{line: 1203, col: 32, offset: 36902},
+						pos:  position{line: 1245, col: 32, offset: 38743},
 						name: "NormalChar",
 					},
 				},
@@ -5067,57 +5305,57 @@ var g = &grammar{
 		},
 		{
 			name: "EscapeSequence",
-			pos:  position{line: 1206, col: 1, offset: 36952},
+			pos:  position{line: 1248, col: 1, offset: 38793},
 			expr: &actionExpr{
-				pos: position{line: 1206, col: 19, offset: 36970},
+				pos: position{line: 1248, col: 19, offset: 38811},
 				run: (*parser).callonEscapeSequence1,
 				expr: &seqExpr{
-					pos: position{line: 1206, col: 19, offset: 36970},
+					pos: position{line: 1248, col: 19, offset: 38811},
 					exprs: []any{
 						&litMatcher{
-							pos:        position{line: 1206, col: 19, offset: 36970},
+							pos:        position{line: 1248, col: 19, offset: 38811},
 							val:        "\\",
 							ignoreCase: false,
 							want:       "\"\\\\\"",
 						},
 						&labeledExpr{
-							pos:   position{line: 1206, col: 24, offset: 36975},
+							pos:   position{line: 1248, col: 24, offset: 38816},
 							label: "seq",
 							expr: &choiceExpr{
-								pos: position{line: 1206, col: 30, offset: 36981},
+								pos: position{line: 1248, col: 30, offset: 38822},
 								alternatives: []any{
 									&litMatcher{
-										pos:        position{line: 1206, col: 30, offset: 36981},
+										pos:        position{line: 1248, col: 30, offset: 38822},
 										val:        "\"",
 										ignoreCase: false,
 										want:       "\"\\\"\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1206, col: 36, offset: 36987},
+										pos:        position{line: 1248, col: 36, offset: 38828},
 										val:        "\\",
 										ignoreCase: false,
 										want:       "\"\\\\\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1206, col: 43, offset: 36994},
+										pos:        position{line: 1248, col: 43, offset: 38835},
 										val:        "n",
 										ignoreCase: false,
 										want:       "\"n\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1206, col: 49, offset: 37000},
+										pos:        position{line: 1248, col: 49, offset: 38841},
 										val:        "t",
 										ignoreCase: false,
 										want:       "\"t\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1206, col: 55, offset: 37006},
+										pos:        position{line: 1248, col: 55, offset: 38847},
 										val:        "r",
 										ignoreCase: false,
 										want:       "\"r\"",
 									},
 									&litMatcher{
-										pos:        position{line: 1206, col: 61, offset: 37012},
+										pos:        position{line: 1248, col: 61, offset: 38853},
 										val:        "f",
 										ignoreCase: false,
 										want:       "\"f\"",
@@ -5131,12 +5369,12 @@ var g = &grammar{
 		},
 		{
 			name: "NormalChar",
-			pos:  position{line: 1228, col: 1, offset: 37381},
+			pos:  position{line: 1270, col: 1, offset: 39222},
 			expr: &actionExpr{
-				pos: position{line: 1228, col: 15, offset: 37395},
+				pos: position{line: 1270, col: 15, offset: 39236},
 				run: (*parser).callonNormalChar1,
 				expr: &charClassMatcher{
-					pos:        position{line: 1228, col: 15, offset: 37395},
+					pos:        position{line: 1270, col: 15, offset: 39236},
 					val:        "[^\"\\\\]",
 					chars:      []rune{'"', '\\'},
 					ignoreCase: false,
@@ -5146,11 +5384,11 @@ var g = &grammar{
 		},
 		{
 			name: "ws",
-			pos:  position{line: 1237, col: 1, offset: 37650},
+			pos:  position{line: 1279, col: 1, offset: 39491},
 			expr: &oneOrMoreExpr{
-				pos: position{line: 1237, col: 7, offset: 37656},
+				pos: position{line: 1279, col: 7, offset: 39497},
 				expr: &charClassMatcher{
-					pos:        position{line: 1237, col: 7, offset: 37656},
+					pos:        position{line: 1279, col: 7, offset: 39497},
 					val:        "[ \\t\\n\\r]",
 					chars:      []rune{' ', '\t', '\n', '\r'},
 					ignoreCase: false,
@@ -5177,11 +5415,11 @@ func (c *current) onImpliesExpr1(left, rest any) (any, error) {
 	}
 	parts := rest.([]interface{})
 	right := parts[3].(ast.Expression)
-	return &ast.LogicInfixExpression{
+	return c.node(&ast.LogicInfixExpression{
 		Operator: "⇒",
 		Left:     left.(ast.Expression),
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonImpliesExpr1() (any, error) {
@@ -5203,7 +5441,7 @@ func (c *current) onEquivExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonEquivExpr1() (any, error) {
@@ -5225,7 +5463,7 @@ func (c *current) onOrExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonOrExpr1() (any, error) {
@@ -5247,7 +5485,7 @@ func (c *current) onAndExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonAndExpr1() (any, error) {
@@ -5257,10 +5495,10 @@ func (p *parser) callonAndExpr1() (any, error) {
 }
 
 func (c *current) onNotExpr2(right any) (any, error) {
-	return &ast.LogicPrefixExpression{
+	return c.node(&ast.LogicPrefixExpression{
 		Operator: "¬",
 		Right:    right.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonNotExpr2() (any, error) {
@@ -5270,11 +5508,11 @@ func (p *parser) callonNotExpr2() (any, error) {
 }
 
 func (c *current) onQuantifierExpr2(op, membership, predicate any) (any, error) {
-	return &ast.Quantifier{
+	return c.node(&ast.Quantifier{
 		Quantifier: op.(string),
 		Membership: membership.(ast.Expression),
 		Predicate:  predicate.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonQuantifierExpr2() (any, error) {
@@ -5308,11 +5546,11 @@ func (c *current) onSetMembershipExpr1(left, rest any) (any, error) {
 	parts := rest.([]interface{})
 	op := parts[1].(string)
 	right := parts[3].(ast.Expression)
-	return &ast.Membership{
+	return c.node(&ast.Membership{
 		Operator: op,
 		Left:     left.(ast.Expression),
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonSetMembershipExpr1() (any, error) {
@@ -5346,11 +5584,11 @@ func (c *current) onSetComparisonExpr1(left, rest any) (any, error) {
 	parts := rest.([]interface{})
 	op := parts[1].(string)
 	right := parts[3].(ast.Expression)
-	return &ast.BinarySetComparison{
+	return c.node(&ast.BinarySetComparison{
 		Operator: op,
 		Left:     left.(ast.Expression),
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonSetComparisonExpr1() (any, error) {
@@ -5388,11 +5626,11 @@ func (c *current) onBagComparisonExpr1(left, rest any) (any, error) {
 	parts := rest.([]interface{})
 	op := parts[1].(string)
 	right := parts[3].(ast.Expression)
-	return &ast.BinaryBagComparison{
+	return c.node(&ast.BinaryBagComparison{
 		Operator: op,
 		Left:     left.(ast.Expression),
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonBagComparisonExpr1() (any, error) {
@@ -5430,11 +5668,11 @@ func (c *current) onEqualityExpr1(left, rest any) (any, error) {
 	parts := rest.([]interface{})
 	op := parts[1].(string)
 	right := parts[3].(ast.Expression)
-	return &ast.BinaryEquality{
+	return c.node(&ast.BinaryEquality{
 		Operator: op,
 		Left:     left.(ast.Expression),
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonEqualityExpr1() (any, error) {
@@ -5466,11 +5704,11 @@ func (c *current) onNumericComparisonExpr1(left, rest any) (any, error) {
 	parts := rest.([]interface{})
 	op := parts[1].(string)
 	right := parts[3].(ast.Expression)
-	return &ast.BinaryComparison{
+	return c.node(&ast.BinaryComparison{
 		Operator: op,
 		Left:     left.(ast.Expression),
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonNumericComparisonExpr1() (any, error) {
@@ -5510,7 +5748,7 @@ func (c *current) onSetDifferenceExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonSetDifferenceExpr1() (any, error) {
@@ -5532,7 +5770,7 @@ func (c *current) onSetIntersectionExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonSetIntersectionExpr1() (any, error) {
@@ -5554,7 +5792,7 @@ func (c *current) onSetUnionExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonSetUnionExpr1() (any, error) {
@@ -5569,9 +5807,9 @@ func (c *current) onCartesianProductExpr2(left, rest any) (any, error) {
 		parts := r.([]interface{})
 		operands = append(operands, parts[3].(ast.Expression))
 	}
-	return &ast.CartesianProduct{
+	return c.node(&ast.CartesianProduct{
 		Operands: operands,
-	}, nil
+	})
 }
 
 func (p *parser) callonCartesianProductExpr2() (any, error) {
@@ -5596,10 +5834,10 @@ func (c *current) onSetRangeExpr1(left, rest any) (any, error) {
 	}
 	parts := rest.([]interface{})
 	right := parts[3].(ast.Expression)
-	return &ast.SetRangeExpr{
+	return c.node(&ast.SetRangeExpr{
 		Start: left.(ast.Expression),
 		End:   right,
-	}, nil
+	})
 }
 
 func (p *parser) callonSetRangeExpr1() (any, error) {
@@ -5621,7 +5859,7 @@ func (c *current) onBagSumExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonBagSumExpr1() (any, error) {
@@ -5643,7 +5881,7 @@ func (c *current) onModuloExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonModuloExpr1() (any, error) {
@@ -5665,7 +5903,7 @@ func (c *current) onAdditionExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonAdditionExpr1() (any, error) {
@@ -5687,7 +5925,7 @@ func (c *current) onBagDiffExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonBagDiffExpr1() (any, error) {
@@ -5709,7 +5947,7 @@ func (c *current) onSubtractionExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonSubtractionExpr1() (any, error) {
@@ -5719,7 +5957,7 @@ func (p *parser) callonSubtractionExpr1() (any, error) {
 }
 
 func (c *current) onNegationExpr2(right any) (any, error) {
-	return ast.NewNegation(right.(ast.Expression)), nil
+	return c.node(ast.NewNegation(right.(ast.Expression)))
 }
 
 func (p *parser) callonNegationExpr2() (any, error) {
@@ -5741,7 +5979,7 @@ func (c *current) onDivisionExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonDivisionExpr1() (any, error) {
@@ -5763,7 +6001,7 @@ func (c *current) onConcatExpr1(left, rest any) (any, error) {
 			Operands: operands,
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonConcatExpr1() (any, error) {
@@ -5785,7 +6023,7 @@ func (c *current) onMultiplicationExpr1(left, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonMultiplicationExpr1() (any, error) {
@@ -5803,7 +6041,7 @@ func (c *current) onFractionExpr1(left, rest any) (any, error) {
 			result = ast.NewFractionExpr(result, right)
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonFractionExpr1() (any, error) {
@@ -5813,7 +6051,7 @@ func (p *parser) callonFractionExpr1() (any, error) {
 }
 
 func (c *current) onUnaryExpr2(right any) (any, error) {
-	return ast.NewNegation(right.(ast.Expression)), nil
+	return c.node(ast.NewNegation(right.(ast.Expression)))
 }
 
 func (p *parser) callonUnaryExpr2() (any, error) {
@@ -5828,11 +6066,11 @@ func (c *current) onPowerExpr1(left, rest any) (any, error) {
 	}
 	parts := rest.([]interface{})
 	right := parts[3].(ast.Expression)
-	return &ast.RealInfixExpression{
+	return c.node(&ast.RealInfixExpression{
 		Left:     left.(ast.Expression),
 		Operator: "^",
 		Right:    right,
-	}, nil
+	})
 }
 
 func (p *parser) callonPowerExpr1() (any, error) {
@@ -5845,7 +6083,7 @@ func (c *current) onPrimedExpr1(base, prime any) (any, error) {
 	if prime == nil {
 		return base.(ast.Expression), nil
 	}
-	return &ast.Primed{Base: base.(ast.Expression)}, nil
+	return c.node(&ast.Primed{Base: base.(ast.Expression)})
 }
 
 func (p *parser) callonPrimedExpr1() (any, error) {
@@ -5874,7 +6112,7 @@ func (c *current) onFieldAccessExpr1(base, rest any) (any, error) {
 			}
 		}
 	}
-	return result, nil
+	return c.node(result)
 }
 
 func (p *parser) callonFieldAccessExpr1() (any, error) {
@@ -5904,7 +6142,7 @@ func (p *parser) callonTupleIndexSuffix1() (any, error) {
 }
 
 func (c *current) onParenExpr1(expr any) (any, error) {
-	return ast.NewParenExpr(expr.(ast.Expression)), nil
+	return c.node(ast.NewParenExpr(expr.(ast.Expression)))
 }
 
 func (p *parser) callonParenExpr1() (any, error) {
@@ -5914,11 +6152,11 @@ func (p *parser) callonParenExpr1() (any, error) {
 }
 
 func (c *current) onSetBinding1(left, op, right any) (any, error) {
-	return &ast.Membership{
+	return c.node(&ast.Membership{
 		Operator: op.(string),
 		Left:     left.(ast.Expression),
 		Right:    right.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonSetBinding1() (any, error) {
@@ -5928,10 +6166,10 @@ func (p *parser) callonSetBinding1() (any, error) {
 }
 
 func (c *current) onSetMap1(transform, membership any) (any, error) {
-	return &ast.SetMap{
+	return c.node(&ast.SetMap{
 		Transform:  transform.(ast.Expression),
 		Membership: membership.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonSetMap1() (any, error) {
@@ -5941,10 +6179,10 @@ func (p *parser) callonSetMap1() (any, error) {
 }
 
 func (c *current) onSetFilter1(membership, predicate any) (any, error) {
-	return &ast.SetFilter{
+	return c.node(&ast.SetFilter{
 		Membership: membership.(ast.Expression),
 		Predicate:  predicate.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonSetFilter1() (any, error) {
@@ -5955,9 +6193,9 @@ func (p *parser) callonSetFilter1() (any, error) {
 
 func (c *current) onSetLiteral1(elems any) (any, error) {
 	if elems == nil {
-		return &ast.SetLiteral{Elements: []ast.Expression{}}, nil
+		return c.node(&ast.SetLiteral{Elements: []ast.Expression{}})
 	}
-	return &ast.SetLiteral{Elements: elems.([]ast.Expression)}, nil
+	return c.node(&ast.SetLiteral{Elements: elems.([]ast.Expression)})
 }
 
 func (p *parser) callonSetLiteral1() (any, error) {
@@ -5985,9 +6223,9 @@ func (p *parser) callonSetElements1() (any, error) {
 
 func (c *current) onTupleLiteral1(elems any) (any, error) {
 	if elems == nil {
-		return &ast.TupleLiteral{Elements: []ast.Expression{}}, nil
+		return c.node(&ast.TupleLiteral{Elements: []ast.Expression{}})
 	}
-	return &ast.TupleLiteral{Elements: elems.([]ast.Expression)}, nil
+	return c.node(&ast.TupleLiteral{Elements: elems.([]ast.Expression)})
 }
 
 func (p *parser) callonTupleLiteral1() (any, error) {
@@ -6015,10 +6253,10 @@ func (p *parser) callonTupleElements1() (any, error) {
 
 func (c *current) onRecordAltered1(base, alts any) (any, error) {
 	alterations := alts.([]*ast.FieldAlteration)
-	return &ast.RecordAltered{
+	return c.node(&ast.RecordAltered{
 		Base:        base.(ast.Expression),
 		Alterations: alterations,
-	}, nil
+	})
 }
 
 func (p *parser) callonRecordAltered1() (any, error) {
@@ -6045,12 +6283,12 @@ func (p *parser) callonFieldAlterations1() (any, error) {
 }
 
 func (c *current) onFieldAlteration1(field, expr any) (any, error) {
-	return &ast.FieldAlteration{
+	return c.node(&ast.FieldAlteration{
 		Field: &ast.FieldAccess{
 			Member: field.(string),
 		},
 		Expression: expr.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonFieldAlteration1() (any, error) {
@@ -6060,9 +6298,9 @@ func (p *parser) callonFieldAlteration1() (any, error) {
 }
 
 func (c *current) onRecordTypeExpr1(fields any) (any, error) {
-	return &ast.RecordTypeExpr{
+	return c.node(&ast.RecordTypeExpr{
 		Fields: fields.([]*ast.RecordTypeField),
-	}, nil
+	})
 }
 
 func (p *parser) callonRecordTypeExpr1() (any, error) {
@@ -6089,10 +6327,10 @@ func (p *parser) callonRecordTypeFields1() (any, error) {
 }
 
 func (c *current) onRecordTypeFieldBinding1(name, typeExpr any) (any, error) {
-	return &ast.RecordTypeField{
+	return c.node(&ast.RecordTypeField{
 		Name: &ast.Identifier{Value: name.(string)},
 		Type: typeExpr.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonRecordTypeFieldBinding1() (any, error) {
@@ -6102,9 +6340,9 @@ func (p *parser) callonRecordTypeFieldBinding1() (any, error) {
 }
 
 func (c *current) onRecordInstance1(bindings any) (any, error) {
-	return &ast.RecordInstance{
+	return c.node(&ast.RecordInstance{
 		Bindings: bindings.([]*ast.FieldBinding),
-	}, nil
+	})
 }
 
 func (p *parser) callonRecordInstance1() (any, error) {
@@ -6131,10 +6369,10 @@ func (p *parser) callonFieldBindings1() (any, error) {
 }
 
 func (c *current) onFieldBinding1(field, expr any) (any, error) {
-	return &ast.FieldBinding{
+	return c.node(&ast.FieldBinding{
 		Field:      &ast.Identifier{Value: field.(string)},
 		Expression: expr.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonFieldBinding1() (any, error) {
@@ -6144,11 +6382,11 @@ func (p *parser) callonFieldBinding1() (any, error) {
 }
 
 func (c *current) onIfThenElse1(cond, then, else_ any) (any, error) {
-	return &ast.IfThenElse{
+	return c.node(&ast.IfThenElse{
 		Condition: cond.(ast.Expression),
 		Then:      then.(ast.Expression),
 		Else:      else_.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonIfThenElse1() (any, error) {
@@ -6158,11 +6396,11 @@ func (p *parser) callonIfThenElse1() (any, error) {
 }
 
 func (c *current) onLetExpr1(name, value, body any) (any, error) {
-	return &ast.LetExpr{
+	return c.node(&ast.LetExpr{
 		Variable: name.(string),
 		Value:    value.(ast.Expression),
 		Body:     body.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonLetExpr1() (any, error) {
@@ -6171,11 +6409,26 @@ func (p *parser) callonLetExpr1() (any, error) {
 	return p.cur.onLetExpr1(stack["name"], stack["value"], stack["body"])
 }
 
+func (c *current) onLetFunction1(name, membership, value, body any) (any, error) {
+	return c.node(&ast.LetFunction{
+		Name:       name.(string),
+		Membership: membership.(ast.Expression),
+		Value:      value.(ast.Expression),
+		Body:       body.(ast.Expression),
+	})
+}
+
+func (p *parser) callonLetFunction1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onLetFunction1(stack["name"], stack["membership"], stack["value"], stack["body"])
+}
+
 func (c *current) onChooseExpr1(membership, predicate any) (any, error) {
-	return &ast.ChooseExpr{
+	return c.node(&ast.ChooseExpr{
 		Membership: membership.(ast.Expression),
 		Predicate:  predicate.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonChooseExpr1() (any, error) {
@@ -6191,7 +6444,7 @@ func (c *current) onCaseExpr1(branches, other any) (any, error) {
 	if other != nil {
 		caseExpr.Other = other.(ast.Expression)
 	}
-	return caseExpr, nil
+	return c.node(caseExpr)
 }
 
 func (p *parser) callonCaseExpr1() (any, error) {
@@ -6218,10 +6471,10 @@ func (p *parser) callonCaseBranches1() (any, error) {
 }
 
 func (c *current) onCaseBranch1(cond, result any) (any, error) {
-	return &ast.CaseBranch{
+	return c.node(&ast.CaseBranch{
 		Condition: cond.(ast.Expression),
 		Result:    result.(ast.Expression),
-	}, nil
+	})
 }
 
 func (p *parser) callonCaseBranch1() (any, error) {
@@ -6271,11 +6524,11 @@ func (c *current) onFunctionCall1(scopePath, funcName, args any) (any, error) {
 	} else {
 		argList = []ast.Expression{}
 	}
-	return &ast.FunctionCall{
+	return c.node(&ast.FunctionCall{
 		ScopePath: scopeIdents,
 		Name:      &ast.Identifier{Value: funcName.(string)},
 		Args:      argList,
-	}, nil
+	})
 }
 
 func (p *parser) callonFunctionCall1() (any, error) {
@@ -6284,6 +6537,30 @@ func (p *parser) callonFunctionCall1() (any, error) {
 	return p.cur.onFunctionCall1(stack["scopePath"], stack["funcName"], stack["args"])
 }
 
+func (c *current) onModuleConstant1(module, name any) (any, error) {
+	return c.node(&ast.FunctionCall{
+		ScopePath: []*ast.Identifier{{Value: module.(string)}},
+		Name:      &ast.Identifier{Value: name.(string)},
+		Args:      []ast.Expression{},
+	})
+}
+
+func (p *parser) callonModuleConstant1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onModuleConstant1(stack["module"], stack["name"])
+}
+
+func (c *current) onModuleName1() (any, error) {
+	return string(c.text), nil
+}
+
+func (p *parser) callonModuleName1() (any, error) {
+	stack := p.vstack[len(p.vstack)-1]
+	_ = stack
+	return p.cur.onModuleName1()
+}
+
 func (c *current) onScopePath1(segments any) (any, error) {
 	if segments == nil {
 		return []*ast.Identifier{}, nil
@@ -6321,7 +6598,7 @@ func (p *parser) callonFunctionArgs1() (any, error) {
 }
 
 func (c *current) onExistingValue1() (any, error) {
-	return &ast.ExistingValue{}, nil
+	return c.node(&ast.ExistingValue{})
 }
 
 func (p *parser) callonExistingValue1() (any, error) {
@@ -6331,7 +6608,7 @@ func (p *parser) callonExistingValue1() (any, error) {
 }
 
 func (c *current) onIdentifier1(name any) (any, error) {
-	return &ast.Identifier{Value: name.(string)}, nil
+	return c.node(&ast.Identifier{Value: name.(string)})
 }
 
 func (p *parser) callonIdentifier1() (any, error) {
@@ -6392,7 +6669,7 @@ func (p *parser) callonIdentifierName1() (any, error) {
 
 func (c *current) onBooleanLiteral1() (any, error) {
 	value := string(c.text) == "TRUE"
-	return &ast.BooleanLiteral{Value: value}, nil
+	return c.node(&ast.BooleanLiteral{Value: value})
 }
 
 func (p *parser) callonBooleanLiteral1() (any, error) {
@@ -6402,7 +6679,7 @@ func (p *parser) callonBooleanLiteral1() (any, error) {
 }
 
 func (c *current) onHexNumber1(prefix, digits any) (any, error) {
-	return ast.NewHexNumberLiteral(string(prefix.([]byte)), digits.(string)), nil
+	return c.node(ast.NewHexNumberLiteral(string(prefix.([]byte)), digits.(string)))
 }
 
 func (p *parser) callonHexNumber1() (any, error) {
@@ -6412,7 +6689,7 @@ func (p *parser) callonHexNumber1() (any, error) {
 }
 
 func (c *current) onOctalNumber1(prefix, digits any) (any, error) {
-	return ast.NewOctalNumberLiteral(string(prefix.([]byte)), digits.(string)), nil
+	return c.node(ast.NewOctalNumberLiteral(string(prefix.([]byte)), digits.(string)))
 }
 
 func (p *parser) callonOctalNumber1() (any, error) {
@@ -6422,7 +6699,7 @@ func (p *parser) callonOctalNumber1() (any, error) {
 }
 
 func (c *current) onBinaryNumber1(prefix, digits any) (any, error) {
-	return ast.NewBinaryNumberLiteral(string(prefix.([]byte)), digits.(string)), nil
+	return c.node(ast.NewBinaryNumberLiteral(string(prefix.([]byte)), digits.(string)))
 }
 
 func (p *parser) callonBinaryNumber1() (any, error) {
@@ -6436,7 +6713,7 @@ func (c *current) onDecimalWithFraction1(integer, fractional any) (any, error) {
 	if integer != nil {
 		intPart = integer.(string)
 	}
-	return ast.NewDecimalNumberLiteral(intPart, fractional.(string)), nil
+	return c.node(ast.NewDecimalNumberLiteral(intPart, fractional.(string)))
 }
 
 func (p *parser) callonDecimalWithFraction1() (any, error) {
@@ -6446,7 +6723,7 @@ func (p *parser) callonDecimalWithFraction1() (any, error) {
 }
 
 func (c *current) onDecimalInteger1(digits any) (any, error) {
-	return ast.NewNumberLiteral(digits.(string)), nil
+	return c.node(ast.NewNumberLiteral(digits.(string)))
 }
 
 func (p *parser) callonDecimalInteger1() (any, error) {
@@ -6496,7 +6773,7 @@ func (p *parser) callonBinaryDigits1() (any, error) {
 }
 
 func (c *current) onStringLiteral1(content any) (any, error) {
-	return &ast.StringLiteral{Value: content.(string)}, nil
+	return c.node(&ast.StringLiteral{Value: content.(string)})
 }
 
 func (p *parser) callonStringLiteral1() (any, error) {
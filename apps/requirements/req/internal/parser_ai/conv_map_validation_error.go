@@ -192,6 +192,11 @@ var coreToParserCode = map[coreerr.Code]int{
 	coreerr.NsetSpecInvalid:            ErrConvLogicSpecInvalid,
 	coreerr.NsetTypespecInvalid:        ErrConvLogicSpecInvalid,
 
+	// Global function recursion.
+	coreerr.ModelGfuncRecursionUndeclared:     ErrConvLogicSpecInvalid,
+	coreerr.ModelGfuncRecursionMutual:         ErrConvLogicSpecInvalid,
+	coreerr.ModelGfuncRecursionNotWellFounded: ErrConvLogicSpecInvalid,
+
 	// Internal key errors — should not normally occur if converter works correctly.
 	coreerr.KeyTypeInvalid:            ErrConvInternalKeyError,
 	coreerr.KeySubkeyRequired:         ErrConvInternalKeyError,
@@ -437,6 +442,10 @@ var coreToParserCode = map[coreerr.Code]int{
 	coreerr.ExprModuleRequired:        ErrConvLogicSpecInvalid,
 	coreerr.ExprFunctionRequired:      ErrConvLogicSpecInvalid,
 	coreerr.ExprSetkeyInvalid:         ErrConvLogicSpecInvalid,
+	coreerr.ExprBuiltinArgType:        ErrConvLogicSpecInvalid,
+
+	// Expression recursion errors.
+	coreerr.ExprRecursionNotWellFounded: ErrConvLogicSpecInvalid,
 
 	// Expression type validation errors — internal to TLA+ type system.
 	coreerr.ExprtypeEnumValuesRequired:      ErrConvLogicSpecInvalid,
@@ -93,10 +93,14 @@ func (s *MapValidationErrorSuite) TestMappedCoreCodeWithPath() {
 		{"gfunc logic invalid", coreerr.GfuncLogicInvalid, ErrConvLogicSpecInvalid},
 		{"guard logic invalid", coreerr.GuardLogicInvalid, ErrConvLogicSpecInvalid},
 		{"nset spec invalid", coreerr.NsetSpecInvalid, ErrConvLogicSpecInvalid},
+		{"gfunc recursion undeclared", coreerr.ModelGfuncRecursionUndeclared, ErrConvLogicSpecInvalid},
+		{"gfunc recursion not well founded", coreerr.ModelGfuncRecursionNotWellFounded, ErrConvLogicSpecInvalid},
 
 		// Expression AST errors.
 		{"expr op invalid", coreerr.ExprOpInvalid, ErrConvLogicSpecInvalid},
 		{"expr left required", coreerr.ExprLeftRequired, ErrConvLogicSpecInvalid},
+		{"expr builtin arg type", coreerr.ExprBuiltinArgType, ErrConvLogicSpecInvalid},
+		{"expr recursion not well founded", coreerr.ExprRecursionNotWellFounded, ErrConvLogicSpecInvalid},
 
 		// Expression type errors.
 		{"exprtype enum values required", coreerr.ExprtypeEnumValuesRequired, ErrConvLogicSpecInvalid},
@@ -146,6 +146,7 @@ func convertGlobalFunctionFromModel(gf *model_logic.GlobalFunction) *inputGlobal
 	return &inputGlobalFunction{
 		Name:       gf.Name,
 		Parameters: gf.Parameters,
+		Recursive:  gf.Recursive,
 		Logic:      convertLogicFromModel(&gf.Logic),
 	}
 }
@@ -196,7 +196,7 @@ func convertGlobalFunctionToModel(keyStr string, gf *inputGlobalFunction) (model
 		return model_logic.GlobalFunction{}, convErr(ErrConvModelValidation, fmt.Sprintf("failed to convert global function logic: %s", err.Error()), gfFile)
 	}
 
-	result := model_logic.NewGlobalFunction(key, gf.Name, gf.Parameters, logic)
+	result := model_logic.NewGlobalFunction(key, gf.Name, gf.Parameters, gf.Recursive, logic)
 	return result, nil
 }
 
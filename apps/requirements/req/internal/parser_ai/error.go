@@ -36,6 +36,8 @@ type ParseError struct {
 	ErrorDetail string                // Content of the error markdown file (used by VerboseError and --explain)
 	File        string                // The JSON file being parsed where the error occurred
 	Field       string                // JSON path to the field that caused the error (optional), e.g., "name", "attributes.myAttr.name"
+	Line        int                   // 1-based line of the field in File, when known
+	Column      int                   // 1-based column of the field in File, when known
 	Hint        string                // Concise remediation hint (1-3 lines)
 	Context     *CoreValidationDetail // Structured core validation detail (nil for non-core errors).
 }
@@ -47,6 +49,9 @@ func (e *ParseError) Error() string {
 
 	fmt.Fprintf(&b, "E%d: %s", e.Code, e.Message)
 	fmt.Fprintf(&b, "\n  file: %s", e.File)
+	if e.Line > 0 {
+		fmt.Fprintf(&b, ":%d:%d", e.Line, e.Column)
+	}
 	if e.Field != "" {
 		fmt.Fprintf(&b, "\n  field: %s", e.Field)
 	}
@@ -12,9 +12,11 @@ import (
 // inputGlobalFunction represents a global function/definition in JSON.
 // Global functions are referenced from expressions throughout the model.
 // Names must start with underscore (e.g., _Max, _SetOfValues).
+// Recursive marks a definition that calls itself (TLA+ RECURSIVE).
 type inputGlobalFunction struct {
 	Name       string     `json:"name"`
 	Parameters []string   `json:"parameters,omitempty"`
+	Recursive  bool       `json:"recursive,omitempty"`
 	Logic      inputLogic `json:"logic"`
 }
 
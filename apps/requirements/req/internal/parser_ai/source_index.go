@@ -0,0 +1,118 @@
+package parser_ai
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
+)
+
+// SourceIndex indexes the JSON files of a model tree so diagnostics can be given file,
+// line, and column positions. Paths are joined onto modelDir, as in ParseError.File,
+// and each class directory is the scope of its class key. Files that are not valid
+// JSON are skipped; ReadModel reports those.
+func SourceIndex(modelDir string) (*sourcepos.Index, error) {
+	idx := sourcepos.NewIndex()
+	err := filepath.WalkDir(modelDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			if classKey, ok := classDirKey(modelDir, path); ok {
+				idx.SetScope(classKey.String(), path)
+			}
+			return nil
+		}
+		if filepath.Ext(path) != ".json" {
+			return nil
+		}
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		_ = idx.AddJSON(path, string(content))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return idx, nil
+}
+
+// classDirKey returns the class key for a domains/{d}/subdomains/{s}/classes/{c} directory.
+func classDirKey(modelDir, dir string) (identity.Key, bool) {
+	rel, err := filepath.Rel(modelDir, dir)
+	if err != nil {
+		return identity.Key{}, false
+	}
+	parts := strings.Split(rel, string(filepath.Separator))
+	if len(parts) != 6 || parts[0] != "domains" || parts[2] != "subdomains" || parts[4] != "classes" {
+		return identity.Key{}, false
+	}
+	domainKey, err := identity.NewDomainKey(parts[1])
+	if err != nil {
+		return identity.Key{}, false
+	}
+	subdomainKey, err := identity.NewSubdomainKey(domainKey, parts[3])
+	if err != nil {
+		return identity.Key{}, false
+	}
+	classKey, err := identity.NewClassKey(subdomainKey, parts[5])
+	if err != nil {
+		return identity.Key{}, false
+	}
+	return classKey, true
+}
+
+// _schemaPointer finds the JSON pointer of the failing value in a schema validation message.
+var _schemaPointer = regexp.MustCompile(`jsonschema: '([^']*)' does not validate`)
+
+// locateParseErrors fills in the line and column of every ParseError in err from
+// the position of its field in its file. A schema violation without a field is
+// located at the value its message points at.
+func locateParseErrors(err error, sources *sourcepos.Index) {
+	switch wrapped := err.(type) {
+	case *ParseError:
+		field := wrapped.Field
+		if field == "" {
+			field = schemaField(wrapped.Message)
+		}
+		pos := sources.LocateField(wrapped.File, field)
+		wrapped.Line, wrapped.Column = pos.Line, pos.Column
+	case interface{ Unwrap() []error }:
+		for _, inner := range wrapped.Unwrap() {
+			locateParseErrors(inner, sources)
+		}
+	case interface{ Unwrap() error }:
+		if inner := wrapped.Unwrap(); inner != nil {
+			locateParseErrors(inner, sources)
+		}
+	}
+}
+
+// schemaField converts the JSON pointer in a schema validation message, such as
+// "/states/open/actions/0", to a field path such as "states.open.actions[0]".
+func schemaField(message string) string {
+	match := _schemaPointer.FindStringSubmatch(message)
+	if match == nil || match[1] == "" {
+		return ""
+	}
+	var b strings.Builder
+	for _, segment := range strings.Split(strings.TrimPrefix(match[1], "/"), "/") {
+		segment = strings.NewReplacer("~1", "/", "~0", "~").Replace(segment)
+		if _, err := strconv.Atoi(segment); err == nil {
+			b.WriteString("[" + segment + "]")
+			continue
+		}
+		if b.Len() > 0 {
+			b.WriteString(".")
+		}
+		b.WriteString(segment)
+	}
+	return b.String()
+}
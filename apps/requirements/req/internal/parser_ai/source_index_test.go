@@ -0,0 +1,66 @@
+package parser_ai
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceIndexScopesClassDirectories(t *testing.T) {
+	t.Parallel()
+	modelDir := t.TempDir()
+	for _, class := range []string{"order", "invoice"} {
+		classDir := filepath.Join(modelDir, "domains", "sales", "subdomains", "default", "classes", class)
+		require.NoError(t, os.MkdirAll(classDir, 0755))
+		require.NoError(t, os.WriteFile(filepath.Join(classDir, "class.json"), []byte("{\n  \"invariants\": [{\"specification\": \"self.total >= 0\"}]\n}"), 0600))
+	}
+	require.NoError(t, os.WriteFile(filepath.Join(modelDir, "broken.json"), []byte(`{"name":`), 0600))
+
+	sources, err := SourceIndex(modelDir)
+	require.NoError(t, err)
+
+	subdomainKey := helper.Must(identity.NewSubdomainKey(helper.Must(identity.NewDomainKey("sales")), "default"))
+	invoiceKey := helper.Must(identity.NewClassKey(subdomainKey, "invoice"))
+	invoiceFile := filepath.Join(modelDir, "domains", "sales", "subdomains", "default", "classes", "invoice", "class.json")
+	require.Equal(t, sourcepos.Position{File: invoiceFile, Line: 2, Column: 42}, sources.Locate(invoiceKey.String(), "self.total >= 0", 5))
+}
+
+func TestReadModelLocatesParseErrors(t *testing.T) {
+	t.Parallel()
+	modelDir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(modelDir, "model.json"), []byte("{\n  \"name\": \"\"\n}"), 0600))
+
+	_, err := ReadModel(modelDir)
+	require.Error(t, err)
+	var pe *ParseError
+	require.ErrorAs(t, err, &pe)
+	require.Equal(t, 2, pe.Line)
+	require.Equal(t, 11, pe.Column)
+	require.Contains(t, pe.Error(), "model.json:2:11")
+}
+
+func TestSchemaField(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		message  string
+		expected string
+	}{
+		{name: "top level", message: "model JSON does not match schema: jsonschema: '/name' does not validate with x", expected: "name"},
+		{name: "nested", message: "jsonschema: '/states/open/actions/0/when' does not validate with x", expected: "states.open.actions[0].when"},
+		{name: "escaped", message: "jsonschema: '/a~1b/c~0d' does not validate with x", expected: "a/b.c~d"},
+		{name: "root", message: "jsonschema: '' does not validate with x", expected: ""},
+		{name: "not a schema error", message: "name is required", expected: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			require.Equal(t, tt.expected, schemaField(tt.message))
+		})
+	}
+}
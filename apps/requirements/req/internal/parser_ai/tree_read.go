@@ -21,6 +21,9 @@ func ReadModel(inputModelPath string) (core.Model, error) {
 		if !errors.As(err, &parseErr) {
 			return core.Model{}, fmt.Errorf("STOP AND REPORT THIS ERROR to the user. This is an unexpected internal error that cannot be fixed by changing input files: %w", err)
 		}
+		if sources, indexErr := SourceIndex(inputModelPath); indexErr == nil {
+			locateParseErrors(err, sources)
+		}
 		return core.Model{}, err
 	}
 	return model, nil
@@ -44,6 +44,11 @@ func parseClass(subdomainKey identity.Key, classSubKey, filename, contents strin
 		return model_class.Class{}, nil, errors.WithStack(err)
 	}
 
+	notation, err := parseNotation(yamlData)
+	if err != nil {
+		return model_class.Class{}, nil, err
+	}
+
 	// Parse optional key references from YAML.
 	actorKey, err := parseClassActorKey(yamlData)
 	if err != nil {
@@ -71,6 +76,7 @@ func parseClass(subdomainKey identity.Key, classSubKey, filename, contents strin
 	if err != nil {
 		return model_class.Class{}, nil, err
 	}
+	forEachClassExpressionSpec(&class, setNotation(notation))
 
 	return class, associations, nil
 }
@@ -1403,8 +1409,9 @@ func buildClassLookups(class model_class.Class) classLookups {
 	return lookups
 }
 
-// generateClassTopLevelFields adds optional top-level key fields (actor_key, superclass_of_key, subclass_of_key).
+// generateClassTopLevelFields adds optional top-level fields (notation, actor_key, superclass_of_key, subclass_of_key).
 func generateClassTopLevelFields(builder *YamlBuilder, class model_class.Class) {
+	addNotationField(builder, func(fn func(*logic_spec.ExpressionSpec)) { forEachClassExpressionSpec(&class, fn) })
 	if class.ActorKey != nil {
 		builder.AddField("actor_key", class.ActorKey.SubKey)
 	}
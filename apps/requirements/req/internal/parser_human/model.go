@@ -19,7 +19,7 @@ func parseModel(key, filename, contents string) (model core.Model, err error) {
 		return core.Model{}, err
 	}
 
-	invariants, globalFunctions, namedSets, err := parseModelYamlData(parsedFile.Data)
+	invariants, globalFunctions, namedSets, notation, err := parseModelYamlData(parsedFile.Data)
 	if err != nil {
 		return core.Model{}, err
 	}
@@ -34,19 +34,25 @@ func parseModel(key, filename, contents string) (model core.Model, err error) {
 	model = core.NewModel(strings.TrimSpace(strings.ToLower(key)), core.ModelDetails{
 		Name: parsedFile.Title, Details: markdown,
 	}, parsedFile.UnfinishedNotes, invariants, globalFunctions, namedSets)
+	forEachModelExpressionSpec(&model, setNotation(notation))
 
 	return model, nil
 }
 
-// parseModelYamlData parses invariants, global functions, and named sets from the YAML data section.
-func parseModelYamlData(data string) ([]model_logic.Logic, map[identity.Key]model_logic.GlobalFunction, map[identity.Key]model_logic.NamedSet, error) {
+// parseModelYamlData parses the notation, invariants, global functions, and named sets from the YAML data section.
+func parseModelYamlData(data string) ([]model_logic.Logic, map[identity.Key]model_logic.GlobalFunction, map[identity.Key]model_logic.NamedSet, string, error) {
 	if data == "" {
-		return nil, nil, nil, nil
+		return nil, nil, nil, model_logic.NotationTLAPlus, nil
 	}
 
 	yamlData := map[string]any{}
 	if err := yaml.Unmarshal([]byte(data), &yamlData); err != nil {
-		return nil, nil, nil, errors.WithStack(err)
+		return nil, nil, nil, "", errors.WithStack(err)
+	}
+
+	notation, err := parseNotation(yamlData)
+	if err != nil {
+		return nil, nil, nil, "", err
 	}
 
 	invariantKeyFunc := func(_ identity.Key, subKey string) (identity.Key, error) {
@@ -55,20 +61,20 @@ func parseModelYamlData(data string) ([]model_logic.Logic, map[identity.Key]mode
 	invariants, err := logicListFromYamlData(yamlData, "invariants",
 		model_logic.LogicTypeAssessment, identity.Key{}, invariantKeyFunc, nil)
 	if err != nil {
-		return nil, nil, nil, errors.Wrap(err, "model invariants")
+		return nil, nil, nil, "", errors.Wrap(err, "model invariants")
 	}
 
 	globalFunctions, err := parseGlobalFunctions(yamlData)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, "", err
 	}
 
 	namedSets, err := parseNamedSets(yamlData)
 	if err != nil {
-		return nil, nil, nil, err
+		return nil, nil, nil, "", err
 	}
 
-	return invariants, globalFunctions, namedSets, nil
+	return invariants, globalFunctions, namedSets, notation, nil
 }
 
 // parseGlobalFunctions parses the global_functions list from YAML data.
@@ -119,6 +125,14 @@ func parseOneGlobalFunction(gfMap map[string]any) (model_logic.GlobalFunction, e
 		}
 	}
 
+	recursive := false
+	if r, ok := gfMap["recursive"]; ok {
+		recursive, ok = r.(bool)
+		if !ok {
+			return model_logic.GlobalFunction{}, errors.Errorf("global function recursive must be a boolean")
+		}
+	}
+
 	description := ""
 	if d, ok := gfMap["description"]; ok {
 		description = d.(string)
@@ -135,7 +149,7 @@ func parseOneGlobalFunction(gfMap map[string]any) (model_logic.GlobalFunction, e
 
 	logic := model_logic.NewLogic(gfKey, model_logic.LogicTypeValue, description, "", spec, nil)
 
-	gf := model_logic.NewGlobalFunction(gfKey, name, parameters, logic)
+	gf := model_logic.NewGlobalFunction(gfKey, name, parameters, recursive, logic)
 	return gf, nil
 }
 
@@ -207,6 +221,9 @@ func parseOneNamedSet(nsMap map[string]any) (model_logic.NamedSet, error) {
 func generateModelContent(model core.Model) string {
 	builder := NewYamlBuilder()
 
+	// Generate the notation the logic specifications are written in, if not TLA+.
+	addNotationField(builder, func(fn func(*logic_spec.ExpressionSpec)) { forEachModelExpressionSpec(&model, fn) })
+
 	// Generate invariants YAML.
 	generateLogicSequence(builder, "invariants", model.Invariants)
 
@@ -242,6 +259,7 @@ func generateGlobalFunctionsYaml(builder *YamlBuilder, globalFunctions map[ident
 		if len(gf.Parameters) > 0 {
 			gfBuilder.AddSequenceField("parameters", gf.Parameters)
 		}
+		gfBuilder.AddBoolField("recursive", gf.Recursive)
 		gfBuilder.AddField("description", gf.Logic.Description)
 		gfBuilder.AddQuotedField("specification", gf.Logic.Spec.Specification)
 		gfBuilders = append(gfBuilders, gfBuilder)
@@ -0,0 +1,125 @@
+package parser_human
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+
+	"github.com/pkg/errors"
+)
+
+// parseNotation reads the optional file-level "notation" field naming the notation
+// that every logic specification in the file is written in. Absent means TLA+.
+func parseNotation(yamlData map[string]any) (string, error) {
+	notation, err := yamlString(yamlData, "notation")
+	if err != nil {
+		return "", err
+	}
+	if notation == "" {
+		return model_logic.NotationTLAPlus, nil
+	}
+	if !logic_spec.IsExpressionNotation(notation) {
+		return "", errors.Errorf("notation '%s' is not valid, want one of: %s, %s", notation, model_logic.NotationTLAPlus, model_logic.NotationInfix)
+	}
+	return notation, nil
+}
+
+// addNotationField writes the "notation" field when the specs are not TLA+.
+// Files are written in one notation, so the first spec with a notation decides.
+func addNotationField(builder *YamlBuilder, visit func(func(*logic_spec.ExpressionSpec))) {
+	notation := model_logic.NotationTLAPlus
+	visit(func(spec *logic_spec.ExpressionSpec) {
+		if notation == model_logic.NotationTLAPlus && spec.Notation != "" {
+			notation = spec.Notation
+		}
+	})
+	if notation != model_logic.NotationTLAPlus {
+		builder.AddField("notation", notation)
+	}
+}
+
+// setNotation returns a visitor that sets every parsed spec to the given notation.
+// Unused specs (no notation, such as a logic without a destroy_event) are left alone.
+func setNotation(notation string) func(*logic_spec.ExpressionSpec) {
+	return func(spec *logic_spec.ExpressionSpec) {
+		if spec.Notation != "" {
+			spec.Notation = notation
+		}
+	}
+}
+
+// forEachModelExpressionSpec calls fn for every logic spec in the model file's own
+// sections: invariants, global functions, and named sets.
+func forEachModelExpressionSpec(model *core.Model, fn func(*logic_spec.ExpressionSpec)) {
+	forEachLogicSpec(model.Invariants, fn)
+	for key, gf := range model.GlobalFunctions {
+		forEachLogic(&gf.Logic, fn)
+		model.GlobalFunctions[key] = gf
+	}
+	for key, ns := range model.NamedSets {
+		fn(&ns.Spec)
+		model.NamedSets[key] = ns
+	}
+}
+
+// forEachClassExpressionSpec calls fn for every logic spec in a class file.
+func forEachClassExpressionSpec(class *model_class.Class, fn func(*logic_spec.ExpressionSpec)) {
+	forEachLogicSpec(class.Invariants, fn)
+	for i := range class.Attributes {
+		attr := &class.Attributes[i]
+		if attr.DerivationPolicy != nil {
+			forEachLogic(attr.DerivationPolicy, fn)
+		}
+		forEachLogicSpec(attr.Invariants, fn)
+	}
+	for key, guard := range class.Guards {
+		forEachLogic(&guard.Logic, fn)
+		class.Guards[key] = guard
+	}
+	for key, action := range class.Actions {
+		forEachLogicSpec(action.Requires, fn)
+		forEachLogicSpec(action.Guarantees, fn)
+		forEachLogicSpec(action.SafetyRules, fn)
+		forEachParameterSpec(action.Parameters, fn)
+		class.Actions[key] = action
+	}
+	for key, query := range class.Queries {
+		forEachLogicSpec(query.Requires, fn)
+		forEachLogicSpec(query.Guarantees, fn)
+		forEachParameterSpec(query.Parameters, fn)
+		class.Queries[key] = query
+	}
+}
+
+// forEachParameterSpec calls fn for every parameter invariant and simulation rule spec.
+func forEachParameterSpec(params []model_state.Parameter, fn func(*logic_spec.ExpressionSpec)) {
+	for i := range params {
+		forEachLogicSpec(params[i].Invariants, fn)
+		if params[i].Simulation == nil {
+			continue
+		}
+		for r := range params[i].Simulation.Rules {
+			rule := &params[i].Simulation.Rules[r]
+			forEachLogicSpec(rule.Requires, fn)
+			if rule.Specification != nil {
+				forEachLogic(rule.Specification, fn)
+			}
+		}
+	}
+}
+
+// forEachLogicSpec calls fn for the expression specs of each logic.
+func forEachLogicSpec(logics []model_logic.Logic, fn func(*logic_spec.ExpressionSpec)) {
+	for i := range logics {
+		forEachLogic(&logics[i], fn)
+	}
+}
+
+// forEachLogic calls fn for each of the expression specs a logic carries.
+func forEachLogic(logic *model_logic.Logic, fn func(*logic_spec.ExpressionSpec)) {
+	fn(&logic.Spec)
+	fn(&logic.EndpointSelectorSpec)
+	fn(&logic.DestroyEventSpec)
+}
@@ -0,0 +1,41 @@
+package parser_human
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestNotationSuite(t *testing.T) {
+	suite.Run(t, new(NotationSuite))
+}
+
+type NotationSuite struct {
+	suite.Suite
+}
+
+func (suite *NotationSuite) TestParseNotation() {
+	tests := []struct {
+		testName string
+		yamlData map[string]any
+		expected string
+		errstr   string
+	}{
+		{testName: "absent defaults to tla_plus", yamlData: map[string]any{}, expected: "tla_plus"},
+		{testName: "tla_plus", yamlData: map[string]any{"notation": "tla_plus"}, expected: "tla_plus"},
+		{testName: "infix", yamlData: map[string]any{"notation": "infix"}, expected: "infix"},
+		{testName: "error unknown", yamlData: map[string]any{"notation": "z"}, errstr: "notation 'z' is not valid, want one of: tla_plus, infix"},
+		{testName: "error not a string", yamlData: map[string]any{"notation": 1}, errstr: "field 'notation' must be a quoted string value"},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			notation, err := parseNotation(tt.yamlData)
+			if tt.errstr != "" {
+				suite.Require().ErrorContains(err, tt.errstr)
+				return
+			}
+			suite.Require().NoError(err)
+			suite.Equal(tt.expected, notation)
+		})
+	}
+}
@@ -1,6 +1,9 @@
 package parser_human
 
-import "github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
+)
 
 // ParseFailure records a single .class file that failed to parse.
 //
@@ -9,8 +12,14 @@ import "github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
 // on its domain page. This record carries the error message so the class's own
 // generated page can show it instead of the empty placeholder content.
 type ParseFailure struct {
-	ClassKey identity.Key // Key of the class that failed to parse.
-	Name     string       // Display name of the class.
-	Path     string       // Source file path, relative to the model root.
-	Err      string       // The parse error message.
+	ClassKey identity.Key       // Key of the class that failed to parse.
+	Name     string             // Display name of the class.
+	Path     string             // Source file path, relative to the model root.
+	Err      string             // The parse error message.
+	Position sourcepos.Position // Where in the file the error is, as exactly as it is known.
+}
+
+// Message returns the error message led by its position in the source file.
+func (f ParseFailure) Message() string {
+	return f.Position.Prefix() + f.Err
 }
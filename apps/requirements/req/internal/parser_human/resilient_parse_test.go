@@ -80,6 +80,12 @@ func TestParseIsolatesBrokenClass(t *testing.T) {
 		t.Errorf("failure record incomplete: %+v", failures[0])
 	}
 
+	// The failure points at the corrupted line of the file.
+	brokenLine := strings.Count(string(orig), "\n") + 2
+	if pos := failures[0].Position; pos.File != failures[0].Path || pos.Line != brokenLine || pos.Column != 1 {
+		t.Errorf("expected failure at %s:%d:1, got %s", failures[0].Path, brokenLine, pos)
+	}
+
 	// The model still has the same class count — the broken class is a placeholder.
 	got := 0
 	var placeholderFound bool
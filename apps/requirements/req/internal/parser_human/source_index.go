@@ -0,0 +1,104 @@
+package parser_human
+
+import (
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
+
+	"github.com/pkg/errors"
+)
+
+// _yamlErrorLine finds the data-section line number in a YAML error message.
+var _yamlErrorLine = regexp.MustCompile(`\bline (\d+)\b`)
+
+// SourceIndex indexes the data sections of a model's source files so diagnostics
+// about the specifications in them can be given file, line, and column positions.
+// Paths are relative to the model root, as in ParseFailure.Path, and each class
+// file is the scope of its class key. Files whose data does not parse are skipped;
+// Parse reports those.
+func SourceIndex(modelPath string) (*sourcepos.Index, error) {
+	toParseFiles, err := findFilesToParse(modelPath)
+	if err != nil {
+		return nil, errors.WithStack(err)
+	}
+
+	idx := sourcepos.NewIndex()
+	for _, toParseFile := range toParseFiles {
+		contentBytes, err := os.ReadFile(toParseFile.PathAbs)
+		if err != nil {
+			return nil, errors.WithStack(err)
+		}
+		contents := string(contentBytes)
+
+		offset, ok := dataOffset(contents)
+		if !ok {
+			continue
+		}
+		if err := idx.AddYAML(toParseFile.PathRel, contents, offset); err != nil {
+			continue
+		}
+		if toParseFile.FileType == _EXT_CLASS {
+			if classKey, err := sourceClassKey(toParseFile); err == nil {
+				idx.SetScope(classKey.String(), toParseFile.PathRel)
+			}
+		}
+	}
+	return idx, nil
+}
+
+// sourceClassKey derives the key a class file's class gets from the file's path alone.
+func sourceClassKey(toParseFile fileToParse) (identity.Key, error) {
+	domainKey, err := identity.NewDomainKey(toParseFile.Domain)
+	if err != nil {
+		return identity.Key{}, errors.WithStack(err)
+	}
+	subdomainName := toParseFile.Subdomain
+	if subdomainName == "" {
+		subdomainName = _DEFAULT_SUBDOMAIN_NAME
+	}
+	subdomainKey, err := identity.NewSubdomainKey(domainKey, subdomainName)
+	if err != nil {
+		return identity.Key{}, errors.WithStack(err)
+	}
+	classKey, err := identity.NewClassKey(subdomainKey, classFileSubKey(toParseFile))
+	if err != nil {
+		return identity.Key{}, errors.WithStack(err)
+	}
+	return classKey, nil
+}
+
+// dataOffset returns the byte offset at which a source file's data section starts,
+// skipping the whitespace parseFile trims, or false when the file has no data marker.
+func dataOffset(contents string) (int, bool) {
+	marker := strings.Index(contents, _DATA_MARKER)
+	if marker < 0 {
+		return 0, false
+	}
+	offset := marker + len(_DATA_MARKER)
+	if start := strings.IndexFunc(contents[offset:], func(r rune) bool { return !unicode.IsSpace(r) }); start >= 0 {
+		offset += start
+	}
+	return offset, true
+}
+
+// failurePosition returns where in a source file the problem a parse error reports is.
+// A YAML error names a line of the data section, which is mapped to the file; any other
+// error is placed at the start of the data section, or of the file if it has none.
+func failurePosition(path, contents string, err error) sourcepos.Position {
+	offset, ok := dataOffset(contents)
+	if !ok {
+		return sourcepos.At(path, contents, 0)
+	}
+	if match := _yamlErrorLine.FindStringSubmatch(err.Error()); match != nil {
+		line, _ := strconv.Atoi(match[1])
+		if lineStart, ok := sourcepos.LineStart(contents[offset:], line); ok {
+			offset += lineStart
+		}
+	}
+	return sourcepos.At(path, contents, offset)
+}
@@ -0,0 +1,96 @@
+package parser_human
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/sourcepos"
+)
+
+func TestSourceIndexSuite(t *testing.T) {
+	suite.Run(t, new(SourceIndexSuite))
+}
+
+type SourceIndexSuite struct {
+	suite.Suite
+}
+
+// classSource is a class file whose invariant specification starts on line 9, column 22.
+const classSource = `# Account
+
+Holds money.
+
+◇
+
+invariants:
+    - details: Never overdrawn.
+      specification: self.balance >= 0
+`
+
+func (suite *SourceIndexSuite) TestSourceIndex() {
+	root := suite.T().TempDir()
+	for _, rel := range []string{"orders/classes/account.class", "orders/billing/classes/account.class"} {
+		path := filepath.Join(root, rel)
+		suite.Require().NoError(os.MkdirAll(filepath.Dir(path), 0o755))
+		suite.Require().NoError(os.WriteFile(path, []byte(classSource), 0o644)) //nolint:gosec // test fixture
+	}
+	// A file that does not parse is skipped.
+	suite.Require().NoError(os.WriteFile(filepath.Join(root, "orders", "classes", "broken.class"), []byte("◇\na: [\n"), 0o644)) //nolint:gosec // test fixture
+
+	idx, err := SourceIndex(root)
+	suite.Require().NoError(err)
+
+	domainKey := helper.Must(identity.NewDomainKey("orders"))
+	billingKey := helper.Must(identity.NewClassKey(helper.Must(identity.NewSubdomainKey(domainKey, "billing")), "account"))
+	defaultKey := helper.Must(identity.NewClassKey(helper.Must(identity.NewSubdomainKey(domainKey, _DEFAULT_SUBDOMAIN_NAME)), "account"))
+
+	suite.Equal(sourcepos.Position{File: filepath.Join("orders", "billing", "classes", "account.class"), Line: 9, Column: 35},
+		idx.Locate(billingKey.String(), "self.balance >= 0", 13))
+	suite.Equal(sourcepos.Position{File: filepath.Join("orders", "classes", "account.class"), Line: 9, Column: 22},
+		idx.Locate(defaultKey.String(), "self.balance >= 0", 0))
+}
+
+func (suite *SourceIndexSuite) TestFailurePosition() {
+	tests := []struct {
+		testName string
+		contents string
+		err      string
+		expected sourcepos.Position
+	}{
+		{
+			testName: "yaml line",
+			contents: "# Account\n\n◇\n\nname: x\n  bad: [\n",
+			err:      "yaml: line 2: did not find expected key",
+			expected: sourcepos.Position{File: "a.class", Line: 6, Column: 3},
+		},
+		{
+			testName: "other error at data start",
+			contents: "# Account\n\n◇\n\nname: x\n",
+			err:      "missing attribute",
+			expected: sourcepos.Position{File: "a.class", Line: 5, Column: 1},
+		},
+		{
+			testName: "no data section",
+			contents: "# Account\n",
+			err:      "yaml: line 2: bad",
+			expected: sourcepos.Position{File: "a.class", Line: 1, Column: 1},
+		},
+		{
+			testName: "line past the end",
+			contents: "◇ name: x",
+			err:      "yaml: line 9: bad",
+			expected: sourcepos.Position{File: "a.class", Line: 1, Column: 3},
+		},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			suite.Equal(tt.expected, failurePosition("a.class", tt.contents, errors.New(tt.err)))
+		})
+	}
+}
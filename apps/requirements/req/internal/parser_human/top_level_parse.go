@@ -141,7 +141,7 @@ func parseAllFiles(modelKey string, filesToParse []fileToParse, ctx *parseContex
 		var failure *ParseFailure
 		model, failure, err = parseOneFile(modelKey, &model, ctx, toParseFile, contents)
 		if failure != nil {
-			log.Printf("   parse failure: %s: %s", failure.Path, failure.Err)
+			log.Printf("   parse failure: %s", failure.Message())
 			failures = append(failures, *failure)
 		}
 		if err != nil {
@@ -418,10 +418,7 @@ func parseClassFileResilient(model *core.Model, ctx *parseContext, toParseFile f
 		return nil, err
 	}
 
-	classSubKey := toParseFile.Class
-	if idx := len(toParseFile.Domain) + 1; idx < len(toParseFile.Class) {
-		classSubKey = toParseFile.Class[idx:]
-	}
+	classSubKey := classFileSubKey(toParseFile)
 
 	class, associations, parseErr := safeParseClass(subdomainKey, classSubKey, toParseFile.PathRel, contents)
 	if parseErr != nil {
@@ -437,6 +434,7 @@ func parseClassFileResilient(model *core.Model, ctx *parseContext, toParseFile f
 			Name:     placeholder.Name,
 			Path:     toParseFile.PathRel,
 			Err:      parseErr.Error(),
+			Position: failurePosition(toParseFile.PathRel, contents, parseErr),
 		}, nil
 	}
 
@@ -447,6 +445,14 @@ func parseClassFileResilient(model *core.Model, ctx *parseContext, toParseFile f
 	return nil, nil //nolint:nilnil // success: no resilient-parse failure to report
 }
 
+// classFileSubKey returns the sub key of the class in a class file, without its domain prefix.
+func classFileSubKey(toParseFile fileToParse) string {
+	if idx := len(toParseFile.Domain) + 1; idx < len(toParseFile.Class) {
+		return toParseFile.Class[idx:]
+	}
+	return toParseFile.Class
+}
+
 // addClassToSubdomain inserts a class into its subdomain and writes it back to the model.
 func addClassToSubdomain(model *core.Model, domainKey, subdomainKey identity.Key, domain model_domain.Domain, subdomain model_domain.Subdomain, class model_class.Class) {
 	if subdomain.Classes == nil {
@@ -198,6 +198,10 @@ func controlFlowExpressionChildren(expr me.Expression) []me.Expression {
 		return []me.Expression{node.Condition, node.Then, node.Else}
 	case *me.LetExpr:
 		return []me.Expression{node.Value, node.Body}
+	case *me.FunctionDef:
+		return []me.Expression{node.Domain, node.Value, node.Body}
+	case *me.FunctionApply:
+		return []me.Expression{node.Arg}
 	case *me.Choose:
 		return []me.Expression{node.Set, node.Predicate}
 	case *me.Quantifier:
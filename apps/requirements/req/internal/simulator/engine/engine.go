@@ -76,6 +76,9 @@ type SimulationEngine struct {
 
 	// scopeEntries summarize which classes/subdomains participate (include-list scope).
 	scopeEntries []surface.ScopeEntry
+
+	// firedSteps counts the steps run through FireEvent, for their step numbers.
+	firedSteps int
 }
 
 // NewSimulationEngine creates and wires up all simulation components.
@@ -578,6 +581,31 @@ func (e *SimulationEngine) Run() (*SimulationResult, error) {
 	return result, nil
 }
 
+// FireEvent runs one chosen event on an instance outside the random selection loop,
+// creating an instance when instance is nil. The event parameters are sampled as in
+// Run, and the step's class and attribute invariants are checked. Test case generation
+// uses it to replay a chosen event sequence.
+func (e *SimulationEngine) FireEvent(classKey, eventKey identity.Key, instance *state.ClassInstance) (*SimulationStep, error) {
+	classInfo := e.catalog.GetClassInfo(classKey)
+	if classInfo == nil {
+		return nil, fmt.Errorf("class %s is not simulated", classKey.String())
+	}
+	event, ok := classInfo.Class.Events[eventKey]
+	if !ok {
+		return nil, fmt.Errorf("event %s not found in class %s", eventKey.String(), classInfo.Class.Name)
+	}
+
+	e.firedSteps++
+	pending := &PendingAction{Class: classInfo, Event: &event, Instance: instance, IsCreation: instance == nil}
+	stepResult, err := e.stepExecutor.Execute(pending, e.simState, e.firedSteps)
+	if err != nil {
+		return nil, fmt.Errorf("step %d execution error: %w", e.firedSteps, err)
+	}
+	stepResult.Violations = append(stepResult.Violations, e.invariantChecker.CheckClassInvariants(e.simState, e.bindingsBuilder)...)
+	stepResult.Violations = append(stepResult.Violations, e.invariantChecker.CheckAttributeInvariants(e.simState, e.bindingsBuilder)...)
+	return stepResult, nil
+}
+
 // State returns the current simulation state (useful for testing).
 func (e *SimulationEngine) State() *state.SimulationState {
 	return e.simState
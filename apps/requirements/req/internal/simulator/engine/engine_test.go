@@ -146,6 +146,31 @@ func (s *EngineSuite) TestSimulationTransitions() {
 	s.True(foundNormal, "should have normal transition steps")
 }
 
+func (s *EngineSuite) TestFireEvent() {
+	orderClass, orderKey := simpleOrderClass()
+	model := testModel(classEntry(orderClass, orderKey))
+
+	engine, err := NewSimulationEngine(model, SimulationConfig{RandomSeed: 42})
+	s.Require().NoError(err)
+
+	created, err := engine.FireEvent(orderKey, mustKey("domain/d/subdomain/s/class/order/event/create"), nil)
+	s.Require().NoError(err)
+	s.Equal(StepKindCreation, created.Kind)
+	s.Equal("Open", created.ToState)
+
+	instance := engine.State().GetInstance(created.InstanceID)
+	s.Require().NotNil(instance)
+	closed, err := engine.FireEvent(orderKey, mustKey("domain/d/subdomain/s/class/order/event/close"), instance)
+	s.Require().NoError(err)
+	s.Equal(mustKey("domain/d/subdomain/s/class/order/transition/close"), closed.TransitionResult.TransitionKey)
+	s.Equal("Open", closed.FromState)
+	s.Equal("Closed", closed.ToState)
+
+	// An event with no transition from the current state fails.
+	_, err = engine.FireEvent(orderKey, mustKey("domain/d/subdomain/s/class/order/event/close"), engine.State().GetInstance(created.InstanceID))
+	s.Error(err)
+}
+
 func (s *EngineSuite) TestDeadlockDetection() {
 	// A class with no creation transitions → immediate deadlock.
 	classKey := mustKey("domain/d/subdomain/s/class/stuck")
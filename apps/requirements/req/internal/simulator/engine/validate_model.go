@@ -12,10 +12,12 @@ import (
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/actions"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/executability"
 )
 
-// validateSimulationModel rejects models with no simulatable classes and checks
-// parsed action requires the parameter sampler cannot satisfy.
+// validateSimulationModel rejects models with no simulatable classes, checks
+// parsed action requires the parameter sampler cannot satisfy, and rejects
+// specifications the evaluator cannot execute.
 func validateSimulationModel(model *core.Model) error {
 	if err := validateAtLeastOneSimulatableClass(model); err != nil {
 		return err
@@ -26,7 +28,10 @@ func validateSimulationModel(model *core.Model) error {
 	if err := validateRequiresSamplingSupport(model); err != nil {
 		return err
 	}
-	return validateReferenceDataTypeInvariants(model)
+	if err := validateReferenceDataTypeInvariants(model); err != nil {
+		return err
+	}
+	return executability.Analyze(model).Err()
 }
 
 func validateAtLeastOneSimulatableClass(model *core.Model) error {
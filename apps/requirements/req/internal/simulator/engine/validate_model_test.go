@@ -208,3 +208,17 @@ func (s *ValidateModelSuite) TestValidateReferenceDataTypeInvariantsReportsMissi
 	s.Require().Error(err)
 	s.Contains(err.Error(), `class "Jurisdiction" attribute "Country Code": reference data type has no invariant`)
 }
+
+func (s *ValidateModelSuite) TestNewSimulationEngineFailsOnNonExecutableSpecification() {
+	class, classKey := testOrderClass()
+	invariantKey := helper.Must(identity.NewClassInvariantKey(classKey, "0"))
+	class.SetInvariants([]model_logic.Logic{
+		model_logic.NewLogic(invariantKey, model_logic.LogicTypeAssessment, "", "", parsedSpec(`\A n \in Nat : n >= 0`), nil),
+	})
+	model := testModel(classEntry(class, classKey))
+
+	_, err := NewSimulationEngine(model, SimulationConfig{MaxSteps: 1, RandomSeed: 42})
+	s.Require().Error(err)
+	s.Contains(err.Error(), "specifications the simulator cannot execute")
+	s.Contains(err.Error(), invariantKey.String()+": quantifier ∀ n ∈ Nat : n ≥ 0 ranges over Nat, which is infinite")
+}
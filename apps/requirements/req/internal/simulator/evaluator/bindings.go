@@ -1,6 +1,7 @@
 package evaluator
 
 import (
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
 	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/object"
 )
 
@@ -44,6 +45,19 @@ type Bindings struct {
 	// existingValue is set when evaluating EXCEPT expressions
 	// to provide the @ reference to the current field value.
 	existingValue object.Object
+
+	// functions holds LET-defined recursive functions declared in this scope.
+	functions map[string]*localFunction
+
+	// callDepth counts the nested function calls this scope is evaluated under,
+	// guarding against recursion that never reaches its base case.
+	callDepth int
+}
+
+// localFunction is a LET-defined recursive function with the scope it was defined in.
+type localFunction struct {
+	def   *me.FunctionDef
+	scope *Bindings
 }
 
 // NewBindings creates a new root bindings context.
@@ -62,6 +76,7 @@ func NewEnclosedBindings(outer *Bindings) *Bindings {
 		b.self = outer.self
 		b.selfClassKey = outer.selfClassKey
 		b.relationCtx = outer.relationCtx
+		b.callDepth = outer.callDepth
 	}
 	return b
 }
@@ -243,6 +258,25 @@ func (b *Bindings) GetExistingValue() object.Object {
 	return nil
 }
 
+// setFunction declares a LET-defined recursive function in this scope.
+func (b *Bindings) setFunction(fn *localFunction) {
+	if b.functions == nil {
+		b.functions = make(map[string]*localFunction)
+	}
+	b.functions[fn.def.Name] = fn
+}
+
+// getFunction looks up a LET-defined recursive function, checking outer scopes.
+func (b *Bindings) getFunction(name string) (*localFunction, bool) {
+	if fn, ok := b.functions[name]; ok {
+		return fn, true
+	}
+	if b.outer != nil {
+		return b.outer.getFunction(name)
+	}
+	return nil, false
+}
+
 // Clone creates a deep copy of the bindings (without outer reference).
 func (b *Bindings) Clone() *Bindings {
 	clone := NewBindings()
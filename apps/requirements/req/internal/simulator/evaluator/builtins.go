@@ -1,6 +1,10 @@
 package evaluator
 
-import "github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/object"
+import (
+	"math"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/object"
+)
 
 // BuiltinFn is the signature for all builtin functions.
 // Args are pre-evaluated object.Object values.
@@ -15,6 +19,13 @@ const (
 	GZWhenNullElse = "WhenNullElse"
 )
 
+// Sequences set constructors, which are tested by definition rather than enumerated.
+const (
+	moduleSeq     = "_Seq"
+	funcSeq       = "Seq"
+	funcSeqUnique = "SeqUnique"
+)
+
 // builtins maps function names to their implementations.
 // Names follow _Module!Function syntax to avoid collision with user-defined names.
 // Most _Module prefixes mirror real TLA+ / community modules. Exceptions are engine
@@ -25,6 +36,7 @@ var builtins = map[string]BuiltinFn{
 	"_Seq!Tail":   builtinSeqTail,
 	"_Seq!Append": builtinSeqAppend,
 	"_Seq!Len":    builtinSeqLen,
+	"_Seq!SubSeq": builtinSeqSubSeq,
 
 	// Stack (LIFO) - custom module for data type support
 	"_Stack!Push": builtinStackPush,
@@ -40,6 +52,9 @@ var builtins = map[string]BuiltinFn{
 	"_Bags!CopiesIn":       builtinCopiesIn,
 	"_Bags!BagIn":          builtinBagIn,
 	"_Bags!BagCardinality": builtinBagCardinality,
+	"_Bags!BagUnion":       builtinBagUnion,
+	"_Bags!SubBag":         builtinSubBag,
+	"_Bags!EmptyBag":       builtinEmptyBag,
 
 	// FiniteSets (standard TLA+ FiniteSets module)
 	"_FiniteSets!Cardinality": builtinFiniteSetsCardinality,
@@ -51,6 +66,26 @@ func LookupBuiltin(name string) (BuiltinFn, bool) {
 	return fn, ok
 }
 
+// OperatorFn applies the operator argument of a higher-order builtin to one value.
+type OperatorFn func(arg object.Object) *EvalResult
+
+// HigherOrderBuiltinFn is the signature for builtins that take a TLA+ operator
+// argument (see logic_expression.BuiltinOperatorArg). Args holds the remaining
+// pre-evaluated arguments in order, without the operator.
+type HigherOrderBuiltinFn func(op OperatorFn, args []object.Object) *EvalResult
+
+// higherOrderBuiltins maps higher-order function names to their implementations.
+var higherOrderBuiltins = map[string]HigherOrderBuiltinFn{
+	"_Seq!SelectSeq": builtinSeqSelectSeq,
+	"_Bags!BagOfAll": builtinBagOfAll,
+}
+
+// LookupHigherOrderBuiltin returns the higher-order builtin function for the given name.
+func LookupHigherOrderBuiltin(name string) (HigherOrderBuiltinFn, bool) {
+	fn, ok := higherOrderBuiltins[name]
+	return fn, ok
+}
+
 // === Sequence Builtins (_Seq module) ===
 
 func builtinSeqHead(args []object.Object) *EvalResult {
@@ -101,6 +136,74 @@ func builtinSeqLen(args []object.Object) *EvalResult {
 	return NewEvalResult(object.NewNatural(int64(tuple.Len())))
 }
 
+// builtinSeqSubSeq is Sequences!SubSeq(s, m, n): the elements s[m] through s[n].
+// An empty range (m > n) is the empty sequence; otherwise m..n must lie within s.
+func builtinSeqSubSeq(args []object.Object) *EvalResult {
+	if len(args) != 3 {
+		return NewEvalError("_Seq!SubSeq requires 3 arguments, got %d", len(args))
+	}
+	tuple, ok := args[0].(*object.Tuple)
+	if !ok {
+		return NewEvalError("_Seq!SubSeq requires Tuple as first arg, got %s", args[0].Type())
+	}
+	from, errResult := integerArg("_Seq!SubSeq", "second", args[1])
+	if errResult != nil {
+		return errResult
+	}
+	to, errResult := integerArg("_Seq!SubSeq", "third", args[2])
+	if errResult != nil {
+		return errResult
+	}
+	if from > to {
+		return NewEvalResult(object.NewTuple())
+	}
+	if from < 1 || to > tuple.Len() {
+		return NewEvalError("_Seq!SubSeq range %d..%d out of bounds [1, %d]", from, to, tuple.Len())
+	}
+	return NewEvalResult(tuple.SubSeq(from, to))
+}
+
+// builtinSeqSelectSeq is Sequences!SelectSeq(s, Test): the elements of s for which
+// Test is TRUE, in order.
+func builtinSeqSelectSeq(test OperatorFn, args []object.Object) *EvalResult {
+	if len(args) != 1 {
+		return NewEvalError("_Seq!SelectSeq requires 2 arguments, got %d", len(args)+1)
+	}
+	tuple, ok := args[0].(*object.Tuple)
+	if !ok {
+		return NewEvalError("_Seq!SelectSeq requires Tuple as first arg, got %s", args[0].Type())
+	}
+	selected := object.NewTuple()
+	for _, elem := range tuple.Elements() {
+		result := test(elem)
+		if result.IsError() {
+			return result
+		}
+		keep, ok := result.Value.(*object.Boolean)
+		if !ok {
+			return NewEvalError("_Seq!SelectSeq test must return Boolean, got %s", result.Value.Type())
+		}
+		if keep.Value() {
+			selected = selected.Append(elem)
+		}
+	}
+	return NewEvalResult(selected)
+}
+
+// integerArg extracts an integer builtin argument, at the given position of the builtin,
+// or an error if it is not an integer or is too large for an int.
+func integerArg(builtin, position string, arg object.Object) (int, *EvalResult) {
+	num, ok := arg.(*object.Number)
+	if !ok || (num.Kind() != object.KindNatural && num.Kind() != object.KindInteger) {
+		return 0, NewEvalError("%s requires integer as %s arg, got %s", builtin, position, arg.Inspect())
+	}
+	value := num.Rat().Num()
+	if !value.IsInt64() || value.Int64() < math.MinInt || value.Int64() > math.MaxInt {
+		return 0, NewEvalError("%s %s arg %s is out of range", builtin, position, arg.Inspect())
+	}
+	return int(value.Int64()), nil
+}
+
 // === Stack Builtins (LIFO) ===
 
 func builtinStackPush(args []object.Object) *EvalResult {
@@ -229,6 +332,86 @@ func builtinBagIn(args []object.Object) *EvalResult {
 	return NewEvalResult(nativeBoolToBoolean(contains))
 }
 
+// builtinBagUnion is Bags!BagUnion(S): the bag sum (⊕) of every bag in the set S.
+func builtinBagUnion(args []object.Object) *EvalResult {
+	if len(args) != 1 {
+		return NewEvalError("_Bags!BagUnion requires 1 argument, got %d", len(args))
+	}
+	set, ok := CoerceToSet(args[0])
+	if !ok {
+		return NewEvalError("_Bags!BagUnion requires Set of Bags, got %s", args[0].Type())
+	}
+	union := object.NewBag()
+	for _, elem := range set.Elements() {
+		bag, ok := elem.(*object.Bag)
+		if !ok {
+			return NewEvalError("_Bags!BagUnion requires Set of Bags, got element %s", elem.Type())
+		}
+		union = union.Sum(bag)
+	}
+	return NewEvalResult(union)
+}
+
+// builtinSubBag is Bags!SubBag(B): the set of all subbags of B.
+func builtinSubBag(args []object.Object) *EvalResult {
+	if len(args) != 1 {
+		return NewEvalError("_Bags!SubBag requires 1 argument, got %d", len(args))
+	}
+	bag, ok := args[0].(*object.Bag)
+	if !ok {
+		return NewEvalError("_Bags!SubBag requires Bag, got %s", args[0].Type())
+	}
+	// Each subbag picks 0..CopiesIn(e, B) copies of every element e.
+	subBags := []*object.Bag{object.NewBag()}
+	for _, elem := range bag.Elements() {
+		copies := bag.CopiesIn(elem)
+		next := make([]*object.Bag, 0, len(subBags)*(copies+1))
+		for _, sub := range subBags {
+			for count := 0; count <= copies; count++ {
+				extended := sub.Clone().(*object.Bag)
+				if count > 0 {
+					extended.Add(elem, count)
+				}
+				next = append(next, extended)
+			}
+		}
+		subBags = next
+	}
+	result := object.NewSet()
+	for _, sub := range subBags {
+		result.Add(sub)
+	}
+	return NewEvalResult(result)
+}
+
+// builtinEmptyBag is Bags!EmptyBag: the bag with no elements.
+func builtinEmptyBag(args []object.Object) *EvalResult {
+	if len(args) != 0 {
+		return NewEvalError("_Bags!EmptyBag takes no arguments, got %d", len(args))
+	}
+	return NewEvalResult(object.NewBag())
+}
+
+// builtinBagOfAll is Bags!BagOfAll(F, B): the bag of F(e) for each copy of e in B.
+func builtinBagOfAll(fn OperatorFn, args []object.Object) *EvalResult {
+	if len(args) != 1 {
+		return NewEvalError("_Bags!BagOfAll requires 2 arguments, got %d", len(args)+1)
+	}
+	bag, ok := args[0].(*object.Bag)
+	if !ok {
+		return NewEvalError("_Bags!BagOfAll requires Bag as second arg, got %s", args[0].Type())
+	}
+	result := object.NewBag()
+	for _, elem := range bag.Elements() {
+		mapped := fn(elem)
+		if mapped.IsError() {
+			return mapped
+		}
+		result.Add(mapped.Value, bag.CopiesIn(elem))
+	}
+	return NewEvalResult(result)
+}
+
 // === FiniteSets Builtins ===
 
 // builtinFiniteSetsCardinality is FiniteSets!Cardinality: size of a finite set.
@@ -160,6 +160,10 @@ func Eval(node me.Expression, bindings *Bindings) *EvalResult {
 		return evalMEIfThenElse(n, bindings)
 	case *me.LetExpr:
 		return evalMELetExpr(n, bindings)
+	case *me.FunctionDef:
+		return evalMEFunctionDef(n, bindings)
+	case *me.FunctionApply:
+		return evalMEFunctionApply(n, bindings)
 	case *me.Choose:
 		return evalMEChoose(n, bindings)
 	case *me.Case:
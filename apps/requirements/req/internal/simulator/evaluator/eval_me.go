@@ -411,6 +411,12 @@ func applyArithOp(op me.ArithOp, left, right *object.Number) (*object.Number, *E
 		return left.Sub(right), nil
 	case me.ArithMul:
 		return left.Mul(right), nil
+	case me.ArithIntDiv:
+		quo, err := left.IntDiv(right)
+		if err != nil {
+			return nil, NewEvalError("division error: %v", err)
+		}
+		return quo, nil
 	case me.ArithDiv:
 		if right.IsZero() {
 			return nil, NewEvalError("division by zero")
@@ -615,21 +621,82 @@ func evalMEMembership(n *me.Membership, bindings *Bindings) *EvalResult {
 	if elemResult.IsError() {
 		return elemResult
 	}
-	setResult := Eval(n.Set, bindings)
-	if setResult.IsError() {
-		return setResult
+
+	contains, errResult := isMember(elemResult.Value, n.Set, bindings)
+	if errResult != nil {
+		return errResult
+	}
+	if n.Negated {
+		contains = !contains
+	}
+	return NewEvalResult(nativeBoolToBoolean(contains))
+}
+
+// isMember reports whether value is an element of the set expression. Sets that
+// cannot be enumerated (Nat, Int, Real, and _Seq!Seq(S)) are tested by definition.
+func isMember(value object.Object, setExpr me.Expression, bindings *Bindings) (bool, *EvalResult) {
+	switch n := setExpr.(type) {
+	case *me.SetConstant:
+		if n.Kind != me.SetConstantBoolean {
+			return isNumberIn(value, n.Kind), nil
+		}
+	case *me.BuiltinCall:
+		if n.Module == moduleSeq && (n.Function == funcSeq || n.Function == funcSeqUnique) {
+			return isSequenceIn(value, n, bindings)
+		}
 	}
 
+	setResult := Eval(setExpr, bindings)
+	if setResult.IsError() {
+		return false, setResult
+	}
 	set, ok := CoerceToSet(setResult.Value)
 	if !ok {
-		return NewEvalError("membership test requires Set, got %s", setResult.Value.Type())
+		return false, NewEvalError("membership test requires Set, got %s", setResult.Value.Type())
 	}
+	return set.Contains(value), nil
+}
 
-	contains := set.Contains(elemResult.Value)
-	if n.Negated {
-		contains = !contains
+// isNumberIn tests membership in the infinite number sets Nat, Int, and Real.
+func isNumberIn(value object.Object, kind me.SetConstantKind) bool {
+	num, ok := value.(*object.Number)
+	if !ok {
+		return false
+	}
+	switch kind {
+	case me.SetConstantNat:
+		return num.Kind() == object.KindNatural
+	case me.SetConstantInt:
+		return num.Kind() == object.KindNatural || num.Kind() == object.KindInteger
+	default:
+		return true
 	}
-	return NewEvalResult(nativeBoolToBoolean(contains))
+}
+
+// isSequenceIn tests membership in Sequences!Seq(S): a tuple whose elements are all in S.
+// SeqUnique(S) additionally requires the elements to be distinct.
+func isSequenceIn(value object.Object, n *me.BuiltinCall, bindings *Bindings) (bool, *EvalResult) {
+	if len(n.Args) != 1 {
+		return false, NewEvalError("%s!%s requires 1 argument, got %d", n.Module, n.Function, len(n.Args))
+	}
+	tuple, ok := value.(*object.Tuple)
+	if !ok {
+		return false, nil
+	}
+	seen := object.NewSet()
+	for _, elem := range tuple.Elements() {
+		member, errResult := isMember(elem, n.Args[0], bindings)
+		if errResult != nil || !member {
+			return false, errResult
+		}
+		if n.Function == funcSeqUnique {
+			if seen.Contains(elem) {
+				return false, nil
+			}
+			seen.Add(elem)
+		}
+	}
+	return true, nil
 }
 
 // ============================================================
@@ -901,6 +968,37 @@ func evalMELetExpr(n *me.LetExpr, bindings *Bindings) *EvalResult {
 	return Eval(n.Body, childBindings)
 }
 
+func evalMEFunctionDef(n *me.FunctionDef, bindings *Bindings) *EvalResult {
+	childBindings := NewEnclosedBindings(bindings)
+	childBindings.setFunction(&localFunction{def: n, scope: childBindings})
+	return Eval(n.Body, childBindings)
+}
+
+func evalMEFunctionApply(n *me.FunctionApply, bindings *Bindings) *EvalResult {
+	fn, ok := bindings.getFunction(n.Name)
+	if !ok {
+		return NewEvalError("unknown function: %s", n.Name)
+	}
+	argResult := Eval(n.Arg, bindings)
+	if argResult.IsError() {
+		return argResult
+	}
+	inDomain, errResult := isMember(argResult.Value, fn.def.Domain, fn.scope)
+	if errResult != nil {
+		return errResult
+	}
+	if !inDomain {
+		return NewEvalError("%s[%s]: argument is not in the function's domain", n.Name, argResult.Value.Inspect())
+	}
+
+	callBindings, errResult := enterCall(fn.scope, bindings, n.Name)
+	if errResult != nil {
+		return errResult
+	}
+	callBindings.Set(fn.def.Variable, argResult.Value, NamespaceLocal)
+	return Eval(fn.def.Value, callBindings)
+}
+
 func evalMEChoose(n *me.Choose, bindings *Bindings) *EvalResult {
 	setResult := Eval(n.Set, bindings)
 	if setResult.IsError() {
@@ -1115,13 +1213,14 @@ func evalMEBuiltinCall(n *me.BuiltinCall, bindings *Bindings) *EvalResult {
 		return evalGZBuiltinCall(n, bindings)
 	}
 
-	args := make([]object.Object, len(n.Args))
-	for i, argExpr := range n.Args {
-		result := Eval(argExpr, bindings)
-		if result.IsError() {
-			return result
-		}
-		args[i] = result.Value
+	// Higher-order builtins take a global function as an operator argument.
+	if operatorIndex, ok := me.BuiltinOperatorArg(n.Module, n.Function); ok {
+		return evalHigherOrderBuiltinCall(n, operatorIndex, bindings)
+	}
+
+	args, errResult := evalArgs(n.Args, bindings)
+	if errResult != nil {
+		return errResult
 	}
 
 	// Build the full builtin name: Module!Function or just Function.
@@ -1139,6 +1238,49 @@ func evalMEBuiltinCall(n *me.BuiltinCall, bindings *Bindings) *EvalResult {
 	return fn(args)
 }
 
+// evalHigherOrderBuiltinCall evaluates a builtin such as _Seq!SelectSeq whose
+// operator argument names a global function applied to one value at a time.
+func evalHigherOrderBuiltinCall(n *me.BuiltinCall, operatorIndex int, bindings *Bindings) *EvalResult {
+	fullName := n.Module + "!" + n.Function
+	fn, ok := LookupHigherOrderBuiltin(fullName)
+	if !ok {
+		return NewEvalError("unknown builtin: %s", fullName)
+	}
+	if operatorIndex >= len(n.Args) {
+		return NewEvalError("%s requires an operator as argument %d", fullName, operatorIndex+1)
+	}
+	operator, ok := n.Args[operatorIndex].(*me.GlobalCall)
+	if !ok || len(operator.Args) != 0 {
+		return NewEvalError("%s requires a global function as argument %d", fullName, operatorIndex+1)
+	}
+
+	rest := make([]me.Expression, 0, len(n.Args)-1)
+	rest = append(rest, n.Args[:operatorIndex]...)
+	rest = append(rest, n.Args[operatorIndex+1:]...)
+	args, errResult := evalArgs(rest, bindings)
+	if errResult != nil {
+		return errResult
+	}
+
+	apply := func(arg object.Object) *EvalResult {
+		return callGlobalFunction(operator.FunctionKey.SubKey, []object.Object{arg}, bindings)
+	}
+	return fn(apply, args)
+}
+
+// evalArgs evaluates call arguments in order, stopping at the first error.
+func evalArgs(argExprs []me.Expression, bindings *Bindings) ([]object.Object, *EvalResult) {
+	args := make([]object.Object, len(argExprs))
+	for i, argExpr := range argExprs {
+		result := Eval(argExpr, bindings)
+		if result.IsError() {
+			return nil, result
+		}
+		args[i] = result.Value
+	}
+	return args, nil
+}
+
 // evalGZBuiltinCall implements _GZ!WhenNotNull / WhenNull / WhenNullElse.
 // Semantics match IF id = NULL THEN … ELSE … without eager evaluation of the unused arm.
 func evalGZBuiltinCall(n *me.BuiltinCall, bindings *Bindings) *EvalResult {
@@ -1193,18 +1335,16 @@ func evalIsNullDriver(driver me.Expression, bindings *Bindings) (bool, *EvalResu
 }
 
 func evalMEGlobalCall(n *me.GlobalCall, bindings *Bindings) *EvalResult {
-	funcName := n.FunctionKey.SubKey
-
-	// Try builtins first.
-	args := make([]object.Object, len(n.Args))
-	for i, argExpr := range n.Args {
-		result := Eval(argExpr, bindings)
-		if result.IsError() {
-			return result
-		}
-		args[i] = result.Value
+	args, errResult := evalArgs(n.Args, bindings)
+	if errResult != nil {
+		return errResult
 	}
+	return callGlobalFunction(n.FunctionKey.SubKey, args, bindings)
+}
 
+// callGlobalFunction applies a global function to pre-evaluated arguments.
+func callGlobalFunction(funcName string, args []object.Object, bindings *Bindings) *EvalResult {
+	// Try builtins first.
 	fn, ok := LookupBuiltin(funcName)
 	if ok {
 		return fn(args)
@@ -1215,7 +1355,7 @@ func evalMEGlobalCall(n *me.GlobalCall, bindings *Bindings) *EvalResult {
 	if ctx != nil && ctx.IRRegistry != nil {
 		body, params, found := ctx.IRRegistry.LookupGlobal(funcName)
 		if found {
-			return evalRegistryCall(body, params, args, bindings)
+			return evalRegistryCall(funcName, body, params, args, bindings)
 		}
 	}
 
@@ -1229,12 +1369,15 @@ func evalMEActionCall(n *me.ActionCall, _ *Bindings) *EvalResult {
 }
 
 // evalRegistryCall evaluates a registry function body with parameter bindings.
-func evalRegistryCall(body me.Expression, params []string, args []object.Object, bindings *Bindings) *EvalResult {
+func evalRegistryCall(funcName string, body me.Expression, params []string, args []object.Object, bindings *Bindings) *EvalResult {
 	if len(params) != len(args) {
 		return NewEvalError("function expects %d arguments, got %d", len(params), len(args))
 	}
 
-	childBindings := NewEnclosedBindings(bindings)
+	childBindings, errResult := enterCall(bindings, bindings, funcName)
+	if errResult != nil {
+		return errResult
+	}
 	for i, paramName := range params {
 		childBindings.Set(paramName, args[i], NamespaceLocal)
 	}
@@ -1242,6 +1385,21 @@ func evalRegistryCall(body me.Expression, params []string, args []object.Object,
 	return Eval(body, childBindings)
 }
 
+// MaxCallDepth bounds nested function calls. Recursion is checked to decrease its
+// argument when the model is validated, but a missing base case still only fails here.
+const MaxCallDepth = 1000
+
+// enterCall opens the scope for a function call made from caller, enclosing scope.
+// It fails once calls nest deeper than MaxCallDepth rather than exhausting the stack.
+func enterCall(scope, caller *Bindings, name string) (*Bindings, *EvalResult) {
+	if caller.callDepth >= MaxCallDepth {
+		return nil, NewEvalError("%s: recursion depth limit of %d exceeded", name, MaxCallDepth)
+	}
+	childBindings := NewEnclosedBindings(scope)
+	childBindings.callDepth = caller.callDepth + 1
+	return childBindings, nil
+}
+
 // ObjectsEqual compares two simulator objects for structural equality.
 func ObjectsEqual(left, right object.Object) bool {
 	return objectsEqual(left, right)
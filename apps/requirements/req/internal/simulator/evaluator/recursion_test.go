@@ -0,0 +1,123 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/evaluator"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/registry"
+	"github.com/stretchr/testify/suite"
+)
+
+// RecursionSuite evaluates recursive global functions and LET-defined recursive functions.
+type RecursionSuite struct {
+	suite.Suite
+	ctx *convert.LowerContext
+}
+
+func TestRecursionSuite(t *testing.T) {
+	suite.Run(t, new(RecursionSuite))
+}
+
+func (s *RecursionSuite) SetupTest() {
+	sumKey, err := identity.NewGlobalFunctionKey("_sum")
+	s.Require().NoError(err)
+	lengthKey, err := identity.NewGlobalFunctionKey("_length")
+	s.Require().NoError(err)
+	foreverKey, err := identity.NewGlobalFunctionKey("_forever")
+	s.Require().NoError(err)
+	s.ctx = &convert.LowerContext{
+		Parameters: map[string]bool{"n": true, "amounts": true, "items": true},
+		GlobalFunctions: map[string]identity.Key{
+			"_Sum":     sumKey,
+			"_Length":  lengthKey,
+			"_Forever": foreverKey,
+		},
+	}
+
+	reg := registry.NewRegistry()
+	_, err = reg.RegisterGlobalFunction("_Sum",
+		s.lower(`IF amounts = {} THEN 0 ELSE LET x == CHOOSE y \in amounts : TRUE IN x + _Sum(amounts \ {x})`),
+		[]registry.Parameter{{Name: "amounts"}})
+	s.Require().NoError(err)
+	_, err = reg.RegisterGlobalFunction("_Length",
+		s.lower(`IF items = <<>> THEN 0 ELSE 1 + _Length(_Seq!Tail(items))`),
+		[]registry.Parameter{{Name: "items"}})
+	s.Require().NoError(err)
+	// Never reaches a base case; model validation rejects it, the depth guard stops it here.
+	_, err = reg.RegisterGlobalFunction("_Forever", s.lower(`_Forever(n + 1)`), []registry.Parameter{{Name: "n"}})
+	s.Require().NoError(err)
+	evaluator.SetEvalContext(&evaluator.EvalContext{IRRegistry: registry.NewRuntimeAdapter(reg)})
+}
+
+func (s *RecursionSuite) TearDownTest() {
+	evaluator.ClearEvalContext()
+}
+
+func (s *RecursionSuite) lower(spec string) me.Expression {
+	astExpr, err := parser.ParseExpression(spec)
+	s.Require().NoError(err, spec)
+	expr, err := convert.Lower(astExpr, s.ctx)
+	s.Require().NoError(err, spec)
+	return expr
+}
+
+func (s *RecursionSuite) TestRecursion() {
+	tests := []struct {
+		spec     string
+		expected string
+		errstr   string
+	}{
+		// Recursive global functions.
+		{spec: `_Sum({3, 1, 2})`, expected: "6"},
+		{spec: `_Sum({})`, expected: "0"},
+		{spec: `_Length(<<"a", "b", "c">>)`, expected: "3"},
+		{spec: `_Forever(0)`, errstr: "recursion depth limit of 1000 exceeded"},
+
+		// LET-defined recursive functions.
+		{spec: `LET fact[k \in Nat] == IF k = 0 THEN 1 ELSE k * fact[k - 1] IN fact[5]`, expected: "120"},
+		{spec: `LET fact[k \in Nat] == IF k = 0 THEN 1 ELSE k * fact[k - 1] IN fact[0]`, expected: "1"},
+		{spec: `LET total[s \in {{}, {1}, {2}, {1, 2}}] == IF s = {} THEN 0 ELSE LET x == CHOOSE y \in s : TRUE IN x + total[s \ {x}] IN total[{1, 2}]`, expected: "3"},
+		{spec: `LET fib[k \in Nat] == IF k < 2 THEN k ELSE fib[k - 1] + fib[k - 2] IN fib[10]`, expected: "55"},
+		{spec: `LET fact[k \in Nat] == k * fact[k - 1] IN fact[3]`, errstr: "fact[-1]: argument is not in the function's domain"},
+		{spec: `LET down[k \in Int] == down[k - 1] IN down[0]`, errstr: "down: recursion depth limit of 1000 exceeded"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.spec, func() {
+			result := evaluator.Eval(s.lower(tt.spec), evaluator.NewBindings())
+			if tt.errstr != "" {
+				s.Require().True(result.IsError(), "expected error, got %v", result.Value)
+				s.Contains(result.Error.Inspect(), tt.errstr)
+				return
+			}
+			s.Require().False(result.IsError(), "unexpected error: %v", result.Error)
+			s.Equal(tt.expected, result.Value.Inspect())
+		})
+	}
+}
+
+func (s *RecursionSuite) TestLowerErrors() {
+	tests := []struct {
+		spec   string
+		errstr string
+	}{
+		{spec: `LET fact[k \in Nat] == 1 IN fact`, errstr: `function "fact" must be applied to an argument, as in fact[x]`},
+		{spec: `LET fact[k \in Nat] == 1 IN k`, errstr: "k"},
+		{spec: `LET fact[5] == 1 IN fact[1]`, errstr: "expected Membership"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.spec, func() {
+			astExpr, err := parser.ParseExpression(tt.spec)
+			if err != nil {
+				s.Contains(err.Error(), tt.errstr)
+				return
+			}
+			_, err = convert.Lower(astExpr, s.ctx)
+			s.Require().Error(err)
+			s.Contains(err.Error(), tt.errstr)
+		})
+	}
+}
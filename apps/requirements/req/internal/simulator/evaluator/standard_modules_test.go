@@ -0,0 +1,148 @@
+package evaluator_test
+
+import (
+	"testing"
+
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/parser"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/evaluator"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/object"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/simulator/registry"
+	"github.com/stretchr/testify/suite"
+)
+
+// StandardModulesSuite evaluates the TLA+ standard module operators end to end:
+// parse, lower, and evaluate.
+type StandardModulesSuite struct {
+	suite.Suite
+	ctx *convert.LowerContext
+}
+
+func TestStandardModulesSuite(t *testing.T) {
+	suite.Run(t, new(StandardModulesSuite))
+}
+
+func (s *StandardModulesSuite) SetupTest() {
+	isEvenKey, err := identity.NewGlobalFunctionKey("_iseven")
+	s.Require().NoError(err)
+	doubleKey, err := identity.NewGlobalFunctionKey("_double")
+	s.Require().NoError(err)
+	s.ctx = &convert.LowerContext{
+		Parameters: map[string]bool{"x": true},
+		GlobalFunctions: map[string]identity.Key{
+			"_IsEven": isEvenKey,
+			"_Double": doubleKey,
+		},
+	}
+
+	reg := registry.NewRegistry()
+	_, err = reg.RegisterGlobalFunction("_IsEven", s.lower(`x % 2 = 0`), []registry.Parameter{{Name: "x"}})
+	s.Require().NoError(err)
+	_, err = reg.RegisterGlobalFunction("_Double", s.lower(`x * 2`), []registry.Parameter{{Name: "x"}})
+	s.Require().NoError(err)
+	evaluator.SetEvalContext(&evaluator.EvalContext{IRRegistry: registry.NewRuntimeAdapter(reg)})
+}
+
+func (s *StandardModulesSuite) TearDownTest() {
+	evaluator.ClearEvalContext()
+}
+
+func (s *StandardModulesSuite) lower(spec string) me.Expression {
+	astExpr, err := parser.ParseExpression(spec)
+	s.Require().NoError(err, spec)
+	expr, err := convert.Lower(astExpr, s.ctx)
+	s.Require().NoError(err, spec)
+	return expr
+}
+
+func (s *StandardModulesSuite) TestOperators() {
+	tests := []struct {
+		spec     string
+		expected string
+		errstr   string
+	}{
+		// Sequences.
+		{spec: `_Seq!SubSeq(<<1, 2, 3, 4>>, 2, 3)`, expected: "<<2, 3>>"},
+		{spec: `_Seq!SubSeq(<<1, 2, 3>>, 3, 2)`, expected: "<<>>"},
+		{spec: `_Seq!SubSeq(<<1, 2, 3>>, 0, 2)`, errstr: "_Seq!SubSeq range 0..2 out of bounds [1, 3]"},
+		{spec: `_Seq!SubSeq(<<1, 2, 3>>, 2, 4)`, errstr: "out of bounds"},
+		{spec: `_Seq!SubSeq({1}, 1, 1)`, errstr: "_Seq!SubSeq requires Tuple as first arg"},
+		{spec: `_Seq!SubSeq(<<1, 2, 3>>, "a", 2)`, errstr: `_Seq!SubSeq requires integer as second arg, got "a"`},
+		{spec: `_Seq!SubSeq(<<1, 2, 3>>, 2^64 + 1, 2)`, errstr: "_Seq!SubSeq second arg 18446744073709551617 is out of range"},
+		{spec: `_Seq!SubSeq(<<1, 2, 3>>, 1, 2^64 + 2)`, errstr: "_Seq!SubSeq third arg 18446744073709551618 is out of range"},
+		{spec: `_Seq!SelectSeq(<<1, 2, 3, 4>>, _IsEven)`, expected: "<<2, 4>>"},
+		{spec: `_Seq!SelectSeq(<<1, 3>>, _IsEven)`, expected: "<<>>"},
+		{spec: `_Seq!SelectSeq(<<1>>, _Double)`, errstr: "_Seq!SelectSeq test must return Boolean"},
+		{spec: `<<1, 2>> \o <<3>>`, expected: "<<1, 2, 3>>"},
+		{spec: `<<1, 2>> \in _Seq!Seq(Nat)`, expected: "true"},
+		{spec: `<<1, -2>> \in _Seq!Seq(Nat)`, expected: "false"},
+		{spec: `<<>> \in _Seq!Seq({"a"})`, expected: "true"},
+		{spec: `<<"a", "a">> \in _Seq!SeqUnique({"a"})`, expected: "false"},
+		{spec: `<<<<1>>>> \in _Seq!Seq(_Seq!Seq(Int))`, expected: "true"},
+		{spec: `1 \in _Seq!Seq(Nat)`, expected: "false"},
+		{spec: `<<1>> \notin _Seq!Seq(Nat)`, expected: "false"},
+
+		// Bags.
+		{spec: `_Bags!SetToBag({1}) (+) _Bags!SetToBag({1, 2})`, expected: "(1, 1, 2)"},
+		{spec: `_Bags!SetToBag({1, 2}) (-) _Bags!SetToBag({1})`, expected: "(2)"},
+		{spec: `_Bags!BagUnion({_Bags!SetToBag({1}), _Bags!SetToBag({1, 2})})`, expected: "(1, 1, 2)"},
+		{spec: `_Bags!BagUnion({})`, expected: "()"},
+		{spec: `_Bags!BagUnion({1})`, errstr: "_Bags!BagUnion requires Set of Bags, got element"},
+		{spec: `_FiniteSets!Cardinality(_Bags!SubBag(_Bags!SetToBag({1}) (+) _Bags!SetToBag({1, 2})))`, expected: "6"},
+		{spec: `_Bags!EmptyBag \in _Bags!SubBag(_Bags!SetToBag({1}))`, expected: "true"},
+		{spec: `_Bags!BagCardinality(_Bags!EmptyBag)`, expected: "0"},
+		{spec: `_Bags!EmptyBag()`, expected: "()"},
+		{spec: `_Bags!BagOfAll(_Double, _Bags!SetToBag({1}) (+) _Bags!SetToBag({1, 2}))`, expected: "(2, 2, 4)"},
+		{spec: `_Bags!BagOfAll(_Double, {1})`, errstr: "_Bags!BagOfAll requires Bag as second arg"},
+
+		// Naturals and Integers.
+		{spec: `7 \div 2`, expected: "3"},
+		{spec: `-7 \div 2`, expected: "-3"},
+		{spec: `(0 - 7) \div 2`, expected: "-4"},
+		{spec: `7 \div 0`, errstr: "division by zero"},
+		{spec: `7 \div (0 - 2)`, errstr: "positive divisor"},
+		{spec: `7 / 2`, expected: "7/2"},
+		{spec: `x / 2`, expected: "3/2"},
+		{spec: `7 % 3`, expected: "1"},
+		{spec: `(0 - 7) % 3`, expected: "2"},
+		{spec: `2 ^ 10`, expected: "1024"},
+		{spec: `1..3`, expected: "{1, 2, 3}"},
+		{spec: `3..1`, expected: "{}"},
+		{spec: `2 \in Nat`, expected: "true"},
+		{spec: `(0 - 2) \in Nat`, expected: "false"},
+		{spec: `(0 - 2) \in Int`, expected: "true"},
+		{spec: `x / 2 \in Int`, expected: "false"},
+		{spec: `x / 2 \in Real`, expected: "true"},
+	}
+	for _, tt := range tests {
+		s.Run(tt.spec, func() {
+			bindings := evaluator.NewBindings()
+			bindings.Set("x", object.NewNatural(3), evaluator.NamespaceLocal)
+			result := evaluator.Eval(s.lower(tt.spec), bindings)
+			if tt.errstr != "" {
+				s.Require().True(result.IsError(), "expected error, got %v", result.Value)
+				s.Contains(result.Error.Inspect(), tt.errstr)
+				return
+			}
+			s.Require().False(result.IsError(), result.Error)
+			s.Equal(tt.expected, result.Value.Inspect())
+		})
+	}
+}
+
+func (s *StandardModulesSuite) TestOperatorArgumentMustBeGlobalFunction() {
+	for _, spec := range []string{
+		`_Seq!SelectSeq(<<1>>, x)`,
+		`_Seq!SelectSeq(<<1>>, _Missing)`,
+		`_Bags!BagOfAll(x + 1, _Bags!EmptyBag)`,
+	} {
+		s.Run(spec, func() {
+			astExpr, err := parser.ParseExpression(spec)
+			s.Require().NoError(err)
+			_, err = convert.Lower(astExpr, s.ctx)
+			s.Require().ErrorContains(err, "operator")
+		})
+	}
+}
@@ -0,0 +1,96 @@
+// Package executability statically checks whether the simulator's evaluator can
+// execute the specifications of a model, so that constructs it cannot run are found
+// before a simulation starts stepping rather than as errors in the middle of a run.
+package executability
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// Classification is how far the evaluator can execute a construct.
+type Classification string
+
+const (
+	// Executable constructs run in time fixed by the specification itself.
+	Executable = Classification("executable")
+	// ExecutableWithBound constructs run, but how long they take depends on the
+	// simulation state, such as the number of instances of a class.
+	ExecutableWithBound = Classification("executable_with_bound")
+	// NonExecutable constructs always fail to evaluate, such as a quantifier over Nat.
+	NonExecutable = Classification("non_executable")
+)
+
+// Finding classifies one construct within a specification.
+type Finding struct {
+	Classification Classification
+	LogicKey       identity.Key // The logic whose specification holds the construct.
+	ClassKey       identity.Key // The class the logic belongs to; zero for model-level logic.
+	Construct      string       // Node type of the construct, such as "quantifier".
+	Expression     string       // The construct, printed in the notation of its specification.
+	Specification  string       // The whole specification text.
+	Reason         string       // Why the construct has its classification.
+}
+
+// String describes the finding, led by the key of its logic.
+func (f Finding) String() string {
+	return fmt.Sprintf("%s: %s %s %s", f.LogicKey.String(), strings.ReplaceAll(f.Construct, "_", " "), f.Expression, f.Reason)
+}
+
+// Report holds the findings for every specification analyzed, ordered by logic key.
+// Constructs that neither iterate nor fail, such as arithmetic, are not listed.
+type Report struct {
+	Findings []Finding
+}
+
+// Count returns the number of findings with the classification.
+func (r Report) Count(classification Classification) int {
+	count := 0
+	for _, finding := range r.Findings {
+		if finding.Classification == classification {
+			count++
+		}
+	}
+	return count
+}
+
+// NonExecutable returns the findings for constructs the evaluator cannot execute.
+func (r Report) NonExecutable() []Finding {
+	var findings []Finding
+	for _, finding := range r.Findings {
+		if finding.Classification == NonExecutable {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+// Err returns an error listing every non-executable construct, or nil if there are none.
+func (r Report) Err() error {
+	findings := r.NonExecutable()
+	if len(findings) == 0 {
+		return nil
+	}
+	messages := make([]string, len(findings))
+	for i, finding := range findings {
+		messages[i] = finding.String()
+	}
+	return fmt.Errorf("specifications the simulator cannot execute: %s", strings.Join(messages, "; "))
+}
+
+// Analyze classifies the constructs of every lowered specification in the model.
+// Specifications that did not lower are skipped; parsing reports those.
+// Classes outside the model, or in realized domains, are never populated by a
+// simulation, so ranging over their instances is flagged.
+func Analyze(model *core.Model) Report {
+	a := newModelAnalyzer(model)
+	a.analyzeModel()
+	slices.SortStableFunc(a.findings, func(x, y Finding) int {
+		return strings.Compare(x.LogicKey.String(), y.LogicKey.String())
+	})
+	return Report{Findings: a.findings}
+}
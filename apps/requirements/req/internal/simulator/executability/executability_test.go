@@ -0,0 +1,223 @@
+package executability
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExecutabilitySuite struct {
+	suite.Suite
+	orderKey  identity.Key
+	ledgerKey identity.Key
+	countKey  identity.Key
+}
+
+func TestExecutabilitySuite(t *testing.T) {
+	suite.Run(t, new(ExecutabilitySuite))
+}
+
+func (s *ExecutabilitySuite) SetupTest() {
+	s.orderKey = helper.Must(identity.ParseKey("domain/d/subdomain/s/class/order"))
+	s.ledgerKey = helper.Must(identity.ParseKey("domain/r/subdomain/s/class/ledger"))
+	s.countKey = helper.Must(identity.NewGlobalFunctionKey("_count"))
+}
+
+// testModel builds a model with an Order class, whose invariant is specification, and
+// a Ledger class in a realized domain.
+func (s *ExecutabilitySuite) testModel(specification string) *core.Model {
+	ctx := &convert.LowerContext{
+		ClassKey:        s.orderKey,
+		ClassNames:      map[string]identity.Key{"Order": s.orderKey, "Ledger": s.ledgerKey},
+		GlobalFunctions: map[string]identity.Key{"_Count": s.countKey},
+	}
+	spec := helper.Must(logic_spec.NewExpressionSpec(model_logic.NotationTLAPlus, specification, convert.NewExpressionParseFunc(ctx)))
+	s.Require().NotNil(spec.Expression, "specification %q must lower", specification)
+
+	order := model_class.NewClass(s.orderKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Order"})
+	invariantKey := helper.Must(identity.NewClassInvariantKey(s.orderKey, "0"))
+	order.SetInvariants([]model_logic.Logic{model_logic.NewLogic(invariantKey, model_logic.LogicTypeAssessment, "", "", spec, nil)})
+	ledger := model_class.NewClass(s.ledgerKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Ledger"})
+
+	countSpec := helper.Must(logic_spec.NewExpressionSpec(model_logic.NotationTLAPlus, "IF n <= 0 THEN 0 ELSE _Count(n - 1)", convert.NewExpressionParseFunc(&convert.LowerContext{
+		GlobalFunctions: map[string]identity.Key{"_Count": s.countKey},
+		Parameters:      map[string]bool{"n": true},
+	})))
+	count := model_logic.NewGlobalFunction(s.countKey, "_Count", []string{"n"}, true,
+		model_logic.NewLogic(s.countKey, model_logic.LogicTypeValue, "", "", countSpec, nil))
+
+	model := core.NewModel("test", core.ModelDetails{Name: "Test"}, "", nil, map[identity.Key]model_logic.GlobalFunction{s.countKey: count}, nil)
+	model.Domains = map[identity.Key]model_domain.Domain{
+		s.domain("domain/d", false, order).Key: s.domain("domain/d", false, order),
+		s.domain("domain/r", true, ledger).Key: s.domain("domain/r", true, ledger),
+	}
+	return &model
+}
+
+func (s *ExecutabilitySuite) domain(key string, realized bool, class model_class.Class) model_domain.Domain {
+	domainKey := helper.Must(identity.ParseKey(key))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "s"))
+	subdomain := model_domain.NewSubdomain(subdomainKey, "S", "", "", "")
+	subdomain.Classes = map[identity.Key]model_class.Class{class.Key: class}
+	domain := model_domain.NewDomain(domainKey, key, "", "", realized, "")
+	domain.Subdomains = map[identity.Key]model_domain.Subdomain{subdomainKey: subdomain}
+	return domain
+}
+
+// invariantFindings analyzes the model of specification, dropping findings for the
+// recursive global function every test model has.
+func (s *ExecutabilitySuite) invariantFindings(specification string) []Finding {
+	var findings []Finding
+	for _, finding := range Analyze(s.testModel(specification)).Findings {
+		if finding.LogicKey != s.countKey {
+			findings = append(findings, finding)
+		}
+	}
+	return findings
+}
+
+func (s *ExecutabilitySuite) TestClassifiesConstructs() {
+	tests := []struct {
+		testName       string
+		specification  string
+		construct      string
+		expression     string
+		classification Classification
+		reason         string
+	}{
+		{
+			testName:       "quantifier over a literal set",
+			specification:  `\E x \in {1, 2, 3} : x > 2`,
+			construct:      "quantifier",
+			expression:     "∃ x ∈ {1, 2, 3} : x > 2",
+			classification: Executable,
+			reason:         "ranges over a literal set of 3 elements",
+		},
+		{
+			testName:       "quantifier over BOOLEAN",
+			specification:  `\A b \in BOOLEAN : b \/ ~b`,
+			construct:      "quantifier",
+			classification: Executable,
+			reason:         "ranges over BOOLEAN",
+		},
+		{
+			testName:       "quantifier over Nat",
+			specification:  `\A n \in Nat : n >= 0`,
+			construct:      "quantifier",
+			expression:     "∀ n ∈ Nat : n ≥ 0",
+			classification: NonExecutable,
+			reason:         "ranges over Nat, which is infinite",
+		},
+		{
+			testName:       "choose over Int",
+			specification:  `(CHOOSE i \in Int : i > 0) > 0`,
+			construct:      "choose",
+			classification: NonExecutable,
+			reason:         "ranges over Int, which is infinite",
+		},
+		{
+			testName:       "set filter over a union with Nat",
+			specification:  `{n \in Nat \union {1} : n < 3} = {1}`,
+			construct:      "set_filter",
+			classification: NonExecutable,
+			reason:         "ranges over Nat, which is infinite",
+		},
+		{
+			testName:       "quantifier over sequences",
+			specification:  `\A q \in _Seq!Seq({1}) : TRUE`,
+			construct:      "quantifier",
+			classification: NonExecutable,
+			reason:         "ranges over a set of sequences, which is infinite",
+		},
+		{
+			testName:       "quantifier over class instances",
+			specification:  `\A o \in Order : o.id > 0`,
+			construct:      "quantifier",
+			classification: ExecutableWithBound,
+			reason:         "ranges over the instances of Order, bounded by how many the run creates",
+		},
+		{
+			testName:       "quantifier over a realized class",
+			specification:  `\A l \in Ledger : l.id > 0`,
+			construct:      "quantifier",
+			classification: ExecutableWithBound,
+			reason:         "ranges over the instances of Ledger, which is in a realized domain and never has any",
+		},
+		{
+			testName:       "choose over a realized class",
+			specification:  `LET l == CHOOSE x \in Ledger : TRUE IN l.id > 0`,
+			construct:      "choose",
+			classification: NonExecutable,
+			reason:         "ranges over the instances of Ledger, which is in a realized domain and never has any, so there is never an element to choose",
+		},
+		{
+			testName:       "cardinality of Nat",
+			specification:  `_FiniteSets!Cardinality(Nat) > 0`,
+			construct:      "set_constant",
+			expression:     "Nat",
+			classification: NonExecutable,
+			reason:         "enumerates Nat, which is infinite; only membership in it can be tested",
+		},
+		{
+			testName:       "recursive global function call",
+			specification:  `_Count(3) = 0`,
+			construct:      "global_call",
+			classification: ExecutableWithBound,
+			reason:         "recurses as deep as its arguments are large",
+		},
+	}
+	for _, tt := range tests {
+		s.Run(tt.testName, func() {
+			findings := s.invariantFindings(tt.specification)
+			s.Require().Len(findings, 1, "%v", findings)
+			finding := findings[0]
+			s.Equal(tt.construct, finding.Construct)
+			s.Equal(tt.classification, finding.Classification)
+			s.Equal(tt.reason, finding.Reason)
+			s.Equal(helper.Must(identity.NewClassInvariantKey(s.orderKey, "0")), finding.LogicKey)
+			s.Equal(s.orderKey, finding.ClassKey)
+			if tt.expression != "" {
+				s.Equal(tt.expression, finding.Expression)
+			}
+		})
+	}
+}
+
+func (s *ExecutabilitySuite) TestMembershipIsTestedByDefinition() {
+	for _, specification := range []string{
+		`5 \in Nat`,
+		`-1 \notin Nat`,
+		`<<1, 2>> \in _Seq!Seq(Nat)`,
+		`\A x \in {1, 2} : x \in Int`,
+	} {
+		for _, finding := range s.invariantFindings(specification) {
+			s.NotEqual(NonExecutable, finding.Classification, "%s: %s", specification, finding)
+		}
+	}
+}
+
+func (s *ExecutabilitySuite) TestRecursiveGlobalFunctionIsBounded() {
+	report := Analyze(s.testModel("TRUE"))
+	s.Require().Len(report.Findings, 1)
+	s.Equal(s.countKey, report.Findings[0].LogicKey)
+	s.Equal(ExecutableWithBound, report.Findings[0].Classification)
+	s.Equal(`_Count(n - 1)`, report.Findings[0].Expression)
+	s.NoError(report.Err())
+}
+
+func (s *ExecutabilitySuite) TestReportErr() {
+	report := Analyze(s.testModel(`\A n \in Nat : n >= 0`))
+	s.Equal(1, report.Count(NonExecutable))
+	s.Equal(1, report.Count(ExecutableWithBound))
+	err := report.Err()
+	s.Require().Error(err)
+	s.Equal(`specifications the simulator cannot execute: domain/d/subdomain/s/class/order/cinvariant/0: quantifier ∀ n ∈ Nat : n ≥ 0 ranges over Nat, which is infinite`, err.Error())
+}
@@ -0,0 +1,262 @@
+package executability
+
+import (
+	"fmt"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+)
+
+// Sequences set constructors, which the evaluator tests membership of but cannot enumerate.
+const (
+	moduleSeq     = "_Seq"
+	funcSeq       = "Seq"
+	funcSeqUnique = "SeqUnique"
+)
+
+// specAnalyzer classifies the constructs of one specification.
+type specAnalyzer struct {
+	model    *modelAnalyzer
+	logic    *model_logic.Logic
+	classKey identity.Key
+	raise    *convert.RaiseContext
+
+	// covered holds infinite sets already blamed by the construct ranging over them,
+	// so they are not reported a second time on their own.
+	covered map[me.Expression]bool
+
+	findings []Finding
+}
+
+// domain is what is known statically about a set a construct ranges over.
+type domain struct {
+	classification Classification
+	reason         string
+	empty          bool // The set is always empty during a simulation.
+}
+
+func (s *specAnalyzer) walk(expr me.Expression) {
+	me.Walk(expr, s.visit)
+}
+
+// visit classifies expr, returning false when it has walked the children itself.
+// The evaluator enumerates every set it evaluates, except the set of a membership
+// test and the domain of a recursive function, which are tested by definition.
+func (s *specAnalyzer) visit(expr me.Expression) bool {
+	switch n := expr.(type) {
+	case *me.Membership:
+		s.walk(n.Element)
+		s.walkTested(n.Set)
+		return false
+	case *me.Quantifier:
+		s.rangeOver(n, n.Domain, false)
+		s.walk(n.Predicate)
+		return false
+	case *me.Choose:
+		s.rangeOver(n, n.Set, true)
+		s.walk(n.Predicate)
+		return false
+	case *me.SetFilter:
+		s.rangeOver(n, n.Set, false)
+		s.walk(n.Predicate)
+		return false
+	case *me.SetMap:
+		s.rangeOver(n, n.Set, false)
+		s.walk(n.Transform)
+		return false
+	case *me.FunctionDef:
+		s.add(n, ExecutableWithBound, fmt.Sprintf("recurses as deep as its argument is large, bounded by decreasing %s", n.Variable))
+		s.walkTested(n.Domain)
+		s.walk(n.Value)
+		s.walk(n.Body)
+		return false
+	case *me.GlobalCall:
+		if s.model.recursive[n.FunctionKey] {
+			s.add(n, ExecutableWithBound, "recurses as deep as its arguments are large")
+		}
+	case *me.SetConstant:
+		if infinite(n) && !s.covered[n] {
+			s.add(n, NonExecutable, fmt.Sprintf("enumerates %s, which is infinite; only membership in it can be tested", setConstantName(n.Kind)))
+		}
+	case *me.BuiltinCall:
+		if isSeqSet(n) {
+			if !s.covered[n] {
+				s.add(n, NonExecutable, "enumerates a set of sequences, which is infinite; only membership in it can be tested")
+			}
+			s.walkArgsTested(n)
+			return false
+		}
+	}
+	return true
+}
+
+// walkTested walks a set the evaluator tests membership of without enumerating it.
+func (s *specAnalyzer) walkTested(set me.Expression) {
+	switch n := set.(type) {
+	case *me.SetConstant:
+		return
+	case *me.BuiltinCall:
+		if isSeqSet(n) {
+			s.walkArgsTested(n)
+			return
+		}
+	}
+	s.walk(set)
+}
+
+func (s *specAnalyzer) walkArgsTested(n *me.BuiltinCall) {
+	for _, arg := range n.Args {
+		s.walkTested(arg)
+	}
+}
+
+// rangeOver classifies a construct that evaluates its body once for each element of set.
+func (s *specAnalyzer) rangeOver(construct, set me.Expression, chooses bool) {
+	d := s.domainOf(set)
+	if chooses && d.empty && d.classification != NonExecutable {
+		d = domain{classification: NonExecutable, reason: d.reason + ", so there is never an element to choose"}
+	}
+	s.add(construct, d.classification, d.reason)
+	s.walk(set)
+}
+
+// domainOf classifies the set a construct ranges over.
+func (s *specAnalyzer) domainOf(set me.Expression) domain {
+	switch n := set.(type) {
+	case *me.SetConstant:
+		if !infinite(n) {
+			return domain{classification: Executable, reason: "ranges over BOOLEAN"}
+		}
+		s.covered[n] = true
+		return domain{classification: NonExecutable, reason: fmt.Sprintf("ranges over %s, which is infinite", setConstantName(n.Kind))}
+	case *me.BuiltinCall:
+		if isSeqSet(n) {
+			s.covered[n] = true
+			return domain{classification: NonExecutable, reason: "ranges over a set of sequences, which is infinite"}
+		}
+	case *me.SetLiteral:
+		return domain{classification: Executable, reason: fmt.Sprintf("ranges over a literal set of %d elements", len(n.Elements)), empty: len(n.Elements) == 0}
+	case *me.SetRange:
+		if isIntLiteral(n.Start) && isIntLiteral(n.End) {
+			return domain{classification: Executable, reason: "ranges over a literal integer range"}
+		}
+		return domain{classification: ExecutableWithBound, reason: "ranges over an integer range whose ends are only known during the run"}
+	case *me.NamedSetRef:
+		return domain{classification: Executable, reason: fmt.Sprintf("ranges over the named set %s, evaluated once before the run", n.SetKey.SubKey)}
+	case *me.ClassRef:
+		return s.classDomain(n)
+	case *me.SetFilter:
+		return s.domainOf(n.Set)
+	case *me.SetMap:
+		return s.domainOf(n.Set)
+	case *me.SetOp:
+		return s.setOpDomain(n)
+	}
+	return domain{classification: ExecutableWithBound, reason: "ranges over a set computed during the run"}
+}
+
+func (s *specAnalyzer) classDomain(n *me.ClassRef) domain {
+	if s.model.populated[n.ClassKey] {
+		return domain{classification: ExecutableWithBound, reason: fmt.Sprintf("ranges over the instances of %s, bounded by how many the run creates", n.Name)}
+	}
+	if s.model.realized(n.ClassKey) {
+		return domain{classification: ExecutableWithBound, reason: fmt.Sprintf("ranges over the instances of %s, which is in a realized domain and never has any", n.Name), empty: true}
+	}
+	return domain{classification: ExecutableWithBound, reason: fmt.Sprintf("ranges over the instances of %s, which is outside the simulated surface and never has any", n.Name), empty: true}
+}
+
+// setOpDomain combines the sides of a set operation. The evaluator evaluates both
+// sides, so the operation is only as executable as its least executable side.
+func (s *specAnalyzer) setOpDomain(n *me.SetOp) domain {
+	left := s.domainOf(n.Left)
+	right := s.domainOf(n.Right)
+	d := left
+	if rank(right.classification) > rank(left.classification) {
+		d = right
+	}
+	switch n.Op {
+	case me.SetUnion:
+		d.empty = left.empty && right.empty
+	case me.SetIntersect:
+		d.empty = left.empty || right.empty
+	default:
+		d.empty = left.empty
+	}
+	return d
+}
+
+func (s *specAnalyzer) add(construct me.Expression, classification Classification, reason string) {
+	s.findings = append(s.findings, Finding{
+		Classification: classification,
+		LogicKey:       s.logic.Key,
+		ClassKey:       s.classKey,
+		Construct:      construct.NodeType(),
+		Expression:     s.print(construct),
+		Specification:  s.logic.Spec.Specification,
+		Reason:         reason,
+	})
+}
+
+// print renders expr in the notation of its specification, or by node type if it
+// cannot be raised.
+func (s *specAnalyzer) print(expr me.Expression) string {
+	raised, err := convert.Raise(expr, s.raise)
+	if err != nil {
+		return "<" + expr.NodeType() + ">"
+	}
+	return convert.PrintNotation(s.logic.Spec.Notation, raised)
+}
+
+// realized reports whether the class is in a realized domain of the model.
+func (a *modelAnalyzer) realized(classKey identity.Key) bool {
+	for _, domain := range a.model.Domains {
+		if !domain.Realized {
+			continue
+		}
+		for _, subdomain := range domain.Subdomains {
+			if _, ok := subdomain.Classes[classKey]; ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func infinite(n *me.SetConstant) bool {
+	return n.Kind != me.SetConstantBoolean
+}
+
+func isSeqSet(n *me.BuiltinCall) bool {
+	return n.Module == moduleSeq && (n.Function == funcSeq || n.Function == funcSeqUnique)
+}
+
+func isIntLiteral(expr me.Expression) bool {
+	_, ok := expr.(*me.IntLiteral)
+	return ok
+}
+
+func setConstantName(kind me.SetConstantKind) string {
+	switch kind {
+	case me.SetConstantNat:
+		return "Nat"
+	case me.SetConstantInt:
+		return "Int"
+	case me.SetConstantReal:
+		return "Real"
+	default:
+		return "BOOLEAN"
+	}
+}
+
+func rank(classification Classification) int {
+	switch classification {
+	case NonExecutable:
+		return 2
+	case ExecutableWithBound:
+		return 1
+	default:
+		return 0
+	}
+}
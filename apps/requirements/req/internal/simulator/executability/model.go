@@ -0,0 +1,138 @@
+package executability
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	me "github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/notation/tla_plus/convert"
+)
+
+// modelAnalyzer walks the logic of a model, analyzing each specification in turn.
+type modelAnalyzer struct {
+	model           *core.Model
+	globalFunctions map[string]identity.Key
+	namedSets       map[string]identity.Key
+	allActions      map[string]identity.Key
+	classNames      map[string]identity.Key
+
+	// populated holds the classes a simulation creates instances of.
+	populated map[identity.Key]bool
+	// recursive holds the global functions declared recursive.
+	recursive map[identity.Key]bool
+
+	findings []Finding
+}
+
+func newModelAnalyzer(model *core.Model) *modelAnalyzer {
+	a := &modelAnalyzer{
+		model:           model,
+		globalFunctions: convert.BuildGlobalFunctionMap(model),
+		namedSets:       convert.BuildNamedSetMap(model),
+		allActions:      convert.BuildAllActionsMap(model),
+		classNames:      convert.BuildClassNameMap(model),
+		populated:       make(map[identity.Key]bool),
+		recursive:       make(map[identity.Key]bool),
+	}
+	for _, domain := range model.Domains {
+		if domain.Realized {
+			continue
+		}
+		for _, subdomain := range domain.Subdomains {
+			for classKey := range subdomain.Classes {
+				a.populated[classKey] = true
+			}
+		}
+	}
+	for gfKey, gf := range model.GlobalFunctions {
+		if gf.Recursive {
+			a.recursive[gfKey] = true
+		}
+	}
+	return a
+}
+
+func (a *modelAnalyzer) analyzeModel() {
+	modelCtx := &convert.LowerContext{
+		GlobalFunctions: a.globalFunctions,
+		NamedSets:       a.namedSets,
+		ClassNames:      a.classNames,
+		AllActions:      a.allActions,
+	}
+
+	for i := range a.model.Invariants {
+		a.analyzeLogic(&a.model.Invariants[i], modelCtx, identity.Key{})
+	}
+	for _, gf := range a.model.GlobalFunctions {
+		a.analyzeLogic(&gf.Logic, modelCtx, identity.Key{})
+	}
+	for _, ns := range a.model.NamedSets {
+		logic := model_logic.Logic{Key: ns.Key, Spec: ns.Spec}
+		a.analyzeLogic(&logic, modelCtx, identity.Key{})
+	}
+
+	associations := a.model.GetClassAssociations()
+	for _, domain := range a.model.Domains {
+		if domain.Realized {
+			continue
+		}
+		for _, subdomain := range domain.Subdomains {
+			for _, class := range subdomain.Classes {
+				classCtx := convert.NewClassLowerContext(&class, a.globalFunctions, a.namedSets, a.allActions, associations, subdomain.Classes)
+				a.analyzeClass(&class, classCtx)
+			}
+		}
+	}
+}
+
+func (a *modelAnalyzer) analyzeClass(class *model_class.Class, classCtx *convert.LowerContext) {
+	a.analyzeLogics(class.Invariants, classCtx, class.Key)
+	for _, attr := range class.Attributes {
+		if attr.DerivationPolicy != nil {
+			a.analyzeLogic(attr.DerivationPolicy, classCtx, class.Key)
+		}
+		a.analyzeLogics(attr.Invariants, classCtx, class.Key)
+	}
+	for _, guard := range class.Guards {
+		a.analyzeLogic(&guard.Logic, classCtx, class.Key)
+	}
+	for _, action := range class.Actions {
+		actCtx := convert.ContextWithParameters(classCtx, action.Parameters)
+		a.analyzeLogics(action.Requires, actCtx, class.Key)
+		a.analyzeLogics(action.Guarantees, actCtx, class.Key)
+		a.analyzeLogics(action.SafetyRules, actCtx, class.Key)
+		for _, param := range action.Parameters {
+			a.analyzeLogics(param.Invariants, actCtx, class.Key)
+		}
+	}
+	for _, query := range class.Queries {
+		qCtx := convert.ContextWithParameters(classCtx, query.Parameters)
+		a.analyzeLogics(query.Requires, qCtx, class.Key)
+		a.analyzeLogics(query.Guarantees, qCtx, class.Key)
+		for _, param := range query.Parameters {
+			a.analyzeLogics(param.Invariants, qCtx, class.Key)
+		}
+	}
+}
+
+func (a *modelAnalyzer) analyzeLogics(logics []model_logic.Logic, ctx *convert.LowerContext, classKey identity.Key) {
+	for i := range logics {
+		a.analyzeLogic(&logics[i], ctx, classKey)
+	}
+}
+
+func (a *modelAnalyzer) analyzeLogic(logic *model_logic.Logic, ctx *convert.LowerContext, classKey identity.Key) {
+	if logic.Spec.Expression == nil {
+		return
+	}
+	s := &specAnalyzer{
+		model:    a,
+		logic:    logic,
+		classKey: classKey,
+		raise:    convert.RaiseContextFromLower(ctx),
+		covered:  make(map[me.Expression]bool),
+	}
+	s.walk(logic.Spec.Expression)
+	a.findings = append(a.findings, s.findings...)
+}
@@ -90,6 +90,12 @@ func collectAttributeRefs(expr me.Expression, out map[identity.Key]bool) {
 	case *me.LetExpr:
 		collectAttributeRefs(e.Value, out)
 		collectAttributeRefs(e.Body, out)
+	case *me.FunctionDef:
+		collectAttributeRefs(e.Domain, out)
+		collectAttributeRefs(e.Value, out)
+		collectAttributeRefs(e.Body, out)
+	case *me.FunctionApply:
+		collectAttributeRefs(e.Arg, out)
 	case *me.Choose:
 		collectAttributeRefs(e.Set, out)
 		collectAttributeRefs(e.Predicate, out)
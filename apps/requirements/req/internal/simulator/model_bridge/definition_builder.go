@@ -419,6 +419,10 @@ func ContainsAnyPrimedME(expr me.Expression) bool {
 		return ContainsAnyPrimedME(e.Condition) || ContainsAnyPrimedME(e.Then) || ContainsAnyPrimedME(e.Else)
 	case *me.LetExpr:
 		return ContainsAnyPrimedME(e.Value) || ContainsAnyPrimedME(e.Body)
+	case *me.FunctionDef:
+		return ContainsAnyPrimedME(e.Domain) || ContainsAnyPrimedME(e.Value) || ContainsAnyPrimedME(e.Body)
+	case *me.FunctionApply:
+		return ContainsAnyPrimedME(e.Arg)
 	case *me.Choose:
 		return ContainsAnyPrimedME(e.Set) || ContainsAnyPrimedME(e.Predicate)
 	case *me.Case:
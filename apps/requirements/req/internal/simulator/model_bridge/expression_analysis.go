@@ -105,6 +105,10 @@ func containsGlobalCallControlFlow(expr me.Expression) bool {
 		return ContainsGlobalCall(e.Condition) || ContainsGlobalCall(e.Then) || ContainsGlobalCall(e.Else)
 	case *me.LetExpr:
 		return ContainsGlobalCall(e.Value) || ContainsGlobalCall(e.Body)
+	case *me.FunctionDef:
+		return ContainsGlobalCall(e.Domain) || ContainsGlobalCall(e.Value) || ContainsGlobalCall(e.Body)
+	case *me.FunctionApply:
+		return ContainsGlobalCall(e.Arg)
 	case *me.Choose:
 		return ContainsGlobalCall(e.Set) || ContainsGlobalCall(e.Predicate)
 	case *me.Case:
@@ -66,11 +66,11 @@ func (s *ExtractorTestSuite) TestExtractModelInvariants_Empty() {
 func (s *ExtractorTestSuite) TestExtractGlobalFunctions() {
 	gfuncMaxKey := helper.Must(identity.NewGlobalFunctionKey("_Max"))
 	gfuncMaxLogic := model_logic.NewLogic(gfuncMaxKey, model_logic.LogicTypeValue, "Max of two values.", "", parsedSpec("IF x > y THEN x ELSE y", "x", "y"), nil)
-	gfuncMax := model_logic.NewGlobalFunction(gfuncMaxKey, "_Max", []string{"x", "y"}, gfuncMaxLogic)
+	gfuncMax := model_logic.NewGlobalFunction(gfuncMaxKey, "_Max", []string{"x", "y"}, false, gfuncMaxLogic)
 
 	gfuncStatusKey := helper.Must(identity.NewGlobalFunctionKey("_ValidStatuses"))
 	gfuncStatusLogic := model_logic.NewLogic(gfuncStatusKey, model_logic.LogicTypeValue, "Valid status set.", "", parsedSpec(`{"pending", "active", "complete"}`), nil)
-	gfuncStatus := model_logic.NewGlobalFunction(gfuncStatusKey, "_ValidStatuses", []string{}, gfuncStatusLogic)
+	gfuncStatus := model_logic.NewGlobalFunction(gfuncStatusKey, "_ValidStatuses", []string{}, false, gfuncStatusLogic)
 
 	globalFunctions := map[identity.Key]model_logic.GlobalFunction{
 		gfuncMaxKey:    gfuncMax,
@@ -308,7 +308,7 @@ func (s *ExtractorTestSuite) TestExtractFromModel_Combined() {
 	// Global function
 	gfuncKey := helper.Must(identity.NewGlobalFunctionKey("_LowStockThreshold"))
 	gfuncLogic := model_logic.NewLogic(gfuncKey, model_logic.LogicTypeValue, "Low stock threshold.", "", parsedSpec("10"), nil)
-	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_LowStockThreshold", nil, gfuncLogic)
+	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_LowStockThreshold", nil, false, gfuncLogic)
 
 	globalFunctions := map[identity.Key]model_logic.GlobalFunction{
 		gfuncKey: gfunc,
@@ -95,7 +95,7 @@ func (s *LoaderTestSuite) TestLoadGlobalFunctions() {
 	s.Require().NoError(err)
 
 	gfuncLogic := model_logic.NewLogic(gfuncKey, model_logic.LogicTypeValue, "Max of two values.", "", parsedSpec("IF x > y THEN x ELSE y", "x", "y"), nil)
-	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_Max", []string{"x", "y"}, gfuncLogic)
+	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_Max", []string{"x", "y"}, false, gfuncLogic)
 
 	globalFunctions := map[identity.Key]model_logic.GlobalFunction{
 		gfuncKey: gfunc,
@@ -124,7 +124,7 @@ func (s *LoaderTestSuite) TestLoadGlobalFunctions_NoParams() {
 	s.Require().NoError(err)
 
 	gfuncLogic := model_logic.NewLogic(gfuncKey, model_logic.LogicTypeValue, "Status set.", "", parsedSpec(`{"pending", "active"}`), nil)
-	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_StatusSet", []string{}, gfuncLogic)
+	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_StatusSet", []string{}, false, gfuncLogic)
 
 	globalFunctions := map[identity.Key]model_logic.GlobalFunction{
 		gfuncKey: gfunc,
@@ -341,7 +341,7 @@ func (s *LoaderTestSuite) TestLoadCombined() {
 	gfuncKey, err := identity.NewGlobalFunctionKey("_Threshold")
 	s.Require().NoError(err)
 	gfuncLogic := model_logic.NewLogic(gfuncKey, model_logic.LogicTypeValue, "Threshold value.", "", parsedSpec("10"), nil)
-	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_Threshold", nil, gfuncLogic)
+	gfunc := model_logic.NewGlobalFunction(gfuncKey, "_Threshold", nil, false, gfuncLogic)
 
 	globalFunctions := map[identity.Key]model_logic.GlobalFunction{
 		gfuncKey: gfunc,
@@ -178,37 +178,43 @@ func (n *Number) Div(other *Number) *Number {
 	return &Number{rat: result}
 }
 
-// IntDiv returns a new Number that is the integer division of n by other.
-// Both operands must be integers (not Rational or Real).
+// IntDiv returns a new Number that is the integer division of n by other (TLA+ \div).
+// Both operands must be integers and the divisor must be positive; the quotient
+// rounds toward negative infinity, so -7 \div 2 = -4.
 func (n *Number) IntDiv(other *Number) (*Number, error) {
-	if n.real || other.real {
-		return nil, fmt.Errorf("integer division requires integer operands, got Real")
-	}
-	if n.Kind() == KindRational || other.Kind() == KindRational {
-		return nil, fmt.Errorf("integer division requires integer operands")
-	}
-	if other.rat.Sign() == 0 {
-		return nil, fmt.Errorf("division by zero")
+	if err := checkIntegerDivision(n, other); err != nil {
+		return nil, err
 	}
-	// Perform integer division: floor(n/other) for positive, toward zero for mixed signs
-	quo := new(big.Int).Quo(n.rat.Num(), other.rat.Num())
+	quo := new(big.Int).Div(n.rat.Num(), other.rat.Num())
 	return &Number{rat: new(big.Rat).SetInt(quo)}, nil
 }
 
-// Mod returns a new Number that is the remainder of n divided by other.
-// Both operands must be integers (not Rational or Real).
+// Mod returns a new Number that is the remainder of n divided by other (TLA+ %).
+// Both operands must be integers and the divisor must be positive; the result is
+// always in 0..other-1, so -7 % 2 = 1.
 func (n *Number) Mod(other *Number) (*Number, error) {
+	if err := checkIntegerDivision(n, other); err != nil {
+		return nil, err
+	}
+	mod := new(big.Int).Mod(n.rat.Num(), other.rat.Num())
+	return &Number{rat: new(big.Rat).SetInt(mod)}, nil
+}
+
+// checkIntegerDivision enforces the Integers module domain of \div and %.
+func checkIntegerDivision(n, other *Number) error {
 	if n.real || other.real {
-		return nil, fmt.Errorf("modulo requires integer operands, got Real")
+		return fmt.Errorf("integer division requires integer operands, got Real")
 	}
 	if n.Kind() == KindRational || other.Kind() == KindRational {
-		return nil, fmt.Errorf("modulo requires integer operands")
+		return fmt.Errorf("integer division requires integer operands")
 	}
 	if other.rat.Sign() == 0 {
-		return nil, fmt.Errorf("division by zero")
+		return fmt.Errorf("division by zero")
 	}
-	rem := new(big.Int).Rem(n.rat.Num(), other.rat.Num())
-	return &Number{rat: new(big.Rat).SetInt(rem)}, nil
+	if other.rat.Sign() < 0 {
+		return fmt.Errorf("integer division requires a positive divisor, got %s", other.rat.RatString())
+	}
+	return nil
 }
 
 // Neg returns a new Number that is the negation of n.
@@ -233,6 +233,14 @@ func (s *NumberSuite) TestIntDiv() {
 	n2 = NewNatural(3)
 	_, err = n1.IntDiv(n2)
 	s.Require().Error(err)
+	// Rounds toward negative infinity
+	result, err = NewInteger(-7).IntDiv(NewNatural(2))
+	s.Require().NoError(err)
+	s.Equal("-4", result.Inspect())
+
+	// Divisor must be positive
+	_, err = NewNatural(7).IntDiv(NewInteger(-2))
+	s.Require().ErrorContains(err, "positive divisor")
 }
 
 func (s *NumberSuite) TestMod() {
@@ -247,6 +255,14 @@ func (s *NumberSuite) TestMod() {
 	n2 = NewNatural(0)
 	_, err = n1.Mod(n2)
 	s.Require().Error(err)
+	// Result is never negative
+	result, err = NewInteger(-7).Mod(NewNatural(2))
+	s.Require().NoError(err)
+	s.Equal("1", result.Inspect())
+
+	// Divisor must be positive
+	_, err = NewNatural(7).Mod(NewInteger(-2))
+	s.Require().ErrorContains(err, "positive divisor")
 }
 
 func (s *NumberSuite) TestNeg() {
@@ -192,6 +192,12 @@ func walkIdentifiersControlFlow(expr me.Expression, result map[string]bool) {
 	case *me.LetExpr:
 		walkIdentifiersIR(e.Value, result)
 		walkIdentifiersIR(e.Body, result)
+	case *me.FunctionDef:
+		walkIdentifiersIR(e.Domain, result)
+		walkIdentifiersIR(e.Value, result)
+		walkIdentifiersIR(e.Body, result)
+	case *me.FunctionApply:
+		walkIdentifiersIR(e.Arg, result)
 	case *me.Choose:
 		walkIdentifiersIR(e.Set, result)
 		walkIdentifiersIR(e.Predicate, result)
@@ -344,6 +344,11 @@ func collectAssocNavControlFlow(
 		collectAssociationClassKeys(e.Else, ownerClassKey, nav, required)
 	case *me.LetExpr:
 		collectAssocNavPair(e.Value, e.Body, ownerClassKey, nav, required)
+	case *me.FunctionDef:
+		collectAssociationClassKeys(e.Domain, ownerClassKey, nav, required)
+		collectAssocNavPair(e.Value, e.Body, ownerClassKey, nav, required)
+	case *me.FunctionApply:
+		collectAssociationClassKeys(e.Arg, ownerClassKey, nav, required)
 	case *me.Choose:
 		collectAssocNavPair(e.Set, e.Predicate, ownerClassKey, nav, required)
 	case *me.Case:
@@ -0,0 +1,107 @@
+package sourcepos
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// quoting is how a string value is written in its source file.
+type quoting int
+
+const (
+	unquoted     quoting = iota // Plain and block scalars: characters appear as themselves.
+	singleQuoted                // YAML single quotes: '' stands for one quote.
+	doubleQuoted                // YAML and JSON double quotes: backslash escapes.
+)
+
+// align maps every byte of a decoded string value to the byte offset in content of the
+// character it was decoded from, scanning content from start, the first character of the
+// value's source text. The result has one extra entry for the offset just past the value.
+//
+// Characters the decoding dropped (indentation, quotes, escape backslashes, continuation
+// line breaks) are skipped, and a space or line break that folding produced matches the
+// run of whitespace it replaced.
+func align(value, content string, start int, q quoting) []int {
+	offsets := make([]int, len(value)+1)
+	next := start
+	for i := 0; i < len(value); {
+		r, size := utf8.DecodeRuneInString(value[i:])
+		at, after, ok := matchRune(content, next, r, q)
+		if !ok {
+			// The source ran out before the value did; point the rest at the end.
+			at, after = min(next, len(content)), next
+		}
+		for b := range size {
+			offsets[i+b] = at
+		}
+		next = after
+		i += size
+	}
+	offsets[len(value)] = min(next, len(content))
+	return offsets
+}
+
+// matchRune finds the source text of the decoded rune r at or after offset from,
+// returning where it starts and where the scan resumes.
+func matchRune(content string, from int, r rune, q quoting) (at, after int, ok bool) {
+	for pos := from; pos < len(content); {
+		c, size := utf8.DecodeRuneInString(content[pos:])
+		switch {
+		case q == doubleQuoted && c == '\\':
+			if pos+1 < len(content) && (content[pos+1] == '\n' || content[pos+1] == '\r') {
+				// An escaped line break continues the value on the next line.
+				pos = skipWhitespace(content, pos+1)
+				continue
+			}
+			return pos, pos + escapeLength(content, pos), true
+		case q == singleQuoted && c == '\'' && r == '\'' && strings.HasPrefix(content[pos:], "''"):
+			return pos, pos + 2, true
+		case c == r:
+			return pos, pos + size, true
+		case unicode.IsSpace(r) && unicode.IsSpace(c):
+			// Folding turned a line break and its indentation into r.
+			return pos, skipWhitespace(content, pos), true
+		}
+		pos += size
+	}
+	return 0, 0, false
+}
+
+// escapeLength returns the length of the backslash escape at content[pos].
+func escapeLength(content string, pos int) int {
+	if pos+1 >= len(content) {
+		return 1
+	}
+	switch content[pos+1] {
+	case 'x':
+		return min(4, len(content)-pos)
+	case 'u':
+		// A JSON surrogate pair spells one character with two escapes.
+		if pos+12 <= len(content) && isHighSurrogate(content[pos+2:pos+6]) && content[pos+6:pos+8] == `\u` {
+			return 12
+		}
+		return min(6, len(content)-pos)
+	case 'U':
+		return min(10, len(content)-pos)
+	}
+	_, size := utf8.DecodeRuneInString(content[pos+1:])
+	return 1 + size
+}
+
+// isHighSurrogate reports whether four hex digits name a UTF-16 high surrogate.
+func isHighSurrogate(hex string) bool {
+	return len(hex) == 4 && (hex[0] == 'd' || hex[0] == 'D') && strings.ContainsRune("89abAB", rune(hex[1]))
+}
+
+// skipWhitespace returns the offset of the first non-whitespace character at or after pos.
+func skipWhitespace(content string, pos int) int {
+	for pos < len(content) {
+		c, size := utf8.DecodeRuneInString(content[pos:])
+		if !unicode.IsSpace(c) {
+			break
+		}
+		pos += size
+	}
+	return pos
+}
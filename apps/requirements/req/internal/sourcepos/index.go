@@ -0,0 +1,284 @@
+package sourcepos
+
+import (
+	"encoding/json"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/pkg/errors"
+	"gopkg.in/yaml.v3"
+)
+
+// Index records where the string values of a model's source files are, so that an
+// offset inside a decoded value can be reported as a position in its file.
+// A nil *Index is valid and locates nothing.
+type Index struct {
+	files  []*sourceFile
+	byPath map[string]*sourceFile
+	scopes map[string]string // Entity key -> file or directory holding its source.
+}
+
+// sourceFile is one indexed file.
+type sourceFile struct {
+	path    string
+	content string
+	values  []stringValue
+	fields  map[string]int // JSON field path -> byte offset of its value.
+}
+
+// stringValue is one decoded string and where each of its bytes came from.
+type stringValue struct {
+	text    string
+	offsets []int
+}
+
+// NewIndex returns an empty index.
+func NewIndex() *Index {
+	return &Index{
+		byPath: make(map[string]*sourceFile),
+		scopes: make(map[string]string),
+	}
+}
+
+// AddYAML indexes the string values of a YAML document that starts at byte dataOffset of
+// content, the file's complete text. Positions are reported against the whole file.
+func (idx *Index) AddYAML(path, content string, dataOffset int) error {
+	data := content[dataOffset:]
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(data), &root); err != nil {
+		return errors.WithStack(err)
+	}
+	file := idx.addFile(path, content)
+	file.addYAMLNode(&root, data, dataOffset)
+	return nil
+}
+
+func (f *sourceFile) addYAMLNode(node *yaml.Node, data string, dataOffset int) {
+	if node.Kind == yaml.ScalarNode {
+		start := dataOffset + offsetOf(data, node.Line, node.Column)
+		q := unquoted
+		switch {
+		case node.Style&(yaml.LiteralStyle|yaml.FoldedStyle) != 0:
+			// The value starts on the line after its | or > indicator.
+			if newline := strings.IndexByte(f.content[start:], '\n'); newline >= 0 {
+				start += newline + 1
+			}
+		case node.Style&yaml.DoubleQuotedStyle != 0:
+			start++
+			q = doubleQuoted
+		case node.Style&yaml.SingleQuotedStyle != 0:
+			start++
+			q = singleQuoted
+		}
+		f.values = append(f.values, stringValue{text: node.Value, offsets: align(node.Value, f.content, start, q)})
+		return
+	}
+	for _, child := range node.Content {
+		f.addYAMLNode(child, data, dataOffset)
+	}
+}
+
+// AddJSON indexes the string values and field paths of a JSON document.
+func (idx *Index) AddJSON(path, content string) error {
+	file := idx.addFile(path, content)
+	file.fields = make(map[string]int)
+
+	// frame is one open object or array.
+	type frame struct {
+		path    string
+		object  bool
+		key     string
+		wantKey bool
+		index   int
+	}
+	var stack []*frame
+	childPath := func() string {
+		if len(stack) == 0 {
+			return ""
+		}
+		top := stack[len(stack)-1]
+		if !top.object {
+			return top.path + "[" + strconv.Itoa(top.index) + "]"
+		}
+		if top.path == "" {
+			return top.key
+		}
+		return top.path + "." + top.key
+	}
+	valueDone := func() {
+		if len(stack) == 0 {
+			return
+		}
+		top := stack[len(stack)-1]
+		if top.object {
+			top.wantKey = true
+		} else {
+			top.index++
+		}
+	}
+
+	dec := json.NewDecoder(strings.NewReader(content))
+	for {
+		before := int(dec.InputOffset())
+		tok, err := dec.Token()
+		if err == io.EOF {
+			if len(stack) > 0 {
+				return errors.WithStack(io.ErrUnexpectedEOF)
+			}
+			return nil
+		}
+		if err != nil {
+			return errors.WithStack(err)
+		}
+		start := tokenStart(content, before)
+
+		if delim, ok := tok.(json.Delim); ok && (delim == '}' || delim == ']') {
+			stack = stack[:len(stack)-1]
+			valueDone()
+			continue
+		}
+		if len(stack) > 0 && stack[len(stack)-1].wantKey {
+			top := stack[len(stack)-1]
+			top.key, _ = tok.(string)
+			top.wantKey = false
+			continue
+		}
+
+		path := childPath()
+		file.fields[path] = start
+		switch value := tok.(type) {
+		case json.Delim:
+			stack = append(stack, &frame{path: path, object: value == '{', wantKey: value == '{'})
+			continue
+		case string:
+			file.values = append(file.values, stringValue{text: value, offsets: align(value, content, start+1, doubleQuoted)})
+		}
+		valueDone()
+	}
+}
+
+// tokenStart returns the offset of the JSON token that follows offset, past whitespace and separators.
+func tokenStart(content string, offset int) int {
+	for offset < len(content) && strings.IndexByte(" \t\r\n,:", content[offset]) >= 0 {
+		offset++
+	}
+	return offset
+}
+
+func (idx *Index) addFile(path, content string) *sourceFile {
+	file := &sourceFile{path: path, content: content}
+	if existing, ok := idx.byPath[path]; ok {
+		// Re-adding a file replaces what was indexed for it.
+		*existing = *file
+		return existing
+	}
+	idx.files = append(idx.files, file)
+	idx.byPath[path] = file
+	return file
+}
+
+// SetScope records the file, or the directory of files, that holds the source of the
+// entity with the given key. Locate searches an entity's scope before the other files.
+func (idx *Index) SetScope(key, path string) {
+	idx.scopes[key] = path
+}
+
+// Locate returns the position of the character at byte offset within a string value
+// that reads text, searching the scope of key first. A value equal to text is preferred
+// over one that contains it. The zero Position means the text was not found.
+func (idx *Index) Locate(key, text string, offset int) Position {
+	if idx == nil || text == "" {
+		return Position{}
+	}
+	offset = max(0, min(offset, len(text)))
+	scope := idx.scopes[key]
+	inScope := func(file *sourceFile) bool {
+		return scope != "" && (file.path == scope || strings.HasPrefix(file.path, scope+string(filepath.Separator)))
+	}
+	for _, preferScope := range []bool{true, false} {
+		if preferScope && scope == "" {
+			continue
+		}
+		for _, exact := range []bool{true, false} {
+			for _, file := range idx.files {
+				if inScope(file) != preferScope {
+					continue
+				}
+				if pos, ok := file.locate(text, offset, exact); ok {
+					return pos
+				}
+			}
+		}
+	}
+	return Position{}
+}
+
+func (f *sourceFile) locate(text string, offset int, exact bool) (Position, bool) {
+	for _, value := range f.values {
+		start := 0
+		if exact {
+			if value.text != text {
+				continue
+			}
+		} else {
+			start = strings.Index(value.text, text)
+			if start < 0 {
+				continue
+			}
+		}
+		return At(f.path, f.content, value.offsets[start+offset]), true
+	}
+	return Position{}, false
+}
+
+// LocateField returns the position of the value at a JSON field path such as
+// "states.open.actions[0].when" in an indexed file. When the field is missing the
+// position of its closest enclosing value is returned, so a missing field points at
+// the object that should hold it.
+func (idx *Index) LocateField(path, field string) Position {
+	if idx == nil {
+		return Position{}
+	}
+	file, ok := idx.byPath[path]
+	if !ok || file.fields == nil {
+		return Position{}
+	}
+	for {
+		if offset, ok := file.fields[field]; ok {
+			return At(file.path, file.content, offset)
+		}
+		if field == "" {
+			return Position{}
+		}
+		field = parentField(field)
+	}
+}
+
+// parentField drops the last member or index from a field path.
+func parentField(field string) string {
+	cut := max(strings.LastIndexByte(field, '.'), strings.LastIndexByte(field, '['))
+	if cut < 0 {
+		return ""
+	}
+	return field[:cut]
+}
+
+// offsetOf returns the byte offset of a 1-based line and column, counted in characters, in text.
+func offsetOf(text string, line, column int) int {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := strings.IndexByte(text[offset:], '\n')
+		if next < 0 {
+			return len(text)
+		}
+		offset += next + 1
+	}
+	for i := 1; i < column && offset < len(text); i++ {
+		_, size := utf8.DecodeRuneInString(text[offset:])
+		offset += size
+	}
+	return offset
+}
@@ -0,0 +1,153 @@
+package sourcepos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type IndexSuite struct {
+	suite.Suite
+}
+
+func TestIndexSuite(t *testing.T) {
+	suite.Run(t, new(IndexSuite))
+}
+
+// yamlFile is a class file whose data section follows markdown, with a specification
+// written in each YAML scalar style.
+const yamlFile = `# Account
+
+Notes.
+
+◇
+
+invariants:
+    - plain: self.balance >= 0
+    - double: "self.limit \\in 1..\"x\""
+    - single: 'self.name = ''bob'''
+    - literal: |
+        \A x \in self.items :
+            x.price > 0
+    - folded: >
+        self.a = 1
+        /\ self.b = 2
+    - multiline: self.first
+        /\ self.second
+`
+
+func (suite *IndexSuite) yamlIndex() *Index {
+	idx := NewIndex()
+	suite.Require().NoError(idx.AddYAML("domain/account.class", yamlFile, len("# Account\n\nNotes.\n\n◇\n\n")))
+	return idx
+}
+
+func (suite *IndexSuite) TestLocateYAML() {
+	idx := suite.yamlIndex()
+	tests := []struct {
+		testName string
+		text     string
+		offset   int
+		line     int
+		column   int
+	}{
+		{testName: "plain start", text: "self.balance >= 0", offset: 0, line: 8, column: 14},
+		{testName: "plain inside", text: "self.balance >= 0", offset: 13, line: 8, column: 27},
+		{testName: "double quoted skips the quote", text: `self.limit \in 1.."x"`, offset: 0, line: 9, column: 16},
+		{testName: "double quoted escape", text: `self.limit \in 1.."x"`, offset: 11, line: 9, column: 27},
+		{testName: "double quoted after escape", text: `self.limit \in 1.."x"`, offset: 19, line: 9, column: 37},
+		{testName: "single quoted doubled quote", text: "self.name = 'bob'", offset: 13, line: 10, column: 30},
+		{testName: "literal first line", text: "\\A x \\in self.items :\n    x.price > 0\n", offset: 0, line: 12, column: 9},
+		{testName: "literal second line", text: "\\A x \\in self.items :\n    x.price > 0\n", offset: 26, line: 13, column: 13},
+		{testName: "folded second line", text: "self.a = 1 /\\ self.b = 2\n", offset: 14, line: 16, column: 12},
+		{testName: "plain multi line", text: "self.first /\\ self.second", offset: 14, line: 18, column: 12},
+		{testName: "contained text", text: "x.price > 0", offset: 2, line: 13, column: 15},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			suite.Equal(Position{File: "domain/account.class", Line: tt.line, Column: tt.column}, idx.Locate("", tt.text, tt.offset))
+		})
+	}
+}
+
+func (suite *IndexSuite) TestLocateMissing() {
+	suite.Equal(Position{}, suite.yamlIndex().Locate("", "no such text", 0))
+	var idx *Index
+	suite.Equal(Position{}, idx.Locate("", "self.balance >= 0", 0))
+	suite.Equal(Position{}, idx.LocateField("model.json", "name"))
+}
+
+func (suite *IndexSuite) TestLocatePrefersScope() {
+	idx := NewIndex()
+	suite.Require().NoError(idx.AddJSON("m/classes/a/class.json", `{"invariant": "x > 0"}`))
+	suite.Require().NoError(idx.AddJSON("m/classes/b/class.json", `{"invariant":  "x > 0"}`))
+	idx.SetScope("class/b", "m/classes/b")
+
+	suite.Equal(Position{File: "m/classes/a/class.json", Line: 1, Column: 16}, idx.Locate("class/a", "x > 0", 0))
+	suite.Equal(Position{File: "m/classes/b/class.json", Line: 1, Column: 17}, idx.Locate("class/b", "x > 0", 0))
+}
+
+// jsonFile is a class file with an escaped multi-line specification.
+const jsonFile = `{
+  "name": "Account",
+  "attributes": {
+    "balance": {"name": "Balance", "type_spec": "Nat"}
+  },
+  "invariants": [
+    {"specification": "self.a = \"x\\ny\" /\\\n\u00e9 = 1"},
+    {"specification": "🙂 > q"}
+  ]
+}`
+
+func (suite *IndexSuite) TestLocateJSON() {
+	idx := NewIndex()
+	suite.Require().NoError(idx.AddJSON("class.json", jsonFile))
+	tests := []struct {
+		testName string
+		text     string
+		offset   int
+		column   int
+	}{
+		{testName: "start", text: "self.a = \"x\\ny\" /\\\né = 1", offset: 0, column: 24},
+		{testName: "escaped quote", text: "self.a = \"x\\ny\" /\\\né = 1", offset: 9, column: 33},
+		{testName: "after escaped newline", text: "self.a = \"x\\ny\" /\\\né = 1", offset: 19, column: 48},
+		{testName: "after unicode escape", text: "self.a = \"x\\ny\" /\\\né = 1", offset: 21, column: 54},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			suite.Equal(Position{File: "class.json", Line: 7, Column: tt.column}, idx.Locate("", tt.text, tt.offset))
+		})
+	}
+	suite.Equal(Position{File: "class.json", Line: 8, Column: 28}, idx.Locate("", "🙂 > q", 7))
+}
+
+func (suite *IndexSuite) TestLocateField() {
+	idx := NewIndex()
+	suite.Require().NoError(idx.AddJSON("class.json", jsonFile))
+	tests := []struct {
+		testName string
+		field    string
+		line     int
+		column   int
+	}{
+		{testName: "root", field: "", line: 1, column: 1},
+		{testName: "top level", field: "name", line: 2, column: 11},
+		{testName: "nested", field: "attributes.balance.type_spec", line: 4, column: 49},
+		{testName: "array element", field: "invariants[1]", line: 8, column: 5},
+		{testName: "array element field", field: "invariants[1].specification", line: 8, column: 23},
+		{testName: "missing field points at its object", field: "attributes.balance.details", line: 4, column: 16},
+		{testName: "missing index points at its array", field: "invariants[5].specification", line: 6, column: 17},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			suite.Equal(Position{File: "class.json", Line: tt.line, Column: tt.column}, idx.LocateField("class.json", tt.field))
+		})
+	}
+	suite.Equal(Position{}, idx.LocateField("other.json", "name"))
+}
+
+func (suite *IndexSuite) TestInvalidSource() {
+	idx := NewIndex()
+	suite.Error(idx.AddYAML("bad.class", "a: [", 0))
+	suite.Error(idx.AddJSON("bad.json", `{"a": `))
+}
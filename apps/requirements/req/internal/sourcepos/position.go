@@ -0,0 +1,79 @@
+// Package sourcepos locates diagnostics in model source files.
+//
+// Parsers report problems against values they decoded from YAML or JSON: a class file
+// that does not parse, or a character inside a multi-line specification string. An Index
+// remembers where every string value sits in its file, so a byte offset inside a decoded
+// value maps back to the line and column of the exact character in the original file,
+// through quoting, escapes, block scalar indentation, and line folding.
+package sourcepos
+
+import (
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Position is a location in a source file. Line and Column are 1-based and the
+// column counts characters, not bytes. The zero value means the location is unknown.
+type Position struct {
+	File   string // Path of the source file.
+	Line   int    // Line number, 0 when only the file is known.
+	Column int    // Column number, 0 when only the line is known.
+}
+
+// IsZero reports whether the position is unknown.
+func (p Position) IsZero() bool {
+	return p == Position{}
+}
+
+// String formats the position as file:line:column, leaving off the parts that are unknown.
+func (p Position) String() string {
+	parts := make([]string, 0, 3)
+	if p.File != "" {
+		parts = append(parts, p.File)
+	}
+	if p.Line > 0 {
+		parts = append(parts, strconv.Itoa(p.Line))
+		if p.Column > 0 {
+			parts = append(parts, strconv.Itoa(p.Column))
+		}
+	}
+	return strings.Join(parts, ":")
+}
+
+// Prefix returns the position followed by ": ", ready to lead a message, or "" when the position is unknown.
+func (p Position) Prefix() string {
+	if p.IsZero() {
+		return ""
+	}
+	return p.String() + ": "
+}
+
+// At returns the position of the byte offset in content, a file's complete text.
+// Offsets past the end are clamped to the end.
+func At(file, content string, offset int) Position {
+	offset = max(0, min(offset, len(content)))
+	lineStart := strings.LastIndexByte(content[:offset], '\n') + 1
+	return Position{
+		File:   file,
+		Line:   strings.Count(content[:lineStart], "\n") + 1,
+		Column: utf8.RuneCountInString(content[lineStart:offset]) + 1,
+	}
+}
+
+// LineStart returns the byte offset of the first non-blank character of a 1-based line
+// in content, or false when content has fewer lines.
+func LineStart(content string, line int) (int, bool) {
+	offset := 0
+	for i := 1; i < line; i++ {
+		next := strings.IndexByte(content[offset:], '\n')
+		if next < 0 {
+			return 0, false
+		}
+		offset += next + 1
+	}
+	for offset < len(content) && (content[offset] == ' ' || content[offset] == '\t') {
+		offset++
+	}
+	return offset, true
+}
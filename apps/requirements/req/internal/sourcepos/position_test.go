@@ -0,0 +1,68 @@
+package sourcepos
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type PositionSuite struct {
+	suite.Suite
+}
+
+func TestPositionSuite(t *testing.T) {
+	suite.Run(t, new(PositionSuite))
+}
+
+func (suite *PositionSuite) TestString() {
+	tests := []struct {
+		testName string
+		position Position
+		expected string
+		prefix   string
+	}{
+		{testName: "unknown", position: Position{}, expected: "", prefix: ""},
+		{testName: "file only", position: Position{File: "a.class"}, expected: "a.class", prefix: "a.class: "},
+		{testName: "line", position: Position{File: "a.class", Line: 3}, expected: "a.class:3", prefix: "a.class:3: "},
+		{testName: "line and column", position: Position{File: "a.class", Line: 3, Column: 7}, expected: "a.class:3:7", prefix: "a.class:3:7: "},
+		{testName: "no file", position: Position{Line: 3, Column: 7}, expected: "3:7", prefix: "3:7: "},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			suite.Equal(tt.expected, tt.position.String())
+			suite.Equal(tt.prefix, tt.position.Prefix())
+			suite.Equal(tt.expected == "", tt.position.IsZero())
+		})
+	}
+}
+
+func (suite *PositionSuite) TestAt() {
+	content := "first\nsé cond\n\nlast"
+	tests := []struct {
+		testName string
+		offset   int
+		expected Position
+	}{
+		{testName: "start", offset: 0, expected: Position{File: "f", Line: 1, Column: 1}},
+		{testName: "end of first line", offset: 5, expected: Position{File: "f", Line: 1, Column: 6}},
+		{testName: "second line", offset: 6, expected: Position{File: "f", Line: 2, Column: 1}},
+		{testName: "columns count characters", offset: 10, expected: Position{File: "f", Line: 2, Column: 4}},
+		{testName: "empty line", offset: 15, expected: Position{File: "f", Line: 3, Column: 1}},
+		{testName: "past the end", offset: 100, expected: Position{File: "f", Line: 4, Column: 5}},
+	}
+	for _, tt := range tests {
+		suite.Run(tt.testName, func() {
+			suite.Equal(tt.expected, At("f", content, tt.offset))
+		})
+	}
+}
+
+func (suite *PositionSuite) TestLineStart() {
+	content := "a: 1\n  b: 2\n"
+	offset, ok := LineStart(content, 2)
+	suite.Require().True(ok)
+	suite.Equal(7, offset)
+
+	_, ok = LineStart(content, 5)
+	suite.False(ok)
+}
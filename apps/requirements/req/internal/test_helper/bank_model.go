@@ -0,0 +1,247 @@
+package test_helper
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_actor"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// GetBankModel returns a small bank, for generator tests that check their whole output.
+//
+// A clerk opens accounts for customers, who hold them through a holding association
+// class. A savings account is a kind of account, and accounts post entries to a ledger
+// kept in its own subdomain. An account opens with a deposit, takes further deposits,
+// closes once its balance is over 50 and is then destroyed. The frozen state has no way
+// in, the audit event no transition and the nickname attribute no type. The ledger entry
+// has unfinished notes.
+func GetBankModel() core.Model {
+	actorKey := helper.Must(identity.NewActorKey("clerk"))
+	domainKey := helper.Must(identity.NewDomainKey("bank"))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "accounts"))
+	ledgerSubdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "ledger"))
+	clerkKey := helper.Must(identity.NewClassKey(subdomainKey, "clerk"))
+	customerKey := helper.Must(identity.NewClassKey(subdomainKey, "customer"))
+	accountKey := helper.Must(identity.NewClassKey(subdomainKey, "account"))
+	savingsKey := helper.Must(identity.NewClassKey(subdomainKey, "savings"))
+	holdingKey := helper.Must(identity.NewClassKey(subdomainKey, "holding"))
+	entryKey := helper.Must(identity.NewClassKey(ledgerSubdomainKey, "entry"))
+	genKey := helper.Must(identity.NewGeneralizationKey(subdomainKey, "account_kinds"))
+	totalKey := helper.Must(identity.NewGlobalFunctionKey("_total"))
+
+	account := bankAccount(accountKey, genKey)
+	clerk := model_class.NewClass(clerkKey, model_class.ClassLinks{ActorKey: &actorKey}, model_class.ClassDetails{Name: "Clerk"})
+	customer := model_class.NewClass(customerKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Customer"})
+	savings := model_class.NewClass(savingsKey, model_class.ClassLinks{SubclassOfKey: &genKey}, model_class.ClassDetails{Name: "Savings"})
+	holding := model_class.NewClass(holdingKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Holding"})
+	entry := model_class.NewClass(entryKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Entry", UnfinishedNotes: "Ask finance what an entry holds."})
+
+	holdsKey := helper.Must(identity.NewClassAssociationKey(subdomainKey, customerKey, accountKey, "holds"))
+	holds := model_class.NewAssociation(holdsKey, model_class.AssociationDetails{Name: "holds"},
+		model_class.AssociationEnd{ClassKey: customerKey, Multiplicity: helper.Must(model_class.NewMultiplicity("any"))},
+		model_class.AssociationEnd{ClassKey: accountKey, Multiplicity: helper.Must(model_class.NewMultiplicity("any"))},
+		model_class.AssociationOptions{AssociationClassKey: &holdingKey})
+	servesKey := helper.Must(identity.NewClassAssociationKey(subdomainKey, clerkKey, customerKey, "serves"))
+	serves := model_class.NewAssociation(servesKey, model_class.AssociationDetails{Name: "serves"},
+		model_class.AssociationEnd{ClassKey: clerkKey, Multiplicity: helper.Must(model_class.NewMultiplicity("1"))},
+		model_class.AssociationEnd{ClassKey: customerKey, Multiplicity: helper.Must(model_class.NewMultiplicity("any"))},
+		model_class.AssociationOptions{})
+	postsKey := helper.Must(identity.NewClassAssociationKey(domainKey, accountKey, entryKey, "posts"))
+	posts := model_class.NewAssociation(postsKey, model_class.AssociationDetails{Name: "posts"},
+		model_class.AssociationEnd{ClassKey: accountKey, Multiplicity: helper.Must(model_class.NewMultiplicity("1"))},
+		model_class.AssociationEnd{ClassKey: entryKey, Multiplicity: helper.Must(model_class.NewMultiplicity("any"))},
+		model_class.AssociationOptions{})
+
+	useCase := bankOpenAccount(subdomainKey, clerkKey, accountKey)
+	subdomain := model_domain.Subdomain{
+		Key:  subdomainKey,
+		Name: "Accounts",
+		Classes: map[identity.Key]model_class.Class{
+			clerkKey: clerk, customerKey: customer, accountKey: account, savingsKey: savings, holdingKey: holding,
+		},
+		Generalizations: map[identity.Key]model_class.Generalization{
+			genKey: model_class.NewGeneralization(genKey, model_class.GeneralizationDetails{Name: "Account kinds"}, "", model_class.GeneralizationTraits{IsComplete: true}, ""),
+		},
+		ClassAssociations: map[identity.Key]model_class.Association{holdsKey: holds, servesKey: serves},
+		UseCases:          map[identity.Key]model_use_case.UseCase{useCase.Key: useCase},
+	}
+	ledger := model_domain.Subdomain{Key: ledgerSubdomainKey, Name: "Ledger", Classes: map[identity.Key]model_class.Class{entryKey: entry}}
+	domain := model_domain.Domain{
+		Key:               domainKey,
+		Name:              "Bank",
+		Subdomains:        map[identity.Key]model_domain.Subdomain{subdomainKey: subdomain, ledgerSubdomainKey: ledger},
+		ClassAssociations: map[identity.Key]model_class.Association{postsKey: posts},
+	}
+	return core.Model{
+		Key:    "bank",
+		Name:   "Bank",
+		Actors: map[identity.Key]model_actor.Actor{actorKey: {Key: actorKey, Name: "Clerk", Type: "person"}},
+		Invariants: []model_logic.Logic{
+			model_logic.NewLogic(helper.Must(identity.NewInvariantKey("0")), model_logic.LogicTypeAssessment, "", "", newSpec("TRUE"), nil),
+		},
+		GlobalFunctions: map[identity.Key]model_logic.GlobalFunction{
+			totalKey: model_logic.NewGlobalFunction(totalKey, "_Total", []string{"s"}, true,
+				model_logic.NewLogic(totalKey, model_logic.LogicTypeValue, "Sum of a set.", "", newSpec(`IF s = {} THEN 0 ELSE LET x == CHOOSE y \in s : TRUE IN x + _Total(s \ {x})`), nil)),
+		},
+		Domains: map[identity.Key]model_domain.Domain{domainKey: domain},
+	}
+}
+
+// bankAccount is the account class of the bank, with its state machine.
+func bankAccount(accountKey, genKey identity.Key) model_class.Class {
+	openKey := helper.Must(identity.NewStateKey(accountKey, "open"))
+	closedKey := helper.Must(identity.NewStateKey(accountKey, "closed"))
+	frozenKey := helper.Must(identity.NewStateKey(accountKey, "frozen"))
+	newKey := helper.Must(identity.NewEventKey(accountKey, "_new"))
+	depositKey := helper.Must(identity.NewEventKey(accountKey, "deposit"))
+	closeKey := helper.Must(identity.NewEventKey(accountKey, "close"))
+	thawKey := helper.Must(identity.NewEventKey(accountKey, "thaw"))
+	auditKey := helper.Must(identity.NewEventKey(accountKey, "audit"))
+	destroyKey := helper.Must(identity.NewEventKey(accountKey, "_destroy"))
+	richKey := helper.Must(identity.NewGuardKey(accountKey, "rich"))
+	openActionKey := helper.Must(identity.NewActionKey(accountKey, "open"))
+	depositActionKey := helper.Must(identity.NewActionKey(accountKey, "deposit"))
+	closeActionKey := helper.Must(identity.NewActionKey(accountKey, "close"))
+	logActionKey := helper.Must(identity.NewActionKey(accountKey, "log"))
+	balanceQueryKey := helper.Must(identity.NewQueryKey(accountKey, "balance"))
+
+	attribute := func(subKey, rules string, nullable bool) model_class.Attribute {
+		return helper.Must(model_class.NewAttribute(helper.Must(identity.NewAttributeKey(accountKey, subKey)), model_class.AttributeDetails{Name: subKey}, rules, nil, nullable, model_class.AttributeAnnotations{}))
+	}
+	balance := attribute("balance", "[0 .. 1000] at 1 dollar", false)
+	balance.DataType.TypeSpec = natTypeSpec()
+
+	account := model_class.NewClass(accountKey, model_class.ClassLinks{SuperclassOfKey: &genKey}, model_class.ClassDetails{Name: "Account", Details: "Money held for a customer.", UmlComment: "Never shared."})
+	account.Attributes = []model_class.Attribute{
+		balance,
+		attribute("rate", "[0 .. 1) at 0.01 percent", true),
+		attribute("status", "enum of open, frozen", true),
+		attribute("owners", "unique 1-3 unordered of obj of customer", true),
+		attribute("nickname", "", true),
+	}
+	account.Invariants = []model_logic.Logic{
+		model_logic.NewLogic(helper.Must(identity.NewClassInvariantKey(accountKey, "0")), model_logic.LogicTypeAssessment, "Balance is never negative.", "", newSpec("self.balance ≥ 0"), nil),
+	}
+
+	open := model_state.NewState(openKey, "Open", "", "")
+	open.SetActions([]model_state.StateAction{
+		model_state.NewStateAction(helper.Must(identity.NewStateActionKey(openKey, "entry", "log")), logActionKey, "entry"),
+	})
+	account.States = map[identity.Key]model_state.State{
+		openKey:   open,
+		closedKey: model_state.NewState(closedKey, "Closed", "", ""),
+		frozenKey: model_state.NewState(frozenKey, "Frozen", "", ""),
+	}
+	account.Events = map[identity.Key]model_state.Event{
+		newKey:     model_state.NewEvent(newKey, "_new", "", []string{"opening"}),
+		depositKey: model_state.NewEvent(depositKey, "deposit", "Money arrives.", []string{"amount", "type"}),
+		closeKey:   model_state.NewEvent(closeKey, "close", "", nil),
+		thawKey:    model_state.NewEvent(thawKey, "thaw", "", nil),
+		auditKey:   model_state.NewEvent(auditKey, "audit", "", nil),
+		destroyKey: model_state.NewEvent(destroyKey, "_destroy", "", nil),
+	}
+	account.Guards = map[identity.Key]model_state.Guard{
+		richKey: model_state.NewGuard(richKey, "rich", model_logic.NewLogic(richKey, model_logic.LogicTypeAssessment, "balance > 50", "", newSpec("balance > 50"), nil)),
+	}
+
+	account.Actions = map[identity.Key]model_state.Action{
+		openActionKey:    setBalance(openActionKey, "Open", "opening", "opening"),
+		depositActionKey: setBalance(depositActionKey, "Deposit", "amount", "balance + amount"),
+		closeActionKey: model_state.NewAction(closeActionKey, model_state.ActionDetails{Name: "Close", Details: "Closes the account."},
+			[]model_logic.Logic{
+				model_logic.NewLogic(helper.Must(identity.NewActionRequireKey(closeActionKey, "0")), model_logic.LogicTypeAssessment, "Nothing is owed.", "", newSpec("balance >= 0"), nil),
+			},
+			nil, nil, nil),
+		logActionKey: model_state.NewAction(logActionKey, model_state.ActionDetails{Name: "Log"}, nil, nil, nil, nil),
+	}
+
+	balanceTypeSpec := logic_spec.TypeSpec{Notation: model_logic.NotationTLAPlus, Specification: "Int", ExpressionType: &logic_expression_type.IntegerType{}}
+	account.Queries = map[identity.Key]model_state.Query{
+		balanceQueryKey: model_state.NewQuery(balanceQueryKey, "Balance", "", nil,
+			[]model_logic.Logic{
+				model_logic.NewLogic(helper.Must(identity.NewQueryGuaranteeKey(balanceQueryKey, "0")), model_logic.LogicTypeQuery, "", "balance", newSpec("self.balance"), &balanceTypeSpec),
+			},
+			nil),
+	}
+
+	account.Transitions = map[identity.Key]model_state.Transition{}
+	addTransition := func(from *identity.Key, event identity.Key, to *identity.Key, guard, action *identity.Key) {
+		subKey := func(key *identity.Key) string {
+			if key == nil {
+				return ""
+			}
+			return key.SubKey
+		}
+		key := helper.Must(identity.NewTransitionKey(accountKey, subKey(from), event.SubKey, subKey(guard), subKey(action), subKey(to)))
+		account.Transitions[key] = model_state.NewTransition(key, event,
+			model_state.TransitionStateKeys{FromStateKey: from, ToStateKey: to},
+			model_state.TransitionLogicKeys{GuardKey: guard, ActionKey: action}, "")
+	}
+	addTransition(nil, newKey, &openKey, nil, &openActionKey)
+	addTransition(&openKey, depositKey, &openKey, nil, &depositActionKey)
+	addTransition(&openKey, closeKey, &closedKey, &richKey, &closeActionKey)
+	addTransition(&frozenKey, thawKey, &openKey, nil, nil)
+	addTransition(&closedKey, destroyKey, nil, nil, nil)
+	return account
+}
+
+// bankOpenAccount is the use case of a clerk opening an account and paying cash in.
+func bankOpenAccount(subdomainKey, clerkKey, accountKey identity.Key) model_use_case.UseCase {
+	useCaseKey := helper.Must(identity.NewUseCaseKey(subdomainKey, "open_account"))
+	scenarioKey := helper.Must(identity.NewScenarioKey(useCaseKey, "happy"))
+	clerkObjectKey := helper.Must(identity.NewScenarioObjectKey(scenarioKey, "clerk"))
+	accountObjectKey := helper.Must(identity.NewScenarioObjectKey(scenarioKey, "account"))
+	newKey := helper.Must(identity.NewEventKey(accountKey, "_new"))
+	depositKey := helper.Must(identity.NewEventKey(accountKey, "deposit"))
+	leafEvent := model_scenario.LEAF_TYPE_EVENT
+	leafDestroy := model_scenario.LEAF_TYPE_DESTROY
+	stepKey := func(subKey string) identity.Key { return helper.Must(identity.NewScenarioStepKey(scenarioKey, subKey)) }
+
+	scenario := model_scenario.NewScenario(scenarioKey, "Happy", "The clerk pays in cash.")
+	scenario.Objects = map[identity.Key]model_scenario.Object{
+		clerkObjectKey:   model_scenario.NewObject(clerkObjectKey, 1, model_scenario.ObjectDiagramName{Name: "Ann", NameStyle: "name"}, clerkKey, false, ""),
+		accountObjectKey: model_scenario.NewObject(accountObjectKey, 2, model_scenario.ObjectDiagramName{NameStyle: "unnamed"}, accountKey, false, ""),
+	}
+	scenario.Steps = &model_scenario.Step{
+		Key:      stepKey("0"),
+		StepType: model_scenario.STEP_TYPE_SEQUENCE,
+		Statements: []model_scenario.Step{
+			{Key: stepKey("1"), StepType: model_scenario.STEP_TYPE_LEAF, LeafType: &leafEvent, FromObjectKey: &clerkObjectKey, ToObjectKey: &accountObjectKey, EventKey: &newKey},
+			{Key: stepKey("2"), StepType: model_scenario.STEP_TYPE_SWITCH, Statements: []model_scenario.Step{
+				{Key: stepKey("3"), StepType: model_scenario.STEP_TYPE_CASE, Condition: "cash on hand", Statements: []model_scenario.Step{
+					{Key: stepKey("4"), StepType: model_scenario.STEP_TYPE_LEAF, LeafType: &leafEvent, Description: "Pay in ", FromObjectKey: &clerkObjectKey, ToObjectKey: &accountObjectKey, EventKey: &depositKey},
+				}},
+				{Key: stepKey("5"), StepType: model_scenario.STEP_TYPE_CASE, Condition: "no cash", Statements: []model_scenario.Step{
+					{Key: stepKey("6"), StepType: model_scenario.STEP_TYPE_LEAF, LeafType: &leafDestroy, FromObjectKey: &accountObjectKey},
+				}},
+			}},
+		},
+	}
+
+	useCase := model_use_case.NewUseCase(useCaseKey, model_use_case.UseCaseTraits{Level: "sea"}, model_use_case.GeneralizationRefs{}, model_use_case.UseCaseDetails{Name: "Open account", Details: "A clerk opens an account & pays in."})
+	useCase.Actors = map[identity.Key]model_use_case.Actor{clerkKey: {}}
+	useCase.Scenarios = map[identity.Key]model_scenario.Scenario{scenarioKey: scenario}
+	return useCase
+}
+
+// setBalance is an action that takes one whole amount and sets the balance to expression.
+func setBalance(actionKey identity.Key, name, parameter, expression string) model_state.Action {
+	guarantee := model_logic.NewLogic(helper.Must(identity.NewActionGuaranteeKey(actionKey, "0")), model_logic.LogicTypeStateChange, "", "balance", newSpec(expression), nil)
+	param := helper.Must(model_state.NewParameter(actionKey, parameter, "[1 .. 40] at 1 dollar", false))
+	param.DataType.TypeSpec = natTypeSpec()
+	return model_state.NewAction(actionKey, model_state.ActionDetails{Name: name}, nil, []model_logic.Logic{guarantee}, nil, []model_state.Parameter{param})
+}
+
+// natTypeSpec is the TLA+ type of whole amounts.
+func natTypeSpec() *logic_spec.TypeSpec {
+	typeSpec := helper.Must(logic_spec.NewTypeSpec(model_logic.NotationTLAPlus, "Nat", nil))
+	return &typeSpec
+}
@@ -0,0 +1,29 @@
+package test_helper
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetBankModel(t *testing.T) {
+	model := GetBankModel()
+
+	assert.Equal(t, "bank", model.Key)
+	assert.Equal(t, "Bank", model.Name)
+	assert.Len(t, model.Domains, 1)
+
+	// Tests edit the model they are given, so each call builds a new one.
+	for _, domain := range model.Domains {
+		for subdomainKey := range domain.Subdomains {
+			delete(domain.Subdomains, subdomainKey)
+		}
+	}
+	for _, domain := range GetBankModel().Domains {
+		assert.Len(t, domain.Subdomains, 2)
+	}
+
+	model = GetBankModel()
+	require.NoError(t, model.Validate())
+}
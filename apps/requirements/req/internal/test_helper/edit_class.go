@@ -0,0 +1,26 @@
+package test_helper
+
+import (
+	"testing"
+
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/stretchr/testify/require"
+)
+
+// EditClass changes a class of a model in place, finding it by name.
+func EditClass(t *testing.T, model core.Model, name string, edit func(class *model_class.Class)) {
+	t.Helper()
+	for _, domain := range model.Domains {
+		for _, subdomain := range domain.Subdomains {
+			for key, class := range subdomain.Classes {
+				if class.Name == name {
+					edit(&class)
+					subdomain.Classes[key] = class
+					return
+				}
+			}
+		}
+	}
+	require.Failf(t, "no class", "no class named '%s'", name)
+}
@@ -0,0 +1,124 @@
+package test_helper
+
+import (
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_actor"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_class"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_domain"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_expression_type"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_logic/logic_spec"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_scenario"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_state"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/core/model_use_case"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/helper"
+	"github.com/glemzurg/glemzurg/apps/requirements/req/internal/identity"
+)
+
+// GetShopModel returns a small shop, for tests of the generators of data schemas and APIs.
+//
+// A shopper, who is a customer, pays for an order. The order has a span, an enumeration,
+// a collection and a record attribute, and a query of its total. Nothing sends the ship
+// event.
+func GetShopModel() core.Model {
+	actorKey := helper.Must(identity.NewActorKey("shopper"))
+	domainKey := helper.Must(identity.NewDomainKey("shop"))
+	subdomainKey := helper.Must(identity.NewSubdomainKey(domainKey, "orders"))
+	customerKey := helper.Must(identity.NewClassKey(subdomainKey, "customer"))
+	orderKey := helper.Must(identity.NewClassKey(subdomainKey, "order"))
+	openKey := helper.Must(identity.NewStateKey(orderKey, "open"))
+	paidKey := helper.Must(identity.NewStateKey(orderKey, "paid"))
+	payEventKey := helper.Must(identity.NewEventKey(orderKey, "pay"))
+	shipEventKey := helper.Must(identity.NewEventKey(orderKey, "ship"))
+	payKey := helper.Must(identity.NewActionKey(orderKey, "pay"))
+	totalQueryKey := helper.Must(identity.NewQueryKey(orderKey, "total"))
+
+	attribute := func(classKey identity.Key, subKey string, details model_class.AttributeDetails, rules string, nullable bool) model_class.Attribute {
+		return helper.Must(model_class.NewAttribute(helper.Must(identity.NewAttributeKey(classKey, subKey)), details, rules, nil, nullable, model_class.AttributeAnnotations{}))
+	}
+	customer := model_class.NewClass(customerKey, model_class.ClassLinks{ActorKey: &actorKey}, model_class.ClassDetails{Name: "Customer"})
+	customer.Attributes = []model_class.Attribute{
+		attribute(customerKey, "name", model_class.AttributeDetails{Name: "Name", Details: "What they are called."}, "unconstrained", false),
+	}
+	order := model_class.NewClass(orderKey, model_class.ClassLinks{}, model_class.ClassDetails{Name: "Order", Details: "A basket being bought."})
+	order.Attributes = []model_class.Attribute{
+		attribute(orderKey, "total", model_class.AttributeDetails{Name: "Total", Details: "What is owed."}, "[0 .. 1000) at 0.01 dollar", false),
+		attribute(orderKey, "status", model_class.AttributeDetails{Name: "Status"}, "enum of open, paid", true),
+		attribute(orderKey, "buyers", model_class.AttributeDetails{Name: "Buyers"}, "unique 1-3 unordered of obj of customer", false),
+		attribute(orderKey, "address", model_class.AttributeDetails{Name: "Address"}, "{ street: unconstrained ; floor: [0 .. 100] at 1 floor }", false),
+	}
+
+	amount := helper.Must(model_state.NewParameter(payKey, "amount", "[1 .. 500] at 1 unit", false))
+	pay := model_state.NewAction(payKey, model_state.ActionDetails{Name: "Pay"},
+		[]model_logic.Logic{
+			model_logic.NewLogic(helper.Must(identity.NewActionRequireKey(payKey, "0")), model_logic.LogicTypeAssessment, "Pays the whole total.", "", newSpec("amount = self.total"), nil),
+		},
+		[]model_logic.Logic{
+			model_logic.NewLogic(helper.Must(identity.NewActionGuaranteeKey(payKey, "0")), model_logic.LogicTypeStateChange, "", "status", newSpec(`"paid"`), nil),
+		},
+		nil,
+		[]model_state.Parameter{amount})
+	totalTypeSpec := logic_spec.TypeSpec{Notation: model_logic.NotationTLAPlus, Specification: "Int", ExpressionType: &logic_expression_type.IntegerType{}}
+	totalQuery := model_state.NewQuery(totalQueryKey, "Total", "The amount owed.", nil,
+		[]model_logic.Logic{
+			model_logic.NewLogic(helper.Must(identity.NewQueryGuaranteeKey(totalQueryKey, "0")), model_logic.LogicTypeQuery, "", "total", newSpec("self.total"), &totalTypeSpec),
+		},
+		nil)
+
+	order.States = map[identity.Key]model_state.State{
+		openKey: model_state.NewState(openKey, "Open", "", ""),
+		paidKey: model_state.NewState(paidKey, "Paid", "", ""),
+	}
+	order.Events = map[identity.Key]model_state.Event{
+		payEventKey:  model_state.NewEvent(payEventKey, "pay", "Money arrives.", []string{"amount"}),
+		shipEventKey: model_state.NewEvent(shipEventKey, "ship", "", nil),
+	}
+	order.Actions = map[identity.Key]model_state.Action{payKey: pay}
+	order.Queries = map[identity.Key]model_state.Query{totalQueryKey: totalQuery}
+	payTransitionKey := helper.Must(identity.NewTransitionKey(orderKey, "open", "pay", "", "pay", "paid"))
+	order.Transitions = map[identity.Key]model_state.Transition{
+		payTransitionKey: model_state.NewTransition(payTransitionKey, payEventKey,
+			model_state.TransitionStateKeys{FromStateKey: &openKey, ToStateKey: &paidKey},
+			model_state.TransitionLogicKeys{ActionKey: &payKey}, ""),
+	}
+
+	useCaseKey := helper.Must(identity.NewUseCaseKey(subdomainKey, "checkout"))
+	scenarioKey := helper.Must(identity.NewScenarioKey(useCaseKey, "happy"))
+	shopperObjectKey := helper.Must(identity.NewScenarioObjectKey(scenarioKey, "shopper"))
+	orderObjectKey := helper.Must(identity.NewScenarioObjectKey(scenarioKey, "order"))
+	leafEvent := model_scenario.LEAF_TYPE_EVENT
+	scenario := model_scenario.NewScenario(scenarioKey, "Happy", "")
+	scenario.Objects = map[identity.Key]model_scenario.Object{
+		shopperObjectKey: model_scenario.NewObject(shopperObjectKey, 1, model_scenario.ObjectDiagramName{NameStyle: "unnamed"}, customerKey, false, ""),
+		orderObjectKey:   model_scenario.NewObject(orderObjectKey, 2, model_scenario.ObjectDiagramName{NameStyle: "unnamed"}, orderKey, false, ""),
+	}
+	scenario.Steps = &model_scenario.Step{
+		Key:      helper.Must(identity.NewScenarioStepKey(scenarioKey, "0")),
+		StepType: model_scenario.STEP_TYPE_SEQUENCE,
+		Statements: []model_scenario.Step{{
+			Key:           helper.Must(identity.NewScenarioStepKey(scenarioKey, "1")),
+			StepType:      model_scenario.STEP_TYPE_LEAF,
+			LeafType:      &leafEvent,
+			FromObjectKey: &shopperObjectKey,
+			ToObjectKey:   &orderObjectKey,
+			EventKey:      &payEventKey,
+		}},
+	}
+	useCase := model_use_case.NewUseCase(useCaseKey, model_use_case.UseCaseTraits{Level: "sea"}, model_use_case.GeneralizationRefs{}, model_use_case.UseCaseDetails{Name: "Checkout"})
+	useCase.Actors = map[identity.Key]model_use_case.Actor{customerKey: {}}
+	useCase.Scenarios = map[identity.Key]model_scenario.Scenario{scenarioKey: scenario}
+
+	subdomain := model_domain.Subdomain{
+		Key:      subdomainKey,
+		Name:     "Orders",
+		Classes:  map[identity.Key]model_class.Class{customerKey: customer, orderKey: order},
+		UseCases: map[identity.Key]model_use_case.UseCase{useCaseKey: useCase},
+	}
+	domain := model_domain.Domain{Key: domainKey, Name: "Shop", Subdomains: map[identity.Key]model_domain.Subdomain{subdomainKey: subdomain}}
+	return core.Model{
+		Key:     "shop",
+		Name:    "Shop",
+		Actors:  map[identity.Key]model_actor.Actor{actorKey: {Key: actorKey, Name: "Shopper", Type: "person"}},
+		Domains: map[identity.Key]model_domain.Domain{domainKey: domain},
+	}
+}
@@ -1153,7 +1153,7 @@ func buildLogic(k testKeys) (testLogic, error) {
 	// Global function logic.
 	l.globalFunc1Log = model_logic.NewLogic(k.globalFunc1, model_logic.LogicTypeValue, "Returns maximum of two values", "", parsedSpec("IF x > y THEN x ELSE y"), nil)
 	l.globalFunc2Log = model_logic.NewLogic(k.globalFunc2, model_logic.LogicTypeValue, "Returns the input unchanged", "", newSpec(""), nil)
-	l.globalFunc3Log = model_logic.NewLogic(k.globalFunc3, model_logic.LogicTypeValue, "Counts elements in a set", "", parsedSpec("Cardinality(s)"), nil)
+	l.globalFunc3Log = model_logic.NewLogic(k.globalFunc3, model_logic.LogicTypeValue, "Counts elements in a set", "", parsedSpec(`IF s = {} THEN 0 ELSE 1 + _Count(s \ {CHOOSE x \in s : TRUE})`), nil)
 
 	return l, nil
 }
@@ -1163,12 +1163,13 @@ func buildLogic(k testKeys) (testLogic, error) {
 // =========================================================================
 
 func buildGlobalFunctions(k testKeys, l testLogic) map[identity.Key]model_logic.GlobalFunction {
-	gf1 := model_logic.NewGlobalFunction(k.globalFunc1, "_Max", []string{"x", "y", "z"}, l.globalFunc1Log)
+	gf1 := model_logic.NewGlobalFunction(k.globalFunc1, "_Max", []string{"x", "y", "z"}, false, l.globalFunc1Log)
 
 	// Empty parameters (pairwise: nil vs populated).
-	gf2 := model_logic.NewGlobalFunction(k.globalFunc2, "_Identity", nil, l.globalFunc2Log)
+	gf2 := model_logic.NewGlobalFunction(k.globalFunc2, "_Identity", nil, false, l.globalFunc2Log)
 
-	gf3 := model_logic.NewGlobalFunction(k.globalFunc3, "_Count", []string{"s"}, l.globalFunc3Log)
+	// Recursive (pairwise: declared RECURSIVE vs not).
+	gf3 := model_logic.NewGlobalFunction(k.globalFunc3, "_Count", []string{"s"}, true, l.globalFunc3Log)
 
 	return map[identity.Key]model_logic.GlobalFunction{
 		k.globalFunc1: gf1,
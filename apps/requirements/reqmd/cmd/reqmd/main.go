@@ -13,10 +13,18 @@ func main() {
 
 	// Example call: $GOBIN/reqmd -config design/config.json -path design/requirements
 
+	// Example call adding stubs for the new requirements of a ReqIF document from a requirements tool,
+	// and writing all the requirements with their aspects as a ReqIF document for the tool:
+	// $GOBIN/reqmd -config design/config.json -path design/requirements -reqifimport customer.reqif -reqifexport design/requirements.reqif
+
 	var configFilename string
 	var path string
+	var reqIFImportFilename string
+	var reqIFExportFilename string
 	flag.StringVar(&configFilename, "config", "", "the path to the configuration file")
 	flag.StringVar(&path, "path", "", "the path to the requirements file tree")
+	flag.StringVar(&reqIFImportFilename, "reqifimport", "", "a ReqIF document to add stub requirements for, in imported.md")
+	flag.StringVar(&reqIFExportFilename, "reqifexport", "", "the path to write a ReqIF document of the requirements to")
 	flag.Parse()
 	fmt.Printf("\nconfig: %s\n", configFilename)
 
@@ -38,6 +46,21 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Add stubs for any new requirements of a ReqIF document, and start again with them.
+	if reqIFImportFilename != "" {
+		fmt.Printf("\nimporting: \n\n")
+		err = req.ImportReqIFFile(reqIFImportFilename)
+		if err != nil {
+			fmt.Printf("%+v\n\n", err)
+			os.Exit(1)
+		}
+		req, err = requirements.New(path)
+		if err != nil {
+			fmt.Printf("%+v\n\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Number unnumbered requirements.
 	err = req.NumberAll()
 	if err != nil {
@@ -75,6 +98,16 @@ func main() {
 		os.Exit(1)
 	}
 
+	// Write the ReqIF document.
+	if reqIFExportFilename != "" {
+		fmt.Printf("\nreqif: \n\n")
+		err = req.WriteReqIFFile(reqIFExportFilename, config)
+		if err != nil {
+			fmt.Printf("%+v\n\n", err)
+			os.Exit(1)
+		}
+	}
+
 	// Have a print line at the end of output.
 	fmt.Println()
 
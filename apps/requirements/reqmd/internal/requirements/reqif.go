@@ -0,0 +1,592 @@
+package requirements
+
+import (
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// The exchange of requirements with requirements tools as ReqIF 1.2 documents.
+
+const (
+	_REQIF_NAMESPACE        = "http://www.omg.org/spec/ReqIF/20110401/reqif.xsd"
+	_REQIF_IMPORT_FILE_NAME = "imported.md"
+	_REQIF_IMPORTED_FROM    = "Imported from ReqIF object " // Starts the text of a stub requirement, followed by the object identifier.
+
+	// Standard attribute names that requirements tools map to their own fields.
+	_REQIF_FOREIGN_ID   = "ReqIF.ForeignID"
+	_REQIF_NAME         = "ReqIF.Name"
+	_REQIF_TEXT         = "ReqIF.Text"
+	_REQIF_CHAPTER_NAME = "ReqIF.ChapterName"
+
+	_REQIF_LINKS_TO = "Links to"
+)
+
+// The names of the kinds of requirement, each a SpecObject type in ReqIF documents.
+var _KindNames = map[string]string{
+	UseCase:        "Use case",
+	Actor:          "Actor",
+	Functional:     "Functional",
+	NonFunctional:  "Non-functional",
+	Stakeholder:    "Stakeholder",
+	NonRequirement: "Non-requirement",
+}
+
+// The kinds of requirement in the order they sort.
+var _kindsInOrder = []string{UseCase, Actor, Functional, NonFunctional, Stakeholder, NonRequirement}
+
+//===========================================
+// Export
+//===========================================
+
+func (r *Requirements) WriteReqIFFile(filename string, config Config) (err error) {
+
+	contents, err := r.ReqIF(config, time.Now())
+	if err != nil {
+		return err
+	}
+
+	// Report what we're doing.
+	fmt.Printf("  writing '%s'\n", filename)
+
+	// Make the write.
+	if err = os.WriteFile(filename, []byte(contents), 0644); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// ReqIF returns a ReqIF document of the requirements, with every element last changed at lastChange.
+// Each kind of requirement is a SpecObject type with its configured aspects as attributes, links
+// between requirements are SpecRelations, and each file is a specification.
+func (r *Requirements) ReqIF(config Config, lastChange time.Time) (document string, err error) {
+
+	changed := lastChange.UTC().Format(time.RFC3339)
+	identifiable := func(name, id, longName string) *reqIFElement {
+		element := newReqIFElement(name, "IDENTIFIER", id, "LAST-CHANGE", changed)
+		if longName != "" {
+			element.attrs = append(element.attrs, "LONG-NAME", longName)
+		}
+		return element
+	}
+	typeRef := func(name, refName, id string) *reqIFElement {
+		return newReqIFElement(name).add(&reqIFElement{name: refName, text: id})
+	}
+
+	// Get all the requirements and sort them.
+	var sortedReqs []Requirement
+	ids := map[string]bool{}
+	for _, req := range r.reqs {
+		if req.Header.Num == 0 {
+			return "", errors.WithStack(errors.Errorf(`requirement not numbered: '%s'`, req.Header.IdTitle()))
+		}
+		sortedReqs = append(sortedReqs, req)
+		ids[req.Id()] = true
+	}
+	sort.Slice(sortedReqs, func(i, j int) bool {
+		return lessThan(sortedReqs[i].Header, sortedReqs[j].Header)
+	})
+
+	// Each kind has the attributes of its configured aspects, then of any others its requirements have.
+	kindAspects := map[string][]string{}
+	for _, kind := range _kindsInOrder {
+		kindAspects[kind] = append(kindAspects[kind], config.Aspects[kind]...)
+	}
+	for _, req := range sortedReqs {
+		for _, aspect := range req.Aspects {
+			if strings.TrimSpace(aspect.value) != "" && aspectAttributeName(kindAspects[req.Header.Kind], aspect.name) == "" {
+				kindAspects[req.Header.Kind] = append(kindAspects[req.Header.Kind], aspect.name)
+			}
+		}
+	}
+
+	// The one datatype every attribute has.
+	datatypes := newReqIFElement("DATATYPES").add(
+		identifiable("DATATYPE-DEFINITION-STRING", "_datatype.string", "String").set("MAX-LENGTH", "1000000"))
+
+	// The types.
+	attributeId := func(kind, name string) string {
+		return "_type." + kind + "." + reqIFIdentifier(name)
+	}
+	specTypes := newReqIFElement("SPEC-TYPES")
+	for _, kind := range _kindsInOrder {
+		attributes := newReqIFElement("SPEC-ATTRIBUTES")
+		for _, name := range append([]string{_REQIF_FOREIGN_ID, _REQIF_NAME, _REQIF_TEXT}, kindAspects[kind]...) {
+			attributes.add(identifiable("ATTRIBUTE-DEFINITION-STRING", attributeId(kind, name), name).add(
+				typeRef("TYPE", "DATATYPE-DEFINITION-STRING-REF", "_datatype.string")))
+		}
+		specTypes.add(identifiable("SPEC-OBJECT-TYPE", "_type."+kind, _KindNames[kind]).add(attributes))
+	}
+	specTypes.add(identifiable("SPEC-RELATION-TYPE", "_relation_type.links", _REQIF_LINKS_TO))
+	specTypes.add(identifiable("SPECIFICATION-TYPE", "_type.specification", "Specification"))
+
+	// The requirements and the links between them.
+	objects := newReqIFElement("SPEC-OBJECTS")
+	relations := newReqIFElement("SPEC-RELATIONS")
+	for _, req := range sortedReqs {
+		kind := req.Header.Kind
+
+		values := newReqIFElement("VALUES")
+		addValue := func(name, value string) {
+			if value != "" {
+				values.add(newReqIFElement("ATTRIBUTE-VALUE-STRING", "THE-VALUE", value).add(
+					typeRef("DEFINITION", "ATTRIBUTE-DEFINITION-STRING-REF", attributeId(kind, name))))
+			}
+		}
+		addValue(_REQIF_FOREIGN_ID, req.Id())
+		addValue(_REQIF_NAME, req.Header.Title)
+		addValue(_REQIF_TEXT, req.Body)
+		for _, aspect := range req.Aspects {
+			addValue(aspectAttributeName(kindAspects[kind], aspect.name), strings.TrimSpace(aspect.value))
+		}
+		objects.add(identifiable("SPEC-OBJECT", "_"+req.Id(), "").add(values, typeRef("TYPE", "SPEC-OBJECT-TYPE-REF", "_type."+kind)))
+
+		// Only links to known requirements are traced.
+		var linkIds []string
+		for linkId := range req.Links {
+			if ids[linkId] {
+				linkIds = append(linkIds, linkId)
+			}
+		}
+		sort.Strings(linkIds)
+		for _, linkId := range linkIds {
+			relations.add(identifiable("SPEC-RELATION", "_"+req.Id()+".links."+linkId, "").add(
+				typeRef("SOURCE", "SPEC-OBJECT-REF", "_"+req.Id()),
+				typeRef("TARGET", "SPEC-OBJECT-REF", "_"+linkId),
+				typeRef("TYPE", "SPEC-RELATION-TYPE-REF", "_relation_type.links"),
+			))
+		}
+	}
+
+	// A specification for each file, of its requirements in order.
+	specifications := newReqIFElement("SPECIFICATIONS")
+	for _, filename := range r.filenames {
+		relativeFilename, err := filepath.Rel(r.path, filename)
+		if err != nil {
+			return "", errors.WithStack(err)
+		}
+		children := newReqIFElement("CHILDREN")
+		for _, ref := range r.files[filename].refs {
+			req := r.reqs[ref]
+			id := req.Id()
+			children.add(identifiable("SPEC-HIERARCHY", "_hierarchy."+id, "").add(typeRef("OBJECT", "SPEC-OBJECT-REF", "_"+id)))
+		}
+		specification := identifiable("SPECIFICATION", "_file."+reqIFIdentifier(filepath.ToSlash(relativeFilename)), filepath.ToSlash(relativeFilename)).add(
+			typeRef("TYPE", "SPECIFICATION-TYPE-REF", "_type.specification"))
+		if len(children.children) > 0 {
+			specification.add(children)
+		}
+		specifications.add(specification)
+	}
+
+	root := newReqIFElement("REQ-IF", "xmlns", _REQIF_NAMESPACE).add(
+		newReqIFElement("THE-HEADER").add(newReqIFElement("REQ-IF-HEADER", "IDENTIFIER", "_header").add(
+			&reqIFElement{name: "CREATION-TIME", text: changed},
+			&reqIFElement{name: "REQ-IF-TOOL-ID", text: "reqmd"},
+			&reqIFElement{name: "REQ-IF-VERSION", text: "1.0"},
+			&reqIFElement{name: "SOURCE-TOOL-ID", text: "reqmd"},
+			&reqIFElement{name: "TITLE", text: filepath.Base(r.path)},
+		)),
+		newReqIFElement("CORE-CONTENT").add(newReqIFElement("REQ-IF-CONTENT").add(datatypes, specTypes, objects, relations, specifications)),
+	)
+
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	root.write(&b, 0)
+
+	return b.String(), nil
+}
+
+// aspectAttributeName returns the attribute name of an aspect, matched without case the way
+// aspect tables match aspects to their configured names, or "" if there is none.
+func aspectAttributeName(attributeNames []string, aspectName string) (name string) {
+	for _, attributeName := range attributeNames {
+		if strings.TrimSpace(strings.ToLower(attributeName)) == strings.TrimSpace(strings.ToLower(aspectName)) {
+			return attributeName
+		}
+	}
+	return ""
+}
+
+// reqIFIdentifier turns text into part of a ReqIF identifier, which are XML ids.
+func reqIFIdentifier(text string) (identifier string) {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '/':
+			return '.'
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '_', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, text)
+}
+
+// reqIFElement is an XML element written with its attributes in the order they were given.
+type reqIFElement struct {
+	name     string
+	attrs    []string // Alternating names and values.
+	text     string
+	children []*reqIFElement
+}
+
+func newReqIFElement(name string, attrs ...string) *reqIFElement {
+	return &reqIFElement{name: name, attrs: attrs}
+}
+
+func (e *reqIFElement) add(children ...*reqIFElement) *reqIFElement {
+	e.children = append(e.children, children...)
+	return e
+}
+
+func (e *reqIFElement) set(name, value string) *reqIFElement {
+	e.attrs = append(e.attrs, name, value)
+	return e
+}
+
+var (
+	_reqIFAttrEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;", "\n", "&#10;", "\t", "&#9;", "\r", "&#13;")
+	_reqIFTextEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;")
+)
+
+func (e *reqIFElement) write(b *strings.Builder, depth int) {
+	indent := strings.Repeat("  ", depth)
+	b.WriteString(indent + "<" + e.name)
+	for i := 0; i+1 < len(e.attrs); i += 2 {
+		b.WriteString(" " + e.attrs[i] + `="` + _reqIFAttrEscaper.Replace(e.attrs[i+1]) + `"`)
+	}
+	switch {
+	case len(e.children) > 0:
+		b.WriteString(">\n")
+		for _, child := range e.children {
+			child.write(b, depth+1)
+		}
+		b.WriteString(indent + "</" + e.name + ">\n")
+	case e.text != "":
+		b.WriteString(">" + _reqIFTextEscaper.Replace(e.text) + "</" + e.name + ">\n")
+	default:
+		b.WriteString("/>\n")
+	}
+}
+
+//===========================================
+// Import
+//===========================================
+
+func (r *Requirements) ImportReqIFFile(reqIFFilename string) (err error) {
+
+	document, err := os.ReadFile(reqIFFilename)
+	if err != nil {
+		return errors.WithStack(err)
+	}
+
+	stubs, err := r.importReqIF(document)
+	if err != nil {
+		return err
+	}
+
+	// Nothing new.
+	if stubs == "" {
+		return nil
+	}
+
+	// Stubs are added to the end of the import file, starting it if needed.
+	filename := filepath.Join(r.path, _REQIF_IMPORT_FILE_NAME)
+	contents, err := os.ReadFile(filename)
+	if err != nil {
+		if !os.IsNotExist(err) {
+			return errors.WithStack(err)
+		}
+		contents = []byte("# Imported\n\nRequirements imported from ReqIF documents, to move to their places.")
+	}
+
+	// Report what we're doing.
+	fmt.Printf("  importing '%s' into '%s'\n", reqIFFilename, filename)
+
+	// Make the write.
+	if err = os.WriteFile(filename, []byte(strings.TrimSpace(string(contents))+"\n\n"+stubs+"\n"), 0644); err != nil {
+		return errors.WithStack(err)
+	}
+
+	return nil
+}
+
+// importReqIF returns the markdown of a stub requirement for every object of a ReqIF document
+// that is not a requirement already. Objects whose ReqIF.ForeignID is a requirement id, or that an
+// earlier import made stubs for, are already requirements; sections and headings are skipped.
+// A stub's text says where it came from and asks where it belongs, so it stays incomplete until
+// it is moved into place, and the object's attributes become its aspects.
+func (r *Requirements) importReqIF(document []byte) (stubs string, err error) {
+
+	var doc reqIFDocument
+	if err = xml.Unmarshal(document, &doc); err != nil {
+		return "", errors.WithStack(err)
+	}
+	content := doc.Content
+
+	// What objects already have requirements?
+	ids := map[string]bool{}
+	stubbed := map[string]bool{}
+	for _, req := range r.reqs {
+		if req.Header.Num != 0 {
+			ids[req.Id()] = true
+		}
+		for _, textline := range strings.Split(req.Body, "\n") {
+			if rest, found := strings.CutPrefix(strings.TrimSpace(textline), _REQIF_IMPORTED_FROM); found {
+				identifier, _, _ := strings.Cut(rest, " ")
+				stubbed[identifier] = true
+			}
+		}
+	}
+
+	// The names of everything by identifier.
+	names := map[string]string{}
+	for _, enumeration := range content.Enumerations {
+		for _, value := range enumeration.Values {
+			names[value.Identifier] = value.LongName
+		}
+	}
+	for _, specType := range content.ObjectTypes {
+		names[specType.Identifier] = specType.LongName
+		for _, definition := range specType.Attributes.Definitions {
+			names[definition.Identifier] = definition.LongName
+		}
+	}
+	for _, relationType := range content.RelationTypes {
+		names[relationType.Identifier] = relationType.LongName
+	}
+
+	// Objects that are requirements already are linked to by their id.
+	objectValues := map[string][]Aspect{}
+	for _, object := range content.Objects {
+		values := object.values(names)
+		objectValues[object.Identifier] = values
+		names[object.Identifier] = reqIFObjectName(object, values)
+		if ids[reqIFValue(values, _REQIF_FOREIGN_ID)] {
+			names[object.Identifier] = "[" + reqIFValue(values, _REQIF_FOREIGN_ID) + "][]"
+		}
+	}
+
+	var stubTexts []string
+	for _, object := range content.Objects {
+		values := objectValues[object.Identifier]
+		typeName := names[object.Type]
+
+		// Skip what is already a requirement, and what only structures the document.
+		if ids[reqIFValue(values, _REQIF_FOREIGN_ID)] || stubbed[object.Identifier] {
+			continue
+		}
+		if reqIFValue(values, _REQIF_CHAPTER_NAME) != "" && reqIFValue(values, _REQIF_NAME) == "" && reqIFValue(values, _REQIF_TEXT) == "" {
+			continue
+		}
+
+		// The kind is the one named by the object's type, or functional.
+		kind := Functional
+		for k, kindName := range _KindNames {
+			if strings.EqualFold(kindName, typeName) {
+				kind = k
+			}
+		}
+
+		// Where it came from, and a question so it is incomplete until placed.
+		text := _REQIF_IMPORTED_FROM + object.Identifier
+		if typeName != "" {
+			text += " (" + typeName + ")"
+		}
+		if doc.Title != "" {
+			text += fmt.Sprintf(" of %q", doc.Title)
+		}
+		text += ". Where does it belong?"
+		if body := reqIFValue(values, _REQIF_TEXT); body != "" {
+			text += "\n\n" + body
+		}
+
+		// The relations to and from it.
+		var relationLines []string
+		for _, relation := range content.Relations {
+			if relation.Source == object.Identifier {
+				relationLines = append(relationLines, fmt.Sprintf("- %s → %s", names[relation.Type], names[relation.Target]))
+			}
+			if relation.Target == object.Identifier {
+				relationLines = append(relationLines, fmt.Sprintf("- %s ← %s", names[relation.Type], names[relation.Source]))
+			}
+		}
+		if len(relationLines) > 0 {
+			text += "\n\n" + strings.Join(relationLines, "\n")
+		}
+
+		// The other attributes are aspects, on one line of the aspect table each.
+		var aspects []Aspect
+		for _, value := range values {
+			if value.name != _REQIF_NAME && value.name != _REQIF_TEXT {
+				aspect, err := newAspect(value.name, strings.ReplaceAll(normalizeWhitespace(value.value), "|", "/"))
+				if err != nil {
+					return "", err
+				}
+				aspects = append(aspects, aspect)
+			}
+		}
+		aspectBlock, err := generateAspectBlock(nil, aspects)
+		if err != nil {
+			return "", err
+		}
+		if aspectBlock != "" {
+			text += "\n\n" + aspectBlock
+		}
+
+		stubTexts = append(stubTexts, "## "+kind+". "+reqIFStubTitle(names[object.Identifier], object.Identifier)+"\n\n"+text)
+	}
+
+	return strings.Join(stubTexts, "\n\n"), nil
+}
+
+// reqIFObjectName returns what to call an imported object.
+func reqIFObjectName(object reqIFObject, values []Aspect) (name string) {
+	for _, name := range []string{reqIFValue(values, _REQIF_NAME), reqIFValue(values, _REQIF_CHAPTER_NAME), object.LongName, object.Identifier} {
+		if name != "" {
+			return name
+		}
+	}
+	return ""
+}
+
+// reqIFStubTitle returns a title a requirement header can hold, which must start with a letter or number.
+func reqIFStubTitle(name, identifier string) (title string) {
+	title = strings.TrimLeftFunc(normalizeWhitespace(name), func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+	})
+	if title == "" {
+		title = "Requirement " + identifier
+	}
+	return strings.TrimSpace(title)
+}
+
+// reqIFValue returns the value of an imported attribute.
+func reqIFValue(values []Aspect, name string) (value string) {
+	for _, aspect := range values {
+		if aspect.name == name {
+			return aspect.value
+		}
+	}
+	return ""
+}
+
+// The parts of a ReqIF document import reads.
+type (
+	reqIFDocument struct {
+		Title   string       `xml:"THE-HEADER>REQ-IF-HEADER>TITLE"`
+		Content reqIFContent `xml:"CORE-CONTENT>REQ-IF-CONTENT"`
+	}
+	reqIFContent struct {
+		Enumerations  []reqIFEnumeration  `xml:"DATATYPES>DATATYPE-DEFINITION-ENUMERATION"`
+		ObjectTypes   []reqIFObjectType   `xml:"SPEC-TYPES>SPEC-OBJECT-TYPE"`
+		RelationTypes []reqIFIdentifiable `xml:"SPEC-TYPES>SPEC-RELATION-TYPE"`
+		Objects       []reqIFObject       `xml:"SPEC-OBJECTS>SPEC-OBJECT"`
+		Relations     []reqIFRelation     `xml:"SPEC-RELATIONS>SPEC-RELATION"`
+	}
+	reqIFIdentifiable struct {
+		Identifier string `xml:"IDENTIFIER,attr"`
+		LongName   string `xml:"LONG-NAME,attr"`
+	}
+	reqIFEnumeration struct {
+		Values []reqIFIdentifiable `xml:"SPECIFIED-VALUES>ENUM-VALUE"`
+	}
+	reqIFObjectType struct {
+		reqIFIdentifiable
+		Attributes struct {
+			Definitions []reqIFIdentifiable `xml:",any"`
+		} `xml:"SPEC-ATTRIBUTES"`
+	}
+	reqIFObject struct {
+		reqIFIdentifiable
+		Values struct {
+			Values []reqIFAttributeValue `xml:",any"`
+		} `xml:"VALUES"`
+		Type string `xml:"TYPE>SPEC-OBJECT-TYPE-REF"`
+	}
+	reqIFAttributeValue struct {
+		XMLName    xml.Name
+		TheValue   string `xml:"THE-VALUE,attr"`
+		Definition struct {
+			Refs []reqIFRef `xml:",any"`
+		} `xml:"DEFINITION"`
+		XHTML struct {
+			Inner []byte `xml:",innerxml"`
+		} `xml:"THE-VALUE"`
+		EnumRefs []string `xml:"VALUES>ENUM-VALUE-REF"`
+	}
+	reqIFRef struct {
+		Value string `xml:",chardata"`
+	}
+	reqIFRelation struct {
+		Source string `xml:"SOURCE>SPEC-OBJECT-REF"`
+		Target string `xml:"TARGET>SPEC-OBJECT-REF"`
+		Type   string `xml:"TYPE>SPEC-RELATION-TYPE-REF"`
+	}
+)
+
+// values returns the object's attribute values as aspects named by their definitions.
+func (o reqIFObject) values(names map[string]string) (values []Aspect) {
+	for _, v := range o.Values.Values {
+		if len(v.Definition.Refs) == 0 {
+			continue
+		}
+		var value string
+		switch v.XMLName.Local {
+		case "ATTRIBUTE-VALUE-XHTML":
+			value = reqIFXHTMLText(v.XHTML.Inner)
+		case "ATTRIBUTE-VALUE-ENUMERATION":
+			var literals []string
+			for _, ref := range v.EnumRefs {
+				literals = append(literals, names[strings.TrimSpace(ref)])
+			}
+			value = strings.Join(literals, ", ")
+		default:
+			value = v.TheValue
+		}
+		if value != "" {
+			values = append(values, Aspect{name: names[strings.TrimSpace(v.Definition.Refs[0].Value)], value: value})
+		}
+	}
+	return values
+}
+
+// reqIFXHTMLText returns the text of an XHTML value, with its paragraphs on their own lines.
+func reqIFXHTMLText(inner []byte) (text string) {
+	decoder := xml.NewDecoder(bytes.NewReader(inner))
+	decoder.Strict = false
+	var b strings.Builder
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return strings.TrimSpace(string(inner))
+			}
+			break
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			b.Write(t)
+		case xml.EndElement:
+			switch t.Name.Local {
+			case "p", "div", "li", "br", "h1", "h2", "h3", "h4", "h5", "h6", "tr":
+				b.WriteString("\n")
+			}
+		}
+	}
+	var textlines []string
+	for _, textline := range strings.Split(b.String(), "\n") {
+		if textline = strings.TrimSpace(textline); textline != "" {
+			textlines = append(textlines, textline)
+		}
+	}
+	return strings.Join(textlines, "\n")
+}
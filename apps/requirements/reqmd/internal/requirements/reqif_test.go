@@ -0,0 +1,224 @@
+package requirements
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+)
+
+func TestReqIFSuite(t *testing.T) {
+	suite.Run(t, new(ReqIFSuite))
+}
+
+type ReqIFSuite struct {
+	suite.Suite
+}
+
+var T_ReqIFLastChange = time.Date(2026, 10, 18, 9, 30, 0, 0, time.UTC)
+
+const (
+	T_ReqIFFunctional = `# Functional
+
+# F1. Send statements
+
+Statements go out monthly, see [S1][].
+
+| Aspect   | Value |
+|----------|-------|
+| Priority | high  |
+| Owner    | Ann   |
+`
+	T_ReqIFStakeholders = `# Stakeholders
+
+# S1. Customer & family
+`
+
+	// A document from another tool, with a heading, requirements with XHTML text and enumerated
+	// attributes, one of them exported from here earlier, and a trace between two of them.
+	T_ReqIFForeignDocument = `<?xml version="1.0" encoding="UTF-8"?>
+<REQ-IF xmlns="http://www.omg.org/spec/ReqIF/20110401/reqif.xsd" xmlns:xhtml="http://www.w3.org/1999/xhtml">
+  <THE-HEADER><REQ-IF-HEADER IDENTIFIER="h"><TITLE>Customer spec</TITLE></REQ-IF-HEADER></THE-HEADER>
+  <CORE-CONTENT>
+    <REQ-IF-CONTENT>
+      <DATATYPES>
+        <DATATYPE-DEFINITION-ENUMERATION IDENTIFIER="dt-priority">
+          <SPECIFIED-VALUES><ENUM-VALUE IDENTIFIER="p-low" LONG-NAME="Low"/></SPECIFIED-VALUES>
+        </DATATYPE-DEFINITION-ENUMERATION>
+      </DATATYPES>
+      <SPEC-TYPES>
+        <SPEC-OBJECT-TYPE IDENTIFIER="t-req" LONG-NAME="Non-functional">
+          <SPEC-ATTRIBUTES>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="a-id" LONG-NAME="ReqIF.ForeignID"/>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="a-heading" LONG-NAME="ReqIF.ChapterName"/>
+            <ATTRIBUTE-DEFINITION-STRING IDENTIFIER="a-name" LONG-NAME="ReqIF.Name"/>
+            <ATTRIBUTE-DEFINITION-XHTML IDENTIFIER="a-text" LONG-NAME="ReqIF.Text"/>
+            <ATTRIBUTE-DEFINITION-ENUMERATION IDENTIFIER="a-priority" LONG-NAME="Priority"/>
+          </SPEC-ATTRIBUTES>
+        </SPEC-OBJECT-TYPE>
+        <SPEC-RELATION-TYPE IDENTIFIER="r-refines" LONG-NAME="Refines"/>
+      </SPEC-TYPES>
+      <SPEC-OBJECTS>
+        <SPEC-OBJECT IDENTIFIER="o-1">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="1 Quality"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-heading</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-req</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o-2">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="F1"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-id</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="Send statements"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-name</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-req</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+        <SPEC-OBJECT IDENTIFIER="o-3">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="CUS-7"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-id</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="– Statements arrive fast"><DEFINITION><ATTRIBUTE-DEFINITION-STRING-REF>a-name</ATTRIBUTE-DEFINITION-STRING-REF></DEFINITION></ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-XHTML>
+              <DEFINITION><ATTRIBUTE-DEFINITION-XHTML-REF>a-text</ATTRIBUTE-DEFINITION-XHTML-REF></DEFINITION>
+              <THE-VALUE><xhtml:div><xhtml:p>Within a day</xhtml:p><xhtml:p>of <xhtml:b>month</xhtml:b> end.</xhtml:p></xhtml:div></THE-VALUE>
+            </ATTRIBUTE-VALUE-XHTML>
+            <ATTRIBUTE-VALUE-ENUMERATION>
+              <DEFINITION><ATTRIBUTE-DEFINITION-ENUMERATION-REF>a-priority</ATTRIBUTE-DEFINITION-ENUMERATION-REF></DEFINITION>
+              <VALUES><ENUM-VALUE-REF>p-low</ENUM-VALUE-REF></VALUES>
+            </ATTRIBUTE-VALUE-ENUMERATION>
+          </VALUES>
+          <TYPE><SPEC-OBJECT-TYPE-REF>t-req</SPEC-OBJECT-TYPE-REF></TYPE>
+        </SPEC-OBJECT>
+      </SPEC-OBJECTS>
+      <SPEC-RELATIONS>
+        <SPEC-RELATION IDENTIFIER="r-1">
+          <SOURCE><SPEC-OBJECT-REF>o-3</SPEC-OBJECT-REF></SOURCE>
+          <TARGET><SPEC-OBJECT-REF>o-2</SPEC-OBJECT-REF></TARGET>
+          <TYPE><SPEC-RELATION-TYPE-REF>r-refines</SPEC-RELATION-TYPE-REF></TYPE>
+        </SPEC-RELATION>
+      </SPEC-RELATIONS>
+    </REQ-IF-CONTENT>
+  </CORE-CONTENT>
+</REQ-IF>`
+)
+
+// T_ReqIFRequirements writes the files of a small requirements tree and reads it back.
+func (suite *ReqIFSuite) T_ReqIFRequirements(path string) Requirements {
+	suite.Require().NoError(os.WriteFile(filepath.Join(path, "functional.md"), []byte(T_ReqIFFunctional), 0644))
+	suite.Require().NoError(os.WriteFile(filepath.Join(path, "stakeholders.md"), []byte(T_ReqIFStakeholders), 0644))
+	req := T_Must(New(path))
+	suite.Require().NoError(req.NumberAll())
+	return req
+}
+
+func (suite *ReqIFSuite) TestExport() {
+	path := suite.T().TempDir()
+	req := suite.T_ReqIFRequirements(path)
+
+	document, err := req.ReqIF(Config{Aspects: map[string][]string{Functional: {"Priority", "Worry"}}}, T_ReqIFLastChange)
+	suite.Require().NoError(err)
+
+	// The configured aspects come before others found on the requirements.
+	suite.Contains(document, `<SPEC-OBJECT-TYPE IDENTIFIER="_type.F" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Functional">`)
+	suite.Contains(document, `<ATTRIBUTE-DEFINITION-STRING IDENTIFIER="_type.F.Priority" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Priority">`)
+	suite.Contains(document, `<ATTRIBUTE-DEFINITION-STRING IDENTIFIER="_type.F.Worry" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Worry">`)
+	suite.Contains(document, `<ATTRIBUTE-DEFINITION-STRING IDENTIFIER="_type.F.owner" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="owner">`)
+	suite.Contains(document, `<SPEC-OBJECT-TYPE IDENTIFIER="_type.S" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="Stakeholder">`)
+
+	suite.Contains(document, `<SPEC-OBJECT IDENTIFIER="_F1" LAST-CHANGE="2026-10-18T09:30:00Z">
+          <VALUES>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="F1">
+              <DEFINITION>
+                <ATTRIBUTE-DEFINITION-STRING-REF>_type.F.ReqIF.ForeignID</ATTRIBUTE-DEFINITION-STRING-REF>
+              </DEFINITION>
+            </ATTRIBUTE-VALUE-STRING>
+            <ATTRIBUTE-VALUE-STRING THE-VALUE="Send statements">`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-STRING THE-VALUE="Statements go out monthly, see [S1][].">`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-STRING THE-VALUE="high">
+              <DEFINITION>
+                <ATTRIBUTE-DEFINITION-STRING-REF>_type.F.Priority</ATTRIBUTE-DEFINITION-STRING-REF>`)
+	suite.Contains(document, `<ATTRIBUTE-VALUE-STRING THE-VALUE="Customer &amp; family">`)
+
+	suite.Contains(document, `<SPEC-RELATION IDENTIFIER="_F1.links.S1" LAST-CHANGE="2026-10-18T09:30:00Z">
+          <SOURCE>
+            <SPEC-OBJECT-REF>_F1</SPEC-OBJECT-REF>
+          </SOURCE>
+          <TARGET>
+            <SPEC-OBJECT-REF>_S1</SPEC-OBJECT-REF>
+          </TARGET>`)
+
+	suite.Contains(document, `<SPECIFICATION IDENTIFIER="_file.functional.md" LAST-CHANGE="2026-10-18T09:30:00Z" LONG-NAME="functional.md">
+          <TYPE>
+            <SPECIFICATION-TYPE-REF>_type.specification</SPECIFICATION-TYPE-REF>
+          </TYPE>
+          <CHILDREN>
+            <SPEC-HIERARCHY IDENTIFIER="_hierarchy.F1" LAST-CHANGE="2026-10-18T09:30:00Z">`)
+
+	// The same requirements export the same document.
+	again, err := req.ReqIF(Config{Aspects: map[string][]string{Functional: {"Priority", "Worry"}}}, T_ReqIFLastChange)
+	suite.Require().NoError(err)
+	suite.Equal(document, again)
+}
+
+func (suite *ReqIFSuite) TestExportUnnumbered() {
+	path := suite.T().TempDir()
+	suite.Require().NoError(os.WriteFile(filepath.Join(path, "functional.md"), []byte("# F. Send statements\n"), 0644))
+	req := T_Must(New(path))
+
+	_, err := req.ReqIF(Config{}, T_ReqIFLastChange)
+	suite.ErrorContains(err, "requirement not numbered: 'F. Send statements'")
+}
+
+func (suite *ReqIFSuite) TestImport() {
+	path := suite.T().TempDir()
+	req := suite.T_ReqIFRequirements(path)
+
+	stubs, err := req.importReqIF([]byte(T_ReqIFForeignDocument))
+	suite.Require().NoError(err)
+	suite.Equal(`## R. Statements arrive fast
+
+Imported from ReqIF object o-3 (Non-functional) of "Customer spec". Where does it belong?
+
+Within a day
+of month end.
+
+- Refines → [F1][]
+
+| Aspect          | Value |
+|-----------------|-------|
+| ReqIF.ForeignID | CUS-7 |
+| Priority        | Low   |`, stubs)
+
+	// Its own export has nothing new.
+	document, err := req.ReqIF(Config{}, T_ReqIFLastChange)
+	suite.Require().NoError(err)
+	stubs, err = req.importReqIF([]byte(document))
+	suite.Require().NoError(err)
+	suite.Equal("", stubs)
+
+	_, err = req.importReqIF([]byte("<REQ-IF>"))
+	suite.Error(err)
+}
+
+func (suite *ReqIFSuite) TestImportFile() {
+	path := suite.T().TempDir()
+	req := suite.T_ReqIFRequirements(path)
+	reqIFFilename := filepath.Join(suite.T().TempDir(), "customer.reqif")
+	suite.Require().NoError(os.WriteFile(reqIFFilename, []byte(T_ReqIFForeignDocument), 0644))
+
+	suite.Require().NoError(req.ImportReqIFFile(reqIFFilename))
+	contents := string(T_Must(os.ReadFile(filepath.Join(path, _REQIF_IMPORT_FILE_NAME))))
+	suite.Contains(contents, "# Imported\n\nRequirements imported from ReqIF documents, to move to their places.\n\n## R. Statements arrive fast\n")
+
+	// The stub is numbered like any other requirement, and importing again adds nothing.
+	req = T_Must(New(path))
+	suite.Require().NoError(req.NumberAll())
+	suite.Contains(req.reqRefs, "R1")
+	suite.Require().NoError(req.ImportReqIFFile(reqIFFilename))
+	suite.Equal(contents, string(T_Must(os.ReadFile(filepath.Join(path, _REQIF_IMPORT_FILE_NAME)))))
+}
+
+func (suite *ReqIFSuite) TestStubTitle() {
+	suite.Equal("Statements arrive fast", reqIFStubTitle("– Statements  arrive fast ", "o-3"))
+	suite.Equal("Requirement o-3", reqIFStubTitle("§", "o-3"))
+}